@@ -31,8 +31,11 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/builder"
+	_ "github.com/apache/camel-k/pkg/builder/buildah"
 	_ "github.com/apache/camel-k/pkg/builder/kaniko"
 	_ "github.com/apache/camel-k/pkg/builder/s2i"
+	_ "github.com/apache/camel-k/pkg/builder/spectrum"
+	_ "github.com/apache/camel-k/pkg/builder/tekton"
 	"github.com/apache/camel-k/pkg/client"
 	util "github.com/apache/camel-k/pkg/controller/build"
 	"github.com/apache/camel-k/pkg/util/cancellable"
@@ -48,6 +48,9 @@ func exitOnError(err error) {
 	if err != nil {
 		fmt.Println("Error:", err)
 
+		if e, ok := err.(*cmd.ExitError); ok {
+			os.Exit(e.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -26,9 +26,13 @@ import (
 	"runtime"
 	"time"
 
+	"github.com/apache/camel-k/pkg/admission"
 	"github.com/apache/camel-k/pkg/apis"
 	"github.com/apache/camel-k/pkg/controller"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/status"
 	"github.com/apache/camel-k/pkg/util/defaults"
+	camellog "github.com/apache/camel-k/pkg/util/log"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	"github.com/operator-framework/operator-sdk/pkg/leader"
 	"github.com/operator-framework/operator-sdk/pkg/ready"
@@ -42,6 +46,10 @@ import (
 )
 
 var log = logf.Log.WithName("cmd")
+var statusAddress = flag.String("status-address", "", "The address the status summary endpoint binds to, it is disabled if not set")
+var statusTLSCert = flag.String("status-tls-cert", "", "The certificate file of the status summary endpoint, that is served over plain HTTP if not set")
+var statusTLSKey = flag.String("status-tls-key", "", "The private key file of the status summary endpoint")
+var admissionPort = flag.Int("admission-port", 0, "The port the admission webhooks bind to, they are disabled if not set")
 var GitCommit string
 
 func printVersion() {
@@ -61,7 +69,7 @@ func main() {
 	// implementing the logr.Logger interface. This logger will
 	// be propagated through the whole operator, generating
 	// uniform and structured logs.
-	logf.SetLogger(logf.ZapLogger(false))
+	logf.SetLogger(camellog.ZapLogger())
 
 	printVersion()
 
@@ -114,6 +122,23 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Expose the status summary of the watched namespaces
+	if *statusAddress != "" {
+		server := status.NewServer(*statusAddress, mgr.GetClient(), status.NewAuthorizer(cfg)).WithTLS(*statusTLSCert, *statusTLSKey)
+		if err := mgr.Add(server); err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
+	// Record the users changing the resources
+	if *admissionPort > 0 {
+		if err := admission.AddToManager(mgr, platform.GetOperatorNamespace(), int32(*admissionPort)); err != nil {
+			log.Error(err, "")
+			os.Exit(1)
+		}
+	}
+
 	log.Info("Starting the Cmd.")
 
 	// Start the Cmd
@@ -77,6 +77,16 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - tekton.dev
+  resources:
+  - pipelineruns
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - watch
 
 `
 	Resources["builder-role-openshift.yaml"] =
@@ -159,6 +169,16 @@ rules:
   - builds/clone
   verbs:
   - create
+- apiGroups:
+  - tekton.dev
+  resources:
+  - pipelineruns
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - watch
 
 `
 	Resources["builder-service-account.yaml"] =
@@ -986,6 +1006,8 @@ spec:
     camel-hdfs:
       groupId: org.apache.camel
       artifactId: camel-hdfs
+      deprecated: true
+      replacedBy: camel-hdfs2
       schemes:
       - id: hdfs
         http: false
@@ -1019,6 +1041,8 @@ spec:
     camel-http:
       groupId: org.apache.camel
       artifactId: camel-http
+      deprecated: true
+      replacedBy: camel-http4
       schemes:
       - id: http
         http: false
@@ -1039,6 +1063,8 @@ spec:
     camel-ibatis:
       groupId: org.apache.camel
       artifactId: camel-ibatis
+      deprecated: true
+      replacedBy: camel-mybatis
       schemes:
       - id: ibatis
         http: false
@@ -1498,6 +1524,8 @@ spec:
     camel-mina:
       groupId: org.apache.camel
       artifactId: camel-mina
+      deprecated: true
+      replacedBy: camel-mina2
       schemes:
       - id: mina
         http: false
@@ -1594,6 +1622,8 @@ spec:
     camel-netty:
       groupId: org.apache.camel
       artifactId: camel-netty
+      deprecated: true
+      replacedBy: camel-netty4
       schemes:
       - id: netty
         http: false
@@ -1601,6 +1631,8 @@ spec:
     camel-netty-http:
       groupId: org.apache.camel
       artifactId: camel-netty-http
+      deprecated: true
+      replacedBy: camel-netty4-http
       schemes:
       - id: netty-http
         http: true
@@ -1731,6 +1763,8 @@ spec:
     camel-quartz:
       groupId: org.apache.camel
       artifactId: camel-quartz
+      deprecated: true
+      replacedBy: camel-quartz2
       schemes:
       - id: quartz
         http: false
@@ -3068,6 +3102,8 @@ spec:
     camel-hdfs:
       groupId: org.apache.camel
       artifactId: camel-hdfs
+      deprecated: true
+      replacedBy: camel-hdfs2
       schemes:
       - id: hdfs
         http: false
@@ -3101,6 +3137,8 @@ spec:
     camel-http:
       groupId: org.apache.camel
       artifactId: camel-http
+      deprecated: true
+      replacedBy: camel-http4
       schemes:
       - id: http
         http: false
@@ -3121,6 +3159,8 @@ spec:
     camel-ibatis:
       groupId: org.apache.camel
       artifactId: camel-ibatis
+      deprecated: true
+      replacedBy: camel-mybatis
       schemes:
       - id: ibatis
         http: false
@@ -3580,6 +3620,8 @@ spec:
     camel-mina:
       groupId: org.apache.camel
       artifactId: camel-mina
+      deprecated: true
+      replacedBy: camel-mina2
       schemes:
       - id: mina
         http: false
@@ -3676,6 +3718,8 @@ spec:
     camel-netty:
       groupId: org.apache.camel
       artifactId: camel-netty
+      deprecated: true
+      replacedBy: camel-netty4
       schemes:
       - id: netty
         http: false
@@ -3683,6 +3727,8 @@ spec:
     camel-netty-http:
       groupId: org.apache.camel
       artifactId: camel-netty-http
+      deprecated: true
+      replacedBy: camel-netty4-http
       schemes:
       - id: netty-http
         http: true
@@ -3813,6 +3859,8 @@ spec:
     camel-quartz:
       groupId: org.apache.camel
       artifactId: camel-quartz
+      deprecated: true
+      replacedBy: camel-quartz2
       schemes:
       - id: quartz
         http: false
@@ -5150,6 +5198,8 @@ spec:
     camel-hdfs:
       groupId: org.apache.camel
       artifactId: camel-hdfs
+      deprecated: true
+      replacedBy: camel-hdfs2
       schemes:
       - id: hdfs
         http: false
@@ -5183,6 +5233,8 @@ spec:
     camel-http:
       groupId: org.apache.camel
       artifactId: camel-http
+      deprecated: true
+      replacedBy: camel-http4
       schemes:
       - id: http
         http: false
@@ -5203,6 +5255,8 @@ spec:
     camel-ibatis:
       groupId: org.apache.camel
       artifactId: camel-ibatis
+      deprecated: true
+      replacedBy: camel-mybatis
       schemes:
       - id: ibatis
         http: false
@@ -5662,6 +5716,8 @@ spec:
     camel-mina:
       groupId: org.apache.camel
       artifactId: camel-mina
+      deprecated: true
+      replacedBy: camel-mina2
       schemes:
       - id: mina
         http: false
@@ -5758,6 +5814,8 @@ spec:
     camel-netty:
       groupId: org.apache.camel
       artifactId: camel-netty
+      deprecated: true
+      replacedBy: camel-netty4
       schemes:
       - id: netty
         http: false
@@ -5765,6 +5823,8 @@ spec:
     camel-netty-http:
       groupId: org.apache.camel
       artifactId: camel-netty-http
+      deprecated: true
+      replacedBy: camel-netty4-http
       schemes:
       - id: netty-http
         http: true
@@ -5895,6 +5955,8 @@ spec:
     camel-quartz:
       groupId: org.apache.camel
       artifactId: camel-quartz
+      deprecated: true
+      replacedBy: camel-quartz2
       schemes:
       - id: quartz
         http: false
@@ -7242,6 +7304,8 @@ spec:
     camel-hdfs:
       groupId: org.apache.camel
       artifactId: camel-hdfs
+      deprecated: true
+      replacedBy: camel-hdfs2
       schemes:
       - id: hdfs
         http: false
@@ -7275,6 +7339,8 @@ spec:
     camel-http:
       groupId: org.apache.camel
       artifactId: camel-http
+      deprecated: true
+      replacedBy: camel-http4
       schemes:
       - id: http
         http: false
@@ -7295,6 +7361,8 @@ spec:
     camel-ibatis:
       groupId: org.apache.camel
       artifactId: camel-ibatis
+      deprecated: true
+      replacedBy: camel-mybatis
       schemes:
       - id: ibatis
         http: false
@@ -7768,6 +7836,8 @@ spec:
     camel-mina:
       groupId: org.apache.camel
       artifactId: camel-mina
+      deprecated: true
+      replacedBy: camel-mina2
       schemes:
       - id: mina
         http: false
@@ -7864,6 +7934,8 @@ spec:
     camel-netty:
       groupId: org.apache.camel
       artifactId: camel-netty
+      deprecated: true
+      replacedBy: camel-netty4
       schemes:
       - id: netty
         http: false
@@ -7871,6 +7943,8 @@ spec:
     camel-netty-http:
       groupId: org.apache.camel
       artifactId: camel-netty-http
+      deprecated: true
+      replacedBy: camel-netty4-http
       schemes:
       - id: netty-http
         http: true
@@ -8008,6 +8082,8 @@ spec:
     camel-quartz:
       groupId: org.apache.camel
       artifactId: camel-quartz
+      deprecated: true
+      replacedBy: camel-quartz2
       schemes:
       - id: quartz
         http: false
@@ -10672,6 +10748,10 @@ spec:
       type: string
       description: The build phase
       JSONPath: .status.phase
+    - name: Priority
+      type: string
+      description: The build priority
+      JSONPath: .spec.priority
     - name: Age
       type: date
       description: The time at which the build was last (re-)started
@@ -10881,6 +10961,157 @@ spec:
       description: The IntegrationKit to use
       JSONPath: .status.kit
 
+`
+	Resources["crd-kamelet-binding.yaml"] =
+		`
+# ---------------------------------------------------------------------------
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+# ---------------------------------------------------------------------------
+
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: kameletbindings.camel.apache.org
+  labels:
+    app: "camel-k"
+spec:
+  group: camel.apache.org
+  scope: Namespaced
+  version: v1alpha1
+  names:
+    kind: KameletBinding
+    listKind: KameletBindingList
+    plural: kameletbindings
+    singular: kameletbinding
+    shortNames:
+    - klb
+  subresources:
+    status: {}
+  additionalPrinterColumns:
+    - name: Phase
+      type: string
+      description: The kamelet binding phase
+      JSONPath: .status.phase
+
+`
+	Resources["crd-kamelet.yaml"] =
+		`
+# ---------------------------------------------------------------------------
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+# ---------------------------------------------------------------------------
+
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: kamelets.camel.apache.org
+  labels:
+    app: "camel-k"
+spec:
+  group: camel.apache.org
+  scope: Namespaced
+  version: v1alpha1
+  names:
+    kind: Kamelet
+    listKind: KameletList
+    plural: kamelets
+    singular: kamelet
+  subresources:
+    status: {}
+  additionalPrinterColumns:
+    - name: Phase
+      type: string
+      description: The kamelet phase
+      JSONPath: .status.phase
+    - name: Title
+      type: string
+      description: The title of the kamelet
+      JSONPath: .spec.definition.title
+
+`
+	Resources["crd-replay.yaml"] =
+		`
+# ---------------------------------------------------------------------------
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+# ---------------------------------------------------------------------------
+
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: replays.camel.apache.org
+  labels:
+    app: "camel-k"
+spec:
+  group: camel.apache.org
+  scope: Namespaced
+  version: v1alpha1
+  names:
+    kind: Replay
+    listKind: ReplayList
+    plural: replays
+    singular: replay
+  subresources:
+    status: {}
+  additionalPrinterColumns:
+    - name: Integration
+      type: string
+      description: The integration the messages are replayed into
+      JSONPath: .spec.integration
+    - name: Phase
+      type: string
+      description: The replay phase
+      JSONPath: .status.phase
+    - name: Sent
+      type: integer
+      description: The number of messages sent
+      JSONPath: .status.sent
+    - name: Failed
+      type: integer
+      description: The number of messages that could not be sent
+      JSONPath: .status.failed
+    - name: Total
+      type: integer
+      description: The number of messages to send
+      JSONPath: .status.total
+
 `
 	Resources["cr-example.yaml"] =
 		`
@@ -10921,6 +11152,169 @@ spec:
           }
           .to('log:info?showHeaders=true')
     name: routes.groovy
+`
+	Resources["operator-cluster-role-admission.yaml"] =
+		`
+# ---------------------------------------------------------------------------
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+# ---------------------------------------------------------------------------
+
+# Allows the operators to install their admission webhooks (kamel install --admission-webhook)
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: camel-k:admission
+  labels:
+    app: "camel-k"
+rules:
+- apiGroups:
+  - admissionregistration.k8s.io
+  resources:
+  - mutatingwebhookconfigurations
+  - validatingwebhookconfigurations
+  verbs:
+  - create
+  - get
+  - list
+  - update
+  - watch
+
+`
+	Resources["operator-cluster-role-dependencies-strimzi.yaml"] =
+		`
+# ---------------------------------------------------------------------------
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+# ---------------------------------------------------------------------------
+
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: camel-k:dependencies-strimzi
+  labels:
+    app: "camel-k"
+    camel.apache.org/aggregate-to-dependencies: "true"
+rules:
+- apiGroups:
+  - kafka.strimzi.io
+  resources:
+  - kafkas
+  - kafkatopics
+  - kafkausers
+  verbs:
+  - get
+
+`
+	Resources["operator-cluster-role-dependencies.yaml"] =
+		`
+# ---------------------------------------------------------------------------
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+# ---------------------------------------------------------------------------
+
+# Grants the operator read access to the resources integrations depend on (spec.dependsOn), the rules
+# being aggregated from the cluster roles labelled with camel.apache.org/aggregate-to-dependencies
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: camel-k:dependencies
+  labels:
+    app: "camel-k"
+aggregationRule:
+  clusterRoleSelectors:
+  - matchLabels:
+      camel.apache.org/aggregate-to-dependencies: "true"
+rules: []
+
+`
+	Resources["operator-config.yaml"] =
+		`
+# ---------------------------------------------------------------------------
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+# ---------------------------------------------------------------------------
+
+#
+# The operator tunables, that are reloaded without restarting the operator when changed.
+# The commented out keys are optional.
+#
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: camel-k-operator-config
+  labels:
+    app: "camel-k"
+    camel.apache.org/component: operator
+data:
+  # runtime-version: the runtime version used by default by new platforms
+  # the maximum number of builds running at the same time in a namespace
+  max-running-builds: "1"
+  # max-running-builds-total: the maximum number of builds running at the same time in all the namespaces
+  # watched by a global operator (unlimited if not set)
+  # the operator log level (debug, info, warn, error)
+  log-level: "info"
+  # the interval used to re-check resources waiting for something to happen
+  requeue-interval: "5s"
+  # integration-monitor-interval: the interval running integrations are monitored at (defaults to requeue-interval)
+  # build-poll-interval: the interval the status of builds is polled at (defaults to requeue-interval)
+  # platform-monitor-interval: the interval ready platforms are monitored at (defaults to requeue-interval)
+  # the minimum interval between two collections of the unused platform kits
+  kit-gc-interval: "1m"
+  # how the operator reacts to out-of-band changes to the resources generated for integrations
+  # (none, recreate, revert)
+  drift-enforcement: "recreate"
+  # where the trait environments that fail to be applied are recorded (none, configmap, file)
+  trait-snapshots: "none"
+
 `
 	Resources["operator-deployment.yaml"] =
 		`
@@ -10985,6 +11379,40 @@ spec:
                 fieldRef:
                   fieldPath: metadata.namespace
 
+`
+	Resources["operator-role-binding-dependencies.yaml"] =
+		`
+# ---------------------------------------------------------------------------
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+#
+#      http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+# ---------------------------------------------------------------------------
+
+kind: RoleBinding
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: camel-k-operator-dependencies
+  labels:
+    app: "camel-k"
+subjects:
+- kind: ServiceAccount
+  name: camel-k-operator
+roleRef:
+  kind: ClusterRole
+  name: camel-k:dependencies
+  apiGroup: rbac.authorization.k8s.io
+
 `
 	Resources["operator-role-binding-knative.yaml"] =
 		`
@@ -11218,6 +11646,29 @@ rules:
   - patch
   - update
   - watch
+- apiGroups:
+  - tekton.dev
+  resources:
+  - pipelineruns
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - watch
+- apiGroups:
+  - argoproj.io
+  resources:
+  - rollouts
+  verbs:
+  - create
+  - delete
+  - deletecollection
+  - get
+  - list
+  - patch
+  - update
+  - watch
 
 `
 	Resources["operator-role-olm.yaml"] =
@@ -11569,6 +12020,29 @@ rules:
   verbs:
   - create
 
+- apiGroups:
+  - tekton.dev
+  resources:
+  - pipelineruns
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - watch
+- apiGroups:
+  - argoproj.io
+  resources:
+  - rollouts
+  verbs:
+  - create
+  - delete
+  - deletecollection
+  - get
+  - list
+  - patch
+  - update
+  - watch
 
 `
 	Resources["operator-service-account.yaml"] =
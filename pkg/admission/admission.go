@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"os"
+	"path"
+
+	admissionregistrationv1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/builder"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+)
+
+// ServiceName is the name of the service fronting the admission webhooks of the operator
+const ServiceName = "camel-k-admission"
+
+// AddToManager registers the admission webhooks of the operator running in the given namespace. The webhook
+// configuration, the service and the serving certificate are installed by the operator when it starts.
+func AddToManager(mgr manager.Manager, namespace string, port int32) error {
+	server, err := webhook.NewServer("camel-k-admission-server", mgr, webhook.ServerOptions{
+		Port:    port,
+		CertDir: path.Join(os.TempDir(), "camel-k-admission", "cert"),
+		BootstrapOptions: &webhook.BootstrapOptions{
+			MutatingWebhookConfigName:   "camel-k-" + namespace,
+			ValidatingWebhookConfigName: "camel-k-" + namespace,
+			Service: &webhook.Service{
+				Namespace: namespace,
+				Name:      ServiceName,
+				Selectors: map[string]string{
+					"name": "camel-k-operator",
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	requester, err := builder.NewWebhookBuilder().
+		Name("requester.camel.apache.org").
+		Path("/requester").
+		Mutating().
+		Rules(admissionregistrationv1beta1.RuleWithOperations{
+			Operations: []admissionregistrationv1beta1.OperationType{
+				admissionregistrationv1beta1.Create,
+				admissionregistrationv1beta1.Update,
+			},
+			Rule: admissionregistrationv1beta1.Rule{
+				APIGroups:   []string{v1alpha1.SchemeGroupVersion.Group},
+				APIVersions: []string{v1alpha1.SchemeGroupVersion.Version},
+				Resources:   []string{"integrations", "integrationkits"},
+			},
+		}).
+		// the resources can still be changed while the operator is unavailable, without requester
+		FailurePolicy(admissionregistrationv1beta1.Ignore).
+		WithManager(mgr).
+		Handlers(&requesterAnnotator{operatorNamespace: namespace}).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	c, err := client.FromManager(mgr)
+	if err != nil {
+		return err
+	}
+
+	policy, err := builder.NewWebhookBuilder().
+		Name("policy.camel.apache.org").
+		Path("/policy").
+		Validating().
+		Rules(admissionregistrationv1beta1.RuleWithOperations{
+			Operations: []admissionregistrationv1beta1.OperationType{
+				admissionregistrationv1beta1.Create,
+				admissionregistrationv1beta1.Update,
+			},
+			Rule: admissionregistrationv1beta1.Rule{
+				APIGroups:   []string{v1alpha1.SchemeGroupVersion.Group},
+				APIVersions: []string{v1alpha1.SchemeGroupVersion.Version},
+				Resources:   []string{"integrations"},
+			},
+		}).
+		// the integrations not following the policy are still held back at initialization
+		FailurePolicy(admissionregistrationv1beta1.Ignore).
+		WithManager(mgr).
+		Handlers(&policyValidator{client: c, operatorNamespace: namespace}).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	return server.Register(requester, policy)
+}
@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+)
+
+// policyValidator rejects the integrations that do not follow the naming and labeling policy of the platform
+// of their namespace, so that users are told at once instead of finding the integration held back at initialization
+type policyValidator struct {
+	client            client.Client
+	operatorNamespace string
+}
+
+func (v *policyValidator) Handle(ctx context.Context, req types.Request) types.Response {
+	if isOperator(req.AdmissionRequest.UserInfo.Username, v.operatorNamespace) {
+		return admission.ValidationResponse(true, "")
+	}
+
+	integration := v1alpha1.Integration{}
+	if err := json.Unmarshal(req.AdmissionRequest.Object.Raw, &integration); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	namespace := integration.Namespace
+	if namespace == "" {
+		namespace = req.AdmissionRequest.Namespace
+	}
+
+	pl, err := platform.GetCurrentPlatform(ctx, v.client, namespace)
+	if err != nil {
+		// the policy is checked again when the integration is initialized, once the platform is available
+		return admission.ValidationResponse(true, "")
+	}
+
+	name := integration.Name
+	if name == "" {
+		name = req.AdmissionRequest.Name
+	}
+	violations, err := platform.PolicyViolations(pl.Spec.Policy, name, integration.Labels)
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	if len(violations) > 0 {
+		return admission.ValidationResponse(false, "integration does not follow the platform policy: "+strings.Join(violations, "; "))
+	}
+
+	return admission.ValidationResponse(true, "")
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func newPolicyTestRequest(t *testing.T, user string, name string, labels map[string]string) types.Request {
+	integration := v1alpha1.NewIntegration("ns", name)
+	integration.Labels = labels
+	raw, err := json.Marshal(&integration)
+	assert.Nil(t, err)
+
+	return types.Request{
+		AdmissionRequest: &admissionv1beta1.AdmissionRequest{
+			Operation: admissionv1beta1.Create,
+			Namespace: "ns",
+			UserInfo:  authenticationv1.UserInfo{Username: user},
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func TestPolicyValidator(t *testing.T) {
+	platform := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	platform.Status.Phase = v1alpha1.IntegrationPlatformPhaseReady
+	platform.Spec.Policy = v1alpha1.IntegrationPlatformPolicySpec{
+		NamePattern:    "^team-",
+		RequiredLabels: []string{"team"},
+	}
+
+	c, err := test.NewFakeClient(&platform)
+	assert.Nil(t, err)
+
+	validator := policyValidator{client: c, operatorNamespace: "camel-k"}
+
+	res := validator.Handle(context.TODO(), newPolicyTestRequest(t, "developer", "team-route", map[string]string{"team": "a"}))
+	assert.True(t, res.Response.Allowed)
+
+	res = validator.Handle(context.TODO(), newPolicyTestRequest(t, "developer", "my-route", nil))
+	assert.False(t, res.Response.Allowed)
+	assert.Contains(t, res.Response.Result.Reason, `name "my-route" does not match the pattern "^team-"`)
+	assert.Contains(t, res.Response.Result.Reason, "missing required labels team")
+
+	// the operator is not subject to the policy
+	res = validator.Handle(context.TODO(), newPolicyTestRequest(t, "system:serviceaccount:camel-k:camel-k-operator", "my-route", nil))
+	assert.True(t, res.Response.Allowed)
+
+	// an account of the same name in another namespace cannot bypass the policy
+	res = validator.Handle(context.TODO(), newPolicyTestRequest(t, "system:serviceaccount:ns:camel-k-operator", "my-route", nil))
+	assert.False(t, res.Response.Allowed)
+}
+
+func TestPolicyValidatorWithoutPlatform(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	validator := policyValidator{client: c}
+
+	res := validator.Handle(context.TODO(), newPolicyTestRequest(t, "developer", "my-route", nil))
+	assert.True(t, res.Response.Allowed)
+}
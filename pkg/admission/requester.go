@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+
+	"github.com/apache/camel-k/pkg/util/audit"
+)
+
+// operatorServiceAccount is the name of the service account of the operator, whose changes keep the requester
+// it copies from the resources they originate from, e.g. the kits created for integrations
+const operatorServiceAccount = "camel-k-operator"
+
+// requesterAnnotator records the user authenticated by the API server as the requester of the created and changed
+// resources, replacing the requester possibly set by the client
+type requesterAnnotator struct {
+	operatorNamespace string
+}
+
+func (a *requesterAnnotator) Handle(_ context.Context, req types.Request) types.Response {
+	user := req.AdmissionRequest.UserInfo.Username
+	if isOperator(user, a.operatorNamespace) {
+		return admission.ValidationResponse(true, "")
+	}
+
+	original := unstructured.Unstructured{}
+	if err := json.Unmarshal(req.AdmissionRequest.Object.Raw, &original.Object); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+
+	annotated := original.DeepCopy()
+	annotations := annotated.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[audit.AnnotationRequestedBy] = user
+	annotated.SetAnnotations(annotations)
+
+	return admission.PatchResponse(&original, annotated)
+}
+
+// isOperator returns true if the given user is the service account of the operator running in the given namespace,
+// the accounts of the same name in other namespaces being possibly created by anyone, e.g. kamel install --ci-serviceaccount
+func isOperator(user string, operatorNamespace string) bool {
+	return user == "system:serviceaccount:"+operatorNamespace+":"+operatorServiceAccount
+}
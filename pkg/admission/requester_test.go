@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission/types"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/audit"
+)
+
+func newRequesterTestRequest(t *testing.T, user string, annotations map[string]string) types.Request {
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	integration.Annotations = annotations
+
+	raw, err := json.Marshal(&integration)
+	assert.Nil(t, err)
+
+	return types.Request{
+		AdmissionRequest: &admissionv1beta1.AdmissionRequest{
+			Operation: admissionv1beta1.Create,
+			UserInfo:  authenticationv1.UserInfo{Username: user},
+			Object:    runtime.RawExtension{Raw: raw},
+		},
+	}
+}
+
+func TestRequesterAnnotator(t *testing.T) {
+	annotator := requesterAnnotator{operatorNamespace: "camel-k"}
+
+	res := annotator.Handle(context.TODO(), newRequesterTestRequest(t, "developer", nil))
+	assert.True(t, res.Response.Allowed)
+	assert.Len(t, res.Patches, 1)
+	assert.Equal(t, "add", res.Patches[0].Operation)
+	assert.Equal(t, "/metadata/annotations", res.Patches[0].Path)
+	assert.Equal(t, map[string]interface{}{audit.AnnotationRequestedBy: "developer"}, res.Patches[0].Value)
+
+	// the requester set by the client is replaced
+	res = annotator.Handle(context.TODO(), newRequesterTestRequest(t, "developer", map[string]string{
+		audit.AnnotationRequestedBy: "admin",
+	}))
+	assert.True(t, res.Response.Allowed)
+	assert.Len(t, res.Patches, 1)
+	assert.Equal(t, "replace", res.Patches[0].Operation)
+	assert.Equal(t, "developer", res.Patches[0].Value)
+
+	// the operator keeps the requester of the resources it originates from
+	res = annotator.Handle(context.TODO(), newRequesterTestRequest(t, "system:serviceaccount:camel-k:camel-k-operator", map[string]string{
+		audit.AnnotationRequestedBy: "developer",
+	}))
+	assert.True(t, res.Response.Allowed)
+	assert.Empty(t, res.Patches)
+
+	// an account of the same name in another namespace is not the operator
+	res = annotator.Handle(context.TODO(), newRequesterTestRequest(t, "system:serviceaccount:ns:camel-k-operator", map[string]string{
+		audit.AnnotationRequestedBy: "developer",
+	}))
+	assert.True(t, res.Response.Allowed)
+	assert.Len(t, res.Patches, 1)
+	assert.Equal(t, "system:serviceaccount:ns:camel-k-operator", res.Patches[0].Value)
+}
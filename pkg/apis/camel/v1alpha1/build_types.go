@@ -38,20 +38,39 @@ type BuildSpec struct {
 	Resources      []ResourceSpec          `json:"resources,omitempty"`
 	Dependencies   []string                `json:"dependencies,omitempty"`
 	BuildDir       string                  `json:"buildDir,omitempty"`
+	Priority       BuildPriority           `json:"priority,omitempty"`
+	Incremental    *bool                   `json:"incremental,omitempty"`
+	BaseKit        *BuildBaseKit           `json:"baseKit,omitempty"`
+}
+
+// BuildBaseKit is the existing kit whose image the build layers the missing artifacts on top of
+type BuildBaseKit struct {
+	Name         string     `json:"name,omitempty"`
+	Image        string     `json:"image,omitempty"`
+	Dependencies []string   `json:"dependencies,omitempty"`
+	Artifacts    []Artifact `json:"artifacts,omitempty"`
 }
 
 // BuildStatus defines the observed state of Build
 type BuildStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
-	Phase       BuildPhase  `json:"phase,omitempty"`
-	Image       string      `json:"image,omitempty"`
-	BaseImage   string      `json:"baseImage,omitempty"`
-	PublicImage string      `json:"publicImage,omitempty"`
-	Artifacts   []Artifact  `json:"artifacts,omitempty"`
-	Error       string      `json:"error,omitempty"`
-	Failure     *Failure    `json:"failure,omitempty"`
-	StartedAt   metav1.Time `json:"startedAt,omitempty"`
+	Phase           BuildPhase     `json:"phase,omitempty"`
+	Image           string         `json:"image,omitempty"`
+	BaseImage       string         `json:"baseImage,omitempty"`
+	PublicImage     string         `json:"publicImage,omitempty"`
+	ImageStreamTag  string         `json:"imageStreamTag,omitempty"`
+	ImageSize       int64          `json:"imageSize,omitempty"`
+	Artifacts       []Artifact     `json:"artifacts,omitempty"`
+	Error           string         `json:"error,omitempty"`
+	Reason          string         `json:"reason,omitempty"`
+	Warnings        []string       `json:"warnings,omitempty"`
+	Vulnerabilities map[string]int `json:"vulnerabilities,omitempty"`
+	Failure         *Failure       `json:"failure,omitempty"`
+	StartedAt       metav1.Time    `json:"startedAt,omitempty"`
+	Conditions      []Condition    `json:"conditions,omitempty"`
+	// ImageLabels holds the provenance labels set on the published image
+	ImageLabels map[string]string `json:"imageLabels,omitempty"`
 	// Change to Duration / ISO 8601 when CRD uses OpenAPI spec v3
 	// https://github.com/OAI/OpenAPI-Specification/issues/845
 	Duration string `json:"duration,omitempty"`
@@ -60,10 +79,41 @@ type BuildStatus struct {
 // BuildPhase --
 type BuildPhase string
 
+// BuildPriority defines the order in which builds waiting to be scheduled are run
+type BuildPriority string
+
+const (
+	// BuildPriorityAnnotation carries the priority of the builds from integrations to kits
+	BuildPriorityAnnotation = "camel.apache.org/build.priority"
+	// BuildIncrementalAnnotation can be set to "false" on an integration or a kit to build the kit image from
+	// the platform base image, rather than from the image of the closest existing kit
+	BuildIncrementalAnnotation = "camel.apache.org/build.incremental"
+
+	// BuildPriorityInteractive is used for builds a developer is waiting for (e.g. kamel run --dev)
+	BuildPriorityInteractive BuildPriority = "interactive"
+	// BuildPriorityNormal --
+	BuildPriorityNormal BuildPriority = "normal"
+	// BuildPriorityBatch is used for rebuilds of unchanged integrations (e.g. after the kits have been reset)
+	BuildPriorityBatch BuildPriority = "batch"
+)
+
 const (
 	// BuildKind --
 	BuildKind string = "Build"
 
+	// BuildReasonDependencyResolution --
+	BuildReasonDependencyResolution = "DependencyResolution"
+	// BuildReasonCompilation --
+	BuildReasonCompilation = "Compilation"
+	// BuildReasonTimeout --
+	BuildReasonTimeout = "Timeout"
+	// BuildReasonClasspathConflict --
+	BuildReasonClasspathConflict = "ClasspathConflict"
+	// BuildReasonArtifactPolicy --
+	BuildReasonArtifactPolicy = "ArtifactPolicy"
+	// BuildReasonVulnerabilities --
+	BuildReasonVulnerabilities = "Vulnerabilities"
+
 	// BuildPhaseInitial --
 	BuildPhaseInitial BuildPhase = ""
 	// BuildPhaseScheduling --
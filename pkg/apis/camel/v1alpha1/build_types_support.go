@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Rank returns the rank of the priority, builds with higher ranks being scheduled first
+func (p BuildPriority) Rank() int {
+	switch p {
+	case BuildPriorityInteractive:
+		return 2
+	case BuildPriorityBatch:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// IsIncremental returns true if the build can reuse the image of an existing kit as base image, which is the default
+func (spec *BuildSpec) IsIncremental() bool {
+	return spec.Incremental == nil || *spec.Incremental
+}
+
+// BuildName returns the name of the build of the kit with the given namespace and name. The kit namespace
+// is prepended when the build runs in another namespace, that may be shared by the builds of many namespaces,
+// along with a hash of the kit namespace and name, as names like a-b/c and a/b-c would otherwise collide
+func BuildName(kitNamespace string, kitName string, buildNamespace string) string {
+	if buildNamespace == kitNamespace {
+		return kitName
+	}
+
+	hash := sha256.Sum256([]byte(kitNamespace + "/" + kitName))
+	return kitNamespace + "-" + kitName + "-" + hex.EncodeToString(hash[:])[:8]
+}
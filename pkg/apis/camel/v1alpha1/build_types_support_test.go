@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildName(t *testing.T) {
+	build := IntegrationPlatformBuildSpec{}
+	assert.Equal(t, "ns", build.BuildNamespace("ns"))
+	assert.Equal(t, "kit-1", BuildName("ns", "kit-1", build.BuildNamespace("ns")))
+
+	build.Namespace = "builds"
+	assert.Equal(t, "builds", build.BuildNamespace("ns"))
+	assert.Regexp(t, "^ns-kit-1-[0-9a-f]{8}$", BuildName("ns", "kit-1", build.BuildNamespace("ns")))
+	assert.NotEqual(t, BuildName("a-b", "c", "builds"), BuildName("a", "b-c", "builds"))
+}
+
+func TestBuildIsIncremental(t *testing.T) {
+	spec := BuildSpec{}
+	assert.True(t, spec.IsIncremental())
+
+	incremental := false
+	spec.Incremental = &incremental
+	assert.False(t, spec.IsIncremental())
+}
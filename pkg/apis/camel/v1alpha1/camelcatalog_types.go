@@ -26,6 +26,18 @@ type CamelScheme struct {
 	ID      string `json:"id" yaml:"id"`
 	Passive bool   `json:"passive" yaml:"passive"`
 	HTTP    bool   `json:"http" yaml:"http"`
+	// Parameters lists the query parameters of the endpoint URIs, the URIs of the schemes
+	// without parameters metadata are not validated
+	Parameters []CamelSchemeParameter `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+}
+
+// CamelSchemeParameter describes a query parameter of the endpoint URIs of a scheme
+type CamelSchemeParameter struct {
+	Name string `json:"name" yaml:"name"`
+	// Prefix is set for the multi value parameters, whose names start with the prefix, e.g. scheduler.
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+	// Enum lists the values allowed for the parameter, if restricted
+	Enum []string `json:"enum,omitempty" yaml:"enum,omitempty"`
 }
 
 // CamelArtifactExclusion --
@@ -49,6 +61,8 @@ type CamelArtifact struct {
 	Languages               []string        `json:"languages,omitempty" yaml:"languages,omitempty"`
 	DataFormats             []string        `json:"dataformats,omitempty" yaml:"dataformats,omitempty"`
 	Dependencies            []CamelArtifact `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Deprecated              bool            `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	ReplacedBy              string          `json:"replacedBy,omitempty" yaml:"replacedBy,omitempty"`
 }
 
 // CamelCatalogSpec defines the desired state of CamelCatalog
@@ -33,6 +33,7 @@ type Artifact struct {
 	ID       string `json:"id" yaml:"id"`
 	Location string `json:"location,omitempty" yaml:"location,omitempty"`
 	Target   string `json:"target,omitempty" yaml:"target,omitempty"`
+	Checksum string `json:"checksum,omitempty" yaml:"checksum,omitempty"`
 }
 
 // Flow --
@@ -63,6 +64,79 @@ type FailureRecovery struct {
 	AttemptTime metav1.Time `json:"attemptTime"`
 }
 
+// ConditionType --
+type ConditionType string
+
+const (
+	// ConditionQuotaExceeded is set when the resource is held back because the
+	// quota defined on the integration platform has been reached
+	ConditionQuotaExceeded ConditionType = "QuotaExceeded"
+	// ConditionDeprecatedComponents is set when the integration uses components that are
+	// deprecated in the camel catalog it is built with
+	ConditionDeprecatedComponents ConditionType = "DeprecatedComponents"
+	// ConditionInvalidEndpointParameters is set when the endpoint URIs of the integration have
+	// query parameters that are unknown to, or have values not allowed by, the camel catalog
+	ConditionInvalidEndpointParameters ConditionType = "InvalidEndpointParameters"
+	// ConditionUnresolvedPlaceholders is set when the property placeholders of the endpoint URIs of
+	// the integration cannot be resolved from its configuration
+	ConditionUnresolvedPlaceholders ConditionType = "UnresolvedPlaceholders"
+	// ConditionKnativeUnavailable is set when the integration uses Knative channels or
+	// endpoints but Knative is not installed on the cluster
+	ConditionKnativeUnavailable ConditionType = "KnativeUnavailable"
+	// ConditionResourcesDrifted is set when resources generated for the integration have been
+	// deleted or modified out-of-band and the operator has not restored them
+	ConditionResourcesDrifted ConditionType = "ResourcesDrifted"
+	// ConditionReconcilePaused is set when the reconciliation of the resource has been paused
+	// through the ReconcileAnnotation
+	ConditionReconcilePaused ConditionType = "ReconcilePaused"
+	// ConditionWaitingForDependencies is set when the deployment of the integration is held back
+	// until the objects listed in spec.dependsOn are ready
+	ConditionWaitingForDependencies ConditionType = "WaitingForDependencies"
+	// ConditionWaitingForApproval is set when the rollout of a rebuilt integration image
+	// is held back until it gets approved
+	ConditionWaitingForApproval ConditionType = "WaitingForApproval"
+	// ConditionInitContainersFailed is set when init containers of the integration pods
+	// have failed, holding back the start of the Camel runtime
+	ConditionInitContainersFailed ConditionType = "InitContainersFailed"
+	// ConditionPolicyViolated is set when the integration is held back because it does not follow
+	// the naming and labeling policy defined on the integration platform
+	ConditionPolicyViolated ConditionType = "PolicyViolated"
+	// ConditionHardcodedCredentials is set when the inline sources of the integration likely contain
+	// hard-coded credentials, instead of property placeholders resolved from secrets
+	ConditionHardcodedCredentials ConditionType = "HardcodedCredentials"
+	// ConditionPipelineRunSucceeded mirrors on the build the status of the Tekton pipeline run
+	// the image build has been delegated to
+	ConditionPipelineRunSucceeded ConditionType = "PipelineRunSucceeded"
+	// ConditionWaitingForSharedKit is set when the integration waits for the kit built
+	// in the shared kit namespace of the integration platform
+	ConditionWaitingForSharedKit ConditionType = "WaitingForSharedKit"
+	// ConditionDeploymentFailed is set when the resources generated for the integration cannot run, e.g.
+	// because the image cannot be pulled, the container is crash looping or the Knative revision failed
+	ConditionDeploymentFailed ConditionType = "DeploymentFailed"
+	// ConditionVulnerabilities is set on the kits whose image contains vulnerabilities at or above
+	// the severity configured on the integration platform, and on the integrations using them
+	ConditionVulnerabilities ConditionType = "Vulnerabilities"
+	// ConditionKitIncompatible is set when the kit the integration is pinned to cannot be found,
+	// or lacks some of the dependencies required by the integration
+	ConditionKitIncompatible ConditionType = "KitIncompatible"
+)
+
+const (
+	// ReconcileAnnotation controls the reconciliation of integrations and kits
+	ReconcileAnnotation = "camel.apache.org/reconcile"
+	// ReconcilePaused is the ReconcileAnnotation value making the operator skip the reconciliation
+	ReconcilePaused = "paused"
+)
+
+// Condition describes the state of a resource at a certain point
+type Condition struct {
+	Type               ConditionType          `json:"type"`
+	Status             corev1.ConditionStatus `json:"status"`
+	LastTransitionTime metav1.Time            `json:"lastTransitionTime,omitempty"`
+	Reason             string                 `json:"reason,omitempty"`
+	Message            string                 `json:"message,omitempty"`
+}
+
 // A TraitSpec contains the configuration of a trait
 type TraitSpec struct {
 	Configuration map[string]string `json:"configuration,omitempty"`
@@ -73,9 +147,23 @@ type Configurable interface {
 	Configurations() []ConfigurationSpec
 }
 
+// MaskingSpec defines the rules masking the sensitive data out of the integration logs and traced messages
+type MaskingSpec struct {
+	// Patterns are the regular expressions matching the text to mask
+	Patterns []string `json:"patterns,omitempty"`
+	// Headers are the names of the message headers whose values are masked
+	Headers []string `json:"headers,omitempty"`
+}
+
 // MavenSpec --
 type MavenSpec struct {
-	Settings ValueSource `json:"settings,omitempty"`
+	Settings        ValueSource `json:"settings,omitempty"`
+	Version         string      `json:"version,omitempty"`
+	VerifyChecksums bool        `json:"verifyChecksums,omitempty"`
+	// StrictChecksums fails the build when an artifact has no checksum to verify, a warning being reported otherwise
+	StrictChecksums bool     `json:"strictChecksums,omitempty"`
+	AllowedGroups   []string `json:"allowedGroups,omitempty"`
+	DeniedGroups    []string `json:"deniedGroups,omitempty"`
 }
 
 // ValueSource --
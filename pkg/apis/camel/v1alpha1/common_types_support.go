@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	yaml2 "gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (in *Artifact) String() string {
@@ -39,3 +40,85 @@ func (flows Flows) Serialize() (string, error) {
 	}
 	return string(res), nil
 }
+
+// IsReconcilePaused returns true if the reconciliation of the resource has been paused through the ReconcileAnnotation
+func IsReconcilePaused(meta metav1.ObjectMeta) bool {
+	return meta.Annotations[ReconcileAnnotation] == ReconcilePaused
+}
+
+// GetCondition returns the condition of the given type, if present
+func GetCondition(conditions []Condition, conditionType ConditionType) *Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetCondition adds the given condition or replaces the existing one of the same type,
+// the transition time is retained as long as the status of the condition does not change
+func SetCondition(conditions []Condition, condition Condition) []Condition {
+	if condition.LastTransitionTime.IsZero() {
+		condition.LastTransitionTime = metav1.Now()
+	}
+
+	for i := range conditions {
+		if conditions[i].Type == condition.Type {
+			if conditions[i].Status == condition.Status {
+				condition.LastTransitionTime = conditions[i].LastTransitionTime
+			}
+			conditions[i] = condition
+			return conditions
+		}
+	}
+
+	return append(conditions, condition)
+}
+
+// RemoveCondition returns the given conditions without the one of the given type
+func RemoveCondition(conditions []Condition, conditionType ConditionType) []Condition {
+	var newConditions []Condition
+	for _, c := range conditions {
+		if c.Type != conditionType {
+			newConditions = append(newConditions, c)
+		}
+	}
+	return newConditions
+}
+
+// MergeMaskingSpecs returns the union of the given masking rules, or nil if there are none, so that the rules
+// defined on the platform can't be relaxed by the integrations
+func MergeMaskingSpecs(specs ...*MaskingSpec) *MaskingSpec {
+	var merged *MaskingSpec
+	for _, spec := range specs {
+		if spec == nil {
+			continue
+		}
+		if merged == nil {
+			merged = &MaskingSpec{}
+		}
+		merged.Patterns = appendMissing(merged.Patterns, spec.Patterns...)
+		merged.Headers = appendMissing(merged.Headers, spec.Headers...)
+	}
+	if merged != nil && len(merged.Patterns) == 0 && len(merged.Headers) == 0 {
+		return nil
+	}
+	return merged
+}
+
+func appendMissing(items []string, extra ...string) []string {
+	for _, e := range extra {
+		found := false
+		for _, item := range items {
+			if item == e {
+				found = true
+				break
+			}
+		}
+		if !found && e != "" {
+			items = append(items, e)
+		}
+	}
+	return items
+}
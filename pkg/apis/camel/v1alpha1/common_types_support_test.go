@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetCondition(t *testing.T) {
+	then := metav1.NewTime(time.Now().Add(-time.Hour))
+
+	conditions := SetCondition(nil, Condition{
+		Type:               ConditionQuotaExceeded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: then,
+		Message:            "first",
+	})
+	assert.Len(t, conditions, 1)
+
+	// same status, the transition time is retained
+	conditions = SetCondition(conditions, Condition{
+		Type:    ConditionQuotaExceeded,
+		Status:  corev1.ConditionTrue,
+		Message: "second",
+	})
+	assert.Len(t, conditions, 1)
+	assert.Equal(t, "second", conditions[0].Message)
+	assert.Equal(t, then, conditions[0].LastTransitionTime)
+
+	// status change, the transition time is updated
+	conditions = SetCondition(conditions, Condition{
+		Type:   ConditionQuotaExceeded,
+		Status: corev1.ConditionFalse,
+	})
+	c := GetCondition(conditions, ConditionQuotaExceeded)
+	assert.NotNil(t, c)
+	assert.Equal(t, corev1.ConditionFalse, c.Status)
+	assert.True(t, c.LastTransitionTime.After(then.Time))
+
+	conditions = RemoveCondition(conditions, ConditionQuotaExceeded)
+	assert.Empty(t, conditions)
+	assert.Nil(t, GetCondition(conditions, ConditionQuotaExceeded))
+}
+
+func TestIsReconcilePaused(t *testing.T) {
+	assert.False(t, IsReconcilePaused(metav1.ObjectMeta{}))
+	assert.False(t, IsReconcilePaused(metav1.ObjectMeta{Annotations: map[string]string{ReconcileAnnotation: "enabled"}}))
+	assert.True(t, IsReconcilePaused(metav1.ObjectMeta{Annotations: map[string]string{ReconcileAnnotation: ReconcilePaused}}))
+}
+
+func TestMergeMaskingSpecs(t *testing.T) {
+	assert.Nil(t, MergeMaskingSpecs(nil, &MaskingSpec{}))
+
+	merged := MergeMaskingSpecs(
+		&MaskingSpec{Patterns: []string{`\d{16}`}, Headers: []string{"Authorization"}},
+		nil,
+		&MaskingSpec{Patterns: []string{`\d{16}`, `[a-z]+@[a-z.]+`}, Headers: []string{"X-Api-Key"}},
+	)
+	assert.Equal(t, &MaskingSpec{
+		Patterns: []string{`\d{16}`, `[a-z]+@[a-z.]+`},
+		Headers:  []string{"Authorization", "X-Api-Key"},
+	}, merged)
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -25,16 +26,21 @@ import (
 
 // IntegrationSpec defines the desired state of Integration
 type IntegrationSpec struct {
-	Replicas           *int32               `json:"replicas,omitempty"`
-	Sources            []SourceSpec         `json:"sources,omitempty"`
-	Resources          []ResourceSpec       `json:"resources,omitempty"`
-	Kit                string               `json:"kit,omitempty"`
-	Dependencies       []string             `json:"dependencies,omitempty"`
-	Profile            TraitProfile         `json:"profile,omitempty"`
-	Traits             map[string]TraitSpec `json:"traits,omitempty"`
-	Configuration      []ConfigurationSpec  `json:"configuration,omitempty"`
-	Repositories       []string             `json:"repositories,omitempty"`
-	ServiceAccountName string               `json:"serviceAccountName,omitempty"`
+	Replicas           *int32                   `json:"replicas,omitempty"`
+	Sources            []SourceSpec             `json:"sources,omitempty"`
+	Resources          []ResourceSpec           `json:"resources,omitempty"`
+	Kit                string                   `json:"kit,omitempty"`
+	KitProtected       bool                     `json:"kitProtected,omitempty"`
+	Dependencies       []string                 `json:"dependencies,omitempty"`
+	Libraries          []string                 `json:"libraries,omitempty"`
+	Profile            TraitProfile             `json:"profile,omitempty"`
+	Traits             map[string]TraitSpec     `json:"traits,omitempty"`
+	Configuration      []ConfigurationSpec      `json:"configuration,omitempty"`
+	Repositories       []string                 `json:"repositories,omitempty"`
+	ServiceAccountName string                   `json:"serviceAccountName,omitempty"`
+	DependsOn          []corev1.ObjectReference `json:"dependsOn,omitempty"`
+	InitContainers     []corev1.Container       `json:"initContainers,omitempty"`
+	Masking            *MaskingSpec             `json:"masking,omitempty"`
 }
 
 // IntegrationStatus defines the observed state of Integration
@@ -42,6 +48,7 @@ type IntegrationStatus struct {
 	Phase            IntegrationPhase    `json:"phase,omitempty"`
 	Digest           string              `json:"digest,omitempty"`
 	Image            string              `json:"image,omitempty"`
+	ImageDigest      string              `json:"imageDigest,omitempty"`
 	Dependencies     []string            `json:"dependencies,omitempty"`
 	Kit              string              `json:"kit,omitempty"`
 	GeneratedSources []SourceSpec        `json:"generatedSources,omitempty"`
@@ -49,6 +56,29 @@ type IntegrationStatus struct {
 	CamelVersion     string              `json:"camelVersion,omitempty"`
 	RuntimeVersion   string              `json:"runtimeVersion,omitempty"`
 	Configuration    []ConfigurationSpec `json:"configuration,omitempty"`
+	Conditions       []Condition         `json:"conditions,omitempty"`
+	Routes           []RouteStatus       `json:"routes,omitempty"`
+	RoutesUpdateTime *metav1.Time        `json:"routesUpdateTime,omitempty"`
+	// ResourcesCheckTime records when the generated resources have been last checked for drifts
+	ResourcesCheckTime *metav1.Time `json:"resourcesCheckTime,omitempty"`
+	// PhaseTransitions records when the integration entered its phases since it has been last initialized
+	PhaseTransitions []IntegrationPhaseTransition `json:"phaseTransitions,omitempty"`
+}
+
+// IntegrationPhaseTransition records the time the integration entered a phase
+type IntegrationPhaseTransition struct {
+	Phase IntegrationPhase `json:"phase"`
+	Time  metav1.Time      `json:"time"`
+}
+
+// RouteStatus is a snapshot of the statistics of a Camel route, aggregated over the integration pods.
+// The mean processing time is expressed in milliseconds.
+type RouteStatus struct {
+	ID                 string `json:"id"`
+	State              string `json:"state,omitempty"`
+	ExchangesCompleted int64  `json:"exchangesCompleted"`
+	ExchangesFailed    int64  `json:"exchangesFailed"`
+	MeanProcessingTime int64  `json:"meanProcessingTime"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -142,6 +172,35 @@ const (
 	// IntegrationKind --
 	IntegrationKind string = "Integration"
 
+	// IntegrationApprovedImageAnnotation holds the image whose rollout has been approved,
+	// when the approval trait is enabled
+	IntegrationApprovedImageAnnotation = "camel.apache.org/approved-image"
+
+	// IntegrationMaxRestartsAnnotation is set on the integration deployment by the restart-policy trait,
+	// with the number of crash looping restarts after which the integration is failed
+	IntegrationMaxRestartsAnnotation = "camel.apache.org/restart-policy.max-restarts"
+	// IntegrationScaleToZeroAnnotation is set on the integration deployment by the restart-policy trait,
+	// when failed integrations are scaled to zero
+	IntegrationScaleToZeroAnnotation = "camel.apache.org/restart-policy.scale-to-zero"
+
+	// IntegrationTraceBacklogSizeAnnotation is set on the integration deployment by the tracing trait,
+	// with the number of traced messages kept by the runtime and by the operator
+	IntegrationTraceBacklogSizeAnnotation = "camel.apache.org/tracing.backlog-size"
+	// IntegrationTraceBodyMaxCharsAnnotation is set on the integration deployment by the tracing trait,
+	// with the maximum number of characters of the traced message bodies
+	IntegrationTraceBodyMaxCharsAnnotation = "camel.apache.org/tracing.body-max-chars"
+	// IntegrationTraceRoutesAnnotation is set on the integration deployment by the tracing trait,
+	// with the comma separated list of the traced routes
+	IntegrationTraceRoutesAnnotation = "camel.apache.org/tracing.routes"
+	// IntegrationTraceFilterAnnotation is set on the integration deployment by the tracing trait,
+	// with the predicate the traced exchanges must match
+	IntegrationTraceFilterAnnotation = "camel.apache.org/tracing.filter"
+	// IntegrationTraceMaskingAnnotation is set on the integration deployment by the tracing trait,
+	// with the JSON encoded masking rules the operator applies to the traced messages
+	IntegrationTraceMaskingAnnotation = "camel.apache.org/tracing.masking"
+	// IntegrationTracesKey is the key of the traced messages in the integration traces config map
+	IntegrationTracesKey = "traces.json"
+
 	// IntegrationPhaseInitial --
 	IntegrationPhaseInitial IntegrationPhase = ""
 	// IntegrationPhaseWaitingForPlatform --
@@ -19,6 +19,7 @@ package v1alpha1
 
 import (
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -161,3 +162,53 @@ func (s SourceSpec) InferLanguage() Language {
 	}
 	return ""
 }
+
+// TracesConfigMapName returns the name of the config map the operator collects the traced messages of the integration in
+func (in *Integration) TracesConfigMapName() string {
+	return in.Name + "-traces"
+}
+
+// IsKitPinned returns true if the integration is pinned to the kit it declares, the operator
+// not being allowed to replace it
+func (in *Integration) IsKitPinned() bool {
+	return in.Spec.Kit != "" && in.Spec.KitProtected
+}
+
+// maxPhaseTransitions bounds the phase transitions recorded for integrations flapping between phases
+const maxPhaseTransitions = 20
+
+// RecordPhaseTransition records the time the integration entered its current phase, if not recorded yet. The
+// transitions are reset when the integration is initialized again, e.g. after a change, so that they measure
+// the time to running of its current version. It returns true if a transition has been recorded.
+func (in *IntegrationStatus) RecordPhaseTransition(now metav1.Time) bool {
+	if n := len(in.PhaseTransitions); n > 0 && in.PhaseTransitions[n-1].Phase == in.Phase {
+		return false
+	}
+
+	if in.Phase == IntegrationPhaseInitial {
+		in.PhaseTransitions = nil
+	} else if len(in.PhaseTransitions) >= maxPhaseTransitions {
+		in.PhaseTransitions = in.PhaseTransitions[len(in.PhaseTransitions)-maxPhaseTransitions+1:]
+	}
+
+	in.PhaseTransitions = append(in.PhaseTransitions, IntegrationPhaseTransition{
+		Phase: in.Phase,
+		Time:  now,
+	})
+
+	return true
+}
+
+// TimeToRunning returns how long the integration took to get running since it has been last initialized,
+// and false if it's not running yet
+func (in *IntegrationStatus) TimeToRunning() (time.Duration, bool) {
+	if len(in.PhaseTransitions) == 0 {
+		return 0, false
+	}
+	for _, t := range in.PhaseTransitions {
+		if t.Phase == IntegrationPhaseRunning {
+			return t.Time.Sub(in.PhaseTransitions[0].Time.Time), true
+		}
+	}
+	return 0, false
+}
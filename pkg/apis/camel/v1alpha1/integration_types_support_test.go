@@ -20,8 +20,11 @@ package v1alpha1
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestAllLanguages(t *testing.T) {
@@ -78,3 +81,50 @@ func TestAddDependency(t *testing.T) {
 	integration.AddDependency("file:ciaone")
 	assert.Equal(t, integration.Dependencies, []string{"file:ciaone"})
 }
+
+func TestRecordPhaseTransition(t *testing.T) {
+	start := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	status := IntegrationStatus{}
+
+	_, running := status.TimeToRunning()
+	assert.False(t, running)
+
+	assert.True(t, status.RecordPhaseTransition(metav1.NewTime(start)))
+	assert.False(t, status.RecordPhaseTransition(metav1.NewTime(start.Add(time.Second))))
+
+	status.Phase = IntegrationPhaseBuildingKit
+	assert.True(t, status.RecordPhaseTransition(metav1.NewTime(start.Add(5*time.Second))))
+	status.Phase = IntegrationPhaseDeploying
+	assert.True(t, status.RecordPhaseTransition(metav1.NewTime(start.Add(90*time.Second))))
+	status.Phase = IntegrationPhaseRunning
+	assert.True(t, status.RecordPhaseTransition(metav1.NewTime(start.Add(2*time.Minute))))
+	assert.Len(t, status.PhaseTransitions, 4)
+
+	elapsed, running := status.TimeToRunning()
+	assert.True(t, running)
+	assert.Equal(t, 2*time.Minute, elapsed)
+
+	// a new initialization resets the transitions
+	status.Phase = IntegrationPhaseInitial
+	assert.True(t, status.RecordPhaseTransition(metav1.NewTime(start.Add(time.Hour))))
+	assert.Len(t, status.PhaseTransitions, 1)
+	_, running = status.TimeToRunning()
+	assert.False(t, running)
+}
+
+func TestRecordPhaseTransitionIsBounded(t *testing.T) {
+	start := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	status := IntegrationStatus{Phase: IntegrationPhaseRunning}
+
+	for i := 0; i < 2*maxPhaseTransitions; i++ {
+		if i%2 == 0 {
+			status.Phase = IntegrationPhaseRunning
+		} else {
+			status.Phase = IntegrationPhaseError
+		}
+		status.RecordPhaseTransition(metav1.NewTime(start.Add(time.Duration(i) * time.Second)))
+	}
+
+	assert.Len(t, status.PhaseTransitions, maxPhaseTransitions)
+	assert.Equal(t, IntegrationPhaseError, status.PhaseTransitions[maxPhaseTransitions-1].Phase)
+}
@@ -38,11 +38,20 @@ type IntegrationKitStatus struct {
 	BaseImage      string              `json:"baseImage,omitempty"`
 	Image          string              `json:"image,omitempty"`
 	PublicImage    string              `json:"publicImage,omitempty"`
+	ImageStreamTag string              `json:"imageStreamTag,omitempty"`
+	ImageSize      int64               `json:"imageSize,omitempty"`
 	Digest         string              `json:"digest,omitempty"`
 	Artifacts      []Artifact          `json:"artifacts,omitempty"`
 	Failure        *Failure            `json:"failure,omitempty"`
 	CamelVersion   string              `json:"camelVersion,omitempty"`
 	RuntimeVersion string              `json:"runtimeVersion,omitempty"`
+	Conditions     []Condition         `json:"conditions,omitempty"`
+	// ImageLabels holds the provenance labels set on the kit image
+	ImageLabels map[string]string `json:"imageLabels,omitempty"`
+	// Vulnerabilities holds the number of vulnerabilities found in the kit image by severity
+	Vulnerabilities map[string]int `json:"vulnerabilities,omitempty"`
+	// ScannedAt records when the kit image has been last scanned for vulnerabilities
+	ScannedAt *metav1.Time `json:"scannedAt,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -82,6 +91,26 @@ const (
 	// IntegrationKitTypeExternal --
 	IntegrationKitTypeExternal = "external"
 
+	// IntegrationKitTypeLibrary identifies kits publishing shared route libraries that integrations
+	// reference by name through spec.libraries
+	IntegrationKitTypeLibrary = "library"
+
+	// IntegrationKitInvalidatedAnnotation marks kits that must not be reused by new integrations nor
+	// as base images of new builds, e.g. after a registry wipe or a base image change
+	IntegrationKitInvalidatedAnnotation = "camel.apache.org/kit.invalidated"
+
+	// IntegrationKitAliasLabel holds a human readable name of the kit, platform kits being named
+	// after the digest of their content are aliased after the integration they have been created for
+	IntegrationKitAliasLabel = "camel.apache.org/kit.alias"
+
+	// IntegrationKitUnusedSinceAnnotation records, in RFC 3339 format, when a platform kit has been found
+	// not to be used by any integration, for the Unused kit garbage collection policy
+	IntegrationKitUnusedSinceAnnotation = "camel.apache.org/kit.unused-since"
+
+	// IntegrationKitSharedFromAnnotation references, as namespace/name, the shared kit a platform
+	// kit has been copied from, the image of the shared kit being used in place of a local build
+	IntegrationKitSharedFromAnnotation = "camel.apache.org/kit.shared-from"
+
 	// IntegrationKitPhaseBuildSubmitted --
 	IntegrationKitPhaseBuildSubmitted IntegrationKitPhase = "Build Submitted"
 	// IntegrationKitPhaseBuildRunning --
@@ -51,6 +51,11 @@ func (in *IntegrationKit) ImageForIntegration() string {
 	return in.Status.Image
 }
 
+// IsInvalidated tells whether the kit has been invalidated and cannot be reused
+func (in *IntegrationKit) IsInvalidated() bool {
+	return in.Annotations[IntegrationKitInvalidatedAnnotation] == "true"
+}
+
 // Configurations --
 func (in *IntegrationKitSpec) Configurations() []ConfigurationSpec {
 	if in == nil {
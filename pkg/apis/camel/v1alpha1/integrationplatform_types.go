@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -28,18 +29,82 @@ type IntegrationPlatformSpec struct {
 	Profile       TraitProfile                     `json:"profile,omitempty"`
 	Build         IntegrationPlatformBuildSpec     `json:"build,omitempty"`
 	Resources     IntegrationPlatformResourcesSpec `json:"resources,omitempty"`
+	Quota         IntegrationPlatformQuotaSpec     `json:"quota,omitempty"`
+	Policy        IntegrationPlatformPolicySpec    `json:"policy,omitempty"`
 	Traits        map[string]TraitSpec             `json:"traits,omitempty"`
 	Configuration []ConfigurationSpec              `json:"configuration,omitempty"`
+	Masking       *MaskingSpec                     `json:"masking,omitempty"`
+	FIPS          bool                             `json:"fips,omitempty"`
+	KitGC         *IntegrationPlatformKitGCSpec    `json:"kitGC,omitempty"`
 }
 
+// IntegrationPlatformKitGCSpec configures the lifecycle of the platform kits, that are managed by the platform
+// rather than owned by the integrations they have been created for unless the Owned policy is set
+type IntegrationPlatformKitGCSpec struct {
+	Policy IntegrationPlatformKitGCPolicy `json:"policy,omitempty"`
+	// How long a platform kit is kept once no integration uses it, with the Unused policy
+	TTL metav1.Duration `json:"ttl,omitempty"`
+}
+
+// IntegrationPlatformKitGCPolicy enumerates the ways the platform kits are garbage collected
+type IntegrationPlatformKitGCPolicy string
+
+const (
+	// IntegrationPlatformKitGCPolicyRetain keeps the platform kits until they are deleted explicitly
+	IntegrationPlatformKitGCPolicyRetain IntegrationPlatformKitGCPolicy = "Retain"
+
+	// IntegrationPlatformKitGCPolicyOwned makes the integrations using a platform kit own it, so that the kit
+	// is garbage collected along with the last of them
+	IntegrationPlatformKitGCPolicyOwned IntegrationPlatformKitGCPolicy = "Owned"
+
+	// IntegrationPlatformKitGCPolicyUnused deletes the platform kits that no integration has used for the TTL
+	IntegrationPlatformKitGCPolicyUnused IntegrationPlatformKitGCPolicy = "Unused"
+)
+
 // IntegrationPlatformResourcesSpec contains platform related resources
 type IntegrationPlatformResourcesSpec struct {
 	Kits []string `json:"kits,omitempty"`
 }
 
+// IntegrationPlatformQuotaSpec contains the limits enforced on the resources of the
+// namespace the platform belongs to, a zero value means no limit
+type IntegrationPlatformQuotaSpec struct {
+	// The maximum number of builds requested from the namespace running at the same time, that overrides the
+	// operator max-running-builds for the namespace unless the builds run in a dedicated build namespace
+	MaxRunningBuilds int `json:"maxRunningBuilds,omitempty"`
+	MaxKits          int `json:"maxKits,omitempty"`
+	MaxIntegrations  int `json:"maxIntegrations,omitempty"`
+}
+
+// IntegrationPlatformPolicySpec contains the naming and labeling conventions the integrations of the
+// namespace the platform belongs to have to follow before being admitted
+type IntegrationPlatformPolicySpec struct {
+	NamePattern          string                               `json:"namePattern,omitempty"`
+	RequiredLabels       []string                             `json:"requiredLabels,omitempty"`
+	HardcodedCredentials IntegrationPlatformCredentialsPolicy `json:"hardcodedCredentials,omitempty"`
+}
+
 // IntegrationPlatformStatus defines the observed state of IntegrationPlatform
 type IntegrationPlatformStatus struct {
-	Phase IntegrationPlatformPhase `json:"phase,omitempty"`
+	Phase           IntegrationPlatformPhase           `json:"phase,omitempty"`
+	Builds          IntegrationPlatformBuildsStatus    `json:"builds,omitempty"`
+	Registry        *IntegrationPlatformRegistryStatus `json:"registry,omitempty"`
+	CatalogVersions []string                           `json:"catalogVersions,omitempty"`
+}
+
+// IntegrationPlatformBuildsStatus counts the builds running in the platform namespace
+type IntegrationPlatformBuildsStatus struct {
+	// Builds waiting for a slot to be scheduled
+	Queued  int `json:"queued,omitempty"`
+	Running int `json:"running,omitempty"`
+	// Duration of the last completed build
+	LastDuration string `json:"lastDuration,omitempty"`
+}
+
+// IntegrationPlatformRegistryStatus reports the outcome of the last probe of the platform registry
+type IntegrationPlatformRegistryStatus struct {
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -90,16 +155,41 @@ var allTraitProfiles = []TraitProfile{TraitProfileOpenShift, TraitProfileKuberne
 // IntegrationPlatformBuildSpec contains platform related build information
 type IntegrationPlatformBuildSpec struct {
 	BuildStrategy         IntegrationPlatformBuildStrategy        `json:"buildStrategy,omitempty"`
+	BuildTool             IntegrationPlatformBuildTool            `json:"buildTool,omitempty"`
 	PublishStrategy       IntegrationPlatformBuildPublishStrategy `json:"publishStrategy,omitempty"`
 	CamelVersion          string                                  `json:"camelVersion,omitempty"`
 	RuntimeVersion        string                                  `json:"runtimeVersion,omitempty"`
 	BaseImage             string                                  `json:"baseImage,omitempty"`
+	JavaVersion           string                                  `json:"javaVersion,omitempty"`
+	ClasspathConflicts    IntegrationPlatformClasspathPolicy      `json:"classpathConflicts,omitempty"`
 	Properties            map[string]string                       `json:"properties,omitempty"`
 	LocalRepository       string                                  `json:"localRepository,omitempty"`
 	Registry              IntegrationPlatformRegistrySpec         `json:"registry,omitempty"`
+	Proxy                 IntegrationPlatformProxySpec            `json:"proxy,omitempty"`
+	S2I                   IntegrationPlatformS2ISpec              `json:"s2i,omitempty"`
+	Tekton                IntegrationPlatformTektonSpec           `json:"tekton,omitempty"`
 	Timeout               metav1.Duration                         `json:"timeout,omitempty"`
 	PersistentVolumeClaim string                                  `json:"persistentVolumeClaim,omitempty"`
 	Maven                 MavenSpec                               `json:"maven,omitempty"`
+	ImageScan             *IntegrationPlatformImageScanSpec       `json:"imageScan,omitempty"`
+	Env                   []corev1.EnvVar                         `json:"env,omitempty"`
+	Secrets               []IntegrationPlatformBuildSecret        `json:"secrets,omitempty"`
+	Provided              IntegrationPlatformProvidedSpec         `json:"provided,omitempty"`
+	Namespace             string                                  `json:"namespace,omitempty"`
+	SharedKits            *IntegrationPlatformSharedKitsSpec      `json:"sharedKits,omitempty"`
+}
+
+// IntegrationPlatformProvidedSpec declares the dependencies already shipped by the base image, that are
+// excluded from the integration kit images, and where they're located in the base image
+type IntegrationPlatformProvidedSpec struct {
+	Artifacts []string `json:"artifacts,omitempty"`
+	Classpath []string `json:"classpath,omitempty"`
+}
+
+// IntegrationPlatformBuildSecret is a secret mounted in the builder pods, e.g. a custom truststore
+type IntegrationPlatformBuildSecret struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
 }
 
 // IntegrationPlatformRegistrySpec --
@@ -110,6 +200,55 @@ type IntegrationPlatformRegistrySpec struct {
 	Organization string `json:"organization,omitempty"`
 }
 
+// IntegrationPlatformProxySpec configures the proxy used by the operator and the builders to reach
+// external services, e.g. Maven repositories or image registries
+type IntegrationPlatformProxySpec struct {
+	HTTPProxy  string `json:"httpProxy,omitempty"`
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// Comma separated list of hosts, domains (.example.com) and CIDRs not to be proxied
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// IntegrationPlatformS2ISpec configures the builds when the S2I publish strategy is used
+type IntegrationPlatformS2ISpec struct {
+	// Reuse the artifacts of the image previously built for the kit
+	Incremental bool `json:"incremental,omitempty"`
+}
+
+// IntegrationPlatformTektonSpec configures the Tekton pipeline the kit images are built by when the Tekton
+// publish strategy is used
+type IntegrationPlatformTektonSpec struct {
+	// The name of the pipeline, in the build namespace, run for each build
+	Pipeline string `json:"pipeline,omitempty"`
+	// The service account the pipeline runs are executed with
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Additional parameters passed to the pipeline runs
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// IntegrationPlatformSharedKitsSpec configures a namespace the platform kits are built in once and shared by the
+// namespaces served by a global operator, the kits being looked up there before being built locally
+type IntegrationPlatformSharedKitsSpec struct {
+	// The namespace the shared kits are built in, it requires an integration platform
+	Namespace string `json:"namespace,omitempty"`
+	// The secret, in the shared namespace, granting access to the shared kit images, that is
+	// propagated to the namespaces using them
+	PullSecret string `json:"pullSecret,omitempty"`
+}
+
+// IntegrationPlatformImageScanSpec configures the vulnerability scanning of the built images
+type IntegrationPlatformImageScanSpec struct {
+	// The scanning service endpoint, trivy is run by the operator if not set
+	Endpoint string `json:"endpoint,omitempty"`
+	// The minimum severity (UNKNOWN, LOW, MEDIUM, HIGH, CRITICAL) failing the build
+	Severity string `json:"severity,omitempty"`
+	// The interval the images of the ready kits are scanned again at, to report the vulnerabilities
+	// disclosed after they have been built (not scanned again if not set)
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// Hold back the deployment of the integrations whose kit contains vulnerabilities at or above the severity
+	BlockDeployments bool `json:"blockDeployments,omitempty"`
+}
+
 // IntegrationPlatformBuildStrategy enumerates all implemented build strategies
 type IntegrationPlatformBuildStrategy string
 
@@ -121,6 +260,44 @@ const (
 	IntegrationPlatformBuildStrategyPod = "pod"
 )
 
+// IntegrationPlatformBuildTool enumerates all supported tools used to compute the integration dependencies
+type IntegrationPlatformBuildTool string
+
+const (
+	// IntegrationPlatformBuildToolMaven resolves the dependencies using a generated Maven project
+	IntegrationPlatformBuildToolMaven = "maven"
+
+	// IntegrationPlatformBuildToolGradle resolves the dependencies using a generated Gradle project with dependency locking
+	IntegrationPlatformBuildToolGradle = "gradle"
+)
+
+// IntegrationPlatformClasspathPolicy enumerates the ways classpath conflicts are handled at build time
+type IntegrationPlatformClasspathPolicy string
+
+const (
+	// IntegrationPlatformClasspathPolicyWarn reports classpath conflicts as build warnings
+	IntegrationPlatformClasspathPolicyWarn = "warn"
+
+	// IntegrationPlatformClasspathPolicyFail fails the build when classpath conflicts are detected
+	IntegrationPlatformClasspathPolicyFail = "fail"
+
+	// IntegrationPlatformClasspathPolicyIgnore skips classpath conflicts detection
+	IntegrationPlatformClasspathPolicyIgnore = "ignore"
+)
+
+// IntegrationPlatformCredentialsPolicy enumerates the ways the credentials hard-coded in the sources of the
+// integrations are handled at admission
+type IntegrationPlatformCredentialsPolicy string
+
+const (
+	// IntegrationPlatformCredentialsPolicyWarn reports hard-coded credentials with a condition on the integration
+	IntegrationPlatformCredentialsPolicyWarn IntegrationPlatformCredentialsPolicy = "warn"
+	// IntegrationPlatformCredentialsPolicyReject holds back the integrations having hard-coded credentials
+	IntegrationPlatformCredentialsPolicyReject IntegrationPlatformCredentialsPolicy = "reject"
+	// IntegrationPlatformCredentialsPolicyIgnore skips hard-coded credentials detection
+	IntegrationPlatformCredentialsPolicyIgnore IntegrationPlatformCredentialsPolicy = "ignore"
+)
+
 // IntegrationPlatformBuildPublishStrategy enumerates all implemented publish strategies
 type IntegrationPlatformBuildPublishStrategy string
 
@@ -130,6 +307,16 @@ const (
 
 	// IntegrationPlatformBuildPublishStrategyKaniko performs
 	IntegrationPlatformBuildPublishStrategyKaniko = "Kaniko"
+
+	// IntegrationPlatformBuildPublishStrategyTekton delegates the image build to a Tekton pipeline
+	IntegrationPlatformBuildPublishStrategyTekton = "Tekton"
+
+	// IntegrationPlatformBuildPublishStrategyBuildah builds and pushes the images from a Buildah pod
+	IntegrationPlatformBuildPublishStrategyBuildah = "Buildah"
+
+	// IntegrationPlatformBuildPublishStrategySpectrum appends the kit artifacts as a new layer of the base image
+	// directly against the registry API, with no Docker daemon nor builder pod
+	IntegrationPlatformBuildPublishStrategySpectrum = "Spectrum"
 )
 
 // IntegrationPlatformPhase --
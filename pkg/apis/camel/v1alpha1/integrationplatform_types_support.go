@@ -19,10 +19,14 @@ package v1alpha1
 
 import (
 	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// defaultKitGCTTL is how long the unused platform kits are kept by default with the Unused policy
+const defaultKitGCTTL = 24 * time.Hour
+
 // NewIntegrationPlatformList --
 func NewIntegrationPlatformList() IntegrationPlatformList {
 	return IntegrationPlatformList{
@@ -74,3 +78,29 @@ func (in *IntegrationPlatform) Configurations() []ConfigurationSpec {
 
 	return in.Spec.Configuration
 }
+
+// KitGCPolicy returns the way the platform kits are garbage collected, defaulting to Retain
+func (in *IntegrationPlatformSpec) KitGCPolicy() IntegrationPlatformKitGCPolicy {
+	if in == nil || in.KitGC == nil || in.KitGC.Policy == "" {
+		return IntegrationPlatformKitGCPolicyRetain
+	}
+	return in.KitGC.Policy
+}
+
+// KitGCTTL returns how long the platform kits are kept once unused with the Unused policy, defaulting to a day
+func (in *IntegrationPlatformSpec) KitGCTTL() time.Duration {
+	if in == nil || in.KitGC == nil || in.KitGC.TTL.Duration <= 0 {
+		return defaultKitGCTTL
+	}
+	return in.KitGC.TTL.Duration
+}
+
+// BuildNamespace returns the namespace the builds of the kits living in the given namespace run in,
+// i.e. the dedicated build namespace if any
+func (in *IntegrationPlatformBuildSpec) BuildNamespace(namespace string) string {
+	if in.Namespace != "" {
+		return in.Namespace
+	}
+
+	return namespace
+}
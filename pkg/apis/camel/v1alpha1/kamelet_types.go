@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KameletSpec defines a route template that integrations use as an endpoint, with kamelet:name URIs
+type KameletSpec struct {
+	Definition   KameletDefinition `json:"definition,omitempty"`
+	Template     SourceSpec        `json:"template,omitempty"`
+	Dependencies []string          `json:"dependencies,omitempty"`
+}
+
+// KameletDefinition is the schema of the properties of a kamelet
+type KameletDefinition struct {
+	Title       string                     `json:"title,omitempty"`
+	Description string                     `json:"description,omitempty"`
+	Required    []string                   `json:"required,omitempty"`
+	Properties  map[string]KameletProperty `json:"properties,omitempty"`
+}
+
+// KameletProperty is the schema of a property of a kamelet
+type KameletProperty struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Example     string `json:"example,omitempty"`
+}
+
+// KameletStatus defines the observed state of Kamelet
+type KameletStatus struct {
+	Phase              KameletPhase `json:"phase,omitempty"`
+	ObservedGeneration int64        `json:"observedGeneration,omitempty"`
+	Error              string       `json:"error,omitempty"`
+}
+
+// KameletPhase --
+type KameletPhase string
+
+const (
+	// KameletKind --
+	KameletKind string = "Kamelet"
+
+	// KameletScheme is the scheme of the endpoint URIs referencing kamelets, e.g. kamelet:telegram-source
+	KameletScheme string = "kamelet"
+
+	// KameletPropertyTypeString --
+	KameletPropertyTypeString string = "string"
+	// KameletPropertyTypeInteger --
+	KameletPropertyTypeInteger string = "integer"
+	// KameletPropertyTypeNumber --
+	KameletPropertyTypeNumber string = "number"
+	// KameletPropertyTypeBoolean --
+	KameletPropertyTypeBoolean string = "boolean"
+
+	// KameletPhaseInitial --
+	KameletPhaseInitial KameletPhase = ""
+	// KameletPhaseReady --
+	KameletPhaseReady KameletPhase = "Ready"
+	// KameletPhaseError --
+	KameletPhaseError KameletPhase = "Error"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Kamelet is the Schema for the kamelets API, a route template whose properties are validated against a schema
+// +k8s:openapi-gen=true
+type Kamelet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KameletSpec   `json:"spec,omitempty"`
+	Status KameletStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KameletList contains a list of Kamelet
+type KameletList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Kamelet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Kamelet{}, &KameletList{})
+}
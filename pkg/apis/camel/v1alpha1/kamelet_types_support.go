@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// NewKamelet --
+func NewKamelet(namespace string, name string) Kamelet {
+	return Kamelet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       KameletKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+}
+
+// PropertyType returns the type of the property, string by default
+func (in *KameletProperty) PropertyType() string {
+	if in.Type == "" {
+		return KameletPropertyTypeString
+	}
+	return in.Type
+}
+
+// IsReady returns true when the kamelet has been validated in its current generation
+func (in *Kamelet) IsReady() bool {
+	return in.Status.Phase == KameletPhaseReady && in.Status.ObservedGeneration == in.Generation
+}
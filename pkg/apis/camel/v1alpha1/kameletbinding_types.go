@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KameletBindingSpec defines an integration connecting a source to a sink, through optional processing steps
+type KameletBindingSpec struct {
+	Integration *IntegrationSpec `json:"integration,omitempty"`
+	Source      Endpoint         `json:"source,omitempty"`
+	Steps       []Endpoint       `json:"steps,omitempty"`
+	Sink        Endpoint         `json:"sink,omitempty"`
+}
+
+// Endpoint references a kamelet, a Kafka topic or a Knative channel, or is given as an endpoint URI, along with
+// the properties that are added to its URI
+type Endpoint struct {
+	Ref        *corev1.ObjectReference `json:"ref,omitempty"`
+	URI        string                  `json:"uri,omitempty"`
+	Properties map[string]string       `json:"properties,omitempty"`
+}
+
+// KameletBindingStatus defines the observed state of KameletBinding
+type KameletBindingStatus struct {
+	Phase              KameletBindingPhase `json:"phase,omitempty"`
+	ObservedGeneration int64               `json:"observedGeneration,omitempty"`
+	Error              string              `json:"error,omitempty"`
+}
+
+// KameletBindingPhase --
+type KameletBindingPhase string
+
+const (
+	// KameletBindingKind --
+	KameletBindingKind string = "KameletBinding"
+
+	// KameletBindingPhaseInitial --
+	KameletBindingPhaseInitial KameletBindingPhase = ""
+	// KameletBindingPhaseCreating --
+	KameletBindingPhaseCreating KameletBindingPhase = "Creating"
+	// KameletBindingPhaseReady --
+	KameletBindingPhaseReady KameletBindingPhase = "Ready"
+	// KameletBindingPhaseError --
+	KameletBindingPhaseError KameletBindingPhase = "Error"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KameletBinding is the Schema for the kameletbindings API, that materializes an integration from a declarative
+// description of its source, steps and sink, so that no route code has to be written
+// +k8s:openapi-gen=true
+type KameletBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KameletBindingSpec   `json:"spec,omitempty"`
+	Status KameletBindingStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KameletBindingList contains a list of KameletBinding
+type KameletBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KameletBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KameletBinding{}, &KameletBindingList{})
+}
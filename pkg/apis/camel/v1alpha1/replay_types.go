@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReplaySpec defines the messages replayed into an endpoint of an integration
+type ReplaySpec struct {
+	Integration string `json:"integration,omitempty"`
+	Endpoint    string `json:"endpoint,omitempty"`
+	ConfigMap   string `json:"configMap,omitempty"`
+	Rate        int    `json:"rate,omitempty"`
+	Repeat      int    `json:"repeat,omitempty"`
+}
+
+// ReplayStatus defines the observed state of Replay
+type ReplayStatus struct {
+	Phase       ReplayPhase  `json:"phase,omitempty"`
+	Total       int          `json:"total,omitempty"`
+	Sent        int          `json:"sent,omitempty"`
+	Failed      int          `json:"failed,omitempty"`
+	StartedAt   *metav1.Time `json:"startedAt,omitempty"`
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// ReplayPhase --
+type ReplayPhase string
+
+const (
+	// ReplayKind --
+	ReplayKind string = "Replay"
+
+	// ReplayPhaseInitial --
+	ReplayPhaseInitial ReplayPhase = ""
+	// ReplayPhaseRunning --
+	ReplayPhaseRunning ReplayPhase = "Running"
+	// ReplayPhaseCompleted --
+	ReplayPhaseCompleted ReplayPhase = "Completed"
+	// ReplayPhaseError --
+	ReplayPhaseError ReplayPhase = "Error"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Replay is the Schema for the replays API, that feeds recorded messages into an endpoint of an integration
+// at a given rate, e.g. for performance testing or regression validation
+// +k8s:openapi-gen=true
+type Replay struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ReplaySpec   `json:"spec,omitempty"`
+	Status ReplayStatus `json:"status,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ReplayList contains a list of Replay
+type ReplayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Replay `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Replay{}, &ReplayList{})
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// NewReplay --
+func NewReplay(namespace string, name string) Replay {
+	return Replay{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: SchemeGroupVersion.String(),
+			Kind:       ReplayKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+		},
+	}
+}
+
+// RatePerSecond returns the number of messages sent per second, one by default
+func (in *ReplaySpec) RatePerSecond() int {
+	if in.Rate <= 0 {
+		return 1
+	}
+	return in.Rate
+}
+
+// Repetitions returns the number of times the messages are replayed, once by default
+func (in *ReplaySpec) Repetitions() int {
+	if in.Repeat <= 0 {
+		return 1
+	}
+	return in.Repeat
+}
+
+// Processed returns the number of messages that have been sent, successfully or not
+func (in *ReplayStatus) Processed() int {
+	return in.Sent + in.Failed
+}
@@ -53,6 +53,32 @@ func (in *Build) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuildBaseKit) DeepCopyInto(out *BuildBaseKit) {
+	*out = *in
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]Artifact, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildBaseKit.
+func (in *BuildBaseKit) DeepCopy() *BuildBaseKit {
+	if in == nil {
+		return nil
+	}
+	out := new(BuildBaseKit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuildList) DeepCopyInto(out *BuildList) {
 	*out = *in
@@ -111,6 +137,16 @@ func (in *BuildSpec) DeepCopyInto(out *BuildSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Incremental != nil {
+		in, out := &in.Incremental, &out.Incremental
+		*out = new(bool)
+		**out = **in
+	}
+	if in.BaseKit != nil {
+		in, out := &in.BaseKit, &out.BaseKit
+		*out = new(BuildBaseKit)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -132,12 +168,38 @@ func (in *BuildStatus) DeepCopyInto(out *BuildStatus) {
 		*out = make([]Artifact, len(*in))
 		copy(*out, *in)
 	}
+	if in.Warnings != nil {
+		in, out := &in.Warnings, &out.Warnings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Vulnerabilities != nil {
+		in, out := &in.Vulnerabilities, &out.Vulnerabilities
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.Failure != nil {
 		in, out := &in.Failure, &out.Failure
 		*out = new(Failure)
 		(*in).DeepCopyInto(*out)
 	}
 	in.StartedAt.DeepCopyInto(&out.StartedAt)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImageLabels != nil {
+		in, out := &in.ImageLabels, &out.ImageLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -158,7 +220,9 @@ func (in *CamelArtifact) DeepCopyInto(out *CamelArtifact) {
 	if in.Schemes != nil {
 		in, out := &in.Schemes, &out.Schemes
 		*out = make([]CamelScheme, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Languages != nil {
 		in, out := &in.Languages, &out.Languages
@@ -330,6 +394,13 @@ func (in *CamelCatalogStatus) DeepCopy() *CamelCatalogStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CamelScheme) DeepCopyInto(out *CamelScheme) {
 	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]CamelSchemeParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -343,6 +414,44 @@ func (in *CamelScheme) DeepCopy() *CamelScheme {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CamelSchemeParameter) DeepCopyInto(out *CamelSchemeParameter) {
+	*out = *in
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CamelSchemeParameter.
+func (in *CamelSchemeParameter) DeepCopy() *CamelSchemeParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(CamelSchemeParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConfigurationSpec) DeepCopyInto(out *ConfigurationSpec) {
 	*out = *in
@@ -375,6 +484,34 @@ func (in *DataSpec) DeepCopy() *DataSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Endpoint) DeepCopyInto(out *Endpoint) {
+	*out = *in
+	if in.Ref != nil {
+		in, out := &in.Ref, &out.Ref
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoint.
+func (in *Endpoint) DeepCopy() *Endpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Failure) DeepCopyInto(out *Failure) {
 	*out = *in
@@ -593,6 +730,31 @@ func (in *IntegrationKitStatus) DeepCopyInto(out *IntegrationKitStatus) {
 		*out = new(Failure)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImageLabels != nil {
+		in, out := &in.ImageLabels, &out.ImageLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Vulnerabilities != nil {
+		in, out := &in.Vulnerabilities, &out.Vulnerabilities
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ScannedAt != nil {
+		in, out := &in.ScannedAt, &out.ScannedAt
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -639,13 +801,30 @@ func (in *IntegrationList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPhaseTransition) DeepCopyInto(out *IntegrationPhaseTransition) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPhaseTransition.
+func (in *IntegrationPhaseTransition) DeepCopy() *IntegrationPhaseTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPhaseTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationPlatform) DeepCopyInto(out *IntegrationPlatform) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -678,11 +857,53 @@ func (in *IntegrationPlatformBuildSpec) DeepCopyInto(out *IntegrationPlatformBui
 		}
 	}
 	out.Registry = in.Registry
+	out.Proxy = in.Proxy
+	out.S2I = in.S2I
+	in.Tekton.DeepCopyInto(&out.Tekton)
 	out.Timeout = in.Timeout
 	in.Maven.DeepCopyInto(&out.Maven)
+	if in.ImageScan != nil {
+		in, out := &in.ImageScan, &out.ImageScan
+		*out = new(IntegrationPlatformImageScanSpec)
+		**out = **in
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]IntegrationPlatformBuildSecret, len(*in))
+		copy(*out, *in)
+	}
+	in.Provided.DeepCopyInto(&out.Provided)
+	if in.SharedKits != nil {
+		in, out := &in.SharedKits, &out.SharedKits
+		*out = new(IntegrationPlatformSharedKitsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformBuildSecret) DeepCopyInto(out *IntegrationPlatformBuildSecret) {
+	*out = *in
 	return
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformBuildSecret.
+func (in *IntegrationPlatformBuildSecret) DeepCopy() *IntegrationPlatformBuildSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformBuildSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformBuildSpec.
 func (in *IntegrationPlatformBuildSpec) DeepCopy() *IntegrationPlatformBuildSpec {
 	if in == nil {
@@ -693,6 +914,56 @@ func (in *IntegrationPlatformBuildSpec) DeepCopy() *IntegrationPlatformBuildSpec
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformBuildsStatus) DeepCopyInto(out *IntegrationPlatformBuildsStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformBuildsStatus.
+func (in *IntegrationPlatformBuildsStatus) DeepCopy() *IntegrationPlatformBuildsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformBuildsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformImageScanSpec) DeepCopyInto(out *IntegrationPlatformImageScanSpec) {
+	*out = *in
+	out.Interval = in.Interval
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformImageScanSpec.
+func (in *IntegrationPlatformImageScanSpec) DeepCopy() *IntegrationPlatformImageScanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformImageScanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformKitGCSpec) DeepCopyInto(out *IntegrationPlatformKitGCSpec) {
+	*out = *in
+	out.TTL = in.TTL
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformKitGCSpec.
+func (in *IntegrationPlatformKitGCSpec) DeepCopy() *IntegrationPlatformKitGCSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformKitGCSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IntegrationPlatformList) DeepCopyInto(out *IntegrationPlatformList) {
 	*out = *in
@@ -727,109 +998,282 @@ func (in *IntegrationPlatformList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationPlatformRegistrySpec) DeepCopyInto(out *IntegrationPlatformRegistrySpec) {
+func (in *IntegrationPlatformQuotaSpec) DeepCopyInto(out *IntegrationPlatformQuotaSpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformRegistrySpec.
-func (in *IntegrationPlatformRegistrySpec) DeepCopy() *IntegrationPlatformRegistrySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformQuotaSpec.
+func (in *IntegrationPlatformQuotaSpec) DeepCopy() *IntegrationPlatformQuotaSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntegrationPlatformRegistrySpec)
+	out := new(IntegrationPlatformQuotaSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationPlatformResourcesSpec) DeepCopyInto(out *IntegrationPlatformResourcesSpec) {
+func (in *IntegrationPlatformPolicySpec) DeepCopyInto(out *IntegrationPlatformPolicySpec) {
 	*out = *in
-	if in.Kits != nil {
-		in, out := &in.Kits, &out.Kits
+	if in.RequiredLabels != nil {
+		in, out := &in.RequiredLabels, &out.RequiredLabels
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformResourcesSpec.
-func (in *IntegrationPlatformResourcesSpec) DeepCopy() *IntegrationPlatformResourcesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformPolicySpec.
+func (in *IntegrationPlatformPolicySpec) DeepCopy() *IntegrationPlatformPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntegrationPlatformResourcesSpec)
+	out := new(IntegrationPlatformPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationPlatformSpec) DeepCopyInto(out *IntegrationPlatformSpec) {
+func (in *IntegrationPlatformProvidedSpec) DeepCopyInto(out *IntegrationPlatformProvidedSpec) {
 	*out = *in
-	in.Build.DeepCopyInto(&out.Build)
-	in.Resources.DeepCopyInto(&out.Resources)
-	if in.Traits != nil {
-		in, out := &in.Traits, &out.Traits
-		*out = make(map[string]TraitSpec, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Configuration != nil {
-		in, out := &in.Configuration, &out.Configuration
-		*out = make([]ConfigurationSpec, len(*in))
+	if in.Classpath != nil {
+		in, out := &in.Classpath, &out.Classpath
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformSpec.
-func (in *IntegrationPlatformSpec) DeepCopy() *IntegrationPlatformSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformProvidedSpec.
+func (in *IntegrationPlatformProvidedSpec) DeepCopy() *IntegrationPlatformProvidedSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntegrationPlatformSpec)
+	out := new(IntegrationPlatformProvidedSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationPlatformStatus) DeepCopyInto(out *IntegrationPlatformStatus) {
+func (in *IntegrationPlatformProxySpec) DeepCopyInto(out *IntegrationPlatformProxySpec) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformStatus.
-func (in *IntegrationPlatformStatus) DeepCopy() *IntegrationPlatformStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformProxySpec.
+func (in *IntegrationPlatformProxySpec) DeepCopy() *IntegrationPlatformProxySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IntegrationPlatformStatus)
+	out := new(IntegrationPlatformProxySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
+func (in *IntegrationPlatformRegistrySpec) DeepCopyInto(out *IntegrationPlatformRegistrySpec) {
 	*out = *in
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Sources != nil {
-		in, out := &in.Sources, &out.Sources
-		*out = make([]SourceSpec, len(*in))
-		copy(*out, *in)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformRegistrySpec.
+func (in *IntegrationPlatformRegistrySpec) DeepCopy() *IntegrationPlatformRegistrySpec {
+	if in == nil {
+		return nil
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make([]ResourceSpec, len(*in))
-		copy(*out, *in)
+	out := new(IntegrationPlatformRegistrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformRegistryStatus) DeepCopyInto(out *IntegrationPlatformRegistryStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformRegistryStatus.
+func (in *IntegrationPlatformRegistryStatus) DeepCopy() *IntegrationPlatformRegistryStatus {
+	if in == nil {
+		return nil
 	}
-	if in.Dependencies != nil {
-		in, out := &in.Dependencies, &out.Dependencies
-		*out = make([]string, len(*in))
+	out := new(IntegrationPlatformRegistryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformResourcesSpec) DeepCopyInto(out *IntegrationPlatformResourcesSpec) {
+	*out = *in
+	if in.Kits != nil {
+		in, out := &in.Kits, &out.Kits
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformResourcesSpec.
+func (in *IntegrationPlatformResourcesSpec) DeepCopy() *IntegrationPlatformResourcesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformResourcesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformS2ISpec) DeepCopyInto(out *IntegrationPlatformS2ISpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformS2ISpec.
+func (in *IntegrationPlatformS2ISpec) DeepCopy() *IntegrationPlatformS2ISpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformS2ISpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformSharedKitsSpec) DeepCopyInto(out *IntegrationPlatformSharedKitsSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformSharedKitsSpec.
+func (in *IntegrationPlatformSharedKitsSpec) DeepCopy() *IntegrationPlatformSharedKitsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformSharedKitsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformSpec) DeepCopyInto(out *IntegrationPlatformSpec) {
+	*out = *in
+	in.Build.DeepCopyInto(&out.Build)
+	in.Resources.DeepCopyInto(&out.Resources)
+	out.Quota = in.Quota
+	in.Policy.DeepCopyInto(&out.Policy)
+	if in.Traits != nil {
+		in, out := &in.Traits, &out.Traits
+		*out = make(map[string]TraitSpec, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Configuration != nil {
+		in, out := &in.Configuration, &out.Configuration
+		*out = make([]ConfigurationSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Masking != nil {
+		in, out := &in.Masking, &out.Masking
+		*out = new(MaskingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KitGC != nil {
+		in, out := &in.KitGC, &out.KitGC
+		*out = new(IntegrationPlatformKitGCSpec)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformSpec.
+func (in *IntegrationPlatformSpec) DeepCopy() *IntegrationPlatformSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformStatus) DeepCopyInto(out *IntegrationPlatformStatus) {
+	*out = *in
+	out.Builds = in.Builds
+	if in.Registry != nil {
+		in, out := &in.Registry, &out.Registry
+		*out = new(IntegrationPlatformRegistryStatus)
+		**out = **in
+	}
+	if in.CatalogVersions != nil {
+		in, out := &in.CatalogVersions, &out.CatalogVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformStatus.
+func (in *IntegrationPlatformStatus) DeepCopy() *IntegrationPlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationPlatformTektonSpec) DeepCopyInto(out *IntegrationPlatformTektonSpec) {
+	*out = *in
+	if in.Params != nil {
+		in, out := &in.Params, &out.Params
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IntegrationPlatformTektonSpec.
+func (in *IntegrationPlatformTektonSpec) DeepCopy() *IntegrationPlatformTektonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IntegrationPlatformTektonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]SourceSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 	if in.Traits != nil {
@@ -849,6 +1293,28 @@ func (in *IntegrationSpec) DeepCopyInto(out *IntegrationSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Libraries != nil {
+		in, out := &in.Libraries, &out.Libraries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Masking != nil {
+		in, out := &in.Masking, &out.Masking
+		*out = new(MaskingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -885,6 +1351,33 @@ func (in *IntegrationStatus) DeepCopyInto(out *IntegrationStatus) {
 		*out = make([]ConfigurationSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]RouteStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoutesUpdateTime != nil {
+		in, out := &in.RoutesUpdateTime, &out.RoutesUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ResourcesCheckTime != nil {
+		in, out := &in.ResourcesCheckTime, &out.ResourcesCheckTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PhaseTransitions != nil {
+		in, out := &in.PhaseTransitions, &out.PhaseTransitions
+		*out = make([]IntegrationPhaseTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -898,10 +1391,297 @@ func (in *IntegrationStatus) DeepCopy() *IntegrationStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Kamelet) DeepCopyInto(out *Kamelet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kamelet.
+func (in *Kamelet) DeepCopy() *Kamelet {
+	if in == nil {
+		return nil
+	}
+	out := new(Kamelet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Kamelet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletBinding) DeepCopyInto(out *KameletBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletBinding.
+func (in *KameletBinding) DeepCopy() *KameletBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KameletBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletBindingList) DeepCopyInto(out *KameletBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KameletBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletBindingList.
+func (in *KameletBindingList) DeepCopy() *KameletBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KameletBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletBindingSpec) DeepCopyInto(out *KameletBindingSpec) {
+	*out = *in
+	if in.Integration != nil {
+		in, out := &in.Integration, &out.Integration
+		*out = new(IntegrationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]Endpoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Sink.DeepCopyInto(&out.Sink)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletBindingSpec.
+func (in *KameletBindingSpec) DeepCopy() *KameletBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletBindingStatus) DeepCopyInto(out *KameletBindingStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletBindingStatus.
+func (in *KameletBindingStatus) DeepCopy() *KameletBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletDefinition) DeepCopyInto(out *KameletDefinition) {
+	*out = *in
+	if in.Required != nil {
+		in, out := &in.Required, &out.Required
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Properties != nil {
+		in, out := &in.Properties, &out.Properties
+		*out = make(map[string]KameletProperty, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletDefinition.
+func (in *KameletDefinition) DeepCopy() *KameletDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletList) DeepCopyInto(out *KameletList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Kamelet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletList.
+func (in *KameletList) DeepCopy() *KameletList {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KameletList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletProperty) DeepCopyInto(out *KameletProperty) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletProperty.
+func (in *KameletProperty) DeepCopy() *KameletProperty {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletProperty)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletSpec) DeepCopyInto(out *KameletSpec) {
+	*out = *in
+	in.Definition.DeepCopyInto(&out.Definition)
+	out.Template = in.Template
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletSpec.
+func (in *KameletSpec) DeepCopy() *KameletSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KameletStatus) DeepCopyInto(out *KameletStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KameletStatus.
+func (in *KameletStatus) DeepCopy() *KameletStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KameletStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaskingSpec) DeepCopyInto(out *MaskingSpec) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaskingSpec.
+func (in *MaskingSpec) DeepCopy() *MaskingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaskingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MavenSpec) DeepCopyInto(out *MavenSpec) {
 	*out = *in
 	in.Settings.DeepCopyInto(&out.Settings)
+	if in.AllowedGroups != nil {
+		in, out := &in.AllowedGroups, &out.AllowedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedGroups != nil {
+		in, out := &in.DeniedGroups, &out.DeniedGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -915,6 +1695,107 @@ func (in *MavenSpec) DeepCopy() *MavenSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Replay) DeepCopyInto(out *Replay) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Replay.
+func (in *Replay) DeepCopy() *Replay {
+	if in == nil {
+		return nil
+	}
+	out := new(Replay)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Replay) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplayList) DeepCopyInto(out *ReplayList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Replay, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplayList.
+func (in *ReplayList) DeepCopy() *ReplayList {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplayList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ReplayList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplaySpec) DeepCopyInto(out *ReplaySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplaySpec.
+func (in *ReplaySpec) DeepCopy() *ReplaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplayStatus) DeepCopyInto(out *ReplayStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplayStatus.
+func (in *ReplayStatus) DeepCopy() *ReplayStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplayStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceSpec) DeepCopyInto(out *ResourceSpec) {
 	*out = *in
@@ -932,6 +1813,22 @@ func (in *ResourceSpec) DeepCopy() *ResourceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteStatus) DeepCopyInto(out *RouteStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteStatus.
+func (in *RouteStatus) DeepCopy() *RouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceSpec) DeepCopyInto(out *SourceSpec) {
 	*out = *in
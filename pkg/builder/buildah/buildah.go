@@ -0,0 +1,72 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildah
+
+import (
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/platform"
+)
+
+func init() {
+	builder.RegisterSteps(Steps)
+	builder.RegisterPublishStrategy(v1alpha1.IntegrationPlatformBuildPublishStrategyBuildah, publishStrategy{})
+}
+
+type steps struct {
+	Publisher builder.Step
+}
+
+// Steps --
+var Steps = steps{
+	Publisher: builder.NewStep(
+		builder.ApplicationPublishPhase,
+		publisher,
+	),
+}
+
+// DefaultSteps --
+var DefaultSteps = []builder.Step{
+	builder.Steps.GenerateProject,
+	builder.Steps.GenerateProjectSettings,
+	builder.Steps.InjectDependencies,
+	builder.Steps.SanitizeDependencies,
+	builder.Steps.ComputeDependencies,
+	builder.Steps.VerifyArtifacts,
+	builder.Steps.IncrementalPackager,
+	Steps.Publisher,
+}
+
+// BuildDir is the directory where to build artifacts (shared with the Buildah pod)
+var BuildDir = "/workspace"
+
+// publishStrategy pushes the kit images to the platform registry from a Buildah pod
+type publishStrategy struct {
+}
+
+func (publishStrategy) Supports(p *v1alpha1.IntegrationPlatform) bool {
+	return platform.SupportsBuildahPublishStrategy(p)
+}
+
+func (publishStrategy) Steps(_ v1alpha1.TraitProfile) []builder.Step {
+	return DefaultSteps
+}
+
+func (publishStrategy) BuildDir() string {
+	return BuildDir
+}
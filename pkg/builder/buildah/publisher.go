@@ -0,0 +1,239 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildah
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"strconv"
+
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/proxy"
+	"github.com/apache/camel-k/pkg/util/tar"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Image is the Buildah image the kit images are built and pushed with
+var Image = "quay.io/buildah/stable:v1.9.0"
+
+const (
+	// storageDir is where Buildah stores the image layers, an empty dir shared by the build and push containers
+	storageDir = "/var/lib/containers"
+	// authFile is the registry secret, of type kubernetes.io/dockerconfigjson, used to pull and push images
+	authFile = "/buildah/secret/" + corev1.DockerConfigJsonKey
+)
+
+func publisher(ctx *builder.Context) error {
+	image := builder.PublishedImage(ctx)
+	baseDir, _ := path.Split(ctx.Archive)
+	contextDir := path.Join(baseDir, "context")
+	if err := tar.Extract(ctx.Archive, contextDir); err != nil {
+		return err
+	}
+
+	err := ioutil.WriteFile(path.Join(contextDir, "Dockerfile"), dockerfile(ctx), 0777)
+	if err != nil {
+		return err
+	}
+
+	pod := newBuildahPod(ctx, image, contextDir)
+
+	err = ctx.Client.Delete(ctx.C, &pod)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot delete buildah builder pod")
+	}
+
+	err = ctx.Client.Create(ctx.C, &pod)
+	if err != nil {
+		return errors.Wrap(err, "cannot create buildah builder pod")
+	}
+
+	err = kubernetes.WaitCondition(ctx.C, ctx.Client, &pod, func(obj interface{}) (bool, error) {
+		if val, ok := obj.(*corev1.Pod); ok {
+			if val.Status.Phase == corev1.PodSucceeded {
+				return true, nil
+			}
+			if val.Status.Phase == corev1.PodFailed {
+				return false, fmt.Errorf("build failed: %s", val.Status.Message)
+			}
+		}
+		return false, nil
+	}, ctx.Build.Platform.Build.Timeout.Duration)
+
+	if err != nil {
+		return err
+	}
+
+	ctx.Image = image
+	return nil
+}
+
+// newBuildahPod creates the pod building the kit image from the given context directory, in an init container,
+// and pushing it to the platform registry. The layers are kept in an empty dir, so that no cache volume is needed
+func newBuildahPod(ctx *builder.Context, image string, contextDir string) corev1.Pod {
+	registry := ctx.Build.Platform.Build.Registry
+
+	volumes := []corev1.Volume{
+		{
+			Name: "camel-k-builder",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: ctx.Build.Platform.Build.PersistentVolumeClaim,
+				},
+			},
+		},
+		{
+			Name: "buildah-storage",
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "camel-k-builder",
+			MountPath: BuildDir,
+		},
+		{
+			Name:      "buildah-storage",
+			MountPath: storageDir,
+		},
+	}
+
+	// the insecure registries are accessed over plain HTTP or with self-signed certificates
+	commonArgs := []string{
+		"--storage-driver=vfs",
+		"--tls-verify=" + strconv.FormatBool(!registry.Insecure),
+	}
+	if registry.Secret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: "buildah-secret",
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: registry.Secret,
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      "buildah-secret",
+			MountPath: path.Dir(authFile),
+		})
+		commonArgs = append(commonArgs, "--authfile="+authFile)
+	}
+
+	// Buildah honors the proxy environment variables when pulling and pushing images
+	envs := proxy.EnvVars(ctx.Build.Platform.Build.Proxy)
+
+	// Provide the build environment, e.g. custom truststores
+	secretVolumes, secretMounts := builder.BuildSecretVolumes(ctx.Build.Platform.Build)
+	volumes = append(volumes, secretVolumes...)
+	volumeMounts = append(volumeMounts, secretMounts...)
+	envs = append(envs, ctx.Build.Platform.Build.Env...)
+
+	budArgs := append([]string{"bud", "--isolation=chroot"}, commonArgs...)
+	budArgs = append(budArgs, "--file="+path.Join(contextDir, "Dockerfile"), "--tag="+image, contextDir)
+
+	pushArgs := append([]string{"push"}, commonArgs...)
+	pushArgs = append(pushArgs, image, "docker://"+image)
+
+	pod := corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ctx.Namespace,
+			Name:      "camel-k-" + ctx.ResourceName(),
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name:         "buildah-bud",
+					Image:        Image,
+					Command:      []string{"buildah"},
+					Args:         budArgs,
+					Env:          envs,
+					VolumeMounts: volumeMounts,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:         "buildah-push",
+					Image:        Image,
+					Command:      []string{"buildah"},
+					Args:         pushArgs,
+					Env:          envs,
+					VolumeMounts: volumeMounts,
+				},
+			},
+			RestartPolicy: corev1.RestartPolicyNever,
+			Volumes:       volumes,
+		},
+	}
+
+	var labelKey string
+	var labelValue string
+	if ctx.Namespace == platform.GetOperatorNamespace() {
+		// Check if the operator is running in the same namespace
+		labelKey = "camel.apache.org/component"
+		labelValue = "operator"
+	} else {
+		labelKey = "camel.apache.org/build"
+		labelValue = ctx.ResourceName()
+	}
+
+	// Co-locate with builder pod for sharing the volume
+	pod.Spec.Affinity = &corev1.Affinity{
+		PodAffinity: &corev1.PodAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+				{
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							labelKey: labelValue,
+						},
+					},
+					TopologyKey: "kubernetes.io/hostname",
+				},
+			},
+		},
+	}
+
+	return pod
+}
+
+// dockerfile returns the Dockerfile of the kit image, with the provenance labels of the kit
+func dockerfile(ctx *builder.Context) []byte {
+	labels := ctx.ImageLabels()
+
+	// #nosec G202
+	content := "FROM " + ctx.Image + "\n" +
+		"ADD . /deployments\n"
+	for _, name := range builder.SortedImageLabels(labels) {
+		content += fmt.Sprintf("LABEL %q=%q\n", name, labels[name])
+	}
+
+	return []byte(content)
+}
@@ -0,0 +1,103 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package buildah
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
+)
+
+func newTestContext() *builder.Context {
+	ctx := builder.Context{
+		Namespace: "ns",
+		Build: v1alpha1.BuildSpec{
+			Meta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "kit-1",
+			},
+		},
+	}
+	ctx.Build.Platform.Build.PersistentVolumeClaim = "camel-k"
+	ctx.Build.Platform.Build.Registry.Address = "registry:5000"
+	return &ctx
+}
+
+func TestNewBuildahPod(t *testing.T) {
+	ctx := newTestContext()
+
+	pod := newBuildahPod(ctx, "registry:5000/ns/camel-k-kits:1234", "/workspace/builder/context")
+
+	assert.Equal(t, "ns", pod.Namespace)
+	assert.Equal(t, "camel-k-kit-1", pod.Name)
+	assert.Len(t, pod.Spec.InitContainers, 1)
+	assert.Len(t, pod.Spec.Containers, 1)
+
+	bud := pod.Spec.InitContainers[0]
+	assert.Equal(t, Image, bud.Image)
+	assert.Equal(t, []string{"buildah"}, bud.Command)
+	assert.Equal(t, []string{
+		"bud",
+		"--isolation=chroot",
+		"--storage-driver=vfs",
+		"--tls-verify=true",
+		"--file=/workspace/builder/context/Dockerfile",
+		"--tag=registry:5000/ns/camel-k-kits:1234",
+		"/workspace/builder/context",
+	}, bud.Args)
+
+	push := pod.Spec.Containers[0]
+	assert.Equal(t, []string{
+		"push",
+		"--storage-driver=vfs",
+		"--tls-verify=true",
+		"registry:5000/ns/camel-k-kits:1234",
+		"docker://registry:5000/ns/camel-k-kits:1234",
+	}, push.Args)
+
+	// the image layers are shared by the build and push containers
+	storage := corev1.VolumeMount{Name: "buildah-storage", MountPath: storageDir}
+	assert.Contains(t, bud.VolumeMounts, storage)
+	assert.Contains(t, push.VolumeMounts, storage)
+	assert.Equal(t, "camel-k", pod.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.NotNil(t, pod.Spec.Volumes[1].EmptyDir)
+}
+
+func TestNewBuildahPodInsecureRegistryWithSecret(t *testing.T) {
+	ctx := newTestContext()
+	ctx.Build.Platform.Build.Registry.Insecure = true
+	ctx.Build.Platform.Build.Registry.Secret = "registry-secret"
+
+	pod := newBuildahPod(ctx, "registry:5000/ns/camel-k-kits:1234", "/workspace/builder/context")
+
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		assert.Contains(t, c.Args, "--tls-verify=false")
+		assert.Contains(t, c.Args, "--authfile=/buildah/secret/.dockerconfigjson")
+		assert.Contains(t, c.VolumeMounts, corev1.VolumeMount{Name: "buildah-secret", MountPath: "/buildah/secret"})
+	}
+
+	secret := pod.Spec.Volumes[len(pod.Spec.Volumes)-1]
+	assert.Equal(t, "buildah-secret", secret.Name)
+	assert.Equal(t, "registry-secret", secret.Secret.SecretName)
+}
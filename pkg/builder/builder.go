@@ -31,6 +31,10 @@ import (
 	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/cancellable"
 	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/maven"
+	"github.com/apache/camel-k/pkg/util/scan"
+
+	"github.com/pkg/errors"
 )
 
 type defaultBuilder struct {
@@ -71,7 +75,10 @@ func (b *defaultBuilder) Build(build v1alpha1.BuildSpec) v1alpha1.BuildStatus {
 
 	defer os.RemoveAll(builderPath)
 
-	catalog, err := camel.Catalog(b.ctx, b.client, build.Meta.Namespace, build.CamelVersion)
+	// the build runs in the kit namespace, unless a dedicated build namespace is configured
+	namespace := build.Platform.Build.BuildNamespace(build.Meta.Namespace)
+
+	catalog, err := camel.Catalog(b.ctx, b.client, namespace, build.CamelVersion)
 	if err != nil {
 		log.Error(err, "Error while loading Camel catalog")
 
@@ -81,9 +88,10 @@ func (b *defaultBuilder) Build(build v1alpha1.BuildSpec) v1alpha1.BuildStatus {
 
 	c := Context{
 		Client:    b.client,
+		C:         b.ctx,
 		Catalog:   catalog,
 		Path:      builderPath,
-		Namespace: build.Meta.Namespace,
+		Namespace: namespace,
 		Build:     build,
 		Image:     build.Platform.Build.BaseImage,
 	}
@@ -179,19 +187,29 @@ func (b *defaultBuilder) Build(build v1alpha1.BuildSpec) v1alpha1.BuildStatus {
 		result.BaseImage = c.BaseImage
 		result.Image = c.Image
 		result.PublicImage = c.PublicImage
+		result.ImageStreamTag = c.ImageStreamTag
+		result.ImageSize = imageSize(&c)
 
 		if c.Error != nil {
 			result.Error = c.Error.Error()
+			result.Reason = errorReason(c.Error)
 			result.Phase = v1alpha1.BuildPhaseFailed
 		}
 
 		result.Artifacts = make([]v1alpha1.Artifact, 0, len(c.Artifacts))
 		result.Artifacts = append(result.Artifacts, c.Artifacts...)
+		result.Warnings = c.Warnings
+		result.Vulnerabilities = c.Vulnerabilities
+		result.Conditions = c.Conditions
+		result.ImageLabels = c.Labels
 
 		b.log.Infof("build request %s executed in %s", build.Meta.Name, result.Duration)
 		b.log.Infof("dependencies: %s", build.Dependencies)
 		b.log.Infof("artifacts: %s", artifactIDs(c.Artifacts))
 		b.log.Infof("artifacts selected: %s", artifactIDs(c.SelectedArtifacts))
+		if len(c.Warnings) > 0 {
+			b.log.Infof("warnings: %s", c.Warnings)
+		}
 		b.log.Infof("requested image: %s", build.Image)
 		b.log.Infof("base image: %s", c.BaseImage)
 		b.log.Infof("resolved image: %s", c.Image)
@@ -202,3 +220,28 @@ func (b *defaultBuilder) Build(build v1alpha1.BuildSpec) v1alpha1.BuildStatus {
 
 	return result
 }
+
+// errorReason maps typed errors raised by the build steps to a build failure reason
+func errorReason(err error) string {
+	if merr, ok := errors.Cause(err).(*maven.Error); ok {
+		switch merr.Kind {
+		case maven.ErrorKindResolution:
+			return v1alpha1.BuildReasonDependencyResolution
+		case maven.ErrorKindCompilation:
+			return v1alpha1.BuildReasonCompilation
+		case maven.ErrorKindTimeout:
+			return v1alpha1.BuildReasonTimeout
+		}
+	}
+	if _, ok := errors.Cause(err).(*ClasspathConflictError); ok {
+		return v1alpha1.BuildReasonClasspathConflict
+	}
+	if _, ok := errors.Cause(err).(*ArtifactPolicyError); ok {
+		return v1alpha1.BuildReasonArtifactPolicy
+	}
+	if _, ok := errors.Cause(err).(*scan.Error); ok {
+		return v1alpha1.BuildReasonVulnerabilities
+	}
+
+	return ""
+}
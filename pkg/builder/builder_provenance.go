@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/audit"
+	"github.com/apache/camel-k/pkg/util/defaults"
+)
+
+// Provenance labels set on the published kit images, so that it can be checked how and from what
+// an image has been built, e.g. when an image is unexpectedly reused for a changed integration
+const (
+	// ImageLabelCreated holds the time the image has been built at, in RFC 3339 format
+	ImageLabelCreated = "org.opencontainers.image.created"
+	// ImageLabelOperatorVersion holds the version of the operator that built the image
+	ImageLabelOperatorVersion = "org.apache.camel.k.operator-version"
+	// ImageLabelCamelVersion holds the Camel version of the kit
+	ImageLabelCamelVersion = "org.apache.camel.k.camel-version"
+	// ImageLabelRuntimeVersion holds the Camel K runtime version of the kit
+	ImageLabelRuntimeVersion = "org.apache.camel.k.runtime-version"
+	// ImageLabelDependenciesDigest holds the digest of the artifacts added to the image
+	ImageLabelDependenciesDigest = "org.apache.camel.k.dependencies-digest"
+	// ImageLabelIntegrationDigest holds the digest of the integration the kit has been created for
+	ImageLabelIntegrationDigest = "org.apache.camel.k.integration-digest"
+)
+
+// ImageLabels returns the provenance labels of the image being built, they are computed once per build
+// so that all the publishers and the build status agree on the creation time
+func (c *Context) ImageLabels() map[string]string {
+	if c.Labels != nil {
+		return c.Labels
+	}
+
+	c.Labels = map[string]string{
+		ImageLabelCreated:            time.Now().UTC().Format(time.RFC3339),
+		ImageLabelOperatorVersion:    defaults.Version,
+		ImageLabelCamelVersion:       c.Build.CamelVersion,
+		ImageLabelRuntimeVersion:     c.Build.RuntimeVersion,
+		ImageLabelDependenciesDigest: DependenciesDigest(c.Artifacts),
+	}
+	// Kits created by users are not bound to any integration
+	if digest := c.Build.Meta.Annotations[audit.AnnotationDigest]; digest != "" {
+		c.Labels[ImageLabelIntegrationDigest] = digest
+	}
+
+	return c.Labels
+}
+
+// SortedImageLabels returns the names of the given labels in alphabetical order
+func SortedImageLabels(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DependenciesDigest computes the digest of the given artifacts, that is independent of their order
+// and accounts for their checksums, when known, so that rebuilt snapshots are told apart
+func DependenciesDigest(artifacts []v1alpha1.Artifact) string {
+	entries := make([]string, 0, len(artifacts))
+	for _, a := range artifacts {
+		entries = append(entries, a.ID+"@"+a.Checksum)
+	}
+	sort.Strings(entries)
+
+	sum := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// ContentDigest computes the digest of the content of the image being built, that is its base image,
+// its artifacts and its resources, so that the images of kits with the same content share the same tag
+func (c *Context) ContentDigest() string {
+	resources := make([]Resource, 0, len(c.Resources))
+	resources = append(resources, c.Resources...)
+	sort.SliceStable(resources, func(i, j int) bool {
+		return resources[i].Target < resources[j].Target
+	})
+
+	entries := []string{c.Image, DependenciesDigest(c.Artifacts)}
+	for _, r := range resources {
+		sum := sha256.Sum256(r.Content)
+		entries = append(entries, r.Target+"@"+hex.EncodeToString(sum[:]))
+	}
+
+	sum := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+
+	return hex.EncodeToString(sum[:])
+}
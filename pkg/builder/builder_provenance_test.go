@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/audit"
+	"github.com/apache/camel-k/pkg/util/defaults"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestImageLabels(t *testing.T) {
+	ctx := Context{
+		Build: v1alpha1.BuildSpec{
+			Meta: metav1.ObjectMeta{
+				Name: "kit-1",
+				Annotations: map[string]string{
+					audit.AnnotationDigest: "v123",
+				},
+			},
+			CamelVersion:   "2.23.2",
+			RuntimeVersion: "0.3.3",
+		},
+		Artifacts: []v1alpha1.Artifact{
+			{ID: "org.apache.camel:camel-core:jar:2.23.2", Checksum: "sha256:1234"},
+		},
+	}
+
+	labels := ctx.ImageLabels()
+	assert.Equal(t, defaults.Version, labels[ImageLabelOperatorVersion])
+	assert.Equal(t, "2.23.2", labels[ImageLabelCamelVersion])
+	assert.Equal(t, "0.3.3", labels[ImageLabelRuntimeVersion])
+	assert.Equal(t, DependenciesDigest(ctx.Artifacts), labels[ImageLabelDependenciesDigest])
+	assert.Equal(t, "v123", labels[ImageLabelIntegrationDigest])
+
+	_, err := time.Parse(time.RFC3339, labels[ImageLabelCreated])
+	assert.Nil(t, err)
+
+	// computed once per build
+	labels[ImageLabelCreated] = "2019-01-01T00:00:00Z"
+	assert.Equal(t, "2019-01-01T00:00:00Z", ctx.ImageLabels()[ImageLabelCreated])
+	assert.Equal(t, labels, ctx.Labels)
+}
+
+func TestImageLabelsForUserKit(t *testing.T) {
+	ctx := Context{}
+
+	labels := ctx.ImageLabels()
+	assert.NotContains(t, labels, ImageLabelIntegrationDigest)
+	assert.Equal(t, []string{
+		ImageLabelCamelVersion,
+		ImageLabelDependenciesDigest,
+		ImageLabelOperatorVersion,
+		ImageLabelRuntimeVersion,
+		ImageLabelCreated,
+	}, SortedImageLabels(labels))
+}
+
+func TestDependenciesDigest(t *testing.T) {
+	a := v1alpha1.Artifact{ID: "org.apache.camel:camel-core:jar:2.23.2", Checksum: "sha256:1234"}
+	b := v1alpha1.Artifact{ID: "org.apache.camel.k:camel-k-runtime-jvm:jar:0.3.3", Checksum: "sha256:5678"}
+
+	digest := DependenciesDigest([]v1alpha1.Artifact{a, b})
+	assert.Regexp(t, "^sha256:[0-9a-f]{64}$", digest)
+	assert.Equal(t, digest, DependenciesDigest([]v1alpha1.Artifact{b, a}))
+
+	b.Checksum = "sha256:9012"
+	assert.NotEqual(t, digest, DependenciesDigest([]v1alpha1.Artifact{a, b}))
+	assert.NotEqual(t, digest, DependenciesDigest([]v1alpha1.Artifact{a}))
+}
+
+func TestContentDigest(t *testing.T) {
+	ctx := Context{
+		Image: "adoptopenjdk/openjdk8:slim",
+		Artifacts: []v1alpha1.Artifact{
+			{ID: "org.apache.camel:camel-core:jar:2.23.2", Checksum: "sha256:1234"},
+		},
+		Resources: []Resource{
+			{Target: "resources/a.txt", Content: []byte("a")},
+			{Target: "resources/b.txt", Content: []byte("b")},
+		},
+	}
+
+	digest := ctx.ContentDigest()
+	assert.Regexp(t, "^[0-9a-f]{64}$", digest)
+
+	// independent of the order of the resources
+	ctx.Resources[0], ctx.Resources[1] = ctx.Resources[1], ctx.Resources[0]
+	assert.Equal(t, digest, ctx.ContentDigest())
+
+	ctx.Resources[0].Content = []byte("c")
+	assert.NotEqual(t, digest, ctx.ContentDigest())
+
+	ctx.Resources[0].Content = []byte("b")
+	ctx.Image = "adoptopenjdk/openjdk11:slim"
+	assert.NotEqual(t, digest, ctx.ContentDigest())
+}
+
+func TestPublishedImage(t *testing.T) {
+	ctx := Context{
+		Namespace: "ns",
+		Image:     "adoptopenjdk/openjdk8:slim",
+	}
+	ctx.Build.Platform.Build.Registry.Address = "registry:5000"
+
+	assert.Equal(t, "registry:5000/ns/camel-k-kits:"+ctx.ContentDigest(), PublishedImage(&ctx))
+
+	// kits with the same content share the image, whatever their namespace
+	ctx.Build.Platform.Build.Registry.Organization = "kits"
+	other := ctx
+	other.Namespace = "other"
+	assert.Equal(t, "registry:5000/kits/camel-k-kits:"+ctx.ContentDigest(), PublishedImage(&ctx))
+	assert.Equal(t, PublishedImage(&ctx), PublishedImage(&other))
+}
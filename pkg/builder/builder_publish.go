@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package builder
+
+import (
+	"fmt"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+// PublishStrategy builds the integration kit images and publishes them, e.g. through an OpenShift S2I build
+// or a Kaniko pod. Strategies are registered under the name set in the platform publish strategy field
+type PublishStrategy interface {
+	// Supports returns true if the strategy can publish the kit images with the given platform configuration
+	Supports(platform *v1alpha1.IntegrationPlatform) bool
+	// Steps returns the build steps, including the publishing ones, for the given trait profile
+	Steps(profile v1alpha1.TraitProfile) []Step
+	// BuildDir returns the directory where to build artifacts, or an empty string for the default one
+	BuildDir() string
+}
+
+// KitImagesRepository is the repository, in the registry organization, the kit images are pushed to. The images
+// being tagged by their content digest, the kits having the same content share the same image
+const KitImagesRepository = "camel-k-kits"
+
+var publishStrategies = make(map[v1alpha1.IntegrationPlatformBuildPublishStrategy]PublishStrategy)
+
+// RegisterPublishStrategy makes the given strategy available to the platforms with the given publish strategy,
+// it's meant to be called from the init function of the package providing the strategy
+func RegisterPublishStrategy(name v1alpha1.IntegrationPlatformBuildPublishStrategy, strategy PublishStrategy) {
+	if _, exists := publishStrategies[name]; exists {
+		panic(fmt.Errorf("the publish strategy is already registered: %s", name))
+	}
+	publishStrategies[name] = strategy
+}
+
+// PublishStrategyFor returns the publish strategy configured on the given platform, provided it's registered
+// and supports the platform configuration
+func PublishStrategyFor(platform *v1alpha1.IntegrationPlatform) (PublishStrategy, bool) {
+	strategy, ok := publishStrategies[platform.Spec.Build.PublishStrategy]
+	if !ok || !strategy.Supports(platform) {
+		return nil, false
+	}
+
+	return strategy, true
+}
+
+// PublishedImage returns the content-addressed image the kit is pushed to in the platform registry
+func PublishedImage(ctx *Context) string {
+	organization := ctx.Build.Platform.Build.Registry.Organization
+	if organization == "" {
+		organization = ctx.Namespace
+	}
+	return ctx.Build.Platform.Build.Registry.Address + "/" + organization + "/" + KitImagesRepository + ":" + ctx.ContentDigest()
+}
@@ -18,12 +18,22 @@ limitations under the License.
 package builder
 
 import (
+	"archive/zip"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
+	"hash"
+	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 
@@ -32,7 +42,11 @@ import (
 	"github.com/scylladb/go-set/strset"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/gradle"
 	"github.com/apache/camel-k/pkg/util/maven"
+	"github.com/apache/camel-k/pkg/util/proxy"
+	"github.com/apache/camel-k/pkg/util/scan"
 	"github.com/apache/camel-k/pkg/util/tar"
 
 	yaml2 "gopkg.in/yaml.v2"
@@ -42,18 +56,31 @@ import (
 
 var stepsByID = make(map[string]Step)
 
+// appCDSTrainingTimeout is how long the training launch of the GenerateAppCDSArchive step runs
+const appCDSTrainingTimeout = 30 * time.Second
+
 func init() {
 	RegisterSteps(Steps)
 }
 
 type steps struct {
-	GenerateProject         Step
-	GenerateProjectSettings Step
-	InjectDependencies      Step
-	SanitizeDependencies    Step
-	ComputeDependencies     Step
-	StandardPackager        Step
-	IncrementalPackager     Step
+	GenerateProject           Step
+	GenerateProjectSettings   Step
+	InjectDependencies        Step
+	SanitizeDependencies      Step
+	ComputeDependencies       Step
+	ComputeGradleDependencies Step
+	VerifyArtifacts           Step
+	DetectClasspathConflicts  Step
+	StandardPackager          Step
+	IncrementalPackager       Step
+	BaseKitPackager           Step
+	ScanImage                 Step
+	InjectBuildEnvironment    Step
+	DeduplicateArtifacts      Step
+	TrimJRE                   Step
+	ExcludeProvidedArtifacts  Step
+	GenerateAppCDSArchive     Step
 }
 
 // Steps --
@@ -78,6 +105,18 @@ var Steps = steps{
 		ProjectBuildPhase,
 		computeDependencies,
 	),
+	ComputeGradleDependencies: NewStep(
+		ProjectBuildPhase,
+		computeGradleDependencies,
+	),
+	VerifyArtifacts: NewStep(
+		ProjectBuildPhase+1,
+		verifyArtifacts,
+	),
+	DetectClasspathConflicts: NewStep(
+		ProjectBuildPhase+2,
+		detectClasspathConflicts,
+	),
 	StandardPackager: NewStep(
 		ApplicationPackagePhase,
 		standardPackager,
@@ -86,6 +125,34 @@ var Steps = steps{
 		ApplicationPackagePhase,
 		incrementalPackager,
 	),
+	BaseKitPackager: NewStep(
+		ApplicationPackagePhase,
+		baseKitPackager,
+	),
+	ScanImage: NewStep(
+		ApplicationPackagePhase+5,
+		scanImage,
+	),
+	InjectBuildEnvironment: NewStep(
+		ProjectGenerationPhase+4,
+		injectBuildEnvironment,
+	),
+	DeduplicateArtifacts: NewStep(
+		ProjectBuildPhase+3,
+		deduplicateArtifacts,
+	),
+	TrimJRE: NewStep(
+		ProjectBuildPhase+4,
+		trimJRE,
+	),
+	ExcludeProvidedArtifacts: NewStep(
+		ProjectBuildPhase+5,
+		excludeProvidedArtifacts,
+	),
+	GenerateAppCDSArchive: NewStep(
+		ProjectBuildPhase+6,
+		generateAppCDSArchive,
+	),
 }
 
 // RegisterSteps --
@@ -122,6 +189,16 @@ func generateProject(ctx *Context) error {
 	}
 
 	ctx.Maven.Project = p
+
+	//
+	// set-up java toolchain
+	//
+	if err := camel.ValidateJavaVersion(ctx.Catalog.Version, ctx.Build.Platform.Build.JavaVersion); err != nil {
+		return err
+	}
+
+	ctx.Maven.Project.SetJavaVersion(ctx.Build.Platform.Build.JavaVersion)
+
 	//
 	// set-up dependencies
 	//
@@ -154,6 +231,14 @@ func generateProject(ctx *Context) error {
 			artifactID := strings.Replace(d, "runtime:", "camel-k-runtime-", 1)
 
 			ctx.Maven.Project.AddDependencyGAV("org.apache.camel.k", artifactID, ctx.Build.RuntimeVersion)
+		case strings.HasPrefix(d, "github:"):
+			dep, err := ParseGitHubDependency(d)
+			if err != nil {
+				return err
+			}
+
+			ctx.Maven.Project.AddDependency(dep)
+			ctx.Maven.Project.AddRepository(maven.NewJitPackRepository())
 		case strings.HasPrefix(d, "bom:"):
 			// no-op
 		default:
@@ -180,6 +265,58 @@ func generateProjectSettings(ctx *Context) error {
 	}
 	if val != "" {
 		ctx.Maven.SettingsData = []byte(val)
+		return nil
+	}
+
+	// user provided settings are expected to declare the proxies
+	ctx.Maven.Settings, err = proxy.MavenSettings(ctx.Build.Platform.Build.Proxy)
+
+	return err
+}
+
+// injectBuildEnvironment provides the build environment variables and secrets to the build tools run by the operator,
+// that is with the routine strategy, as they are set on the builder containers otherwise. The secrets are written in
+// the build directory, the references to their mount paths in the environment variables being rewritten accordingly.
+func injectBuildEnvironment(ctx *Context) error {
+	replacements := make([]string, 0, 2*len(ctx.Build.Platform.Build.Secrets))
+	for _, s := range ctx.Build.Platform.Build.Secrets {
+		secret, err := kubernetes.GetSecret(ctx.C, ctx.Client, s.Name, ctx.Namespace)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read build secret %s", s.Name)
+		}
+
+		dir := path.Join(ctx.Path, "secrets", s.Name)
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+		for key, value := range secret.Data {
+			if err := ioutil.WriteFile(path.Join(dir, key), value, 0600); err != nil {
+				return err
+			}
+		}
+
+		replacements = append(replacements, s.MountPath, dir)
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	for _, e := range ctx.Build.Platform.Build.Env {
+		value := e.Value
+		if e.ValueFrom != nil {
+			var err error
+			switch {
+			case e.ValueFrom.SecretKeyRef != nil:
+				value, err = kubernetes.GetSecretRefValue(ctx.C, ctx.Client, ctx.Namespace, e.ValueFrom.SecretKeyRef)
+			case e.ValueFrom.ConfigMapKeyRef != nil:
+				value, err = kubernetes.GetConfigMapRefValue(ctx.C, ctx.Client, ctx.Namespace, e.ValueFrom.ConfigMapKeyRef)
+			default:
+				err = fmt.Errorf("unsupported value source for build environment variable %s", e.Name)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		ctx.Env = append(ctx.Env, e.Name+"="+replacer.Replace(value))
 	}
 
 	return nil
@@ -266,14 +403,83 @@ func computeDependencies(ctx *Context) error {
 	mc := maven.NewContext(path.Join(ctx.Path, "maven"), ctx.Maven.Project)
 	mc.Settings = ctx.Maven.Settings
 	mc.SettingsData = ctx.Maven.SettingsData
+	mc.Version = ctx.Build.Platform.Build.Maven.Version
+	mc.Timeout = ctx.Build.Platform.Build.Timeout.Duration
+	mc.Env = ctx.Env
 	mc.AddArguments(maven.ExtraOptions(ctx.Build.Platform.Build.LocalRepository)...)
+	if ctx.Build.Platform.Build.Maven.VerifyChecksums {
+		// fail the build if the checksums of downloaded artifacts do not match
+		mc.AddArgument("--strict-checksums")
+	}
+	if ctx.Build.Platform.FIPS {
+		// keep maven from falling back to the MD5 checksums
+		mc.AddArgument("-Daether.checksums.algorithms=SHA-512,SHA-256,SHA-1")
+	}
 	mc.AddArgumentf("org.apache.camel.k:camel-k-maven-plugin:%s:generate-dependency-list", ctx.Build.RuntimeVersion)
 
 	if err := maven.Run(mc); err != nil {
 		return errors.Wrap(err, "failure while determining classpath")
 	}
 
-	dependencies := path.Join(mc.Path, "target", "dependencies.yaml")
+	return readDependencies(ctx, path.Join(mc.Path, "target", "dependencies.yaml"))
+}
+
+func computeGradleDependencies(ctx *Context) error {
+	if ctx.Build.Platform.Build.Maven.VerifyChecksums {
+		// gradle does not verify the artifacts against the repositories checksums as maven --strict-checksums does,
+		// and it does not keep the checksums alongside the artifacts for verifyArtifacts to check them
+		return &ArtifactPolicyError{Violations: []string{
+			"checksum verification is not supported by the gradle build tool, the maven build tool must be used",
+		}}
+	}
+
+	project := gradle.NewProjectFromMaven(ctx.Maven.Project)
+	settings, err := gradleSettings(ctx)
+	if err != nil {
+		return err
+	}
+	if settings != nil {
+		project.ApplySettings(*settings)
+	}
+
+	gc := gradle.NewContext(path.Join(ctx.Path, "gradle"), project)
+	gc.Env = ctx.Env
+	localRepo := ctx.Build.Platform.Build.LocalRepository
+	if _, err := os.Stat(localRepo); err == nil {
+		// the locks are kept alongside the local repository, so that the following builds of the
+		// same dependencies resolve the locked versions
+		digest, err := project.Digest()
+		if err != nil {
+			return err
+		}
+		gc.LockDir = path.Join(localRepo, ".gradle-locks", digest)
+	}
+	gc.AddArguments(gradle.ExtraOptions(localRepo)...)
+	gc.AddArgument(gradle.DependencyListTask)
+
+	if err := gradle.Run(gc); err != nil {
+		return errors.Wrap(err, "failure while determining classpath")
+	}
+
+	return readDependencies(ctx, path.Join(gc.Path, "build", "dependencies.yaml"))
+}
+
+// gradleSettings returns the Maven settings the Gradle project is configured with, that are the ones provided
+// by the user if any, or the ones generated for the platform proxies otherwise
+func gradleSettings(ctx *Context) (*maven.Settings, error) {
+	if len(ctx.Maven.SettingsData) > 0 {
+		settings, err := maven.ParseSettings(ctx.Maven.SettingsData)
+		if err != nil {
+			return nil, err
+		}
+		return &settings, nil
+	}
+
+	return ctx.Maven.Settings, nil
+}
+
+// readDependencies loads the list of resolved artifacts from a dependencies.yaml file
+func readDependencies(ctx *Context, dependencies string) error {
 	content, err := ioutil.ReadFile(dependencies)
 	if err != nil {
 		return err
@@ -303,6 +509,339 @@ func computeDependencies(ctx *Context) error {
 	return nil
 }
 
+func verifyArtifacts(ctx *Context) error {
+	spec := ctx.Build.Platform.Build.Maven
+	violations := make([]string, 0)
+
+	for _, a := range ctx.Artifacts {
+		gav, err := maven.ParseGAV(a.ID)
+		if err != nil {
+			return err
+		}
+
+		if len(spec.AllowedGroups) > 0 && !matchesGroup(spec.AllowedGroups, gav.GroupID) {
+			violations = append(violations, fmt.Sprintf("artifact %s is not in an allowed group", a.ID))
+			continue
+		}
+		if matchesGroup(spec.DeniedGroups, gav.GroupID) {
+			violations = append(violations, fmt.Sprintf("artifact %s is in a denied group", a.ID))
+			continue
+		}
+
+		if spec.VerifyChecksums && a.Location != "" {
+			err := verifyChecksum(a.Location, checksumAlgorithms(ctx.Build.Platform.FIPS))
+			if err == errNoChecksum && !spec.StrictChecksums {
+				ctx.Warnings = append(ctx.Warnings, fmt.Sprintf("artifact %s: %s", a.ID, err.Error()))
+			} else if err != nil {
+				violations = append(violations, fmt.Sprintf("artifact %s: %s", a.ID, err.Error()))
+			}
+		}
+	}
+
+	if len(violations) > 0 {
+		return &ArtifactPolicyError{Violations: violations}
+	}
+
+	return nil
+}
+
+// matchesGroup checks if the given groupId matches any of the patterns, a pattern
+// ending with ".*" matches the group itself and all its sub groups
+func matchesGroup(patterns []string, groupID string) bool {
+	for _, p := range patterns {
+		if p == groupID {
+			return true
+		}
+		if strings.HasSuffix(p, ".*") {
+			prefix := strings.TrimSuffix(p, ".*")
+			if groupID == prefix || strings.HasPrefix(groupID, prefix+".") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// matchesArtifact checks if the given artifact matches any of the groupId:artifactId patterns, the groupId
+// follows the same rules as matchesGroup and the artifactId may be a glob like camel-*
+func matchesArtifact(patterns []string, gav maven.Dependency) bool {
+	for _, p := range patterns {
+		groupID := p
+		artifactID := "*"
+		if i := strings.Index(p, ":"); i >= 0 {
+			groupID = p[:i]
+			artifactID = p[i+1:]
+		}
+		if !matchesGroup([]string{groupID}, gav.GroupID) {
+			continue
+		}
+		if matched, err := path.Match(artifactID, gav.ArtifactID); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// excludeProvidedArtifacts removes the artifacts shipped by the base image from the ones packaged in the
+// kit image, the integrations find them in the classpath provided by the platform
+func excludeProvidedArtifacts(ctx *Context) error {
+	patterns := ctx.Build.Platform.Build.Provided.Artifacts
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	checksums := strset.New()
+	artifacts := make([]v1alpha1.Artifact, 0, len(ctx.Artifacts))
+	for _, a := range ctx.Artifacts {
+		gav, err := maven.ParseGAV(a.ID)
+		if err != nil {
+			return err
+		}
+		if matchesArtifact(patterns, gav) {
+			if a.Checksum != "" {
+				checksums.Add(a.Checksum)
+			}
+			continue
+		}
+		artifacts = append(artifacts, a)
+	}
+
+	// the artifacts de-duplicated against a provided one are not packaged either
+	ctx.Artifacts = make([]v1alpha1.Artifact, 0, len(artifacts))
+	for _, a := range artifacts {
+		if a.Checksum == "" || !checksums.Has(a.Checksum) {
+			ctx.Artifacts = append(ctx.Artifacts, a)
+		}
+	}
+
+	return nil
+}
+
+// checksumAlgorithm is an algorithm of the checksums stored by maven alongside the artifacts
+type checksumAlgorithm struct {
+	extension string
+	hash      func() hash.Hash
+}
+
+// checksumAlgorithms returns the algorithms of the checksums the artifacts are verified with, by order of preference.
+// The FIPS mode prefers the SHA-2 checksums, MD5 checksums being never used.
+func checksumAlgorithms(fips bool) []checksumAlgorithm {
+	if fips {
+		return []checksumAlgorithm{
+			{extension: "sha512", hash: sha512.New},
+			{extension: "sha256", hash: sha256.New},
+			{extension: "sha1", hash: sha1.New},
+		}
+	}
+	return []checksumAlgorithm{
+		{extension: "sha1", hash: sha1.New},
+	}
+}
+
+// errNoChecksum is returned when maven hasn't stored any checksum for an artifact
+var errNoChecksum = errors.New("no checksum found")
+
+// verifyChecksum compares the digest of the given file with the checksum stored alongside it by maven in the
+// local repository, using the first algorithm a checksum is found for
+func verifyChecksum(location string, algorithms []checksumAlgorithm) error {
+	var expected []byte
+	var algorithm checksumAlgorithm
+	for _, a := range algorithms {
+		data, err := ioutil.ReadFile(location + "." + a.extension)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		expected = data
+		algorithm = a
+		break
+	}
+	if expected == nil {
+		return errNoChecksum
+	}
+
+	content, err := ioutil.ReadFile(location)
+	if err != nil {
+		return err
+	}
+
+	// the checksum file may contain the file name after the digest
+	fields := strings.Fields(string(expected))
+	if len(fields) == 0 {
+		return errors.New("empty checksum")
+	}
+
+	h := algorithm.hash()
+	if _, err := h.Write(content); err != nil {
+		return err
+	}
+	actual := fmt.Sprintf("%x", h.Sum(nil))
+	if !strings.EqualFold(fields[0], actual) {
+		return fmt.Errorf("checksum mismatch (expected=%s, actual=%s)", fields[0], actual)
+	}
+
+	return nil
+}
+
+func detectClasspathConflicts(ctx *Context) error {
+	policy := ctx.Build.Platform.Build.ClasspathConflicts
+	if policy == v1alpha1.IntegrationPlatformClasspathPolicyIgnore {
+		return nil
+	}
+
+	conflicts, err := findClasspathConflicts(ctx.Artifacts)
+	if err != nil {
+		return errors.Wrap(err, "failure while detecting classpath conflicts")
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	if policy == v1alpha1.IntegrationPlatformClasspathPolicyFail {
+		return &ClasspathConflictError{Conflicts: conflicts}
+	}
+
+	ctx.Warnings = append(ctx.Warnings, conflicts...)
+
+	return nil
+}
+
+// findClasspathConflicts reports artifacts resolved with multiple versions and
+// classes provided by more than one artifact
+func findClasspathConflicts(artifacts []v1alpha1.Artifact) ([]string, error) {
+	conflicts := make([]string, 0)
+
+	versions := make(map[string][]string)
+	owners := make(map[string][]string)
+
+	for _, a := range artifacts {
+		gav, err := maven.ParseGAV(a.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		key := gav.GroupID + ":" + gav.ArtifactID
+		if gav.Classifier != "" {
+			key += ":" + gav.Classifier
+		}
+		versions[key] = append(versions[key], gav.Version)
+
+		if !strings.HasSuffix(a.Location, ".jar") {
+			continue
+		}
+
+		classes, err := listClasses(a.Location)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range classes {
+			owners[c] = append(owners[c], a.ID)
+		}
+	}
+
+	for _, key := range sortedKeys(versions) {
+		if v := strset.New(versions[key]...); v.Size() > 1 {
+			list := v.List()
+			sort.Strings(list)
+
+			conflicts = append(conflicts, fmt.Sprintf("conflicting versions of %s: %s", key, strings.Join(list, ", ")))
+		}
+	}
+
+	// group duplicate classes by the set of artifacts providing them
+	duplicates := make(map[string][]string)
+	for _, class := range sortedKeys(owners) {
+		if len(owners[class]) > 1 {
+			key := strings.Join(owners[class], ", ")
+			duplicates[key] = append(duplicates[key], class)
+		}
+	}
+
+	for _, key := range sortedKeys(duplicates) {
+		classes := duplicates[key]
+		conflicts = append(conflicts, fmt.Sprintf("%d duplicate classes (e.g. %s) provided by: %s", len(classes), classes[0], key))
+	}
+
+	return conflicts, nil
+}
+
+// listClasses returns the fully qualified name of the classes contained in the given jar
+func listClasses(jar string) ([]string, error) {
+	r, err := zip.OpenReader(jar)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	classes := make([]string, 0)
+	for _, f := range r.File {
+		if !strings.HasSuffix(f.Name, ".class") || strings.HasPrefix(f.Name, "META-INF/") {
+			continue
+		}
+
+		name := strings.TrimSuffix(f.Name, ".class")
+		if name == "module-info" || strings.HasSuffix(name, "package-info") {
+			continue
+		}
+
+		classes = append(classes, strings.Replace(name, "/", ".", -1))
+	}
+
+	return classes, nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// scanImage scans the content of the image before it's published, that is the base image, which has already
+// been published, and the packaged layer built on top of it
+func scanImage(ctx *Context) error {
+	spec := ctx.Build.Platform.Build.ImageScan
+	if spec == nil {
+		return nil
+	}
+
+	sc := scan.Context{
+		Image:    ctx.BaseImage,
+		Endpoint: spec.Endpoint,
+		Insecure: ctx.Build.Platform.Build.Registry.Insecure,
+		Client:   proxy.NewClient(ctx.Build.Platform.Build.Proxy),
+	}
+	if ctx.Archive != "" {
+		sc.Path = path.Join(ctx.Path, "scan")
+		if err := tar.Extract(ctx.Archive, sc.Path); err != nil {
+			return errors.Wrap(err, "failure while extracting the image content to scan")
+		}
+	}
+	for _, a := range ctx.SelectedArtifacts {
+		sc.Artifacts = append(sc.Artifacts, a.ID)
+	}
+
+	summary, err := scan.Run(ctx.C, sc)
+	if err != nil {
+		return errors.Wrap(err, "failure while scanning image")
+	}
+
+	ctx.Vulnerabilities = summary
+
+	if spec.Severity != "" && summary.Exceeds(spec.Severity) > 0 {
+		return &scan.Error{Threshold: spec.Severity, Summary: summary}
+	}
+
+	return nil
+}
+
 // ArtifactsSelector --
 type ArtifactsSelector func(ctx *Context) error
 
@@ -315,7 +854,7 @@ func standardPackager(ctx *Context) error {
 }
 
 func incrementalPackager(ctx *Context) error {
-	if ctx.HasRequiredImage() {
+	if ctx.HasRequiredImage() || !ctx.Build.IsIncremental() {
 		//
 		// If the build requires a specific image, don't try to determine the
 		// base image using artifact so just use the standard packages
@@ -328,6 +867,26 @@ func incrementalPackager(ctx *Context) error {
 		return err
 	}
 
+	return imagePackager(ctx, images)
+}
+
+// baseKitPackager layers the artifacts missing from the image of the base kit selected by the operator,
+// falling back to the standard packager when the resolved artifacts have diverged from the base kit ones
+func baseKitPackager(ctx *Context) error {
+	base := ctx.Build.BaseKit
+	if ctx.HasRequiredImage() || !ctx.Build.IsIncremental() || base == nil || base.Image == "" {
+		return standardPackager(ctx)
+	}
+
+	return imagePackager(ctx, []publishedImage{{
+		Image:        base.Image,
+		Artifacts:    base.Artifacts,
+		Dependencies: base.Dependencies,
+	}})
+}
+
+// imagePackager packages the artifacts that are not part of the best of the given images, that is used as base image
+func imagePackager(ctx *Context, images []publishedImage) error {
 	return packager(ctx, func(ctx *Context) error {
 		ctx.SelectedArtifacts = ctx.Artifacts
 
@@ -342,13 +901,190 @@ func incrementalPackager(ctx *Context) error {
 
 			ctx.BaseImage = bestImage.Image
 			ctx.Image = bestImage.Image
-			ctx.SelectedArtifacts = selectedArtifacts
+			ctx.SelectedArtifacts = reuseBaseImageArtifacts(ctx, bestImage.Artifacts, selectedArtifacts)
 		}
 
 		return nil
 	})
 }
 
+// deduplicateArtifacts computes the checksums of the artifacts, the ones having the same content,
+// e.g. relocated artifacts, sharing the same target so that they're packaged only once
+func deduplicateArtifacts(ctx *Context) error {
+	targets := make(map[string]string)
+	for i, a := range ctx.Artifacts {
+		checksum, err := fileChecksum(a.Location)
+		if err != nil {
+			return errors.Wrapf(err, "cannot compute the checksum of artifact %s", a.ID)
+		}
+
+		ctx.Artifacts[i].Checksum = checksum
+		if target, ok := targets[checksum]; ok {
+			ctx.Artifacts[i].Target = target
+		} else {
+			targets[checksum] = a.Target
+		}
+	}
+
+	return nil
+}
+
+// reuseBaseImageArtifacts excludes the selected artifacts whose content is already in the base image
+// under a different identity, pointing them to the base image ones
+func reuseBaseImageArtifacts(ctx *Context, base []v1alpha1.Artifact, selected []v1alpha1.Artifact) []v1alpha1.Artifact {
+	targets := make(map[string]string)
+	for _, a := range base {
+		if a.Checksum != "" {
+			targets[a.Checksum] = a.Target
+		}
+	}
+	if len(targets) == 0 {
+		return selected
+	}
+
+	result := make([]v1alpha1.Artifact, 0, len(selected))
+	for _, a := range selected {
+		target, ok := targets[a.Checksum]
+		if a.Checksum == "" || !ok {
+			result = append(result, a)
+			continue
+		}
+		for i := range ctx.Artifacts {
+			if ctx.Artifacts[i].Checksum == a.Checksum {
+				ctx.Artifacts[i].Target = target
+			}
+		}
+	}
+
+	return result
+}
+
+func fileChecksum(location string) (string, error) {
+	file, err := os.Open(location)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// trimJRE creates a JRE containing only the modules required by the artifacts, to be used in place of the one
+// of the base image. The build goes on with a warning when the JRE cannot be created, e.g. if the JDK tools are
+// not available.
+func trimJRE(ctx *Context) error {
+	jars := make([]string, 0, len(ctx.Artifacts))
+	for _, a := range ctx.Artifacts {
+		if strings.HasSuffix(a.Location, ".jar") {
+			jars = append(jars, a.Location)
+		}
+	}
+
+	args := []string{"--print-module-deps", "--ignore-missing-deps", "--multi-release", "base", "-q", "--class-path", strings.Join(jars, ":")}
+	out, err := exec.CommandContext(ctx.C, "jdeps", append(args, jars...)...).Output()
+	if err != nil {
+		ctx.Warnings = append(ctx.Warnings, fmt.Sprintf("cannot detect the modules required by the artifacts, the JRE is not trimmed: %v", err))
+		return nil
+	}
+
+	modules := strset.New(trimmedJREModules...)
+	for _, m := range strings.Split(strings.TrimSpace(string(out)), ",") {
+		if m != "" {
+			modules.Add(m)
+		}
+	}
+	names := modules.List()
+	sort.Strings(names)
+
+	jre := path.Join(ctx.Path, TrimmedJREDir)
+	cmd := exec.CommandContext(ctx.C, "jlink", "--add-modules", strings.Join(names, ","),
+		"--strip-debug", "--no-man-pages", "--no-header-files", "--compress=2", "--output", jre)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		ctx.Warnings = append(ctx.Warnings, fmt.Sprintf("cannot create the trimmed JRE: %v: %s", err, strings.TrimSpace(string(out))))
+		return nil
+	}
+
+	ctx.TrimmedJRE = jre
+
+	return nil
+}
+
+// generateAppCDSArchive runs a training launch of the integration runtime to record the classes it loads, then
+// dumps them into a class data sharing archive, to be mapped by the JVM at startup instead of loading and
+// verifying the classes again. The training runs with the JRE of the image when trimmed, the JVM ignoring
+// the archive at runtime if it's been created by another JVM or for another classpath. The build goes on
+// with a warning when the archive cannot be created.
+func generateAppCDSArchive(ctx *Context) error {
+	if ctx.Build.Platform.Build.BuildStrategy == v1alpha1.IntegrationPlatformBuildStrategyRoutine {
+		return errors.New("the class data sharing archive cannot be created by builds running in the operator")
+	}
+
+	java := "java"
+	if ctx.TrimmedJRE != "" {
+		java = path.Join(ctx.TrimmedJRE, "bin", "java")
+	}
+
+	// lay out the artifacts as in the image, so that the archive records the classpath of the integration
+	dir := path.Join(ctx.Path, "appcds")
+	for _, a := range ctx.Artifacts {
+		target := path.Join(dir, a.Target)
+		if err := os.MkdirAll(path.Dir(target), 0777); err != nil {
+			return err
+		}
+		if err := os.Symlink(a.Location, target); err != nil && !os.IsExist(err) {
+			return err
+		}
+	}
+
+	classpath := strings.Join(AppCDSClasspath(ctx.Artifacts), ":")
+	classList := path.Join(dir, "classes.lst")
+
+	// the runtime keeps running once started, so the training launch is stopped after a while, the
+	// loaded classes being recorded as they are loaded
+	training, cancel := context.WithTimeout(ctx.C, appCDSTrainingTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(training, java, "-Xshare:off", "-XX:DumpLoadedClassList="+classList, "-cp", classpath, AppCDSMainClass)
+	cmd.Dir = dir
+	_ = cmd.Run()
+
+	if info, err := os.Stat(classList); err != nil || info.Size() == 0 {
+		ctx.Warnings = append(ctx.Warnings, "the training launch hasn't recorded any class, the class data sharing archive is not created")
+		return nil
+	}
+
+	archive := path.Join(dir, path.Base(AppCDSArchiveFile))
+	cmd = exec.CommandContext(ctx.C, java, "-Xshare:dump", "-XX:SharedClassListFile="+classList, "-XX:SharedArchiveFile="+archive, "-cp", classpath)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		ctx.Warnings = append(ctx.Warnings, fmt.Sprintf("cannot create the class data sharing archive: %v: %s", err, strings.TrimSpace(string(out))))
+		return nil
+	}
+
+	ctx.AppCDSArchive = archive
+
+	return nil
+}
+
+// AppCDSClasspath returns the classpath the class data sharing archive is created for, i.e. the targets of the
+// jar artifacts in lexical order. The JVM maps the archive only if the integration classpath starts with it.
+func AppCDSClasspath(artifacts []v1alpha1.Artifact) []string {
+	set := strset.New()
+	for _, a := range artifacts {
+		if strings.HasSuffix(a.Target, ".jar") {
+			set.Add(a.Target)
+		}
+	}
+	classpath := set.List()
+	sort.Strings(classpath)
+
+	return classpath
+}
+
 // ClassPathPackager --
 func packager(ctx *Context, selector ArtifactsSelector) error {
 	err := selector(ctx)
@@ -370,7 +1106,14 @@ func packager(ctx *Context, selector ArtifactsSelector) error {
 	}
 	defer tarAppender.Close()
 
+	added := make(map[string]bool)
 	for _, entry := range ctx.SelectedArtifacts {
+		// deduplicated artifacts share the same target
+		if added[entry.Target] {
+			continue
+		}
+		added[entry.Target] = true
+
 		_, tarFileName := path.Split(entry.Target)
 		tarFilePath := path.Dir(entry.Target)
 
@@ -386,6 +1129,18 @@ func packager(ctx *Context, selector ArtifactsSelector) error {
 		}
 	}
 
+	if ctx.TrimmedJRE != "" {
+		if err := tarAppender.AddDirectory(ctx.TrimmedJRE, TrimmedJREDir); err != nil {
+			return err
+		}
+	}
+
+	if ctx.AppCDSArchive != "" {
+		if _, err := tarAppender.AddFileWithName(path.Base(AppCDSArchiveFile), ctx.AppCDSArchive, path.Dir(AppCDSArchiveFile)); err != nil {
+			return err
+		}
+	}
+
 	ctx.Archive = tarFileName
 
 	return nil
@@ -403,7 +1158,7 @@ func listPublishedImages(context *Context) ([]publishedImage, error) {
 	for _, item := range list.Items {
 		kit := item
 
-		if kit.Status.Phase != v1alpha1.IntegrationKitPhaseReady {
+		if kit.Status.Phase != v1alpha1.IntegrationKitPhaseReady || kit.IsInvalidated() {
 			continue
 		}
 		if kit.Status.CamelVersion != context.Catalog.Version {
@@ -415,7 +1170,10 @@ func listPublishedImages(context *Context) ([]publishedImage, error) {
 		if kit.Status.Phase != v1alpha1.IntegrationKitPhaseReady || kit.Labels == nil {
 			continue
 		}
-		if ctxType, present := kit.Labels["camel.apache.org/kit.type"]; !present || ctxType != v1alpha1.IntegrationKitTypePlatform {
+		// library kits are layered too, so that integrations reusing shared route libraries
+		// only add their own artifacts on top of the library image
+		if ctxType, present := kit.Labels["camel.apache.org/kit.type"]; !present ||
+			(ctxType != v1alpha1.IntegrationKitTypePlatform && ctxType != v1alpha1.IntegrationKitTypeLibrary) {
 			continue
 		}
 
@@ -18,6 +18,18 @@ limitations under the License.
 package builder
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
@@ -27,6 +39,7 @@ import (
 	"github.com/apache/camel-k/pkg/util/cancellable"
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/maven"
+	"github.com/apache/camel-k/pkg/util/scan"
 	"github.com/apache/camel-k/pkg/util/test"
 
 	"github.com/stretchr/testify/assert"
@@ -205,6 +218,194 @@ func TestMavenSettingsFromSecret(t *testing.T) {
 	assert.Equal(t, []byte("setting-data"), ctx.Maven.SettingsData)
 }
 
+func TestMavenSettingsWithProxy(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	ctx := Context{
+		Client:    c,
+		Namespace: "ns",
+		Build: v1alpha1.BuildSpec{
+			Platform: v1alpha1.IntegrationPlatformSpec{
+				Build: v1alpha1.IntegrationPlatformBuildSpec{
+					Proxy: v1alpha1.IntegrationPlatformProxySpec{
+						HTTPSProxy: "http://proxy.example.com:3128",
+						NoProxy:    ".svc",
+					},
+				},
+			},
+		},
+	}
+
+	err = generateProjectSettings(&ctx)
+	assert.Nil(t, err)
+
+	assert.Nil(t, ctx.Maven.SettingsData)
+	assert.NotNil(t, ctx.Maven.Settings)
+	assert.Equal(t, []maven.Proxy{
+		{
+			ID:            "camel-k-https-proxy",
+			Active:        true,
+			Protocol:      "https",
+			Host:          "proxy.example.com",
+			Port:          3128,
+			NonProxyHosts: "*.svc",
+		},
+	}, ctx.Maven.Settings.Proxies)
+}
+
+func TestInjectBuildEnvironment(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "build-env")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	c, err := test.NewFakeClient(
+		&corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "registry-credentials",
+			},
+			Data: map[string][]byte{
+				"token": []byte("my-token"),
+			},
+		},
+	)
+	assert.Nil(t, err)
+
+	ctx := Context{
+		Client:    c,
+		Namespace: "ns",
+		Path:      tmpDir,
+		Build: v1alpha1.BuildSpec{
+			Platform: v1alpha1.IntegrationPlatformSpec{
+				Build: v1alpha1.IntegrationPlatformBuildSpec{
+					Env: []corev1.EnvVar{
+						{
+							Name:  "TOKEN_FILE",
+							Value: "/etc/build/credentials/token",
+						},
+						{
+							Name: "TOKEN",
+							ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{
+										Name: "registry-credentials",
+									},
+									Key: "token",
+								},
+							},
+						},
+					},
+					Secrets: []v1alpha1.IntegrationPlatformBuildSecret{
+						{
+							Name:      "registry-credentials",
+							MountPath: "/etc/build/credentials",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err = injectBuildEnvironment(&ctx)
+	assert.Nil(t, err)
+
+	secretDir := path.Join(tmpDir, "secrets", "registry-credentials")
+	assert.Equal(t, []string{
+		"TOKEN_FILE=" + path.Join(secretDir, "token"),
+		"TOKEN=my-token",
+	}, ctx.Env)
+
+	data, err := ioutil.ReadFile(path.Join(secretDir, "token"))
+	assert.Nil(t, err)
+	assert.Equal(t, "my-token", string(data))
+}
+
+func TestDeduplicateArtifacts(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "deduplicate")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	for name, content := range map[string]string{"a.jar": "content-a", "b.jar": "content-b", "c.jar": "content-a"} {
+		assert.Nil(t, ioutil.WriteFile(path.Join(tmpDir, name), []byte(content), 0644))
+	}
+
+	ctx := Context{
+		Path: tmpDir,
+		Artifacts: []v1alpha1.Artifact{
+			{ID: "org.acme:a:1.0", Location: path.Join(tmpDir, "a.jar"), Target: "dependencies/org.acme.a-1.0.jar"},
+			{ID: "org.acme:b:1.0", Location: path.Join(tmpDir, "b.jar"), Target: "dependencies/org.acme.b-1.0.jar"},
+			{ID: "org.relocated:a:1.0", Location: path.Join(tmpDir, "c.jar"), Target: "dependencies/org.relocated.a-1.0.jar"},
+		},
+	}
+
+	err = deduplicateArtifacts(&ctx)
+	assert.Nil(t, err)
+
+	assert.NotEmpty(t, ctx.Artifacts[0].Checksum)
+	assert.Equal(t, ctx.Artifacts[0].Checksum, ctx.Artifacts[2].Checksum)
+	assert.NotEqual(t, ctx.Artifacts[0].Checksum, ctx.Artifacts[1].Checksum)
+	assert.Equal(t, "dependencies/org.acme.a-1.0.jar", ctx.Artifacts[2].Target)
+
+	err = standardPackager(&ctx)
+	assert.Nil(t, err)
+
+	file, err := os.Open(ctx.Archive)
+	assert.Nil(t, err)
+	defer file.Close()
+
+	entries := make([]string, 0)
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		entries = append(entries, header.Name)
+	}
+	assert.ElementsMatch(t, []string{"dependencies/org.acme.a-1.0.jar", "dependencies/org.acme.b-1.0.jar"}, entries)
+
+	selected := reuseBaseImageArtifacts(&ctx, []v1alpha1.Artifact{
+		{ID: "org.base:b:1.0", Target: "dependencies/org.base.b-1.0.jar", Checksum: ctx.Artifacts[1].Checksum},
+	}, ctx.Artifacts)
+	assert.Len(t, selected, 2)
+	assert.Equal(t, "dependencies/org.base.b-1.0.jar", ctx.Artifacts[1].Target)
+}
+
+func TestExcludeProvidedArtifacts(t *testing.T) {
+	ctx := Context{
+		Build: v1alpha1.BuildSpec{
+			Platform: v1alpha1.IntegrationPlatformSpec{
+				Build: v1alpha1.IntegrationPlatformBuildSpec{
+					Provided: v1alpha1.IntegrationPlatformProvidedSpec{
+						Artifacts: []string{"org.apache.camel:camel-*", "org.acme.*"},
+					},
+				},
+			},
+		},
+		Artifacts: []v1alpha1.Artifact{
+			{ID: "org.apache.camel:camel-core:2.23.0", Target: "dependencies/org.apache.camel.camel-core-2.23.0.jar", Checksum: "1"},
+			{ID: "org.apache.camel.k:camel-k-runtime-jvm:0.3.0", Target: "dependencies/org.apache.camel.k.camel-k-runtime-jvm-0.3.0.jar"},
+			{ID: "org.acme.lib:lib:1.0", Target: "dependencies/org.acme.lib.lib-1.0.jar"},
+			{ID: "org.relocated:camel-core:2.23.0", Target: "dependencies/org.apache.camel.camel-core-2.23.0.jar", Checksum: "1"},
+			{ID: "org.apache.camel:other:2.23.0", Target: "dependencies/org.apache.camel.other-2.23.0.jar", Checksum: "2"},
+		},
+	}
+
+	err := excludeProvidedArtifacts(&ctx)
+	assert.Nil(t, err)
+
+	assert.Equal(t, []v1alpha1.Artifact{
+		{ID: "org.apache.camel.k:camel-k-runtime-jvm:0.3.0", Target: "dependencies/org.apache.camel.k.camel-k-runtime-jvm-0.3.0.jar"},
+		{ID: "org.apache.camel:other:2.23.0", Target: "dependencies/org.apache.camel.other-2.23.0.jar", Checksum: "2"},
+	}, ctx.Artifacts)
+}
+
 func TestGenerateGroovyProject(t *testing.T) {
 	catalog, err := test.DefaultCatalog()
 	assert.Nil(t, err)
@@ -379,3 +580,284 @@ func TestListPublishedImages(t *testing.T) {
 	assert.Len(t, i, 1)
 	assert.Equal(t, "image-2", i[0].Image)
 }
+
+func TestBaseKitPackager(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "base-kit-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	core := writeJar(t, tmpDir, "camel-core-2.23.2.jar", "org/apache/camel/CamelContext.class")
+	kafka := writeJar(t, tmpDir, "camel-kafka-2.23.2.jar", "org/apache/camel/component/kafka/KafkaComponent.class")
+
+	newContext := func() *Context {
+		return &Context{
+			Path:      tmpDir,
+			Image:     "base-image",
+			BaseImage: "base-image",
+			Artifacts: []v1alpha1.Artifact{
+				{ID: "org.apache.camel:camel-core:jar:2.23.2", Location: core, Target: "dependencies/camel-core-2.23.2.jar"},
+				{ID: "org.apache.camel:camel-kafka:jar:2.23.2", Location: kafka, Target: "dependencies/camel-kafka-2.23.2.jar"},
+			},
+			Build: v1alpha1.BuildSpec{
+				Dependencies: []string{"camel:core", "camel:kafka"},
+				BaseKit: &v1alpha1.BuildBaseKit{
+					Name:         "kit-1",
+					Image:        "kit-1-image",
+					Dependencies: []string{"camel:core"},
+					Artifacts: []v1alpha1.Artifact{
+						{ID: "org.apache.camel:camel-core:jar:2.23.2", Target: "dependencies/camel-core-2.23.2.jar"},
+					},
+				},
+			},
+		}
+	}
+
+	ctx := newContext()
+	assert.Nil(t, baseKitPackager(ctx))
+	assert.Equal(t, "kit-1-image", ctx.BaseImage)
+	assert.Equal(t, "kit-1-image", ctx.Image)
+	assert.Len(t, ctx.SelectedArtifacts, 1)
+	assert.Equal(t, "org.apache.camel:camel-kafka:jar:2.23.2", ctx.SelectedArtifacts[0].ID)
+	assert.Equal(t, path.Join(tmpDir, "package", "occi.tar"), ctx.Archive)
+
+	// opted out
+	ctx = newContext()
+	incremental := false
+	ctx.Build.Incremental = &incremental
+	assert.Nil(t, baseKitPackager(ctx))
+	assert.Equal(t, "base-image", ctx.BaseImage)
+	assert.Len(t, ctx.SelectedArtifacts, 2)
+
+	// the resolved artifacts no longer match the base kit ones
+	ctx = newContext()
+	ctx.Build.BaseKit.Artifacts = []v1alpha1.Artifact{
+		{ID: "org.apache.camel:camel-core:jar:2.23.1"},
+		{ID: "org.apache.camel:camel-util:jar:2.23.1"},
+	}
+	assert.Nil(t, baseKitPackager(ctx))
+	assert.Equal(t, "base-image", ctx.BaseImage)
+	assert.Len(t, ctx.SelectedArtifacts, 2)
+}
+
+func TestDetectClasspathConflicts(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "classpath-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	ctx := Context{
+		Artifacts: []v1alpha1.Artifact{
+			{
+				ID:       "org.apache.logging.log4j:log4j-slf4j-impl:jar:2.11.2",
+				Location: writeJar(t, tmpDir, "log4j-slf4j-impl-2.11.2.jar", "org/slf4j/impl/StaticLoggerBinder.class", "org/slf4j/impl/StaticMDCBinder.class"),
+			},
+			{
+				ID:       "org.slf4j:slf4j-log4j12:jar:1.7.25",
+				Location: writeJar(t, tmpDir, "slf4j-log4j12-1.7.25.jar", "org/slf4j/impl/StaticLoggerBinder.class", "org/slf4j/impl/StaticMDCBinder.class", "module-info.class"),
+			},
+			{
+				ID:       "org.slf4j:slf4j-api:jar:1.7.25",
+				Location: writeJar(t, tmpDir, "slf4j-api-1.7.25.jar", "org/slf4j/Logger.class", "module-info.class"),
+			},
+			{
+				ID:       "org.slf4j:slf4j-api:jar:1.7.26",
+				Location: writeJar(t, tmpDir, "slf4j-api-1.7.26.jar", "org/slf4j/Logger.class", "module-info.class"),
+			},
+			{
+				ID:       "org.apache.camel:camel-bom:pom:2.23.1",
+				Location: path.Join(tmpDir, "camel-bom-2.23.1.pom"),
+			},
+		},
+	}
+
+	err = detectClasspathConflicts(&ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"conflicting versions of org.slf4j:slf4j-api: 1.7.25, 1.7.26",
+		"2 duplicate classes (e.g. org.slf4j.impl.StaticLoggerBinder) provided by: org.apache.logging.log4j:log4j-slf4j-impl:jar:2.11.2, org.slf4j:slf4j-log4j12:jar:1.7.25",
+		"1 duplicate classes (e.g. org.slf4j.Logger) provided by: org.slf4j:slf4j-api:jar:1.7.25, org.slf4j:slf4j-api:jar:1.7.26",
+	}, ctx.Warnings)
+
+	ctx.Warnings = nil
+	ctx.Build.Platform.Build.ClasspathConflicts = v1alpha1.IntegrationPlatformClasspathPolicyFail
+
+	err = detectClasspathConflicts(&ctx)
+	assert.NotNil(t, err)
+	assert.IsType(t, &ClasspathConflictError{}, err)
+	assert.Equal(t, v1alpha1.BuildReasonClasspathConflict, errorReason(err))
+	assert.Empty(t, ctx.Warnings)
+
+	ctx.Build.Platform.Build.ClasspathConflicts = v1alpha1.IntegrationPlatformClasspathPolicyIgnore
+
+	err = detectClasspathConflicts(&ctx)
+	assert.Nil(t, err)
+	assert.Empty(t, ctx.Warnings)
+}
+
+func TestVerifyArtifacts(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "artifacts-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	core := writeJar(t, tmpDir, "camel-core-2.23.1.jar", "org/apache/camel/CamelContext.class")
+	content, err := ioutil.ReadFile(core)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(core+".sha1", []byte(fmt.Sprintf("%x  camel-core-2.23.1.jar", sha1.Sum(content))), 0644))
+
+	api := writeJar(t, tmpDir, "slf4j-api-1.7.25.jar", "org/slf4j/Logger.class")
+	assert.Nil(t, ioutil.WriteFile(api+".sha1", []byte("da39a3ee5e6b4b0d3255bfef95601890afd80709"), 0644))
+
+	ctx := Context{
+		Artifacts: []v1alpha1.Artifact{
+			{ID: "org.apache.camel:camel-core:jar:2.23.1", Location: core},
+			{ID: "org.slf4j:slf4j-api:jar:1.7.25", Location: api},
+			{ID: "com.github.my-org:my-routes:jar:1.0.0", Location: writeJar(t, tmpDir, "my-routes-1.0.0.jar")},
+		},
+	}
+
+	assert.Nil(t, verifyArtifacts(&ctx))
+
+	ctx.Build.Platform.Build.Maven.AllowedGroups = []string{"org.apache.*", "org.slf4j"}
+	ctx.Build.Platform.Build.Maven.DeniedGroups = []string{"org.slf4j"}
+
+	err = verifyArtifacts(&ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, v1alpha1.BuildReasonArtifactPolicy, errorReason(err))
+	assert.Equal(t, []string{
+		"artifact org.slf4j:slf4j-api:jar:1.7.25 is in a denied group",
+		"artifact com.github.my-org:my-routes:jar:1.0.0 is not in an allowed group",
+	}, err.(*ArtifactPolicyError).Violations)
+
+	ctx.Build.Platform.Build.Maven.AllowedGroups = nil
+	ctx.Build.Platform.Build.Maven.DeniedGroups = nil
+	ctx.Build.Platform.Build.Maven.VerifyChecksums = true
+
+	err = verifyArtifacts(&ctx)
+	assert.NotNil(t, err)
+	assert.Len(t, err.(*ArtifactPolicyError).Violations, 1)
+	assert.Contains(t, err.(*ArtifactPolicyError).Violations[0], "artifact org.slf4j:slf4j-api:jar:1.7.25: checksum mismatch")
+	assert.Equal(t, []string{"artifact com.github.my-org:my-routes:jar:1.0.0: no checksum found"}, ctx.Warnings)
+
+	ctx.Warnings = nil
+	ctx.Build.Platform.Build.Maven.StrictChecksums = true
+
+	err = verifyArtifacts(&ctx)
+	assert.NotNil(t, err)
+	assert.Len(t, err.(*ArtifactPolicyError).Violations, 2)
+	assert.Contains(t, err.(*ArtifactPolicyError).Violations[0], "artifact org.slf4j:slf4j-api:jar:1.7.25: checksum mismatch")
+	assert.Equal(t, "artifact com.github.my-org:my-routes:jar:1.0.0: no checksum found", err.(*ArtifactPolicyError).Violations[1])
+}
+
+func TestComputeGradleDependenciesChecksums(t *testing.T) {
+	ctx := Context{}
+	ctx.Build.Platform.Build.Maven.VerifyChecksums = true
+
+	err := computeGradleDependencies(&ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, v1alpha1.BuildReasonArtifactPolicy, errorReason(err))
+}
+
+func TestVerifyArtifactsFIPS(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "artifacts-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	core := writeJar(t, tmpDir, "camel-core-2.23.1.jar", "org/apache/camel/CamelContext.class")
+	content, err := ioutil.ReadFile(core)
+	assert.Nil(t, err)
+	assert.Nil(t, ioutil.WriteFile(core+".sha256", []byte(fmt.Sprintf("%x", sha256.Sum256(content))), 0644))
+	// the SHA-1 checksum is ignored as a SHA-2 one is available
+	assert.Nil(t, ioutil.WriteFile(core+".sha1", []byte("da39a3ee5e6b4b0d3255bfef95601890afd80709"), 0644))
+
+	api := writeJar(t, tmpDir, "slf4j-api-1.7.25.jar", "org/slf4j/Logger.class")
+	assert.Nil(t, ioutil.WriteFile(api+".md5", []byte("d41d8cd98f00b204e9800998ecf8427e"), 0644))
+
+	ctx := Context{
+		Artifacts: []v1alpha1.Artifact{
+			{ID: "org.apache.camel:camel-core:jar:2.23.1", Location: core},
+			{ID: "org.slf4j:slf4j-api:jar:1.7.25", Location: api},
+		},
+	}
+	ctx.Build.Platform.FIPS = true
+	ctx.Build.Platform.Build.Maven.VerifyChecksums = true
+	ctx.Build.Platform.Build.Maven.StrictChecksums = true
+
+	err = verifyArtifacts(&ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{
+		"artifact org.slf4j:slf4j-api:jar:1.7.25: no checksum found",
+	}, err.(*ArtifactPolicyError).Violations)
+}
+
+func writeJar(t *testing.T, dir string, name string, entries ...string) string {
+	location := path.Join(dir, name)
+
+	f, err := os.Create(location)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for _, e := range entries {
+		_, err := w.Create(e)
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, w.Close())
+
+	return location
+}
+
+func TestGenerateAppCDSArchiveInOperator(t *testing.T) {
+	ctx := Context{}
+	ctx.Build.Platform.Build.BuildStrategy = v1alpha1.IntegrationPlatformBuildStrategyRoutine
+
+	assert.NotNil(t, generateAppCDSArchive(&ctx))
+}
+
+func TestAppCDSClasspath(t *testing.T) {
+	artifacts := []v1alpha1.Artifact{
+		{ID: "org.apache.camel:camel-core:2.23.0", Target: "dependencies/org.apache.camel.camel-core-2.23.0.jar"},
+		{ID: "org.acme:lib:1.0", Target: "dependencies/org.acme.lib-1.0.jar"},
+		{ID: "org.relocated:camel-core:2.23.0", Target: "dependencies/org.apache.camel.camel-core-2.23.0.jar"},
+		{ID: "org.acme:native:1.0", Target: "dependencies/org.acme.native-1.0.so"},
+	}
+
+	assert.Equal(t, []string{
+		"dependencies/org.acme.lib-1.0.jar",
+		"dependencies/org.apache.camel.camel-core-2.23.0.jar",
+	}, AppCDSClasspath(artifacts))
+}
+
+func TestScanImageBeforePublishing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := make(map[string]interface{})
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+		// the image isn't published yet, the base image is scanned along with the artifacts layered on top of it
+		assert.Equal(t, "registry/base:1", request["image"])
+		assert.Equal(t, []interface{}{"org.apache.camel:camel-core:jar:2.23.1"}, request["artifacts"])
+		_, _ = w.Write([]byte(`{"HIGH": 1}`))
+	}))
+	defer server.Close()
+
+	assert.True(t, Steps.ScanImage.Phase() < ApplicationPublishPhase)
+
+	ctx := Context{
+		C:         cancellable.NewContext(),
+		BaseImage: "registry/base:1",
+		Image:     "registry/kit:1",
+		SelectedArtifacts: []v1alpha1.Artifact{
+			{ID: "org.apache.camel:camel-core:jar:2.23.1"},
+		},
+	}
+	ctx.Build.Platform.Build.ImageScan = &v1alpha1.IntegrationPlatformImageScanSpec{
+		Endpoint: server.URL,
+		Severity: scan.SeverityCritical,
+	}
+
+	assert.Nil(t, scanImage(&ctx))
+	assert.Equal(t, scan.Summary{scan.SeverityHigh: 1}, ctx.Vulnerabilities)
+
+	ctx.Build.Platform.Build.ImageScan.Severity = scan.SeverityHigh
+
+	err := scanImage(&ctx)
+	assert.IsType(t, &scan.Error{}, err)
+	assert.Equal(t, v1alpha1.BuildReasonVulnerabilities, errorReason(err))
+}
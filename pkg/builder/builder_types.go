@@ -20,12 +20,14 @@ package builder
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/cancellable"
 	"github.com/apache/camel-k/pkg/util/maven"
+	"github.com/apache/camel-k/pkg/util/scan"
 )
 
 const (
@@ -43,6 +45,25 @@ const (
 	NotifyPhase int32 = math.MaxInt32
 )
 
+// TrimmedJREDir is the directory, relative to the image working directory, of the JRE created by the TrimJRE step
+const TrimmedJREDir = "jre"
+
+// AppCDSArchiveFile is the path, relative to the image working directory, of the class data sharing archive
+// created by the GenerateAppCDSArchive step
+const AppCDSArchiveFile = "appcds/app.jsa"
+
+// AppCDSMainClass is the main class run by the training launch of the GenerateAppCDSArchive step
+const AppCDSMainClass = "org.apache.camel.k.jvm.Application"
+
+// trimmedJREModules are the modules added to the ones detected by jdeps, as they are loaded reflectively
+// by the libraries or required by the agents and the TLS connections
+var trimmedJREModules = []string{
+	"java.instrument",
+	"java.management",
+	"jdk.crypto.ec",
+	"jdk.unsupported",
+}
+
 // Builder --
 type Builder interface {
 	Build(build v1alpha1.BuildSpec) v1alpha1.BuildStatus
@@ -99,12 +120,14 @@ type Resource struct {
 // Context --
 type Context struct {
 	client.Client
-	C                 cancellable.Context
-	Catalog           *camel.RuntimeCatalog
-	Build             v1alpha1.BuildSpec
-	BaseImage         string
-	Image             string
-	PublicImage       string
+	C           cancellable.Context
+	Catalog     *camel.RuntimeCatalog
+	Build       v1alpha1.BuildSpec
+	BaseImage   string
+	Image       string
+	PublicImage string
+	// ImageStreamTag is the OpenShift image stream tag the image has been pushed to, if any
+	ImageStreamTag    string
 	Error             error
 	Namespace         string
 	Path              string
@@ -112,6 +135,18 @@ type Context struct {
 	SelectedArtifacts []v1alpha1.Artifact
 	Archive           string
 	Resources         []Resource
+	Warnings          []string
+	Vulnerabilities   scan.Summary
+	// TrimmedJRE is the directory of the JRE created for the image, if any
+	TrimmedJRE string
+	// AppCDSArchive is the class data sharing archive created for the image, if any
+	AppCDSArchive string
+	// Env holds the KEY=value environment variables of the build tool processes run by the operator
+	Env []string
+	// Conditions are recorded on the build status, e.g. to report the status of external builds
+	Conditions []v1alpha1.Condition
+	// Labels holds the provenance labels of the published image, see ImageLabels
+	Labels map[string]string
 
 	Maven struct {
 		Project      maven.Project
@@ -120,6 +155,24 @@ type Context struct {
 	}
 }
 
+// ClasspathConflictError is returned when conflicting artifacts are found on the integration classpath
+type ClasspathConflictError struct {
+	Conflicts []string
+}
+
+func (e *ClasspathConflictError) Error() string {
+	return "classpath conflicts detected: " + strings.Join(e.Conflicts, "; ")
+}
+
+// ArtifactPolicyError is returned when resolved artifacts do not comply with the platform artifact policy
+type ArtifactPolicyError struct {
+	Violations []string
+}
+
+func (e *ArtifactPolicyError) Error() string {
+	return "artifact policy violations: " + strings.Join(e.Violations, "; ")
+}
+
 // HasRequiredImage --
 func (c *Context) HasRequiredImage() bool {
 	return c.Build.Image != ""
@@ -134,6 +187,12 @@ func (c *Context) GetImage() string {
 	return c.Image
 }
 
+// ResourceName returns the name of the resources created for the build, e.g. publishing pods, that is qualified
+// with the kit namespace when building in a dedicated namespace
+func (c *Context) ResourceName() string {
+	return v1alpha1.BuildName(c.Build.Meta.Namespace, c.Build.Meta.Name, c.Build.Platform.Build.BuildNamespace(c.Build.Meta.Namespace))
+}
+
 type publishedImage struct {
 	Image        string
 	Artifacts    []v1alpha1.Artifact
@@ -18,12 +18,16 @@ limitations under the License.
 package builder
 
 import (
+	"fmt"
+	"os"
 	"strings"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/maven"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 // StepIDsFor --
@@ -35,6 +39,20 @@ func StepIDsFor(steps ...Step) []string {
 	return IDs
 }
 
+// ReplaceStep returns a copy of the given steps where the old step is substituted by the new one
+func ReplaceStep(steps []Step, old Step, new Step) []Step {
+	result := make([]Step, 0, len(steps))
+	for _, s := range steps {
+		if s.ID() == old.ID() {
+			result = append(result, new)
+		} else {
+			result = append(result, s)
+		}
+	}
+
+	return result
+}
+
 func artifactIDs(artifacts []v1alpha1.Artifact) []string {
 	result := make([]string, 0, len(artifacts))
 
@@ -60,7 +78,10 @@ func NewMavenProject(ctx *Context) (maven.Project, error) {
 	}
 
 	p := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", defaults.Version)
-	p.Properties = ctx.Build.Platform.Build.Properties
+	p.Properties = make(maven.Properties)
+	for k, v := range ctx.Build.Platform.Build.Properties {
+		p.Properties[k] = v
+	}
 	p.DependencyManagement = maven.DependencyManagement{Dependencies: make([]maven.Dependency, 0)}
 	p.Dependencies = make([]maven.Dependency, 0)
 
@@ -97,3 +118,86 @@ func NewMavenProject(ctx *Context) (maven.Project, error) {
 
 	return p, nil
 }
+
+// ParseGitHubDependency translates a dependency hosted on GitHub to the related maven
+// dependency served by JitPack.
+//
+// The dependency is in one of the forms:
+//
+//     github:<owner>/<repo>
+//     github:<owner>/<repo>(:|/)<version>
+//     github:<owner>/<repo>/<module>(:|/)<version>
+//
+// If no version is given, the latest commit of the master branch is used. As the last path segment
+// is the version when no colon is used, a module always comes with its version.
+func ParseGitHubDependency(dependency string) (maven.Dependency, error) {
+	gav := strings.TrimPrefix(dependency, "github:")
+	version := "master-SNAPSHOT"
+
+	parts := strings.Split(gav, "/")
+	if idx := strings.Index(gav, ":"); idx != -1 {
+		version = gav[idx+1:]
+		parts = strings.Split(gav[:idx], "/")
+		if strings.ContainsAny(version, ":/") {
+			return maven.Dependency{}, fmt.Errorf("invalid github dependency: %s", dependency)
+		}
+	} else if len(parts) > 2 {
+		version = parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+
+	for _, p := range append(parts, version) {
+		if p == "" {
+			return maven.Dependency{}, fmt.Errorf("invalid github dependency: %s", dependency)
+		}
+	}
+
+	switch len(parts) {
+	case 2:
+		return maven.NewDependency("com.github."+parts[0], parts[1], version), nil
+	case 3:
+		return maven.NewDependency("com.github."+parts[0]+"."+parts[1], parts[2], version), nil
+	default:
+		return maven.Dependency{}, fmt.Errorf("invalid github dependency: %s", dependency)
+	}
+}
+
+// imageSize returns the size in bytes of the artifacts and resources packaged in the image,
+// ignoring the artifacts that are no more available
+func imageSize(ctx *Context) int64 {
+	size := int64(0)
+	for _, a := range ctx.Artifacts {
+		if info, err := os.Stat(a.Location); err == nil {
+			size += info.Size()
+		}
+	}
+	for _, r := range ctx.Resources {
+		size += int64(len(r.Content))
+	}
+	return size
+}
+
+// BuildSecretVolumes returns the volumes and mounts of the secrets configured for the builder pods
+func BuildSecretVolumes(spec v1alpha1.IntegrationPlatformBuildSpec) ([]corev1.Volume, []corev1.VolumeMount) {
+	volumes := make([]corev1.Volume, 0, len(spec.Secrets))
+	mounts := make([]corev1.VolumeMount, 0, len(spec.Secrets))
+
+	for i, secret := range spec.Secrets {
+		name := fmt.Sprintf("build-secret-%d", i)
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret.Name,
+				},
+			},
+		})
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      name,
+			MountPath: secret.MountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return volumes, mounts
+}
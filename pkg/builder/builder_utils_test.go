@@ -26,6 +26,8 @@ import (
 	"github.com/apache/camel-k/pkg/util/test"
 
 	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
 )
 
 func TestNewProject(t *testing.T) {
@@ -75,3 +77,106 @@ func TestNewProject(t *testing.T) {
 		Scope:      "import",
 	})
 }
+
+func TestParseGitHubDependency(t *testing.T) {
+	dep, err := ParseGitHubDependency("github:apache/camel-k-examples:v1.0.0")
+	assert.Nil(t, err)
+	assert.Equal(t, maven.NewDependency("com.github.apache", "camel-k-examples", "v1.0.0"), dep)
+
+	dep, err = ParseGitHubDependency("github:apache/camel-k-examples/v1.0.0")
+	assert.Nil(t, err)
+	assert.Equal(t, maven.NewDependency("com.github.apache", "camel-k-examples", "v1.0.0"), dep)
+
+	dep, err = ParseGitHubDependency("github:apache/camel-k-examples/routes:1a2b3c4")
+	assert.Nil(t, err)
+	assert.Equal(t, maven.NewDependency("com.github.apache.camel-k-examples", "routes", "1a2b3c4"), dep)
+
+	dep, err = ParseGitHubDependency("github:apache/camel-k-examples/routes/1a2b3c4")
+	assert.Nil(t, err)
+	assert.Equal(t, maven.NewDependency("com.github.apache.camel-k-examples", "routes", "1a2b3c4"), dep)
+
+	dep, err = ParseGitHubDependency("github:apache/camel-k-examples")
+	assert.Nil(t, err)
+	assert.Equal(t, maven.NewDependency("com.github.apache", "camel-k-examples", "master-SNAPSHOT"), dep)
+
+	for _, invalid := range []string{
+		"github:apache",
+		"github:apache//v1.0.0",
+		"github:apache/camel-k-examples:",
+		"github:apache/camel-k-examples/routes/",
+		"github:apache/camel-k-examples/routes/1a2b3c4/extra",
+		"github:apache/camel-k-examples/routes/1a2b3c4:v1.0.0",
+		"github:apache/camel-k-examples:v1.0.0/routes",
+	} {
+		_, err = ParseGitHubDependency(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestNewProjectWithGitHubDependency(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	ctx := Context{
+		Catalog: catalog,
+		Build: v1alpha1.BuildSpec{
+			RuntimeVersion: defaults.RuntimeVersion,
+			Platform: v1alpha1.IntegrationPlatformSpec{
+				Build: v1alpha1.IntegrationPlatformBuildSpec{
+					CamelVersion: catalog.Version,
+				},
+			},
+			Dependencies: []string{
+				"runtime:jvm",
+				"github:my-org/my-routes:1.0.0",
+				"github:my-org/my-other-routes:2.0.0",
+			},
+		},
+	}
+
+	err = generateProject(&ctx)
+	assert.Nil(t, err)
+
+	assert.Contains(t, ctx.Maven.Project.Dependencies, maven.NewDependency("com.github.my-org", "my-routes", "1.0.0"))
+	assert.Contains(t, ctx.Maven.Project.Dependencies, maven.NewDependency("com.github.my-org", "my-other-routes", "2.0.0"))
+
+	jitpack := 0
+	for _, r := range ctx.Maven.Project.Repositories {
+		if r.URL == "https://jitpack.io" {
+			jitpack++
+			assert.Equal(t, "jitpack.io", r.ID)
+			assert.True(t, r.Snapshots.Enabled)
+		}
+	}
+	assert.Equal(t, 1, jitpack)
+}
+
+func TestBuildSecretVolumes(t *testing.T) {
+	volumes, mounts := BuildSecretVolumes(v1alpha1.IntegrationPlatformBuildSpec{
+		Secrets: []v1alpha1.IntegrationPlatformBuildSecret{
+			{Name: "registry-credentials", MountPath: "/etc/build/credentials"},
+			{Name: "signing-keys", MountPath: "/etc/build/keys"},
+		},
+	})
+
+	assert.Len(t, volumes, 2)
+	assert.Equal(t, "build-secret-0", volumes[0].Name)
+	assert.Equal(t, "registry-credentials", volumes[0].Secret.SecretName)
+	assert.Equal(t, "build-secret-1", volumes[1].Name)
+	assert.Equal(t, "signing-keys", volumes[1].Secret.SecretName)
+
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "build-secret-0", MountPath: "/etc/build/credentials", ReadOnly: true},
+		{Name: "build-secret-1", MountPath: "/etc/build/keys", ReadOnly: true},
+	}, mounts)
+}
+
+func TestReplaceStep(t *testing.T) {
+	steps := ReplaceStep(
+		[]Step{Steps.ComputeDependencies, Steps.IncrementalPackager},
+		Steps.IncrementalPackager,
+		Steps.BaseKitPackager,
+	)
+
+	assert.Equal(t, StepIDsFor(Steps.ComputeDependencies, Steps.BaseKitPackager), StepIDsFor(steps...))
+}
@@ -18,11 +18,14 @@ limitations under the License.
 package kaniko
 
 import (
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/platform"
 )
 
 func init() {
 	builder.RegisterSteps(Steps)
+	builder.RegisterPublishStrategy(v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko, publishStrategy{})
 }
 
 type steps struct {
@@ -44,9 +47,26 @@ var DefaultSteps = []builder.Step{
 	builder.Steps.InjectDependencies,
 	builder.Steps.SanitizeDependencies,
 	builder.Steps.ComputeDependencies,
+	builder.Steps.VerifyArtifacts,
 	builder.Steps.IncrementalPackager,
 	Steps.Publisher,
 }
 
 // BuildDir is the directory where to build artifacts (shared with the Kaniko pod)
 var BuildDir = "/workspace"
+
+// publishStrategy pushes the kit images to the platform registry from a Kaniko pod
+type publishStrategy struct {
+}
+
+func (publishStrategy) Supports(p *v1alpha1.IntegrationPlatform) bool {
+	return platform.SupportsKanikoPublishStrategy(p)
+}
+
+func (publishStrategy) Steps(_ v1alpha1.TraitProfile) []builder.Step {
+	return DefaultSteps
+}
+
+func (publishStrategy) BuildDir() string {
+	return BuildDir
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/apache/camel-k/pkg/builder"
 	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/proxy"
 	"github.com/apache/camel-k/pkg/util/tar"
 
 	"github.com/pkg/errors"
@@ -36,24 +37,14 @@ import (
 )
 
 func publisher(ctx *builder.Context) error {
-	organization := ctx.Build.Platform.Build.Registry.Organization
-	if organization == "" {
-		organization = ctx.Namespace
-	}
-	image := ctx.Build.Platform.Build.Registry.Address + "/" + organization + "/camel-k-" + ctx.Build.Meta.Name + ":" + ctx.Build.Meta.ResourceVersion
+	image := builder.PublishedImage(ctx)
 	baseDir, _ := path.Split(ctx.Archive)
 	contextDir := path.Join(baseDir, "context")
 	if err := tar.Extract(ctx.Archive, contextDir); err != nil {
 		return err
 	}
 
-	// #nosec G202
-	dockerFileContent := []byte(`
-		FROM ` + ctx.Image + `
-		ADD . /deployments
-	`)
-
-	err := ioutil.WriteFile(path.Join(contextDir, "Dockerfile"), dockerFileContent, 0777)
+	err := ioutil.WriteFile(path.Join(contextDir, "Dockerfile"), dockerfile(ctx), 0777)
 	if err != nil {
 		return err
 	}
@@ -111,6 +102,16 @@ func publisher(ctx *builder.Context) error {
 		args = baseArgs
 	}
 
+	// Kaniko honors the proxy environment variables when pulling and pushing images
+	envs = append(envs, proxy.EnvVars(ctx.Build.Platform.Build.Proxy)...)
+	args = append(args, proxy.KanikoArgs(ctx.Build.Platform.Build.Proxy)...)
+
+	// Provide the build environment, e.g. custom truststores
+	secretVolumes, secretMounts := builder.BuildSecretVolumes(ctx.Build.Platform.Build)
+	volumes = append(volumes, secretVolumes...)
+	volumeMounts = append(volumeMounts, secretMounts...)
+	envs = append(envs, ctx.Build.Platform.Build.Env...)
+
 	pod := corev1.Pod{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: corev1.SchemeGroupVersion.String(),
@@ -118,7 +119,7 @@ func publisher(ctx *builder.Context) error {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: ctx.Namespace,
-			Name:      "camel-k-" + ctx.Build.Meta.Name,
+			Name:      "camel-k-" + ctx.ResourceName(),
 		},
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{
@@ -135,7 +136,6 @@ func publisher(ctx *builder.Context) error {
 		},
 	}
 
-
 	var labelKey string
 	var labelValue string
 	if ctx.Namespace == platform.GetOperatorNamespace() {
@@ -144,7 +144,7 @@ func publisher(ctx *builder.Context) error {
 		labelValue = "operator"
 	} else {
 		labelKey = "camel.apache.org/build"
-		labelValue = ctx.Build.Meta.Name
+		labelValue = ctx.ResourceName()
 	}
 
 	// Co-locate with builder pod for sharing the volume
@@ -192,3 +192,18 @@ func publisher(ctx *builder.Context) error {
 	ctx.Image = image
 	return nil
 }
+
+// dockerfile returns the Dockerfile of the kit image, the provenance labels being set after adding
+// the application so that the layers cached by Kaniko are not invalidated by the build timestamp
+func dockerfile(ctx *builder.Context) []byte {
+	labels := ctx.ImageLabels()
+
+	// #nosec G202
+	content := "FROM " + ctx.Image + "\n" +
+		"ADD . /deployments\n"
+	for _, name := range builder.SortedImageLabels(labels) {
+		content += fmt.Sprintf("LABEL %q=%q\n", name, labels[name])
+	}
+
+	return []byte(content)
+}
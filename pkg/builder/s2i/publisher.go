@@ -20,6 +20,7 @@ package s2i
 import (
 	"io/ioutil"
 	"regexp"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,58 +32,29 @@ import (
 	buildv1 "github.com/openshift/api/build/v1"
 	imagev1 "github.com/openshift/api/image/v1"
 
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/builder"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/kubernetes/customclient"
+	"github.com/apache/camel-k/pkg/util/proxy"
 
 	"github.com/pkg/errors"
 )
 
 const (
 	openShiftDockerRegistryHost = "docker-registry.default.svc"
+	incrementalTag              = "latest"
 )
 
 func publisher(ctx *builder.Context) error {
-	bc := buildv1.BuildConfig{
-		TypeMeta: metav1.TypeMeta{
-			APIVersion: buildv1.SchemeGroupVersion.String(),
-			Kind:       "BuildConfig",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "camel-k-" + ctx.Build.Meta.Name,
-			Namespace: ctx.Namespace,
-		},
-		Spec: buildv1.BuildConfigSpec{
-			CommonSpec: buildv1.CommonSpec{
-				Source: buildv1.BuildSource{
-					Type: buildv1.BuildSourceBinary,
-				},
-				Strategy: buildv1.BuildStrategy{
-					SourceStrategy: &buildv1.SourceBuildStrategy{
-						From: corev1.ObjectReference{
-							Kind: "DockerImage",
-							Name: ctx.Image,
-						},
-					},
-				},
-				Output: buildv1.BuildOutput{
-					To: &corev1.ObjectReference{
-						Kind: "ImageStreamTag",
-						Name: "camel-k-" + ctx.Build.Meta.Name + ":" + ctx.Build.Meta.ResourceVersion,
-					},
-				},
-			},
-		},
-	}
-
-	err := ctx.Client.Delete(ctx.C, &bc)
-	if err != nil && !apierrors.IsNotFound(err) {
-		return errors.Wrap(err, "cannot delete build config")
-	}
-
-	err = ctx.Client.Create(ctx.C, &bc)
-	if err != nil {
-		return errors.Wrap(err, "cannot create build config")
+	// The output tag depends on the content of the image, so it's computed before the image is replaced
+	tag := outputTag(ctx)
+
+	// The build config and the image stream are reused by the successive builds of the kit,
+	// so that incremental builds can retrieve the previously built image
+	bc := newBuildConfig(ctx)
+	if err := kubernetes.ReplaceResource(ctx.C, ctx.Client, &bc); err != nil {
+		return errors.Wrap(err, "cannot create or update build config")
 	}
 
 	is := imagev1.ImageStream{
@@ -91,7 +63,7 @@ func publisher(ctx *builder.Context) error {
 			Kind:       "ImageStream",
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "camel-k-" + ctx.Build.Meta.Name,
+			Name:      "camel-k-" + ctx.ResourceName(),
 			Namespace: ctx.Namespace,
 		},
 		Spec: imagev1.ImageStreamSpec{
@@ -101,13 +73,8 @@ func publisher(ctx *builder.Context) error {
 		},
 	}
 
-	err = ctx.Client.Delete(ctx.C, &is)
-	if err != nil && !apierrors.IsNotFound(err) {
-		return errors.Wrap(err, "cannot delete image stream")
-	}
-
-	err = ctx.Client.Create(ctx.C, &is)
-	if err != nil {
+	err := ctx.Client.Create(ctx.C, &is)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
 		return errors.Wrap(err, "cannot create image stream")
 	}
 
@@ -125,7 +92,7 @@ func publisher(ctx *builder.Context) error {
 		Namespace(ctx.Namespace).
 		Body(resource).
 		Resource("buildconfigs").
-		Name("camel-k-" + ctx.Build.Meta.Name).
+		Name("camel-k-" + ctx.ResourceName()).
 		SubResource("instantiatebinary").
 		Do()
 
@@ -174,11 +141,72 @@ func publisher(ctx *builder.Context) error {
 		return errors.New("dockerImageRepository not available in ImageStream")
 	}
 
-	ctx.Image = is.Status.DockerImageRepository + ":" + ctx.Build.Meta.ResourceVersion
+	ctx.ImageStreamTag = is.Name + ":" + tag
+
+	// Incremental builds share the output tag, the image being then referenced by digest
+	if out := ocbuild.Status.Output.To; ctx.Build.Platform.Build.S2I.Incremental && out != nil && out.ImageDigest != "" {
+		ctx.Image = is.Status.DockerImageRepository + "@" + out.ImageDigest
+	} else {
+		ctx.Image = is.Status.DockerImageRepository + ":" + tag
+	}
 
 	return nil
 }
 
+func newBuildConfig(ctx *builder.Context) buildv1.BuildConfig {
+	bc := buildv1.BuildConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: buildv1.SchemeGroupVersion.String(),
+			Kind:       "BuildConfig",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "camel-k-" + ctx.ResourceName(),
+			Namespace: ctx.Namespace,
+		},
+		Spec: buildv1.BuildConfigSpec{
+			CommonSpec: buildv1.CommonSpec{
+				Source: buildv1.BuildSource{
+					Type:    buildv1.BuildSourceBinary,
+					Secrets: buildSecrets(ctx.Build.Platform.Build),
+				},
+				Strategy: buildv1.BuildStrategy{
+					SourceStrategy: &buildv1.SourceBuildStrategy{
+						From: corev1.ObjectReference{
+							Kind: "DockerImage",
+							Name: ctx.Image,
+						},
+						Env: append(proxy.EnvVars(ctx.Build.Platform.Build.Proxy), ctx.Build.Platform.Build.Env...),
+					},
+				},
+				Output: buildv1.BuildOutput{
+					To: &corev1.ObjectReference{
+						Kind: "ImageStreamTag",
+						Name: "camel-k-" + ctx.ResourceName() + ":" + outputTag(ctx),
+					},
+					ImageLabels: imageLabels(ctx),
+				},
+			},
+		},
+	}
+
+	if ctx.Build.Platform.Build.S2I.Incremental {
+		incremental := true
+		bc.Spec.Strategy.SourceStrategy.Incremental = &incremental
+	}
+
+	return bc
+}
+
+// outputTag returns the tag the image is pushed to, incremental builds using a tag shared by the kit
+// versions as the artifacts are retrieved from the image previously pushed to the output tag, the other
+// builds using the content digest of the image
+func outputTag(ctx *builder.Context) string {
+	if ctx.Build.Platform.Build.S2I.Incremental {
+		return incrementalTag
+	}
+	return ctx.ContentDigest()
+}
+
 func replaceHost(ctx *builder.Context) error {
 	ctx.PublicImage = getImageWithOpenShiftHost(ctx.Image)
 	return nil
@@ -188,3 +216,32 @@ func getImageWithOpenShiftHost(image string) string {
 	pattern := regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+\.[0-9]+([:/].*)`)
 	return pattern.ReplaceAllString(image, openShiftDockerRegistryHost+"$1")
 }
+
+// imageLabels returns the provenance labels of the image as build output labels
+func imageLabels(ctx *builder.Context) []buildv1.ImageLabel {
+	labels := ctx.ImageLabels()
+
+	result := make([]buildv1.ImageLabel, 0, len(labels))
+	for _, name := range builder.SortedImageLabels(labels) {
+		result = append(result, buildv1.ImageLabel{
+			Name:  name,
+			Value: labels[name],
+		})
+	}
+	return result
+}
+
+// buildSecrets returns the build secrets, S2I builds copying them in the build working directory
+// as the destination directories must be relative
+func buildSecrets(spec v1alpha1.IntegrationPlatformBuildSpec) []buildv1.SecretBuildSource {
+	secrets := make([]buildv1.SecretBuildSource, 0, len(spec.Secrets))
+	for _, s := range spec.Secrets {
+		secrets = append(secrets, buildv1.SecretBuildSource{
+			Secret: corev1.LocalObjectReference{
+				Name: s.Name,
+			},
+			DestinationDir: strings.TrimPrefix(s.MountPath, "/"),
+		})
+	}
+	return secrets
+}
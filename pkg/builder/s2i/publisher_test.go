@@ -21,6 +21,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
 )
 
 func TestIPReplacement(t *testing.T) {
@@ -32,3 +37,52 @@ func TestIPReplacement(t *testing.T) {
 	assert.Equal(t, "gcr.io/camel-k/camel-k:latest", getImageWithOpenShiftHost("gcr.io/camel-k/camel-k:latest"))
 	assert.Equal(t, "docker.io/camel-k:latest", getImageWithOpenShiftHost("docker.io/camel-k:latest"))
 }
+
+func TestNewBuildConfig(t *testing.T) {
+	ctx := builder.Context{
+		Namespace: "ns",
+		Image:     "fabric8/s2i-java:3.0-java8",
+		Build: v1alpha1.BuildSpec{
+			Meta: metav1.ObjectMeta{
+				Name:            "kit-1",
+				ResourceVersion: "1234",
+			},
+		},
+	}
+
+	bc := newBuildConfig(&ctx)
+	assert.Equal(t, "camel-k-kit-1", bc.Name)
+	assert.Equal(t, "camel-k-kit-1:"+ctx.ContentDigest(), bc.Spec.Output.To.Name)
+	assert.Nil(t, bc.Spec.Strategy.SourceStrategy.Incremental)
+	assert.Len(t, bc.Spec.Output.ImageLabels, len(ctx.Labels))
+	for _, label := range bc.Spec.Output.ImageLabels {
+		assert.Equal(t, ctx.Labels[label.Name], label.Value)
+	}
+
+	ctx.Build.Platform.Build.S2I.Incremental = true
+
+	bc = newBuildConfig(&ctx)
+	assert.Equal(t, "camel-k-kit-1:latest", bc.Spec.Output.To.Name)
+	assert.NotNil(t, bc.Spec.Strategy.SourceStrategy.Incremental)
+	assert.True(t, *bc.Spec.Strategy.SourceStrategy.Incremental)
+}
+
+func TestNewBuildConfigInBuildNamespace(t *testing.T) {
+	ctx := builder.Context{
+		Namespace: "builds",
+		Image:     "fabric8/s2i-java:3.0-java8",
+		Build: v1alpha1.BuildSpec{
+			Meta: metav1.ObjectMeta{
+				Namespace:       "ns",
+				Name:            "kit-1",
+				ResourceVersion: "1234",
+			},
+		},
+	}
+	ctx.Build.Platform.Build.Namespace = "builds"
+
+	bc := newBuildConfig(&ctx)
+	assert.Equal(t, "builds", bc.Namespace)
+	assert.Equal(t, "camel-k-"+v1alpha1.BuildName("ns", "kit-1", "builds"), bc.Name)
+	assert.Equal(t, bc.Name+":"+ctx.ContentDigest(), bc.Spec.Output.To.Name)
+}
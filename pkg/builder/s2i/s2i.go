@@ -18,11 +18,14 @@ limitations under the License.
 package s2i
 
 import (
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/platform"
 )
 
 func init() {
 	builder.RegisterSteps(Steps)
+	builder.RegisterPublishStrategy(v1alpha1.IntegrationPlatformBuildPublishStrategyS2I, publishStrategy{})
 }
 
 type steps struct {
@@ -49,6 +52,30 @@ var DefaultSteps = []builder.Step{
 	builder.Steps.InjectDependencies,
 	builder.Steps.SanitizeDependencies,
 	builder.Steps.ComputeDependencies,
+	builder.Steps.VerifyArtifacts,
 	builder.Steps.IncrementalPackager,
 	Steps.Publisher,
 }
+
+// publishStrategy publishes the kit images through OpenShift binary S2I builds
+type publishStrategy struct {
+}
+
+func (publishStrategy) Supports(p *v1alpha1.IntegrationPlatform) bool {
+	return platform.SupportsS2iPublishStrategy(p)
+}
+
+func (publishStrategy) Steps(profile v1alpha1.TraitProfile) []builder.Step {
+	if profile == v1alpha1.TraitProfileKnative {
+		// copy to not alter the default steps
+		steps := make([]builder.Step, 0, len(DefaultSteps)+1)
+		steps = append(steps, DefaultSteps...)
+		return append(steps, Steps.ReplaceHost)
+	}
+
+	return DefaultSteps
+}
+
+func (publishStrategy) BuildDir() string {
+	return ""
+}
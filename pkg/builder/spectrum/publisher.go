@@ -0,0 +1,340 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spectrum
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/proxy"
+	"github.com/apache/camel-k/pkg/util/registry"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// deploymentsDir is the directory of the image the kit artifacts are added to
+const deploymentsDir = "deployments"
+
+// layer is a gzipped tar layer, identified by the digest of its compressed content and by the digest of its
+// uncompressed content (the diff ID) in the image config
+type layer struct {
+	file   string
+	size   int64
+	digest string
+	diffID string
+}
+
+func publisher(ctx *builder.Context) error {
+	image := builder.PublishedImage(ctx)
+	target, err := registry.ParseReference(image)
+	if err != nil {
+		return err
+	}
+	base, err := registry.ParseReference(ctx.Image)
+	if err != nil {
+		return err
+	}
+
+	c, err := newRegistryClient(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := c.GetManifest(ctx.C, base, registry.DefaultPlatform)
+	if err != nil {
+		return err
+	}
+
+	// the layers of the base image, e.g. a previously published kit image, are usually found in the target
+	// repository already, so that only the new layer is uploaded
+	for _, l := range manifest.Layers {
+		if err := copyBlob(ctx.C, c, base, target, l); err != nil {
+			return errors.Wrapf(err, "cannot copy layer %s of base image %s", l.Digest, base)
+		}
+	}
+
+	l, err := newLayer(ctx.Archive, path.Join(path.Dir(ctx.Archive), "layer.tar.gz"))
+	if err != nil {
+		return err
+	}
+	if err := uploadLayer(ctx.C, c, target, l); err != nil {
+		return err
+	}
+
+	baseConfig, err := c.GetBlob(ctx.C, base, manifest.Config.Digest)
+	if err != nil {
+		return err
+	}
+	defer baseConfig.Close()
+	content, err := ioutil.ReadAll(baseConfig)
+	if err != nil {
+		return err
+	}
+	config, err := newConfig(content, l.diffID, ctx.ImageLabels(), time.Now().UTC())
+	if err != nil {
+		return errors.Wrapf(err, "invalid config of base image %s", base)
+	}
+	configDigest := registry.Digest(config)
+	if err := c.PutBlob(ctx.C, target, configDigest, int64(len(config)), bytes.NewReader(config)); err != nil {
+		return err
+	}
+
+	layerMediaType := registry.MediaTypeLayer
+	if manifest.IsOCI() {
+		layerMediaType = registry.MediaTypeOCILayer
+	}
+
+	published := *manifest
+	published.Config = registry.Descriptor{
+		MediaType: manifest.Config.MediaType,
+		Size:      int64(len(config)),
+		Digest:    configDigest,
+	}
+	published.Layers = append(append([]registry.Descriptor{}, manifest.Layers...), registry.Descriptor{
+		MediaType: layerMediaType,
+		Size:      l.size,
+		Digest:    l.digest,
+	})
+	if _, err := c.PutManifest(ctx.C, target, &published); err != nil {
+		return err
+	}
+
+	ctx.Image = image
+	return nil
+}
+
+// newRegistryClient creates the client accessing the platform registry, with the credentials of the
+// registry secret that is expected to be of the kubernetes.io/dockerconfigjson type
+func newRegistryClient(ctx *builder.Context, target registry.Reference) (*registry.Client, error) {
+	spec := ctx.Build.Platform.Build.Registry
+
+	c := registry.NewClient(proxy.NewClient(ctx.Build.Platform.Build.Proxy))
+	if spec.Insecure {
+		c.SetInsecure(target.Registry)
+	}
+
+	if spec.Secret != "" {
+		secret, err := kubernetes.GetSecret(ctx.C, ctx.Client, spec.Secret, ctx.Namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot get registry secret %s", spec.Secret)
+		}
+		data, ok := secret.Data[corev1.DockerConfigJsonKey]
+		if !ok {
+			return nil, errors.Errorf("registry secret %s has no %s key", spec.Secret, corev1.DockerConfigJsonKey)
+		}
+		credentials, err := registry.ParseDockerConfig(data)
+		if err != nil {
+			return nil, err
+		}
+		for r, cr := range credentials {
+			c.SetCredentials(r, cr)
+		}
+	}
+
+	return c, nil
+}
+
+// copyBlob makes the blob of the base image available in the target repository, mounting it when both
+// are in the same registry or streaming it from the base registry otherwise
+func copyBlob(ctx context.Context, c *registry.Client, base registry.Reference, target registry.Reference, blob registry.Descriptor) error {
+	// foreign layers are pulled from their URLs, not from the registry
+	if len(blob.URLs) > 0 {
+		return nil
+	}
+
+	exists, err := c.HasBlob(ctx, target, blob.Digest)
+	if err != nil || exists {
+		return err
+	}
+	if base.Registry == target.Registry {
+		mounted, err := c.MountBlob(ctx, target, base.Repository, blob.Digest)
+		if err != nil || mounted {
+			return err
+		}
+	}
+
+	content, err := c.GetBlob(ctx, base, blob.Digest)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	return c.PutBlob(ctx, target, blob.Digest, blob.Size, content)
+}
+
+// uploadLayer uploads the layer to the target repository, unless it's there already
+func uploadLayer(ctx context.Context, c *registry.Client, target registry.Reference, l layer) error {
+	exists, err := c.HasBlob(ctx, target, l.digest)
+	if err != nil || exists {
+		return err
+	}
+
+	content, err := os.Open(l.file)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	return c.PutBlob(ctx, target, l.digest, l.size, content)
+}
+
+// newLayer creates the gzipped layer adding the entries of the given archive to the deployments directory
+// of the image, along with their parent directories
+func newLayer(archive string, file string) (layer, error) {
+	in, err := os.Open(archive)
+	if err != nil {
+		return layer{}, err
+	}
+	defer in.Close()
+
+	out, err := os.Create(file)
+	if err != nil {
+		return layer{}, err
+	}
+	defer out.Close()
+
+	compressed := sha256.New()
+	uncompressed := sha256.New()
+	gz := gzip.NewWriter(io.MultiWriter(out, compressed))
+	tw := tar.NewWriter(io.MultiWriter(gz, uncompressed))
+
+	dirs := make(map[string]bool)
+	addDir := func(dir string, modTime time.Time) error {
+		for _, d := range parentDirs(dir) {
+			if dirs[d] {
+				continue
+			}
+			dirs[d] = true
+			if err := tw.WriteHeader(&tar.Header{Name: d + "/", Typeflag: tar.TypeDir, Mode: 0755, ModTime: modTime}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tr := tar.NewReader(in)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return layer{}, errors.Wrapf(err, "cannot read archive %s", archive)
+		}
+
+		name := path.Join(deploymentsDir, header.Name)
+		if header.Typeflag == tar.TypeDir {
+			if err := addDir(name, header.ModTime); err != nil {
+				return layer{}, err
+			}
+			continue
+		}
+		if err := addDir(path.Dir(name), header.ModTime); err != nil {
+			return layer{}, err
+		}
+
+		header.Name = name
+		if err := tw.WriteHeader(header); err != nil {
+			return layer{}, err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return layer{}, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return layer{}, err
+	}
+	if err := gz.Close(); err != nil {
+		return layer{}, err
+	}
+	info, err := out.Stat()
+	if err != nil {
+		return layer{}, err
+	}
+
+	return layer{
+		file:   file,
+		size:   info.Size(),
+		digest: "sha256:" + hex.EncodeToString(compressed.Sum(nil)),
+		diffID: "sha256:" + hex.EncodeToString(uncompressed.Sum(nil)),
+	}, nil
+}
+
+// parentDirs returns the given directory and its parents, from the top-most one
+func parentDirs(dir string) []string {
+	dirs := make([]string, 0)
+	for d := path.Clean(dir); d != "." && d != "/"; d = path.Dir(d) {
+		dirs = append([]string{d}, dirs...)
+	}
+	return dirs
+}
+
+// newConfig returns the config of the base image with the new layer appended to its root filesystem and
+// the given labels, the fields the config does not know about being kept as is
+func newConfig(base []byte, diffID string, labels map[string]string, created time.Time) ([]byte, error) {
+	config := make(map[string]interface{})
+	if err := json.Unmarshal(base, &config); err != nil {
+		return nil, err
+	}
+
+	rootfs, ok := config["rootfs"].(map[string]interface{})
+	if !ok {
+		rootfs = map[string]interface{}{"type": "layers"}
+	}
+	diffIDs, _ := rootfs["diff_ids"].([]interface{})
+	rootfs["diff_ids"] = append(diffIDs, diffID)
+	config["rootfs"] = rootfs
+
+	containerConfig, ok := config["config"].(map[string]interface{})
+	if !ok {
+		containerConfig = make(map[string]interface{})
+	}
+	imageLabels, ok := containerConfig["Labels"].(map[string]interface{})
+	if !ok {
+		imageLabels = make(map[string]interface{})
+	}
+	for name, value := range labels {
+		imageLabels[name] = value
+	}
+	containerConfig["Labels"] = imageLabels
+	config["config"] = containerConfig
+
+	history, _ := config["history"].([]interface{})
+	config["history"] = append(history, map[string]interface{}{
+		"created":    created.Format(time.RFC3339),
+		"created_by": "ADD . /" + deploymentsDir,
+	})
+	config["created"] = created.Format(time.RFC3339)
+
+	return json.Marshal(config)
+}
@@ -0,0 +1,216 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package spectrum
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/util/cancellable"
+	"github.com/apache/camel-k/pkg/util/registry"
+	tarutils "github.com/apache/camel-k/pkg/util/tar"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+// addBaseImage pushes a single layer image to the fake registry
+func addBaseImage(r *test.FakeRegistry, repository string, tag string) registry.Manifest {
+	config := []byte(`{"architecture":"amd64","os":"linux","config":{"Env":["JAVA_HOME=/opt/java"]},` +
+		`"rootfs":{"type":"layers","diff_ids":["sha256:base"]},"history":[{"created_by":"base"}]}`)
+	layer := []byte("base layer")
+
+	manifest := registry.Manifest{
+		SchemaVersion: 2,
+		MediaType:     registry.MediaTypeManifest,
+		Config:        registry.Descriptor{MediaType: registry.MediaTypeConfig, Size: int64(len(config)), Digest: r.AddBlob(repository, config)},
+		Layers:        []registry.Descriptor{{MediaType: registry.MediaTypeLayer, Size: int64(len(layer)), Digest: r.AddBlob(repository, layer)}},
+	}
+	content, _ := json.Marshal(manifest)
+	r.AddManifest(repository, tag, registry.MediaTypeManifest, content)
+
+	return manifest
+}
+
+func newTestContext(t *testing.T, r *test.FakeRegistry, image string) *builder.Context {
+	dir, err := ioutil.TempDir("", "camel-k-spectrum-")
+	assert.Nil(t, err)
+
+	archive := path.Join(dir, "occi.tar")
+	appender, err := tarutils.NewAppender(archive)
+	assert.Nil(t, err)
+	assert.Nil(t, appender.AddData([]byte("jar"), "dependencies/org.apache.camel.camel-core-2.23.2.jar"))
+	assert.Nil(t, appender.AddData([]byte("from('timer:tick')"), "sources/routes.groovy"))
+	assert.Nil(t, appender.Close())
+
+	ctx := builder.Context{
+		C:         cancellable.NewContext(),
+		Namespace: "ns",
+		Path:      dir,
+		Image:     image,
+		Archive:   archive,
+		Artifacts: []v1alpha1.Artifact{{ID: "org.apache.camel:camel-core:jar:2.23.2"}},
+		Build: v1alpha1.BuildSpec{
+			Meta:         metav1.ObjectMeta{Namespace: "ns", Name: "kit-1"},
+			CamelVersion: "2.23.2",
+		},
+	}
+	ctx.Build.Platform.Build.Registry = v1alpha1.IntegrationPlatformRegistrySpec{
+		Address:  r.Address(),
+		Insecure: true,
+	}
+
+	return &ctx
+}
+
+func TestPublisher(t *testing.T) {
+	r := test.NewFakeRegistry()
+	defer r.Close()
+	r.Token = "token"
+
+	base := addBaseImage(r, "base/jdk", "8")
+
+	ctx := newTestContext(t, r, r.Address()+"/base/jdk:8")
+	defer os.RemoveAll(ctx.Path)
+	image := builder.PublishedImage(ctx)
+
+	assert.Nil(t, publisher(ctx))
+	assert.Equal(t, image, ctx.Image)
+
+	// the base layer is mounted from the base repository
+	assert.Equal(t, []string{"ns/camel-k-kits@" + base.Layers[0].Digest}, r.Mounted)
+
+	ref, err := registry.ParseReference(image)
+	assert.Nil(t, err)
+	content, ok := r.Manifest(ref.Repository, ref.Reference)
+	assert.True(t, ok)
+
+	manifest := registry.Manifest{}
+	assert.Nil(t, json.Unmarshal(content, &manifest))
+	assert.Equal(t, registry.MediaTypeManifest, manifest.MediaType)
+	assert.Len(t, manifest.Layers, 2)
+	assert.Equal(t, base.Layers[0], manifest.Layers[0])
+	assert.Equal(t, registry.MediaTypeLayer, manifest.Layers[1].MediaType)
+
+	configContent, ok := r.Blob(ref.Repository, manifest.Config.Digest)
+	assert.True(t, ok)
+	config := struct {
+		Config struct {
+			Env    []string
+			Labels map[string]string
+		} `json:"config"`
+		RootFS struct {
+			DiffIDs []string `json:"diff_ids"`
+		} `json:"rootfs"`
+		History []map[string]string `json:"history"`
+	}{}
+	assert.Nil(t, json.Unmarshal(configContent, &config))
+	assert.Equal(t, []string{"JAVA_HOME=/opt/java"}, config.Config.Env)
+	assert.Equal(t, "2.23.2", config.Config.Labels[builder.ImageLabelCamelVersion])
+	assert.Len(t, config.RootFS.DiffIDs, 2)
+	assert.Len(t, config.History, 2)
+
+	layerContent, ok := r.Blob(ref.Repository, manifest.Layers[1].Digest)
+	assert.True(t, ok)
+	assert.Equal(t, []string{
+		"deployments/",
+		"deployments/dependencies/",
+		"deployments/dependencies/org.apache.camel.camel-core-2.23.2.jar",
+		"deployments/sources/",
+		"deployments/sources/routes.groovy",
+	}, layerEntries(t, layerContent))
+
+	// an image built on top of the kit image only uploads its own layer and config
+	uploaded := len(r.Uploaded)
+	next := newTestContext(t, r, image)
+	defer os.RemoveAll(next.Path)
+	next.Artifacts = append(next.Artifacts, v1alpha1.Artifact{ID: "org.apache.camel:camel-kafka:jar:2.23.2"})
+	appender, err := tarutils.NewAppender(next.Archive)
+	assert.Nil(t, err)
+	assert.Nil(t, appender.AddData([]byte("jar"), "dependencies/org.apache.camel.camel-kafka-2.23.2.jar"))
+	assert.Nil(t, appender.Close())
+
+	assert.Nil(t, publisher(next))
+	assert.Len(t, r.Uploaded, uploaded+2)
+	assert.Len(t, r.Mounted, 1)
+}
+
+func TestCopyBlob(t *testing.T) {
+	source := test.NewFakeRegistry()
+	defer source.Close()
+	target := test.NewFakeRegistry()
+	defer target.Close()
+
+	manifest := addBaseImage(source, "base/jdk", "8")
+
+	c := registry.NewClient(http.DefaultClient)
+	c.SetInsecure(source.Address())
+	c.SetInsecure(target.Address())
+	base := registry.Reference{Registry: source.Address(), Repository: "base/jdk", Reference: "8"}
+	ref := registry.Reference{Registry: target.Address(), Repository: "ns/camel-k-kits", Reference: "1234"}
+
+	// the blobs of other registries are streamed
+	assert.Nil(t, copyBlob(context.TODO(), c, base, ref, manifest.Layers[0]))
+	assert.Equal(t, []string{"ns/camel-k-kits@" + manifest.Layers[0].Digest}, target.Uploaded)
+	content, ok := target.Blob("ns/camel-k-kits", manifest.Layers[0].Digest)
+	assert.True(t, ok)
+	assert.Equal(t, "base layer", string(content))
+
+	// and copied only once
+	assert.Nil(t, copyBlob(context.TODO(), c, base, ref, manifest.Layers[0]))
+	assert.Len(t, target.Uploaded, 1)
+}
+
+func layerEntries(t *testing.T, content []byte) []string {
+	dir, err := ioutil.TempDir("", "camel-k-layer-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := path.Join(dir, "layer.tar.gz")
+	assert.Nil(t, ioutil.WriteFile(file, content, 0644))
+	f, err := os.Open(file)
+	assert.Nil(t, err)
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	assert.Nil(t, err)
+
+	entries := make([]string, 0)
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		entries = append(entries, header.Name)
+	}
+	return entries
+}
@@ -0,0 +1,175 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	pipelineRunAPIVersion = "tekton.dev/v1alpha1"
+	pipelineRunKind       = "PipelineRun"
+)
+
+func publisher(ctx *builder.Context) error {
+	image := builder.PublishedImage(ctx)
+
+	// The pipeline run is named after the kit version, so that a recovered build
+	// tracks the run it has already created
+	run := newPipelineRun(ctx, image)
+	err := ctx.Client.Create(ctx.C, run)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		return errors.Wrap(err, "cannot create pipeline run")
+	}
+
+	var succeeded *v1alpha1.Condition
+	err = kubernetes.WaitCondition(ctx.C, ctx.Client, run, func(obj interface{}) (bool, error) {
+		if val, ok := obj.(*unstructured.Unstructured); ok {
+			if succeeded = pipelineRunCondition(val); succeeded != nil {
+				switch succeeded.Status {
+				case corev1.ConditionTrue:
+					return true, nil
+				case corev1.ConditionFalse:
+					return false, errors.New(succeeded.Message)
+				}
+			}
+		}
+		return false, nil
+	}, ctx.Build.Platform.Build.Timeout.Duration)
+
+	if succeeded != nil {
+		ctx.Conditions = v1alpha1.SetCondition(ctx.Conditions, *succeeded)
+	}
+	if err != nil {
+		return err
+	}
+
+	ctx.Image = image
+	return nil
+}
+
+// newPipelineRun creates a run of the pipeline configured on the platform, the image to build and the kit
+// dependencies being passed as parameters along with the ones set on the platform
+func newPipelineRun(ctx *builder.Context, image string) *unstructured.Unstructured {
+	tekton := ctx.Build.Platform.Build.Tekton
+
+	dependencies := make([]string, 0, len(ctx.Artifacts))
+	for _, a := range ctx.Artifacts {
+		dependencies = append(dependencies, a.ID)
+	}
+
+	// The provenance labels are passed as a comma separated list of name=value pairs,
+	// it's up to the pipeline to set them on the image
+	labels := ctx.ImageLabels()
+	imageLabels := make([]string, 0, len(labels))
+	for _, name := range builder.SortedImageLabels(labels) {
+		imageLabels = append(imageLabels, name+"="+labels[name])
+	}
+
+	values := map[string]string{
+		"image":          image,
+		"baseImage":      ctx.BaseImage,
+		"dependencies":   strings.Join(dependencies, ","),
+		"imageLabels":    strings.Join(imageLabels, ","),
+		"camelVersion":   ctx.Build.CamelVersion,
+		"runtimeVersion": ctx.Build.RuntimeVersion,
+		"kit":            ctx.Build.Meta.Name,
+		"namespace":      ctx.Build.Meta.Namespace,
+	}
+	for k, v := range tekton.Params {
+		values[k] = v
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	params := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		params = append(params, map[string]interface{}{
+			"name":  name,
+			"value": values[name],
+		})
+	}
+
+	spec := map[string]interface{}{
+		"pipelineRef": map[string]interface{}{
+			"name": tekton.Pipeline,
+		},
+		"params": params,
+	}
+	if tekton.ServiceAccount != "" {
+		spec["serviceAccount"] = tekton.ServiceAccount
+	}
+
+	run := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	run.SetAPIVersion(pipelineRunAPIVersion)
+	run.SetKind(pipelineRunKind)
+	run.SetNamespace(ctx.Namespace)
+	run.SetName("camel-k-" + ctx.ResourceName() + "-" + ctx.Build.Meta.ResourceVersion)
+	run.SetLabels(map[string]string{
+		"camel.apache.org/kit": ctx.Build.Meta.Name,
+	})
+
+	return &run
+}
+
+// pipelineRunCondition returns the Succeeded condition of the pipeline run, if any, as a build condition
+func pipelineRunCondition(run *unstructured.Unstructured) *v1alpha1.Condition {
+	conditions, _, _ := unstructured.NestedSlice(run.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if t, _, _ := unstructured.NestedString(condition, "type"); t != "Succeeded" {
+			continue
+		}
+
+		status, _, _ := unstructured.NestedString(condition, "status")
+		reason, _, _ := unstructured.NestedString(condition, "reason")
+		message, _, _ := unstructured.NestedString(condition, "message")
+
+		return &v1alpha1.Condition{
+			Type:    v1alpha1.ConditionPipelineRunSucceeded,
+			Status:  corev1.ConditionStatus(status),
+			Reason:  reason,
+			Message: fmt.Sprintf("pipeline run %s: %s", run.GetName(), message),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tekton
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
+)
+
+func TestNewPipelineRun(t *testing.T) {
+	ctx := builder.Context{
+		Namespace: "ns",
+		BaseImage: "adoptopenjdk/openjdk8:slim",
+		Artifacts: []v1alpha1.Artifact{
+			{ID: "org.apache.camel:camel-core:jar:2.23.2"},
+			{ID: "org.apache.camel.k:camel-k-runtime-jvm:jar:0.3.3"},
+		},
+		Build: v1alpha1.BuildSpec{
+			Meta: metav1.ObjectMeta{
+				Namespace:       "ns",
+				Name:            "kit-1",
+				ResourceVersion: "1234",
+			},
+			CamelVersion: "2.23.2",
+		},
+	}
+	ctx.Build.Platform.Build.Tekton = v1alpha1.IntegrationPlatformTektonSpec{
+		Pipeline:       "build-image",
+		ServiceAccount: "pipeline",
+		Params: map[string]string{
+			"builder":      "buildah",
+			"camelVersion": "overridden",
+		},
+	}
+
+	run := newPipelineRun(&ctx, "registry/ns/camel-k-kit-1:1234")
+
+	assert.Equal(t, "tekton.dev/v1alpha1", run.GetAPIVersion())
+	assert.Equal(t, "PipelineRun", run.GetKind())
+	assert.Equal(t, "ns", run.GetNamespace())
+	assert.Equal(t, "camel-k-kit-1-1234", run.GetName())
+
+	pipeline, _, _ := unstructured.NestedString(run.Object, "spec", "pipelineRef", "name")
+	assert.Equal(t, "build-image", pipeline)
+	sa, _, _ := unstructured.NestedString(run.Object, "spec", "serviceAccount")
+	assert.Equal(t, "pipeline", sa)
+
+	params, _, _ := unstructured.NestedSlice(run.Object, "spec", "params")
+	values := make(map[string]interface{})
+	for _, p := range params {
+		param := p.(map[string]interface{})
+		values[param["name"].(string)] = param["value"]
+	}
+	assert.Equal(t, "registry/ns/camel-k-kit-1:1234", values["image"])
+	assert.Equal(t, "adoptopenjdk/openjdk8:slim", values["baseImage"])
+	assert.Equal(t, "org.apache.camel:camel-core:jar:2.23.2,org.apache.camel.k:camel-k-runtime-jvm:jar:0.3.3", values["dependencies"])
+	assert.Equal(t, "buildah", values["builder"])
+	assert.Equal(t, "overridden", values["camelVersion"])
+	assert.Equal(t, "kit-1", values["kit"])
+	assert.Contains(t, values["imageLabels"], builder.ImageLabelCamelVersion+"=2.23.2,")
+	assert.Contains(t, values["imageLabels"], builder.ImageLabelDependenciesDigest+"="+builder.DependenciesDigest(ctx.Artifacts))
+}
+
+func TestPipelineRunCondition(t *testing.T) {
+	run := unstructured.Unstructured{
+		Object: map[string]interface{}{},
+	}
+	run.SetName("camel-k-kit-1-1234")
+	assert.Nil(t, pipelineRunCondition(&run))
+
+	run.Object["status"] = map[string]interface{}{
+		"conditions": []interface{}{
+			map[string]interface{}{
+				"type":    "Succeeded",
+				"status":  "False",
+				"reason":  "Failed",
+				"message": "TaskRun build-image-push failed",
+			},
+		},
+	}
+
+	condition := pipelineRunCondition(&run)
+	assert.NotNil(t, condition)
+	assert.Equal(t, v1alpha1.ConditionPipelineRunSucceeded, condition.Type)
+	assert.Equal(t, corev1.ConditionFalse, condition.Status)
+	assert.Equal(t, "Failed", condition.Reason)
+	assert.Equal(t, "pipeline run camel-k-kit-1-1234: TaskRun build-image-push failed", condition.Message)
+}
@@ -121,6 +121,28 @@ func FromManager(manager manager.Manager) (Client, error) {
 	}, nil
 }
 
+// FromManagerWithoutCache creates a new k8s client from a manager object, that reads objects straight from the
+// API server, e.g. to access namespaces the manager cache is not restricted to
+func FromManagerWithoutCache(manager manager.Manager) (Client, error) {
+	var err error
+	var clientset kubernetes.Interface
+	if clientset, err = kubernetes.NewForConfig(manager.GetConfig()); err != nil {
+		return nil, err
+	}
+	dynClient, err := controller.New(manager.GetConfig(), controller.Options{
+		Scheme: manager.GetScheme(),
+		Mapper: manager.GetRESTMapper(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &defaultClient{
+		Client:    dynClient,
+		Interface: clientset,
+		scheme:    manager.GetScheme(),
+	}, nil
+}
+
 // init initialize the k8s client for usage outside the cluster
 func initialize(kubeconfig string) {
 	if kubeconfig == "" {
@@ -146,23 +168,30 @@ func GetCurrentNamespace(kubeconfig string) (string, error) {
 		return "default", nil
 	}
 
-	data, err := ioutil.ReadFile(kubeconfig)
+	clientcmdconfig, err := loadKubeConfig(kubeconfig)
 	if err != nil {
 		return "", err
 	}
+
+	cc := clientcmd.NewDefaultClientConfig(*clientcmdconfig, &clientcmd.ConfigOverrides{})
+	ns, _, err := cc.Namespace()
+	return ns, err
+}
+
+func loadKubeConfig(kubeconfig string) (*clientcmdapi.Config, error) {
+	data, err := ioutil.ReadFile(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
 	conf := clientcmdapi.NewConfig()
 	if len(data) == 0 {
-		return "", errors.New("kubernetes config file is empty")
+		return nil, errors.New("kubernetes config file is empty")
 	}
 
 	decoded, _, err := clientcmdlatest.Codec.Decode(data, &schema.GroupVersionKind{Version: clientcmdlatest.Version, Kind: "Config"}, conf)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	clientcmdconfig := decoded.(*clientcmdapi.Config)
-
-	cc := clientcmd.NewDefaultClientConfig(*clientcmdconfig, &clientcmd.ConfigOverrides{})
-	ns, _, err := cc.Namespace()
-	return ns, err
+	return decoded.(*clientcmdapi.Config), nil
 }
@@ -0,0 +1,429 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// applyManagerLabel records the field manager that applied an integration, the integrations being pruned
+// by the field manager they belong to
+const applyManagerLabel = "camel.apache.org/apply.manager"
+
+// kustomizationFiles are the names of the files turning a directory into a kustomization
+var kustomizationFiles = []string{"kustomization.yaml", "kustomization.yml", "Kustomization"}
+
+// kustomization holds the fields of a kustomization supported when applying integrations
+type kustomization struct {
+	Resources         []string          `json:"resources,omitempty"`
+	Namespace         string            `json:"namespace,omitempty"`
+	NamePrefix        string            `json:"namePrefix,omitempty"`
+	NameSuffix        string            `json:"nameSuffix,omitempty"`
+	CommonLabels      map[string]string `json:"commonLabels,omitempty"`
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+}
+
+func newCmdApply(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := applyCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "apply -f file|directory",
+		Short: "Apply the integrations defined in manifest files or directories",
+		Long: `Apply the integrations defined in manifest files or directories.
+
+The integrations are labeled with the field manager applying them, and integrations managed by someone else
+are not updated unless forced. Directories holding a kustomization are rendered from the resources, namespace,
+namePrefix, nameSuffix, commonLabels and commonAnnotations fields of the kustomization. The manifests other
+than integrations are skipped.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			return impl.run()
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&impl.filenames, "filename", "f", nil, "The manifest file, or directory of manifest files, holding the integrations to apply")
+	cmd.Flags().StringVar(&impl.fieldManager, "field-manager", "kamel", "The name of the manager the applied integrations belong to")
+	cmd.Flags().BoolVar(&impl.force, "force", false, "Take over the integrations managed by another field manager")
+	cmd.Flags().BoolVar(&impl.prune, "prune", false, "Delete the integrations of the field manager that are not defined in the manifests anymore")
+	cmd.Flags().BoolVarP(&impl.wait, "wait", "w", false, "Waits for the applied integrations to be running")
+	cmd.Flags().DurationVar(&impl.timeout, "timeout", 10*time.Minute, "How long to wait for each integration to be running")
+
+	return &cmd
+}
+
+type applyCmdOptions struct {
+	*RootCmdOptions
+	filenames    []string
+	fieldManager string
+	force        bool
+	prune        bool
+	wait         bool
+	timeout      time.Duration
+}
+
+func (o *applyCmdOptions) validate(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("unexpected arguments %s, the manifests are given with the -f flag", strings.Join(args, " "))
+	}
+	if len(o.filenames) == 0 {
+		return errors.New("at least one manifest file or directory must be given with the -f flag")
+	}
+	if msgs := validation.IsValidLabelValue(o.fieldManager); o.fieldManager == "" || len(msgs) > 0 {
+		return fmt.Errorf("invalid field manager %q: %s", o.fieldManager, strings.Join(msgs, "; "))
+	}
+	return nil
+}
+
+func (o *applyCmdOptions) run() error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	integrations, err := o.load()
+	if err != nil {
+		return err
+	}
+
+	if err := o.apply(c, integrations); err != nil {
+		return err
+	}
+
+	if o.prune {
+		if err := o.pruneIntegrations(c, integrations); err != nil {
+			return err
+		}
+	}
+
+	if o.wait {
+		for i := range integrations {
+			if err := o.waitForIntegrationRunning(c, &integrations[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// load returns the integrations defined in the manifests, in the namespace they are applied to
+func (o *applyCmdOptions) load() ([]v1alpha1.Integration, error) {
+	integrations := make([]v1alpha1.Integration, 0)
+	for _, filename := range o.filenames {
+		loaded, err := loadIntegrationManifests(filename)
+		if err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, loaded...)
+	}
+
+	keys := make(map[string]bool)
+	for i := range integrations {
+		if integrations[i].Namespace == "" {
+			integrations[i].Namespace = o.Namespace
+		}
+		key := integrations[i].Namespace + "/" + integrations[i].Name
+		if keys[key] {
+			return nil, fmt.Errorf("integration %s is defined more than once", key)
+		}
+		keys[key] = true
+	}
+
+	return integrations, nil
+}
+
+// apply creates or updates the given integrations on behalf of the field manager
+func (o *applyCmdOptions) apply(c client.Client, integrations []v1alpha1.Integration) error {
+	for i := range integrations {
+		integration := &integrations[i]
+		if integration.Labels == nil {
+			integration.Labels = make(map[string]string)
+		}
+		integration.Labels[applyManagerLabel] = o.fieldManager
+
+		existing := v1alpha1.NewIntegration(integration.Namespace, integration.Name)
+		key := k8sclient.ObjectKey{
+			Namespace: integration.Namespace,
+			Name:      integration.Name,
+		}
+		err := c.Get(o.Context, key, &existing)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		if err != nil {
+			if err := c.Create(o.Context, integration); err != nil {
+				return err
+			}
+			fmt.Printf("integration \"%s\" created\n", integration.Name)
+			continue
+		}
+
+		if manager := existing.Labels[applyManagerLabel]; manager != o.fieldManager && !o.force {
+			if manager == "" {
+				manager = "no field manager"
+			}
+			return fmt.Errorf("integration %s is managed by %s, use --force to take it over", integration.Name, manager)
+		}
+
+		if equality.Semantic.DeepEqual(existing.Spec, integration.Spec) && equality.Semantic.DeepEqual(existing.Labels, integration.Labels) &&
+			containsAnnotations(existing.Annotations, integration.Annotations) {
+			fmt.Printf("integration \"%s\" unchanged\n", integration.Name)
+			continue
+		}
+
+		// the annotations set by the operator and the other clients are kept
+		annotations := existing.Annotations
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		for k, v := range integration.Annotations {
+			annotations[k] = v
+		}
+		integration.Annotations = annotations
+		integration.ResourceVersion = existing.ResourceVersion
+
+		if err := c.Update(o.Context, integration); err != nil {
+			return err
+		}
+		fmt.Printf("integration \"%s\" updated\n", integration.Name)
+	}
+
+	return nil
+}
+
+// pruneIntegrations deletes the integrations of the field manager that are not part of the applied ones,
+// in the namespaces the integrations are applied to
+func (o *applyCmdOptions) pruneIntegrations(c client.Client, applied []v1alpha1.Integration) error {
+	keys := make(map[string]bool)
+	namespaces := []string{o.Namespace}
+	for _, integration := range applied {
+		keys[integration.Namespace+"/"+integration.Name] = true
+		util.StringSliceUniqueAdd(&namespaces, integration.Namespace)
+	}
+
+	for _, namespace := range namespaces {
+		list := v1alpha1.NewIntegrationList()
+		options := k8sclient.ListOptions{
+			LabelSelector: labels.SelectorFromSet(labels.Set{
+				applyManagerLabel: o.fieldManager,
+			}),
+			Namespace: namespace,
+		}
+		if err := c.List(o.Context, &options, &list); err != nil {
+			return err
+		}
+
+		for _, integration := range list.Items {
+			if integration.Labels[applyManagerLabel] != o.fieldManager || keys[integration.Namespace+"/"+integration.Name] {
+				continue
+			}
+			if err := DeleteIntegration(o.Context, c, integration.Name, integration.Namespace); err != nil && !k8serrors.IsNotFound(err) {
+				return err
+			}
+			fmt.Printf("integration \"%s\" pruned\n", integration.Name)
+		}
+	}
+
+	return nil
+}
+
+func (o *applyCmdOptions) waitForIntegrationRunning(c client.Client, integration *v1alpha1.Integration) error {
+	return kubernetes.WaitCondition(o.Context, c, integration, func(obj interface{}) (bool, error) {
+		if i, ok := obj.(*v1alpha1.Integration); ok {
+			switch i.Status.Phase {
+			case v1alpha1.IntegrationPhaseRunning:
+				fmt.Printf("integration \"%s\" running\n", i.Name)
+				return true, nil
+			case v1alpha1.IntegrationPhaseError:
+				return false, fmt.Errorf("integration \"%s\" deployment failed", i.Name)
+			}
+		}
+		return false, nil
+	}, o.timeout)
+}
+
+// containsAnnotations returns true if all the given annotations are set with the same value in the existing ones
+func containsAnnotations(existing map[string]string, annotations map[string]string) bool {
+	for k, v := range annotations {
+		if value, ok := existing[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// loadIntegrationManifests returns the integrations defined in the given manifest file or directory
+func loadIntegrationManifests(filename string) ([]v1alpha1.Integration, error) {
+	if !isDirectory(filename) {
+		return loadIntegrationManifestFile(filename)
+	}
+
+	for _, name := range kustomizationFiles {
+		if _, err := os.Stat(path.Join(filename, name)); err == nil {
+			return loadKustomization(filename, path.Join(filename, name))
+		}
+	}
+
+	files, err := ioutil.ReadDir(filename)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		switch strings.ToLower(path.Ext(f.Name())) {
+		case ".yaml", ".yml", ".json":
+			if !f.IsDir() {
+				names = append(names, f.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+
+	integrations := make([]v1alpha1.Integration, 0)
+	for _, name := range names {
+		loaded, err := loadIntegrationManifestFile(path.Join(filename, name))
+		if err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, loaded...)
+	}
+
+	return integrations, nil
+}
+
+// loadIntegrationManifestFile returns the integrations defined in the documents of the given YAML or JSON file
+func loadIntegrationManifestFile(filename string) ([]v1alpha1.Integration, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	integrations := make([]v1alpha1.Integration, 0)
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		u := unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to decode %s: %v", filename, err)
+		}
+		if len(u.Object) == 0 {
+			continue
+		}
+
+		gvk := u.GroupVersionKind()
+		if gvk.Group != v1alpha1.SchemeGroupVersion.Group || gvk.Kind != v1alpha1.IntegrationKind {
+			fmt.Printf("%s \"%s\" skipped from %s, only integrations are applied\n", gvk.Kind, u.GetName(), filename)
+			continue
+		}
+
+		integration := v1alpha1.Integration{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &integration); err != nil {
+			return nil, fmt.Errorf("unable to decode integration %s from %s: %v", u.GetName(), filename, err)
+		}
+		integrations = append(integrations, integration)
+	}
+
+	return integrations, nil
+}
+
+// loadKustomization returns the integrations defined in the resources of the kustomization of the given directory,
+// with the transformations of the kustomization applied. The kustomizations using fields that cannot be rendered
+// without kustomize, e.g. patches, are rejected
+func loadKustomization(dir string, filename string) ([]v1alpha1.Integration, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{})
+	if err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096).Decode(&fields); err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %v", filename, err)
+	}
+	for field := range fields {
+		switch field {
+		case "apiVersion", "kind", "resources", "namespace", "namePrefix", "nameSuffix", "commonLabels", "commonAnnotations":
+		default:
+			return nil, fmt.Errorf("unsupported kustomization field %s in %s", field, filename)
+		}
+	}
+
+	k := kustomization{}
+	if err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096).Decode(&k); err != nil {
+		return nil, fmt.Errorf("unable to decode %s: %v", filename, err)
+	}
+
+	integrations := make([]v1alpha1.Integration, 0)
+	for _, resource := range k.Resources {
+		loaded, err := loadIntegrationManifests(path.Join(dir, resource))
+		if err != nil {
+			return nil, err
+		}
+		integrations = append(integrations, loaded...)
+	}
+
+	for i := range integrations {
+		integration := &integrations[i]
+		integration.Name = k.NamePrefix + integration.Name + k.NameSuffix
+		if k.Namespace != "" {
+			integration.Namespace = k.Namespace
+		}
+		for key, value := range k.CommonLabels {
+			if integration.Labels == nil {
+				integration.Labels = make(map[string]string)
+			}
+			integration.Labels[key] = value
+		}
+		for key, value := range k.CommonAnnotations {
+			if integration.Annotations == nil {
+				integration.Annotations = make(map[string]string)
+			}
+			integration.Annotations[key] = value
+		}
+	}
+
+	return integrations, nil
+}
@@ -0,0 +1,180 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const applyTestIntegration = `apiVersion: camel.apache.org/v1alpha1
+kind: Integration
+metadata:
+  name: %s
+spec:
+  sources:
+  - name: routes.groovy
+    content: from('timer:tick').to('log:info')
+`
+
+func writeApplyTestFile(t *testing.T, dir string, name string, content string) {
+	assert.Nil(t, os.MkdirAll(path.Dir(path.Join(dir, name)), 0755))
+	assert.Nil(t, ioutil.WriteFile(path.Join(dir, name), []byte(content), 0644))
+}
+
+func newApplyTestOptions(filenames ...string) *applyCmdOptions {
+	return &applyCmdOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "default",
+		},
+		filenames:    filenames,
+		fieldManager: "kamel",
+	}
+}
+
+func TestApplyDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-apply-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	writeApplyTestFile(t, dir, "timer.yaml", fmt.Sprintf(applyTestIntegration, "timer")+`---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: timer-config
+`)
+	writeApplyTestFile(t, dir, "rest.json", `{"apiVersion": "camel.apache.org/v1alpha1", "kind": "Integration", "metadata": {"name": "rest"}}`)
+	writeApplyTestFile(t, dir, "README.md", "not a manifest")
+
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	options := newApplyTestOptions(dir)
+	assert.Nil(t, options.validate(nil))
+
+	integrations, err := options.load()
+	assert.Nil(t, err)
+	assert.Len(t, integrations, 2)
+	assert.Equal(t, "rest", integrations[0].Name)
+	assert.Equal(t, "timer", integrations[1].Name)
+	assert.Equal(t, "default", integrations[1].Namespace)
+	assert.Len(t, integrations[1].Spec.Sources, 1)
+
+	assert.Nil(t, options.apply(c, integrations))
+
+	applied := v1alpha1.NewIntegration("default", "timer")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "default", Name: "timer"}, &applied))
+	assert.Equal(t, "kamel", applied.Labels[applyManagerLabel])
+
+	// applying again the same manifests is a no-op
+	integrations, err = options.load()
+	assert.Nil(t, err)
+	assert.Nil(t, options.apply(c, integrations))
+
+	integrations[1].Spec.Sources[0].Content = "from('timer:tock').to('log:info')"
+	assert.Nil(t, options.apply(c, integrations[1:]))
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "default", Name: "timer"}, &applied))
+	assert.Equal(t, "from('timer:tock').to('log:info')", applied.Spec.Sources[0].Content)
+}
+
+func TestApplyKustomization(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-apply-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	writeApplyTestFile(t, dir, "base/timer.yaml", fmt.Sprintf(applyTestIntegration, "timer"))
+	writeApplyTestFile(t, dir, "base/kustomization.yaml", `resources:
+- timer.yaml
+commonLabels:
+  app: timer
+`)
+	writeApplyTestFile(t, dir, "overlays/prod/kustomization.yaml", `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+- ../../base
+namespace: prod
+namePrefix: prod-
+commonLabels:
+  env: prod
+`)
+	writeApplyTestFile(t, dir, "overlays/test/kustomization.yaml", `resources:
+- ../../base
+patchesStrategicMerge:
+- timer-patch.yaml
+`)
+
+	integrations, err := newApplyTestOptions(path.Join(dir, "overlays/prod")).load()
+	assert.Nil(t, err)
+	assert.Len(t, integrations, 1)
+	assert.Equal(t, "prod-timer", integrations[0].Name)
+	assert.Equal(t, "prod", integrations[0].Namespace)
+	assert.Equal(t, map[string]string{"app": "timer", "env": "prod"}, integrations[0].Labels)
+
+	_, err = newApplyTestOptions(path.Join(dir, "overlays/test")).load()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "unsupported kustomization field patchesStrategicMerge")
+}
+
+func TestApplyOwnershipAndPrune(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-apply-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	writeApplyTestFile(t, dir, "timer.yaml", fmt.Sprintf(applyTestIntegration, "timer"))
+
+	unmanaged := v1alpha1.NewIntegration("default", "timer")
+	removed := v1alpha1.NewIntegration("default", "removed")
+	removed.Labels = map[string]string{applyManagerLabel: "kamel"}
+	other := v1alpha1.NewIntegration("default", "other")
+	other.Labels = map[string]string{applyManagerLabel: "team-b"}
+
+	c, err := test.NewFakeClient(&unmanaged, &removed, &other)
+	assert.Nil(t, err)
+
+	options := newApplyTestOptions(path.Join(dir, "timer.yaml"))
+	integrations, err := options.load()
+	assert.Nil(t, err)
+
+	err = options.apply(c, integrations)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "use --force to take it over")
+
+	options.force = true
+	assert.Nil(t, options.apply(c, integrations))
+	assert.Nil(t, options.pruneIntegrations(c, integrations))
+
+	list := v1alpha1.NewIntegrationList()
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "default"}, &list))
+	names := make([]string, 0)
+	for _, integration := range list.Items {
+		names = append(names, integration.Name)
+	}
+	assert.ElementsMatch(t, []string{"timer", "other"}, names)
+}
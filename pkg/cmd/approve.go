@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/spf13/cobra"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newCmdApprove(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := approveCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "approve integration",
+		Short: "Approve the rollout of a rebuilt integration",
+		Long: `Approve the rollout of a rebuilt integration.
+
+When the approval trait is enabled, the workload of an integration is kept on its previous image
+until the rollout of the new image gets approved.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			return impl.run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&impl.image, "image", "", "The image to approve (defaults to the image waiting for approval)")
+
+	return &cmd
+}
+
+type approveCmdOptions struct {
+	*RootCmdOptions
+	image string
+}
+
+func (o *approveCmdOptions) validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("approve expects exactly one integration name")
+	}
+
+	return nil
+}
+
+func (o *approveCmdOptions) run(args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	integration := v1alpha1.NewIntegration(o.Namespace, kubernetes.SanitizeName(args[0]))
+	if err := c.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: integration.Name}, &integration); err != nil {
+		return err
+	}
+
+	image := o.image
+	if image == "" {
+		if v1alpha1.GetCondition(integration.Status.Conditions, v1alpha1.ConditionWaitingForApproval) == nil {
+			return fmt.Errorf("integration %s is not waiting for approval", integration.Name)
+		}
+		image = integration.Status.Image
+	}
+
+	if integration.Annotations == nil {
+		integration.Annotations = make(map[string]string)
+	}
+	integration.Annotations[v1alpha1.IntegrationApprovedImageAnnotation] = image
+
+	if err := c.Update(o.Context, &integration); err != nil {
+		return err
+	}
+
+	fmt.Printf("integration \"%s\" approved (image %s)\n", integration.Name, image)
+	return nil
+}
@@ -69,8 +69,13 @@ __kamel_dependency_type() {
         COMPREPLY=( $( compgen -W "${type_list}" -- "$cur") )
 		compopt -o nospace
         ;;
+    g*)
+        local type_list="github:"
+        COMPREPLY=( $( compgen -W "${type_list}" -- "$cur") )
+		compopt -o nospace
+        ;;
     *)
-        local type_list="camel mvn: file:"
+        local type_list="camel mvn: file: github:"
         COMPREPLY=( $( compgen -W "${type_list}" -- "$cur") )
 	    compopt -o nospace
     esac
@@ -169,6 +174,18 @@ __custom_func() {
             __kamel_kubectl_get_integrations
             return
             ;;
+        kamel_promote)
+            __kamel_kubectl_get_integrations
+            return
+            ;;
+        kamel_export)
+            __kamel_kubectl_get_integrations
+            return
+            ;;
+        kamel_wait)
+            __kamel_kubectl_get_integrations
+            return
+            ;;
         kamel_kit_delete)
             __kamel_kubectl_get_non_platform_integrationkits
             return
@@ -0,0 +1,156 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/spf13/cobra"
+)
+
+func newCmdDebugEnv(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "debug-env",
+		Short: "Troubleshoot the trait environments recorded by the operator",
+		Long: `Troubleshoot the trait environments recorded by the operator.
+
+The operator records the trait environments that fail to be applied when the trait-snapshots
+key of its configuration is set to configmap or file.`,
+	}
+
+	cmd.AddCommand(newDebugEnvReplayCmd(rootCmdOptions))
+
+	return &cmd
+}
+
+func newDebugEnvReplayCmd(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := debugEnvReplayCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "replay [snapshot-file]",
+		Short: "Apply the traits of a recorded trait environment again",
+		Long: `Apply the traits of a recorded trait environment again, locally, against the resources recorded in the snapshot.
+
+The snapshot is either read from a file, or from the ConfigMap the operator has recorded it in.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			return impl.run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&impl.configMap, "configmap", "", "Read the snapshot from the given ConfigMap of the namespace")
+	cmd.Flags().StringVarP(&impl.output, "output", "o", "", "Print the resources computed by the traits (yaml)")
+
+	return &cmd
+}
+
+type debugEnvReplayCmdOptions struct {
+	*RootCmdOptions
+	configMap string
+	output    string
+}
+
+func (o *debugEnvReplayCmdOptions) validate(args []string) error {
+	if len(args) > 1 {
+		return errors.New("replay expects at most one snapshot file")
+	}
+	if (len(args) == 1) == (o.configMap != "") {
+		return errors.New("replay expects either a snapshot file or a ConfigMap")
+	}
+	if o.output != "" && o.output != "yaml" {
+		return fmt.Errorf("invalid output format %s, should be yaml", o.output)
+	}
+
+	return nil
+}
+
+func (o *debugEnvReplayCmdOptions) run(args []string) error {
+	var data []byte
+	if len(args) == 1 {
+		content, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		data = content
+	} else {
+		c, err := o.GetCmdClient()
+		if err != nil {
+			return err
+		}
+		cm, err := kubernetes.GetConfigMap(o.Context, c, o.configMap, o.Namespace)
+		if err != nil {
+			return err
+		}
+		if cm.Data[trait.TraitSnapshotKey] == "" {
+			return fmt.Errorf("no trait snapshot found in ConfigMap %s", o.configMap)
+		}
+		data = []byte(cm.Data[trait.TraitSnapshotKey])
+	}
+
+	snapshot, err := trait.LoadSnapshot(data)
+	if err != nil {
+		return err
+	}
+
+	return o.replay(snapshot, os.Stdout)
+}
+
+// replay applies the traits of the snapshot again and reports the outcome
+func (o *debugEnvReplayCmdOptions) replay(snapshot *trait.Snapshot, out io.Writer) error {
+	fmt.Fprintf(out, "Recorded error: %s\n", snapshot.Error)
+	if len(snapshot.Environment.ExecutedTraits) > 0 {
+		fmt.Fprintf(out, "Recorded executed traits: %v\n", snapshot.Environment.ExecutedTraits)
+	}
+
+	env, err := snapshot.Replay(o.Context)
+	if err != nil {
+		fmt.Fprintf(out, "Replay error: %s\n", err.Error())
+		return nil
+	}
+
+	traits := make([]string, 0, len(env.ExecutedTraits))
+	for _, t := range env.ExecutedTraits {
+		traits = append(traits, string(t.ID()))
+	}
+	fmt.Fprintf(out, "Replay succeeded, executed traits: %v\n", traits)
+
+	for _, res := range env.Resources.Items() {
+		if o.output == "" {
+			fmt.Fprintf(out, "  %s\n", kubernetes.FindResourceDetails(res))
+			continue
+		}
+
+		data, err := kubernetes.ToYAML(res)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "---\n%s", string(data))
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDebugEnvReplay(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	p := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	p.Status.Phase = v1alpha1.IntegrationPlatformPhaseReady
+	p.Spec.Cluster = v1alpha1.IntegrationPlatformClusterKubernetes
+	p.Spec.Profile = v1alpha1.TraitProfileKubernetes
+	p.Spec.Build.CamelVersion = catalog.Version
+
+	integration := v1alpha1.NewIntegration("ns", "my-route")
+	integration.Status.Phase = v1alpha1.IntegrationPhaseDeploying
+	integration.Status.Kit = "my-kit"
+
+	kit := v1alpha1.NewIntegrationKit("ns", "my-kit")
+	kit.Status.Phase = v1alpha1.IntegrationKitPhaseReady
+	kit.Status.Image = "registry/my-kit:1"
+
+	snapshot := trait.Snapshot{
+		Error:          "recorded failure",
+		Integration:    &integration,
+		IntegrationKit: &kit,
+		Platform:       &p,
+		CamelCatalog:   &catalog.CamelCatalogSpec,
+		Environment: trait.SnapshotEnvironment{
+			ExecutedTraits: []string{"camel"},
+		},
+	}
+
+	options := debugEnvReplayCmdOptions{
+		RootCmdOptions: &RootCmdOptions{Context: context.TODO(), Namespace: "ns"},
+	}
+
+	out := bytes.Buffer{}
+	assert.Nil(t, options.replay(&snapshot, &out))
+	assert.Contains(t, out.String(), "Recorded error: recorded failure")
+	assert.Contains(t, out.String(), "Recorded executed traits: [camel]")
+	assert.Contains(t, out.String(), "Replay succeeded")
+	assert.Contains(t, out.String(), "Deployment my-route")
+
+	// the replay errors are reported
+	snapshot.TraitsConfigMap = &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        trait.TraitsConfigMapName,
+			Annotations: map[string]string{trait.TraitsPrecedenceAnnotation: "always"},
+		},
+	}
+
+	out.Reset()
+	assert.Nil(t, options.replay(&snapshot, &out))
+	assert.Contains(t, out.String(), "Replay error:")
+}
+
+func TestDebugEnvReplayValidation(t *testing.T) {
+	options := debugEnvReplayCmdOptions{
+		RootCmdOptions: &RootCmdOptions{Namespace: "ns"},
+	}
+
+	assert.Nil(t, options.validate([]string{"snapshot.yaml"}))
+	assert.NotNil(t, options.validate(nil))
+	assert.NotNil(t, options.validate([]string{"a.yaml", "b.yaml"}))
+
+	options.configMap = "my-route-trait-snapshot"
+	assert.Nil(t, options.validate(nil))
+	assert.NotNil(t, options.validate([]string{"snapshot.yaml"}))
+
+	options.output = "json"
+	assert.NotNil(t, options.validate(nil))
+}
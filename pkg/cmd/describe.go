@@ -92,6 +92,19 @@ func describeTraits(w *indentedWriter, traits map[string]v1alpha1.TraitSpec) {
 	}
 }
 
+func describeConditions(w *indentedWriter, conditions []v1alpha1.Condition) {
+	if len(conditions) > 0 {
+		w.write(0, "Conditions:\n")
+
+		for _, condition := range conditions {
+			w.write(1, "Type:\t%s\n", condition.Type)
+			w.write(1, "Status:\t%s\n", condition.Status)
+			w.write(1, "Reason:\t%s\n", condition.Reason)
+			w.write(1, "Message:\t%s\n", condition.Message)
+		}
+	}
+}
+
 func indentedString(f func(io.Writer)) string {
 	out := new(tabwriter.Writer)
 	buf := &bytes.Buffer{}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/spf13/cobra"
@@ -94,6 +95,9 @@ func (command *describeIntegrationCommand) describeIntegration(i v1alpha1.Integr
 		w.write(0, "Camel Version:\t%s\n", i.Status.CamelVersion)
 		w.write(0, "Kit:\t%s\n", i.Status.Kit)
 		w.write(0, "Image:\t%s\n", i.Status.Image)
+		if i.Status.ImageDigest != "" {
+			w.write(0, "Image Digest:\t%s\n", i.Status.ImageDigest)
+		}
 
 		if len(i.Spec.Configuration) > 0 {
 			w.write(0, "Configuration:\n")
@@ -110,6 +114,27 @@ func (command *describeIntegrationCommand) describeIntegration(i v1alpha1.Integr
 			}
 		}
 
+		if len(i.Spec.Libraries) > 0 {
+			w.write(0, "Libraries:\n")
+			for _, library := range i.Spec.Libraries {
+				w.write(1, "%s\n", library)
+			}
+		}
+
+		if len(i.Spec.DependsOn) > 0 {
+			w.write(0, "Depends On:\n")
+			for _, ref := range i.Spec.DependsOn {
+				w.write(1, "%s/%s/%s\n", ref.APIVersion, ref.Kind, ref.Name)
+			}
+		}
+
+		if len(i.Spec.InitContainers) > 0 {
+			w.write(0, "Init Containers:\n")
+			for _, container := range i.Spec.InitContainers {
+				w.write(1, "%s\t%s\n", container.Name, container.Image)
+			}
+		}
+
 		if len(i.Spec.Repositories) > 0 {
 			w.write(0, "Repositories:\n")
 			for _, repository := range i.Spec.Repositories {
@@ -137,5 +162,43 @@ func (command *describeIntegrationCommand) describeIntegration(i v1alpha1.Integr
 		}
 
 		describeTraits(w, i.Spec.Traits)
+		describeConditions(w, i.Status.Conditions)
+		describePhaseTransitions(w, i.Status, time.Now())
+
+		if len(i.Status.Routes) > 0 {
+			w.write(0, "Routes:\n")
+			for _, route := range i.Status.Routes {
+				w.write(1, "ID:\t%s\n", route.ID)
+				w.write(1, "State:\t%s\n", route.State)
+				w.write(1, "Exchanges Completed:\t%d\n", route.ExchangesCompleted)
+				w.write(1, "Exchanges Failed:\t%d\n", route.ExchangesFailed)
+				w.write(1, "Mean Processing Time:\t%dms\n", route.MeanProcessingTime)
+			}
+		}
 	})
 }
+
+// describePhaseTransitions prints the time spent by the integration in each phase since it has been last
+// initialized, the current phase reporting the time elapsed so far
+func describePhaseTransitions(w *indentedWriter, status v1alpha1.IntegrationStatus, now time.Time) {
+	if len(status.PhaseTransitions) == 0 {
+		return
+	}
+
+	w.write(0, "Phases:\n")
+	for i, t := range status.PhaseTransitions {
+		phase := string(t.Phase)
+		if t.Phase == v1alpha1.IntegrationPhaseInitial {
+			phase = "Initialization"
+		}
+		end := now
+		if i < len(status.PhaseTransitions)-1 {
+			end = status.PhaseTransitions[i+1].Time.Time
+		}
+		w.write(1, "%s:\t%s\n", phase, end.Sub(t.Time.Time).Round(time.Second))
+	}
+
+	if elapsed, ok := status.TimeToRunning(); ok {
+		w.write(0, "Time To Running:\t%s\n", elapsed.Round(time.Second))
+	}
+}
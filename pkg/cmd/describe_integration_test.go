@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDescribePhaseTransitions(t *testing.T) {
+	start := time.Date(2019, 6, 1, 10, 0, 0, 0, time.UTC)
+	status := v1alpha1.IntegrationStatus{
+		Phase: v1alpha1.IntegrationPhaseRunning,
+		PhaseTransitions: []v1alpha1.IntegrationPhaseTransition{
+			{Phase: v1alpha1.IntegrationPhaseInitial, Time: metav1.NewTime(start)},
+			{Phase: v1alpha1.IntegrationPhaseBuildingKit, Time: metav1.NewTime(start.Add(2 * time.Second))},
+			{Phase: v1alpha1.IntegrationPhaseDeploying, Time: metav1.NewTime(start.Add(62 * time.Second))},
+			{Phase: v1alpha1.IntegrationPhaseRunning, Time: metav1.NewTime(start.Add(72 * time.Second))},
+		},
+	}
+
+	var out bytes.Buffer
+	w := newIndentedWriter(&out)
+	describePhaseTransitions(w, status, start.Add(10*time.Minute))
+	w.Flush()
+
+	assert.Regexp(t, `Initialization:\s+2s`, out.String())
+	assert.Regexp(t, `Building Kit:\s+1m0s`, out.String())
+	assert.Regexp(t, `Deploying:\s+10s`, out.String())
+	assert.Regexp(t, `Running:\s+8m48s`, out.String())
+	assert.Regexp(t, `Time To Running:\s+1m12s`, out.String())
+	assert.Equal(t, "1m12s", timeToRunning(status))
+	assert.Equal(t, "-", timeToRunning(v1alpha1.IntegrationStatus{}))
+}
@@ -23,7 +23,6 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/spf13/cobra"
-	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func newDescribeKitCmd(rootCmdOptions *RootCmdOptions) *cobra.Command {
@@ -68,14 +67,8 @@ func (command *describeKitCommand) run(args []string) error {
 		return err
 	}
 
-	kit := v1alpha1.NewIntegrationKit(command.Namespace, args[0])
-	kitKey := k8sclient.ObjectKey{
-		Namespace: command.Namespace,
-		Name:      args[0],
-	}
-
-	if err := c.Get(command.Context, kitKey, &kit); err == nil {
-		fmt.Print(command.describeIntegrationKit(kit))
+	if kit, err := lookupKit(command.Context, c, command.Namespace, args[0]); err == nil {
+		fmt.Print(command.describeIntegrationKit(*kit))
 	} else {
 		fmt.Printf("IntegrationKit '%s' does not exist.\n", args[0])
 	}
@@ -89,9 +82,19 @@ func (command *describeKitCommand) describeIntegrationKit(kit v1alpha1.Integrati
 
 		describeObjectMeta(w, kit.ObjectMeta)
 
+		if alias := kit.Labels[v1alpha1.IntegrationKitAliasLabel]; alias != "" {
+			w.write(0, "Alias:\t%s\n", alias)
+		}
+
 		w.write(0, "Phase:\t%s\n", kit.Status.Phase)
 		w.write(0, "Camel Version:\t%s\n", kit.Status.CamelVersion)
 		w.write(0, "Image:\t%s\n", kit.Status.Image)
+		if kit.Status.ImageSize > 0 {
+			w.write(0, "Image Size:\t%s\n", formatImageSize(kit.Status.ImageSize))
+		}
+		if kit.Status.ImageStreamTag != "" {
+			w.write(0, "Image Stream Tag:\t%s\n", kit.Status.ImageStreamTag)
+		}
 
 		if len(kit.Status.Artifacts) > 0 {
 			w.write(0, "Artifacts:\t\n")
@@ -123,5 +126,6 @@ func (command *describeKitCommand) describeIntegrationKit(kit v1alpha1.Integrati
 		}
 
 		describeTraits(w, kit.Spec.Traits)
+		describeConditions(w, kit.Status.Conditions)
 	})
 }
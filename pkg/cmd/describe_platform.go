@@ -88,6 +88,9 @@ func (command *describePlatformCommand) describeIntegrationPlatform(platform v1a
 		describeObjectMeta(w, platform.ObjectMeta)
 		w.write(0, "Phase:\t%s\n", platform.Status.Phase)
 		w.write(0, "Base Image:\t%s\n", platform.Spec.Build.BaseImage)
+		if platform.Spec.FIPS {
+			w.write(0, "FIPS:\t%t\n", platform.Spec.FIPS)
+		}
 		w.write(0, "Camel Version:\t%s\n", platform.Spec.Build.CamelVersion)
 		w.write(0, "Local Repository:\t%s\n", platform.Spec.Build.LocalRepository)
 		w.write(0, "Publish Strategy:\t%s\n", platform.Spec.Build.PublishStrategy)
@@ -99,5 +102,13 @@ func (command *describePlatformCommand) describeIntegrationPlatform(platform v1a
 				w.write(2, "%s\n", kit)
 			}
 		}
+
+		quota := platform.Spec.Quota
+		if quota.MaxRunningBuilds > 0 || quota.MaxKits > 0 || quota.MaxIntegrations > 0 {
+			w.write(0, "Quota:\n")
+			w.write(1, "Max Running Builds:\t%d\n", quota.MaxRunningBuilds)
+			w.write(1, "Max Kits:\t%d\n", quota.MaxKits)
+			w.write(1, "Max Integrations:\t%d\n", quota.MaxIntegrations)
+		}
 	})
 }
@@ -0,0 +1,313 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/gzip"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/cancellable"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/maven"
+	"github.com/spf13/cobra"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	exportFormatKubernetes = "k8s"
+	exportFormatMaven      = "maven"
+)
+
+func newCmdExport(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := exportCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "export integration",
+		Short: "Export an integration as plain Kubernetes resources or as a Maven project",
+		Long: `Export an integration as plain Kubernetes resources or as a Maven project.
+
+The k8s format prints the resources generated for the integration, that can be deployed without the operator.
+The maven format generates a project reproducing the integration kit build, including the integration resources
+and configuration, that runs the integration with mvn exec:exec.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			return impl.run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&impl.format, "format", exportFormatKubernetes, "Export format (k8s|maven)")
+	cmd.Flags().StringVarP(&impl.output, "output", "o", "", "The directory where the maven project is generated (defaults to the integration name)")
+
+	return &cmd
+}
+
+type exportCmdOptions struct {
+	*RootCmdOptions
+	format string
+	output string
+}
+
+func (o *exportCmdOptions) validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("export expects exactly one integration name")
+	}
+	if o.format != exportFormatKubernetes && o.format != exportFormatMaven {
+		return fmt.Errorf("invalid format %s, should be one of: %s|%s", o.format, exportFormatKubernetes, exportFormatMaven)
+	}
+
+	return nil
+}
+
+func (o *exportCmdOptions) run(args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	integration := v1alpha1.NewIntegration(o.Namespace, kubernetes.SanitizeName(args[0]))
+	if err := c.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: integration.Name}, &integration); err != nil {
+		return err
+	}
+	if integration.Status.Kit == "" {
+		return fmt.Errorf("integration %s has no kit yet (phase=%s)", integration.Name, integration.Status.Phase)
+	}
+
+	kit := v1alpha1.NewIntegrationKit(o.Namespace, integration.Status.Kit)
+	if err := c.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: kit.Name}, &kit); err != nil {
+		return err
+	}
+
+	if o.format == exportFormatMaven {
+		dir := o.output
+		if dir == "" {
+			dir = integration.Name
+		}
+		if err := o.exportMaven(c, &integration, &kit, dir); err != nil {
+			return err
+		}
+
+		fmt.Printf("integration \"%s\" exported to %s\n", integration.Name, dir)
+		return nil
+	}
+
+	return o.exportKubernetes(c, &integration, &kit, os.Stdout)
+}
+
+// exportKubernetes prints the resources the operator would create to deploy the integration
+func (o *exportCmdOptions) exportKubernetes(c client.Client, integration *v1alpha1.Integration, kit *v1alpha1.IntegrationKit, out io.Writer) error {
+	if kit.Status.Phase != v1alpha1.IntegrationKitPhaseReady {
+		return fmt.Errorf("integration kit %s is not ready (phase=%s)", kit.Name, kit.Status.Phase)
+	}
+
+	target := integration.DeepCopy()
+	target.Status.Phase = v1alpha1.IntegrationPhaseDeploying
+
+	env, err := trait.Apply(o.Context, c, target, kit)
+	if err != nil {
+		return err
+	}
+
+	// resources are not owned by the integration anymore
+	env.Resources.VisitMetaObject(func(res metav1.Object) {
+		res.SetOwnerReferences(nil)
+	})
+
+	for _, res := range env.Resources.Items() {
+		data, err := kubernetes.ToYAML(res)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(out, "---\n%s", string(data)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// exportMaven generates a maven project with the dependencies of the integration kit, the integration sources
+// and resources, and the configuration the operator would mount in the integration container
+func (o *exportCmdOptions) exportMaven(c client.Client, integration *v1alpha1.Integration, kit *v1alpha1.IntegrationKit, dir string) error {
+	pl, err := platform.GetCurrentPlatform(o.Context, c, o.Namespace)
+	if err != nil {
+		return err
+	}
+
+	catalog, err := camel.Catalog(o.Context, c, o.Namespace, kit.Status.CamelVersion)
+	if err != nil {
+		return err
+	}
+
+	ctx := builder.Context{
+		Client:    c,
+		C:         cancellable.NewContextWithParent(o.Context),
+		Catalog:   catalog,
+		Namespace: o.Namespace,
+		Build: v1alpha1.BuildSpec{
+			CamelVersion:   kit.Status.CamelVersion,
+			RuntimeVersion: kit.Status.RuntimeVersion,
+			Platform:       pl.Spec,
+			Dependencies:   kit.Spec.Dependencies,
+		},
+	}
+
+	steps := []builder.Step{
+		builder.Steps.GenerateProject,
+		builder.Steps.GenerateProjectSettings,
+		builder.Steps.InjectDependencies,
+		builder.Steps.SanitizeDependencies,
+	}
+	for _, step := range steps {
+		if err := step.Execute(&ctx); err != nil {
+			return err
+		}
+	}
+
+	sources, err := kubernetes.ResolveIntegrationSources(o.Context, c, integration, kubernetes.NewCollection())
+	if err != nil {
+		return err
+	}
+
+	mc := maven.NewContext(dir, ctx.Maven.Project)
+	mc.Settings = ctx.Maven.Settings
+	mc.SettingsData = ctx.Maven.SettingsData
+
+	routes := make([]string, 0, len(sources))
+	for _, s := range sources {
+		content := []byte(s.Content)
+		if s.Compression {
+			if content, err = gzip.UncompressBase64(content); err != nil {
+				return err
+			}
+		}
+
+		name := strings.TrimPrefix(s.Name, "/")
+		mc.AdditionalEntries[path.Join("src", "main", "resources", "sources", name)] = content
+
+		route := "classpath:sources/" + name
+		if s.InferLanguage() != "" {
+			route += "?language=" + string(s.InferLanguage())
+		}
+		routes = append(routes, route)
+	}
+
+	resources, err := kubernetes.ResolveIntegrationResources(o.Context, c, integration, kubernetes.NewCollection())
+	if err != nil {
+		return err
+	}
+	for _, r := range resources {
+		if r.Type != v1alpha1.ResourceTypeData {
+			continue
+		}
+		content := []byte(r.Content)
+		if r.Compression {
+			if content, err = gzip.UncompressBase64(content); err != nil {
+				return err
+			}
+		}
+		mc.AdditionalEntries[path.Join("src", "main", "resources", strings.TrimPrefix(r.Name, "/"))] = content
+	}
+
+	// the properties are loaded from the same locations as in the integration container, relatively to the project
+	pairs := trait.CollectConfigurationPairs("property", pl, kit, integration)
+	properties := ""
+	for _, key := range sortedKeys(pairs) {
+		properties += fmt.Sprintf("%s=%s\n", key, pairs[key])
+	}
+	mc.AdditionalEntries[path.Join("src", "main", "conf", "application.properties")] = []byte(properties)
+
+	for _, name := range trait.CollectConfigurationValues("configmap", pl, kit, integration) {
+		cm, err := kubernetes.GetConfigMap(o.Context, c, name, integration.Namespace)
+		if err != nil {
+			return err
+		}
+		for key, val := range cm.Data {
+			mc.AdditionalEntries[path.Join("src", "main", "conf.d", "integration-cm-"+strings.ToLower(name), key)] = []byte(val)
+		}
+	}
+	for _, name := range trait.CollectConfigurationValues("secret", pl, kit, integration) {
+		secret, err := kubernetes.GetSecret(o.Context, c, name, integration.Namespace)
+		if err != nil {
+			return err
+		}
+		for key, val := range secret.Data {
+			mc.AdditionalEntries[path.Join("src", "main", "conf.d", "integration-secret-"+strings.ToLower(name), key)] = val
+		}
+	}
+
+	env := trait.CollectConfigurationPairs("env", pl, kit, integration)
+	env["CAMEL_K_ROUTES"] = strings.Join(routes, ",")
+	env["CAMEL_K_CONF"] = "${project.basedir}/src/main/conf/application.properties"
+	env["CAMEL_K_CONF_D"] = "${project.basedir}/src/main/conf.d"
+
+	mc.Project.AddPlugin(execPlugin(env))
+
+	return maven.GenerateProjectStructure(mc)
+}
+
+// execPlugin configures the exec plugin so the integration can be run using mvn exec:exec
+func execPlugin(env map[string]string) maven.Plugin {
+	variables := make([]maven.ConfigurationValue, 0, len(env))
+	for _, name := range sortedKeys(env) {
+		variables = append(variables, maven.NewConfigurationValue(name, env[name]))
+	}
+
+	return maven.Plugin{
+		GroupID:    "org.codehaus.mojo",
+		ArtifactID: "exec-maven-plugin",
+		Version:    "1.6.0",
+		Configuration: &maven.Configuration{
+			Values: []maven.ConfigurationValue{
+				maven.NewConfigurationValue("executable", "java"),
+				maven.NewConfigurationNode("arguments",
+					maven.NewConfigurationValue("argument", "-classpath"),
+					maven.NewConfigurationValue("classpath", ""),
+					maven.NewConfigurationValue("argument", "org.apache.camel.k.jvm.Application"),
+				),
+				maven.NewConfigurationNode("environmentVariables", variables...),
+			},
+		},
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,160 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportMaven(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	tmpDir, err := ioutil.TempDir("", "export-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	platform := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	platform.Status.Phase = v1alpha1.IntegrationPlatformPhaseReady
+
+	integration := v1alpha1.NewIntegration("ns", "my-route")
+	integration.Spec.Sources = []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "routes.groovy",
+				Content: "from('timer:tick').to('log:info')",
+			},
+		},
+	}
+	integration.Spec.Resources = []v1alpha1.ResourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "greetings.txt",
+				Content: "hello",
+			},
+			Type: v1alpha1.ResourceTypeData,
+		},
+	}
+	integration.Spec.Configuration = []v1alpha1.ConfigurationSpec{
+		{Type: "property", Value: "my.message=hello"},
+		{Type: "env", Value: "MY_ENV=my-value"},
+		{Type: "configmap", Value: "my-cm"},
+	}
+	integration.Status.Kit = "kit-123"
+
+	kit := v1alpha1.NewIntegrationKit("ns", "kit-123")
+	kit.Spec.Dependencies = []string{"camel:log", "camel:timer", "runtime:groovy"}
+	kit.Status.CamelVersion = catalog.Version
+	kit.Status.RuntimeVersion = defaults.RuntimeVersion
+
+	c, err := test.NewFakeClient(
+		&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "my-cm",
+			},
+			Data: map[string]string{
+				"my.properties": "my.other.message=world",
+			},
+		},
+		&platform,
+		&integration,
+		&kit,
+		&v1alpha1.CamelCatalog{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       v1alpha1.CamelCatalogKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "camel-catalog-" + catalog.Version,
+			},
+			Spec: catalog.CamelCatalogSpec,
+		},
+	)
+	assert.Nil(t, err)
+
+	options := exportCmdOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "ns",
+			_client:   c,
+		},
+		format: exportFormatMaven,
+		output: tmpDir,
+	}
+
+	assert.Nil(t, options.validate([]string{"my-route"}))
+	assert.Nil(t, options.run([]string{"my-route"}))
+
+	pom, err := ioutil.ReadFile(path.Join(tmpDir, "pom.xml"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(pom), "<artifactId>camel-k-runtime-groovy</artifactId>")
+	assert.Contains(t, string(pom), "<artifactId>camel-timer</artifactId>")
+	assert.Contains(t, string(pom), "<artifactId>exec-maven-plugin</artifactId>")
+	assert.Contains(t, string(pom), "<CAMEL_K_ROUTES>classpath:sources/routes.groovy?language=groovy</CAMEL_K_ROUTES>")
+	assert.Contains(t, string(pom), "<CAMEL_K_CONF>${project.basedir}/src/main/conf/application.properties</CAMEL_K_CONF>")
+	assert.Contains(t, string(pom), "<CAMEL_K_CONF_D>${project.basedir}/src/main/conf.d</CAMEL_K_CONF_D>")
+	assert.Contains(t, string(pom), "<MY_ENV>my-value</MY_ENV>")
+
+	source, err := ioutil.ReadFile(path.Join(tmpDir, "src", "main", "resources", "sources", "routes.groovy"))
+	assert.Nil(t, err)
+	assert.Equal(t, "from('timer:tick').to('log:info')", string(source))
+
+	resource, err := ioutil.ReadFile(path.Join(tmpDir, "src", "main", "resources", "greetings.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", string(resource))
+
+	properties, err := ioutil.ReadFile(path.Join(tmpDir, "src", "main", "conf", "application.properties"))
+	assert.Nil(t, err)
+	assert.Equal(t, "my.message=hello\n", string(properties))
+
+	cm, err := ioutil.ReadFile(path.Join(tmpDir, "src", "main", "conf.d", "integration-cm-my-cm", "my.properties"))
+	assert.Nil(t, err)
+	assert.Equal(t, "my.other.message=world", string(cm))
+}
+
+func TestExportValidation(t *testing.T) {
+	options := exportCmdOptions{
+		RootCmdOptions: &RootCmdOptions{Namespace: "ns"},
+		format:         exportFormatKubernetes,
+	}
+
+	assert.Nil(t, options.validate([]string{"my-route"}))
+	assert.NotNil(t, options.validate(nil))
+
+	options.format = "helm"
+	assert.NotNil(t, options.validate([]string{"my-route"}))
+}
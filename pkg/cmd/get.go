@@ -20,7 +20,9 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -31,6 +33,7 @@ import (
 
 type getCmdOptions struct {
 	*RootCmdOptions
+	OutputFormat string
 }
 
 func newCmdGet(rootCmdOptions *RootCmdOptions) *cobra.Command {
@@ -44,6 +47,10 @@ func newCmdGet(rootCmdOptions *RootCmdOptions) *cobra.Command {
 		RunE:  options.run,
 	}
 
+	cmd.Flags().StringVarP(&options.OutputFormat, "output", "o", "", "Output format. One of: wide")
+
+	cmd.AddCommand(newCmdGetPlatform(rootCmdOptions))
+
 	return &cmd
 }
 
@@ -68,11 +75,57 @@ func (o *getCmdOptions) run(_ *cobra.Command, _ []string) error {
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
-	fmt.Fprintln(w, "NAME\tPHASE\tCONTEXT")
-	for _, integration := range integrationList.Items {
-		fmt.Fprintf(w, "%s\t%s\t%s\n", integration.Name, string(integration.Status.Phase), integration.Status.Kit)
+	switch o.OutputFormat {
+	case "":
+		fmt.Fprintln(w, "NAME\tPHASE\tCONTEXT")
+		for _, integration := range integrationList.Items {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", integration.Name, integrationPhase(integration), integration.Status.Kit)
+		}
+	case "wide":
+		fmt.Fprintln(w, "NAME\tPHASE\tCONTEXT\tROUTES\tFAILED\tTIME TO RUNNING")
+		for _, integration := range integrationList.Items {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", integration.Name, integrationPhase(integration), integration.Status.Kit,
+				len(integration.Status.Routes), failedRoutes(integration.Status.Routes), timeToRunning(integration.Status))
+		}
+	default:
+		return fmt.Errorf("invalid output format option '%s', should be: wide", o.OutputFormat)
 	}
 	w.Flush()
 
 	return nil
 }
+
+// integrationPhase returns the phase of the integration, followed by the reason its generated resources fail
+// to run if any, e.g. "Running (ImagePullBackOff)"
+func integrationPhase(integration v1alpha1.Integration) string {
+	if c := v1alpha1.GetCondition(integration.Status.Conditions, v1alpha1.ConditionDeploymentFailed); c != nil {
+		return fmt.Sprintf("%s (%s)", integration.Status.Phase, c.Reason)
+	}
+	return string(integration.Status.Phase)
+}
+
+// failedRoutes summarizes the failed exchanges of the routes, e.g. "3 (route1=2, route2=1)"
+func failedRoutes(routes []v1alpha1.RouteStatus) string {
+	total := int64(0)
+	details := make([]string, 0)
+	for _, r := range routes {
+		if r.ExchangesFailed > 0 {
+			total += r.ExchangesFailed
+			details = append(details, fmt.Sprintf("%s=%d", r.ID, r.ExchangesFailed))
+		}
+	}
+
+	if total == 0 {
+		return "0"
+	}
+
+	return fmt.Sprintf("%d (%s)", total, strings.Join(details, ", "))
+}
+
+// timeToRunning returns the time the integration took to get running, or "-" if it's not running yet
+func timeToRunning(status v1alpha1.IntegrationStatus) string {
+	if elapsed, ok := status.TimeToRunning(); ok {
+		return elapsed.Round(time.Second).String()
+	}
+	return "-"
+}
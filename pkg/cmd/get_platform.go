@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/spf13/cobra"
+)
+
+type getPlatformCmdOptions struct {
+	*RootCmdOptions
+	OutputFormat string
+}
+
+func newCmdGetPlatform(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	options := getPlatformCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "platform",
+		Short: "Get the integration platforms",
+		Long:  `Get the status of the integration platforms, including their build queue and registry health.`,
+		RunE:  options.run,
+	}
+
+	cmd.Flags().StringVarP(&options.OutputFormat, "output", "o", "", "Output format. One of: wide")
+
+	return &cmd
+}
+
+func (o *getPlatformCmdOptions) run(_ *cobra.Command, _ []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	platformList := v1alpha1.NewIntegrationPlatformList()
+	if err := c.List(o.Context, &k8sclient.ListOptions{Namespace: o.Namespace}, &platformList); err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	switch o.OutputFormat {
+	case "":
+		fmt.Fprintln(w, "NAME\tPHASE\tQUEUED\tRUNNING")
+		for _, p := range platformList.Items {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", p.Name, string(p.Status.Phase), p.Status.Builds.Queued, p.Status.Builds.Running)
+		}
+	case "wide":
+		fmt.Fprintln(w, "NAME\tPHASE\tQUEUED\tRUNNING\tLAST BUILD\tREGISTRY\tCATALOGS")
+		for _, p := range platformList.Items {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%s\t%s\t%s\n", p.Name, string(p.Status.Phase), p.Status.Builds.Queued, p.Status.Builds.Running,
+				lastBuildDuration(p.Status.Builds), registryHealth(p.Status.Registry), catalogVersions(p.Status.CatalogVersions))
+		}
+	default:
+		return fmt.Errorf("invalid output format option '%s', should be: wide", o.OutputFormat)
+	}
+	w.Flush()
+
+	return nil
+}
+
+// lastBuildDuration returns the duration of the last completed build, or "-" if no build has completed
+func lastBuildDuration(builds v1alpha1.IntegrationPlatformBuildsStatus) string {
+	if d, err := time.ParseDuration(builds.LastDuration); err == nil {
+		return d.Round(time.Second).String()
+	}
+	return "-"
+}
+
+// registryHealth summarizes the outcome of the last registry probe, e.g. "Unhealthy (connection refused)"
+func registryHealth(registry *v1alpha1.IntegrationPlatformRegistryStatus) string {
+	switch {
+	case registry == nil:
+		return "-"
+	case registry.Healthy:
+		return "Healthy"
+	case registry.Message != "":
+		return fmt.Sprintf("Unhealthy (%s)", registry.Message)
+	default:
+		return "Unhealthy"
+	}
+}
+
+// catalogVersions returns the Camel versions of the available catalogs, or "-" if none is available
+func catalogVersions(versions []string) string {
+	if len(versions) == 0 {
+		return "-"
+	}
+	return strings.Join(versions, ",")
+}
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastBuildDuration(t *testing.T) {
+	assert.Equal(t, "-", lastBuildDuration(v1alpha1.IntegrationPlatformBuildsStatus{}))
+	assert.Equal(t, "1m23s", lastBuildDuration(v1alpha1.IntegrationPlatformBuildsStatus{LastDuration: "1m22.876s"}))
+}
+
+func TestRegistryHealth(t *testing.T) {
+	assert.Equal(t, "-", registryHealth(nil))
+	assert.Equal(t, "Healthy", registryHealth(&v1alpha1.IntegrationPlatformRegistryStatus{Healthy: true}))
+	assert.Equal(t, "Unhealthy (connection refused)", registryHealth(&v1alpha1.IntegrationPlatformRegistryStatus{Message: "connection refused"}))
+}
+
+func TestCatalogVersions(t *testing.T) {
+	assert.Equal(t, "-", catalogVersions(nil))
+	assert.Equal(t, "2.23.1,2.23.2", catalogVersions([]string{"2.23.1", "2.23.2"}))
+}
@@ -26,6 +26,8 @@ import (
 	"github.com/apache/camel-k/pkg/util"
 
 	"github.com/apache/camel-k/pkg/util/maven"
+	"github.com/apache/camel-k/pkg/util/proxy"
+	"github.com/apache/camel-k/pkg/util/scan"
 
 	"github.com/apache/camel-k/deploy"
 	"github.com/apache/camel-k/pkg/apis"
@@ -44,6 +46,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 func newCmdInstall(rootCmdOptions *RootCmdOptions) *cobra.Command {
@@ -61,8 +64,10 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	cmd.Flags().BoolVarP(&impl.wait, "wait", "w", false, "Waits for the platform to be running")
 	cmd.Flags().BoolVar(&impl.clusterSetupOnly, "cluster-setup", false, "Execute cluster-wide operations only (may require admin rights)")
 	cmd.Flags().BoolVar(&impl.skipOperatorSetup, "skip-operator-setup", false, "Do not install the operator in the namespace (in case there's a global one)")
+	cmd.Flags().BoolVar(&impl.admissionWebhook, "admission-webhook", false, "Enable the admission webhooks of the operator, that record the users creating and updating the resources and enforce the platform policy")
 	cmd.Flags().BoolVar(&impl.skipClusterSetup, "skip-cluster-setup", false, "Skip the cluster-setup phase")
 	cmd.Flags().BoolVar(&impl.exampleSetup, "example", false, "Install example integration")
+	cmd.Flags().StringVar(&impl.ciServiceAccount, "ci-serviceaccount", "", "Create a service account, with the given name, allowed to create and update the integrations of the namespace from CI pipelines")
 
 	cmd.Flags().StringVarP(&impl.outputFormat, "output", "o", "", "Output format. One of: json|yaml")
 	cmd.Flags().StringVar(&impl.registry.Organization, "organization", "", "A organization on the Docker registry that can be used to publish images")
@@ -73,15 +78,56 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	cmd.Flags().StringVar(&impl.camelVersion, "camel-version", "", "Set the camel version")
 	cmd.Flags().StringVar(&impl.runtimeVersion, "runtime-version", "", "Set the camel-k runtime version")
 	cmd.Flags().StringVar(&impl.baseImage, "base-image", "", "Set the base image used to run integrations")
+	cmd.Flags().StringSliceVar(&impl.provided.Artifacts, "provided-dependency", nil, "Add a groupId:artifactId (artifactId may be *) of the dependencies shipped by the base image, excluded from the integration kits")
+	cmd.Flags().StringSliceVar(&impl.provided.Classpath, "provided-classpath", nil, "Add a classpath entry of the base image where the provided dependencies are located, e.g. /opt/camel/lib/*")
+	cmd.Flags().StringVar(&impl.javaVersion, "java-version", "", "Set the java version used to build and run integrations (i.e. 8, 11)")
+	cmd.Flags().BoolVar(&impl.fips, "fips", false, "Build and run integrations with FIPS compliant base images, JVM flags and checksum algorithms")
 	cmd.Flags().StringVar(&impl.operatorImage, "operator-image", "", "Set the operator image used for the operator deployment")
 	cmd.Flags().StringSliceVar(&impl.kits, "kit", nil, "Add an integration kit to build at startup")
 	cmd.Flags().StringVar(&impl.buildStrategy, "build-strategy", "", "Set the build strategy")
+	cmd.Flags().StringVar(&impl.buildPublishStrategy, "build-publish-strategy", "", "Set the strategy used to build and publish the kit images (S2I|Kaniko|Buildah|Spectrum)")
+	cmd.Flags().StringVar(&impl.buildTool, "build-tool", "", "Set the tool used to compute the integration dependencies (maven|gradle)")
+	cmd.Flags().StringVar(&impl.classpathConflicts, "classpath-conflicts", "", "Detect the classpath conflicts at build time and set how they are handled (warn|fail|ignore)")
+	cmd.Flags().BoolVar(&impl.imageScan, "image-scan", false, "Scan the built images for vulnerabilities")
+	cmd.Flags().StringVar(&impl.imageScanEndpoint, "image-scan-endpoint", "", "Set the endpoint of the service used to scan the built images (trivy is used if not set)")
+	cmd.Flags().StringVar(&impl.imageScanSeverity, "image-scan-severity", "", "Fail builds whose image contains vulnerabilities at or above the given severity (UNKNOWN|LOW|MEDIUM|HIGH|CRITICAL)")
+	cmd.Flags().StringVar(&impl.imageScanInterval, "image-scan-interval", "", "Scan the images of the ready kits again at the given interval, to report newly disclosed vulnerabilities")
+	cmd.Flags().BoolVar(&impl.imageScanBlock, "image-scan-block-deployments", false, "Hold back the deployment of integrations whose kit contains vulnerabilities at or above the image scan severity")
 	cmd.Flags().StringVar(&impl.buildTimeout, "build-timeout", "", "Set how long the build process can last")
+	cmd.Flags().StringVar(&impl.buildNamespace, "build-namespace", "", "Run the builds in the given namespace, that must have its own operator and platform, instead of the integration namespace")
+	cmd.Flags().StringVar(&impl.sharedKits.Namespace, "shared-kit-namespace", "", "Look up the kits in the given namespace, where they are built once for all the namespaces served by a global operator")
+	cmd.Flags().StringVar(&impl.sharedKits.PullSecret, "shared-kit-pull-secret", "", "Set the secret, in the shared kit namespace, propagated to the namespaces pulling the shared kit images")
+	cmd.Flags().StringVar(&impl.tekton.Pipeline, "tekton-pipeline", "", "Delegate the image builds to the given Tekton pipeline")
+	cmd.Flags().StringVar(&impl.tekton.ServiceAccount, "tekton-service-account", "", "Set the service account the Tekton pipeline runs are executed with")
+	cmd.Flags().StringSliceVar(&impl.tektonParams, "tekton-param", nil, "Add a parameter passed to the Tekton pipeline runs, e.g. key=value")
+	cmd.Flags().StringVar(&impl.kitGCPolicy, "kit-gc-policy", "", "Set how the platform kits are garbage collected (Retain|Owned|Unused)")
+	cmd.Flags().StringVar(&impl.kitGCTTL, "kit-gc-ttl", "", "Set how long the platform kits are kept once unused with the Unused kit GC policy")
+	cmd.Flags().BoolVar(&impl.s2iIncremental, "s2i-incremental", false, "Reuse the artifacts of the previously built kit images with the S2I publish strategy")
+
+	// quota
+	cmd.Flags().IntVar(&impl.quota.MaxRunningBuilds, "quota-max-running-builds", 0, "Set the maximum number of builds running concurrently in the namespace, overriding the operator max-running-builds for the builds running in the namespace (0 means no limit)")
+	cmd.Flags().IntVar(&impl.quota.MaxKits, "quota-max-kits", 0, "Set the maximum number of integration kits in the namespace (0 means no limit)")
+	cmd.Flags().IntVar(&impl.quota.MaxIntegrations, "quota-max-integrations", 0, "Set the maximum number of integrations in the namespace (0 means no limit)")
+
+	// policy
+	cmd.Flags().StringVar(&impl.policy.NamePattern, "policy-name-pattern", "", "Set the regular expression the integration names have to match")
+	cmd.Flags().StringSliceVar(&impl.policy.RequiredLabels, "policy-required-label", nil, "Add a label the integrations are required to have, e.g. team")
+	cmd.Flags().StringVar(&impl.hardcodedCredentials, "policy-hardcoded-credentials", "", "Set how the credentials hard-coded in the integration sources are handled (warn|reject|ignore)")
+
+	// proxy
+	cmd.Flags().StringVar(&impl.proxy.HTTPProxy, "http-proxy", "", "Set the proxy used by the operator and the builders to access HTTP resources")
+	cmd.Flags().StringVar(&impl.proxy.HTTPSProxy, "https-proxy", "", "Set the proxy used by the operator and the builders to access HTTPS resources")
+	cmd.Flags().StringVar(&impl.proxy.NoProxy, "no-proxy", "", "Set the comma separated list of hosts, domains (.example.com) and CIDRs not to be proxied")
 
 	// maven settings
 	cmd.Flags().StringVar(&impl.localRepository, "local-repository", "", "Location of the local maven repository")
 	cmd.Flags().StringVar(&impl.mavenSettings, "maven-settings", "", "Configure the source of the maven settings (configmap|secret:name[/key])")
 	cmd.Flags().StringSliceVar(&impl.mavenRepositories, "maven-repository", nil, "Add a maven repository")
+	cmd.Flags().StringVar(&impl.mavenVersion, "maven-version", "", "Set the maven version (or version constraint) required to build integrations")
+	cmd.Flags().BoolVar(&impl.mavenVerifyChecksums, "maven-verify-checksums", false, "Verify the checksums of the artifacts used to build integrations, not supported by the gradle build tool")
+	cmd.Flags().BoolVar(&impl.mavenStrictChecksums, "maven-strict-checksums", false, "Fail the build when an artifact has no checksum to verify, instead of warning about it")
+	cmd.Flags().StringSliceVar(&impl.mavenAllowedGroups, "maven-allowed-group", nil, "Add a groupId (or group prefix like org.apache.*) artifacts are allowed to come from")
+	cmd.Flags().StringSliceVar(&impl.mavenDeniedGroups, "maven-denied-group", nil, "Add a groupId (or group prefix like org.apache.*) artifacts are not allowed to come from")
 
 	// completion support
 	configureBashAnnotationForFlag(
@@ -97,24 +143,53 @@ func newCmdInstall(rootCmdOptions *RootCmdOptions) *cobra.Command {
 
 type installCmdOptions struct {
 	*RootCmdOptions
-	wait              bool
-	clusterSetupOnly  bool
-	skipOperatorSetup bool
-	skipClusterSetup  bool
-	exampleSetup      bool
-	outputFormat      string
-	camelVersion      string
-	runtimeVersion    string
-	baseImage         string
-	operatorImage     string
-	localRepository   string
-	buildStrategy     string
-	buildTimeout      string
-	mavenRepositories []string
-	mavenSettings     string
-	properties        []string
-	kits              []string
-	registry          v1alpha1.IntegrationPlatformRegistrySpec
+	wait                 bool
+	clusterSetupOnly     bool
+	skipOperatorSetup    bool
+	admissionWebhook     bool
+	skipClusterSetup     bool
+	exampleSetup         bool
+	outputFormat         string
+	camelVersion         string
+	runtimeVersion       string
+	baseImage            string
+	javaVersion          string
+	fips                 bool
+	operatorImage        string
+	localRepository      string
+	buildStrategy        string
+	buildPublishStrategy string
+	buildTool            string
+	buildTimeout         string
+	buildNamespace       string
+	sharedKits           v1alpha1.IntegrationPlatformSharedKitsSpec
+	ciServiceAccount     string
+	kitGCPolicy          string
+	kitGCTTL             string
+	s2iIncremental       bool
+	classpathConflicts   string
+	imageScan            bool
+	imageScanEndpoint    string
+	imageScanSeverity    string
+	imageScanInterval    string
+	imageScanBlock       bool
+	mavenRepositories    []string
+	mavenSettings        string
+	mavenVersion         string
+	mavenVerifyChecksums bool
+	mavenStrictChecksums bool
+	mavenAllowedGroups   []string
+	mavenDeniedGroups    []string
+	properties           []string
+	kits                 []string
+	registry             v1alpha1.IntegrationPlatformRegistrySpec
+	quota                v1alpha1.IntegrationPlatformQuotaSpec
+	proxy                v1alpha1.IntegrationPlatformProxySpec
+	policy               v1alpha1.IntegrationPlatformPolicySpec
+	hardcodedCredentials string
+	provided             v1alpha1.IntegrationPlatformProvidedSpec
+	tekton               v1alpha1.IntegrationPlatformTektonSpec
+	tektonParams         []string
 }
 
 // nolint: gocyclo
@@ -152,7 +227,7 @@ func (o *installCmdOptions) install(_ *cobra.Command, _ []string) error {
 		namespace := o.Namespace
 
 		if !o.skipOperatorSetup {
-			err = install.OperatorOrCollect(o.Context, c, namespace, o.operatorImage, collection)
+			err = install.OperatorOrCollect(o.Context, c, namespace, o.operatorImage, o.admissionPort(), collection)
 			if err != nil {
 				return err
 			}
@@ -188,6 +263,17 @@ func (o *installCmdOptions) install(_ *cobra.Command, _ []string) error {
 		if o.baseImage != "" {
 			platform.Spec.Build.BaseImage = o.baseImage
 		}
+		if o.javaVersion != "" {
+			platform.Spec.Build.JavaVersion = o.javaVersion
+		}
+		platform.Spec.FIPS = o.fips
+		if o.mavenVersion != "" {
+			platform.Spec.Build.Maven.Version = o.mavenVersion
+		}
+		platform.Spec.Build.Maven.VerifyChecksums = o.mavenVerifyChecksums
+		platform.Spec.Build.Maven.StrictChecksums = o.mavenStrictChecksums
+		platform.Spec.Build.Maven.AllowedGroups = o.mavenAllowedGroups
+		platform.Spec.Build.Maven.DeniedGroups = o.mavenDeniedGroups
 		if o.buildStrategy != "" {
 			switch s := o.buildStrategy; s {
 			case v1alpha1.IntegrationPlatformBuildStrategyPod:
@@ -198,6 +284,54 @@ func (o *installCmdOptions) install(_ *cobra.Command, _ []string) error {
 				return fmt.Errorf("unknown build strategy: %s", s)
 			}
 		}
+		if o.buildPublishStrategy != "" {
+			switch s := o.buildPublishStrategy; s {
+			case v1alpha1.IntegrationPlatformBuildPublishStrategyS2I,
+				v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko,
+				v1alpha1.IntegrationPlatformBuildPublishStrategyBuildah,
+				v1alpha1.IntegrationPlatformBuildPublishStrategySpectrum:
+				platform.Spec.Build.PublishStrategy = v1alpha1.IntegrationPlatformBuildPublishStrategy(s)
+			default:
+				return fmt.Errorf("unknown build publish strategy: %s", s)
+			}
+		}
+		if o.buildTool != "" {
+			switch t := o.buildTool; t {
+			case v1alpha1.IntegrationPlatformBuildToolMaven:
+				platform.Spec.Build.BuildTool = v1alpha1.IntegrationPlatformBuildToolMaven
+			case v1alpha1.IntegrationPlatformBuildToolGradle:
+				platform.Spec.Build.BuildTool = v1alpha1.IntegrationPlatformBuildToolGradle
+			default:
+				return fmt.Errorf("unknown build tool: %s", t)
+			}
+		}
+		if o.imageScan || o.imageScanEndpoint != "" || o.imageScanSeverity != "" || o.imageScanInterval != "" || o.imageScanBlock {
+			if o.imageScanSeverity != "" {
+				if err := scan.ValidateSeverity(o.imageScanSeverity); err != nil {
+					return err
+				}
+			}
+			platform.Spec.Build.ImageScan = &v1alpha1.IntegrationPlatformImageScanSpec{
+				Endpoint:         o.imageScanEndpoint,
+				Severity:         o.imageScanSeverity,
+				BlockDeployments: o.imageScanBlock,
+			}
+			if o.imageScanInterval != "" {
+				d, err := time.ParseDuration(o.imageScanInterval)
+				if err != nil {
+					return err
+				}
+				platform.Spec.Build.ImageScan.Interval.Duration = d
+			}
+		}
+		if o.classpathConflicts != "" {
+			switch p := o.classpathConflicts; p {
+			case v1alpha1.IntegrationPlatformClasspathPolicyWarn, v1alpha1.IntegrationPlatformClasspathPolicyFail, v1alpha1.IntegrationPlatformClasspathPolicyIgnore:
+				platform.Spec.Build.ClasspathConflicts = v1alpha1.IntegrationPlatformClasspathPolicy(p)
+			default:
+				return fmt.Errorf("unknown classpath conflicts policy: %s", p)
+			}
+		}
 		if o.buildTimeout != "" {
 			d, err := time.ParseDuration(o.buildTimeout)
 			if err != nil {
@@ -207,6 +341,72 @@ func (o *installCmdOptions) install(_ *cobra.Command, _ []string) error {
 			platform.Spec.Build.Timeout.Duration = d
 		}
 
+		if o.quota.MaxRunningBuilds < 0 || o.quota.MaxKits < 0 || o.quota.MaxIntegrations < 0 {
+			return errors.New("quota limits cannot be negative")
+		}
+		platform.Spec.Quota = o.quota
+		if _, err := regexp.Compile(o.policy.NamePattern); err != nil {
+			return errors.Wrap(err, "invalid integration name pattern")
+		}
+		platform.Spec.Policy = o.policy
+		if o.hardcodedCredentials != "" {
+			switch p := v1alpha1.IntegrationPlatformCredentialsPolicy(o.hardcodedCredentials); p {
+			case v1alpha1.IntegrationPlatformCredentialsPolicyWarn, v1alpha1.IntegrationPlatformCredentialsPolicyReject, v1alpha1.IntegrationPlatformCredentialsPolicyIgnore:
+				platform.Spec.Policy.HardcodedCredentials = p
+			default:
+				return fmt.Errorf("unknown hard-coded credentials policy: %s", p)
+			}
+		}
+		platform.Spec.Build.Proxy = o.proxy
+		platform.Spec.Build.S2I.Incremental = o.s2iIncremental
+		platform.Spec.Build.Provided = o.provided
+
+		if o.tekton.Pipeline != "" {
+			platform.Spec.Build.PublishStrategy = v1alpha1.IntegrationPlatformBuildPublishStrategyTekton
+			platform.Spec.Build.Tekton = o.tekton
+			for _, param := range o.tektonParams {
+				kv := strings.SplitN(param, "=", 2)
+				if len(kv) != 2 {
+					return fmt.Errorf("invalid Tekton parameter %s, expected key=value", param)
+				}
+				if platform.Spec.Build.Tekton.Params == nil {
+					platform.Spec.Build.Tekton.Params = make(map[string]string)
+				}
+				platform.Spec.Build.Tekton.Params[kv[0]] = kv[1]
+			}
+		}
+
+		if o.buildNamespace != "" && o.buildNamespace != namespace {
+			platform.Spec.Build.Namespace = o.buildNamespace
+
+			err = install.BuildNamespaceRoleBindingOrCollect(o.Context, c, namespace, o.buildNamespace, collection)
+			if err != nil {
+				return err
+			}
+		}
+
+		if o.sharedKits.Namespace != "" && o.sharedKits.Namespace != namespace {
+			sharedKits := o.sharedKits
+			platform.Spec.Build.SharedKits = &sharedKits
+		}
+
+		if o.kitGCPolicy != "" || o.kitGCTTL != "" {
+			platform.Spec.KitGC = &v1alpha1.IntegrationPlatformKitGCSpec{}
+			switch p := v1alpha1.IntegrationPlatformKitGCPolicy(o.kitGCPolicy); p {
+			case "", v1alpha1.IntegrationPlatformKitGCPolicyRetain, v1alpha1.IntegrationPlatformKitGCPolicyOwned, v1alpha1.IntegrationPlatformKitGCPolicyUnused:
+				platform.Spec.KitGC.Policy = p
+			default:
+				return fmt.Errorf("unknown kit GC policy: %s", p)
+			}
+			if o.kitGCTTL != "" {
+				d, err := time.ParseDuration(o.kitGCTTL)
+				if err != nil {
+					return err
+				}
+				platform.Spec.KitGC.TTL.Duration = d
+			}
+		}
+
 		if len(o.mavenRepositories) > 0 {
 			o.mavenSettings = fmt.Sprintf("configmap:%s-maven-settings/settings.xml", platform.Name)
 
@@ -233,6 +433,11 @@ func (o *installCmdOptions) install(_ *cobra.Command, _ []string) error {
 				},
 			}
 
+			// the proxies are not added by the operator to user provided settings
+			if settings.Proxies, err = proxy.MavenProxies(o.proxy); err != nil {
+				return err
+			}
+
 			data, err := util.EncodeXML(settings)
 			if err != nil {
 				return err
@@ -277,6 +482,13 @@ func (o *installCmdOptions) install(_ *cobra.Command, _ []string) error {
 			return err
 		}
 
+		if o.ciServiceAccount != "" {
+			err = install.CIServiceAccountOrCollect(o.Context, c, namespace, o.ciServiceAccount, collection)
+			if err != nil {
+				return err
+			}
+		}
+
 		if o.exampleSetup {
 			err = install.ExampleOrCollect(o.Context, c, namespace, collection)
 			if err != nil {
@@ -303,6 +515,14 @@ func (o *installCmdOptions) install(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// admissionPort returns the port of the operator admission webhooks, or zero if they are disabled
+func (o *installCmdOptions) admissionPort() int32 {
+	if o.admissionWebhook {
+		return 9443
+	}
+	return 0
+}
+
 func (o *installCmdOptions) printOutput(collection *kubernetes.Collection) error {
 	lst := collection.AsKubernetesList()
 	switch o.outputFormat {
@@ -360,6 +580,18 @@ func (o *installCmdOptions) validate(_ *cobra.Command, _ []string) error {
 		result = multierr.Append(result, err)
 	}
 
+	if o.ciServiceAccount != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(o.ciServiceAccount) {
+			err := fmt.Errorf("invalid CI service account name %s: %s", o.ciServiceAccount, msg)
+			result = multierr.Append(result, err)
+		}
+	}
+
+	if o.buildTool == v1alpha1.IntegrationPlatformBuildToolGradle && o.mavenVerifyChecksums {
+		err := fmt.Errorf("incompatible options combinations: checksum verification is not supported by the gradle build tool")
+		result = multierr.Append(result, err)
+	}
+
 	if len(o.mavenRepositories) > 0 && o.mavenSettings != "" {
 		err := fmt.Errorf("incompatible options combinations: you cannot set both mavenRepository and mavenSettings")
 		result = multierr.Append(result, err)
@@ -67,3 +67,24 @@ func TestDecodeMavenSettings(t *testing.T) {
 	_, err = decodeMavenSettings("secret")
 	assert.NotNil(t, err)
 }
+
+func TestValidateCIServiceAccount(t *testing.T) {
+	options := installCmdOptions{
+		ciServiceAccount: "jenkins",
+	}
+	assert.Nil(t, options.validate(nil, nil))
+
+	options.ciServiceAccount = "Jenkins_CI"
+	assert.NotNil(t, options.validate(nil, nil))
+}
+
+func TestValidateGradleChecksums(t *testing.T) {
+	options := installCmdOptions{
+		buildTool:            v1alpha1.IntegrationPlatformBuildToolGradle,
+		mavenVerifyChecksums: false,
+	}
+	assert.Nil(t, options.validate(nil, nil))
+
+	options.mavenVerifyChecksums = true
+	assert.NotNil(t, options.validate(nil, nil))
+}
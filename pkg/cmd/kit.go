@@ -18,7 +18,14 @@ limitations under the License.
 package cmd
 
 import (
+	"context"
+	"fmt"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/spf13/cobra"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func newCmdKit(rootCmdOptions *RootCmdOptions) *cobra.Command {
@@ -31,6 +38,42 @@ func newCmdKit(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	cmd.AddCommand(newKitCreateCmd(rootCmdOptions))
 	cmd.AddCommand(newKitDeleteCmd(rootCmdOptions))
 	cmd.AddCommand(newKitGetCmd(rootCmdOptions))
+	cmd.AddCommand(newKitInspectCmd(rootCmdOptions))
 
 	return &cmd
 }
+
+// lookupKit returns the kit with the given name or alias, the most recent kit being returned when several kits
+// share the alias, e.g. when the integration they are aliased after has been changed
+func lookupKit(ctx context.Context, c k8sclient.Reader, namespace string, name string) (*v1alpha1.IntegrationKit, error) {
+	kit := v1alpha1.NewIntegrationKit(namespace, name)
+	key := k8sclient.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}
+	err := c.Get(ctx, key, &kit)
+	if err == nil || !k8serrors.IsNotFound(err) {
+		return &kit, err
+	}
+
+	kits := v1alpha1.NewIntegrationKitList()
+	if err := c.List(ctx, &k8sclient.ListOptions{Namespace: namespace}, &kits); err != nil {
+		return nil, err
+	}
+
+	var last *v1alpha1.IntegrationKit
+	for _, k := range kits.Items {
+		k := k // pin
+		if k.Labels[v1alpha1.IntegrationKitAliasLabel] != name {
+			continue
+		}
+		if last == nil || last.CreationTimestamp.Before(&k.CreationTimestamp) {
+			last = &k
+		}
+	}
+	if last == nil {
+		return nil, fmt.Errorf("no integration kit found with name or alias \"%s\"", name)
+	}
+
+	return last, nil
+}
@@ -50,6 +50,7 @@ func newKitCreateCmd(rootCmdOptions *RootCmdOptions) *cobra.Command {
 
 	cmd.Flags().StringVarP(&impl.runtime, "runtime", "r", "jvm", "Runtime provided by the kit")
 	cmd.Flags().StringVar(&impl.image, "image", "", "Image used to create the kit")
+	cmd.Flags().BoolVar(&impl.library, "library", false, "Create a library kit, publishing shared routes that integrations reference with --library")
 	cmd.Flags().StringSliceVarP(&impl.dependencies, "dependency", "d", nil, "Add a dependency")
 	cmd.Flags().StringSliceVarP(&impl.properties, "property", "p", nil, "Add a camel property")
 	cmd.Flags().StringSliceVar(&impl.configmaps, "configmap", nil, "Add a ConfigMap")
@@ -68,6 +69,7 @@ type kitCreateCommand struct {
 
 	runtime      string
 	image        string
+	library      bool
 	dependencies []string
 	properties   []string
 	configmaps   []string
@@ -80,6 +82,9 @@ func (command *kitCreateCommand) validateArgs(_ *cobra.Command, args []string) e
 	if len(args) != 1 {
 		return errors.New("accepts 1 arg, received " + strconv.Itoa(len(args)))
 	}
+	if command.library && command.image != "" {
+		return errors.New("a library kit cannot be created from an image")
+	}
 
 	return nil
 }
@@ -126,6 +131,9 @@ func (command *kitCreateCommand) run(_ *cobra.Command, args []string) error {
 		Repositories:  command.repositories,
 	}
 
+	if command.library {
+		ctx.Labels["camel.apache.org/kit.type"] = v1alpha1.IntegrationKitTypeLibrary
+	}
 	if command.image != "" {
 		//
 		// if the image is set, the kit do not require any build but
@@ -145,6 +153,8 @@ func (command *kitCreateCommand) run(_ *cobra.Command, args []string) error {
 			ctx.Spec.Dependencies = append(ctx.Spec.Dependencies, item)
 		case strings.HasPrefix(item, "file:"):
 			ctx.Spec.Dependencies = append(ctx.Spec.Dependencies, item)
+		case strings.HasPrefix(item, "github:"):
+			ctx.Spec.Dependencies = append(ctx.Spec.Dependencies, item)
 		case strings.HasPrefix(item, "camel-"):
 			ctx.Spec.Dependencies = append(ctx.Spec.Dependencies, "camel:"+strings.TrimPrefix(item, "camel-"))
 		}
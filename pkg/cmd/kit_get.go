@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -52,6 +53,7 @@ func newKitGetCmd(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	cmd.Flags().BoolVar(&impl.user, v1alpha1.IntegrationKitTypeUser, true, "Includes user kits")
 	cmd.Flags().BoolVar(&impl.external, v1alpha1.IntegrationKitTypeExternal, true, "Includes external kits")
 	cmd.Flags().BoolVar(&impl.platform, v1alpha1.IntegrationKitTypePlatform, true, "Includes platform kits")
+	cmd.Flags().StringSliceVar(&impl.with, "with", nil, "Only includes kits containing the given dependency, i.e. camel:kafka")
 
 	return &cmd
 }
@@ -61,6 +63,7 @@ type kitGetCommand struct {
 	user     bool
 	external bool
 	platform bool
+	with     []string
 }
 
 func (command *kitGetCommand) validate(cmd *cobra.Command, args []string) error {
@@ -78,19 +81,71 @@ func (command *kitGetCommand) run() error {
 		return err
 	}
 
+	integrationList := v1alpha1.NewIntegrationList()
+	if err := c.List(command.Context, &k8sclient.ListOptions{Namespace: command.Namespace}, &integrationList); err != nil {
+		return err
+	}
+	usages := make(map[string]int)
+	for _, integration := range integrationList.Items {
+		if integration.Status.Kit != "" {
+			usages[integration.Status.Kit]++
+		}
+	}
+
 	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
-	fmt.Fprintln(w, "NAME\tPHASE\tTYPE\tIMAGE")
+	fmt.Fprintln(w, "NAME\tALIAS\tPHASE\tTYPE\tIMAGE\tSIZE\tINTEGRATIONS\tCREATED")
 	for _, ctx := range kitList.Items {
 		t := ctx.Labels["camel.apache.org/kit.type"]
 		u := command.user && t == v1alpha1.IntegrationKitTypeUser
 		e := command.external && t == v1alpha1.IntegrationKitTypeExternal
 		p := command.platform && t == v1alpha1.IntegrationKitTypePlatform
 
-		if u || e || p {
-			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ctx.Name, string(ctx.Status.Phase), t, ctx.Status.Image)
+		if (u || e || p) && containsDependencies(ctx, command.with) {
+			alias := ctx.Labels[v1alpha1.IntegrationKitAliasLabel]
+			if alias == "" {
+				alias = "-"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n", ctx.Name, alias, string(ctx.Status.Phase), t, ctx.Status.Image,
+				formatImageSize(ctx.Status.ImageSize), usages[ctx.Name], ctx.CreationTimestamp.Format(time.RFC3339))
 		}
 	}
 	w.Flush()
 
 	return nil
 }
+
+// containsDependencies returns true if the kit contains all the given dependencies
+func containsDependencies(kit v1alpha1.IntegrationKit, dependencies []string) bool {
+	for _, dependency := range dependencies {
+		found := false
+		for _, d := range kit.Spec.Dependencies {
+			if d == dependency {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// formatImageSize returns the human readable image size, the size being unknown for the kits
+// built before it was recorded and for the external ones
+func formatImageSize(size int64) string {
+	if size <= 0 {
+		return "-"
+	}
+	if size < 1024 {
+		return fmt.Sprintf("%d B", size)
+	}
+	value := float64(size) / 1024
+	for _, unit := range []string{"KiB", "MiB"} {
+		if value < 1024 {
+			return fmt.Sprintf("%.1f %s", value, unit)
+		}
+		value /= 1024
+	}
+	return fmt.Sprintf("%.1f GiB", value)
+}
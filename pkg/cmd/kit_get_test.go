@@ -0,0 +1,44 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainsDependencies(t *testing.T) {
+	kit := v1alpha1.NewIntegrationKit("ns", "kit-1")
+	kit.Spec.Dependencies = []string{"camel:core", "camel:kafka", "runtime:jvm"}
+
+	assert.True(t, containsDependencies(kit, nil))
+	assert.True(t, containsDependencies(kit, []string{"camel:kafka"}))
+	assert.True(t, containsDependencies(kit, []string{"camel:kafka", "runtime:jvm"}))
+	assert.False(t, containsDependencies(kit, []string{"camel:kafka", "camel:jms"}))
+	assert.False(t, containsDependencies(kit, []string{"kafka"}))
+}
+
+func TestFormatImageSize(t *testing.T) {
+	assert.Equal(t, "-", formatImageSize(0))
+	assert.Equal(t, "512 B", formatImageSize(512))
+	assert.Equal(t, "1.5 KiB", formatImageSize(1536))
+	assert.Equal(t, "42.0 MiB", formatImageSize(42*1024*1024))
+	assert.Equal(t, "2.0 GiB", formatImageSize(2*1024*1024*1024))
+}
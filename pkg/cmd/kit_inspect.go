@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/spf13/cobra"
+)
+
+// provenanceLabels are the image labels displayed by the inspect command, in order
+var provenanceLabels = []struct {
+	name  string
+	title string
+}{
+	{builder.ImageLabelCreated, "Created"},
+	{builder.ImageLabelOperatorVersion, "Operator Version"},
+	{builder.ImageLabelCamelVersion, "Camel Version"},
+	{builder.ImageLabelRuntimeVersion, "Runtime Version"},
+	{builder.ImageLabelIntegrationDigest, "Integration Digest"},
+	{builder.ImageLabelDependenciesDigest, "Dependencies Digest"},
+}
+
+func newKitInspectCmd(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := kitInspectCommand{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "inspect kit-name-or-alias",
+		Short: "Inspect the provenance of an Integration Kit image",
+		Long:  `Inspect the provenance labels recorded on an Integration Kit image, and check the image dependencies against the kit artifacts.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			if err := impl.run(args); err != nil {
+				fmt.Println(err.Error())
+			}
+
+			return nil
+		},
+	}
+
+	return &cmd
+}
+
+type kitInspectCommand struct {
+	*RootCmdOptions
+}
+
+func (command *kitInspectCommand) validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("inspect expects exactly one kit name")
+	}
+	return nil
+}
+
+func (command *kitInspectCommand) run(args []string) error {
+	c, err := command.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	kit, err := lookupKit(command.Context, c, command.Namespace, args[0])
+	if err != nil {
+		return fmt.Errorf("cannot get integration kit %s: %v", args[0], err)
+	}
+
+	fmt.Print(inspectIntegrationKit(*kit))
+	return nil
+}
+
+// inspectIntegrationKit describes the provenance of the kit image, the dependencies digest being verified
+// against the kit artifacts so that an image not matching the kit it's used for can be spotted
+func inspectIntegrationKit(kit v1alpha1.IntegrationKit) string {
+	return indentedString(func(out io.Writer) {
+		w := newIndentedWriter(out)
+
+		w.write(0, "Name:\t%s\n", kit.Name)
+		w.write(0, "Image:\t%s\n", kit.Status.Image)
+
+		labels := kit.Status.ImageLabels
+		if len(labels) == 0 {
+			w.write(0, "Provenance:\tnot recorded\n")
+			return
+		}
+
+		known := make(map[string]bool)
+		for _, label := range provenanceLabels {
+			known[label.name] = true
+			if value, ok := labels[label.name]; ok {
+				w.write(0, "%s:\t%s\n", label.title, value)
+			}
+		}
+
+		if recorded, ok := labels[builder.ImageLabelDependenciesDigest]; ok {
+			if actual := builder.DependenciesDigest(kit.Status.Artifacts); actual == recorded {
+				w.write(0, "Dependencies Check:\tverified\n")
+			} else {
+				w.write(0, "Dependencies Check:\tmismatch, the kit artifacts digest is %s\n", actual)
+			}
+		}
+
+		others := make([]string, 0)
+		for _, name := range builder.SortedImageLabels(labels) {
+			if !known[name] {
+				others = append(others, name)
+			}
+		}
+		if len(others) > 0 {
+			w.write(0, "Labels:\n")
+			for _, name := range others {
+				w.write(1, "%s:\t%s\n", name, labels[name])
+			}
+		}
+	})
+}
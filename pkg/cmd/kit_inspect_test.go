@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspectIntegrationKit(t *testing.T) {
+	kit := v1alpha1.NewIntegrationKit("ns", "kit-1")
+	kit.Status.Image = "registry/ns/camel-k-kit-1:1234"
+	kit.Status.Artifacts = []v1alpha1.Artifact{
+		{ID: "org.apache.camel:camel-core:jar:2.23.2", Checksum: "sha256:1234"},
+	}
+	kit.Status.ImageLabels = map[string]string{
+		builder.ImageLabelCreated:            "2019-06-01T10:00:00Z",
+		builder.ImageLabelCamelVersion:       "2.23.2",
+		builder.ImageLabelIntegrationDigest:  "v123",
+		builder.ImageLabelDependenciesDigest: builder.DependenciesDigest(kit.Status.Artifacts),
+		"vendor":                             "acme",
+	}
+
+	out := inspectIntegrationKit(kit)
+	assert.Regexp(t, `Image:\s+registry/ns/camel-k-kit-1:1234\n`, out)
+	assert.Regexp(t, `Created:\s+2019-06-01T10:00:00Z\n`, out)
+	assert.Regexp(t, `Integration Digest:\s+v123\n`, out)
+	assert.Regexp(t, `Dependencies Check:\s+verified\n`, out)
+	assert.Contains(t, out, "  vendor:  acme\n")
+	assert.NotContains(t, out, "Operator Version")
+
+	kit.Status.Artifacts[0].Checksum = "sha256:5678"
+
+	out = inspectIntegrationKit(kit)
+	assert.Regexp(t, `Dependencies Check:\s+mismatch, the kit artifacts digest is `+builder.DependenciesDigest(kit.Status.Artifacts), out)
+}
+
+func TestInspectIntegrationKitWithoutProvenance(t *testing.T) {
+	kit := v1alpha1.NewIntegrationKit("ns", "kit-1")
+	kit.Status.Image = "registry/ns/camel-k-kit-1:1234"
+
+	assert.Contains(t, inspectIntegrationKit(kit), "Provenance:  not recorded\n")
+}
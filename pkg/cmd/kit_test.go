@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newAliasedTestKit(name string, alias string, created time.Time) *v1alpha1.IntegrationKit {
+	kit := v1alpha1.NewIntegrationKit("ns", name)
+	kit.CreationTimestamp = metav1.NewTime(created)
+	kit.Labels = map[string]string{
+		v1alpha1.IntegrationKitAliasLabel: alias,
+	}
+	return &kit
+}
+
+func TestLookupKit(t *testing.T) {
+	now := time.Now()
+	c, err := test.NewFakeClient(
+		newAliasedTestKit("kit-0123456789abcdef0123", "my-integration", now.Add(-time.Hour)),
+		newAliasedTestKit("kit-456789abcdef01234567", "my-integration", now),
+		newAliasedTestKit("kit-89abcdef0123456789ab", "other", now),
+	)
+	assert.Nil(t, err)
+
+	kit, err := lookupKit(context.TODO(), c, "ns", "kit-0123456789abcdef0123")
+	assert.Nil(t, err)
+	assert.Equal(t, "kit-0123456789abcdef0123", kit.Name)
+
+	kit, err = lookupKit(context.TODO(), c, "ns", "my-integration")
+	assert.Nil(t, err)
+	assert.Equal(t, "kit-456789abcdef01234567", kit.Name)
+
+	_, err = lookupKit(context.TODO(), c, "ns", "unknown")
+	assert.NotNil(t, err)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	k8slog "github.com/apache/camel-k/pkg/util/kubernetes/log"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,6 +41,9 @@ func newCmdLog(rootCmdOptions *RootCmdOptions) *cobra.Command {
 		RunE:  options.run,
 	}
 
+	cmd.Flags().BoolVar(&options.JSON, "json", false, "Pretty print the JSON logs of the integration, e.g. configured by the log-forwarding trait")
+	cmd.Flags().StringVar(&options.Level, "level", "", "Only print the JSON log records at or above the given level (TRACE|DEBUG|INFO|WARN|ERROR|FATAL)")
+
 	// completion support
 	configureKnownCompletions(&cmd)
 
@@ -48,12 +52,22 @@ func newCmdLog(rootCmdOptions *RootCmdOptions) *cobra.Command {
 
 type logCmdOptions struct {
 	*RootCmdOptions
+	JSON  bool
+	Level string
 }
 
 func (o *logCmdOptions) validate(_ *cobra.Command, args []string) error {
 	if len(args) != 1 {
 		return fmt.Errorf("accepts 1 arg, received %d", len(args))
 	}
+	if o.Level != "" {
+		if !o.JSON {
+			return errors.New("the level filter requires the --json flag")
+		}
+		if err := k8slog.ValidateLevel(o.Level); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -81,7 +95,11 @@ func (o *logCmdOptions) run(_ *cobra.Command, args []string) error {
 	if err := c.Get(o.Context, key, &integration); err != nil {
 		return err
 	}
-	if err := k8slog.Print(o.Context, c, &integration); err != nil {
+	if o.JSON {
+		if err := k8slog.PrintJSON(o.Context, c, &integration, o.Level); err != nil {
+			return err
+		}
+	} else if err := k8slog.Print(o.Context, c, &integration); err != nil {
 		return err
 	}
 
@@ -0,0 +1,270 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newCmdPromote(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := promoteCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "promote integration --to namespace",
+		Short: "Promote an integration to another namespace or cluster",
+		Long: `Promote an integration to another namespace or cluster.
+
+The integration is created in the destination using the image of its kit, so no new build is performed.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			return impl.run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&impl.to, "to", "", "The namespace the integration is promoted to")
+	cmd.Flags().StringVar(&impl.toConfig, "to-config", "", "Path to the config file of the cluster the integration is promoted to (defaults to the current cluster)")
+	cmd.Flags().StringVar(&impl.image, "image", "", "The image to use in the destination, i.e. when the kit image has been re-tagged in another registry")
+	cmd.Flags().StringSliceVar(&impl.renames, "rename", nil, "Rename a referenced configmap or secret in the destination (old=new)")
+	cmd.Flags().BoolVar(&impl.copyConfig, "copy-config", false, "Copy the configmaps and secrets referenced by the integration to the destination")
+	cmd.Flags().BoolVarP(&impl.wait, "wait", "w", false, "Waits for the integration to be running in the destination")
+	cmd.Flags().DurationVar(&impl.timeout, "timeout", 10*time.Minute, "How long to wait for the integration to be running")
+
+	return &cmd
+}
+
+type promoteCmdOptions struct {
+	*RootCmdOptions
+	to         string
+	toConfig   string
+	image      string
+	renames    []string
+	copyConfig bool
+	wait       bool
+	timeout    time.Duration
+}
+
+func (o *promoteCmdOptions) validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("promote expects exactly one integration name")
+	}
+	if o.to == "" {
+		return errors.New("the destination namespace must be set using --to")
+	}
+	if o.to == o.Namespace && o.toConfig == "" {
+		return errors.New("the destination must be different from the source")
+	}
+	for _, r := range o.renames {
+		if len(strings.Split(r, "=")) != 2 {
+			return fmt.Errorf("invalid rename %s, expected old=new", r)
+		}
+	}
+
+	return nil
+}
+
+func (o *promoteCmdOptions) run(args []string) error {
+	source, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	destination := source
+	if o.toConfig != "" {
+		destination, err = client.NewOutOfClusterClient(o.toConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	integration := v1alpha1.NewIntegration(o.Namespace, kubernetes.SanitizeName(args[0]))
+	if err := source.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: integration.Name}, &integration); err != nil {
+		return err
+	}
+	if integration.Status.Phase != v1alpha1.IntegrationPhaseRunning || integration.Status.Kit == "" {
+		return fmt.Errorf("integration %s must be running to be promoted (phase=%s)", integration.Name, integration.Status.Phase)
+	}
+
+	kit := v1alpha1.NewIntegrationKit(o.Namespace, integration.Status.Kit)
+	if err := source.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: kit.Name}, &kit); err != nil {
+		return err
+	}
+
+	renames := make(map[string]string)
+	for _, r := range o.renames {
+		kv := strings.Split(r, "=")
+		renames[kv[0]] = kv[1]
+	}
+
+	if o.copyConfig {
+		if err := o.copyConfiguration(source, destination, integration.Spec.Configuration, renames); err != nil {
+			return err
+		}
+	}
+
+	promotedKit := o.promoteKit(kit, renames)
+	if err := kubernetes.ReplaceResource(o.Context, destination, &promotedKit); err != nil {
+		return err
+	}
+	fmt.Printf("integration kit \"%s\" promoted to \"%s\"\n", promotedKit.Name, o.to)
+
+	promoted := v1alpha1.NewIntegration(o.to, integration.Name)
+	promoted.Annotations = make(map[string]string)
+	promoted.Labels = integration.Labels
+	for k, v := range integration.Annotations {
+		// audit annotations describe the source integration only
+		if !strings.HasPrefix(k, "camel.apache.org/audit.") {
+			promoted.Annotations[k] = v
+		}
+	}
+	promoted.Spec = *integration.Spec.DeepCopy()
+	promoted.Spec.Kit = promotedKit.Name
+	promoted.Spec.Configuration = renameConfiguration(integration.Spec.Configuration, renames)
+
+	if err := kubernetes.ReplaceResource(o.Context, destination, &promoted); err != nil {
+		return err
+	}
+	fmt.Printf("integration \"%s\" promoted to \"%s\"\n", promoted.Name, o.to)
+
+	if o.wait {
+		return kubernetes.WaitCondition(o.Context, destination, &promoted, func(obj interface{}) (bool, error) {
+			if i, ok := obj.(*v1alpha1.Integration); ok {
+				switch i.Status.Phase {
+				case v1alpha1.IntegrationPhaseRunning:
+					fmt.Printf("integration \"%s\" running in \"%s\"\n", i.Name, o.to)
+					return true, nil
+				case v1alpha1.IntegrationPhaseError:
+					return false, fmt.Errorf("integration \"%s\" deployment failed in \"%s\"", i.Name, o.to)
+				}
+			}
+			return false, nil
+		}, o.timeout)
+	}
+
+	return nil
+}
+
+// promoteKit creates an external kit pointing at the image built for the source kit
+func (o *promoteCmdOptions) promoteKit(kit v1alpha1.IntegrationKit, renames map[string]string) v1alpha1.IntegrationKit {
+	image := o.image
+	if image == "" {
+		image = kit.Status.PublicImage
+	}
+	if image == "" {
+		image = kit.Status.Image
+	}
+
+	promoted := v1alpha1.NewIntegrationKit(o.to, kit.Name)
+	promoted.Labels = map[string]string{
+		"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypeExternal,
+		"camel.apache.org/promoted": o.Namespace,
+	}
+	promoted.Spec = *kit.Spec.DeepCopy()
+	promoted.Spec.Image = image
+	promoted.Spec.Configuration = renameConfiguration(kit.Spec.Configuration, renames)
+
+	return promoted
+}
+
+func (o *promoteCmdOptions) copyConfiguration(source client.Client, destination client.Client, configuration []v1alpha1.ConfigurationSpec, renames map[string]string) error {
+	for _, c := range configuration {
+		var obj runtime.Object
+
+		switch c.Type {
+		case "configmap":
+			cm := corev1.ConfigMap{}
+			if err := source.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: c.Value}, &cm); err != nil {
+				return err
+			}
+			copied := corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: o.to,
+					Name:      renamed(c.Value, renames),
+					Labels:    cm.Labels,
+				},
+				Data:       cm.Data,
+				BinaryData: cm.BinaryData,
+			}
+			obj = &copied
+		case "secret":
+			secret := corev1.Secret{}
+			if err := source.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: c.Value}, &secret); err != nil {
+				return err
+			}
+			copied := corev1.Secret{
+				TypeMeta: metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: o.to,
+					Name:      renamed(c.Value, renames),
+					Labels:    secret.Labels,
+				},
+				Type: secret.Type,
+				Data: secret.Data,
+			}
+			obj = &copied
+		default:
+			continue
+		}
+
+		if err := destination.Create(o.Context, obj); err != nil {
+			if !k8serrors.IsAlreadyExists(err) {
+				return err
+			}
+			fmt.Printf("%s \"%s\" already exists in \"%s\", skipped\n", c.Type, renamed(c.Value, renames), o.to)
+		}
+	}
+
+	return nil
+}
+
+func renameConfiguration(configuration []v1alpha1.ConfigurationSpec, renames map[string]string) []v1alpha1.ConfigurationSpec {
+	result := make([]v1alpha1.ConfigurationSpec, 0, len(configuration))
+	for _, c := range configuration {
+		if c.Type == "configmap" || c.Type == "secret" {
+			c.Value = renamed(c.Value, renames)
+		}
+		result = append(result, c)
+	}
+
+	return result
+}
+
+func renamed(name string, renames map[string]string) string {
+	if n, ok := renames[name]; ok {
+		return n
+	}
+
+	return name
+}
@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoteIntegration(t *testing.T) {
+	integration := v1alpha1.NewIntegration("dev", "my-route")
+	integration.Spec.Configuration = []v1alpha1.ConfigurationSpec{
+		{Type: "configmap", Value: "my-config"},
+		{Type: "property", Value: "my.prop=1"},
+	}
+	integration.Status.Phase = v1alpha1.IntegrationPhaseRunning
+	integration.Status.Kit = "kit-123"
+
+	kit := v1alpha1.NewIntegrationKit("dev", "kit-123")
+	kit.Spec.Dependencies = []string{"camel:log"}
+	kit.Status.Image = "registry/dev/camel-k-kit-123:1"
+
+	c, err := test.NewFakeClient(
+		&integration,
+		&kit,
+		&corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "dev", Name: "my-config"},
+			Data:       map[string]string{"application.properties": "my.key=dev"},
+		},
+	)
+	assert.Nil(t, err)
+
+	options := promoteCmdOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "dev",
+			_client:   c,
+		},
+		to:         "prod",
+		renames:    []string{"my-config=my-prod-config"},
+		copyConfig: true,
+	}
+
+	assert.Nil(t, options.validate([]string{"my-route"}))
+	assert.Nil(t, options.run([]string{"my-route"}))
+
+	promotedKit := v1alpha1.NewIntegrationKit("prod", "kit-123")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "prod", Name: "kit-123"}, &promotedKit))
+	assert.Equal(t, "registry/dev/camel-k-kit-123:1", promotedKit.Spec.Image)
+	assert.Equal(t, []string{"camel:log"}, promotedKit.Spec.Dependencies)
+	assert.Equal(t, v1alpha1.IntegrationKitTypeExternal, promotedKit.Labels["camel.apache.org/kit.type"])
+
+	promoted := v1alpha1.NewIntegration("prod", "my-route")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "prod", Name: "my-route"}, &promoted))
+	assert.Equal(t, "kit-123", promoted.Spec.Kit)
+	assert.Equal(t, []v1alpha1.ConfigurationSpec{
+		{Type: "configmap", Value: "my-prod-config"},
+		{Type: "property", Value: "my.prop=1"},
+	}, promoted.Spec.Configuration)
+
+	cm := corev1.ConfigMap{}
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "prod", Name: "my-prod-config"}, &cm))
+	assert.Equal(t, "my.key=dev", cm.Data["application.properties"])
+}
+
+func TestPromoteValidation(t *testing.T) {
+	options := promoteCmdOptions{
+		RootCmdOptions: &RootCmdOptions{Namespace: "dev"},
+	}
+
+	assert.NotNil(t, options.validate([]string{"my-route"}))
+
+	options.to = "dev"
+	assert.NotNil(t, options.validate([]string{"my-route"}))
+
+	options.to = "prod"
+	options.renames = []string{"my-config"}
+	assert.NotNil(t, options.validate([]string{"my-route"}))
+
+	options.renames = nil
+	assert.NotNil(t, options.validate(nil))
+	assert.Nil(t, options.validate([]string{"my-route"}))
+}
@@ -34,15 +34,28 @@ func newCmdReset(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "reset",
 		Short: "Reset the Camel K installation",
-		Long:  `Reset the Camel K installation by deleting everything except current platform configuration.`,
-		Run:   options.reset,
+		Long: `Reset the Camel K installation by deleting everything except current platform configuration.
+
+The --integrations, --kits, --builds and --invalidate-kit-cache flags restrict the reset to the selected
+resources, leaving the platform status untouched.`,
+		Run: options.reset,
 	}
 
+	cmd.Flags().BoolVar(&options.integrations, "integrations", false, "Delete the integrations only")
+	cmd.Flags().BoolVar(&options.kits, "kits", false, "Delete the integration kits only")
+	cmd.Flags().BoolVar(&options.builds, "builds", false, "Delete the builds only")
+	cmd.Flags().BoolVar(&options.invalidateKitCache, "invalidate-kit-cache", false,
+		"Invalidate the existing integration kits, so that the next runs neither reuse them nor build on top of their images")
+
 	return &cmd
 }
 
 type resetCmdOptions struct {
 	*RootCmdOptions
+	integrations       bool
+	kits               bool
+	builds             bool
+	invalidateKitCache bool
 }
 
 func (o *resetCmdOptions) reset(_ *cobra.Command, _ []string) {
@@ -51,25 +64,55 @@ func (o *resetCmdOptions) reset(_ *cobra.Command, _ []string) {
 		fmt.Print(err)
 		return
 	}
-	var n int
-	if n, err = o.deleteAllIntegrations(c); err != nil {
+
+	if err := o.run(c); err != nil {
 		fmt.Print(err)
-		return
 	}
-	fmt.Printf("%d integrations deleted from namespace %s\n", n, o.Namespace)
+}
 
-	if n, err = o.deleteAllIntegrationKits(c); err != nil {
-		fmt.Print(err)
-		return
+func (o *resetCmdOptions) run(c client.Client) error {
+	selective := o.integrations || o.kits || o.builds || o.invalidateKitCache
+
+	var n int
+	var err error
+	if !selective || o.integrations {
+		if n, err = o.deleteAllIntegrations(c); err != nil {
+			return err
+		}
+		fmt.Printf("%d integrations deleted from namespace %s\n", n, o.Namespace)
+	}
+
+	if !selective || o.kits {
+		if n, err = o.deleteAllIntegrationKits(c); err != nil {
+			return err
+		}
+		fmt.Printf("%d integration kits deleted from namespace %s\n", n, o.Namespace)
+	}
+
+	if o.builds {
+		if n, err = o.deleteAllBuilds(c); err != nil {
+			return err
+		}
+		fmt.Printf("%d builds deleted from namespace %s\n", n, o.Namespace)
+	}
+
+	if o.invalidateKitCache {
+		if n, err = o.invalidateAllIntegrationKits(c); err != nil {
+			return err
+		}
+		fmt.Printf("%d integration kits invalidated in namespace %s\n", n, o.Namespace)
+	}
+
+	if selective {
+		return nil
 	}
-	fmt.Printf("%d integration kits deleted from namespace %s\n", n, o.Namespace)
 
 	if err = o.resetIntegrationPlatform(c); err != nil {
-		fmt.Print(err)
-		return
+		return err
 	}
 
 	fmt.Println("Camel K platform has been reset successfully!")
+	return nil
 }
 
 func (o *resetCmdOptions) deleteAllIntegrations(c client.Client) (int, error) {
@@ -100,6 +143,43 @@ func (o *resetCmdOptions) deleteAllIntegrationKits(c client.Client) (int, error)
 	return len(list.Items), nil
 }
 
+func (o *resetCmdOptions) deleteAllBuilds(c client.Client) (int, error) {
+	list := v1alpha1.BuildList{}
+	if err := c.List(o.Context, &k8sclient.ListOptions{Namespace: o.Namespace}, &list); err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("could not retrieve builds from namespace %s", o.Namespace))
+	}
+	for _, i := range list.Items {
+		build := i
+		if err := c.Delete(o.Context, &build); err != nil {
+			return 0, errors.Wrap(err, fmt.Sprintf("could not delete build %s from namespace %s", build.Name, build.Namespace))
+		}
+	}
+	return len(list.Items), nil
+}
+
+func (o *resetCmdOptions) invalidateAllIntegrationKits(c client.Client) (int, error) {
+	list := v1alpha1.NewIntegrationKitList()
+	if err := c.List(o.Context, &k8sclient.ListOptions{Namespace: o.Namespace}, &list); err != nil {
+		return 0, errors.Wrap(err, fmt.Sprintf("could not retrieve integration kits from namespace %s", o.Namespace))
+	}
+	n := 0
+	for _, i := range list.Items {
+		kit := i
+		if kit.IsInvalidated() {
+			continue
+		}
+		if kit.Annotations == nil {
+			kit.Annotations = make(map[string]string)
+		}
+		kit.Annotations[v1alpha1.IntegrationKitInvalidatedAnnotation] = "true"
+		if err := c.Update(o.Context, &kit); err != nil {
+			return 0, errors.Wrap(err, fmt.Sprintf("could not invalidate integration kit %s from namespace %s", kit.Name, kit.Namespace))
+		}
+		n++
+	}
+	return n, nil
+}
+
 func (o *resetCmdOptions) resetIntegrationPlatform(c client.Client) error {
 	list := v1alpha1.NewIntegrationPlatformList()
 	if err := c.List(o.Context, &k8sclient.ListOptions{Namespace: o.Namespace}, &list); err != nil {
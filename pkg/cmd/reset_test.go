@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectiveReset(t *testing.T) {
+	integration := v1alpha1.NewIntegration("ns", "my-route")
+	kit := v1alpha1.NewIntegrationKit("ns", "kit-123")
+	build := v1alpha1.Build{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.BuildKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "kit-123",
+		},
+	}
+
+	c, err := test.NewFakeClient(&integration, &kit, &build)
+	assert.Nil(t, err)
+
+	options := resetCmdOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "ns",
+		},
+		builds:             true,
+		invalidateKitCache: true,
+	}
+	assert.Nil(t, options.run(c))
+
+	builds := v1alpha1.BuildList{}
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "ns"}, &builds))
+	assert.Empty(t, builds.Items)
+
+	// integrations and kits are kept, the latter not being reusable anymore
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "my-route"}, &integration))
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "kit-123"}, &kit))
+	assert.True(t, kit.IsInvalidated())
+}
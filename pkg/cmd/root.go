@@ -58,13 +58,23 @@ func NewKamelCommand(ctx context.Context) (*cobra.Command, error) {
 	cmd.AddCommand(newCmdCompletion(&cmd))
 	cmd.AddCommand(newCmdVersion())
 	cmd.AddCommand(newCmdRun(&options))
+	cmd.AddCommand(newCmdApply(&options))
 	cmd.AddCommand(newCmdGet(&options))
 	cmd.AddCommand(newCmdDelete(&options))
 	cmd.AddCommand(newCmdInstall(&options))
 	cmd.AddCommand(newCmdLog(&options))
 	cmd.AddCommand(newCmdKit(&options))
+	cmd.AddCommand(newCmdSource(&options))
 	cmd.AddCommand(newCmdReset(&options))
 	cmd.AddCommand(newCmdDescribe(&options))
+	cmd.AddCommand(newCmdPromote(&options))
+	cmd.AddCommand(newCmdApprove(&options))
+	cmd.AddCommand(newCmdTop(&options))
+	cmd.AddCommand(newCmdTest(&options))
+	cmd.AddCommand(newCmdTrace(&options))
+	cmd.AddCommand(newCmdExport(&options))
+	cmd.AddCommand(newCmdWait(&options))
+	cmd.AddCommand(newCmdDebugEnv(&options))
 
 	return &cmd, nil
 }
@@ -30,20 +30,26 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"text/tabwriter"
+	"text/template"
 
 	"github.com/apache/camel-k/pkg/util/finalizer"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/gzip"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/trait"
 	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	k8slog "github.com/apache/camel-k/pkg/util/kubernetes/log"
 	"github.com/apache/camel-k/pkg/util/sync"
 	"github.com/apache/camel-k/pkg/util/watch"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -61,16 +67,23 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	cmd := cobra.Command{
 		Use:   "run [file to run]",
 		Short: "Run a integration on Kubernetes",
-		Long:  `Deploys and execute a integration pod on Kubernetes.`,
-		Args:  options.validateArgs,
-		RunE:  options.run,
+		Long: `Deploys and execute a integration pod on Kubernetes.
+
+When a directory is given, each source file it contains is run as a separate integration, named
+after the --name template (default "{{filename}}"), e.g. kamel run routes/ --name '{{filename}}-svc'.
+The template functions are filename (the file name without extension), ext and dir.`,
+		Args: options.validateArgs,
+		RunE: options.run,
 	}
 
 	cmd.Flags().StringVarP(&options.Runtime, "runtime", "r", "", "Runtime used by the integration")
-	cmd.Flags().StringVar(&options.IntegrationName, "name", "", "The integration name")
+	cmd.Flags().StringVar(&options.IntegrationName, "name", "", "The integration name, or the template of the integration names when running a directory")
 	cmd.Flags().StringSliceVarP(&options.Dependencies, "dependency", "d", nil, "The integration dependency")
+	cmd.Flags().StringSliceVar(&options.Libraries, "library", nil, "The name of a library kit providing shared routes to the integration")
 	cmd.Flags().BoolVarP(&options.Wait, "wait", "w", false, "Waits for the integration to be running")
 	cmd.Flags().StringVarP(&options.IntegrationKit, "kit", "k", "", "The kit used to run the integration")
+	cmd.Flags().BoolVar(&options.ProtectKit, "protect-kit", false, "Pin the integration to the kit set with --kit, "+
+		"so that the operator reports the missing dependencies instead of building a new kit")
 	cmd.Flags().StringArrayVarP(&options.Properties, "property", "p", nil, "Add a camel property")
 	cmd.Flags().StringSliceVar(&options.ConfigMaps, "configmap", nil, "Add a ConfigMap")
 	cmd.Flags().StringSliceVar(&options.Secrets, "secret", nil, "Add a Secret")
@@ -83,12 +96,16 @@ func newCmdRun(rootCmdOptions *RootCmdOptions) *cobra.Command {
 	cmd.Flags().StringSliceVar(&options.LoggingLevels, "logging-level", nil, "Configure the logging level. "+
 		"E.g. \"--logging-level org.apache.camel=DEBUG\"")
 	cmd.Flags().StringVarP(&options.OutputFormat, "output", "o", "", "Output format. One of: json|yaml")
+	cmd.Flags().StringVar(&options.Save, "save", "", "Save the integration applied to the given file, in JSON if the file has the .json extension or in YAML otherwise")
 	cmd.Flags().BoolVar(&options.Compression, "compression", false, "Enable store source as a compressed binary blob")
 	cmd.Flags().StringSliceVar(&options.Resources, "resource", nil, "Add a resource")
 	cmd.Flags().StringSliceVar(&options.OpenAPIs, "open-api", nil, "Add an OpenAPI v2 spec")
 	cmd.Flags().StringVar(&options.DeletionPolicy, "deletion-policy", "owner", "Policy used to cleanup child resources, default owner")
 	cmd.Flags().StringSliceVarP(&options.Volumes, "volume", "v", nil, "Mount a volume into the integration container. E.g \"-v pvcname:/container/path\"")
 	cmd.Flags().StringSliceVarP(&options.EnvVars, "env", "e", nil, "Set an environment variable in the integration container. E.g \"-e MY_VAR=my-value\"")
+	cmd.Flags().StringSliceVar(&options.Labels, "label", nil, "Add a label to the integration. E.g \"--label team=my-team\"")
+	cmd.Flags().StringSliceVar(&options.DependsOn, "depends-on", nil, "An object that must be ready before the integration is deployed, "+
+		"in the format apiVersion/Kind/name. E.g \"--depends-on kafka.strimzi.io/v1beta1/Kafka/my-cluster\"")
 
 	// completion support
 	configureKnownCompletions(&cmd)
@@ -103,15 +120,18 @@ type runCmdOptions struct {
 	Logs            bool
 	Sync            bool
 	Dev             bool
+	ProtectKit      bool
 	DeletionPolicy  string
 	IntegrationKit  string
 	Runtime         string
 	IntegrationName string
 	Profile         string
 	OutputFormat    string
+	Save            string
 	Resources       []string
 	OpenAPIs        []string
 	Dependencies    []string
+	Libraries       []string
 	Properties      []string
 	ConfigMaps      []string
 	Secrets         []string
@@ -120,12 +140,53 @@ type runCmdOptions struct {
 	LoggingLevels   []string
 	Volumes         []string
 	EnvVars         []string
+	Labels          []string
+	DependsOn       []string
 }
 
 func (o *runCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
 	if len(args) < 1 {
 		return errors.New("accepts at least 1 arg, received 0")
 	}
+	if len(args) == 1 && isDirectory(args[0]) {
+		if o.Wait || o.Logs || o.Sync || o.Dev {
+			return errors.New("wait, logs, sync and dev modes are not supported when running a directory")
+		}
+		if o.Save != "" {
+			return errors.New("the integrations cannot be saved when running a directory")
+		}
+		if _, err := newIntegrationNameTemplate(o.IntegrationName); err != nil {
+			return err
+		}
+	} else if err := o.validateFiles(args); err != nil {
+		return err
+	}
+
+	if o.ProtectKit && o.IntegrationKit == "" {
+		return errors.New("the kit to protect must be set with --kit")
+	}
+
+	for _, volume := range o.Volumes {
+		volumeConfig := strings.Split(volume, ":")
+		if len(volumeConfig) != 2 || len(strings.TrimSpace(volumeConfig[0])) == 0 || len(strings.TrimSpace(volumeConfig[1])) == 0 {
+			return fmt.Errorf("volume '%s' is invalid, it should be in the format: pvcname:/container/path", volume)
+		}
+	}
+	for _, dependsOn := range o.DependsOn {
+		if _, err := parseObjectReference(dependsOn); err != nil {
+			return err
+		}
+	}
+	for _, label := range o.Labels {
+		if parts := strings.SplitN(label, "=", 2); len(parts) != 2 || parts[0] == "" {
+			return fmt.Errorf("label '%s' is invalid, it should be in the format: key=value", label)
+		}
+	}
+
+	return nil
+}
+
+func (o *runCmdOptions) validateFiles(args []string) error {
 	if len(args) > 1 && o.IntegrationName == "" {
 		return errors.New("integration name is mandatory when using multiple sources")
 	}
@@ -148,13 +209,6 @@ func (o *runCmdOptions) validateArgs(_ *cobra.Command, args []string) error {
 		}
 	}
 
-	for _, volume := range o.Volumes {
-		volumeConfig := strings.Split(volume, ":")
-		if len(volumeConfig) != 2 || len(strings.TrimSpace(volumeConfig[0])) == 0 || len(strings.TrimSpace(volumeConfig[1])) == 0 {
-			return fmt.Errorf("volume '%s' is invalid, it should be in the format: pvcname:/container/path", volume)
-		}
-	}
-
 	return nil
 }
 
@@ -175,13 +229,20 @@ func (o *runCmdOptions) run(_ *cobra.Command, args []string) error {
 		}
 	}
 
+	if len(args) == 1 && isDirectory(args[0]) {
+		return o.runDirectory(c, args[0])
+	}
+
 	integration, err := o.createIntegration(c, args)
 	if err != nil {
 		return err
 	}
+	if integration != nil {
+		o.printCatalogWarnings(c, integration)
+	}
 
 	if o.Dev {
-		cs := make(chan os.Signal)
+		cs := make(chan os.Signal, 1)
 		signal.Notify(cs, os.Interrupt, syscall.SIGTERM)
 		go func() {
 			<-cs
@@ -220,6 +281,69 @@ func (o *runCmdOptions) run(_ *cobra.Command, args []string) error {
 	return nil
 }
 
+// printCatalogWarnings warns about the deprecated components and the invalid endpoint parameters used by the
+// integration, this is done on a best effort basis as the catalog may not be available yet
+func (o *runCmdOptions) printCatalogWarnings(c client.Client, integration *v1alpha1.Integration) {
+	pl, err := platform.GetCurrentPlatform(o.Context, c, integration.Namespace)
+	if err != nil {
+		return
+	}
+	catalog, err := camel.Catalog(o.Context, c, integration.Namespace, pl.Spec.Build.CamelVersion)
+	if err != nil {
+		return
+	}
+
+	meta := metadata.ExtractAll(catalog, integration.Sources())
+	uris := append(meta.FromURIs, meta.ToURIs...)
+	for _, warning := range metadata.DeprecationWarnings(catalog, uris) {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+	for _, warning := range metadata.ParameterWarnings(catalog, uris) {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+}
+
+// saveIntegration writes the integration to the file given with the save option, so that it can be versioned
+// and applied declaratively. The annotations set for the current run only, e.g. the requester, are not saved
+func (o *runCmdOptions) saveIntegration(integration *v1alpha1.Integration) error {
+	var data []byte
+	var err error
+	if strings.EqualFold(path.Ext(o.Save), ".json") {
+		data, err = kubernetes.ToJSON(integration)
+	} else {
+		data, err = kubernetes.ToYAML(integration)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(o.Save, data, 0644); err != nil {
+		return errors.Wrap(err, "unable to save integration "+integration.Name)
+	}
+
+	return nil
+}
+
+// checkPolicy fails when the integration does not follow the naming and labeling policy of the platform,
+// rather than letting the operator hold it back
+func (o *runCmdOptions) checkPolicy(c client.Client, integration *v1alpha1.Integration) error {
+	pl, err := platform.GetCurrentPlatform(o.Context, c, integration.Namespace)
+	if err != nil {
+		// the operator checks the policy anyway once the platform is available
+		return nil
+	}
+
+	violations, err := platform.PolicyViolations(pl.Spec.Policy, integration.Name, integration.Labels)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("integration %s does not follow the platform policy: %s", integration.Name, strings.Join(violations, "; "))
+	}
+
+	return nil
+}
+
 func (o *runCmdOptions) waitForIntegrationReady(integration *v1alpha1.Integration) error {
 	handler := func(i *v1alpha1.Integration) bool {
 		//
@@ -273,10 +397,7 @@ func (o *runCmdOptions) createIntegration(c client.Client, sources []string) (*v
 	return o.updateIntegrationCode(c, sources)
 }
 
-//nolint: gocyclo
 func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string) (*v1alpha1.Integration, error) {
-	namespace := o.Namespace
-
 	name := ""
 	if o.IntegrationName != "" {
 		name = o.IntegrationName
@@ -289,6 +410,26 @@ func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string)
 		return nil, errors.New("unable to determine integration name")
 	}
 
+	integration, existed, err := o.applyIntegration(c, name, sources)
+	if err != nil || integration == nil {
+		return integration, err
+	}
+
+	if !existed {
+		fmt.Printf("integration \"%s\" created\n", name)
+	} else {
+		fmt.Printf("integration \"%s\" updated\n", name)
+	}
+	return integration, nil
+}
+
+// applyIntegration creates or updates the integration with the given name and sources, the returned flag
+// tells whether the integration already existed. No integration is returned when it is only printed
+// in the requested output format.
+// nolint: gocyclo
+func (o *runCmdOptions) applyIntegration(c client.Client, name string, sources []string) (*v1alpha1.Integration, bool, error) {
+	namespace := o.Namespace
+
 	integration := v1alpha1.Integration{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       v1alpha1.IntegrationKind,
@@ -301,6 +442,7 @@ func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string)
 		Spec: v1alpha1.IntegrationSpec{
 			Dependencies:  make([]string, 0, len(o.Dependencies)),
 			Kit:           o.IntegrationKit,
+			KitProtected:  o.ProtectKit,
 			Configuration: make([]v1alpha1.ConfigurationSpec, 0),
 			Repositories:  o.Repositories,
 			Profile:       v1alpha1.TraitProfileByName(o.Profile),
@@ -310,7 +452,7 @@ func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string)
 	for _, source := range sources {
 		data, err := o.loadData(source, o.Compression)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		integration.Spec.AddSources(v1alpha1.SourceSpec{
@@ -325,7 +467,7 @@ func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string)
 	for _, resource := range o.Resources {
 		data, err := o.loadData(resource, o.Compression)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		integration.Spec.AddResources(v1alpha1.ResourceSpec{
@@ -341,7 +483,7 @@ func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string)
 	for _, resource := range o.OpenAPIs {
 		data, err := o.loadData(resource, o.Compression)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 
 		integration.Spec.AddResources(v1alpha1.ResourceSpec{
@@ -367,6 +509,9 @@ func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string)
 	for _, item := range o.Dependencies {
 		integration.Spec.AddDependency(item)
 	}
+	for _, item := range o.Libraries {
+		util.StringSliceUniqueAdd(&integration.Spec.Libraries, kubernetes.SanitizeName(item))
+	}
 	for _, item := range o.Properties {
 		integration.Spec.AddConfiguration("property", item)
 	}
@@ -385,10 +530,24 @@ func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string)
 	for _, item := range o.EnvVars {
 		integration.Spec.AddConfiguration("env", item)
 	}
+	for _, item := range o.Labels {
+		if integration.Labels == nil {
+			integration.Labels = make(map[string]string)
+		}
+		parts := strings.SplitN(item, "=", 2)
+		integration.Labels[parts[0]] = parts[1]
+	}
+	for _, item := range o.DependsOn {
+		ref, err := parseObjectReference(item)
+		if err != nil {
+			return nil, false, err
+		}
+		integration.Spec.DependsOn = append(integration.Spec.DependsOn, ref)
+	}
 
 	for _, traitConf := range o.Traits {
 		if err := o.configureTrait(&integration, traitConf); err != nil {
-			return nil, err
+			return nil, false, err
 		}
 	}
 
@@ -398,21 +557,39 @@ func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string)
 	case "yaml":
 		data, err := kubernetes.ToYAML(&integration)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		fmt.Print(string(data))
-		return nil, nil
+		return nil, false, nil
 
 	case "json":
 		data, err := kubernetes.ToJSON(&integration)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		fmt.Print(string(data))
-		return nil, nil
+		return nil, false, nil
 
 	default:
-		return nil, fmt.Errorf("invalid output format option '%s', should be one of: yaml|json", o.OutputFormat)
+		return nil, false, fmt.Errorf("invalid output format option '%s', should be one of: yaml|json", o.OutputFormat)
+	}
+
+	if err := o.checkPolicy(c, &integration); err != nil {
+		return nil, false, err
+	}
+
+	if o.Save != "" {
+		if err := o.saveIntegration(&integration); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if o.Dev {
+		// the developer is waiting for the build to complete
+		if integration.Annotations == nil {
+			integration.Annotations = make(map[string]string)
+		}
+		integration.Annotations[v1alpha1.BuildPriorityAnnotation] = string(v1alpha1.BuildPriorityInteractive)
 	}
 
 	existed := false
@@ -423,26 +600,21 @@ func (o *runCmdOptions) updateIntegrationCode(c client.Client, sources []string)
 		var key k8sclient.ObjectKey
 		key, err = k8sclient.ObjectKeyFromObject(clone)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		err = c.Get(o.Context, key, clone)
 		if err != nil {
-			return nil, err
+			return nil, false, err
 		}
 		integration.ResourceVersion = clone.ResourceVersion
 		err = c.Update(o.Context, &integration)
 	}
 
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
-	if !existed {
-		fmt.Printf("integration \"%s\" created\n", name)
-	} else {
-		fmt.Printf("integration \"%s\" updated\n", name)
-	}
-	return &integration, nil
+	return &integration, existed, nil
 }
 
 func (*runCmdOptions) loadData(fileName string, compress bool) (string, error) {
@@ -515,3 +687,157 @@ func (*runCmdOptions) configureTrait(integration *v1alpha1.Integration, config s
 	integration.Spec.Traits[traitID] = spec
 	return nil
 }
+
+// parseObjectReference parses a reference in the format apiVersion/Kind/name, where
+// apiVersion is either a core version (e.g. v1) or a group/version pair
+func parseObjectReference(value string) (corev1.ObjectReference, error) {
+	parts := strings.Split(value, "/")
+	for _, part := range parts {
+		if part == "" {
+			parts = nil
+			break
+		}
+	}
+
+	switch len(parts) {
+	case 3:
+		return corev1.ObjectReference{APIVersion: parts[0], Kind: parts[1], Name: parts[2]}, nil
+	case 4:
+		return corev1.ObjectReference{APIVersion: parts[0] + "/" + parts[1], Kind: parts[2], Name: parts[3]}, nil
+	default:
+		return corev1.ObjectReference{}, fmt.Errorf("object reference '%s' is invalid, it should be in the format: apiVersion/Kind/name", value)
+	}
+}
+
+func isDirectory(fileName string) bool {
+	info, err := os.Stat(fileName)
+	return err == nil && info.IsDir()
+}
+
+// newIntegrationNameTemplate parses the template used to name the integrations created when running a directory,
+// e.g. "{{filename}}-svc", where filename is the name of the source file without extension
+func newIntegrationNameTemplate(name string) (*template.Template, error) {
+	if name == "" {
+		name = "{{filename}}"
+	}
+
+	// the functions are placeholders only, the actual values are bound when executing the template
+	tmpl, err := template.New("name").Funcs(integrationNameFuncs("")).Parse(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid integration name template")
+	}
+	return tmpl, nil
+}
+
+func integrationNameFuncs(source string) template.FuncMap {
+	base := path.Base(source)
+	ext := path.Ext(base)
+
+	return template.FuncMap{
+		"filename": func() string { return strings.TrimSuffix(base, ext) },
+		"ext":      func() string { return strings.TrimPrefix(ext, ".") },
+		"dir":      func() string { return path.Base(path.Dir(source)) },
+	}
+}
+
+// integrationNameFor computes the name of the integration running the given source
+func integrationNameFor(tmpl *template.Template, source string) (string, error) {
+	t, err := tmpl.Clone()
+	if err != nil {
+		return "", err
+	}
+
+	var name bytes.Buffer
+	if err := t.Funcs(integrationNameFuncs(source)).Execute(&name, nil); err != nil {
+		return "", err
+	}
+	return kubernetes.SanitizeName(name.String()), nil
+}
+
+// sourcesInDirectory lists the files of the directory written in a supported language
+func sourcesInDirectory(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	sources := make([]string, 0)
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		source := v1alpha1.SourceSpec{DataSpec: v1alpha1.DataSpec{Name: f.Name()}}
+		if source.InferLanguage() == "" {
+			continue
+		}
+		sources = append(sources, path.Join(dir, f.Name()))
+	}
+
+	return sources, nil
+}
+
+// runDirectory runs every source of the directory as a separate integration and prints a summary
+func (o *runCmdOptions) runDirectory(c client.Client, dir string) error {
+	tmpl, err := newIntegrationNameTemplate(o.IntegrationName)
+	if err != nil {
+		return err
+	}
+	sources, err := sourcesInDirectory(dir)
+	if err != nil {
+		return err
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("no source file found in directory %s", dir)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 1, '\t', 0)
+	if o.OutputFormat == "" {
+		fmt.Fprintln(w, "NAME\tSOURCE\tRESULT\tERROR")
+	}
+
+	names := make(map[string]string)
+	failures := 0
+	for _, source := range sources {
+		name, err := integrationNameFor(tmpl, source)
+		if err == nil && name == "" {
+			err = errors.New("empty integration name")
+		}
+		if err == nil && names[name] != "" {
+			err = fmt.Errorf("integration name already used by %s", names[name])
+		}
+
+		result := "failed"
+		if err == nil {
+			names[name] = source
+
+			if o.OutputFormat == "yaml" {
+				fmt.Println("---")
+			}
+
+			var existed bool
+			_, existed, err = o.applyIntegration(c, name, []string{source})
+			if existed {
+				result = "updated"
+			} else {
+				result = "created"
+			}
+		}
+
+		message := ""
+		if err != nil {
+			failures++
+			result = "failed"
+			message = err.Error()
+		}
+
+		if o.OutputFormat == "" {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, source, result, message)
+		}
+	}
+	w.Flush()
+
+	if failures > 0 {
+		return fmt.Errorf("%d of %d integrations failed", failures, len(sources))
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegrationNameTemplate(t *testing.T) {
+	tmpl, err := newIntegrationNameTemplate("{{filename}}-{{ext}}-svc")
+	assert.Nil(t, err)
+
+	name, err := integrationNameFor(tmpl, "routes/MyRoute.java")
+	assert.Nil(t, err)
+	assert.Equal(t, "my-route-java-svc", name)
+
+	tmpl, err = newIntegrationNameTemplate("")
+	assert.Nil(t, err)
+
+	name, err = integrationNameFor(tmpl, "routes/timer.groovy")
+	assert.Nil(t, err)
+	assert.Equal(t, "timer", name)
+
+	_, err = newIntegrationNameTemplate("{{unknown}}")
+	assert.NotNil(t, err)
+}
+
+func TestRunDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-run-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"timer.groovy": "from('timer:tick').to('log:info')",
+		"timer.js":     "from('timer:tick').to('log:info')",
+		"rest.java":    "from(\"direct:a\").to(\"log:info\");",
+		"README.md":    "not a source",
+	}
+	for name, content := range files {
+		assert.Nil(t, ioutil.WriteFile(path.Join(dir, name), []byte(content), 0644))
+	}
+
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	options := runCmdOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "default",
+		},
+		IntegrationName: "{{filename}}-svc",
+	}
+
+	// both timer sources map to the same integration name
+	err = options.runDirectory(c, dir)
+	assert.NotNil(t, err)
+	assert.Equal(t, "1 of 3 integrations failed", err.Error())
+
+	for _, name := range []string{"rest-svc", "timer-svc"} {
+		integration := v1alpha1.NewIntegration("default", name)
+		key := k8sclient.ObjectKey{Namespace: "default", Name: name}
+		assert.Nil(t, c.Get(context.TODO(), key, &integration))
+		assert.Len(t, integration.Spec.Sources, 1)
+	}
+}
+
+func TestParseObjectReference(t *testing.T) {
+	ref, err := parseObjectReference("kafka.strimzi.io/v1beta1/Kafka/my-cluster")
+	assert.Nil(t, err)
+	assert.Equal(t, "kafka.strimzi.io/v1beta1", ref.APIVersion)
+	assert.Equal(t, "Kafka", ref.Kind)
+	assert.Equal(t, "my-cluster", ref.Name)
+
+	ref, err = parseObjectReference("v1/Service/db")
+	assert.Nil(t, err)
+	assert.Equal(t, "v1", ref.APIVersion)
+	assert.Equal(t, "Service", ref.Kind)
+	assert.Equal(t, "db", ref.Name)
+
+	_, err = parseObjectReference("Service/db")
+	assert.NotNil(t, err)
+	_, err = parseObjectReference("apps//Deployment/db")
+	assert.NotNil(t, err)
+}
+
+func TestRunPlatformPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-run-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	source := path.Join(dir, "routes.groovy")
+	assert.Nil(t, ioutil.WriteFile(source, []byte("from('timer:tick').to('log:info')"), 0644))
+
+	platform := v1alpha1.NewIntegrationPlatform("default", "camel-k")
+	platform.Status.Phase = v1alpha1.IntegrationPlatformPhaseReady
+	platform.Spec.Policy.RequiredLabels = []string{"team"}
+
+	c, err := test.NewFakeClient(&platform)
+	assert.Nil(t, err)
+
+	options := runCmdOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "default",
+		},
+		IntegrationName: "routes",
+	}
+
+	_, err = options.createIntegration(c, []string{source})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "missing required labels team")
+
+	options.Labels = []string{"team=integration"}
+	assert.Nil(t, options.validateArgs(nil, []string{source}))
+
+	integration, err := options.createIntegration(c, []string{source})
+	assert.Nil(t, err)
+	assert.Equal(t, "integration", integration.Labels["team"])
+
+	options.Labels = []string{"team"}
+	assert.NotNil(t, options.validateArgs(nil, []string{source}))
+}
+
+func TestRunSave(t *testing.T) {
+	dir, err := ioutil.TempDir("", "camel-k-run-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	source := path.Join(dir, "routes.groovy")
+	assert.Nil(t, ioutil.WriteFile(source, []byte("from('timer:tick').to('log:info')"), 0644))
+
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	options := runCmdOptions{
+		RootCmdOptions: &RootCmdOptions{
+			Context:   context.TODO(),
+			Namespace: "default",
+		},
+		Dependencies: []string{"camel:log"},
+		Traits:       []string{"service.enabled=false"},
+		Save:         path.Join(dir, "routes.yaml"),
+	}
+
+	integration, err := options.createIntegration(c, []string{source})
+	assert.Nil(t, err)
+
+	data, err := ioutil.ReadFile(options.Save)
+	assert.Nil(t, err)
+	saved := v1alpha1.Integration{}
+	assert.Nil(t, yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096).Decode(&saved))
+	assert.Equal(t, integration.Name, saved.Name)
+	assert.Equal(t, integration.Spec.Sources, saved.Spec.Sources)
+	assert.Equal(t, integration.Spec.Dependencies, saved.Spec.Dependencies)
+	assert.Equal(t, integration.Spec.Traits, saved.Spec.Traits)
+
+	options.Save = path.Join(dir, "routes.json")
+	_, err = options.createIntegration(c, []string{source})
+	assert.Nil(t, err)
+
+	data, err = ioutil.ReadFile(options.Save)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `"kind":"Integration"`)
+
+	assert.NotNil(t, options.validateArgs(nil, []string{dir}))
+}
@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/gzip"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func newCmdSource(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	cmd := cobra.Command{
+		Use:   "source",
+		Short: "View and edit the sources stored in an integration",
+		Long:  `View and edit the sources stored in an integration.`,
+	}
+
+	cmd.AddCommand(newCmdSourceGet(rootCmdOptions))
+	cmd.AddCommand(newCmdSourceEdit(rootCmdOptions))
+
+	return &cmd
+}
+
+// findSources returns the indexes of the integration sources with the given name, or of all the sources when the
+// name is empty. The name can be given without the directory the source has been stored with
+func findSources(integration *v1alpha1.Integration, name string) ([]int, error) {
+	indexes := make([]int, 0, len(integration.Spec.Sources))
+	names := make([]string, 0, len(integration.Spec.Sources))
+	for i, source := range integration.Spec.Sources {
+		if name == "" || source.Name == name || path.Base(source.Name) == name {
+			indexes = append(indexes, i)
+		}
+		names = append(names, source.Name)
+	}
+
+	if len(integration.Spec.Sources) == 0 {
+		return nil, fmt.Errorf("integration %s has no stored source", integration.Name)
+	}
+	if len(indexes) == 0 {
+		return nil, fmt.Errorf("integration %s has no source named %s (sources: %s)", integration.Name, name, strings.Join(names, ", "))
+	}
+
+	return indexes, nil
+}
+
+// sourceContent returns the plain content of the given source, resolving the config map it references and
+// uncompressing it when needed
+func sourceContent(ctx context.Context, c client.Client, namespace string, source v1alpha1.SourceSpec) (string, error) {
+	data := source.DataSpec
+	err := kubernetes.Resolve(&data, func(name string) (*corev1.ConfigMap, error) {
+		return kubernetes.GetConfigMap(ctx, c, name, namespace)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if !data.Compression {
+		return data.Content, nil
+	}
+
+	content, err := gzip.UncompressBase64([]byte(data.Content))
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// setSourceContent replaces the content of the given source, that is stored in the integration afterwards and
+// compressed as before. The content of the sources referencing a config map is inlined, so that changing it
+// triggers the redeployment of the integration
+func setSourceContent(source *v1alpha1.SourceSpec, content string) error {
+	source.ContentRef = ""
+	source.ContentKey = ""
+
+	if !source.Compression {
+		source.Content = content
+		return nil
+	}
+
+	compressed, err := gzip.CompressBase64([]byte(content))
+	if err != nil {
+		return err
+	}
+	source.Content = string(compressed)
+
+	return nil
+}
@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+const defaultEditor = "vi"
+
+func newCmdSourceEdit(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := sourceEditCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "edit integration [source]",
+		Short: "Edit a source stored in an integration",
+		Long: `Edit a source stored in an integration, e.g. kamel source edit hello routes.groovy.
+
+The source is opened in the editor set by the EDITOR environment variable (vi by default), and the integration
+is updated with the saved source, which redeploys it. The source name can be omitted when the integration has
+a single source.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			return impl.run(args)
+		},
+	}
+
+	return &cmd
+}
+
+type sourceEditCmdOptions struct {
+	*RootCmdOptions
+}
+
+func (o *sourceEditCmdOptions) validate(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("source edit expects an integration name and an optional source name")
+	}
+
+	return nil
+}
+
+func (o *sourceEditCmdOptions) run(args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	integration := v1alpha1.NewIntegration(o.Namespace, kubernetes.SanitizeName(args[0]))
+	if err := c.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: integration.Name}, &integration); err != nil {
+		return err
+	}
+
+	name := ""
+	if len(args) == 2 {
+		name = args[1]
+	}
+	indexes, err := findSources(&integration, name)
+	if err != nil {
+		return err
+	}
+	if len(indexes) > 1 {
+		return fmt.Errorf("integration %s has %d sources, the name of the source to edit is required", integration.Name, len(indexes))
+	}
+
+	source := &integration.Spec.Sources[indexes[0]]
+	content, err := sourceContent(o.Context, c, integration.Namespace, *source)
+	if err != nil {
+		return err
+	}
+
+	edited, err := editContent(path.Base(source.Name), content)
+	if err != nil {
+		return err
+	}
+	if edited == content {
+		fmt.Printf("source %s of integration \"%s\" not changed\n", source.Name, integration.Name)
+		return nil
+	}
+
+	if err := setSourceContent(source, edited); err != nil {
+		return err
+	}
+	if err := c.Update(o.Context, &integration); err != nil {
+		return err
+	}
+
+	fmt.Printf("source %s of integration \"%s\" updated\n", source.Name, integration.Name)
+	return nil
+}
+
+// editContent opens the given content in the user editor, from a temporary file with the given name so that the
+// editor recognizes the language, and returns the saved content
+func editContent(name string, content string) (string, error) {
+	dir, err := ioutil.TempDir("", "kamel-source-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+
+	file := path.Join(dir, name)
+	if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+		return "", err
+	}
+
+	// the editor may be given with arguments, e.g. code --wait
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{defaultEditor}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], file)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %v", editor[0], err)
+	}
+
+	edited, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	return string(edited), nil
+}
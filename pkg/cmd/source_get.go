@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+func newCmdSourceGet(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := sourceGetCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "get integration [source]",
+		Short: "Print the sources stored in an integration",
+		Long: `Print the sources stored in an integration, e.g. kamel source get hello routes.groovy.
+
+All the sources are printed when no source name is given, each one being preceded by its name.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			return impl.run(args, os.Stdout)
+		},
+	}
+
+	return &cmd
+}
+
+type sourceGetCmdOptions struct {
+	*RootCmdOptions
+}
+
+func (o *sourceGetCmdOptions) validate(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New("source get expects an integration name and an optional source name")
+	}
+
+	return nil
+}
+
+func (o *sourceGetCmdOptions) run(args []string, out io.Writer) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	integration := v1alpha1.NewIntegration(o.Namespace, kubernetes.SanitizeName(args[0]))
+	if err := c.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: integration.Name}, &integration); err != nil {
+		return err
+	}
+
+	name := ""
+	if len(args) == 2 {
+		name = args[1]
+	}
+	indexes, err := findSources(&integration, name)
+	if err != nil {
+		return err
+	}
+
+	for n, i := range indexes {
+		source := integration.Spec.Sources[i]
+		content, err := sourceContent(o.Context, c, integration.Namespace, source)
+		if err != nil {
+			return err
+		}
+
+		if name == "" {
+			if n > 0 {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintf(out, "==> %s <==\n", source.Name)
+		}
+		fmt.Fprint(out, content)
+		if len(content) > 0 && content[len(content)-1] != '\n' {
+			fmt.Fprintln(out)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/gzip"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newSourceTestOptions(t *testing.T) *RootCmdOptions {
+	compressed, err := gzip.CompressBase64([]byte("from('timer:tock').to('log:info')\n"))
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "my-route")
+	integration.Spec.Sources = []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "routes.groovy",
+				Content: "from('timer:tick').to('log:info')",
+			},
+		},
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:        "sources/tock.groovy",
+				Content:     string(compressed),
+				Compression: true,
+			},
+		},
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:       "ref.js",
+				ContentRef: "my-route-ref",
+			},
+		},
+	}
+
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-route-ref",
+		},
+		Data: map[string]string{
+			"content": "from('timer:js').to('log:info')",
+		},
+	}
+
+	c, err := test.NewFakeClient(&integration, &cm)
+	assert.Nil(t, err)
+
+	return &RootCmdOptions{
+		Context:   context.TODO(),
+		Namespace: "ns",
+		_client:   c,
+	}
+}
+
+func TestSourceGet(t *testing.T) {
+	options := sourceGetCmdOptions{RootCmdOptions: newSourceTestOptions(t)}
+
+	out := bytes.Buffer{}
+	assert.Nil(t, options.run([]string{"my-route", "tock.groovy"}, &out))
+	assert.Equal(t, "from('timer:tock').to('log:info')\n", out.String())
+
+	out.Reset()
+	assert.Nil(t, options.run([]string{"my-route"}, &out))
+	assert.Equal(t, `==> routes.groovy <==
+from('timer:tick').to('log:info')
+
+==> sources/tock.groovy <==
+from('timer:tock').to('log:info')
+
+==> ref.js <==
+from('timer:js').to('log:info')
+`, out.String())
+
+	assert.NotNil(t, options.run([]string{"my-route", "missing.groovy"}, &out))
+	assert.NotNil(t, options.run([]string{"missing"}, &out))
+}
+
+func TestSourceEdit(t *testing.T) {
+	options := sourceEditCmdOptions{RootCmdOptions: newSourceTestOptions(t)}
+	c, err := options.GetCmdClient()
+	assert.Nil(t, err)
+
+	editor := os.Getenv("EDITOR")
+	defer os.Setenv("EDITOR", editor)
+	os.Setenv("EDITOR", "sed -i s/log:info/log:debug/")
+
+	// several sources, the name is required
+	assert.NotNil(t, options.run([]string{"my-route"}))
+
+	assert.Nil(t, options.run([]string{"my-route", "tock.groovy"}))
+	assert.Nil(t, options.run([]string{"my-route", "ref.js"}))
+
+	integration := v1alpha1.NewIntegration("ns", "my-route")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "my-route"}, &integration))
+	assert.Equal(t, "from('timer:tick').to('log:info')", integration.Spec.Sources[0].Content)
+
+	tock := integration.Spec.Sources[1]
+	assert.True(t, tock.Compression)
+	content, err := sourceContent(context.TODO(), c, "ns", tock)
+	assert.Nil(t, err)
+	assert.Equal(t, "from('timer:tock').to('log:debug')\n", content)
+
+	assert.Equal(t, v1alpha1.DataSpec{
+		Name:    "ref.js",
+		Content: "from('timer:js').to('log:debug')",
+	}, integration.Spec.Sources[2].DataSpec)
+
+	os.Setenv("EDITOR", "false")
+	assert.NotNil(t, options.run([]string{"my-route", "routes.groovy"}))
+}
@@ -0,0 +1,298 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/jolokia"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// testPollInterval is the interval the test pod and the integration logs are polled at
+const testPollInterval = 2 * time.Second
+
+func newCmdTest(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	options := testCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "test integration",
+		Short: "Test a running integration",
+		Long: `Test a running integration.
+
+The message given with --send is sent to an endpoint of the integration, e.g. direct:start, by a short-lived
+test pod calling the Jolokia agent of the integration, which requires the jolokia trait to be enabled. The reply
+is then asserted with --expect-body, and the logs of the integration pods with --expect-log. The command exits
+with an error as soon as an assertion fails, which makes it suitable for CI pipelines.`,
+		Args: options.validate,
+		RunE: options.run,
+	}
+
+	cmd.Flags().StringVar(&options.Send, "send", "", "The endpoint of the integration the body is sent to, e.g. direct:start")
+	cmd.Flags().StringVar(&options.Body, "body", "", "The body of the message sent to the endpoint")
+	cmd.Flags().StringVar(&options.ExpectBody, "expect-body", "", "Wait for the reply of the endpoint and check it contains the given text")
+	cmd.Flags().StringArrayVar(&options.ExpectLogs, "expect-log", nil, "Check the integration logs contain the given text, can be repeated")
+	cmd.Flags().StringVar(&options.Image, "image", "curlimages/curl:7.65.3", "The image of the test pod, that must provide curl")
+	cmd.Flags().DurationVar(&options.Timeout, "timeout", 2*time.Minute, "The time the assertions may take to succeed")
+
+	// completion support
+	configureKnownCompletions(&cmd)
+
+	return &cmd
+}
+
+type testCmdOptions struct {
+	*RootCmdOptions
+	Send       string
+	Body       string
+	ExpectBody string
+	ExpectLogs []string
+	Image      string
+	Timeout    time.Duration
+}
+
+func (o *testCmdOptions) validate(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("accepts 1 arg, received %d", len(args))
+	}
+	if o.Send == "" && len(o.ExpectLogs) == 0 {
+		return errors.New("nothing to test, use --send or --expect-log")
+	}
+	if o.ExpectBody != "" && o.Send == "" {
+		return errors.New("the --expect-body assertion requires the --send flag")
+	}
+
+	return nil
+}
+
+func (o *testCmdOptions) run(_ *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	integration := v1alpha1.NewIntegration(o.Namespace, args[0])
+	key := k8sclient.ObjectKey{
+		Namespace: o.Namespace,
+		Name:      args[0],
+	}
+	if err := c.Get(o.Context, key, &integration); err != nil {
+		return err
+	}
+	if integration.Status.Phase != v1alpha1.IntegrationPhaseRunning {
+		return fmt.Errorf("integration %s is not running (phase %s)", integration.Name, integration.Status.Phase)
+	}
+
+	pods, err := c.CoreV1().Pods(o.Namespace).List(metav1.ListOptions{
+		LabelSelector: "camel.apache.org/integration=" + integration.Name,
+	})
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(o.Timeout)
+	since := metav1.Now()
+
+	if o.Send != "" {
+		endpoint := ""
+		for _, pod := range pods.Items {
+			if endpoint = jolokia.Endpoint(pod); endpoint != "" {
+				break
+			}
+		}
+		if endpoint == "" {
+			return fmt.Errorf("no pod of integration %s exposes a Jolokia agent over HTTP, enable the jolokia trait", integration.Name)
+		}
+
+		reply, err := o.send(c, &integration, endpoint, deadline)
+		if err != nil {
+			return errors.Wrapf(err, "cannot send message to %s", o.Send)
+		}
+		fmt.Printf("sent message to %s\n", o.Send)
+
+		if o.ExpectBody != "" {
+			if !strings.Contains(reply, o.ExpectBody) {
+				return fmt.Errorf("test failed: reply %q does not contain %q", reply, o.ExpectBody)
+			}
+			fmt.Printf("reply contains %q\n", o.ExpectBody)
+		}
+	}
+
+	if len(o.ExpectLogs) > 0 {
+		if err := o.waitForLogs(c, pods.Items, since, deadline); err != nil {
+			return err
+		}
+		for _, expected := range o.ExpectLogs {
+			fmt.Printf("logs contain %q\n", expected)
+		}
+	}
+
+	fmt.Println("test passed")
+
+	return nil
+}
+
+// send runs the test pod sending the body to the endpoint through the Jolokia agent, and returns the reply
+func (o *testCmdOptions) send(c client.Client, integration *v1alpha1.Integration, endpoint string, deadline time.Time) (string, error) {
+	request, err := jolokia.NewSendBodyRequest(o.Send, o.Body, o.ExpectBody != "")
+	if err != nil {
+		return "", err
+	}
+
+	pod, err := c.CoreV1().Pods(o.Namespace).Create(newTestPod(integration, o.Image, endpoint, request))
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if err := c.CoreV1().Pods(o.Namespace).Delete(pod.Name, &metav1.DeleteOptions{}); err != nil {
+			fmt.Printf("cannot delete test pod %s: %v\n", pod.Name, err)
+		}
+	}()
+
+	for {
+		pod, err = c.CoreV1().Pods(o.Namespace).Get(pod.Name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			break
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("test pod %s not completed after %s", pod.Name, o.Timeout)
+		}
+		select {
+		case <-o.Context.Done():
+			return "", o.Context.Err()
+		case <-time.After(testPollInterval):
+		}
+	}
+
+	logs, err := c.CoreV1().Pods(o.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{}).DoRaw()
+	if err != nil {
+		return "", err
+	}
+	if pod.Status.Phase == corev1.PodFailed {
+		return "", fmt.Errorf("test pod %s failed: %s", pod.Name, strings.TrimSpace(string(logs)))
+	}
+
+	return jolokia.ParseExecResponse(logs)
+}
+
+// waitForLogs polls the logs the integration pods have written since the beginning of the test,
+// until they contain all the expected texts
+func (o *testCmdOptions) waitForLogs(c client.Client, pods []corev1.Pod, since metav1.Time, deadline time.Time) error {
+	for {
+		var logs strings.Builder
+		for _, pod := range pods {
+			if pod.Status.Phase != corev1.PodRunning {
+				continue
+			}
+			data, err := c.CoreV1().Pods(o.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+				Container: integrationContainer(pod),
+				SinceTime: &since,
+			}).DoRaw()
+			if err != nil {
+				return err
+			}
+			logs.Write(data)
+		}
+
+		missing := missingLogs(logs.String(), o.ExpectLogs)
+		if len(missing) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("test failed: logs do not contain %q after %s", missing, o.Timeout)
+		}
+
+		select {
+		case <-o.Context.Done():
+			return o.Context.Err()
+		case <-time.After(testPollInterval):
+		}
+	}
+}
+
+// integrationContainer returns the name of the container running the integration, e.g. not a sidecar
+func integrationContainer(pod corev1.Pod) string {
+	for _, c := range pod.Spec.Containers {
+		if c.Name == pod.Labels["camel.apache.org/integration"] {
+			return c.Name
+		}
+	}
+	if len(pod.Spec.Containers) > 0 {
+		return pod.Spec.Containers[0].Name
+	}
+	return ""
+}
+
+// missingLogs returns the expected texts the logs don't contain
+func missingLogs(logs string, expected []string) []string {
+	missing := make([]string, 0)
+	for _, e := range expected {
+		if !strings.Contains(logs, e) {
+			missing = append(missing, e)
+		}
+	}
+	return missing
+}
+
+// newTestPod returns the pod posting the Jolokia request to the agent of the integration, the response
+// being printed to the pod logs
+func newTestPod(integration *v1alpha1.Integration, image string, endpoint string, request []byte) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:    integration.Namespace,
+			GenerateName: integration.Name + "-test-",
+			Labels: map[string]string{
+				"camel.apache.org/integration-test": integration.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy: corev1.RestartPolicyNever,
+			Containers: []corev1.Container{
+				{
+					Name:  "test",
+					Image: image,
+					Command: []string{
+						"curl", "-sS", "-X", "POST",
+						"-H", "Content-Type: application/json",
+						"-d", string(request),
+						endpoint,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTestValidate(t *testing.T) {
+	options := testCmdOptions{}
+	assert.NotNil(t, options.validate(nil, []string{}))
+	assert.NotNil(t, options.validate(nil, []string{"orders"}))
+
+	options.ExpectBody = "OK"
+	assert.NotNil(t, options.validate(nil, []string{"orders"}))
+
+	options.Send = "direct:start"
+	assert.Nil(t, options.validate(nil, []string{"orders"}))
+
+	options = testCmdOptions{ExpectLogs: []string{"order received"}}
+	assert.Nil(t, options.validate(nil, []string{"orders"}))
+}
+
+func TestMissingLogs(t *testing.T) {
+	logs := "2019-07-01 INFO [route1] order received\n2019-07-01 INFO [route1] order stored\n"
+	assert.Empty(t, missingLogs(logs, []string{"order received", "order stored"}))
+	assert.Equal(t, []string{"order shipped"}, missingLogs(logs, []string{"order received", "order shipped"}))
+}
+
+func TestNewTestPod(t *testing.T) {
+	integration := v1alpha1.NewIntegration("ns", "orders")
+	pod := newTestPod(&integration, "curlimages/curl:7.65.3", "http://10.0.0.1:8778/jolokia/", []byte(`{"type":"exec"}`))
+
+	assert.Equal(t, "ns", pod.Namespace)
+	assert.Equal(t, "orders-test-", pod.GenerateName)
+	assert.NotContains(t, pod.Labels, "camel.apache.org/integration")
+	assert.Equal(t, corev1.RestartPolicyNever, pod.Spec.RestartPolicy)
+	assert.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, "curlimages/curl:7.65.3", pod.Spec.Containers[0].Image)
+	assert.Equal(t, []string{
+		"curl", "-sS", "-X", "POST", "-H", "Content-Type: application/json", "-d", `{"type":"exec"}`, "http://10.0.0.1:8778/jolokia/",
+	}, pod.Spec.Containers[0].Command)
+}
+
+func TestIntegrationContainer(t *testing.T) {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{"camel.apache.org/integration": "orders"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "istio-proxy"}, {Name: "orders"}},
+		},
+	}
+	assert.Equal(t, "orders", integrationContainer(pod))
+
+	pod.Spec.Containers = []corev1.Container{{Name: "user-container"}}
+	assert.Equal(t, "user-container", integrationContainer(pod))
+}
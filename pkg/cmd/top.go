@@ -0,0 +1,243 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var topSortKeys = []string{"name", "cpu", "memory", "exchanges", "failed"}
+
+func newCmdTop(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := topCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "top",
+		Short: "Display the resource usage and the throughput of the integrations",
+		Long: `Display the resource usage and the throughput of the integrations.
+
+The CPU and memory usage are read from the metrics server, while the exchanges are read from the
+route statistics reported in the integration status, which requires the jolokia trait to be enabled.
+The throughput is computed between consecutive refreshes when watching.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			return impl.run()
+		},
+	}
+
+	cmd.Flags().StringVar(&impl.sortBy, "sort-by", "name", "Sort the integrations by one of: name|cpu|memory|exchanges|failed")
+	cmd.Flags().BoolVarP(&impl.watch, "watch", "w", false, "Refresh the figures periodically")
+	cmd.Flags().DurationVar(&impl.interval, "interval", 5*time.Second, "The refresh interval when watching")
+
+	return &cmd
+}
+
+type topCmdOptions struct {
+	*RootCmdOptions
+	sortBy   string
+	watch    bool
+	interval time.Duration
+}
+
+// integrationUsage aggregates the figures of the pods of an integration
+type integrationUsage struct {
+	Name      string
+	Phase     v1alpha1.IntegrationPhase
+	Pods      int
+	CPU       int64
+	Memory    int64
+	Exchanges int64
+	Failed    int64
+	// Rate is the number of exchanges completed per second since the last refresh, negative when unknown
+	Rate       float64
+	UpdateTime *metav1.Time
+}
+
+// podMetricsList is the subset of the metrics.k8s.io PodMetricsList read by the command
+type podMetricsList struct {
+	Items []struct {
+		Metadata struct {
+			Labels map[string]string `json:"labels"`
+		} `json:"metadata"`
+		Containers []struct {
+			Usage map[string]resource.Quantity `json:"usage"`
+		} `json:"containers"`
+	} `json:"items"`
+}
+
+func (o *topCmdOptions) validate(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("top does not accept arguments, received %d", len(args))
+	}
+	for _, k := range topSortKeys {
+		if o.sortBy == k {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("invalid sort key %s, should be one of: name|cpu|memory|exchanges|failed", o.sortBy)
+}
+
+func (o *topCmdOptions) run() error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	var previous map[string]integrationUsage
+	for {
+		usages, err := o.collect(c, previous)
+		if err != nil {
+			return err
+		}
+
+		sortUsages(usages, o.sortBy)
+		printUsages(os.Stdout, usages)
+
+		if !o.watch {
+			return nil
+		}
+
+		previous = make(map[string]integrationUsage, len(usages))
+		for _, u := range usages {
+			previous[u.Name] = u
+		}
+
+		select {
+		case <-o.Context.Done():
+			return nil
+		case <-time.After(o.interval):
+			fmt.Println()
+		}
+	}
+}
+
+func (o *topCmdOptions) collect(c client.Client, previous map[string]integrationUsage) ([]integrationUsage, error) {
+	integrations := v1alpha1.NewIntegrationList()
+	if err := c.List(o.Context, &k8sclient.ListOptions{Namespace: o.Namespace}, &integrations); err != nil {
+		return nil, err
+	}
+
+	var metrics podMetricsList
+	data, err := c.CoreV1().RESTClient().Get().
+		AbsPath("/apis/metrics.k8s.io/v1beta1", "namespaces", o.Namespace, "pods").
+		Param("labelSelector", "camel.apache.org/integration").
+		DoRaw()
+	if err == nil {
+		err = json.Unmarshal(data, &metrics)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "resource usage not available, is the metrics server installed? (%s)\n", err.Error())
+	}
+
+	return computeUsages(integrations.Items, metrics, previous), nil
+}
+
+// computeUsages aggregates the pod metrics and the route statistics of each integration
+func computeUsages(integrations []v1alpha1.Integration, metrics podMetricsList, previous map[string]integrationUsage) []integrationUsage {
+	usages := make([]integrationUsage, 0, len(integrations))
+
+	for _, integration := range integrations {
+		u := integrationUsage{
+			Name:       integration.Name,
+			Phase:      integration.Status.Phase,
+			Rate:       -1,
+			UpdateTime: integration.Status.RoutesUpdateTime,
+		}
+
+		for _, pod := range metrics.Items {
+			if pod.Metadata.Labels["camel.apache.org/integration"] != integration.Name {
+				continue
+			}
+			u.Pods++
+			for _, container := range pod.Containers {
+				if cpu, ok := container.Usage["cpu"]; ok {
+					u.CPU += cpu.MilliValue()
+				}
+				if memory, ok := container.Usage["memory"]; ok {
+					u.Memory += memory.Value()
+				}
+			}
+		}
+
+		for _, route := range integration.Status.Routes {
+			u.Exchanges += route.ExchangesCompleted
+			u.Failed += route.ExchangesFailed
+		}
+
+		if p, ok := previous[u.Name]; ok && p.UpdateTime != nil && u.UpdateTime != nil {
+			if elapsed := u.UpdateTime.Sub(p.UpdateTime.Time).Seconds(); elapsed > 0 && u.Exchanges >= p.Exchanges {
+				u.Rate = float64(u.Exchanges-p.Exchanges) / elapsed
+			} else if elapsed == 0 {
+				u.Rate = p.Rate
+			}
+		}
+
+		usages = append(usages, u)
+	}
+
+	return usages
+}
+
+// sortUsages sorts the usages by name, or by decreasing figures
+func sortUsages(usages []integrationUsage, key string) {
+	sort.SliceStable(usages, func(i, j int) bool {
+		switch key {
+		case "cpu":
+			return usages[i].CPU > usages[j].CPU
+		case "memory":
+			return usages[i].Memory > usages[j].Memory
+		case "exchanges":
+			return usages[i].Exchanges > usages[j].Exchanges
+		case "failed":
+			return usages[i].Failed > usages[j].Failed
+		default:
+			return usages[i].Name < usages[j].Name
+		}
+	})
+}
+
+func printUsages(out io.Writer, usages []integrationUsage) {
+	w := tabwriter.NewWriter(out, 0, 8, 1, '\t', 0)
+	fmt.Fprintln(w, "NAME\tPHASE\tPODS\tCPU(cores)\tMEMORY(bytes)\tEXCHANGES\tFAILED\tRATE(/s)")
+	for _, u := range usages {
+		rate := "-"
+		if u.Rate >= 0 {
+			rate = fmt.Sprintf("%.2f", u.Rate)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%dm\t%dMi\t%d\t%d\t%s\n", u.Name, u.Phase, u.Pods, u.CPU, u.Memory/(1024*1024), u.Exchanges, u.Failed, rate)
+	}
+	w.Flush()
+}
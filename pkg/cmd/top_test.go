@@ -0,0 +1,96 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComputeUsages(t *testing.T) {
+	var metrics podMetricsList
+	assert.Nil(t, json.Unmarshal([]byte(`{"items": [
+		{"metadata": {"labels": {"camel.apache.org/integration": "orders"}}, "containers": [{"usage": {"cpu": "150m", "memory": "200Mi"}}]},
+		{"metadata": {"labels": {"camel.apache.org/integration": "orders"}}, "containers": [{"usage": {"cpu": "50m", "memory": "100Mi"}}]},
+		{"metadata": {"labels": {"camel.apache.org/integration": "billing"}}, "containers": [{"usage": {"cpu": "1", "memory": "64Mi"}}]}
+	]}`), &metrics))
+
+	first := metav1.NewTime(time.Now())
+	second := metav1.NewTime(first.Add(10 * time.Second))
+
+	orders := v1alpha1.NewIntegration("ns", "orders")
+	orders.Status.Phase = v1alpha1.IntegrationPhaseRunning
+	orders.Status.Routes = []v1alpha1.RouteStatus{
+		{ID: "route1", ExchangesCompleted: 100, ExchangesFailed: 2},
+		{ID: "route2", ExchangesCompleted: 50},
+	}
+	orders.Status.RoutesUpdateTime = &first
+	billing := v1alpha1.NewIntegration("ns", "billing")
+
+	usages := computeUsages([]v1alpha1.Integration{orders, billing}, metrics, nil)
+	assert.Len(t, usages, 2)
+	assert.Equal(t, 2, usages[0].Pods)
+	assert.Equal(t, int64(200), usages[0].CPU)
+	assert.Equal(t, int64(300*1024*1024), usages[0].Memory)
+	assert.Equal(t, int64(150), usages[0].Exchanges)
+	assert.Equal(t, int64(2), usages[0].Failed)
+	assert.Equal(t, float64(-1), usages[0].Rate)
+	assert.Equal(t, int64(1000), usages[1].CPU)
+
+	previous := map[string]integrationUsage{"orders": usages[0]}
+	orders.Status.Routes[0].ExchangesCompleted = 200
+	orders.Status.RoutesUpdateTime = &second
+	usages = computeUsages([]v1alpha1.Integration{orders}, metrics, previous)
+	assert.Equal(t, float64(10), usages[0].Rate)
+
+	sortUsages(usages, "name")
+	var out bytes.Buffer
+	printUsages(&out, usages)
+	assert.Contains(t, out.String(), "RATE(/s)")
+	assert.Contains(t, out.String(), "10.00")
+}
+
+func TestSortUsages(t *testing.T) {
+	usages := []integrationUsage{
+		{Name: "b", CPU: 10, Failed: 5},
+		{Name: "a", CPU: 30, Failed: 1},
+		{Name: "c", CPU: 20, Failed: 3},
+	}
+
+	sortUsages(usages, "cpu")
+	assert.Equal(t, "a", usages[0].Name)
+	assert.Equal(t, "b", usages[2].Name)
+
+	sortUsages(usages, "failed")
+	assert.Equal(t, "b", usages[0].Name)
+
+	sortUsages(usages, "name")
+	assert.Equal(t, "a", usages[0].Name)
+
+	options := topCmdOptions{sortBy: "throughput"}
+	assert.NotNil(t, options.validate(nil))
+	options.sortBy = "memory"
+	assert.Nil(t, options.validate(nil))
+}
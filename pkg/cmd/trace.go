@@ -0,0 +1,166 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/jolokia"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newCmdTrace(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	options := traceCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+
+	cmd := cobra.Command{
+		Use:   "trace integration",
+		Short: "Display the messages traced in a running integration",
+		Long: `Display the messages traced in a running integration.
+
+The messages flowing through the routes are sampled by the Camel backlog tracer, which requires the tracing and
+the jolokia traits to be enabled, and are periodically collected by the operator, that keeps the most recent ones.`,
+		Args: options.validate,
+		RunE: options.run,
+	}
+
+	cmd.Flags().StringVar(&options.Route, "route", "", "Only display the messages traced in the given route")
+	cmd.Flags().BoolVar(&options.JSON, "json", false, "Display the traced messages as JSON, one per line")
+	cmd.Flags().BoolVarP(&options.Follow, "follow", "f", false, "Keep displaying the messages as they are collected")
+	cmd.Flags().DurationVar(&options.Interval, "interval", 5*time.Second, "The refresh interval when following")
+
+	// completion support
+	configureKnownCompletions(&cmd)
+
+	return &cmd
+}
+
+type traceCmdOptions struct {
+	*RootCmdOptions
+	Route    string
+	JSON     bool
+	Follow   bool
+	Interval time.Duration
+}
+
+func (o *traceCmdOptions) validate(_ *cobra.Command, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("accepts 1 arg, received %d", len(args))
+	}
+
+	return nil
+}
+
+func (o *traceCmdOptions) run(_ *cobra.Command, args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	integration := v1alpha1.NewIntegration(o.Namespace, args[0])
+	key := k8sclient.ObjectKey{
+		Namespace: o.Namespace,
+		Name:      args[0],
+	}
+	if err := c.Get(o.Context, key, &integration); err != nil {
+		return err
+	}
+
+	key.Name = integration.TracesConfigMapName()
+	seen := make(map[string]bool)
+	for {
+		cm := corev1.ConfigMap{}
+		err := c.Get(o.Context, key, &cm)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		if err != nil && !o.Follow {
+			return fmt.Errorf("no message traced in integration %s, is the tracing trait enabled?", integration.Name)
+		}
+
+		traces := make([]jolokia.TracedMessage, 0)
+		if data := cm.Data[v1alpha1.IntegrationTracesKey]; data != "" {
+			if err := json.Unmarshal([]byte(data), &traces); err != nil {
+				return err
+			}
+		}
+		if err := printTraces(os.Stdout, traces, seen, o.Route, o.JSON); err != nil {
+			return err
+		}
+
+		if !o.Follow {
+			return nil
+		}
+
+		select {
+		case <-o.Context.Done():
+			return nil
+		case <-time.After(o.Interval):
+		}
+	}
+}
+
+// printTraces prints the traced messages of the given route, or of all the routes, that haven't been seen yet
+func printTraces(w io.Writer, traces []jolokia.TracedMessage, seen map[string]bool, route string, asJSON bool) error {
+	for _, m := range traces {
+		key := fmt.Sprintf("%s/%d", m.Pod, m.UID)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if route != "" && m.RouteID != route {
+			continue
+		}
+
+		if asJSON {
+			data, err := json.Marshal(m)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(w, string(data))
+			continue
+		}
+
+		fmt.Fprintf(w, "%s %s %s/%s %s\n", m.Timestamp, m.Pod, m.RouteID, m.Node, m.ExchangeID)
+
+		headers := make([]string, 0, len(m.Headers))
+		for h := range m.Headers {
+			headers = append(headers, h)
+		}
+		sort.Strings(headers)
+		for _, h := range headers {
+			fmt.Fprintf(w, "  %s: %s\n", h, m.Headers[h])
+		}
+		fmt.Fprintf(w, "  body: %s\n", m.Body)
+	}
+
+	return nil
+}
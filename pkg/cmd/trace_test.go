@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/jolokia"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintTraces(t *testing.T) {
+	traces := []jolokia.TracedMessage{
+		{
+			UID:        1,
+			Timestamp:  "2019-07-01T10:00:00.000+0000",
+			Pod:        "orders-1",
+			RouteID:    "orders",
+			Node:       "log1",
+			ExchangeID: "ID-1",
+			Headers:    map[string]string{"b": "2", "a": "1"},
+			Body:       "hello",
+		},
+		{
+			UID:     2,
+			Pod:     "orders-1",
+			RouteID: "payments",
+			Node:    "to1",
+		},
+	}
+
+	seen := make(map[string]bool)
+	out := bytes.Buffer{}
+	assert.Nil(t, printTraces(&out, traces, seen, "orders", false))
+	assert.Equal(t, "2019-07-01T10:00:00.000+0000 orders-1 orders/log1 ID-1\n  a: 1\n  b: 2\n  body: hello\n", out.String())
+
+	// messages are only printed once when following
+	out.Reset()
+	assert.Nil(t, printTraces(&out, append(traces, jolokia.TracedMessage{UID: 3, Pod: "orders-1", RouteID: "orders"}), seen, "", true))
+	assert.Equal(t, `{"uid":3,"timestamp":"","pod":"orders-1","routeId":"orders","node":"","exchangeId":""}`+"\n", out.String())
+}
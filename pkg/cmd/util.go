@@ -25,6 +25,16 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// ExitError is returned by the commands that exit with a specific status
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
 // DeleteIntegration --
 func DeleteIntegration(ctx context.Context, c client.Client, name string, namespace string) error {
 	integration := v1alpha1.Integration{
@@ -0,0 +1,193 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/watch"
+)
+
+const (
+	// WaitExitCodeFailed is the exit code of kamel wait when the integration fails before reaching the phase
+	WaitExitCodeFailed = 1
+	// WaitExitCodeTimeout is the exit code of kamel wait when the timeout expires
+	WaitExitCodeTimeout = 2
+)
+
+var waitPhases = []v1alpha1.IntegrationPhase{
+	v1alpha1.IntegrationPhaseWaitingForPlatform,
+	v1alpha1.IntegrationPhaseBuildingKit,
+	v1alpha1.IntegrationPhaseResolvingKit,
+	v1alpha1.IntegrationPhaseDeploying,
+	v1alpha1.IntegrationPhaseRunning,
+	v1alpha1.IntegrationPhaseError,
+}
+
+func newCmdWait(rootCmdOptions *RootCmdOptions) *cobra.Command {
+	impl := waitCmdOptions{
+		RootCmdOptions: rootCmdOptions,
+	}
+	cmd := cobra.Command{
+		Use:   "wait integration/name",
+		Short: "Wait for an integration to reach a phase",
+		Long: `Wait for an integration to reach a phase, e.g. kamel wait integration/hello --for=Running --timeout=5m.
+
+The command exits with status 0 once the integration is in the phase, with status 1 if the integration fails
+before, e.g. because its kit cannot be built, the reason of the failure being printed, and with status 2 when
+the timeout expires.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if err := impl.validate(args); err != nil {
+				return err
+			}
+			return impl.run(args)
+		},
+	}
+
+	cmd.Flags().StringVar(&impl.For, "for", string(v1alpha1.IntegrationPhaseRunning), "The phase to wait for, e.g. Running or BuildingKit")
+	cmd.Flags().DurationVar(&impl.Timeout, "timeout", 0, "The maximum time to wait, e.g. 5m (waits forever by default)")
+
+	return &cmd
+}
+
+type waitCmdOptions struct {
+	*RootCmdOptions
+	For     string
+	Timeout time.Duration
+	phase   v1alpha1.IntegrationPhase
+}
+
+func (o *waitCmdOptions) validate(args []string) error {
+	if len(args) != 1 {
+		return errors.New("wait expects exactly one integration, e.g. integration/hello")
+	}
+	if kind := strings.SplitN(args[0], "/", 2); len(kind) == 2 && kind[0] != "integration" && kind[0] != "it" {
+		return fmt.Errorf("cannot wait for %s, only integrations are supported", kind[0])
+	}
+	if o.Timeout < 0 {
+		return errors.New("timeout cannot be negative")
+	}
+
+	phase, ok := parsePhase(o.For)
+	if !ok {
+		return fmt.Errorf("unknown integration phase %s", o.For)
+	}
+	o.phase = phase
+
+	return nil
+}
+
+func (o *waitCmdOptions) run(args []string) error {
+	c, err := o.GetCmdClient()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	if i := strings.Index(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+
+	integration := v1alpha1.NewIntegration(o.Namespace, kubernetes.SanitizeName(name))
+	if err := c.Get(o.Context, k8sclient.ObjectKey{Namespace: o.Namespace, Name: integration.Name}, &integration); err != nil {
+		return err
+	}
+
+	ctx := o.Context
+	if o.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.Timeout)
+		defer cancel()
+	}
+
+	last := integration.DeepCopy()
+	for !o.done(last) {
+		// the watch is restarted when closed by the API server
+		err := watch.HandleIntegrationStateChanges(ctx, last, func(i *v1alpha1.Integration) bool {
+			last = i
+			return !o.done(i)
+		})
+		if err != nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return &ExitError{
+				Code: WaitExitCodeTimeout,
+				Err:  fmt.Errorf("timed out waiting for integration %s to be %s (phase %s)", last.Name, o.phase, last.Status.Phase),
+			}
+		}
+	}
+
+	if last.Status.Phase != o.phase {
+		return &ExitError{
+			Code: WaitExitCodeFailed,
+			Err:  fmt.Errorf("integration %s failed: %s", last.Name, failureReason(o.Context, c, last)),
+		}
+	}
+
+	fmt.Printf("integration \"%s\" is %s\n", last.Name, last.Status.Phase)
+	return nil
+}
+
+// done returns true when the integration is in the phase waited for, or in error
+func (o *waitCmdOptions) done(integration *v1alpha1.Integration) bool {
+	return integration.Status.Phase == o.phase || integration.Status.Phase == v1alpha1.IntegrationPhaseError
+}
+
+// parsePhase returns the integration phase with the given name, ignoring case and separators, e.g. building-kit
+func parsePhase(name string) (v1alpha1.IntegrationPhase, bool) {
+	normalize := strings.NewReplacer(" ", "", "-", "", "_", "")
+	for _, phase := range waitPhases {
+		if strings.EqualFold(normalize.Replace(string(phase)), normalize.Replace(name)) {
+			return phase, true
+		}
+	}
+	return "", false
+}
+
+// failureReason returns why the integration is in error, that is the error of the build of its kit when the kit
+// cannot be built
+func failureReason(ctx context.Context, c client.Client, integration *v1alpha1.Integration) string {
+	if integration.Status.Failure != nil && integration.Status.Failure.Reason != "" {
+		return integration.Status.Failure.Reason
+	}
+
+	if integration.Status.Kit != "" {
+		kit := v1alpha1.NewIntegrationKit(integration.Namespace, integration.Status.Kit)
+		key := k8sclient.ObjectKey{Namespace: integration.Namespace, Name: integration.Status.Kit}
+		if err := c.Get(ctx, key, &kit); err == nil && kit.Status.Phase == v1alpha1.IntegrationKitPhaseError {
+			if kit.Status.Failure != nil && kit.Status.Failure.Reason != "" {
+				return fmt.Sprintf("the build of kit %s failed: %s", kit.Name, kit.Status.Failure.Reason)
+			}
+			return fmt.Sprintf("the build of kit %s failed", kit.Name)
+		}
+	}
+
+	return "unknown reason, see kamel describe integration " + integration.Name
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitValidate(t *testing.T) {
+	options := waitCmdOptions{For: "Running"}
+	assert.Nil(t, options.validate([]string{"integration/hello"}))
+	assert.Equal(t, v1alpha1.IntegrationPhaseRunning, options.phase)
+	assert.Nil(t, options.validate([]string{"hello"}))
+
+	options.For = "building-kit"
+	assert.Nil(t, options.validate([]string{"hello"}))
+	assert.Equal(t, v1alpha1.IntegrationPhaseBuildingKit, options.phase)
+
+	options.For = "Started"
+	assert.NotNil(t, options.validate([]string{"hello"}))
+
+	options.For = "Running"
+	assert.NotNil(t, options.validate([]string{}))
+	assert.NotNil(t, options.validate([]string{"kit/hello"}))
+
+	options.Timeout = -1
+	assert.NotNil(t, options.validate([]string{"hello"}))
+}
+
+func TestWaitDone(t *testing.T) {
+	options := waitCmdOptions{phase: v1alpha1.IntegrationPhaseRunning}
+	integration := v1alpha1.NewIntegration("ns", "hello")
+
+	integration.Status.Phase = v1alpha1.IntegrationPhaseBuildingKit
+	assert.False(t, options.done(&integration))
+	integration.Status.Phase = v1alpha1.IntegrationPhaseRunning
+	assert.True(t, options.done(&integration))
+	integration.Status.Phase = v1alpha1.IntegrationPhaseError
+	assert.True(t, options.done(&integration))
+}
+
+func TestFailureReason(t *testing.T) {
+	kit := v1alpha1.NewIntegrationKit("ns", "kit-123")
+	kit.Status.Phase = v1alpha1.IntegrationKitPhaseError
+	kit.Status.Failure = &v1alpha1.Failure{Reason: "cannot resolve org.acme:missing:1.0"}
+
+	c, err := test.NewFakeClient(&kit)
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "hello")
+	integration.Status.Phase = v1alpha1.IntegrationPhaseError
+	integration.Status.Kit = "kit-123"
+	assert.Equal(t, "the build of kit kit-123 failed: cannot resolve org.acme:missing:1.0", failureReason(context.TODO(), c, &integration))
+
+	integration.Status.Failure = &v1alpha1.Failure{Reason: "crash loop"}
+	assert.Equal(t, "crash loop", failureReason(context.TODO(), c, &integration))
+
+	integration.Status.Failure = nil
+	integration.Status.Kit = "kit-456"
+	assert.Equal(t, "unknown reason, see kamel describe integration hello", failureReason(context.TODO(), c, &integration))
+}
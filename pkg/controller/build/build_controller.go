@@ -19,7 +19,6 @@ package build
 import (
 	"context"
 	"sync"
-	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -38,6 +37,7 @@ import (
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/builder"
 	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
 )
 
 // Add creates a new Build Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -198,7 +198,7 @@ func (r *ReconcileBuild) Reconcile(request reconcile.Request) (reconcile.Result,
 	if instance.Status.Phase == v1alpha1.BuildPhaseScheduling ||
 		instance.Status.Phase == v1alpha1.BuildPhaseFailed {
 		return reconcile.Result{
-			RequeueAfter: 5 * time.Second,
+			RequeueAfter: platform.GetOperatorConfiguration().BuildPollInterval,
 		}, nil
 	}
 
@@ -54,7 +54,7 @@ func (action *monitorPodAction) Handle(ctx context.Context, build *v1alpha1.Buil
 
 	// Get the build pod
 	pod := &corev1.Pod{}
-	err := action.client.Get(ctx, types.NamespacedName{Namespace: build.Namespace, Name: buildPodName(build.Spec.Meta)}, pod)
+	err := action.client.Get(ctx, types.NamespacedName{Namespace: build.Namespace, Name: buildPodName(build.ObjectMeta)}, pod)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			// Let's reschedule the build
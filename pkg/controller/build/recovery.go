@@ -63,12 +63,19 @@ func (action *errorRecoveryAction) Handle(ctx context.Context, build *v1alpha1.B
 	}
 
 	if build.Status.Failure == nil {
+		attemptMax := 5
+		switch build.Status.Reason {
+		case v1alpha1.BuildReasonCompilation, v1alpha1.BuildReasonArtifactPolicy, v1alpha1.BuildReasonVulnerabilities:
+			// compilation failures and policy violations are not transient so there is no point in retrying
+			attemptMax = 0
+		}
+
 		build.Status.Failure = &v1alpha1.Failure{
 			Reason: build.Status.Error,
 			Time:   metav1.Now(),
 			Recovery: v1alpha1.FailureRecovery{
 				Attempt:    0,
-				AttemptMax: 5,
+				AttemptMax: attemptMax,
 			},
 		}
 	}
@@ -22,9 +22,11 @@ import (
 	"sync"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
 	"github.com/apache/camel-k/pkg/install"
 	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/proxy"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -66,27 +68,28 @@ func (action *schedulePodAction) Handle(ctx context.Context, build *v1alpha1.Bui
 	action.lock.Lock()
 	defer action.lock.Unlock()
 
-	builds := &v1alpha1.BuildList{}
-	options := &k8sclient.ListOptions{Namespace: build.Namespace}
-	// We use the non-caching client as informers cache is not invalidated nor updated
-	// atomically by write operations
-	err := action.reader.List(ctx, options, builds)
+	queue, err := listBuildQueue(ctx, action.reader, build)
 	if err != nil {
 		return err
 	}
 
-	// Emulate a serialized working queue to only allow one build to run at a given time.
-	// This is currently necessary for the incremental build to work as expected.
-	hasScheduledBuild := false
-	for _, b := range builds.Items {
-		if b.Status.Phase == v1alpha1.BuildPhasePending || b.Status.Phase == v1alpha1.BuildPhaseRunning {
-			hasScheduledBuild = true
-			break
-		}
+	// Enforce the quota of the namespace the build has been requested from, if any
+	if quota := queue.quota(build); quota != nil {
+		action.L.Info("Build held back by the platform quota", "reason", quota.Reason)
+		return holdForQuota(ctx, build, quota, action.client)
+	}
+
+	// Emulate a working queue to only allow a limited number of builds to run at a given time.
+	// By default only one build is allowed, which is currently necessary for the incremental
+	// build to work as expected, unless the platform quota allows more.
+	if queue.isFull(build) {
+		// Let's requeue the build in case too many are already running
+		return nil
 	}
 
-	if hasScheduledBuild {
-		// Let's requeue the build in case one is already running
+	// Then let builds with a higher priority, from the namespaces with less running builds, or waiting
+	// for longer, be scheduled first
+	if !queue.isNext(build) {
 		return nil
 	}
 
@@ -123,6 +126,7 @@ func (action *schedulePodAction) Handle(ctx context.Context, build *v1alpha1.Bui
 
 	target := build.DeepCopy()
 	target.Status.Phase = v1alpha1.BuildPhasePending
+	target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionQuotaExceeded)
 	action.L.Info("Build state transition", "phase", target.Status.Phase)
 
 	return action.client.Status().Update(ctx, target)
@@ -155,7 +159,7 @@ func newBuildPod(build *v1alpha1.Build, operatorImage string) *corev1.Pod {
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: build.Namespace,
-			Name:      buildPodName(build.Spec.Meta),
+			Name:      buildPodName(build.ObjectMeta),
 			Labels: map[string]string{
 				"camel.apache.org/build": build.Name,
 			},
@@ -178,24 +182,29 @@ func newBuildPod(build *v1alpha1.Build, operatorImage string) *corev1.Pod {
 		},
 	}
 
-	if build.Spec.Platform.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko {
-		// Mount persistent volume used to coordinate build output with Kaniko cache and image build input
-		pod.Spec.Volumes = []corev1.Volume{
-			{
-				Name: "camel-k-builder",
-				VolumeSource: corev1.VolumeSource{
-					PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-						ClaimName: build.Spec.Platform.Build.PersistentVolumeClaim,
-					},
+	// Provide the build environment, e.g. proxy settings or custom truststores
+	volumes, mounts := builder.BuildSecretVolumes(build.Spec.Platform.Build)
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volumes...)
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, mounts...)
+	pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, proxy.EnvVars(build.Spec.Platform.Build.Proxy)...)
+	pod.Spec.Containers[0].Env = append(pod.Spec.Containers[0].Env, build.Spec.Platform.Build.Env...)
+
+	if build.Spec.Platform.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko ||
+		build.Spec.Platform.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyBuildah {
+		// Mount persistent volume used to coordinate build output with Kaniko cache and image build input,
+		// or with the Buildah image build input
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: "camel-k-builder",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: build.Spec.Platform.Build.PersistentVolumeClaim,
 				},
 			},
-		}
-		pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
-			{
-				Name:      "camel-k-builder",
-				MountPath: build.Spec.BuildDir,
-			},
-		}
+		})
+		pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      "camel-k-builder",
+			MountPath: build.Spec.BuildDir,
+		})
 
 		// Use affinity only when the operator is present in the namespaced
 		if build.Namespace == platform.GetOperatorNamespace() {
@@ -61,33 +61,35 @@ func (action *scheduleRoutineAction) Handle(ctx context.Context, build *v1alpha1
 	action.lock.Lock()
 	defer action.lock.Unlock()
 
-	builds := &v1alpha1.BuildList{}
-	options := &k8sclient.ListOptions{Namespace: build.Namespace}
-	// We use the non-caching client as informers cache is not invalidated nor updated
-	// atomically by write operations
-	err := action.reader.List(ctx, options, builds)
+	queue, err := listBuildQueue(ctx, action.reader, build)
 	if err != nil {
 		return err
 	}
 
-	// Emulate a serialized working queue to only allow one build to run at a given time.
-	// This is currently necessary for the incremental build to work as expected.
-	hasScheduledBuild := false
-	for _, b := range builds.Items {
-		if b.Status.Phase == v1alpha1.BuildPhasePending || b.Status.Phase == v1alpha1.BuildPhaseRunning {
-			hasScheduledBuild = true
-			break
-		}
+	// Enforce the quota of the namespace the build has been requested from, if any
+	if quota := queue.quota(build); quota != nil {
+		action.L.Info("Build held back by the platform quota", "reason", quota.Reason)
+		return holdForQuota(ctx, build, quota, action.client)
 	}
 
-	if hasScheduledBuild {
-		// Let's requeue the build in case one is already running
+	// Emulate a working queue to only allow a limited number of builds to run at a given time.
+	// By default only one build is allowed, which is currently necessary for the incremental
+	// build to work as expected, unless the platform quota allows more.
+	if queue.isFull(build) {
+		// Let's requeue the build in case too many are already running
+		return nil
+	}
+
+	// Then let builds with a higher priority, from the namespaces with less running builds, or waiting
+	// for longer, be scheduled first
+	if !queue.isNext(build) {
 		return nil
 	}
 
 	// Transition the build to running state
 	target := build.DeepCopy()
 	target.Status.Phase = v1alpha1.BuildPhaseRunning
+	target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionQuotaExceeded)
 	action.L.Info("Build state transition", "phase", target.Status.Phase)
 	err = action.client.Status().Update(ctx, target)
 	if err != nil {
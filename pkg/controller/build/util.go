@@ -25,7 +25,10 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
 	logger "github.com/apache/camel-k/pkg/util/log"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // UpdateBuildStatus --
@@ -51,3 +54,134 @@ func UpdateBuildStatus(ctx context.Context, build *v1alpha1.Build, status v1alph
 	build.Status = status
 	return nil
 }
+
+// holdForQuota records on the build the condition explaining why it cannot be scheduled yet
+func holdForQuota(ctx context.Context, build *v1alpha1.Build, quota *v1alpha1.Condition, c client.Client) error {
+	if cond := v1alpha1.GetCondition(build.Status.Conditions, quota.Type); cond != nil && cond.Message == quota.Message {
+		return nil
+	}
+
+	target := build.DeepCopy()
+	target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *quota)
+
+	return c.Status().Update(ctx, target)
+}
+
+// buildQueue is a snapshot of the builds competing for the same build slots, i.e. the builds of the namespace they
+// run in or, when the number of builds running overall is limited, the builds of all the namespaces
+type buildQueue struct {
+	builds []v1alpha1.Build
+	// total is the number of pending and running builds
+	total int
+	// running counts the pending and running builds per namespace they run in
+	running map[string]int
+	// requested counts the pending and running builds per namespace they have been requested from
+	requested map[string]int
+}
+
+func newBuildQueue(builds []v1alpha1.Build) buildQueue {
+	q := buildQueue{
+		builds:    builds,
+		running:   make(map[string]int),
+		requested: make(map[string]int),
+	}
+	for _, b := range builds {
+		if b.Status.Phase == v1alpha1.BuildPhasePending || b.Status.Phase == v1alpha1.BuildPhaseRunning {
+			q.total++
+			q.running[b.Namespace]++
+			q.requested[requestNamespace(&b)]++
+		}
+	}
+	return q
+}
+
+// requestNamespace returns the namespace the build has been requested from, i.e. the namespace of its kit, that
+// differs from the namespace the build runs in when the platform uses a dedicated build namespace
+func requestNamespace(build *v1alpha1.Build) string {
+	if build.Spec.Meta.Namespace != "" {
+		return build.Spec.Meta.Namespace
+	}
+	return build.Namespace
+}
+
+// isFull returns true if the maximum number of builds running at the same time, in the namespace the given build
+// runs in or overall, has been reached
+func (q buildQueue) isFull(build *v1alpha1.Build) bool {
+	if q.running[build.Namespace] >= maxRunningBuilds(build) {
+		return true
+	}
+	configuration := platform.GetOperatorConfiguration()
+	return configuration.MaxRunningBuildsTotal > 0 && q.total >= configuration.MaxRunningBuildsTotal
+}
+
+// maxRunningBuilds returns the maximum number of builds running at the same time in the namespace the given build
+// runs in. The quota of the platform overrides the operator default when the build runs in the namespace it has
+// been requested from, a dedicated build namespace being shared by the builds of many namespaces
+func maxRunningBuilds(build *v1alpha1.Build) int {
+	if quota := build.Spec.Platform.Quota.MaxRunningBuilds; quota > 0 && build.Namespace == requestNamespace(build) {
+		return quota
+	}
+	return platform.GetOperatorConfiguration().MaxRunningBuilds
+}
+
+// quota returns the condition holding back the given build because the namespace it has been requested from has
+// reached the maximum number of concurrent builds of its platform quota, or nil if the build is within the quota
+func (q buildQueue) quota(build *v1alpha1.Build) *v1alpha1.Condition {
+	return platform.CheckBuildQuota(build.Spec.Platform, q.requested[requestNamespace(build)])
+}
+
+// isNext returns true if no other build waiting to be scheduled precedes the given one. Builds are ordered by
+// priority first, then by number of builds already scheduled for the namespace they have been requested from, so
+// that the namespaces take turns and a burst of builds in a namespace does not starve the others, then by creation
+// time. The builds held back by the limits of their namespace do not hold back the others
+func (q buildQueue) isNext(build *v1alpha1.Build) bool {
+	for i := range q.builds {
+		b := &q.builds[i]
+		if b.Name == build.Name && b.Namespace == build.Namespace || b.Status.Phase != v1alpha1.BuildPhaseScheduling {
+			continue
+		}
+		if q.running[b.Namespace] >= maxRunningBuilds(b) || q.quota(b) != nil {
+			continue
+		}
+
+		rank, otherRank := build.Spec.Priority.Rank(), b.Spec.Priority.Rank()
+		if otherRank != rank {
+			if otherRank > rank {
+				return false
+			}
+			continue
+		}
+
+		scheduled, otherScheduled := q.requested[requestNamespace(build)], q.requested[requestNamespace(b)]
+		if otherScheduled != scheduled {
+			if otherScheduled < scheduled {
+				return false
+			}
+			continue
+		}
+
+		if b.CreationTimestamp.Before(&build.CreationTimestamp) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// listBuildQueue lists the builds competing with the given one for being scheduled, all the builds of the cluster
+// being listed when the number of builds running overall is limited
+func listBuildQueue(ctx context.Context, reader k8sclient.Reader, build *v1alpha1.Build) (buildQueue, error) {
+	options := &k8sclient.ListOptions{Namespace: build.Namespace}
+	if platform.GetOperatorConfiguration().MaxRunningBuildsTotal > 0 && platform.IsCurrentOperatorGlobal() {
+		options.Namespace = ""
+	}
+
+	builds := &v1alpha1.BuildList{}
+	// We use the non-caching client as informers cache is not invalidated nor updated
+	// atomically by write operations
+	if err := reader.List(ctx, options, builds); err != nil {
+		return buildQueue{}, err
+	}
+
+	return newBuildQueue(builds.Items), nil
+}
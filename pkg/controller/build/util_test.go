@@ -0,0 +1,169 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package build
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/platform"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newQueuedBuild(name string, priority v1alpha1.BuildPriority, age time.Duration) v1alpha1.Build {
+	return v1alpha1.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+		},
+		Spec: v1alpha1.BuildSpec{
+			Priority: priority,
+		},
+		Status: v1alpha1.BuildStatus{
+			Phase: v1alpha1.BuildPhaseScheduling,
+		},
+	}
+}
+
+func TestBuildQueueIsNext(t *testing.T) {
+	batch := newQueuedBuild("batch", v1alpha1.BuildPriorityBatch, time.Hour)
+	older := newQueuedBuild("older", "", 2*time.Minute)
+	newer := newQueuedBuild("newer", v1alpha1.BuildPriorityNormal, time.Minute)
+	dev := newQueuedBuild("dev", v1alpha1.BuildPriorityInteractive, 0)
+
+	builds := []v1alpha1.Build{batch, older, newer, dev}
+
+	assert.True(t, newBuildQueue(builds).isNext(&dev))
+	assert.False(t, newBuildQueue(builds).isNext(&older))
+	assert.False(t, newBuildQueue(builds).isNext(&batch))
+
+	// builds of the same priority are scheduled in order of creation
+	builds = []v1alpha1.Build{batch, older, newer}
+	assert.True(t, newBuildQueue(builds).isNext(&older))
+	assert.False(t, newBuildQueue(builds).isNext(&newer))
+
+	// running builds are not part of the queue
+	dev.Status.Phase = v1alpha1.BuildPhaseRunning
+	builds = []v1alpha1.Build{batch, dev}
+	assert.True(t, newBuildQueue(builds).isNext(&batch))
+}
+
+func newRequestedBuild(name string, namespace string, phase v1alpha1.BuildPhase, age time.Duration) v1alpha1.Build {
+	b := newQueuedBuild(name, "", age)
+	b.Namespace = "builds"
+	b.Spec.Meta.Namespace = namespace
+	b.Status.Phase = phase
+	return b
+}
+
+func TestBuildQueueFairness(t *testing.T) {
+	defer platform.SetOperatorConfiguration(platform.GetOperatorConfiguration())
+	configuration := platform.DefaultOperatorConfiguration()
+	configuration.MaxRunningBuilds = 3
+	platform.SetOperatorConfiguration(configuration)
+
+	// a burst of builds requested from namespace a, one of them already running
+	a1 := newRequestedBuild("a1", "a", v1alpha1.BuildPhaseRunning, time.Hour)
+	a2 := newRequestedBuild("a2", "a", v1alpha1.BuildPhaseScheduling, time.Hour)
+	a3 := newRequestedBuild("a3", "a", v1alpha1.BuildPhaseScheduling, time.Hour)
+	b1 := newRequestedBuild("b1", "b", v1alpha1.BuildPhaseScheduling, time.Minute)
+
+	queue := newBuildQueue([]v1alpha1.Build{a1, a2, a3, b1})
+	assert.False(t, queue.isFull(&b1))
+	assert.True(t, queue.isNext(&b1))
+	assert.False(t, queue.isNext(&a2))
+	assert.False(t, queue.isNext(&a3))
+
+	// then the namespaces take turns, the oldest build of a being scheduled first
+	b1.Status.Phase = v1alpha1.BuildPhaseRunning
+	queue = newBuildQueue([]v1alpha1.Build{a1, a2, a3, b1})
+	assert.False(t, queue.isFull(&a2))
+	assert.True(t, queue.isNext(&a2) || queue.isNext(&a3))
+
+	// until the namespace the builds run in is full
+	a2.Status.Phase = v1alpha1.BuildPhaseRunning
+	queue = newBuildQueue([]v1alpha1.Build{a1, a2, a3, b1})
+	assert.True(t, queue.isFull(&a3))
+}
+
+func TestBuildQueueQuota(t *testing.T) {
+	defer platform.SetOperatorConfiguration(platform.GetOperatorConfiguration())
+	configuration := platform.DefaultOperatorConfiguration()
+	configuration.MaxRunningBuilds = 3
+	platform.SetOperatorConfiguration(configuration)
+
+	a1 := newRequestedBuild("a1", "a", v1alpha1.BuildPhaseRunning, time.Hour)
+	a2 := newRequestedBuild("a2", "a", v1alpha1.BuildPhaseScheduling, time.Hour)
+	a2.Spec.Platform.Quota.MaxRunningBuilds = 1
+	b1 := newRequestedBuild("b1", "b", v1alpha1.BuildPhaseScheduling, time.Minute)
+	b2 := newRequestedBuild("b2", "b", v1alpha1.BuildPhaseRunning, time.Minute)
+
+	// the quota of a namespace counts the builds requested from it only
+	queue := newBuildQueue([]v1alpha1.Build{a1, a2, b1, b2})
+	assert.NotNil(t, queue.quota(&a2))
+	assert.Nil(t, queue.quota(&b1))
+
+	// and the builds held back by their quota do not hold back the others
+	assert.True(t, queue.isNext(&b1))
+}
+
+func TestBuildQueueTotal(t *testing.T) {
+	defer platform.SetOperatorConfiguration(platform.GetOperatorConfiguration())
+	configuration := platform.DefaultOperatorConfiguration()
+	configuration.MaxRunningBuildsTotal = 2
+	platform.SetOperatorConfiguration(configuration)
+
+	a1 := newQueuedBuild("a1", "", time.Hour)
+	a1.Namespace = "a"
+	a1.Status.Phase = v1alpha1.BuildPhaseRunning
+	b1 := newQueuedBuild("b1", "", time.Hour)
+	b1.Namespace = "b"
+	b1.Status.Phase = v1alpha1.BuildPhaseRunning
+	c1 := newQueuedBuild("c1", "", time.Hour)
+	c1.Namespace = "c"
+
+	assert.True(t, newBuildQueue([]v1alpha1.Build{a1, b1, c1}).isFull(&c1))
+	assert.False(t, newBuildQueue([]v1alpha1.Build{a1, c1}).isFull(&c1))
+}
+
+func TestBuildQueueQuotaOverridesOperatorDefault(t *testing.T) {
+	// the operator runs a single build at a time in a namespace by default
+	a1 := newQueuedBuild("a1", "", time.Hour)
+	a1.Namespace = "a"
+	a1.Status.Phase = v1alpha1.BuildPhaseRunning
+	a2 := newQueuedBuild("a2", "", time.Minute)
+	a2.Namespace = "a"
+
+	assert.True(t, newBuildQueue([]v1alpha1.Build{a1, a2}).isFull(&a2))
+
+	// unless the platform quota of the namespace allows more
+	a2.Spec.Platform.Quota.MaxRunningBuilds = 2
+	queue := newBuildQueue([]v1alpha1.Build{a1, a2})
+	assert.Nil(t, queue.quota(&a2))
+	assert.False(t, queue.isFull(&a2))
+
+	// but not for the builds running in a build namespace shared with other namespaces
+	b1 := newRequestedBuild("b1", "b", v1alpha1.BuildPhaseRunning, time.Hour)
+	b2 := newRequestedBuild("b2", "b", v1alpha1.BuildPhaseScheduling, time.Minute)
+	b2.Spec.Platform.Quota.MaxRunningBuilds = 2
+	assert.True(t, newBuildQueue([]v1alpha1.Build{b1, b2}).isFull(&b2))
+}
@@ -20,12 +20,22 @@ package integration
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/trait"
 	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/audit"
 	"github.com/apache/camel-k/pkg/util/digest"
+	"github.com/pkg/errors"
 	"github.com/rs/xid"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NewBuildKitAction create an action that handles integration kit build
@@ -49,12 +59,28 @@ func (action *buildKitAction) CanHandle(integration *v1alpha1.Integration) bool
 func (action *buildKitAction) Handle(ctx context.Context, integration *v1alpha1.Integration) error {
 	kit, err := LookupKitForIntegration(ctx, action.client, integration)
 	if err != nil {
+		if integration.IsKitPinned() && k8serrors.IsNotFound(errors.Cause(err)) {
+			return action.reportIncompatibleKit(ctx, integration, "KitNotFound",
+				fmt.Sprintf("integration kit %s not found", integration.Spec.Kit))
+		}
 		//TODO: we may need to add a wait strategy, i.e give up after some time
 		return err
 	}
 
 	if kit != nil {
-		if kit.Labels["camel.apache.org/kit.type"] == v1alpha1.IntegrationKitTypePlatform {
+		if integration.IsKitPinned() {
+			// The kit the integration is pinned to is never replaced, what it lacks is reported instead
+			if missing := missingDependencies(kit, integration); len(missing) > 0 {
+				return action.reportIncompatibleKit(ctx, integration, "MissingDependencies",
+					fmt.Sprintf("integration kit %s lacks the dependencies %s", kit.Name, strings.Join(missing, ", ")))
+			}
+			if v1alpha1.GetCondition(integration.Status.Conditions, v1alpha1.ConditionKitIncompatible) != nil {
+				target := integration.DeepCopy()
+				target.Status.Kit = kit.Name
+				target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionKitIncompatible)
+				return action.client.Status().Update(ctx, target)
+			}
+		} else if kit.Labels["camel.apache.org/kit.type"] == v1alpha1.IntegrationKitTypePlatform {
 			// This is a platform kit and as it is auto generated it may get
 			// out of sync if the integration that has generated it, has been
 			// amended to add/remove dependencies
@@ -98,6 +124,10 @@ func (action *buildKitAction) Handle(ctx context.Context, integration *v1alpha1.
 
 			target.Status.Digest = dgst
 
+			if err := action.ownKit(ctx, integration, kit); err != nil {
+				return err
+			}
+
 			if _, err := trait.Apply(ctx, action.client, target, kit); err != nil {
 				return err
 			}
@@ -117,8 +147,197 @@ func (action *buildKitAction) Handle(ctx context.Context, integration *v1alpha1.
 		return nil
 	}
 
-	platformCtxName := fmt.Sprintf("kit-%s", xid.New())
-	platformCtx := v1alpha1.NewIntegrationKit(integration.Namespace, platformCtxName)
+	// Platform kits are named after the digest of their characteristics, so that the integrations racing
+	// for the same new kit elect a single one: the first to create it wins and the others adopt it
+	kitDigest, err := digest.ComputeForPlatformKit(integration)
+	if err != nil {
+		return err
+	}
+	platformCtxName := "kit-" + kitDigest
+
+	if elected, err := action.lookupElectedKit(ctx, integration.Namespace, platformCtxName); err != nil {
+		return err
+	} else if elected != nil {
+		if canAdoptKit(elected, integration) {
+			return action.adoptKit(ctx, integration, elected)
+		}
+		// The elected kit cannot be reused, e.g. its build failed or it has been invalidated
+		platformCtxName = fmt.Sprintf("kit-%s", xid.New())
+	} else if shared, err := action.useSharedKit(ctx, integration, platformCtxName); err != nil || shared {
+		return err
+	}
+
+	platformCtx, err := newPlatformKit(ctx, action.client, integration, integration.Namespace, platformCtxName)
+	if err != nil {
+		return err
+	}
+	if ref, err := action.kitOwnerReference(ctx, integration, platformCtx); err != nil {
+		return err
+	} else if ref != nil {
+		platformCtx.OwnerReferences = append(platformCtx.OwnerReferences, *ref)
+	}
+
+	if err := action.client.Create(ctx, platformCtx); err != nil {
+		if !k8serrors.IsAlreadyExists(err) {
+			return err
+		}
+
+		// Another integration has won the election in the meantime
+		elected, err := action.lookupElectedKit(ctx, integration.Namespace, platformCtxName)
+		if err != nil {
+			return err
+		}
+		if elected == nil || !canAdoptKit(elected, integration) {
+			return errors.Errorf("cannot adopt integration kit %s", platformCtxName)
+		}
+		return action.adoptKit(ctx, integration, elected)
+	}
+
+	audit.Record("IntegrationKit created", v1alpha1.IntegrationKindKind, platformCtx.ObjectMeta)
+
+	// Set the kit name so the next handle loop, will fall through the
+	// same path as integration with a user defined kit
+	target := integration.DeepCopy()
+	target.Status.Kit = platformCtxName
+
+	return action.client.Status().Update(ctx, target)
+}
+
+// reportIncompatibleKit reports that the kit the integration is pinned to cannot be used, the integration
+// being requeued until the kit gets fixed or the integration gets unpinned
+func (action *buildKitAction) reportIncompatibleKit(ctx context.Context, integration *v1alpha1.Integration, reason string, message string) error {
+	condition := v1alpha1.Condition{
+		Type:    v1alpha1.ConditionKitIncompatible,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+	if c := v1alpha1.GetCondition(integration.Status.Conditions, condition.Type); c != nil && c.Message == condition.Message {
+		return nil
+	}
+
+	action.L.Info("Pinned integration kit cannot be used", "kit", integration.Spec.Kit, "reason", reason)
+
+	target := integration.DeepCopy()
+	target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, condition)
+
+	return action.client.Status().Update(ctx, target)
+}
+
+// missingDependencies returns the dependencies of the integration that are not provided by the kit
+func missingDependencies(kit *v1alpha1.IntegrationKit, integration *v1alpha1.Integration) []string {
+	missing := make([]string, 0)
+	for _, dependency := range integration.Status.Dependencies {
+		if !util.StringSliceExists(kit.Spec.Dependencies, dependency) {
+			missing = append(missing, dependency)
+		}
+	}
+	return missing
+}
+
+// lookupElectedKit returns the kit with the given name, if any
+func (action *buildKitAction) lookupElectedKit(ctx context.Context, namespace string, name string) (*v1alpha1.IntegrationKit, error) {
+	kit := v1alpha1.NewIntegrationKit(namespace, name)
+	key := k8sclient.ObjectKey{
+		Namespace: namespace,
+		Name:      name,
+	}
+	if err := action.client.Get(ctx, key, &kit); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &kit, nil
+}
+
+// adoptKit associates the integration with a kit created on behalf of another integration
+func (action *buildKitAction) adoptKit(ctx context.Context, integration *v1alpha1.Integration, kit *v1alpha1.IntegrationKit) error {
+	action.L.Info("Adopting integration kit", "kit", kit.Name)
+
+	if err := action.ownKit(ctx, integration, kit); err != nil {
+		return err
+	}
+
+	target := integration.DeepCopy()
+	target.Status.Kit = kit.Name
+	target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionWaitingForSharedKit)
+
+	return action.client.Status().Update(ctx, target)
+}
+
+// ownKit makes the integration an owner of the platform kit it uses when the platform kits are garbage collected
+// along with the integrations using them, the kit being deleted with the last of its owners
+func (action *buildKitAction) ownKit(ctx context.Context, integration *v1alpha1.Integration, kit *v1alpha1.IntegrationKit) error {
+	ref, err := action.kitOwnerReference(ctx, integration, kit)
+	if err != nil || ref == nil {
+		return err
+	}
+
+	target := kit.DeepCopy()
+	target.OwnerReferences = append(target.OwnerReferences, *ref)
+
+	return action.client.Update(ctx, target)
+}
+
+// kitOwnerReference returns the reference to the integration to be added to the owners of the given kit, if any
+func (action *buildKitAction) kitOwnerReference(ctx context.Context, integration *v1alpha1.Integration, kit *v1alpha1.IntegrationKit) (*metav1.OwnerReference, error) {
+	// platform kits copied from a shared namespace are owned as well, the shared kit being left to the
+	// policy of the shared namespace
+	if kit.Labels["camel.apache.org/kit.type"] != v1alpha1.IntegrationKitTypePlatform || kit.Namespace != integration.Namespace {
+		return nil, nil
+	}
+
+	pl, err := platform.GetCurrentPlatform(ctx, action.client, integration.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if pl.Spec.KitGCPolicy() != v1alpha1.IntegrationPlatformKitGCPolicyOwned {
+		return nil, nil
+	}
+
+	for _, ref := range kit.OwnerReferences {
+		if ref.Kind == v1alpha1.IntegrationKind && ref.Name == integration.Name && ref.UID == integration.UID {
+			return nil, nil
+		}
+	}
+
+	return &metav1.OwnerReference{
+		APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		Kind:       v1alpha1.IntegrationKind,
+		Name:       integration.Name,
+		UID:        integration.UID,
+	}, nil
+}
+
+// canAdoptKit returns true if the platform kit elected by its name satisfies the integration, the kit
+// not being necessarily initialized yet
+func canAdoptKit(kit *v1alpha1.IntegrationKit, integration *v1alpha1.Integration) bool {
+	if kit.Labels["camel.apache.org/kit.type"] != v1alpha1.IntegrationKitTypePlatform {
+		return false
+	}
+	if kit.Status.Phase == v1alpha1.IntegrationKitPhaseError || kit.IsInvalidated() {
+		return false
+	}
+	if kit.Status.CamelVersion != "" && kit.Status.CamelVersion != integration.Status.CamelVersion {
+		return false
+	}
+	if kit.Status.RuntimeVersion != "" && kit.Status.RuntimeVersion != integration.Status.RuntimeVersion {
+		return false
+	}
+	if len(kit.Spec.Dependencies) != len(integration.Status.Dependencies) ||
+		!util.StringSliceContains(kit.Spec.Dependencies, integration.Status.Dependencies) {
+		return false
+	}
+
+	return HasMatchingTraits(kit, integration)
+}
+
+// newPlatformKit creates a platform kit with the characteristics of the integration, recording who and what
+// caused it to be created
+func newPlatformKit(ctx context.Context, c k8sclient.Reader, integration *v1alpha1.Integration, namespace string, name string) (*v1alpha1.IntegrationKit, error) {
+	platformCtx := v1alpha1.NewIntegrationKit(namespace, name)
 
 	// Add some information for post-processing, this may need to be refactored
 	// to a proper data structure
@@ -128,6 +347,9 @@ func (action *buildKitAction) Handle(ctx context.Context, integration *v1alpha1.
 		"camel.apache.org/kit.created.by.name":    integration.Name,
 		"camel.apache.org/kit.created.by.version": integration.ResourceVersion,
 	}
+	if len(validation.IsValidLabelValue(integration.Name)) == 0 {
+		platformCtx.Labels[v1alpha1.IntegrationKitAliasLabel] = integration.Name
+	}
 
 	// Set the kit to have the same characteristics as the integrations
 	platformCtx.Spec = v1alpha1.IntegrationKitSpec{
@@ -136,14 +358,33 @@ func (action *buildKitAction) Handle(ctx context.Context, integration *v1alpha1.
 		Traits:       integration.Spec.Traits,
 	}
 
-	if err := action.client.Create(ctx, &platformCtx); err != nil {
-		return err
+	// Record who and what caused the kit to be created by comparing the integration with
+	// the one the previous kit has been created for
+	previousDigest := ""
+	var previousDependencies []string
+	if previous, err := LookupLastKitCreatedForIntegration(ctx, c, integration); err != nil {
+		return nil, err
+	} else if previous != nil {
+		previousDigest = previous.Annotations[audit.AnnotationDigest]
+		previousDependencies = previous.Spec.Dependencies
 	}
 
-	// Set the kit name so the next handle loop, will fall through the
-	// same path as integration with a user defined kit
-	target := integration.DeepCopy()
-	target.Status.Kit = platformCtxName
+	trigger := audit.NewTrigger(v1alpha1.IntegrationKind, integration.Name, previousDigest, integration.Status.Digest,
+		previousDependencies, integration.Status.Dependencies)
+	audit.Annotate(&platformCtx.ObjectMeta, integration.Annotations[audit.AnnotationRequestedBy], trigger)
 
-	return action.client.Status().Update(ctx, target)
+	// Rebuilds of unchanged integrations are not awaited by anybody, so they should not delay
+	// the builds of the integrations being developed
+	priority := v1alpha1.BuildPriority(integration.Annotations[v1alpha1.BuildPriorityAnnotation])
+	if previousDigest != "" && previousDigest == integration.Status.Digest {
+		priority = v1alpha1.BuildPriorityBatch
+	}
+	if priority != "" {
+		platformCtx.Annotations[v1alpha1.BuildPriorityAnnotation] = string(priority)
+	}
+	if incremental, ok := integration.Annotations[v1alpha1.BuildIncrementalAnnotation]; ok {
+		platformCtx.Annotations[v1alpha1.BuildIncrementalAnnotation] = incremental
+	}
+
+	return &platformCtx, nil
 }
@@ -0,0 +1,259 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/digest"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createBuildKitTestIntegration(name string, dependencies ...string) *v1alpha1.Integration {
+	integration := v1alpha1.NewIntegration("ns", name)
+	integration.Status.Phase = v1alpha1.IntegrationPhaseBuildingKit
+	integration.Status.CamelVersion = "2.23.2"
+	integration.Status.RuntimeVersion = "0.3.3"
+	integration.Status.Dependencies = dependencies
+	return &integration
+}
+
+func createBuildKitTestEnv(t *testing.T, platform *v1alpha1.IntegrationPlatform, objects ...runtime.Object) (client.Client, *buildKitAction) {
+	c, err := test.NewFakeClient(append([]runtime.Object{platform}, objects...)...)
+	assert.Nil(t, err)
+
+	action := buildKitAction{}
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	return c, &action
+}
+
+func TestBuildKitElection(t *testing.T) {
+	integrations := []*v1alpha1.Integration{
+		createBuildKitTestIntegration("it-1", "camel:core", "camel:kafka"),
+		createBuildKitTestIntegration("it-2", "camel:kafka", "camel:core"),
+		createBuildKitTestIntegration("it-3", "camel:core", "camel:kafka"),
+	}
+
+	c, action := createBuildKitTestEnv(t, createTestPlatform(), integrations[0], integrations[1], integrations[2])
+
+	for _, integration := range integrations {
+		assert.Nil(t, action.Handle(context.TODO(), integration))
+	}
+
+	kits := v1alpha1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "ns"}, &kits))
+	assert.Len(t, kits.Items, 1)
+
+	kitDigest, err := digest.ComputeForPlatformKit(integrations[0])
+	assert.Nil(t, err)
+	assert.Equal(t, "kit-"+kitDigest, kits.Items[0].Name)
+	assert.Equal(t, "it-1", kits.Items[0].Labels["camel.apache.org/kit.created.by.name"])
+	assert.Equal(t, "it-1", kits.Items[0].Labels[v1alpha1.IntegrationKitAliasLabel])
+	assert.Empty(t, kits.Items[0].OwnerReferences)
+
+	for _, integration := range integrations {
+		target := v1alpha1.NewIntegration("ns", integration.Name)
+		assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: integration.Name}, &target))
+		assert.Equal(t, kits.Items[0].Name, target.Status.Kit)
+	}
+}
+
+func TestBuildKitOwnedByIntegrations(t *testing.T) {
+	integrations := []*v1alpha1.Integration{
+		createBuildKitTestIntegration("it-1", "camel:core"),
+		createBuildKitTestIntegration("it-2", "camel:core"),
+	}
+	for _, integration := range integrations {
+		integration.UID = types.UID(integration.Name + "-uid")
+	}
+
+	pl := createTestPlatform()
+	pl.Spec.KitGC = &v1alpha1.IntegrationPlatformKitGCSpec{
+		Policy: v1alpha1.IntegrationPlatformKitGCPolicyOwned,
+	}
+
+	c, action := createBuildKitTestEnv(t, pl, integrations[0], integrations[1])
+
+	for _, integration := range integrations {
+		assert.Nil(t, action.Handle(context.TODO(), integration))
+	}
+	// owning the kit twice is a no-op
+	assert.Nil(t, action.Handle(context.TODO(), integrations[1]))
+
+	kits := v1alpha1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "ns"}, &kits))
+	assert.Len(t, kits.Items, 1)
+
+	owners := kits.Items[0].OwnerReferences
+	assert.Len(t, owners, 2)
+	for i, owner := range owners {
+		assert.Equal(t, v1alpha1.IntegrationKind, owner.Kind)
+		assert.Equal(t, integrations[i].Name, owner.Name)
+		assert.Equal(t, integrations[i].UID, owner.UID)
+		assert.Nil(t, owner.Controller)
+	}
+}
+
+func TestBuildKitElectionWithFailedKit(t *testing.T) {
+	integration := createBuildKitTestIntegration("it-1", "camel:core")
+
+	kitDigest, err := digest.ComputeForPlatformKit(integration)
+	assert.Nil(t, err)
+
+	failed := v1alpha1.NewIntegrationKit("ns", "kit-"+kitDigest)
+	failed.Labels = map[string]string{
+		"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypePlatform,
+	}
+	failed.Spec.Dependencies = []string{"camel:core"}
+	failed.Status.Phase = v1alpha1.IntegrationKitPhaseError
+
+	c, action := createBuildKitTestEnv(t, createTestPlatform(), integration, &failed)
+
+	assert.Nil(t, action.Handle(context.TODO(), integration))
+
+	target := v1alpha1.NewIntegration("ns", "it-1")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "it-1"}, &target))
+	assert.NotEmpty(t, target.Status.Kit)
+	assert.NotEqual(t, failed.Name, target.Status.Kit)
+
+	kits := v1alpha1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "ns"}, &kits))
+	assert.Len(t, kits.Items, 2)
+}
+
+func TestCanAdoptKit(t *testing.T) {
+	integration := createBuildKitTestIntegration("it-1", "camel:core", "camel:kafka")
+
+	kit := v1alpha1.NewIntegrationKit("ns", "kit-1")
+	kit.Labels = map[string]string{
+		"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypePlatform,
+	}
+	kit.Spec.Dependencies = []string{"camel:kafka", "camel:core"}
+	assert.True(t, canAdoptKit(&kit, integration))
+
+	kit.Status.CamelVersion = "2.23.2"
+	assert.True(t, canAdoptKit(&kit, integration))
+
+	kit.Status.CamelVersion = "2.24.0"
+	assert.False(t, canAdoptKit(&kit, integration))
+
+	kit.Status.CamelVersion = ""
+	kit.Spec.Dependencies = []string{"camel:core"}
+	assert.False(t, canAdoptKit(&kit, integration))
+
+	kit.Spec.Dependencies = []string{"camel:kafka", "camel:core"}
+	kit.Labels["camel.apache.org/kit.type"] = v1alpha1.IntegrationKitTypeUser
+	assert.False(t, canAdoptKit(&kit, integration))
+}
+
+func TestBuildKitPinnedMissingDependencies(t *testing.T) {
+	integration := createBuildKitTestIntegration("it-1", "camel:core", "camel:kafka")
+	integration.Spec.Kit = "my-kit"
+	integration.Spec.KitProtected = true
+	integration.Status.Kit = "my-kit"
+
+	kit := v1alpha1.NewIntegrationKit("ns", "my-kit")
+	kit.Labels = map[string]string{
+		"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypePlatform,
+	}
+	kit.Spec.Dependencies = []string{"camel:core"}
+	kit.Status.Phase = v1alpha1.IntegrationKitPhaseReady
+
+	c, action := createBuildKitTestEnv(t, createTestPlatform(), integration, &kit)
+
+	assert.Nil(t, action.Handle(context.TODO(), integration))
+
+	target := v1alpha1.NewIntegration("ns", "it-1")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "it-1"}, &target))
+	assert.Equal(t, "my-kit", target.Status.Kit)
+	assert.Equal(t, v1alpha1.IntegrationPhaseBuildingKit, target.Status.Phase)
+
+	condition := v1alpha1.GetCondition(target.Status.Conditions, v1alpha1.ConditionKitIncompatible)
+	assert.NotNil(t, condition)
+	assert.Equal(t, "MissingDependencies", condition.Reason)
+	assert.Contains(t, condition.Message, "camel:kafka")
+	assert.NotContains(t, condition.Message, "camel:core")
+
+	kits := v1alpha1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "ns"}, &kits))
+	assert.Len(t, kits.Items, 1)
+
+	// the condition is cleared once the kit provides the dependencies
+	kit.Spec.Dependencies = []string{"camel:core", "camel:kafka"}
+	assert.Nil(t, c.Update(context.TODO(), &kit))
+	assert.Nil(t, action.Handle(context.TODO(), &target))
+
+	fixed := v1alpha1.NewIntegration("ns", "it-1")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "it-1"}, &fixed))
+	assert.Equal(t, "my-kit", fixed.Status.Kit)
+	assert.Nil(t, v1alpha1.GetCondition(fixed.Status.Conditions, v1alpha1.ConditionKitIncompatible))
+}
+
+func TestBuildKitPinnedNotFound(t *testing.T) {
+	integration := createBuildKitTestIntegration("it-1", "camel:core")
+	integration.Spec.Kit = "my-kit"
+	integration.Spec.KitProtected = true
+
+	c, action := createBuildKitTestEnv(t, createTestPlatform(), integration)
+
+	assert.Nil(t, action.Handle(context.TODO(), integration))
+
+	target := v1alpha1.NewIntegration("ns", "it-1")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "it-1"}, &target))
+	assert.Empty(t, target.Status.Kit)
+
+	condition := v1alpha1.GetCondition(target.Status.Conditions, v1alpha1.ConditionKitIncompatible)
+	assert.NotNil(t, condition)
+	assert.Equal(t, "KitNotFound", condition.Reason)
+
+	kits := v1alpha1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "ns"}, &kits))
+	assert.Empty(t, kits.Items)
+}
+
+func TestBuildKitUnpinnedRebuild(t *testing.T) {
+	integration := createBuildKitTestIntegration("it-1", "camel:core", "camel:kafka")
+	integration.Spec.Kit = "my-kit"
+	integration.Status.Kit = "my-kit"
+
+	kit := v1alpha1.NewIntegrationKit("ns", "my-kit")
+	kit.Labels = map[string]string{
+		"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypePlatform,
+	}
+	kit.Spec.Dependencies = []string{"camel:core"}
+
+	c, action := createBuildKitTestEnv(t, createTestPlatform(), integration, &kit)
+
+	assert.Nil(t, action.Handle(context.TODO(), integration))
+
+	target := v1alpha1.NewIntegration("ns", "it-1")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "it-1"}, &target))
+	assert.Empty(t, target.Status.Kit)
+	assert.Nil(t, v1alpha1.GetCondition(target.Status.Conditions, v1alpha1.ConditionKitIncompatible))
+}
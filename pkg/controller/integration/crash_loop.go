@@ -0,0 +1,155 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// exceptionPattern matches the Java exception lines of a stack trace, e.g.
+// `Caused by: java.net.UnknownHostException: broker: Name or service not known`
+var exceptionPattern = regexp.MustCompile(`^(?:Caused by: )?((?:[\w$]+\.)+[\w$]*(?:Exception|Error))(?::\s*(.*))?$`)
+
+// crashLoop describes an integration container restarted beyond the restart policy
+type crashLoop struct {
+	pod         string
+	restarts    int32
+	reason      string
+	scaleToZero bool
+}
+
+// checkCrashLoop returns the crash looping pod of the integration, when the deployment
+// enforces a restart policy and the integration container exceeded the maximum restarts
+func checkCrashLoop(ctx context.Context, c client.Client, integration *v1alpha1.Integration) (*crashLoop, error) {
+	deployment := appsv1.Deployment{}
+	key := k8sclient.ObjectKey{
+		Namespace: integration.Namespace,
+		Name:      integration.Name,
+	}
+	if err := c.Get(ctx, key, &deployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	value, ok := deployment.Annotations[v1alpha1.IntegrationMaxRestartsAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	maxRestarts, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, err
+	}
+	scaleToZero := deployment.Annotations[v1alpha1.IntegrationScaleToZeroAnnotation] == "true"
+
+	pods := corev1.PodList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+	}
+	options := k8sclient.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			"camel.apache.org/integration": integration.Name,
+		}),
+		Namespace: integration.Namespace,
+	}
+	if err := c.List(ctx, &options, &pods); err != nil {
+		return nil, err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Labels["camel.apache.org/integration"] != integration.Name {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name != integration.Name || status.RestartCount <= int32(maxRestarts) {
+				continue
+			}
+			if status.State.Waiting == nil || status.State.Waiting.Reason != "CrashLoopBackOff" {
+				continue
+			}
+
+			return &crashLoop{
+				pod:         pod.Name,
+				restarts:    status.RestartCount,
+				reason:      terminationReason(status.LastTerminationState.Terminated),
+				scaleToZero: scaleToZero,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// terminationReason returns the exception the container failed with, or the
+// termination reason if it cannot be found in the termination message
+func terminationReason(terminated *corev1.ContainerStateTerminated) string {
+	if terminated == nil {
+		return "container crash looping"
+	}
+	if exception := extractException(terminated.Message); exception != "" {
+		return exception
+	}
+	return fmt.Sprintf("%s (exit code %d)", terminated.Reason, terminated.ExitCode)
+}
+
+// extractException returns the root cause of the last stack trace found in the logs,
+// that is the last `Caused by` or, if none, the exception heading the stack trace
+func extractException(logs string) string {
+	exception := ""
+	for _, line := range strings.Split(logs, "\n") {
+		line = strings.TrimSpace(line)
+		if exceptionPattern.MatchString(line) {
+			exception = strings.TrimPrefix(line, "Caused by: ")
+		}
+	}
+	return exception
+}
+
+// scaleToZero scales the deployment of the integration to zero replicas
+func scaleToZero(ctx context.Context, c client.Client, integration *v1alpha1.Integration) error {
+	deployment := appsv1.Deployment{}
+	key := k8sclient.ObjectKey{
+		Namespace: integration.Namespace,
+		Name:      integration.Name,
+	}
+	if err := c.Get(ctx, key, &deployment); err != nil {
+		return err
+	}
+
+	replicas := int32(0)
+	deployment.Spec.Replicas = &replicas
+
+	return c.Update(ctx, &deployment)
+}
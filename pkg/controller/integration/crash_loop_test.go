@@ -0,0 +1,116 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const crashLoopTestLogs = `2019-06-12 10:15:30.345 ERROR [main] org.apache.camel.k.main.Application - Failed to start
+org.apache.camel.FailedToCreateRouteException: Failed to create route route1
+	at org.apache.camel.reifier.RouteReifier.createRoute(RouteReifier.java:123)
+Caused by: org.apache.camel.ResolveEndpointFailedException: Failed to resolve endpoint: kafka:orders
+	at org.apache.camel.impl.AbstractCamelContext.getEndpoint(AbstractCamelContext.java:801)
+Caused by: java.net.UnknownHostException: broker: Name or service not known
+	at java.net.InetAddress.getAllByName0(InetAddress.java:1281)
+	... 12 more
+`
+
+func createCrashLoopTestEnv(t *testing.T, restarts int32, restartPolicy bool) client.Client {
+	replicas := int32(2)
+	deployment := createTestDeployment("image")
+	deployment.Spec.Replicas = &replicas
+	if restartPolicy {
+		deployment.Annotations = map[string]string{
+			v1alpha1.IntegrationMaxRestartsAnnotation: "3",
+			v1alpha1.IntegrationScaleToZeroAnnotation: "true",
+		}
+	}
+
+	pod := createTestPod("pod", "10.0.0.1", 8778)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name:         "my-integration",
+			RestartCount: restarts,
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Reason:   "Error",
+					Message:  crashLoopTestLogs,
+				},
+			},
+		},
+	}
+
+	c, err := test.NewFakeClient(deployment, pod)
+	assert.Nil(t, err)
+	return c
+}
+
+func TestExtractException(t *testing.T) {
+	assert.Equal(t, "java.net.UnknownHostException: broker: Name or service not known", extractException(crashLoopTestLogs))
+	assert.Equal(t, "java.lang.OutOfMemoryError: Java heap space", extractException("starting\njava.lang.OutOfMemoryError: Java heap space\n"))
+	assert.Equal(t, "", extractException("starting\nstopping\n"))
+
+	assert.Equal(t, "OOMKilled (exit code 137)", terminationReason(&corev1.ContainerStateTerminated{
+		ExitCode: 137,
+		Reason:   "OOMKilled",
+	}))
+}
+
+func TestCheckCrashLoop(t *testing.T) {
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+
+	c := createCrashLoopTestEnv(t, 3, true)
+	loop, err := checkCrashLoop(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.Nil(t, loop)
+
+	c = createCrashLoopTestEnv(t, 4, true)
+	loop, err = checkCrashLoop(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.NotNil(t, loop)
+	assert.Equal(t, "pod", loop.pod)
+	assert.Equal(t, "java.net.UnknownHostException: broker: Name or service not known", loop.reason)
+	assert.True(t, loop.scaleToZero)
+
+	assert.Nil(t, scaleToZero(context.TODO(), c, &integration))
+	deployment := appsv1.Deployment{}
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "my-integration"}, &deployment))
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+
+	// deployments without restart policy are left alone
+	c = createCrashLoopTestEnv(t, 10, false)
+	loop, err = checkCrashLoop(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.Nil(t, loop)
+}
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+const (
+	dependencyReasonNotReady       = "DependencyNotReady"
+	dependencyReasonForbidden      = "DependencyForbidden"
+	dependencyReasonOtherNamespace = "DependencyInOtherNamespace"
+
+	// dependenciesAggregationLabel labels the cluster roles granting the operator access to the dependencies
+	dependenciesAggregationLabel = "camel.apache.org/aggregate-to-dependencies"
+)
+
+// checkDependencies returns a condition describing the first object listed in spec.dependsOn
+// that is not ready yet, or nil if the integration can be deployed. Only the objects of the
+// integration namespace can be referenced.
+func checkDependencies(ctx context.Context, c client.Client, integration *v1alpha1.Integration) *v1alpha1.Condition {
+	for _, ref := range integration.Spec.DependsOn {
+		description := fmt.Sprintf("%s %s/%s (%s)", ref.Kind, integration.Namespace, ref.Name, ref.APIVersion)
+		if ref.Namespace != "" && ref.Namespace != integration.Namespace {
+			return dependencyCondition(dependencyReasonOtherNamespace,
+				fmt.Sprintf("%s %s/%s (%s) does not belong to the integration namespace", ref.Kind, ref.Namespace, ref.Name, ref.APIVersion))
+		}
+
+		ready, message, err := kubernetes.GetObjectReadiness(ctx, c, integration.Namespace, ref)
+		if err != nil && k8serrors.IsForbidden(err) {
+			return dependencyCondition(dependencyReasonForbidden,
+				fmt.Sprintf("the operator is not allowed to get %s, grant it with a cluster role labelled %s=true",
+					description, dependenciesAggregationLabel))
+		}
+		if err != nil {
+			message = err.Error()
+		}
+		if ready {
+			continue
+		}
+
+		return dependencyCondition(dependencyReasonNotReady, fmt.Sprintf("%s is not ready: %s", description, message))
+	}
+
+	return nil
+}
+
+func dependencyCondition(reason string, message string) *v1alpha1.Condition {
+	return &v1alpha1.Condition{
+		Type:    v1alpha1.ConditionWaitingForDependencies,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+}
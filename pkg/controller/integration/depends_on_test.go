@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	deployment := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "db",
+		},
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, Message: "Deployment does not have minimum availability."},
+			},
+		},
+	}
+	service := corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "db",
+		},
+	}
+
+	c, err := test.NewFakeClient(&deployment, &service)
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	integration.Spec.DependsOn = []corev1.ObjectReference{
+		{APIVersion: "v1", Kind: "Service", Name: "db"},
+		{APIVersion: "apps/v1", Kind: "Deployment", Name: "db"},
+	}
+
+	condition := checkDependencies(context.TODO(), c, &integration)
+	assert.NotNil(t, condition)
+	assert.Equal(t, v1alpha1.ConditionWaitingForDependencies, condition.Type)
+	assert.Equal(t, "DependencyNotReady", condition.Reason)
+	assert.Equal(t, "Deployment ns/db (apps/v1) is not ready: condition Available is False: Deployment does not have minimum availability.", condition.Message)
+
+	deployment.Status.Conditions[0].Status = corev1.ConditionTrue
+	assert.Nil(t, c.Status().Update(context.TODO(), &deployment))
+	assert.Nil(t, checkDependencies(context.TODO(), c, &integration))
+
+	integration.Spec.DependsOn = append(integration.Spec.DependsOn, corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Name: "missing"})
+	condition = checkDependencies(context.TODO(), c, &integration)
+	assert.NotNil(t, condition)
+	assert.Contains(t, condition.Message, "ConfigMap ns/missing (v1) is not ready")
+
+	// the objects of other namespaces cannot be referenced
+	integration.Spec.DependsOn = []corev1.ObjectReference{
+		{APIVersion: "v1", Kind: "Service", Namespace: "other", Name: "db"},
+	}
+	condition = checkDependencies(context.TODO(), c, &integration)
+	assert.NotNil(t, condition)
+	assert.Equal(t, dependencyReasonOtherNamespace, condition.Reason)
+
+	integration.Spec.DependsOn[0].Namespace = "ns"
+	assert.Nil(t, checkDependencies(context.TODO(), c, &integration))
+}
+
+func TestCheckForbiddenDependencies(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	integration.Spec.DependsOn = []corev1.ObjectReference{
+		{APIVersion: "kafka.strimzi.io/v1beta1", Kind: "Kafka", Name: "my-cluster"},
+	}
+
+	condition := checkDependencies(context.TODO(), &forbiddenClient{Client: c}, &integration)
+	assert.NotNil(t, condition)
+	assert.Equal(t, dependencyReasonForbidden, condition.Reason)
+	assert.Contains(t, condition.Message, "Kafka ns/my-cluster")
+}
+
+// forbiddenClient is a client that is not allowed to get any object
+type forbiddenClient struct {
+	client.Client
+}
+
+func (c *forbiddenClient) Get(_ context.Context, key k8sclient.ObjectKey, _ runtime.Object) error {
+	return k8serrors.NewForbidden(schema.GroupResource{}, key.Name, errors.New("access denied"))
+}
@@ -21,7 +21,9 @@ import (
 	"context"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/audit"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/pkg/errors"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -45,6 +47,21 @@ func (action *deployAction) CanHandle(integration *v1alpha1.Integration) bool {
 }
 
 func (action *deployAction) Handle(ctx context.Context, integration *v1alpha1.Integration) error {
+	// Hold back the deployment until all the objects the integration depends on are ready
+	if waiting := checkDependencies(ctx, action.client, integration); waiting != nil {
+		current := v1alpha1.GetCondition(integration.Status.Conditions, v1alpha1.ConditionWaitingForDependencies)
+		if current != nil && current.Message == waiting.Message {
+			return nil
+		}
+
+		action.L.Info("Waiting for dependencies", "reason", waiting.Message)
+
+		target := integration.DeepCopy()
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *waiting)
+
+		return action.client.Status().Update(ctx, target)
+	}
+
 	kitName := integration.Status.Kit
 	if kitName == "" {
 		return errors.Errorf("no kit set on integration %s", integration.Name)
@@ -59,11 +76,43 @@ func (action *deployAction) Handle(ctx context.Context, integration *v1alpha1.In
 		return errors.Wrapf(err, "unable to find integration kit %s, %s", kitName, err)
 	}
 
+	// Hold back the deployment while the kit image contains vulnerabilities, if required by the platform
+	if pl, err := platform.GetCurrentPlatform(ctx, action.client, integration.Namespace); err == nil && platform.BlocksDeployment(pl, &kit) {
+		vulnerabilities := platform.IntegrationVulnerabilities(&kit)
+		current := v1alpha1.GetCondition(integration.Status.Conditions, v1alpha1.ConditionVulnerabilities)
+		if current != nil && current.Message == vulnerabilities.Message {
+			return nil
+		}
+
+		action.L.Info("Deployment held back by vulnerabilities", "reason", vulnerabilities.Message)
+
+		target := integration.DeepCopy()
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *vulnerabilities)
+
+		return action.client.Status().Update(ctx, target)
+	}
+
+	pendingApproval := ""
+	if c := v1alpha1.GetCondition(integration.Status.Conditions, v1alpha1.ConditionWaitingForApproval); c != nil {
+		pendingApproval = c.Message
+	}
+
 	env, err := trait.Apply(ctx, action.client, integration, &kit)
 	if err != nil {
 		return err
 	}
 
+	// Keep the workload on the previous image until the rollout gets approved
+	if c := v1alpha1.GetCondition(env.Integration.Status.Conditions, v1alpha1.ConditionWaitingForApproval); c != nil {
+		if c.Message == pendingApproval {
+			return nil
+		}
+
+		action.L.Info("Waiting for approval", "image", integration.Status.Image)
+
+		return action.client.Status().Update(ctx, env.Integration)
+	}
+
 	err = kubernetes.ReplaceResources(ctx, action.client, env.Resources.Items())
 	if err != nil {
 		return err
@@ -76,8 +125,21 @@ func (action *deployAction) Handle(ctx context.Context, integration *v1alpha1.In
 		}
 	}
 
+	audit.Record("Integration deployed", v1alpha1.IntegrationKind, integration.ObjectMeta,
+		"digest", integration.Status.Digest,
+		"kit", kit.Name,
+		"kit-trigger", kit.Annotations[audit.AnnotationTrigger],
+		"image", integration.Status.Image)
+
 	target := integration.DeepCopy()
 	target.Status.Phase = v1alpha1.IntegrationPhaseRunning
+	target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionWaitingForDependencies)
+	target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionWaitingForApproval)
+	if vulnerabilities := platform.IntegrationVulnerabilities(&kit); vulnerabilities != nil {
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *vulnerabilities)
+	} else {
+		target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionVulnerabilities)
+	}
 
 	action.L.Info("Integration state transition", "phase", target.Status.Phase)
 
@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/audit"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// driftedFields are the fields compared to detect out-of-band changes to generated resources
+var driftedFields = []string{"spec", "data"}
+
+// drift describes a generated resource that is missing or differs from the expected one
+type drift struct {
+	object  runtime.Object
+	missing bool
+}
+
+func (d drift) String() string {
+	state := "modified"
+	if d.missing {
+		state = "missing"
+	}
+	return fmt.Sprintf("%s %s", kubernetes.FindResourceDetails(d.object), state)
+}
+
+// detectDrift compares the expected resources with the ones found on the cluster.
+//
+// Only the fields set on the expected resources are compared, so that defaults and
+// values filled by the cluster are not considered as changes.
+func detectDrift(ctx context.Context, c k8sclient.Reader, expected []runtime.Object) ([]drift, error) {
+	drifts := make([]drift, 0)
+
+	for _, object := range expected {
+		live := object.DeepCopyObject()
+		key, err := k8sclient.ObjectKeyFromObject(live)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := c.Get(ctx, key, live); err != nil {
+			if k8serrors.IsNotFound(err) {
+				drifts = append(drifts, drift{object: object, missing: true})
+				continue
+			}
+			if meta.IsNoMatchError(err) {
+				// the resource kind is not served by the cluster
+				continue
+			}
+			return nil, err
+		}
+
+		e, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return nil, err
+		}
+		l, err := runtime.DefaultUnstructuredConverter.ToUnstructured(live)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range driftedFields {
+			if !equality.Semantic.DeepDerivative(e[field], l[field]) {
+				drifts = append(drifts, drift{object: object})
+				break
+			}
+		}
+	}
+
+	return drifts, nil
+}
+
+// enforceDrift restores the drifted resources allowed by the enforcement level and
+// returns the ones that have been left untouched
+func enforceDrift(ctx context.Context, c client.Client, integration *v1alpha1.Integration, enforcement string, drifts []drift) ([]drift, error) {
+	remaining := make([]drift, 0)
+
+	for _, d := range drifts {
+		restore := enforcement == platform.DriftEnforcementRevert ||
+			(enforcement == platform.DriftEnforcementRecreate && d.missing)
+		if !restore {
+			remaining = append(remaining, d)
+			continue
+		}
+
+		if err := kubernetes.ReplaceResource(ctx, c, d.object); err != nil {
+			return nil, errors.Wrap(err, "unable to restore generated resource")
+		}
+
+		event := "Integration resource reverted"
+		if d.missing {
+			event = "Integration resource recreated"
+		}
+		audit.Record(event, v1alpha1.IntegrationKind, integration.ObjectMeta,
+			"resource", kubernetes.FindResourceDetails(d.object))
+	}
+
+	return remaining, nil
+}
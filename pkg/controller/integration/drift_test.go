@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createDriftTestEnv(t *testing.T) (client.Client, []runtime.Object) {
+	service := corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80},
+			},
+		},
+	}
+	configmap := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration-source-000",
+		},
+		Data: map[string]string{
+			"content": "from('timer:tick').to('log:info')",
+		},
+	}
+
+	// the cluster IP is allocated by the cluster, it's not a change
+	allocated := service.DeepCopy()
+	allocated.Spec.ClusterIP = "10.0.0.1"
+
+	c, err := test.NewFakeClient(createTestDeployment("edited"), allocated)
+	assert.Nil(t, err)
+
+	return c, []runtime.Object{createTestDeployment("image"), &service, &configmap}
+}
+
+func TestDetectAndEnforceDrift(t *testing.T) {
+	c, expected := createDriftTestEnv(t)
+
+	drifts, err := detectDrift(context.TODO(), c, expected)
+	assert.Nil(t, err)
+	assert.Len(t, drifts, 2)
+	assert.Equal(t, "Deployment my-integration modified", drifts[0].String())
+	assert.Equal(t, "ConfigMap my-integration-source-000 missing", drifts[1].String())
+
+	integration := &v1alpha1.Integration{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+	}
+
+	remaining, err := enforceDrift(context.TODO(), c, integration, platform.DriftEnforcementNone, drifts)
+	assert.Nil(t, err)
+	assert.Len(t, remaining, 2)
+
+	remaining, err = enforceDrift(context.TODO(), c, integration, platform.DriftEnforcementRecreate, drifts)
+	assert.Nil(t, err)
+	assert.Len(t, remaining, 1)
+	assert.False(t, remaining[0].missing)
+
+	cm := corev1.ConfigMap{}
+	err = c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "my-integration-source-000"}, &cm)
+	assert.Nil(t, err)
+
+	remaining, err = enforceDrift(context.TODO(), c, integration, platform.DriftEnforcementRevert, remaining)
+	assert.Nil(t, err)
+	assert.Len(t, remaining, 0)
+
+	drifts, err = detectDrift(context.TODO(), c, expected)
+	assert.Nil(t, err)
+	assert.Len(t, drifts, 0)
+}
+
+func TestCheckGeneratedResourcesThrottled(t *testing.T) {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	action := monitorAction{}
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+
+	// the resources have just been checked, the traits aren't applied again
+	now := metav1.Now()
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	integration.Status.Phase = v1alpha1.IntegrationPhaseRunning
+	integration.Status.ResourcesCheckTime = &now
+
+	target := integration.DeepCopy()
+	assert.Nil(t, action.checkGeneratedResources(context.TODO(), &integration, target))
+	assert.Equal(t, integration.Status, target.Status)
+}
@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/trait"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// adoptTriggeredImages sets the images resolved by the OpenShift image triggers on the expected
+// deployments, so that the rollouts of re-pushed image stream tags are not reported as drifts
+func adoptTriggeredImages(ctx context.Context, c client.Client, expected []runtime.Object) error {
+	for _, object := range expected {
+		deployment, ok := object.(*appsv1.Deployment)
+		if !ok || deployment.Annotations[trait.OpenShiftImageTriggersAnnotation] == "" {
+			continue
+		}
+
+		live := appsv1.Deployment{}
+		key := k8sclient.ObjectKey{Namespace: deployment.Namespace, Name: deployment.Name}
+		if err := c.Get(ctx, key, &live); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		for i, container := range deployment.Spec.Template.Spec.Containers {
+			for _, l := range live.Spec.Template.Spec.Containers {
+				if l.Name == container.Name {
+					deployment.Spec.Template.Spec.Containers[i].Image = l.Image
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveImageDigest returns the digest of the image run by the integration, either resolved by
+// an image trigger or reported by the running pods, or an empty string if it's not yet known
+func resolveImageDigest(ctx context.Context, c client.Client, integration *v1alpha1.Integration) (string, error) {
+	deployment := appsv1.Deployment{}
+	key := k8sclient.ObjectKey{Namespace: integration.Namespace, Name: integration.Name}
+	if err := c.Get(ctx, key, &deployment); err != nil && !k8serrors.IsNotFound(err) {
+		return "", err
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == integration.Name {
+			if digest := imageDigest(container.Image); digest != "" {
+				return digest, nil
+			}
+		}
+	}
+
+	pods := corev1.PodList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+	}
+	options := k8sclient.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			"camel.apache.org/integration": integration.Name,
+		}),
+		Namespace: integration.Namespace,
+	}
+	if err := c.List(ctx, &options, &pods); err != nil {
+		return "", err
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Labels["camel.apache.org/integration"] != integration.Name || pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		// the integration container comes first
+		if len(pod.Status.ContainerStatuses) > 0 {
+			if digest := imageDigest(pod.Status.ContainerStatuses[0].ImageID); digest != "" {
+				return digest, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// imageDigest extracts the digest from an image reference, i.e. docker-pullable://repo@sha256:...
+func imageDigest(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	return ""
+}
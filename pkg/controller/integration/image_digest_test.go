@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func createImageTestDeployment(image string, triggered bool) *appsv1.Deployment {
+	deployment := createTestDeployment(image)
+	if triggered {
+		deployment.Annotations = map[string]string{
+			trait.OpenShiftImageTriggersAnnotation: "[]",
+		}
+	}
+	return deployment
+}
+
+func TestAdoptTriggeredImages(t *testing.T) {
+	c, err := test.NewFakeClient(createImageTestDeployment("172.30.1.1:5000/ns/camel-k-kit-1@sha256:1234", true))
+	assert.Nil(t, err)
+
+	expected := createImageTestDeployment("172.30.1.1:5000/ns/camel-k-kit-1:latest", true)
+	err = adoptTriggeredImages(context.TODO(), c, []runtime.Object{expected})
+	assert.Nil(t, err)
+	assert.Equal(t, "172.30.1.1:5000/ns/camel-k-kit-1@sha256:1234", expected.Spec.Template.Spec.Containers[0].Image)
+
+	expected = createImageTestDeployment("172.30.1.1:5000/ns/camel-k-kit-1:latest", false)
+	err = adoptTriggeredImages(context.TODO(), c, []runtime.Object{expected})
+	assert.Nil(t, err)
+	assert.Equal(t, "172.30.1.1:5000/ns/camel-k-kit-1:latest", expected.Spec.Template.Spec.Containers[0].Image)
+}
+
+func TestResolveImageDigest(t *testing.T) {
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+
+	c, err := test.NewFakeClient(createImageTestDeployment("172.30.1.1:5000/ns/camel-k-kit-1@sha256:1234", true))
+	assert.Nil(t, err)
+	digest, err := resolveImageDigest(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.Equal(t, "sha256:1234", digest)
+
+	pod := createTestPod("pod-1", "10.0.0.1", 8778)
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "my-integration", ImageID: "docker-pullable://172.30.1.1:5000/ns/camel-k-kit-1@sha256:5678"},
+	}
+	c, err = test.NewFakeClient(createImageTestDeployment("172.30.1.1:5000/ns/camel-k-kit-1:1234", false), pod)
+	assert.Nil(t, err)
+	digest, err = resolveImageDigest(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.Equal(t, "sha256:5678", digest)
+
+	c, err = test.NewFakeClient()
+	assert.Nil(t, err)
+	digest, err = resolveImageDigest(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.Equal(t, "", digest)
+}
@@ -0,0 +1,132 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// initContainerFailureReasons are the waiting reasons of init containers that cannot complete
+// without an external change
+var initContainerFailureReasons = []string{
+	"CrashLoopBackOff",
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"CreateContainerConfigError",
+	"InvalidImageName",
+}
+
+// checkInitContainers reports the init containers of the integration pods that have failed,
+// returning nil if none did
+func checkInitContainers(ctx context.Context, c client.Client, integration *v1alpha1.Integration) (*v1alpha1.Condition, error) {
+	pods := corev1.PodList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+	}
+	options := k8sclient.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			"camel.apache.org/integration": integration.Name,
+		}),
+		Namespace: integration.Namespace,
+	}
+	if err := c.List(ctx, &options, &pods); err != nil {
+		return nil, err
+	}
+
+	failures := make([]string, 0)
+	for _, pod := range pods.Items {
+		if pod.Labels["camel.apache.org/integration"] != integration.Name {
+			continue
+		}
+		for _, status := range pod.Status.InitContainerStatuses {
+			if failure := initContainerFailure(status); failure != "" {
+				failures = append(failures, fmt.Sprintf("%s/%s %s", pod.Name, status.Name, failure))
+			}
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil, nil
+	}
+
+	return &v1alpha1.Condition{
+		Type:    v1alpha1.ConditionInitContainersFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  "InitContainerError",
+		Message: strings.Join(failures, ", "),
+	}, nil
+}
+
+func initContainerFailure(status corev1.ContainerStatus) string {
+	if t := status.State.Terminated; t != nil && t.ExitCode != 0 {
+		return fmt.Sprintf("terminated: %s (exit code %d)", t.Reason, t.ExitCode)
+	}
+	if w := status.State.Waiting; w != nil {
+		for _, reason := range initContainerFailureReasons {
+			if w.Reason != reason {
+				continue
+			}
+			if t := status.LastTerminationState.Terminated; t != nil && t.ExitCode != 0 {
+				return fmt.Sprintf("waiting: %s, last terminated: %s (exit code %d)", w.Reason, t.Reason, t.ExitCode)
+			}
+			return fmt.Sprintf("waiting: %s", w.Reason)
+		}
+	}
+	return ""
+}
+
+// awaitsPods tells whether the deployment or the Knative service of the integration has pods that are not
+// ready yet, e.g. while running init containers, enforcing a restart policy or failing to pull the image,
+// as the status of pods is not watched
+func awaitsPods(ctx context.Context, c client.Client, integration *v1alpha1.Integration) bool {
+	key := k8sclient.ObjectKey{
+		Namespace: integration.Namespace,
+		Name:      integration.Name,
+	}
+
+	deployment := appsv1.Deployment{}
+	if err := c.Get(ctx, key, &deployment); err != nil {
+		service := serving.Service{}
+		if err := c.Get(ctx, key, &service); err != nil {
+			return false
+		}
+		return !service.Status.IsReady()
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return deployment.Status.ReadyReplicas < replicas
+}
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCheckInitContainers(t *testing.T) {
+	failing := createTestPod("pod-1", "10.0.0.1", 8778)
+	failing.Status.Phase = corev1.PodPending
+	failing.Status.InitContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: "fetch-dataset",
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 0, Reason: "Completed"},
+			},
+		},
+		{
+			Name: "migrate-db",
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+			},
+		},
+	}
+	initializing := createTestPod("pod-2", "10.0.0.2", 8778)
+	initializing.Status.Phase = corev1.PodPending
+	initializing.Status.InitContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: "fetch-dataset",
+			State: corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{},
+			},
+		},
+	}
+
+	c, err := test.NewFakeClient(failing, initializing)
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	condition, err := checkInitContainers(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.NotNil(t, condition)
+	assert.Equal(t, v1alpha1.ConditionInitContainersFailed, condition.Type)
+	assert.Equal(t, "pod-1/migrate-db waiting: CrashLoopBackOff, last terminated: Error (exit code 1)", condition.Message)
+
+	other := v1alpha1.NewIntegration("ns", "other")
+	condition, err = checkInitContainers(context.TODO(), c, &other)
+	assert.Nil(t, err)
+	assert.Nil(t, condition)
+}
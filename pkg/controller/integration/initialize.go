@@ -87,6 +87,57 @@ func (action *initializeAction) Handle(ctx context.Context, integration *v1alpha
 		return action.client.Status().Update(ctx, target)
 	}
 
+	// hold the integration back as long as the namespace is over quota
+	quota, err := platform.CheckIntegrationQuota(ctx, action.client, pl, integration)
+	if err != nil {
+		return err
+	}
+	if quota != nil {
+		action.L.Info("Integration held back by the platform quota", "reason", quota.Reason)
+
+		if c := v1alpha1.GetCondition(integration.Status.Conditions, quota.Type); c != nil && c.Message == quota.Message {
+			return nil
+		}
+
+		target := integration.DeepCopy()
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *quota)
+
+		return action.client.Status().Update(ctx, target)
+	}
+
+	// hold the integration back as long as it does not follow the platform naming and labeling policy
+	violation, err := platform.CheckIntegrationPolicy(pl, integration)
+	if err != nil {
+		return err
+	}
+	if violation != nil {
+		action.L.Info("Integration held back by the platform policy", "reason", violation.Message)
+
+		if c := v1alpha1.GetCondition(integration.Status.Conditions, violation.Type); c != nil && c.Message == violation.Message {
+			return nil
+		}
+
+		target := integration.DeepCopy()
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *violation)
+
+		return action.client.Status().Update(ctx, target)
+	}
+
+	// hold the integration back if it has hard-coded credentials and the platform policy rejects them
+	credentials, rejected := platform.CheckHardcodedCredentials(pl, integration)
+	if rejected {
+		action.L.Info("Integration held back because of hard-coded credentials", "reason", credentials.Message)
+
+		if c := v1alpha1.GetCondition(integration.Status.Conditions, credentials.Type); c != nil && c.Message == credentials.Message {
+			return nil
+		}
+
+		target := integration.DeepCopy()
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *credentials)
+
+		return action.client.Status().Update(ctx, target)
+	}
+
 	// better not changing the spec section of the target because it may be used for comparison by a
 	// higher level controller (e.g. Knative source controller)
 
@@ -98,6 +149,15 @@ func (action *initializeAction) Handle(ctx context.Context, integration *v1alpha
 		return err
 	}
 
+	// traits may fail the integration early, e.g. when it requires components not available on the cluster
+	if target.Status.Phase == v1alpha1.IntegrationPhaseError {
+		target.Status.Digest = dgst
+
+		action.L.Info("Integration state transition", "phase", target.Status.Phase)
+
+		return action.client.Status().Update(ctx, target)
+	}
+
 	err = kubernetes.ReplaceResources(ctx, action.client, env.Resources.Items())
 	if err != nil {
 		return err
@@ -107,6 +167,15 @@ func (action *initializeAction) Handle(ctx context.Context, integration *v1alpha
 	target.Status.Digest = dgst
 	target.Status.Kit = integration.Spec.Kit
 	target.Status.Image = ""
+	target.Status.ImageDigest = ""
+	target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionQuotaExceeded)
+	target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionPolicyViolated)
+	if credentials != nil {
+		action.L.Info("Integration has hard-coded credentials", "reason", credentials.Message)
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *credentials)
+	} else {
+		target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionHardcodedCredentials)
+	}
 
 	action.L.Info("Integration state transition", "phase", target.Status.Phase)
 
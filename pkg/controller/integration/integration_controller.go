@@ -18,9 +18,13 @@ package integration
 
 import (
 	"context"
+	"reflect"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 
@@ -35,6 +39,7 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/util/log"
 )
 
@@ -76,9 +81,13 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 			newIntegration := e.ObjectNew.(*v1alpha1.Integration)
 			// Ignore updates to the integration status in which case metadata.Generation does not change,
 			// or except when the integration phase changes as it's used to transition from one phase
-			// to another
+			// to another, or when the reconciliation is paused or resumed, or when a rollout is approved,
+			// or when the labels checked by the platform policy change
 			return oldIntegration.Generation != newIntegration.Generation ||
-				oldIntegration.Status.Phase != newIntegration.Status.Phase
+				oldIntegration.Status.Phase != newIntegration.Status.Phase ||
+				!reflect.DeepEqual(oldIntegration.Labels, newIntegration.Labels) ||
+				v1alpha1.IsReconcilePaused(oldIntegration.ObjectMeta) != v1alpha1.IsReconcilePaused(newIntegration.ObjectMeta) ||
+				oldIntegration.Annotations[v1alpha1.IntegrationApprovedImageAnnotation] != newIntegration.Annotations[v1alpha1.IntegrationApprovedImageAnnotation]
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			// Evaluates to false if the object has been confirmed deleted
@@ -109,6 +118,28 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// Watch for out-of-band deletions or changes of the resources generated for the integration,
+	// so that the monitoring action can detect drifts
+	for _, owned := range []runtime.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.ConfigMap{}} {
+		err = c.Watch(&source.Kind{Type: owned},
+			&handler.EnqueueRequestForOwner{
+				IsController: true,
+				OwnerType:    &v1alpha1.Integration{},
+			},
+			predicate.Funcs{
+				CreateFunc: func(e event.CreateEvent) bool {
+					return false
+				},
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					// Ignore status updates for resources tracking their generation (e.g. deployments)
+					return e.MetaNew.GetGeneration() == 0 || e.MetaOld.GetGeneration() != e.MetaNew.GetGeneration()
+				},
+			})
+		if err != nil {
+			return err
+		}
+	}
+
 	// Watch for IntegrationPlatform phase transitioning to ready
 	// and enqueue requests for any integrations that are in phase waiting for platform
 	err = c.Watch(&source.Kind{Type: &v1alpha1.IntegrationPlatform{}}, &handler.EnqueueRequestsFromMapFunc{
@@ -181,6 +212,41 @@ func (r *ReconcileIntegration) Reconcile(request reconcile.Request) (reconcile.R
 		return reconcile.Result{}, err
 	}
 
+	// Skip the reconciliation while paused, so that generated resources can be hot-fixed
+	// without the operator reverting the changes
+	if instance.GetDeletionTimestamp() == nil && v1alpha1.IsReconcilePaused(instance.ObjectMeta) {
+		if v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionReconcilePaused) == nil {
+			target := instance.DeepCopy()
+			target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, v1alpha1.Condition{
+				Type:    v1alpha1.ConditionReconcilePaused,
+				Status:  corev1.ConditionTrue,
+				Reason:  "PausedByAnnotation",
+				Message: "reconciliation paused through the " + v1alpha1.ReconcileAnnotation + " annotation",
+			})
+
+			return reconcile.Result{}, r.client.Status().Update(ctx, target)
+		}
+
+		rlog.Info("Reconciliation paused")
+		return reconcile.Result{}, nil
+	}
+	if v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionReconcilePaused) != nil {
+		target := instance.DeepCopy()
+		target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionReconcilePaused)
+
+		// resume the reconciliation from the updated resource
+		return reconcile.Result{Requeue: true}, r.client.Status().Update(ctx, target)
+	}
+
+	// Record the time the integration entered its current phase before acting on it, so that the time
+	// spent in each phase can be reported
+	if instance.GetDeletionTimestamp() == nil {
+		target := instance.DeepCopy()
+		if target.Status.RecordPhaseTransition(metav1.Now()) {
+			return reconcile.Result{Requeue: true}, r.client.Status().Update(ctx, target)
+		}
+	}
+
 	integrationActionPool := []Action{
 		NewInitializeAction(),
 		NewBuildKitAction(),
@@ -213,5 +279,46 @@ func (r *ReconcileIntegration) Reconcile(request reconcile.Request) (reconcile.R
 		}
 	}
 
+	// Requeue resources held back by the platform quota so that they are admitted
+	// as soon as the namespace gets below the limits, and those waiting for a shared kit
+	// or for the kit they are pinned to to be fixed, as kits are not watched
+	if v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionQuotaExceeded) != nil ||
+		v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionWaitingForSharedKit) != nil ||
+		v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionKitIncompatible) != nil {
+		return reconcile.Result{
+			RequeueAfter: platform.GetOperatorConfiguration().RequeueInterval,
+		}, nil
+	}
+
+	// Requeue integrations waiting for their dependencies, as changes to the status
+	// of arbitrary objects cannot be watched, waiting for a time-based approval, or held
+	// back until the vulnerabilities of their kit are fixed
+	if instance.Status.Phase == v1alpha1.IntegrationPhaseDeploying &&
+		(len(instance.Spec.DependsOn) > 0 ||
+			v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionWaitingForApproval) != nil ||
+			v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionVulnerabilities) != nil) {
+		return reconcile.Result{
+			RequeueAfter: platform.GetOperatorConfiguration().RequeueInterval,
+		}, nil
+	}
+
+	// Requeue running integrations exposing the Jolokia endpoint, to refresh the route statistics
+	if instance.Status.Phase == v1alpha1.IntegrationPhaseRunning && exposesRouteStatistics(ctx, r.client, instance) {
+		return reconcile.Result{
+			RequeueAfter: platform.GetOperatorConfiguration().IntegrationMonitorInterval,
+		}, nil
+	}
+
+	// Requeue running integrations whose pods are not ready yet or failing, e.g. going through init
+	// containers or unable to pull the image, to report their failures
+	if instance.Status.Phase == v1alpha1.IntegrationPhaseRunning &&
+		(v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionInitContainersFailed) != nil ||
+			v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionDeploymentFailed) != nil ||
+			awaitsPods(ctx, r.client, instance)) {
+		return reconcile.Result{
+			RequeueAfter: platform.GetOperatorConfiguration().IntegrationMonitorInterval,
+		}, nil
+	}
+
 	return reconcile.Result{}, nil
 }
@@ -19,9 +19,18 @@ package integration
 
 import (
 	"context"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/audit"
 	"github.com/apache/camel-k/pkg/util/digest"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // NewMonitorAction creates a new monitoring action for an integration
@@ -52,6 +61,10 @@ func (action *monitorAction) Handle(ctx context.Context, integration *v1alpha1.I
 	if hash != integration.Status.Digest {
 		action.L.Info("Integration needs a rebuild")
 
+		audit.Record("Integration changed", v1alpha1.IntegrationKind, integration.ObjectMeta,
+			"previous-digest", integration.Status.Digest,
+			"digest", hash)
+
 		target := integration.DeepCopy()
 		target.Status.Digest = hash
 		target.Status.Phase = ""
@@ -61,6 +74,148 @@ func (action *monitorAction) Handle(ctx context.Context, integration *v1alpha1.I
 		return action.client.Status().Update(ctx, target)
 	}
 
-	// TODO check also if deployment matches (e.g. replicas)
+	if integration.Status.Phase == v1alpha1.IntegrationPhaseRunning {
+		loop, err := checkCrashLoop(ctx, action.client, integration)
+		if err != nil {
+			return err
+		}
+		if loop != nil {
+			return action.failCrashLoop(ctx, integration, loop)
+		}
+
+		target := integration.DeepCopy()
+
+		if err := action.checkGeneratedResources(ctx, integration, target); err != nil {
+			return err
+		}
+		if err := action.updateRouteStatistics(ctx, target); err != nil {
+			return err
+		}
+		if err := action.collectTraces(ctx, integration); err != nil {
+			return err
+		}
+
+		digest, err := resolveImageDigest(ctx, action.client, integration)
+		if err != nil {
+			return err
+		}
+		if digest != "" {
+			target.Status.ImageDigest = digest
+		}
+
+		failed, err := checkInitContainers(ctx, action.client, integration)
+		if err != nil {
+			return err
+		}
+		if failed != nil {
+			target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *failed)
+		} else {
+			target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionInitContainersFailed)
+		}
+
+		workload, err := checkWorkload(ctx, action.client, integration)
+		if err != nil {
+			return err
+		}
+		if workload != nil {
+			target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *workload)
+		} else {
+			target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionDeploymentFailed)
+		}
+
+		if reflect.DeepEqual(target.Status, integration.Status) {
+			return nil
+		}
+
+		return action.client.Status().Update(ctx, target)
+	}
+
+	return nil
+}
+
+// checkGeneratedResources detects the generated resources that have been deleted or modified
+// out-of-band and restores them according to the operator drift enforcement level. As the resources
+// are computed by applying the traits, they are checked once per monitor interval at most
+func (action *monitorAction) checkGeneratedResources(ctx context.Context, integration *v1alpha1.Integration, target *v1alpha1.Integration) error {
+	interval := platform.GetOperatorConfiguration().IntegrationMonitorInterval
+	if last := integration.Status.ResourcesCheckTime; last != nil && time.Since(last.Time) < interval/2 {
+		return nil
+	}
+
+	// compute the resources as they are when deploying the integration
+	deploying := integration.DeepCopy()
+	deploying.Status.Phase = v1alpha1.IntegrationPhaseDeploying
+
+	env, err := trait.Apply(ctx, action.client, deploying, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := adoptTriggeredImages(ctx, action.client, env.Resources.Items()); err != nil {
+		return err
+	}
+
+	drifts, err := detectDrift(ctx, action.client, env.Resources.Items())
+	if err != nil {
+		return err
+	}
+
+	enforcement := platform.GetOperatorConfiguration().DriftEnforcement
+	for _, d := range drifts {
+		action.L.Info("Integration generated resource drifted", "resource", d.String(), "enforcement", enforcement)
+	}
+
+	remaining, err := enforceDrift(ctx, action.client, integration, enforcement, drifts)
+	if err != nil {
+		return err
+	}
+
+	if len(remaining) > 0 {
+		details := make([]string, 0, len(remaining))
+		for _, d := range remaining {
+			details = append(details, d.String())
+		}
+
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, v1alpha1.Condition{
+			Type:    v1alpha1.ConditionResourcesDrifted,
+			Status:  corev1.ConditionTrue,
+			Reason:  "DriftNotEnforced",
+			Message: strings.Join(details, ", "),
+		})
+	} else {
+		target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionResourcesDrifted)
+	}
+
+	now := metav1.Now()
+	target.Status.ResourcesCheckTime = &now
+
 	return nil
 }
+
+// failCrashLoop sets the integration in error as its restart policy has been exceeded, so that
+// it's only redeployed once changed, optionally scaling it to zero to stop the restarts
+func (action *monitorAction) failCrashLoop(ctx context.Context, integration *v1alpha1.Integration, loop *crashLoop) error {
+	action.L.Info("Integration crash looping", "pod", loop.pod, "restarts", loop.restarts, "reason", loop.reason)
+
+	audit.Record("Integration crash looping", v1alpha1.IntegrationKind, integration.ObjectMeta,
+		"pod", loop.pod,
+		"restarts", loop.restarts,
+		"scale-to-zero", loop.scaleToZero)
+
+	if loop.scaleToZero {
+		if err := scaleToZero(ctx, action.client, integration); err != nil {
+			return err
+		}
+	}
+
+	target := integration.DeepCopy()
+	target.Status.Phase = v1alpha1.IntegrationPhaseError
+	target.Status.Failure = &v1alpha1.Failure{
+		Reason: loop.reason,
+		Time:   metav1.Now(),
+	}
+
+	action.L.Info("Integration state transition", "phase", target.Status.Phase)
+
+	return action.client.Status().Update(ctx, target)
+}
@@ -0,0 +1,157 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/jolokia"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// routeStatisticsTimeout bounds the time spent reading the statistics of all the pods
+const routeStatisticsTimeout = 5 * time.Second
+
+// updateRouteStatistics polls the route statistics of the integration pods exposing a Jolokia
+// endpoint over HTTP and reflects the aggregated snapshot in the target status
+func (action *monitorAction) updateRouteStatistics(ctx context.Context, target *v1alpha1.Integration) error {
+	interval := platform.GetOperatorConfiguration().IntegrationMonitorInterval
+	if last := target.Status.RoutesUpdateTime; last != nil && time.Since(last.Time) < interval/2 {
+		return nil
+	}
+
+	pods, err := listIntegrationPods(ctx, action.client, target)
+	if err != nil {
+		return err
+	}
+
+	// the pods are polled concurrently, within a single deadline
+	pollCtx, cancel := context.WithTimeout(ctx, routeStatisticsTimeout)
+	defer cancel()
+
+	polled := false
+	results := make([][]v1alpha1.RouteStatus, len(pods))
+	var wg sync.WaitGroup
+	for i := range pods {
+		endpoint := jolokia.Endpoint(pods[i])
+		if endpoint == "" || !isPodReady(pods[i]) {
+			continue
+		}
+		polled = true
+
+		wg.Add(1)
+		go func(i int, endpoint string) {
+			defer wg.Done()
+			routes, err := jolokia.ReadRoutes(pollCtx, endpoint)
+			if err != nil {
+				action.L.Info("Cannot read route statistics", "pod", pods[i].Name, "error", err.Error())
+				return
+			}
+			results[i] = routes
+		}(i, endpoint)
+	}
+	wg.Wait()
+
+	if !polled {
+		return nil
+	}
+
+	snapshots := make([][]v1alpha1.RouteStatus, 0, len(pods))
+	for _, routes := range results {
+		if routes != nil {
+			snapshots = append(snapshots, routes)
+		}
+	}
+
+	now := metav1.Now()
+	target.Status.Routes = jolokia.MergeRoutes(snapshots...)
+	target.Status.RoutesUpdateTime = &now
+
+	return nil
+}
+
+// isPodReady tells whether the pod is ready to serve requests
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// listIntegrationPods returns the pods of the integration
+func listIntegrationPods(ctx context.Context, c client.Client, integration *v1alpha1.Integration) ([]corev1.Pod, error) {
+	pods := corev1.PodList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+	}
+	options := k8sclient.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			"camel.apache.org/integration": integration.Name,
+		}),
+		Namespace: integration.Namespace,
+	}
+	if err := c.List(ctx, &options, &pods); err != nil {
+		return nil, err
+	}
+
+	result := make([]corev1.Pod, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Labels["camel.apache.org/integration"] == integration.Name {
+			result = append(result, pod)
+		}
+	}
+
+	return result, nil
+}
+
+// exposesRouteStatistics tells whether the deployment of the integration runs the Jolokia agent
+// the route statistics are read from
+func exposesRouteStatistics(ctx context.Context, c client.Client, integration *v1alpha1.Integration) bool {
+	deployment := appsv1.Deployment{}
+	key := k8sclient.ObjectKey{
+		Namespace: integration.Namespace,
+		Name:      integration.Name,
+	}
+	if err := c.Get(ctx, key, &deployment); err != nil {
+		return false
+	}
+
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		for _, p := range container.Ports {
+			if p.Name == "jolokia" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestUpdateRouteStatistics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/jolokia/", r.URL.Path)
+		_, _ = w.Write([]byte(`{"status": 200, "value": {"org.apache.camel:context=camel-k,type=routes,name=\"route1\"": {
+			"RouteId": "route1", "State": "Started", "ExchangesCompleted": 5, "ExchangesFailed": 1, "MeanProcessingTime": 3}}}`))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	assert.Nil(t, err)
+	host, p, err := net.SplitHostPort(u.Host)
+	assert.Nil(t, err)
+	port, err := strconv.Atoi(p)
+	assert.Nil(t, err)
+
+	// the pods that aren't ready are not polled
+	starting := createTestPod("pod-3", host, int32(port))
+	starting.Status.Conditions[0].Status = corev1.ConditionFalse
+
+	c, err := test.NewFakeClient(
+		createTestPod("pod-1", host, int32(port)),
+		createTestPod("pod-2", host, int32(port)),
+		starting,
+	)
+	assert.Nil(t, err)
+
+	action := monitorAction{}
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	assert.Nil(t, action.updateRouteStatistics(context.TODO(), &integration))
+	assert.NotNil(t, integration.Status.RoutesUpdateTime)
+	assert.Equal(t, []v1alpha1.RouteStatus{
+		{ID: "route1", State: "Started", ExchangesCompleted: 10, ExchangesFailed: 2, MeanProcessingTime: 3},
+	}, integration.Status.Routes)
+
+	// pods without the Jolokia agent leave the status untouched
+	other := v1alpha1.NewIntegration("ns", "other")
+	assert.Nil(t, action.updateRouteStatistics(context.TODO(), &other))
+	assert.Nil(t, other.Status.RoutesUpdateTime)
+}
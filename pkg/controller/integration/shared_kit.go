@@ -0,0 +1,184 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/audit"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// useSharedKit looks up the kit required by the integration in the shared kit namespace of the platform, if any,
+// and copies it to the integration namespace once it's built. The kit is created in the shared namespace when
+// missing, the integration waiting for it to be built. It returns false when the kit has to be built locally.
+func (action *buildKitAction) useSharedKit(ctx context.Context, integration *v1alpha1.Integration, name string) (bool, error) {
+	pl, err := platform.GetCurrentPlatform(ctx, action.client, integration.Namespace)
+	if err != nil {
+		return false, err
+	}
+
+	shared := pl.Spec.Build.SharedKits
+	if shared == nil || shared.Namespace == "" || shared.Namespace == integration.Namespace {
+		return false, nil
+	}
+
+	kit, err := action.lookupElectedKit(ctx, shared.Namespace, name)
+	if err != nil {
+		return false, err
+	}
+
+	if kit == nil {
+		kit, err = newPlatformKit(ctx, action.client, integration, shared.Namespace, name)
+		if err != nil {
+			return false, err
+		}
+		if err := action.client.Create(ctx, kit); err != nil && !k8serrors.IsAlreadyExists(err) {
+			return false, err
+		} else if err == nil {
+			audit.Record("IntegrationKit created", v1alpha1.IntegrationKindKind, kit.ObjectMeta)
+		}
+
+		return true, action.waitForSharedKit(ctx, integration, kit)
+	}
+
+	// The kit is built locally if the shared one cannot be used, e.g. its build failed
+	if !canAdoptKit(kit, integration) {
+		return false, nil
+	}
+	if kit.Status.Phase != v1alpha1.IntegrationKitPhaseReady {
+		return true, action.waitForSharedKit(ctx, integration, kit)
+	}
+
+	if err := action.propagatePullSecret(ctx, integration, shared); err != nil {
+		return false, err
+	}
+
+	// The shared kit is copied in the integration namespace, the copy using the shared image in place of a build
+	local, err := newPlatformKit(ctx, action.client, integration, integration.Namespace, name)
+	if err != nil {
+		return false, err
+	}
+	local.Spec.Image = kit.Status.Image
+	local.Annotations[v1alpha1.IntegrationKitSharedFromAnnotation] = kit.Namespace + "/" + kit.Name
+
+	if err := action.client.Create(ctx, local); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return false, err
+	}
+
+	return true, action.adoptKit(ctx, integration, local)
+}
+
+// waitForSharedKit reports that the integration waits for the shared kit, the integration being requeued
+// as the kits of other namespaces are not watched
+func (action *buildKitAction) waitForSharedKit(ctx context.Context, integration *v1alpha1.Integration, kit *v1alpha1.IntegrationKit) error {
+	condition := v1alpha1.Condition{
+		Type:    v1alpha1.ConditionWaitingForSharedKit,
+		Status:  corev1.ConditionTrue,
+		Reason:  "SharedKitNotReady",
+		Message: fmt.Sprintf("waiting for the shared integration kit %s/%s", kit.Namespace, kit.Name),
+	}
+	if c := v1alpha1.GetCondition(integration.Status.Conditions, condition.Type); c != nil && c.Message == condition.Message {
+		return nil
+	}
+
+	action.L.Info("Waiting for shared integration kit", "namespace", kit.Namespace, "kit", kit.Name)
+
+	target := integration.DeepCopy()
+	target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, condition)
+
+	return action.client.Status().Update(ctx, target)
+}
+
+// propagatePullSecret copies the secret granting access to the shared kit images in the integration namespace,
+// and adds it to the image pull secrets of the service account the integration runs with, as the pod templates
+// of Knative services cannot reference image pull secrets
+func (action *buildKitAction) propagatePullSecret(ctx context.Context, integration *v1alpha1.Integration, shared *v1alpha1.IntegrationPlatformSharedKitsSpec) error {
+	if shared.PullSecret == "" {
+		return nil
+	}
+
+	secret := corev1.Secret{}
+	key := k8sclient.ObjectKey{
+		Namespace: shared.Namespace,
+		Name:      shared.PullSecret,
+	}
+	if err := action.client.Get(ctx, key, &secret); err != nil {
+		return errors.Wrapf(err, "cannot get the shared kit pull secret %s/%s", shared.Namespace, shared.PullSecret)
+	}
+
+	copied := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: integration.Namespace,
+			Name:      secret.Name,
+			Annotations: map[string]string{
+				v1alpha1.IntegrationKitSharedFromAnnotation: secret.Namespace + "/" + secret.Name,
+			},
+		},
+		Type: secret.Type,
+		Data: secret.Data,
+	}
+	if err := kubernetes.ReplaceResource(ctx, action.client, &copied); err != nil {
+		return errors.Wrap(err, "cannot propagate the shared kit pull secret")
+	}
+
+	return linkPullSecret(ctx, action.client, integration, secret.Name)
+}
+
+// linkPullSecret adds the secret to the image pull secrets of the service account the integration runs with,
+// service accounts created by the service-account trait linking the secret on their own
+func linkPullSecret(ctx context.Context, c k8sclient.Client, integration *v1alpha1.Integration, secret string) error {
+	name := integration.Spec.ServiceAccountName
+	if name == "" {
+		name = "default"
+	}
+
+	sa := corev1.ServiceAccount{}
+	key := k8sclient.ObjectKey{
+		Namespace: integration.Namespace,
+		Name:      name,
+	}
+	if err := c.Get(ctx, key, &sa); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	for _, ref := range sa.ImagePullSecrets {
+		if ref.Name == secret {
+			return nil
+		}
+	}
+
+	sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+	return c.Update(ctx, &sa)
+}
@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/digest"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createSharedKitTestEnv(t *testing.T, objects ...runtime.Object) (client.Client, *buildKitAction) {
+	pl := createTestPlatform()
+	pl.Spec.Build.SharedKits = &v1alpha1.IntegrationPlatformSharedKitsSpec{
+		Namespace:  "kits",
+		PullSecret: "kits-pull-secret",
+	}
+	return createBuildKitTestEnv(t, pl, objects...)
+}
+
+func createSharedTestKit(t *testing.T, integration *v1alpha1.Integration, phase v1alpha1.IntegrationKitPhase) *v1alpha1.IntegrationKit {
+	kitDigest, err := digest.ComputeForPlatformKit(integration)
+	assert.Nil(t, err)
+
+	kit := v1alpha1.NewIntegrationKit("kits", "kit-"+kitDigest)
+	kit.Labels = map[string]string{
+		"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypePlatform,
+	}
+	kit.Spec.Dependencies = integration.Status.Dependencies
+	kit.Status.Phase = phase
+	kit.Status.Image = "registry/kits/camel-k-kits:1234"
+	return &kit
+}
+
+func TestSharedKitCreatedInSharedNamespace(t *testing.T) {
+	integration := createBuildKitTestIntegration("it-1", "camel:core")
+
+	c, action := createSharedKitTestEnv(t, integration)
+
+	assert.Nil(t, action.Handle(context.TODO(), integration))
+
+	kits := v1alpha1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "kits"}, &kits))
+	assert.Len(t, kits.Items, 1)
+	assert.Equal(t, "it-1", kits.Items[0].Labels["camel.apache.org/kit.created.by.name"])
+
+	kits = v1alpha1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "ns"}, &kits))
+	assert.Len(t, kits.Items, 0)
+
+	target := v1alpha1.NewIntegration("ns", "it-1")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "it-1"}, &target))
+	assert.Empty(t, target.Status.Kit)
+	assert.NotNil(t, v1alpha1.GetCondition(target.Status.Conditions, v1alpha1.ConditionWaitingForSharedKit))
+}
+
+func TestSharedKitCopiedOnceReady(t *testing.T) {
+	integration := createBuildKitTestIntegration("it-1", "camel:core")
+	integration.Status.Conditions = []v1alpha1.Condition{
+		{Type: v1alpha1.ConditionWaitingForSharedKit, Status: corev1.ConditionTrue},
+	}
+	shared := createSharedTestKit(t, integration, v1alpha1.IntegrationKitPhaseReady)
+
+	secret := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "kits",
+			Name:      "kits-pull-secret",
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: []byte("{}"),
+		},
+	}
+	sa := corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "default",
+		},
+	}
+
+	c, action := createSharedKitTestEnv(t, integration, shared, &secret, &sa)
+
+	assert.Nil(t, action.Handle(context.TODO(), integration))
+
+	local := v1alpha1.NewIntegrationKit("ns", shared.Name)
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: shared.Name}, &local))
+	assert.Equal(t, shared.Status.Image, local.Spec.Image)
+	assert.Equal(t, "kits/"+shared.Name, local.Annotations[v1alpha1.IntegrationKitSharedFromAnnotation])
+
+	target := v1alpha1.NewIntegration("ns", "it-1")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "it-1"}, &target))
+	assert.Equal(t, shared.Name, target.Status.Kit)
+	assert.Nil(t, v1alpha1.GetCondition(target.Status.Conditions, v1alpha1.ConditionWaitingForSharedKit))
+
+	copied := corev1.Secret{}
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "kits-pull-secret"}, &copied))
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, copied.Type)
+	assert.Equal(t, secret.Data, copied.Data)
+
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "default"}, &sa))
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "kits-pull-secret"}}, sa.ImagePullSecrets)
+}
+
+func TestSharedKitFailedBuiltLocally(t *testing.T) {
+	integration := createBuildKitTestIntegration("it-1", "camel:core")
+	shared := createSharedTestKit(t, integration, v1alpha1.IntegrationKitPhaseError)
+
+	c, action := createSharedKitTestEnv(t, integration, shared)
+
+	assert.Nil(t, action.Handle(context.TODO(), integration))
+
+	local := v1alpha1.NewIntegrationKit("ns", shared.Name)
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: shared.Name}, &local))
+	assert.Empty(t, local.Spec.Image)
+	assert.Empty(t, local.Annotations[v1alpha1.IntegrationKitSharedFromAnnotation])
+}
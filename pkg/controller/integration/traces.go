@@ -0,0 +1,188 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/jolokia"
+	"github.com/apache/camel-k/pkg/util/masking"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// traceCollectionTimeout bounds the time spent collecting the traced messages of a pod
+const traceCollectionTimeout = 5 * time.Second
+
+// collectTraces drains the messages sampled by the backlog tracer of the integration pods, when the tracing trait
+// is enabled, and appends them masked to the integration traces config map, that keeps the most recent ones
+func (action *monitorAction) collectTraces(ctx context.Context, integration *v1alpha1.Integration) error {
+	deployment := appsv1.Deployment{}
+	key := k8sclient.ObjectKey{
+		Namespace: integration.Namespace,
+		Name:      integration.Name,
+	}
+	if err := action.client.Get(ctx, key, &deployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	value, ok := deployment.Annotations[v1alpha1.IntegrationTraceBacklogSizeAnnotation]
+	if !ok {
+		return nil
+	}
+	backlogSize, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	options := jolokia.TracerOptions{
+		Pattern:     deployment.Annotations[v1alpha1.IntegrationTraceRoutesAnnotation],
+		Filter:      deployment.Annotations[v1alpha1.IntegrationTraceFilterAnnotation],
+		BacklogSize: backlogSize,
+	}
+	if value, ok := deployment.Annotations[v1alpha1.IntegrationTraceBodyMaxCharsAnnotation]; ok {
+		if options.BodyMaxChars, err = strconv.Atoi(value); err != nil {
+			return err
+		}
+	}
+
+	var rules *v1alpha1.MaskingSpec
+	if value, ok := deployment.Annotations[v1alpha1.IntegrationTraceMaskingAnnotation]; ok {
+		rules = &v1alpha1.MaskingSpec{}
+		if err := json.Unmarshal([]byte(value), rules); err != nil {
+			return err
+		}
+	}
+	masker, err := masking.NewMasker(rules)
+	if err != nil {
+		return err
+	}
+
+	pods, err := listIntegrationPods(ctx, action.client, integration)
+	if err != nil {
+		return err
+	}
+
+	collected := make([]jolokia.TracedMessage, 0)
+	for _, pod := range pods {
+		endpoint := jolokia.Endpoint(pod)
+		if endpoint == "" {
+			continue
+		}
+
+		podCtx, cancel := context.WithTimeout(ctx, traceCollectionTimeout)
+		messages, err := jolokia.CollectTraces(podCtx, endpoint, options)
+		cancel()
+		if err != nil {
+			action.L.Info("Cannot collect traced messages", "pod", pod.Name, "error", err.Error())
+			continue
+		}
+		for _, m := range messages {
+			m.Pod = pod.Name
+			m.Body = masker.Mask(m.Body)
+			for name, value := range m.Headers {
+				m.Headers[name] = masker.MaskHeader(name, value)
+			}
+			collected = append(collected, m)
+		}
+	}
+
+	if len(collected) == 0 {
+		return nil
+	}
+
+	return action.appendTraces(ctx, integration, collected, backlogSize)
+}
+
+// appendTraces stores the collected messages in the integration traces config map, discarding the oldest ones
+// beyond the backlog size. The config map isn't a generated resource, so it's owned by the integration and
+// lives until the integration is deleted
+func (action *monitorAction) appendTraces(ctx context.Context, integration *v1alpha1.Integration, collected []jolokia.TracedMessage, backlogSize int) error {
+	cm := corev1.ConfigMap{}
+	key := k8sclient.ObjectKey{
+		Namespace: integration.Namespace,
+		Name:      integration.TracesConfigMapName(),
+	}
+
+	create := false
+	if err := action.client.Get(ctx, key, &cm); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		create = true
+		cm = corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: corev1.SchemeGroupVersion.String(),
+				Kind:       "ConfigMap",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: integration.Namespace,
+				Name:      integration.TracesConfigMapName(),
+				Labels: map[string]string{
+					"camel.apache.org/integration": integration.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{
+						APIVersion: v1alpha1.SchemeGroupVersion.String(),
+						Kind:       v1alpha1.IntegrationKind,
+						Name:       integration.Name,
+						UID:        integration.UID,
+					},
+				},
+			},
+		}
+	}
+
+	traces := make([]jolokia.TracedMessage, 0)
+	if data, ok := cm.Data[v1alpha1.IntegrationTracesKey]; ok && data != "" {
+		if err := json.Unmarshal([]byte(data), &traces); err != nil {
+			action.L.Info("Discarding invalid traced messages", "configmap", cm.Name, "error", err.Error())
+			traces = traces[:0]
+		}
+	}
+
+	traces = append(traces, collected...)
+	if len(traces) > backlogSize {
+		traces = traces[len(traces)-backlogSize:]
+	}
+
+	data, err := json.Marshal(traces)
+	if err != nil {
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = make(map[string]string)
+	}
+	cm.Data[v1alpha1.IntegrationTracesKey] = string(data)
+
+	if create {
+		return action.client.Create(ctx, &cm)
+	}
+	return action.client.Update(ctx, &cm)
+}
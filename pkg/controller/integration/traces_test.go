@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/jolokia"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestCollectTraces(t *testing.T) {
+	uid := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests := make([]map[string]interface{}, 0)
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&requests))
+
+		uid++
+		dump, err := json.Marshal(`<backlogTracerEventMessages><backlogTracerEventMessage><uid>` + strconv.Itoa(uid) +
+			`</uid><routeId>orders</routeId><toNode>log1</toNode><exchangeId>ID-1</exchangeId>` +
+			`<message><headers><header key="Authorization">Bearer xyz</header></headers><body>hello 1234</body></message></backlogTracerEventMessage></backlogTracerEventMessages>`)
+		assert.Nil(t, err)
+
+		responses := "["
+		for i := 0; i < len(requests)-1; i++ {
+			responses += `{"status": 200}, `
+		}
+		_, _ = w.Write([]byte(responses + `{"status": 200, "value": ` + string(dump) + `}]`))
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	assert.Nil(t, err)
+	host, p, err := net.SplitHostPort(u.Host)
+	assert.Nil(t, err)
+	port, err := strconv.Atoi(p)
+	assert.Nil(t, err)
+
+	deployment := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+			Annotations: map[string]string{
+				v1alpha1.IntegrationTraceBacklogSizeAnnotation: "3",
+				v1alpha1.IntegrationTraceRoutesAnnotation:      "orders",
+				v1alpha1.IntegrationTraceMaskingAnnotation:     `{"patterns": ["\\d+"], "headers": ["authorization"]}`,
+			},
+		},
+	}
+
+	c, err := test.NewFakeClient(
+		&deployment,
+		createTestPod("pod-1", host, int32(port)),
+		createTestPod("pod-2", host, int32(port)),
+	)
+	assert.Nil(t, err)
+
+	action := monitorAction{}
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	assert.Nil(t, action.collectTraces(context.TODO(), &integration))
+	assert.Nil(t, action.collectTraces(context.TODO(), &integration))
+
+	cm := corev1.ConfigMap{}
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "my-integration-traces"}, &cm))
+	assert.Equal(t, "my-integration", cm.Labels["camel.apache.org/integration"])
+	assert.Len(t, cm.OwnerReferences, 1)
+
+	// only the most recent messages are kept
+	traces := make([]jolokia.TracedMessage, 0)
+	assert.Nil(t, json.Unmarshal([]byte(cm.Data[v1alpha1.IntegrationTracesKey]), &traces))
+	assert.Len(t, traces, 3)
+	assert.Equal(t, int64(2), traces[0].UID)
+	assert.Equal(t, int64(4), traces[2].UID)
+	assert.Equal(t, "pod-2", traces[2].Pod)
+	assert.Equal(t, "hello *****", traces[2].Body)
+	assert.Equal(t, "*****", traces[2].Headers["Authorization"])
+
+	// integrations without the tracing trait aren't traced
+	other := v1alpha1.NewIntegration("ns", "other")
+	assert.Nil(t, action.collectTraces(context.TODO(), &other))
+	assert.NotNil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "other-traces"}, &cm))
+}
@@ -35,8 +35,11 @@ var allowedLookupLabels = map[string]bool{
 
 // LookupKitForIntegration --
 func LookupKitForIntegration(ctx context.Context, c k8sclient.Reader, integration *v1alpha1.Integration) (*v1alpha1.IntegrationKit, error) {
-	if integration.Status.Kit != "" {
+	if integration.Status.Kit != "" || integration.IsKitPinned() {
 		name := integration.Status.Kit
+		if integration.IsKitPinned() {
+			name = integration.Spec.Kit
+		}
 		kit := v1alpha1.NewIntegrationKit(integration.Namespace, name)
 		key := k8sclient.ObjectKey{
 			Namespace: integration.Namespace,
@@ -57,7 +60,7 @@ func LookupKitForIntegration(ctx context.Context, c k8sclient.Reader, integratio
 	for _, ctx := range ctxList.Items {
 		ctx := ctx // pin
 
-		if ctx.Status.Phase == v1alpha1.IntegrationKitPhaseError {
+		if ctx.Status.Phase == v1alpha1.IntegrationKitPhaseError || ctx.IsInvalidated() {
 			continue
 		}
 		if ctx.Status.CamelVersion != integration.Status.CamelVersion {
@@ -100,6 +103,29 @@ func LookupKitForIntegration(ctx context.Context, c k8sclient.Reader, integratio
 	return nil, nil
 }
 
+// LookupLastKitCreatedForIntegration returns the most recent kit that has been created on behalf of the given integration
+func LookupLastKitCreatedForIntegration(ctx context.Context, c k8sclient.Reader, integration *v1alpha1.Integration) (*v1alpha1.IntegrationKit, error) {
+	ctxList := v1alpha1.NewIntegrationKitList()
+	if err := c.List(ctx, &k8sclient.ListOptions{Namespace: integration.Namespace}, &ctxList); err != nil {
+		return nil, err
+	}
+
+	var last *v1alpha1.IntegrationKit
+	for _, ctx := range ctxList.Items {
+		ctx := ctx // pin
+
+		if ctx.Labels["camel.apache.org/kit.created.by.kind"] != v1alpha1.IntegrationKind ||
+			ctx.Labels["camel.apache.org/kit.created.by.name"] != integration.Name {
+			continue
+		}
+		if last == nil || last.CreationTimestamp.Before(&ctx.CreationTimestamp) {
+			last = &ctx
+		}
+	}
+
+	return last, nil
+}
+
 // HasMatchingTraits compare traits defined on kit against those defined on integration.
 func HasMatchingTraits(kit *v1alpha1.IntegrationKit, integration *v1alpha1.Integration) bool {
 	for ctxTraitName, ctxTraitConf := range kit.Spec.Traits {
@@ -20,7 +20,10 @@ package integration
 import (
 	"context"
 	"testing"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
@@ -101,6 +104,58 @@ func TestLookupKitForIntegration_DiscardKitsInError(t *testing.T) {
 	assert.Equal(t, "my-kit-2", i.Name)
 }
 
+func TestLookupKitForIntegration_DiscardInvalidatedKits(t *testing.T) {
+	c, err := test.NewFakeClient(
+		&v1alpha1.IntegrationKit{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       v1alpha1.IntegrationKindKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "ns",
+				Name:      "my-kit-1",
+				Labels: map[string]string{
+					"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypePlatform,
+				},
+				Annotations: map[string]string{
+					v1alpha1.IntegrationKitInvalidatedAnnotation: "true",
+				},
+			},
+			Spec: v1alpha1.IntegrationKitSpec{
+				Dependencies: []string{
+					"camel-core",
+					"camel-irc",
+				},
+			},
+			Status: v1alpha1.IntegrationKitStatus{
+				Phase: v1alpha1.IntegrationKitPhaseReady,
+			},
+		},
+	)
+
+	assert.Nil(t, err)
+
+	i, err := LookupKitForIntegration(context.TODO(), c, &v1alpha1.Integration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.IntegrationKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Status: v1alpha1.IntegrationStatus{
+			Dependencies: []string{
+				"camel-core",
+				"camel-irc",
+			},
+		},
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, i)
+}
+
 func TestLookupKitForIntegration_DiscardKitsWithIncompatibleTraits(t *testing.T) {
 	c, err := test.NewFakeClient(
 		//
@@ -289,3 +344,96 @@ func TestLookupKitForIntegration_DiscardKitsWithIncompatibleTraits(t *testing.T)
 	assert.NotNil(t, i)
 	assert.Equal(t, "my-kit-4", i.Name)
 }
+
+func TestLookupLastKitCreatedForIntegration(t *testing.T) {
+	newKit := func(name string, owner string, created time.Time) *v1alpha1.IntegrationKit {
+		kit := v1alpha1.NewIntegrationKit("ns", name)
+		kit.CreationTimestamp = metav1.NewTime(created)
+		kit.Labels = map[string]string{
+			"camel.apache.org/kit.type":            v1alpha1.IntegrationKitTypePlatform,
+			"camel.apache.org/kit.created.by.kind": v1alpha1.IntegrationKind,
+			"camel.apache.org/kit.created.by.name": owner,
+		}
+		return &kit
+	}
+
+	now := time.Now()
+	c, err := test.NewFakeClient(
+		newKit("my-kit-1", "my-integration", now.Add(-2*time.Hour)),
+		newKit("my-kit-2", "my-integration", now.Add(-time.Hour)),
+		newKit("my-kit-3", "other-integration", now),
+	)
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	kit, err := LookupLastKitCreatedForIntegration(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.NotNil(t, kit)
+	assert.Equal(t, "my-kit-2", kit.Name)
+
+	integration = v1alpha1.NewIntegration("ns", "new-integration")
+	kit, err = LookupLastKitCreatedForIntegration(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.Nil(t, kit)
+}
+
+func createTestDeployment(image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "my-integration", Image: image},
+					},
+				},
+			},
+		},
+	}
+}
+
+func createTestPod(name string, ip string, port int32) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      name,
+			Labels: map[string]string{
+				"camel.apache.org/integration": "my-integration",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "my-integration",
+					Ports: []corev1.ContainerPort{
+						{Name: "jolokia", ContainerPort: port},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: ip,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+}
+
+func createTestPlatform() *v1alpha1.IntegrationPlatform {
+	pl := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	pl.Status.Phase = v1alpha1.IntegrationPlatformPhaseReady
+	return &pl
+}
@@ -0,0 +1,211 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util"
+
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// containerFailureReasons are the waiting reasons of the integration containers that cannot
+// start without an external change
+var containerFailureReasons = []string{
+	"CrashLoopBackOff",
+	"ErrImagePull",
+	"ImagePullBackOff",
+	"CreateContainerConfigError",
+	"CreateContainerError",
+	"InvalidImageName",
+}
+
+// workloadFailure is a failure of a resource generated for the integration
+type workloadFailure struct {
+	resource string
+	reason   string
+	message  string
+}
+
+func (f workloadFailure) String() string {
+	if f.message == "" {
+		return fmt.Sprintf("%s %s", f.resource, f.reason)
+	}
+	return fmt.Sprintf("%s %s: %s", f.resource, f.reason, f.message)
+}
+
+// checkWorkload reports why the deployment, the pods or the Knative service of the integration
+// cannot run, e.g. because the image cannot be pulled, returning nil if none is failing
+func checkWorkload(ctx context.Context, c client.Client, integration *v1alpha1.Integration) (*v1alpha1.Condition, error) {
+	failures := make([]workloadFailure, 0)
+
+	deployment, err := deploymentFailures(ctx, c, integration)
+	if err != nil {
+		return nil, err
+	}
+	failures = append(failures, deployment...)
+
+	pods, err := podFailures(ctx, c, integration)
+	if err != nil {
+		return nil, err
+	}
+	failures = append(failures, pods...)
+
+	service, err := knativeServiceFailures(ctx, c, integration)
+	if err != nil {
+		return nil, err
+	}
+	failures = append(failures, service...)
+
+	if len(failures) == 0 {
+		return nil, nil
+	}
+
+	details := make([]string, 0, len(failures))
+	for _, f := range failures {
+		details = append(details, f.String())
+	}
+
+	return &v1alpha1.Condition{
+		Type:    v1alpha1.ConditionDeploymentFailed,
+		Status:  corev1.ConditionTrue,
+		Reason:  failures[0].reason,
+		Message: strings.Join(details, ", "),
+	}, nil
+}
+
+// deploymentFailures returns the replicas that cannot be created, e.g. because of a quota, and
+// the rollouts that exceeded their progress deadline
+func deploymentFailures(ctx context.Context, c client.Client, integration *v1alpha1.Integration) ([]workloadFailure, error) {
+	deployment := appsv1.Deployment{}
+	key := k8sclient.ObjectKey{
+		Namespace: integration.Namespace,
+		Name:      integration.Name,
+	}
+	if err := c.Get(ctx, key, &deployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	failures := make([]workloadFailure, 0)
+	for _, condition := range deployment.Status.Conditions {
+		failed := condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue ||
+			condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse
+		if failed {
+			failures = append(failures, workloadFailure{
+				resource: "deployment/" + deployment.Name,
+				reason:   condition.Reason,
+				message:  condition.Message,
+			})
+		}
+	}
+
+	return failures, nil
+}
+
+// podFailures returns the integration containers waiting on a failure, e.g. an image that cannot be
+// pulled, the init containers being reported by checkInitContainers
+func podFailures(ctx context.Context, c client.Client, integration *v1alpha1.Integration) ([]workloadFailure, error) {
+	pods := corev1.PodList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+	}
+	options := k8sclient.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			"camel.apache.org/integration": integration.Name,
+		}),
+		Namespace: integration.Namespace,
+	}
+	if err := c.List(ctx, &options, &pods); err != nil {
+		return nil, err
+	}
+
+	failures := make([]workloadFailure, 0)
+	for _, pod := range pods.Items {
+		if pod.Labels["camel.apache.org/integration"] != integration.Name {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			w := status.State.Waiting
+			if w == nil || !util.StringSliceExists(containerFailureReasons, w.Reason) {
+				continue
+			}
+
+			message := w.Message
+			if w.Reason == "CrashLoopBackOff" {
+				// the back-off message does not tell why the container terminated
+				message = terminationReason(status.LastTerminationState.Terminated)
+			}
+
+			failures = append(failures, workloadFailure{
+				resource: fmt.Sprintf("pod/%s/%s", pod.Name, status.Name),
+				reason:   w.Reason,
+				message:  message,
+			})
+		}
+	}
+
+	return failures, nil
+}
+
+// knativeServiceFailures returns the failure of the latest revision of the Knative service of
+// the integration, if deployed as such
+func knativeServiceFailures(ctx context.Context, c client.Client, integration *v1alpha1.Integration) ([]workloadFailure, error) {
+	service := serving.Service{}
+	key := k8sclient.ObjectKey{
+		Namespace: integration.Namespace,
+		Name:      integration.Name,
+	}
+	if err := c.Get(ctx, key, &service); err != nil {
+		// Knative may not be installed
+		if k8serrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	ready := service.Status.GetCondition(serving.ServiceConditionReady)
+	if ready == nil || ready.Status != corev1.ConditionFalse {
+		return nil, nil
+	}
+
+	return []workloadFailure{
+		{
+			resource: "ksvc/" + service.Name,
+			reason:   ready.Reason,
+			message:  ready.Message,
+		},
+	}, nil
+}
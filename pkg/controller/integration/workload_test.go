@@ -0,0 +1,142 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	duckv1alpha1 "github.com/knative/pkg/apis/duck/v1alpha1"
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCheckWorkload(t *testing.T) {
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{
+				{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionFalse, Reason: "MinimumReplicasUnavailable"},
+				{Type: appsv1.DeploymentReplicaFailure, Status: corev1.ConditionTrue, Reason: "FailedCreate", Message: "exceeded quota"},
+			},
+		},
+	}
+	pulling := createTestPod("pod-1", "10.0.0.1", 8778)
+	pulling.Status.Phase = corev1.PodPending
+	pulling.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: "my-integration",
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image \"my-image\""},
+			},
+		},
+	}
+	crashing := createTestPod("pod-2", "10.0.0.2", 8778)
+	crashing.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: "my-integration",
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 5m0s restarting failed container"},
+			},
+			LastTerminationState: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Reason:   "Error",
+					Message:  "Caused by: java.net.UnknownHostException: broker",
+				},
+			},
+		},
+	}
+	starting := createTestPod("pod-3", "10.0.0.3", 8778)
+	starting.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: "my-integration",
+			State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "ContainerCreating"},
+			},
+		},
+	}
+
+	c, err := test.NewFakeClient(deployment, pulling, crashing, starting)
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	condition, err := checkWorkload(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.NotNil(t, condition)
+	assert.Equal(t, v1alpha1.ConditionDeploymentFailed, condition.Type)
+	assert.Equal(t, "FailedCreate", condition.Reason)
+	assert.Equal(t, "deployment/my-integration FailedCreate: exceeded quota, "+
+		"pod/pod-1/my-integration ImagePullBackOff: Back-off pulling image \"my-image\", "+
+		"pod/pod-2/my-integration CrashLoopBackOff: java.net.UnknownHostException: broker", condition.Message)
+
+	other := v1alpha1.NewIntegration("ns", "other")
+	condition, err = checkWorkload(context.TODO(), c, &other)
+	assert.Nil(t, err)
+	assert.Nil(t, condition)
+}
+
+func TestCheckWorkloadKnativeService(t *testing.T) {
+	service := &serving.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: serving.SchemeGroupVersion.String(),
+			Kind:       "Service",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Status: serving.ServiceStatus{
+			Conditions: duckv1alpha1.Conditions{
+				{
+					Type:    serving.ServiceConditionReady,
+					Status:  corev1.ConditionFalse,
+					Reason:  "RevisionFailed",
+					Message: "Revision \"my-integration-00001\" failed with message: Unable to fetch image \"my-image\"",
+				},
+			},
+		},
+	}
+
+	c, err := test.NewFakeClient(service)
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	condition, err := checkWorkload(context.TODO(), c, &integration)
+	assert.Nil(t, err)
+	assert.NotNil(t, condition)
+	assert.Equal(t, "RevisionFailed", condition.Reason)
+	assert.Equal(t, "ksvc/my-integration RevisionFailed: Revision \"my-integration-00001\" failed with message: "+
+		"Unable to fetch image \"my-image\"", condition.Message)
+	assert.True(t, awaitsPods(context.TODO(), c, &integration))
+}
@@ -32,16 +32,25 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
 	"github.com/apache/camel-k/pkg/trait"
+	"github.com/apache/camel-k/pkg/util/audit"
+
+	"github.com/scylladb/go-set/strset"
 )
 
-// NewBuildAction creates a new build request handling action for the kit
-func NewBuildAction() Action {
-	return &buildAction{}
+// NewBuildAction creates a new build request handling action for the kit, using the given client to access
+// the builds running in a dedicated build namespace, that are out of the operator cache
+func NewBuildAction(buildClient client.Client) Action {
+	return &buildAction{
+		buildClient: buildClient,
+	}
 }
 
 type buildAction struct {
 	baseAction
+	buildClient client.Client
 }
 
 func (action *buildAction) Name() string {
@@ -63,9 +72,34 @@ func (action *buildAction) Handle(ctx context.Context, kit *v1alpha1.Integration
 	return nil
 }
 
+// lookupBuild returns the key of the build of the kit, and the client to access it
+func (action *buildAction) lookupBuild(ctx context.Context, kit *v1alpha1.IntegrationKit) (types.NamespacedName, client.Client, error) {
+	pl, err := platform.GetCurrentPlatform(ctx, action.client, kit.Namespace)
+	if err != nil {
+		return types.NamespacedName{}, nil, err
+	}
+
+	namespace := pl.Spec.Build.BuildNamespace(kit.Namespace)
+	key := types.NamespacedName{
+		Namespace: namespace,
+		Name:      v1alpha1.BuildName(kit.Namespace, kit.Name, namespace),
+	}
+
+	if namespace != kit.Namespace {
+		return key, action.buildClient, nil
+	}
+
+	return key, action.client, nil
+}
+
 func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1alpha1.IntegrationKit) error {
+	key, c, err := action.lookupBuild(ctx, kit)
+	if err != nil {
+		return err
+	}
+
 	build := &v1alpha1.Build{}
-	err := action.client.Get(ctx, types.NamespacedName{Namespace: kit.Namespace, Name: kit.Name}, build)
+	err = c.Get(ctx, key, build)
 	if err != nil && !k8serrors.IsNotFound(err) {
 		return err
 	}
@@ -84,14 +118,35 @@ func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1alph
 			return errors.New("undefined camel catalog")
 		}
 
+		steps := env.Steps
+		incremental := kit.Annotations[v1alpha1.BuildIncrementalAnnotation] != "false"
+
+		var baseKit *v1alpha1.BuildBaseKit
+		if hasStep(steps, builder.Steps.IncrementalPackager) {
+			if incremental {
+				baseKit, err = action.lookupBaseKit(ctx, kit, env)
+				if err != nil {
+					return err
+				}
+			}
+
+			// the base kit is resolved by the operator as the builder may not access the kits of the namespace,
+			// e.g. when running in a dedicated build namespace
+			if baseKit != nil {
+				steps = builder.ReplaceStep(steps, builder.Steps.IncrementalPackager, builder.Steps.BaseKitPackager)
+			} else if !incremental {
+				steps = builder.ReplaceStep(steps, builder.Steps.IncrementalPackager, builder.Steps.StandardPackager)
+			}
+		}
+
 		build = &v1alpha1.Build{
 			TypeMeta: metav1.TypeMeta{
 				APIVersion: "camel.apache.org/v1alpha1",
 				Kind:       "Build",
 			},
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: kit.Namespace,
-				Name:      kit.Name,
+				Namespace: key.Namespace,
+				Name:      key.Name,
 			},
 			Spec: v1alpha1.BuildSpec{
 				Meta:           kit.ObjectMeta,
@@ -99,25 +154,40 @@ func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1alph
 				RuntimeVersion: env.RuntimeVersion,
 				Platform:       env.Platform.Spec,
 				Dependencies:   kit.Spec.Dependencies,
-				Steps:          builder.StepIDsFor(env.Steps...),
+				Steps:          builder.StepIDsFor(steps...),
 				BuildDir:       env.BuildDir,
+				Priority:       v1alpha1.BuildPriority(kit.Annotations[v1alpha1.BuildPriorityAnnotation]),
+				Incremental:    &incremental,
+				BaseKit:        baseKit,
 			},
 		}
 
-		// Set the integration kit instance as the owner and controller
-		if err := controllerutil.SetControllerReference(kit, build, action.client.GetScheme()); err != nil {
-			return err
+		// Propagate who and what caused the kit to be built
+		audit.CopyAnnotations(kit.ObjectMeta, &build.ObjectMeta)
+
+		// Set the integration kit instance as the owner and controller, owner references
+		// across namespaces are not supported so builds running in a dedicated namespace
+		// are deleted by the operator along with the kit
+		if build.Namespace == kit.Namespace {
+			if err := controllerutil.SetControllerReference(kit, build, action.client.GetScheme()); err != nil {
+				return err
+			}
 		}
 
-		err = action.client.Delete(ctx, build)
+		err = c.Delete(ctx, build)
 		if err != nil && !k8serrors.IsNotFound(err) {
 			return errors.Wrap(err, "cannot delete build")
 		}
 
-		err = action.client.Create(ctx, build)
+		err = c.Create(ctx, build)
 		if err != nil {
 			return errors.Wrap(err, "cannot create build")
 		}
+
+		audit.Record("Build submitted", v1alpha1.BuildKind, build.ObjectMeta, "kit", kit.Name)
+		if baseKit != nil {
+			action.L.Info("Build layered on top of an existing kit", "base", baseKit.Name)
+		}
 	}
 
 	if build.Status.Phase == v1alpha1.BuildPhaseRunning {
@@ -133,8 +203,13 @@ func (action *buildAction) handleBuildSubmitted(ctx context.Context, kit *v1alph
 }
 
 func (action *buildAction) handleBuildRunning(ctx context.Context, kit *v1alpha1.IntegrationKit) error {
+	key, c, err := action.lookupBuild(ctx, kit)
+	if err != nil {
+		return err
+	}
+
 	build := &v1alpha1.Build{}
-	err := action.client.Get(ctx, types.NamespacedName{Namespace: kit.Namespace, Name: kit.Name}, build)
+	err = c.Get(ctx, key, build)
 	if err != nil {
 		return err
 	}
@@ -160,6 +235,15 @@ func (action *buildAction) handleBuildRunning(ctx context.Context, kit *v1alpha1
 		target.Status.BaseImage = build.Status.BaseImage
 		target.Status.Image = build.Status.Image
 		target.Status.PublicImage = build.Status.PublicImage
+		target.Status.ImageStreamTag = build.Status.ImageStreamTag
+		target.Status.ImageSize = build.Status.ImageSize
+		target.Status.ImageLabels = build.Status.ImageLabels
+		if build.Spec.Platform.Build.ImageScan != nil {
+			// the image has been scanned as part of the build
+			target.Status.Vulnerabilities = build.Status.Vulnerabilities
+			now := metav1.Now()
+			target.Status.ScannedAt = &now
+		}
 		target.Status.Phase = v1alpha1.IntegrationKitPhaseReady
 		target.Status.Artifacts = make([]v1alpha1.Artifact, 0, len(build.Status.Artifacts))
 
@@ -169,9 +253,14 @@ func (action *buildAction) handleBuildRunning(ctx context.Context, kit *v1alpha1
 				ID:       a.ID,
 				Location: "",
 				Target:   a.Target,
+				Checksum: a.Checksum,
 			})
 		}
 
+		for _, w := range build.Status.Warnings {
+			action.L.Info("Build completed with warning", "warning", w)
+		}
+
 		action.L.Info("IntegrationKit state transition", "phase", target.Status.Phase)
 		if err := action.client.Status().Update(ctx, target); err != nil {
 			return err
@@ -199,7 +288,7 @@ func (action *buildAction) handleBuildRunning(ctx context.Context, kit *v1alpha1
 		target.Status.Failure = build.Status.Failure
 		target.Status.Phase = v1alpha1.IntegrationKitPhaseError
 
-		action.L.Error(fmt.Errorf(build.Status.Error), "IntegrationKit state transition", "phase", target.Status.Phase)
+		action.L.Error(errors.New(build.Status.Error), "IntegrationKit state transition", "phase", target.Status.Phase)
 
 		return action.client.Status().Update(ctx, target)
 	}
@@ -227,3 +316,65 @@ func (action *buildAction) informIntegrations(ctx context.Context, kit *v1alpha1
 	}
 	return nil
 }
+
+// lookupBaseKit returns the ready kit of the namespace that is the closest to the kit to build, if any
+func (action *buildAction) lookupBaseKit(ctx context.Context, kit *v1alpha1.IntegrationKit, env *trait.Environment) (*v1alpha1.BuildBaseKit, error) {
+	list := v1alpha1.NewIntegrationKitList()
+	if err := action.client.List(ctx, &k8sclient.ListOptions{Namespace: kit.Namespace}, &list); err != nil {
+		return nil, err
+	}
+
+	return closestKit(kit, list.Items, env.CamelCatalog.Version, env.RuntimeVersion), nil
+}
+
+// closestKit selects, among the given kits, the one sharing the most dependencies with the kit to build. Only kits
+// whose dependencies are all required by the kit qualify, so that no unwanted artifact ends up in the image
+func closestKit(kit *v1alpha1.IntegrationKit, kits []v1alpha1.IntegrationKit, camelVersion string, runtimeVersion string) *v1alpha1.BuildBaseKit {
+	dependencies := strset.New(kit.Spec.Dependencies...)
+
+	var closest *v1alpha1.IntegrationKit
+	for i := range kits {
+		candidate := &kits[i]
+
+		if candidate.Name == kit.Name || candidate.Status.Phase != v1alpha1.IntegrationKitPhaseReady || candidate.IsInvalidated() {
+			continue
+		}
+		if candidate.Status.Image == "" || len(candidate.Status.Artifacts) == 0 {
+			continue
+		}
+		if candidate.Status.CamelVersion != camelVersion || candidate.Status.RuntimeVersion != runtimeVersion {
+			continue
+		}
+		if kitType := candidate.Labels["camel.apache.org/kit.type"]; kitType != v1alpha1.IntegrationKitTypePlatform && kitType != v1alpha1.IntegrationKitTypeLibrary {
+			continue
+		}
+		if len(candidate.Spec.Dependencies) == 0 || !dependencies.Has(candidate.Spec.Dependencies...) {
+			continue
+		}
+
+		if closest == nil || len(candidate.Spec.Dependencies) > len(closest.Spec.Dependencies) {
+			closest = candidate
+		}
+	}
+
+	if closest == nil {
+		return nil
+	}
+
+	return &v1alpha1.BuildBaseKit{
+		Name:         closest.Name,
+		Image:        closest.Status.Image,
+		Dependencies: closest.Spec.Dependencies,
+		Artifacts:    closest.Status.Artifacts,
+	}
+}
+
+func hasStep(steps []builder.Step, step builder.Step) bool {
+	for _, s := range steps {
+		if s.ID() == step.ID() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationkit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+func newReadyKit(name string, kitType string, dependencies ...string) v1alpha1.IntegrationKit {
+	kit := v1alpha1.NewIntegrationKit("ns", name)
+	kit.Labels = map[string]string{
+		"camel.apache.org/kit.type": kitType,
+	}
+	kit.Spec.Dependencies = dependencies
+	kit.Status = v1alpha1.IntegrationKitStatus{
+		Phase:          v1alpha1.IntegrationKitPhaseReady,
+		Image:          name + "-image",
+		CamelVersion:   "2.23.2",
+		RuntimeVersion: "0.3.3",
+		Artifacts:      []v1alpha1.Artifact{{ID: "org.apache.camel:camel-core:jar:2.23.2"}},
+	}
+
+	return kit
+}
+
+func TestClosestKit(t *testing.T) {
+	kit := newReadyKit("kit", v1alpha1.IntegrationKitTypePlatform, "camel:core", "camel:kafka", "camel:http4", "runtime:jvm")
+	kit.Status = v1alpha1.IntegrationKitStatus{Phase: v1alpha1.IntegrationKitPhaseBuildSubmitted}
+
+	other := newReadyKit("other-version", v1alpha1.IntegrationKitTypePlatform, "camel:core", "camel:kafka", "camel:http4")
+	other.Status.CamelVersion = "2.23.1"
+	building := newReadyKit("building", v1alpha1.IntegrationKitTypePlatform, "camel:core", "camel:kafka", "camel:http4")
+	building.Status.Phase = v1alpha1.IntegrationKitPhaseBuildRunning
+
+	kits := []v1alpha1.IntegrationKit{
+		kit,
+		other,
+		building,
+		newReadyKit("user", v1alpha1.IntegrationKitTypeUser, "camel:core", "camel:kafka", "camel:http4"),
+		newReadyKit("surplus", v1alpha1.IntegrationKitTypePlatform, "camel:core", "camel:kafka", "camel:jms"),
+		newReadyKit("core", v1alpha1.IntegrationKitTypePlatform, "camel:core", "runtime:jvm"),
+		newReadyKit("kafka", v1alpha1.IntegrationKitTypeLibrary, "camel:core", "camel:kafka", "runtime:jvm"),
+	}
+
+	base := closestKit(&kit, kits, "2.23.2", "0.3.3")
+	assert.NotNil(t, base)
+	assert.Equal(t, "kafka", base.Name)
+	assert.Equal(t, "kafka-image", base.Image)
+	assert.Equal(t, []string{"camel:core", "camel:kafka", "runtime:jvm"}, base.Dependencies)
+	assert.Len(t, base.Artifacts, 1)
+
+	assert.Nil(t, closestKit(&kit, kits, "2.23.2", "0.4.0"))
+	assert.Nil(t, closestKit(&kit, []v1alpha1.IntegrationKit{kit}, "2.23.2", "0.3.3"))
+}
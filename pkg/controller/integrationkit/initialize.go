@@ -45,14 +45,33 @@ func (action *initializeAction) CanHandle(kit *v1alpha1.IntegrationKit) bool {
 
 func (action *initializeAction) Handle(ctx context.Context, kit *v1alpha1.IntegrationKit) error {
 	// The integration platform needs to be initialized before starting to create kits
-	if _, err := platform.GetCurrentPlatform(ctx, action.client, kit.Namespace); err != nil {
+	pl, err := platform.GetCurrentPlatform(ctx, action.client, kit.Namespace)
+	if err != nil {
 		action.L.Info("Waiting for the integration platform to be initialized")
 		return nil
 	}
 
+	// hold the kit back as long as the namespace is over quota
+	quota, err := platform.CheckIntegrationKitQuota(ctx, action.client, pl, kit)
+	if err != nil {
+		return err
+	}
+	if quota != nil {
+		action.L.Info("IntegrationKit held back by the platform quota", "reason", quota.Reason)
+
+		if c := v1alpha1.GetCondition(kit.Status.Conditions, quota.Type); c != nil && c.Message == quota.Message {
+			return nil
+		}
+
+		target := kit.DeepCopy()
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *quota)
+
+		return action.client.Status().Update(ctx, target)
+	}
+
 	target := kit.DeepCopy()
 
-	_, err := trait.Apply(ctx, action.client, nil, target)
+	_, err = trait.Apply(ctx, action.client, nil, target)
 	if err != nil {
 		return err
 	}
@@ -80,6 +99,7 @@ func (action *initializeAction) Handle(ctx context.Context, kit *v1alpha1.Integr
 		return err
 	}
 	target.Status.Digest = dgst
+	target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionQuotaExceeded)
 
 	action.L.Info("IntegrationKit state transition", "phase", target.Status.Phase)
 	return action.client.Status().Update(ctx, target)
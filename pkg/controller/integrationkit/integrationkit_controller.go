@@ -19,8 +19,10 @@ package integrationkit
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -33,6 +35,7 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
 )
 
 // Add creates a new IntegrationKit Controller and adds it to the Manager. The Manager will set fields on the Controller
@@ -42,14 +45,19 @@ func Add(mgr manager.Manager) error {
 	if err != nil {
 		return err
 	}
-	return add(mgr, newReconciler(mgr, c))
+	bc, err := client.FromManagerWithoutCache(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, c, bc))
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, c client.Client, bc client.Client) reconcile.Reconciler {
 	return &ReconcileIntegrationKit{
-		client: c,
-		scheme: mgr.GetScheme(),
+		client:      c,
+		buildClient: bc,
+		scheme:      mgr.GetScheme(),
 	}
 }
 
@@ -68,9 +76,10 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 			newIntegrationKit := e.ObjectNew.(*v1alpha1.IntegrationKit)
 			// Ignore updates to the integration kit status in which case metadata.Generation
 			// does not change, or except when the integration kit phase changes as it's used
-			// to transition from one phase to another
+			// to transition from one phase to another, or when the reconciliation is paused or resumed
 			return oldIntegrationKit.Generation != newIntegrationKit.Generation ||
-				oldIntegrationKit.Status.Phase != newIntegrationKit.Status.Phase
+				oldIntegrationKit.Status.Phase != newIntegrationKit.Status.Phase ||
+				v1alpha1.IsReconcilePaused(oldIntegrationKit.ObjectMeta) != v1alpha1.IsReconcilePaused(newIntegrationKit.ObjectMeta)
 		},
 		DeleteFunc: func(e event.DeleteEvent) bool {
 			// Evaluates to false if the object has been confirmed deleted
@@ -111,7 +120,10 @@ type ReconcileIntegrationKit struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
 	client client.Client
-	scheme *runtime.Scheme
+	// The builds running in a dedicated build namespace are accessed with a client
+	// that reads from the apiserver, as the cache is restricted to the namespace
+	buildClient client.Client
+	scheme      *runtime.Scheme
 }
 
 // Reconcile reads that state of the cluster for a IntegrationKit object and makes changes based on the state read
@@ -133,15 +145,41 @@ func (r *ReconcileIntegrationKit) Reconcile(request reconcile.Request) (reconcil
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
-			return reconcile.Result{}, nil
+			return reconcile.Result{}, r.deleteBuild(ctx, request)
 		}
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
 
+	// Skip the reconciliation while paused, so that generated resources can be hot-fixed
+	// without the operator reverting the changes
+	if instance.GetDeletionTimestamp() == nil && v1alpha1.IsReconcilePaused(instance.ObjectMeta) {
+		if v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionReconcilePaused) == nil {
+			target := instance.DeepCopy()
+			target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, v1alpha1.Condition{
+				Type:    v1alpha1.ConditionReconcilePaused,
+				Status:  corev1.ConditionTrue,
+				Reason:  "PausedByAnnotation",
+				Message: "reconciliation paused through the " + v1alpha1.ReconcileAnnotation + " annotation",
+			})
+
+			return reconcile.Result{}, r.client.Status().Update(ctx, target)
+		}
+
+		rlog.Info("Reconciliation paused")
+		return reconcile.Result{}, nil
+	}
+	if v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionReconcilePaused) != nil {
+		target := instance.DeepCopy()
+		target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionReconcilePaused)
+
+		// resume the reconciliation from the updated resource
+		return reconcile.Result{Requeue: true}, r.client.Status().Update(ctx, target)
+	}
+
 	actionPool := []Action{
 		NewInitializeAction(),
-		NewBuildAction(),
+		NewBuildAction(r.buildClient),
 		NewMonitorAction(),
 	}
 
@@ -164,5 +202,58 @@ func (r *ReconcileIntegrationKit) Reconcile(request reconcile.Request) (reconcil
 		}
 	}
 
+	// Requeue resources held back by the platform quota so that they are admitted
+	// as soon as the namespace gets below the limits
+	if v1alpha1.GetCondition(instance.Status.Conditions, v1alpha1.ConditionQuotaExceeded) != nil {
+		return reconcile.Result{
+			RequeueAfter: platform.GetOperatorConfiguration().RequeueInterval,
+		}, nil
+	}
+
+	// Builds running in a dedicated build namespace are not watched, so their status
+	// is polled to be propagated to the kit
+	if instance.Status.Phase == v1alpha1.IntegrationKitPhaseBuildSubmitted ||
+		instance.Status.Phase == v1alpha1.IntegrationKitPhaseBuildRunning {
+		pl, err := platform.GetCurrentPlatform(ctx, r.client, instance.Namespace)
+		if err == nil && pl.Spec.Build.BuildNamespace(instance.Namespace) != instance.Namespace {
+			return reconcile.Result{
+				RequeueAfter: platform.GetOperatorConfiguration().BuildPollInterval,
+			}, nil
+		}
+	}
+
 	return reconcile.Result{}, nil
 }
+
+// deleteBuild deletes the build of a deleted kit when it runs in a dedicated build namespace,
+// in which case it's not garbage collected along with the kit
+func (r *ReconcileIntegrationKit) deleteBuild(ctx context.Context, request reconcile.Request) error {
+	pl, err := platform.GetCurrentPlatform(ctx, r.client, request.Namespace)
+	if err != nil {
+		// no platform, no dedicated build namespace
+		return nil
+	}
+
+	namespace := pl.Spec.Build.BuildNamespace(request.Namespace)
+	if namespace == request.Namespace {
+		return nil
+	}
+
+	build := v1alpha1.Build{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.BuildKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      v1alpha1.BuildName(request.Namespace, request.Name, namespace),
+		},
+	}
+
+	err = r.buildClient.Delete(ctx, &build)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
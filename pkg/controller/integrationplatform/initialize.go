@@ -98,8 +98,9 @@ func (action *initializeAction) Handle(ctx context.Context, ip *v1alpha1.Integra
 			// The only global strategy we have for now
 			target.Spec.Build.BuildStrategy = v1alpha1.IntegrationPlatformBuildStrategyPod
 		} else {
-			if target.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko {
-				// The build output has to be shared with Kaniko via a persistent volume
+			if target.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko ||
+				target.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyBuildah {
+				// The build output has to be shared with Kaniko or Buildah via a persistent volume
 				target.Spec.Build.BuildStrategy = v1alpha1.IntegrationPlatformBuildStrategyPod
 			} else {
 				target.Spec.Build.BuildStrategy = v1alpha1.IntegrationPlatformBuildStrategyRoutine
@@ -107,9 +108,14 @@ func (action *initializeAction) Handle(ctx context.Context, ip *v1alpha1.Integra
 		}
 	}
 
-	if target.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko && target.Spec.Build.Registry.Address == "" {
+	if (target.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko ||
+		target.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyBuildah ||
+		target.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategySpectrum) && target.Spec.Build.Registry.Address == "" {
 		action.L.Info("No registry specified for publishing images")
 	}
+	if target.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyTekton && target.Spec.Build.Tekton.Pipeline == "" {
+		action.L.Info("No Tekton pipeline specified for building images")
+	}
 
 	if target.Spec.Profile == "" {
 		target.Spec.Profile = platform.GetProfile(target)
@@ -118,10 +124,10 @@ func (action *initializeAction) Handle(ctx context.Context, ip *v1alpha1.Integra
 		target.Spec.Build.CamelVersion = defaults.CamelVersionConstraint
 	}
 	if target.Spec.Build.RuntimeVersion == "" {
-		target.Spec.Build.RuntimeVersion = defaults.RuntimeVersion
+		target.Spec.Build.RuntimeVersion = platform.GetOperatorConfiguration().RuntimeVersion
 	}
 	if target.Spec.Build.BaseImage == "" {
-		target.Spec.Build.BaseImage = defaults.BaseImage
+		target.Spec.Build.BaseImage = platform.DefaultBaseImage(target.Spec.Build.JavaVersion, target.Spec.FIPS)
 	}
 	if target.Spec.Build.LocalRepository == "" {
 		target.Spec.Build.LocalRepository = defaults.LocalRepository
@@ -136,6 +142,15 @@ func (action *initializeAction) Handle(ctx context.Context, ip *v1alpha1.Integra
 	action.L.Infof("CamelVersion set to %s", target.Spec.Build.CamelVersion)
 	action.L.Infof("RuntimeVersion set to %s", target.Spec.Build.RuntimeVersion)
 	action.L.Infof("BaseImage set to %s", target.Spec.Build.BaseImage)
+	if target.Spec.FIPS {
+		action.L.Info("FIPS mode enabled")
+	}
+	if target.Spec.Build.JavaVersion != "" {
+		action.L.Infof("JavaVersion set to %s", target.Spec.Build.JavaVersion)
+	}
+	if target.Spec.Build.Maven.Version != "" {
+		action.L.Infof("Maven version set to %s", target.Spec.Build.Maven.Version)
+	}
 	action.L.Infof("LocalRepository set to %s", target.Spec.Build.LocalRepository)
 	action.L.Infof("Timeout set to %s", target.Spec.Build.Timeout)
 
@@ -168,6 +183,16 @@ func (action *initializeAction) Handle(ctx context.Context, ip *v1alpha1.Integra
 			target.Status.Phase = v1alpha1.IntegrationPlatformPhaseCreating
 		}
 
+	} else if target.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyBuildah {
+		// Create the persistent volume claim used to share the build output with the Buildah pod,
+		// that needs no cache to be warmed up
+		action.L.Info("Create persistent volume claim")
+		err := createPersistentVolumeClaim(ctx, action.client, target)
+		if err != nil {
+			return err
+		}
+
+		target.Status.Phase = v1alpha1.IntegrationPlatformPhaseCreating
 	} else {
 		target.Status.Phase = v1alpha1.IntegrationPlatformPhaseCreating
 	}
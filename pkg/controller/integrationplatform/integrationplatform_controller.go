@@ -18,10 +18,10 @@ package integrationplatform
 
 import (
 	"context"
-	"time"
 
 	camelv1alpha1 "github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -126,6 +126,8 @@ func (r *ReconcileIntegrationPlatform) Reconcile(request reconcile.Request) (rec
 		NewWarmAction(),
 		NewCreateAction(),
 		NewStartAction(),
+		NewKitGCAction(),
+		NewMonitorAction(),
 	}
 
 	ilog := rlog.ForIntegrationPlatform(instance)
@@ -152,12 +154,9 @@ func (r *ReconcileIntegrationPlatform) Reconcile(request reconcile.Request) (rec
 		return reconcile.Result{}, err
 	}
 
-	if instance.Status.Phase == camelv1alpha1.IntegrationPlatformPhaseReady {
-		return reconcile.Result{}, nil
-	}
-	// Requeue
+	// Requeue, ready platforms as well so that the unused kits get collected and the status counters stay up to date
 	return reconcile.Result{
-		RequeueAfter: 5 * time.Second,
+		RequeueAfter: platform.GetOperatorConfiguration().PlatformMonitorInterval,
 	}, nil
 
 }
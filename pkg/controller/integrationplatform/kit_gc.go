@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/platform"
+
+	"github.com/pkg/errors"
+)
+
+// NewKitGCAction returns an action that deletes the platform kits no integration has used for the TTL
+func NewKitGCAction() Action {
+	return &kitGCAction{}
+}
+
+type kitGCAction struct {
+	baseAction
+}
+
+// kitGCTimes records when the kits of each platform namespace have been last collected, as the actions
+// are instantiated at every reconciliation
+var kitGCTimes sync.Map
+
+func (action *kitGCAction) Name() string {
+	return "kit-gc"
+}
+
+func (action *kitGCAction) CanHandle(platform *v1alpha1.IntegrationPlatform) bool {
+	return platform.Status.Phase == v1alpha1.IntegrationPlatformPhaseReady &&
+		platform.Spec.KitGCPolicy() == v1alpha1.IntegrationPlatformKitGCPolicyUnused
+}
+
+func (action *kitGCAction) Handle(ctx context.Context, ip *v1alpha1.IntegrationPlatform) error {
+	// listing the kits and the integrations of the namespace is not worth doing at every platform monitoring
+	now := time.Now()
+	if last, ok := kitGCTimes.Load(ip.Namespace); ok && now.Sub(last.(time.Time)) < platform.GetOperatorConfiguration().KitGCInterval {
+		return nil
+	}
+	kitGCTimes.Store(ip.Namespace, now)
+
+	return action.collect(ctx, ip, now)
+}
+
+// collect deletes the platform kits unused for the TTL, after having marked them as unused
+func (action *kitGCAction) collect(ctx context.Context, platform *v1alpha1.IntegrationPlatform, now time.Time) error {
+	used, err := action.usedKits(ctx, platform)
+	if err != nil {
+		return err
+	}
+
+	kits := v1alpha1.NewIntegrationKitList()
+	options := k8sclient.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypePlatform,
+		}),
+		Namespace: platform.Namespace,
+	}
+	if err := action.client.List(ctx, &options, &kits); err != nil {
+		return err
+	}
+
+	for _, kit := range kits.Items {
+		kit := kit
+
+		if kit.Labels["camel.apache.org/kit.type"] != v1alpha1.IntegrationKitTypePlatform {
+			continue
+		}
+		// kits still being built are left alone
+		if kit.Status.Phase != v1alpha1.IntegrationKitPhaseReady && kit.Status.Phase != v1alpha1.IntegrationKitPhaseError {
+			continue
+		}
+
+		since, marked := kit.Annotations[v1alpha1.IntegrationKitUnusedSinceAnnotation]
+		if used[kit.Name] {
+			if marked {
+				target := kit.DeepCopy()
+				delete(target.Annotations, v1alpha1.IntegrationKitUnusedSinceAnnotation)
+				if err := action.client.Update(ctx, target); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		unusedSince, err := time.Parse(time.RFC3339, since)
+		if !marked || err != nil {
+			target := kit.DeepCopy()
+			if target.Annotations == nil {
+				target.Annotations = make(map[string]string)
+			}
+			target.Annotations[v1alpha1.IntegrationKitUnusedSinceAnnotation] = now.Format(time.RFC3339)
+			if err := action.client.Update(ctx, target); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if now.Sub(unusedSince) >= platform.Spec.KitGCTTL() {
+			if err := action.client.Delete(ctx, &kit); err != nil && !k8serrors.IsNotFound(err) {
+				return errors.Wrapf(err, "unable to delete unused kit %s", kit.Name)
+			}
+			action.L.Info("Unused integration kit deleted", "kit", kit.Name, "unusedSince", since)
+		}
+	}
+
+	return nil
+}
+
+// usedKits returns the names of the kits used by the integrations of the platform namespace
+func (action *kitGCAction) usedKits(ctx context.Context, platform *v1alpha1.IntegrationPlatform) (map[string]bool, error) {
+	integrations := v1alpha1.NewIntegrationList()
+	options := k8sclient.ListOptions{
+		Namespace: platform.Namespace,
+	}
+	if err := action.client.List(ctx, &options, &integrations); err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+	for _, integration := range integrations.Items {
+		if integration.Status.Kit != "" {
+			used[integration.Status.Kit] = true
+		}
+	}
+
+	return used, nil
+}
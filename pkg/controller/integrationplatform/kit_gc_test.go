@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createTestPlatform() *v1alpha1.IntegrationPlatform {
+	platform := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	platform.Status.Phase = v1alpha1.IntegrationPlatformPhaseReady
+	return &platform
+}
+
+func createKitGCTestEnv(t *testing.T, objs ...runtime.Object) (*v1alpha1.IntegrationPlatform, client.Client, Action) {
+	platform := createTestPlatform()
+	platform.Spec.KitGC = &v1alpha1.IntegrationPlatformKitGCSpec{
+		Policy: v1alpha1.IntegrationPlatformKitGCPolicyUnused,
+		TTL:    metav1.Duration{Duration: time.Hour},
+	}
+
+	c, err := test.NewFakeClient(append(objs, platform)...)
+	assert.Nil(t, err)
+
+	action := NewKitGCAction()
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	return platform, c, action
+}
+
+func createKitGCTestKit(name string, unusedSince time.Time) *v1alpha1.IntegrationKit {
+	kit := v1alpha1.NewIntegrationKit("ns", name)
+	kit.Labels = map[string]string{
+		"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypePlatform,
+	}
+	if !unusedSince.IsZero() {
+		kit.Annotations = map[string]string{
+			v1alpha1.IntegrationKitUnusedSinceAnnotation: unusedSince.Format(time.RFC3339),
+		}
+	}
+	kit.Status.Phase = v1alpha1.IntegrationKitPhaseReady
+	return &kit
+}
+
+func handleKitGC(t *testing.T, objs ...runtime.Object) map[string]v1alpha1.IntegrationKit {
+	platform, c, action := createKitGCTestEnv(t, objs...)
+
+	// every test collects the kits, regardless of the previous collections
+	kitGCTimes.Delete(platform.Namespace)
+
+	assert.True(t, action.CanHandle(platform))
+	assert.Nil(t, action.Handle(context.TODO(), platform))
+
+	list := v1alpha1.NewIntegrationKitList()
+	assert.Nil(t, c.List(context.TODO(), &k8sclient.ListOptions{Namespace: "ns"}, &list))
+
+	kits := make(map[string]v1alpha1.IntegrationKit)
+	for _, kit := range list.Items {
+		kits[kit.Name] = kit
+	}
+	return kits
+}
+
+func TestKitGCNotHandledByDefault(t *testing.T) {
+	platform, _, _ := createKitGCTestEnv(t)
+	assert.True(t, NewKitGCAction().CanHandle(platform))
+
+	platform.Spec.KitGC = nil
+	assert.False(t, NewKitGCAction().CanHandle(platform))
+
+	platform.Spec.KitGC = &v1alpha1.IntegrationPlatformKitGCSpec{Policy: v1alpha1.IntegrationPlatformKitGCPolicyOwned}
+	assert.False(t, NewKitGCAction().CanHandle(platform))
+}
+
+func TestKitGCMarksUnusedKits(t *testing.T) {
+	integration := v1alpha1.NewIntegration("ns", "it")
+	integration.Status.Kit = "used"
+
+	building := createKitGCTestKit("building", time.Time{})
+	building.Status.Phase = v1alpha1.IntegrationKitPhaseBuildRunning
+
+	kits := handleKitGC(t,
+		&integration,
+		createKitGCTestKit("used", time.Now().Add(-2*time.Hour)),
+		createKitGCTestKit("unused", time.Time{}),
+		building,
+	)
+
+	assert.Len(t, kits, 3)
+	assert.NotContains(t, kits["used"].Annotations, v1alpha1.IntegrationKitUnusedSinceAnnotation)
+	assert.Contains(t, kits["unused"].Annotations, v1alpha1.IntegrationKitUnusedSinceAnnotation)
+	assert.NotContains(t, kits["building"].Annotations, v1alpha1.IntegrationKitUnusedSinceAnnotation)
+}
+
+func TestKitGCDeletesExpiredKits(t *testing.T) {
+	user := createKitGCTestKit("user", time.Now().Add(-2*time.Hour))
+	user.Labels["camel.apache.org/kit.type"] = v1alpha1.IntegrationKitTypeUser
+
+	kits := handleKitGC(t,
+		createKitGCTestKit("expired", time.Now().Add(-2*time.Hour)),
+		createKitGCTestKit("recent", time.Now().Add(-10*time.Minute)),
+		user,
+	)
+
+	assert.Len(t, kits, 2)
+	assert.NotContains(t, kits, "expired")
+	assert.Contains(t, kits, "recent")
+	assert.Contains(t, kits, "user")
+}
+
+func TestKitGCThrottled(t *testing.T) {
+	platform, c, action := createKitGCTestEnv(t, createKitGCTestKit("unused", time.Time{}))
+
+	// the kits have been collected recently
+	kitGCTimes.Store(platform.Namespace, time.Now())
+	defer kitGCTimes.Delete(platform.Namespace)
+
+	assert.Nil(t, action.Handle(context.TODO(), platform))
+
+	target := v1alpha1.NewIntegrationKit("ns", "unused")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "unused"}, &target))
+	assert.NotContains(t, target.Annotations, v1alpha1.IntegrationKitUnusedSinceAnnotation)
+}
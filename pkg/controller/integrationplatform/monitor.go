@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/platform"
+)
+
+// NewMonitorAction returns an action that reports the builds, the registry health and the catalogs
+// available in the status of a ready platform
+func NewMonitorAction() Action {
+	return &monitorAction{}
+}
+
+type monitorAction struct {
+	baseAction
+}
+
+func (action *monitorAction) Name() string {
+	return "monitor"
+}
+
+func (action *monitorAction) CanHandle(ip *v1alpha1.IntegrationPlatform) bool {
+	return ip.Status.Phase == v1alpha1.IntegrationPlatformPhaseReady
+}
+
+func (action *monitorAction) Handle(ctx context.Context, ip *v1alpha1.IntegrationPlatform) error {
+	builds, err := action.buildsStatus(ctx, ip.Namespace)
+	if err != nil {
+		return err
+	}
+	versions, err := action.catalogVersions(ctx, ip.Namespace)
+	if err != nil {
+		return err
+	}
+
+	target := ip.DeepCopy()
+	target.Status.Builds = builds
+	target.Status.Registry = platform.ProbeRegistry(ctx, ip)
+	target.Status.CatalogVersions = versions
+
+	if equality.Semantic.DeepEqual(ip.Status, target.Status) {
+		return nil
+	}
+
+	return action.client.Status().Update(ctx, target)
+}
+
+// buildsStatus counts the builds of the namespace, including the ones run on behalf of other namespaces
+// when the namespace is a build namespace
+func (action *monitorAction) buildsStatus(ctx context.Context, namespace string) (v1alpha1.IntegrationPlatformBuildsStatus, error) {
+	status := v1alpha1.IntegrationPlatformBuildsStatus{}
+
+	builds := v1alpha1.BuildList{}
+	if err := action.client.List(ctx, &k8sclient.ListOptions{Namespace: namespace}, &builds); err != nil {
+		return status, err
+	}
+
+	var lastCompletion time.Time
+	for _, build := range builds.Items {
+		switch build.Status.Phase {
+		case v1alpha1.BuildPhaseInitial, v1alpha1.BuildPhaseScheduling:
+			status.Queued++
+		case v1alpha1.BuildPhasePending, v1alpha1.BuildPhaseRunning:
+			status.Running++
+		default:
+			duration, err := time.ParseDuration(build.Status.Duration)
+			if err != nil {
+				continue
+			}
+			if completion := build.Status.StartedAt.Add(duration); completion.After(lastCompletion) {
+				lastCompletion = completion
+				status.LastDuration = build.Status.Duration
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// catalogVersions returns the sorted Camel versions of the catalogs available in the namespace
+func (action *monitorAction) catalogVersions(ctx context.Context, namespace string) ([]string, error) {
+	catalogs := v1alpha1.NewCamelCatalogList()
+	if err := action.client.List(ctx, &k8sclient.ListOptions{Namespace: namespace}, &catalogs); err != nil {
+		return nil, err
+	}
+
+	if len(catalogs.Items) == 0 {
+		return nil, nil
+	}
+
+	versions := make([]string, 0, len(catalogs.Items))
+	for _, catalog := range catalogs.Items {
+		versions = append(versions, catalog.Spec.Version)
+	}
+	sort.Strings(versions)
+
+	return versions, nil
+}
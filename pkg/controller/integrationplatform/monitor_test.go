@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integrationplatform
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createMonitorTestBuild(name string, phase v1alpha1.BuildPhase, startedAt time.Time, duration string) *v1alpha1.Build {
+	return &v1alpha1.Build{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.BuildKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      name,
+		},
+		Status: v1alpha1.BuildStatus{
+			Phase:     phase,
+			StartedAt: metav1.NewTime(startedAt),
+			Duration:  duration,
+		},
+	}
+}
+
+func createMonitorTestCatalog(version string) *v1alpha1.CamelCatalog {
+	return &v1alpha1.CamelCatalog{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.CamelCatalogKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "camel-catalog-" + version,
+		},
+		Spec: v1alpha1.CamelCatalogSpec{
+			Version: version,
+		},
+	}
+}
+
+func createMonitorTestEnv(t *testing.T, objs ...runtime.Object) (*v1alpha1.IntegrationPlatform, client.Client, Action) {
+	platform := createTestPlatform()
+
+	c, err := test.NewFakeClient(append(objs, platform)...)
+	assert.Nil(t, err)
+
+	action := NewMonitorAction()
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	return platform, c, action
+}
+
+func TestMonitorPlatformStatus(t *testing.T) {
+	now := time.Now()
+	catalog := createMonitorTestCatalog("2.23.2")
+	older := createMonitorTestCatalog("2.23.1")
+
+	platform, c, action := createMonitorTestEnv(t, catalog, older,
+		createMonitorTestBuild("queued", v1alpha1.BuildPhaseScheduling, time.Time{}, ""),
+		createMonitorTestBuild("pending", v1alpha1.BuildPhasePending, now, ""),
+		createMonitorTestBuild("running", v1alpha1.BuildPhaseRunning, now, ""),
+		createMonitorTestBuild("first", v1alpha1.BuildPhaseSucceeded, now.Add(-time.Hour), "2m0s"),
+		createMonitorTestBuild("last", v1alpha1.BuildPhaseFailed, now.Add(-30*time.Minute), "1m30s"),
+	)
+
+	assert.True(t, action.CanHandle(platform))
+	assert.Nil(t, action.Handle(context.TODO(), platform))
+
+	target := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "camel-k"}, &target))
+
+	assert.Equal(t, 1, target.Status.Builds.Queued)
+	assert.Equal(t, 2, target.Status.Builds.Running)
+	assert.Equal(t, "1m30s", target.Status.Builds.LastDuration)
+	assert.Nil(t, target.Status.Registry)
+	assert.Equal(t, []string{"2.23.1", "2.23.2"}, target.Status.CatalogVersions)
+}
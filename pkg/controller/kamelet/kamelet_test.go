@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestValidateKamelet(t *testing.T) {
+	kamelet := v1alpha1.NewKamelet("ns", "timer")
+	kamelet.Generation = 1
+	kamelet.Spec.Template = v1alpha1.NewSourceSpec("timer.groovy", `from('timer:tick?period={{period}}').to('kamelet:sink')`, "")
+
+	c, err := test.NewFakeClient(&kamelet)
+	assert.Nil(t, err)
+
+	action := NewValidateAction()
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	assert.True(t, action.CanHandle(&kamelet))
+	assert.Nil(t, action.Handle(context.TODO(), &kamelet))
+
+	key := k8sclient.ObjectKey{Namespace: "ns", Name: "timer"}
+	assert.Nil(t, c.Get(context.TODO(), key, &kamelet))
+	assert.Equal(t, v1alpha1.KameletPhaseError, kamelet.Status.Phase)
+	assert.Equal(t, "the template uses undeclared properties: period", kamelet.Status.Error)
+	assert.False(t, action.CanHandle(&kamelet))
+
+	// the kamelet is validated again once its spec has changed
+	kamelet.Generation = 2
+	kamelet.Spec.Definition.Properties = map[string]v1alpha1.KameletProperty{
+		"period": {Type: v1alpha1.KameletPropertyTypeInteger},
+	}
+	assert.True(t, action.CanHandle(&kamelet))
+	assert.Nil(t, action.Handle(context.TODO(), &kamelet))
+
+	validated := v1alpha1.NewKamelet("ns", "timer")
+	assert.Nil(t, c.Get(context.TODO(), key, &validated))
+	assert.Equal(t, v1alpha1.KameletPhaseReady, validated.Status.Phase)
+	assert.Empty(t, validated.Status.Error)
+	assert.True(t, validated.IsReady())
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelet
+
+import (
+	"context"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/kamelet"
+)
+
+// NewValidateAction creates a new validate action
+func NewValidateAction() Action {
+	return &validateAction{}
+}
+
+type validateAction struct {
+	baseAction
+}
+
+// Name returns a common name of the action
+func (action *validateAction) Name() string {
+	return "validate"
+}
+
+// CanHandle tells whether this action can handle the kamelet
+func (action *validateAction) CanHandle(target *v1alpha1.Kamelet) bool {
+	return target.Status.Phase == v1alpha1.KameletPhaseInitial || target.Status.ObservedGeneration != target.Generation
+}
+
+// Handle handles the kamelets
+func (action *validateAction) Handle(ctx context.Context, target *v1alpha1.Kamelet) error {
+	k := target.DeepCopy()
+	k.Status.ObservedGeneration = target.Generation
+
+	if err := kamelet.Validate(target); err != nil {
+		k.Status.Phase = v1alpha1.KameletPhaseError
+		k.Status.Error = err.Error()
+	} else {
+		k.Status.Phase = v1alpha1.KameletPhaseReady
+		k.Status.Error = ""
+	}
+	action.L.Info("Kamelet state transition", "phase", k.Status.Phase)
+
+	return action.client.Status().Update(ctx, k)
+}
@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"context"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+)
+
+// NewInitializeAction creates a new initialize action
+func NewInitializeAction() Action {
+	return &initializeAction{}
+}
+
+type initializeAction struct {
+	baseAction
+}
+
+// Name returns a common name of the action
+func (action *initializeAction) Name() string {
+	return "initialize"
+}
+
+// CanHandle tells whether this action can handle the kamelet binding
+func (action *initializeAction) CanHandle(binding *v1alpha1.KameletBinding) bool {
+	return binding.Status.Phase == v1alpha1.KameletBindingPhaseInitial || binding.Status.ObservedGeneration != binding.Generation
+}
+
+// Handle handles the kamelet bindings
+func (action *initializeAction) Handle(ctx context.Context, binding *v1alpha1.KameletBinding) error {
+	target := binding.DeepCopy()
+	target.Status.ObservedGeneration = binding.Generation
+
+	integration, err := newIntegration(binding)
+	if err == nil {
+		err = kubernetes.ReplaceResource(ctx, action.client, integration)
+	}
+	if err != nil {
+		target.Status.Phase = v1alpha1.KameletBindingPhaseError
+		target.Status.Error = err.Error()
+	} else {
+		target.Status.Phase = v1alpha1.KameletBindingPhaseCreating
+		target.Status.Error = ""
+	}
+	action.L.Info("KameletBinding state transition", "phase", target.Status.Phase)
+
+	return action.client.Status().Update(ctx, target)
+}
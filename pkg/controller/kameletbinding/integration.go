@@ -0,0 +1,149 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+// kameletBindingLabel labels the integrations materialized from kamelet bindings
+const kameletBindingLabel = "camel.apache.org/kamelet.binding"
+
+// newIntegration returns the integration materialized from the kamelet binding, whose only source is a flow
+// consuming from the source endpoint and producing to the steps and to the sink endpoints, in order
+func newIntegration(binding *v1alpha1.KameletBinding) (*v1alpha1.Integration, error) {
+	endpoints := make([]v1alpha1.Endpoint, 0, len(binding.Spec.Steps)+2)
+	endpoints = append(endpoints, binding.Spec.Source)
+	endpoints = append(endpoints, binding.Spec.Steps...)
+	endpoints = append(endpoints, binding.Spec.Sink)
+
+	flow := v1alpha1.Flow{Steps: make([]v1alpha1.Step, 0, len(endpoints))}
+	for i, endpoint := range endpoints {
+		uri, err := endpointURI(binding.Namespace, endpoint)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid %s", endpointDescription(binding, i))
+		}
+		flow.Steps = append(flow.Steps, v1alpha1.Step{Kind: "endpoint", URI: uri})
+	}
+
+	content, err := v1alpha1.Flows{flow}.Serialize()
+	if err != nil {
+		return nil, err
+	}
+
+	controller := true
+	blockOwnerDeletion := true
+	integration := v1alpha1.NewIntegration(binding.Namespace, binding.Name)
+	integration.Labels = map[string]string{
+		kameletBindingLabel: binding.Name,
+	}
+	integration.OwnerReferences = []metav1.OwnerReference{
+		{
+			APIVersion:         v1alpha1.SchemeGroupVersion.String(),
+			Kind:               v1alpha1.KameletBindingKind,
+			Name:               binding.Name,
+			UID:                binding.UID,
+			Controller:         &controller,
+			BlockOwnerDeletion: &blockOwnerDeletion,
+		},
+	}
+	if binding.Spec.Integration != nil {
+		integration.Spec = *binding.Spec.Integration.DeepCopy()
+	}
+	integration.Spec.AddSource(binding.Name+".flow", content, v1alpha1.LanguageYamlFlow)
+
+	return &integration, nil
+}
+
+// endpointURI returns the URI of the endpoint, that is the given URI or the URI of the referenced kamelet, Kafka
+// topic or Knative channel, the properties being added as parameters. The values of the properties are used as is,
+// so that they can be property placeholders, and the values containing reserved characters can be wrapped in RAW()
+func endpointURI(namespace string, endpoint v1alpha1.Endpoint) (string, error) {
+	var uri string
+	switch ref := endpoint.Ref; {
+	case ref != nil && endpoint.URI != "":
+		return "", errors.New("either a reference or a URI is expected, not both")
+	case endpoint.URI != "":
+		uri = endpoint.URI
+	case ref != nil:
+		if ref.Name == "" {
+			return "", errors.Errorf("the name of the referenced %s is required", ref.Kind)
+		}
+		if ref.Namespace != "" && ref.Namespace != namespace {
+			return "", errors.Errorf("%s %s is in namespace %s, only references within the namespace are supported", ref.Kind, ref.Name, ref.Namespace)
+		}
+
+		group := ""
+		if gv, err := schema.ParseGroupVersion(ref.APIVersion); err == nil {
+			group = gv.Group
+		}
+		switch {
+		case ref.Kind == v1alpha1.KameletKind && (group == "" || group == v1alpha1.SchemeGroupVersion.Group):
+			uri = v1alpha1.KameletScheme + ":" + ref.Name
+		case ref.Kind == "KafkaTopic" && (group == "" || group == "kafka.strimzi.io"):
+			uri = "kafka:" + ref.Name
+		case strings.HasSuffix(ref.Kind, "Channel") && (group == "" || group == "messaging.knative.dev" || group == "eventing.knative.dev"):
+			uri = "knative:channel/" + ref.Name
+		default:
+			return "", errors.Errorf("unsupported reference to %s %s", ref.Kind, ref.Name)
+		}
+	default:
+		return "", errors.New("a reference or a URI is required")
+	}
+
+	if len(endpoint.Properties) == 0 {
+		return uri, nil
+	}
+
+	keys := make([]string, 0, len(endpoint.Properties))
+	for k := range endpoint.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, k := range keys {
+		params = append(params, k+"="+endpoint.Properties[k])
+	}
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+
+	return uri + separator + strings.Join(params, "&"), nil
+}
+
+// endpointDescription describes the endpoint at the given position of the flow, e.g. step 2
+func endpointDescription(binding *v1alpha1.KameletBinding, i int) string {
+	switch {
+	case i == 0:
+		return "source"
+	case i > len(binding.Spec.Steps):
+		return "sink"
+	default:
+		return "step " + strconv.Itoa(i)
+	}
+}
@@ -0,0 +1,235 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestKameletBinding() *v1alpha1.KameletBinding {
+	binding := v1alpha1.NewKameletBinding("ns", "telegram-to-kafka")
+	binding.Generation = 1
+	binding.Spec = v1alpha1.KameletBindingSpec{
+		Integration: &v1alpha1.IntegrationSpec{
+			Traits: map[string]v1alpha1.TraitSpec{
+				"jolokia": {Configuration: map[string]string{"enabled": "true"}},
+			},
+		},
+		Source: v1alpha1.Endpoint{
+			Ref: &corev1.ObjectReference{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       v1alpha1.KameletKind,
+				Name:       "telegram",
+			},
+			Properties: map[string]string{
+				"token": "{{telegram.token}}",
+				"chat":  "123",
+			},
+		},
+		Steps: []v1alpha1.Endpoint{
+			{URI: "language:simple:${body.toUpperCase()}"},
+		},
+		Sink: v1alpha1.Endpoint{
+			Ref: &corev1.ObjectReference{
+				APIVersion: "kafka.strimzi.io/v1beta1",
+				Kind:       "KafkaTopic",
+				Name:       "messages",
+			},
+		},
+	}
+	return &binding
+}
+
+func TestEndpointURI(t *testing.T) {
+	testCases := []struct {
+		name     string
+		endpoint v1alpha1.Endpoint
+		uri      string
+		err      string
+	}{
+		{
+			name:     "uri",
+			endpoint: v1alpha1.Endpoint{URI: "timer:tick?period=1s", Properties: map[string]string{"repeatCount": "2"}},
+			uri:      "timer:tick?period=1s&repeatCount=2",
+		},
+		{
+			name:     "kamelet",
+			endpoint: v1alpha1.Endpoint{Ref: &corev1.ObjectReference{Kind: "Kamelet", Name: "telegram"}},
+			uri:      "kamelet:telegram",
+		},
+		{
+			name: "knative channel",
+			endpoint: v1alpha1.Endpoint{Ref: &corev1.ObjectReference{
+				APIVersion: "messaging.knative.dev/v1alpha1",
+				Kind:       "InMemoryChannel",
+				Name:       "messages",
+			}},
+			uri: "knative:channel/messages",
+		},
+		{
+			name:     "missing endpoint",
+			endpoint: v1alpha1.Endpoint{},
+			err:      "a reference or a URI is required",
+		},
+		{
+			name: "reference and uri",
+			endpoint: v1alpha1.Endpoint{
+				Ref: &corev1.ObjectReference{Kind: "Kamelet", Name: "telegram"},
+				URI: "kamelet:telegram",
+			},
+			err: "either a reference or a URI is expected, not both",
+		},
+		{
+			name:     "unsupported reference",
+			endpoint: v1alpha1.Endpoint{Ref: &corev1.ObjectReference{APIVersion: "v1", Kind: "ConfigMap", Name: "messages"}},
+			err:      "unsupported reference to ConfigMap messages",
+		},
+		{
+			name:     "other namespace",
+			endpoint: v1alpha1.Endpoint{Ref: &corev1.ObjectReference{Kind: "Kamelet", Name: "telegram", Namespace: "other"}},
+			err:      "Kamelet telegram is in namespace other, only references within the namespace are supported",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			uri, err := endpointURI("ns", tc.endpoint)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, tc.uri, uri)
+			}
+		})
+	}
+}
+
+func TestInitializeKameletBinding(t *testing.T) {
+	binding := newTestKameletBinding()
+	c, err := test.NewFakeClient(binding)
+	assert.Nil(t, err)
+
+	action := NewInitializeAction()
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	assert.True(t, action.CanHandle(binding))
+	assert.Nil(t, action.Handle(context.TODO(), binding))
+
+	key := k8sclient.ObjectKey{Namespace: "ns", Name: "telegram-to-kafka"}
+	assert.Nil(t, c.Get(context.TODO(), key, binding))
+	assert.Equal(t, v1alpha1.KameletBindingPhaseCreating, binding.Status.Phase)
+	assert.False(t, action.CanHandle(binding))
+
+	integration := v1alpha1.NewIntegration("ns", "telegram-to-kafka")
+	assert.Nil(t, c.Get(context.TODO(), key, &integration))
+	assert.Equal(t, "telegram-to-kafka", integration.Labels[kameletBindingLabel])
+	assert.Len(t, integration.OwnerReferences, 1)
+	assert.Equal(t, v1alpha1.KameletBindingKind, integration.OwnerReferences[0].Kind)
+	assert.Contains(t, integration.Spec.Traits, "jolokia")
+	assert.Len(t, integration.Spec.Sources, 1)
+	assert.Equal(t, "telegram-to-kafka.flow", integration.Spec.Sources[0].Name)
+	assert.Equal(t, v1alpha1.LanguageYamlFlow, integration.Spec.Sources[0].Language)
+	assert.Equal(t, `- steps:
+  - kind: endpoint
+    uri: kamelet:telegram?chat=123&token={{telegram.token}}
+  - kind: endpoint
+    uri: language:simple:${body.toUpperCase()}
+  - kind: endpoint
+    uri: kafka:messages
+`, integration.Spec.Sources[0].Content)
+
+	// the integration is materialized again once the binding has changed
+	binding.Generation = 2
+	binding.Spec.Steps = nil
+	assert.True(t, action.CanHandle(binding))
+	assert.Nil(t, action.Handle(context.TODO(), binding))
+
+	integration = v1alpha1.NewIntegration("ns", "telegram-to-kafka")
+	assert.Nil(t, c.Get(context.TODO(), key, &integration))
+	assert.NotContains(t, integration.Spec.Sources[0].Content, "language:simple")
+}
+
+func TestInitializeInvalidKameletBinding(t *testing.T) {
+	binding := newTestKameletBinding()
+	binding.Spec.Steps = append(binding.Spec.Steps, v1alpha1.Endpoint{})
+	c, err := test.NewFakeClient(binding)
+	assert.Nil(t, err)
+
+	action := NewInitializeAction()
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	assert.Nil(t, action.Handle(context.TODO(), binding))
+
+	key := k8sclient.ObjectKey{Namespace: "ns", Name: "telegram-to-kafka"}
+	assert.Nil(t, c.Get(context.TODO(), key, binding))
+	assert.Equal(t, v1alpha1.KameletBindingPhaseError, binding.Status.Phase)
+	assert.Equal(t, "invalid step 2: a reference or a URI is required", binding.Status.Error)
+
+	integration := v1alpha1.NewIntegration("ns", "telegram-to-kafka")
+	assert.NotNil(t, c.Get(context.TODO(), key, &integration))
+}
+
+func TestMonitorKameletBinding(t *testing.T) {
+	binding := newTestKameletBinding()
+	binding.Status.Phase = v1alpha1.KameletBindingPhaseCreating
+	binding.Status.ObservedGeneration = 1
+	integration := v1alpha1.NewIntegration("ns", "telegram-to-kafka")
+	integration.Status.Phase = v1alpha1.IntegrationPhaseRunning
+
+	c, err := test.NewFakeClient(binding, &integration)
+	assert.Nil(t, err)
+
+	action := NewMonitorAction()
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	assert.True(t, action.CanHandle(binding))
+	assert.Nil(t, action.Handle(context.TODO(), binding))
+
+	key := k8sclient.ObjectKey{Namespace: "ns", Name: "telegram-to-kafka"}
+	assert.Nil(t, c.Get(context.TODO(), key, binding))
+	assert.Equal(t, v1alpha1.KameletBindingPhaseReady, binding.Status.Phase)
+
+	integration.Status.Phase = v1alpha1.IntegrationPhaseError
+	integration.Status.Failure = &v1alpha1.Failure{Reason: "kamelet telegram not found"}
+	assert.Nil(t, c.Status().Update(context.TODO(), &integration))
+	assert.Nil(t, action.Handle(context.TODO(), binding))
+
+	assert.Nil(t, c.Get(context.TODO(), key, binding))
+	assert.Equal(t, v1alpha1.KameletBindingPhaseError, binding.Status.Phase)
+	assert.Equal(t, "integration telegram-to-kafka is in error: kamelet telegram not found", binding.Status.Error)
+	assert.False(t, action.CanHandle(binding))
+
+	// the integration is materialized again when deleted
+	binding.Status.Phase = v1alpha1.KameletBindingPhaseReady
+	assert.Nil(t, c.Delete(context.TODO(), &integration))
+	assert.Nil(t, action.Handle(context.TODO(), binding))
+
+	reset := v1alpha1.NewKameletBinding("ns", "telegram-to-kafka")
+	assert.Nil(t, c.Get(context.TODO(), key, &reset))
+	assert.Equal(t, v1alpha1.KameletBindingPhaseInitial, reset.Status.Phase)
+}
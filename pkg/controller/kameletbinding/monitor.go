@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kameletbinding
+
+import (
+	"context"
+	"fmt"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+// NewMonitorAction creates a new monitor action
+func NewMonitorAction() Action {
+	return &monitorAction{}
+}
+
+type monitorAction struct {
+	baseAction
+}
+
+// Name returns a common name of the action
+func (action *monitorAction) Name() string {
+	return "monitor"
+}
+
+// CanHandle tells whether this action can handle the kamelet binding
+func (action *monitorAction) CanHandle(binding *v1alpha1.KameletBinding) bool {
+	return (binding.Status.Phase == v1alpha1.KameletBindingPhaseCreating || binding.Status.Phase == v1alpha1.KameletBindingPhaseReady) &&
+		binding.Status.ObservedGeneration == binding.Generation
+}
+
+// Handle handles the kamelet bindings
+func (action *monitorAction) Handle(ctx context.Context, binding *v1alpha1.KameletBinding) error {
+	integration := v1alpha1.NewIntegration(binding.Namespace, binding.Name)
+	key := k8sclient.ObjectKey{
+		Namespace: binding.Namespace,
+		Name:      binding.Name,
+	}
+
+	target := binding.DeepCopy()
+	if err := action.client.Get(ctx, key, &integration); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return err
+		}
+		// the integration is materialized again when deleted out-of-band
+		target.Status.Phase = v1alpha1.KameletBindingPhaseInitial
+	} else {
+		switch integration.Status.Phase {
+		case v1alpha1.IntegrationPhaseRunning:
+			target.Status.Phase = v1alpha1.KameletBindingPhaseReady
+		case v1alpha1.IntegrationPhaseError:
+			target.Status.Phase = v1alpha1.KameletBindingPhaseError
+			target.Status.Error = fmt.Sprintf("integration %s is in error", integration.Name)
+			if integration.Status.Failure != nil && integration.Status.Failure.Reason != "" {
+				target.Status.Error += ": " + integration.Status.Failure.Reason
+			}
+		default:
+			target.Status.Phase = v1alpha1.KameletBindingPhaseCreating
+		}
+	}
+
+	if target.Status.Phase == binding.Status.Phase {
+		return nil
+	}
+	action.L.Info("KameletBinding state transition", "phase", target.Status.Phase)
+
+	return action.client.Status().Update(ctx, target)
+}
@@ -0,0 +1,145 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorconfig
+
+import (
+	"context"
+
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/log"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Add creates a new controller watching the operator ConfigMap and adds it to the Manager. The Manager will
+// set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	c, err := client.FromManager(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(c))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(c client.Client) reconcile.Reconciler {
+	return &ReconcileOperatorConfig{
+		client: c,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("operatorconfig-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the operator ConfigMap only
+	err = c.Watch(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return isOperatorConfigMap(e.Meta)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isOperatorConfigMap(e.MetaNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return isOperatorConfigMap(e.Meta)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return isOperatorConfigMap(e.Meta)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func isOperatorConfigMap(meta metav1.Object) bool {
+	if meta.GetName() != platform.OperatorConfigMapName {
+		return false
+	}
+
+	ns := platform.GetOperatorNamespace()
+	return ns == "" || ns == meta.GetNamespace()
+}
+
+var _ reconcile.Reconciler = &ReconcileOperatorConfig{}
+
+// ReconcileOperatorConfig applies the operator configuration whenever the operator ConfigMap changes
+type ReconcileOperatorConfig struct {
+	client client.Client
+}
+
+// Reconcile reloads the operator configuration from the ConfigMap, restoring the defaults if it has been deleted
+func (r *ReconcileOperatorConfig) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rlog := Log.WithValues("request-namespace", request.Namespace, "request-name", request.Name)
+	rlog.Info("Reconciling operator configuration")
+
+	cm := corev1.ConfigMap{}
+	err := r.client.Get(context.TODO(), request.NamespacedName, &cm)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	configuration := platform.DefaultOperatorConfiguration()
+	if err == nil {
+		configuration, err = platform.ParseOperatorConfiguration(cm.Data)
+		if err != nil {
+			// keep the current configuration, the ConfigMap must be fixed
+			rlog.Error(err, "Invalid operator configuration, ignoring changes")
+			return reconcile.Result{}, nil
+		}
+	}
+
+	if err := log.SetLevel(configuration.LogLevel); err != nil {
+		rlog.Error(err, "Invalid operator log level, ignoring changes")
+		return reconcile.Result{}, nil
+	}
+
+	platform.SetOperatorConfiguration(configuration)
+
+	rlog.Info("Operator configuration reloaded",
+		"runtime-version", configuration.RuntimeVersion,
+		"max-running-builds", configuration.MaxRunningBuilds,
+		"max-running-builds-total", configuration.MaxRunningBuildsTotal,
+		"log-level", configuration.LogLevel,
+		"requeue-interval", configuration.RequeueInterval.String(),
+		"integration-monitor-interval", configuration.IntegrationMonitorInterval.String(),
+		"build-poll-interval", configuration.BuildPollInterval.String(),
+		"platform-monitor-interval", configuration.PlatformMonitorInterval.String(),
+		"kit-gc-interval", configuration.KitGCInterval.String(),
+		"drift-enforcement", configuration.DriftEnforcement,
+		"trait-snapshots", configuration.TraitSnapshots,
+	)
+
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package operatorconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReloadOperatorConfiguration(t *testing.T) {
+	defer platform.SetOperatorConfiguration(platform.DefaultOperatorConfiguration())
+
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      platform.OperatorConfigMapName,
+		},
+		Data: map[string]string{
+			"max-running-builds": "4",
+		},
+	}
+
+	c, err := test.NewFakeClient(&cm)
+	assert.Nil(t, err)
+
+	r := newReconciler(c)
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: platform.OperatorConfigMapName}}
+
+	_, err = r.Reconcile(request)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, platform.GetOperatorConfiguration().MaxRunningBuilds)
+
+	// invalid values are ignored
+	cm.Data["max-running-builds"] = "none"
+	assert.Nil(t, c.Update(context.TODO(), &cm))
+
+	_, err = r.Reconcile(request)
+	assert.Nil(t, err)
+	assert.Equal(t, 4, platform.GetOperatorConfiguration().MaxRunningBuilds)
+
+	// defaults are restored when the config map is deleted
+	assert.Nil(t, c.Delete(context.TODO(), &cm))
+
+	_, err = r.Reconcile(request)
+	assert.Nil(t, err)
+	assert.Equal(t, platform.DefaultOperatorConfiguration(), platform.GetOperatorConfiguration())
+}
@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NewInitializeAction creates a new initialize action
+func NewInitializeAction() Action {
+	return &initializeAction{}
+}
+
+type initializeAction struct {
+	baseAction
+}
+
+// Name returns a common name of the action
+func (action *initializeAction) Name() string {
+	return "initialize"
+}
+
+// CanHandle tells whether this action can handle the replay
+func (action *initializeAction) CanHandle(replay *v1alpha1.Replay) bool {
+	return replay.Status.Phase == v1alpha1.ReplayPhaseInitial
+}
+
+// Handle handles the replays
+func (action *initializeAction) Handle(ctx context.Context, replay *v1alpha1.Replay) error {
+	target := replay.DeepCopy()
+
+	messages, err := loadMessages(ctx, action.client, replay)
+	if err != nil {
+		target.Status.Phase = v1alpha1.ReplayPhaseError
+		target.Status.Error = err.Error()
+	} else {
+		target.Status.Phase = v1alpha1.ReplayPhaseRunning
+		target.Status.Total = len(messages) * replay.Spec.Repetitions()
+	}
+	action.L.Info("Replay state transition", "phase", target.Status.Phase)
+
+	return action.client.Status().Update(ctx, target)
+}
+
+// loadMessages returns the messages of the replay, i.e. the values of the ConfigMap sorted by key
+func loadMessages(ctx context.Context, c k8sclient.Reader, replay *v1alpha1.Replay) ([]string, error) {
+	if replay.Spec.Integration == "" || replay.Spec.Endpoint == "" || replay.Spec.ConfigMap == "" {
+		return nil, fmt.Errorf("the integration, the endpoint and the config map of the messages are required")
+	}
+
+	cm := corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+	}
+	key := k8sclient.ObjectKey{
+		Namespace: replay.Namespace,
+		Name:      replay.Spec.ConfigMap,
+	}
+	if err := c.Get(ctx, key, &cm); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, fmt.Errorf("config map %s not found", replay.Spec.ConfigMap)
+		}
+		return nil, err
+	}
+	if len(cm.Data) == 0 {
+		return nil, fmt.Errorf("config map %s holds no message", replay.Spec.ConfigMap)
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for k := range cm.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	messages := make([]string, 0, len(keys))
+	for _, k := range keys {
+		messages = append(messages, cm.Data[k])
+	}
+
+	return messages, nil
+}
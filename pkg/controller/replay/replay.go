@@ -0,0 +1,148 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/jolokia"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// sendTimeout bounds the time spent sending a message
+const sendTimeout = 5 * time.Second
+
+// NewReplayAction creates a new action sending the messages that are due
+func NewReplayAction() Action {
+	return &replayAction{
+		send: jolokia.SendBody,
+		now:  time.Now,
+	}
+}
+
+type replayAction struct {
+	baseAction
+	send func(ctx context.Context, agent string, uri string, body string) error
+	now  func() time.Time
+}
+
+// Name returns a common name of the action
+func (action *replayAction) Name() string {
+	return "replay"
+}
+
+// CanHandle tells whether this action can handle the replay
+func (action *replayAction) CanHandle(replay *v1alpha1.Replay) bool {
+	return replay.Status.Phase == v1alpha1.ReplayPhaseRunning
+}
+
+// Handle sends the messages that are due since the replay started, given the rate, round-robin across the
+// pods of the integration exposing a Jolokia agent
+func (action *replayAction) Handle(ctx context.Context, replay *v1alpha1.Replay) error {
+	target := replay.DeepCopy()
+
+	messages, err := loadMessages(ctx, action.client, replay)
+	if err != nil {
+		target.Status.Phase = v1alpha1.ReplayPhaseError
+		target.Status.Error = err.Error()
+		action.L.Info("Replay state transition", "phase", target.Status.Phase)
+		return action.client.Status().Update(ctx, target)
+	}
+
+	agents, err := action.agents(ctx, replay)
+	if err != nil {
+		return err
+	}
+	if len(agents) == 0 {
+		// the integration is not running yet, or doesn't expose a Jolokia agent
+		action.L.Info("Waiting for the integration to expose a Jolokia agent", "integration", replay.Spec.Integration)
+		return nil
+	}
+
+	now := metav1.NewTime(action.now())
+	if target.Status.StartedAt == nil {
+		target.Status.StartedAt = &now
+	}
+
+	for i := target.Status.Processed(); i < dueMessages(target, now.Time); i++ {
+		sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+		err := action.send(sendCtx, agents[i%len(agents)], replay.Spec.Endpoint, messages[i%len(messages)])
+		cancel()
+		if err != nil {
+			action.L.Info("Cannot send message", "index", i, "error", err.Error())
+			target.Status.Failed++
+		} else {
+			target.Status.Sent++
+		}
+	}
+
+	if target.Status.Processed() >= target.Status.Total {
+		target.Status.Phase = v1alpha1.ReplayPhaseCompleted
+		target.Status.CompletedAt = &now
+		action.L.Info("Replay state transition", "phase", target.Status.Phase, "sent", target.Status.Sent, "failed", target.Status.Failed)
+	}
+
+	return action.client.Status().Update(ctx, target)
+}
+
+// agents returns the Jolokia agents of the running pods of the integration
+func (action *replayAction) agents(ctx context.Context, replay *v1alpha1.Replay) ([]string, error) {
+	pods := corev1.PodList{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Pod",
+		},
+	}
+	options := k8sclient.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{
+			"camel.apache.org/integration": replay.Spec.Integration,
+		}),
+		Namespace: replay.Namespace,
+	}
+	if err := action.client.List(ctx, &options, &pods); err != nil {
+		return nil, err
+	}
+
+	agents := make([]string, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		if pod.Labels["camel.apache.org/integration"] != replay.Spec.Integration {
+			continue
+		}
+		if endpoint := jolokia.Endpoint(pod); endpoint != "" {
+			agents = append(agents, endpoint)
+		}
+	}
+
+	return agents, nil
+}
+
+// dueMessages returns the number of messages that should have been sent at the given time, given the rate
+func dueMessages(replay *v1alpha1.Replay, now time.Time) int {
+	elapsed := now.Sub(replay.Status.StartedAt.Time).Seconds()
+	due := int(elapsed*float64(replay.Spec.RatePerSecond())) + 1
+	if due > replay.Status.Total {
+		due = replay.Status.Total
+	}
+	return due
+}
@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// replayInterval is the interval the due messages of the running replays are sent at
+const replayInterval = time.Second
+
+// Add creates a new Replay Controller and adds it to the Manager. The Manager will set fields on the Controller
+// and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	c, err := client.FromManager(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, c))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(mgr manager.Manager, c client.Client) reconcile.Reconciler {
+	return &ReconcileReplay{
+		client: c,
+		scheme: mgr.GetScheme(),
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	// Create a new controller
+	c, err := controller.New("replay-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to primary resource Replay
+	err = c.Watch(&source.Kind{Type: &v1alpha1.Replay{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldReplay := e.ObjectOld.(*v1alpha1.Replay)
+			newReplay := e.ObjectNew.(*v1alpha1.Replay)
+			// Ignore updates to the replay status in which case metadata.Generation does not change,
+			// or except when the replay phase changes as it's used to transition from one phase
+			// to another
+			return oldReplay.Generation != newReplay.Generation ||
+				oldReplay.Status.Phase != newReplay.Status.Phase
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			// Evaluates to false if the object has been confirmed deleted
+			return !e.DeleteStateUnknown
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileReplay{}
+
+// ReconcileReplay reconciles a Replay object
+type ReconcileReplay struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+// Reconcile reads that state of the cluster for a Replay object and makes changes based on the state read
+// and what is in the Replay.Spec
+// Note:
+// The Controller will requeue the Request to be processed again if the returned error is non-nil or
+// Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
+func (r *ReconcileReplay) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rlog := Log.WithValues("request-namespace", request.Namespace, "request-name", request.Name)
+	rlog.Debug("Reconciling Replay")
+
+	ctx := context.TODO()
+
+	// Fetch the Replay instance
+	instance := &v1alpha1.Replay{}
+	err := r.client.Get(ctx, request.NamespacedName, instance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// Request object not found, could have been deleted after reconcile request.
+			// Return and don't requeue
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return reconcile.Result{}, err
+	}
+
+	replayActionPool := []Action{
+		NewInitializeAction(),
+		NewReplayAction(),
+	}
+
+	rplog := rlog.ForReplay(instance)
+	for _, a := range replayActionPool {
+		a.InjectClient(r.client)
+		a.InjectLogger(rplog)
+		if a.CanHandle(instance) {
+			rplog.Debugf("Invoking action %s", a.Name())
+			if err := a.Handle(ctx, instance); err != nil {
+				if k8serrors.IsConflict(err) {
+					rplog.Error(err, "conflict")
+					return reconcile.Result{
+						Requeue: true,
+					}, nil
+				}
+
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	// Fetch the Replay again and check the state
+	if err = r.client.Get(ctx, request.NamespacedName, instance); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if instance.Status.Phase == v1alpha1.ReplayPhaseRunning {
+		return reconcile.Result{
+			RequeueAfter: replayInterval,
+		}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
@@ -0,0 +1,207 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/log"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createReplayTestEnv(t *testing.T, status v1alpha1.ReplayStatus, objects ...runtime.Object) (*v1alpha1.Replay, client.Client) {
+	replay := v1alpha1.NewReplay("ns", "orders-replay")
+	replay.Spec = v1alpha1.ReplaySpec{
+		Integration: "orders",
+		Endpoint:    "direct:orders",
+		ConfigMap:   "orders-messages",
+		Rate:        2,
+		Repeat:      2,
+	}
+	replay.Status = status
+
+	c, err := test.NewFakeClient(append([]runtime.Object{&replay}, objects...)...)
+	assert.Nil(t, err)
+	return &replay, c
+}
+
+func createReplayTestMessages() *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "orders-messages",
+		},
+		Data: map[string]string{
+			"002": `{"id": 2}`,
+			"001": `{"id": 1}`,
+			"003": `{"id": 3}`,
+		},
+	}
+}
+
+func createReplayTestPod(name string, ip string) *corev1.Pod {
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      name,
+			Labels: map[string]string{
+				"camel.apache.org/integration": "orders",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "orders",
+					Ports: []corev1.ContainerPort{
+						{Name: "jolokia", ContainerPort: 8778},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: ip,
+		},
+	}
+}
+
+func TestInitializeReplay(t *testing.T) {
+	replay, c := createReplayTestEnv(t, v1alpha1.ReplayStatus{}, createReplayTestMessages())
+
+	action := NewInitializeAction()
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	assert.True(t, action.CanHandle(replay))
+	assert.Nil(t, action.Handle(context.TODO(), replay))
+
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "orders-replay"}, replay))
+	assert.Equal(t, v1alpha1.ReplayPhaseRunning, replay.Status.Phase)
+	assert.Equal(t, 6, replay.Status.Total)
+}
+
+func TestInitializeReplayWithoutMessages(t *testing.T) {
+	replay, c := createReplayTestEnv(t, v1alpha1.ReplayStatus{})
+
+	action := NewInitializeAction()
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	assert.Nil(t, action.Handle(context.TODO(), replay))
+
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "orders-replay"}, replay))
+	assert.Equal(t, v1alpha1.ReplayPhaseError, replay.Status.Phase)
+	assert.Equal(t, "config map orders-messages not found", replay.Status.Error)
+}
+
+func TestReplay(t *testing.T) {
+	replay, c := createReplayTestEnv(t, v1alpha1.ReplayStatus{Phase: v1alpha1.ReplayPhaseRunning, Total: 6},
+		createReplayTestMessages(), createReplayTestPod("orders-1", "10.0.0.1"), createReplayTestPod("orders-2", "10.0.0.2"))
+
+	type sent struct {
+		agent string
+		body  string
+	}
+	messages := make([]sent, 0)
+	// the times are serialized with a precision of a second
+	start := time.Now().Truncate(time.Second)
+	now := start
+
+	action := &replayAction{
+		send: func(ctx context.Context, agent string, uri string, body string) error {
+			assert.Equal(t, "direct:orders", uri)
+			messages = append(messages, sent{agent: agent, body: body})
+			if len(messages) == 4 {
+				return errors.New("no consumers available on endpoint")
+			}
+			return nil
+		},
+		now: func() time.Time { return now },
+	}
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+	key := k8sclient.ObjectKey{Namespace: "ns", Name: "orders-replay"}
+
+	// the first message is sent straight away
+	assert.Nil(t, action.Handle(context.TODO(), replay))
+	assert.Nil(t, c.Get(context.TODO(), key, replay))
+	assert.Equal(t, 1, replay.Status.Sent)
+	assert.NotNil(t, replay.Status.StartedAt)
+	assert.Equal(t, v1alpha1.ReplayPhaseRunning, replay.Status.Phase)
+
+	// 2 messages per second
+	now = start.Add(1500 * time.Millisecond)
+	assert.Nil(t, action.Handle(context.TODO(), replay))
+	assert.Nil(t, c.Get(context.TODO(), key, replay))
+	assert.Equal(t, 3, replay.Status.Sent)
+	assert.Equal(t, 1, replay.Status.Failed)
+	assert.Equal(t, v1alpha1.ReplayPhaseRunning, replay.Status.Phase)
+
+	now = start.Add(10 * time.Second)
+	assert.Nil(t, action.Handle(context.TODO(), replay))
+	assert.Nil(t, c.Get(context.TODO(), key, replay))
+	assert.Equal(t, 5, replay.Status.Sent)
+	assert.Equal(t, 1, replay.Status.Failed)
+	assert.Equal(t, v1alpha1.ReplayPhaseCompleted, replay.Status.Phase)
+	assert.NotNil(t, replay.Status.CompletedAt)
+
+	// the messages are replayed in the order of their keys, round-robin across the pods
+	assert.Len(t, messages, 6)
+	assert.Equal(t, `{"id": 1}`, messages[0].body)
+	assert.Equal(t, `{"id": 2}`, messages[1].body)
+	assert.Equal(t, `{"id": 1}`, messages[3].body)
+	assert.NotEqual(t, messages[0].agent, messages[1].agent)
+	assert.Equal(t, messages[0].agent, messages[2].agent)
+}
+
+func TestReplayWaitsForIntegration(t *testing.T) {
+	replay, c := createReplayTestEnv(t, v1alpha1.ReplayStatus{Phase: v1alpha1.ReplayPhaseRunning, Total: 6}, createReplayTestMessages())
+
+	action := &replayAction{
+		send: func(ctx context.Context, agent string, uri string, body string) error {
+			t.Fail()
+			return nil
+		},
+		now: time.Now,
+	}
+	action.InjectClient(c)
+	action.InjectLogger(log.Log)
+
+	assert.Nil(t, action.Handle(context.TODO(), replay))
+	assert.Nil(t, c.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "orders-replay"}, replay))
+	assert.Nil(t, replay.Status.StartedAt)
+	assert.Equal(t, 0, replay.Status.Processed())
+}
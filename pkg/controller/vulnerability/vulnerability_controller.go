@@ -0,0 +1,251 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vulnerability
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/proxy"
+	"github.com/apache/camel-k/pkg/util/scan"
+)
+
+// Add creates a new controller refreshing the vulnerabilities of the kit images and adds it to the Manager.
+// The Manager will set fields on the Controller and Start it when the Manager is Started.
+func Add(mgr manager.Manager) error {
+	c, err := client.FromManager(mgr)
+	if err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(c, scan.Run))
+}
+
+// newReconciler returns a new reconcile.Reconciler
+func newReconciler(c client.Client, scanner func(context.Context, scan.Context) (scan.Summary, error)) reconcile.Reconciler {
+	return &ReconcileVulnerability{
+		client:  c,
+		scanner: scanner,
+	}
+}
+
+// add adds a new Controller to mgr with r as the reconcile.Reconciler
+func add(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("vulnerability-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	// Watch for the kits getting ready, they are then requeued until the next scan
+	err = c.Watch(&source.Kind{Type: &v1alpha1.IntegrationKit{}}, &handler.EnqueueRequestForObject{}, predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldIntegrationKit := e.ObjectOld.(*v1alpha1.IntegrationKit)
+			newIntegrationKit := e.ObjectNew.(*v1alpha1.IntegrationKit)
+			return oldIntegrationKit.Status.Phase != newIntegrationKit.Status.Phase &&
+				newIntegrationKit.Status.Phase == v1alpha1.IntegrationKitPhaseReady
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Watch for changes to the image scan configuration of the platforms, and requeue the ready kits
+	err = c.Watch(&source.Kind{Type: &v1alpha1.IntegrationPlatform{}}, &handler.EnqueueRequestsFromMapFunc{
+		ToRequests: handler.ToRequestsFunc(func(a handler.MapObject) []reconcile.Request {
+			p := a.Object.(*v1alpha1.IntegrationPlatform)
+			requests := []reconcile.Request{}
+
+			list := v1alpha1.NewIntegrationKitList()
+			if err := mgr.GetClient().List(context.TODO(), &k8sclient.ListOptions{Namespace: p.Namespace}, &list); err != nil {
+				Log.Error(err, "Failed to retrieve integration kit list")
+				return requests
+			}
+
+			for _, kit := range list.Items {
+				if kit.Status.Phase == v1alpha1.IntegrationKitPhaseReady {
+					requests = append(requests, reconcile.Request{
+						NamespacedName: types.NamespacedName{
+							Namespace: kit.Namespace,
+							Name:      kit.Name,
+						},
+					})
+				}
+			}
+
+			return requests
+		}),
+	}, predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPlatform := e.ObjectOld.(*v1alpha1.IntegrationPlatform)
+			newPlatform := e.ObjectNew.(*v1alpha1.IntegrationPlatform)
+			return !reflect.DeepEqual(oldPlatform.Spec.Build.ImageScan, newPlatform.Spec.Build.ImageScan)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var _ reconcile.Reconciler = &ReconcileVulnerability{}
+
+// ReconcileVulnerability scans the images of the ready kits at the interval configured on the integration
+// platform, and reports the vulnerabilities found on the kits and the integrations using them
+type ReconcileVulnerability struct {
+	client  client.Client
+	scanner func(context.Context, scan.Context) (scan.Summary, error)
+}
+
+// Reconcile scans the image of the kit when due, and updates the vulnerabilities condition of the kit
+// and of the integrations using it
+func (r *ReconcileVulnerability) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	rlog := Log.WithValues("request-namespace", request.Namespace, "request-name", request.Name)
+	rlog.Debug("Reconciling IntegrationKit vulnerabilities")
+
+	ctx := context.TODO()
+
+	kit := &v1alpha1.IntegrationKit{}
+	err := r.client.Get(ctx, request.NamespacedName, kit)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if kit.Status.Phase != v1alpha1.IntegrationKitPhaseReady || kit.Status.Image == "" || v1alpha1.IsReconcilePaused(kit.ObjectMeta) {
+		return reconcile.Result{}, nil
+	}
+
+	pl, err := platform.GetCurrentPlatform(ctx, r.client, kit.Namespace)
+	if err != nil || pl.Spec.Build.ImageScan == nil {
+		// the images are not scanned
+		return reconcile.Result{}, nil
+	}
+	spec := pl.Spec.Build.ImageScan
+	interval := spec.Interval.Duration
+
+	klog := rlog.ForIntegrationKit(kit)
+	target := kit.DeepCopy()
+
+	// Kits built before the scanning has been enabled are scanned once, the others when the interval has elapsed
+	if kit.Status.ScannedAt == nil || interval > 0 && time.Since(kit.Status.ScannedAt.Time) >= interval {
+		summary, err := r.scanner(ctx, scan.Context{
+			Image:    kit.Status.Image,
+			Endpoint: spec.Endpoint,
+			Insecure: pl.Spec.Build.Registry.Insecure,
+			Client:   proxy.NewClient(pl.Spec.Build.Proxy),
+		})
+		if err != nil {
+			// the scanner may be temporarily unavailable, the scan is retried at the next interval
+			klog.Error(err, "Failure while scanning the kit image")
+			return reconcile.Result{RequeueAfter: interval}, nil
+		}
+
+		now := metav1.Now()
+		target.Status.Vulnerabilities = summary
+		target.Status.ScannedAt = &now
+	}
+
+	if c := platform.KitVulnerabilities(*spec, target.Status.Vulnerabilities); c != nil {
+		target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *c)
+	} else {
+		target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionVulnerabilities)
+	}
+
+	if !reflect.DeepEqual(kit.Status, target.Status) {
+		if c := v1alpha1.GetCondition(target.Status.Conditions, v1alpha1.ConditionVulnerabilities); c != nil {
+			klog.Info("Vulnerabilities found in the kit image", "image", target.Status.Image, "vulnerabilities", c.Message)
+		}
+		if err := r.client.Status().Update(ctx, target); err != nil {
+			if k8serrors.IsConflict(err) {
+				return reconcile.Result{Requeue: true}, nil
+			}
+			return reconcile.Result{}, err
+		}
+	}
+
+	if err := r.informIntegrations(ctx, target); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if interval > 0 {
+		return reconcile.Result{
+			RequeueAfter: interval - time.Since(target.Status.ScannedAt.Time),
+		}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// informIntegrations reports the vulnerabilities of the kit on the integrations using it
+func (r *ReconcileVulnerability) informIntegrations(ctx context.Context, kit *v1alpha1.IntegrationKit) error {
+	list := v1alpha1.NewIntegrationList()
+	if err := r.client.List(ctx, &k8sclient.ListOptions{Namespace: kit.Namespace}, &list); err != nil {
+		return err
+	}
+
+	condition := platform.IntegrationVulnerabilities(kit)
+	for _, integration := range list.Items {
+		if integration.Status.Kit != kit.Name {
+			continue
+		}
+
+		target := integration.DeepCopy()
+		if condition != nil {
+			target.Status.Conditions = v1alpha1.SetCondition(target.Status.Conditions, *condition)
+		} else {
+			target.Status.Conditions = v1alpha1.RemoveCondition(target.Status.Conditions, v1alpha1.ConditionVulnerabilities)
+		}
+
+		if reflect.DeepEqual(integration.Status, target.Status) {
+			continue
+		}
+		if err := r.client.Status().Update(ctx, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vulnerability
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/scan"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRefreshVulnerabilities(t *testing.T) {
+	p := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	p.Status.Phase = v1alpha1.IntegrationPlatformPhaseReady
+	p.Spec.Build.ImageScan = &v1alpha1.IntegrationPlatformImageScanSpec{
+		Severity: scan.SeverityHigh,
+		Interval: metav1.Duration{Duration: time.Hour},
+	}
+
+	scannedAt := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	kit := v1alpha1.NewIntegrationKit("ns", "my-kit")
+	kit.Status.Phase = v1alpha1.IntegrationKitPhaseReady
+	kit.Status.Image = "registry/my-kit:1"
+	kit.Status.ScannedAt = &scannedAt
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	integration.Status.Phase = v1alpha1.IntegrationPhaseRunning
+	integration.Status.Kit = "my-kit"
+
+	other := v1alpha1.NewIntegration("ns", "other-integration")
+	other.Status.Phase = v1alpha1.IntegrationPhaseRunning
+	other.Status.Kit = "other-kit"
+
+	c, err := test.NewFakeClient(&p, &kit, &integration, &other)
+	assert.Nil(t, err)
+
+	summary := scan.Summary{scan.SeverityCritical: 1, scan.SeverityLow: 4}
+	var scanErr error
+	scanned := make([]string, 0)
+	r := newReconciler(c, func(ctx context.Context, s scan.Context) (scan.Summary, error) {
+		scanned = append(scanned, s.Image)
+		return summary, scanErr
+	})
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "my-kit"}}
+
+	// the image is due for a scan
+	res, err := r.Reconcile(request)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"registry/my-kit:1"}, scanned)
+	assert.True(t, res.RequeueAfter > 59*time.Minute && res.RequeueAfter <= time.Hour)
+
+	assert.Nil(t, c.Get(context.TODO(), request.NamespacedName, &kit))
+	assert.Equal(t, map[string]int{"CRITICAL": 1, "LOW": 4}, kit.Status.Vulnerabilities)
+	condition := v1alpha1.GetCondition(kit.Status.Conditions, v1alpha1.ConditionVulnerabilities)
+	assert.NotNil(t, condition)
+	assert.Equal(t, "image contains 1 vulnerabilities at or above HIGH severity (CRITICAL=1, LOW=4)", condition.Message)
+
+	assert.Nil(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: "my-integration"}, &integration))
+	condition = v1alpha1.GetCondition(integration.Status.Conditions, v1alpha1.ConditionVulnerabilities)
+	assert.NotNil(t, condition)
+	assert.Equal(t, "kit my-kit image contains 1 vulnerabilities at or above HIGH severity (CRITICAL=1, LOW=4)", condition.Message)
+
+	assert.Nil(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: "other-integration"}, &other))
+	assert.Nil(t, v1alpha1.GetCondition(other.Status.Conditions, v1alpha1.ConditionVulnerabilities))
+
+	// the image is not scanned again before the interval has elapsed
+	_, err = r.Reconcile(request)
+	assert.Nil(t, err)
+	assert.Len(t, scanned, 1)
+
+	// scanner failures retain the last known vulnerabilities
+	scannedAt = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	kit.Status.ScannedAt = &scannedAt
+	assert.Nil(t, c.Status().Update(context.TODO(), &kit))
+	scanErr = errors.New("scanner unavailable")
+
+	res, err = r.Reconcile(request)
+	assert.Nil(t, err)
+	assert.Len(t, scanned, 2)
+	assert.Equal(t, time.Hour, res.RequeueAfter)
+	assert.Nil(t, c.Get(context.TODO(), request.NamespacedName, &kit))
+	assert.NotNil(t, v1alpha1.GetCondition(kit.Status.Conditions, v1alpha1.ConditionVulnerabilities))
+
+	// the conditions are removed once the vulnerabilities are fixed
+	summary = scan.Summary{scan.SeverityLow: 2}
+	scanErr = nil
+
+	_, err = r.Reconcile(request)
+	assert.Nil(t, err)
+	assert.Len(t, scanned, 3)
+	fixed := v1alpha1.IntegrationKit{}
+	assert.Nil(t, c.Get(context.TODO(), request.NamespacedName, &fixed))
+	assert.Nil(t, v1alpha1.GetCondition(fixed.Status.Conditions, v1alpha1.ConditionVulnerabilities))
+	informed := v1alpha1.Integration{}
+	assert.Nil(t, c.Get(context.TODO(), types.NamespacedName{Namespace: "ns", Name: "my-integration"}, &informed))
+	assert.Nil(t, v1alpha1.GetCondition(informed.Status.Conditions, v1alpha1.ConditionVulnerabilities))
+}
+
+func TestVulnerabilitiesNotScanned(t *testing.T) {
+	p := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	p.Status.Phase = v1alpha1.IntegrationPlatformPhaseReady
+
+	kit := v1alpha1.NewIntegrationKit("ns", "my-kit")
+	kit.Status.Phase = v1alpha1.IntegrationKitPhaseReady
+	kit.Status.Image = "registry/my-kit:1"
+
+	building := v1alpha1.NewIntegrationKit("ns", "building-kit")
+	building.Status.Phase = v1alpha1.IntegrationKitPhaseBuildRunning
+
+	c, err := test.NewFakeClient(&p, &kit, &building)
+	assert.Nil(t, err)
+
+	scanned := 0
+	r := newReconciler(c, func(ctx context.Context, s scan.Context) (scan.Summary, error) {
+		scanned++
+		return scan.Summary{}, nil
+	})
+
+	// the platform does not scan the images
+	_, err = r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "my-kit"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, scanned)
+
+	// the kits being built are scanned by the build
+	p.Spec.Build.ImageScan = &v1alpha1.IntegrationPlatformImageScanSpec{}
+	assert.Nil(t, c.Update(context.TODO(), &p))
+
+	_, err = r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "building-kit"}})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, scanned)
+
+	// the kits built before the scanning has been enabled are scanned once
+	for i := 0; i < 2; i++ {
+		res, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: "my-kit"}})
+		assert.Nil(t, err)
+		assert.Equal(t, reconcile.Result{}, res)
+	}
+	assert.Equal(t, 1, scanned)
+}
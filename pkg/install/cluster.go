@@ -74,6 +74,21 @@ func SetupClusterwideResourcesOrCollect(ctx context.Context, clientProvider clie
 		return err
 	}
 
+	// Install CRD for Replay (if needed)
+	if err := installCRD(ctx, c, "Replay", "crd-replay.yaml", collection); err != nil {
+		return err
+	}
+
+	// Install CRD for Kamelet (if needed)
+	if err := installCRD(ctx, c, "Kamelet", "crd-kamelet.yaml", collection); err != nil {
+		return err
+	}
+
+	// Install CRD for KameletBinding (if needed)
+	if err := installCRD(ctx, c, "KameletBinding", "crd-kamelet-binding.yaml", collection); err != nil {
+		return err
+	}
+
 	// Installing ClusterRole
 	clusterRoleInstalled, err := IsClusterRoleInstalled(ctx, c)
 	if err != nil {
@@ -86,6 +101,15 @@ func SetupClusterwideResourcesOrCollect(ctx context.Context, clientProvider clie
 		}
 	}
 
+	// Installing the ClusterRoles granting the operator access to the resources integrations depend on
+	if err := ResourcesOrCollect(ctx, c, "", collection, IdentityResourceCustomizer,
+		"operator-cluster-role-admission.yaml",
+		"operator-cluster-role-dependencies.yaml",
+		"operator-cluster-role-dependencies-strimzi.yaml",
+	); err != nil {
+		return err
+	}
+
 	// Wait for all CRDs to be installed before proceeding
 	if err := WaitForAllCRDInstallation(ctx, clientProvider, 25*time.Second); err != nil {
 		return err
@@ -139,7 +163,22 @@ func AreAllCRDInstalled(ctx context.Context, c client.Client) (bool, error) {
 	} else if !ok {
 		return false, nil
 	}
-	return IsCRDInstalled(ctx, c, "Build")
+	if ok, err := IsCRDInstalled(ctx, c, "Build"); err != nil {
+		return ok, err
+	} else if !ok {
+		return false, nil
+	}
+	if ok, err := IsCRDInstalled(ctx, c, "Replay"); err != nil {
+		return ok, err
+	} else if !ok {
+		return false, nil
+	}
+	if ok, err := IsCRDInstalled(ctx, c, "Kamelet"); err != nil {
+		return ok, err
+	} else if !ok {
+		return false, nil
+	}
+	return IsCRDInstalled(ctx, c, "KameletBinding")
 }
 
 // IsCRDInstalled check if the given CRD kind is installed
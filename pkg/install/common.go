@@ -107,6 +107,14 @@ func RuntimeObjectOrCollect(ctx context.Context, c client.Client, namespace stri
 		if obj.GetObjectKind().GroupVersionKind().Kind == "PersistentVolumeClaim" {
 			return nil
 		}
+		// Don't reset the tunables of an existing operator
+		if obj.GetObjectKind().GroupVersionKind().Kind == "ConfigMap" {
+			return nil
+		}
+		// Don't reset the secrets of existing service accounts
+		if obj.GetObjectKind().GroupVersionKind().Kind == "ServiceAccount" {
+			return nil
+		}
 		return c.Update(ctx, obj)
 	}
 	return err
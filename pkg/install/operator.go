@@ -20,8 +20,12 @@ package install
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	v1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/apache/camel-k/deploy"
@@ -35,17 +39,28 @@ import (
 
 // Operator installs the operator resources in the given namespace
 func Operator(ctx context.Context, c client.Client, customImage string, namespace string) error {
-	return OperatorOrCollect(ctx, c, namespace, customImage, nil)
+	return OperatorOrCollect(ctx, c, namespace, customImage, 0, nil)
 }
 
-// OperatorOrCollect installs the operator resources or adds them to the collector if present
-func OperatorOrCollect(ctx context.Context, c client.Client, namespace string, customImage string, collection *kubernetes.Collection) error {
+// OperatorOrCollect installs the operator resources or adds them to the collector if present.
+// The admission webhooks of the operator are enabled on the given port, if set
+func OperatorOrCollect(ctx context.Context, c client.Client, namespace string, customImage string, admissionPort int32, collection *kubernetes.Collection) error {
 	customizer := IdentityResourceCustomizer
-	if customImage != "" {
+	if customImage != "" || admissionPort > 0 {
 		customizer = func(o runtime.Object) runtime.Object {
 			if d, ok := o.(*v1.Deployment); ok {
 				if d.Labels["camel.apache.org/component"] == "operator" {
-					d.Spec.Template.Spec.Containers[0].Image = customImage
+					container := &d.Spec.Template.Spec.Containers[0]
+					if customImage != "" {
+						container.Image = customImage
+					}
+					if admissionPort > 0 {
+						container.Args = append(container.Args, fmt.Sprintf("--admission-port=%d", admissionPort))
+						container.Ports = append(container.Ports, corev1.ContainerPort{
+							Name:          "admission",
+							ContainerPort: admissionPort,
+						})
+					}
 				}
 			}
 			return o
@@ -64,6 +79,11 @@ func OperatorOrCollect(ctx context.Context, c client.Client, namespace string, c
 			return err
 		}
 	}
+	if admissionPort > 0 {
+		if err := AdmissionClusterRoleBindingOrCollect(ctx, c, namespace, collection); err != nil {
+			return err
+		}
+	}
 	// Additionally, install Knative resources (roles and bindings)
 	isKnative, err := knative.IsInstalled(ctx, c)
 	if err != nil {
@@ -80,6 +100,8 @@ func installOpenshift(ctx context.Context, c client.Client, namespace string, cu
 		"operator-service-account.yaml",
 		"operator-role-openshift.yaml",
 		"operator-role-binding.yaml",
+		"operator-role-binding-dependencies.yaml",
+		"operator-config.yaml",
 		"operator-deployment.yaml",
 	)
 }
@@ -89,6 +111,8 @@ func installKubernetes(ctx context.Context, c client.Client, namespace string, c
 		"operator-service-account.yaml",
 		"operator-role-kubernetes.yaml",
 		"operator-role-binding.yaml",
+		"operator-role-binding-dependencies.yaml",
+		"operator-config.yaml",
 		"operator-deployment.yaml",
 	)
 }
@@ -100,6 +124,69 @@ func installKnative(ctx context.Context, c client.Client, namespace string, coll
 	)
 }
 
+// BuildNamespaceRoleBindingOrCollect binds the operator role of the dedicated build namespace to the operator of
+// the given namespace, so that it can submit and monitor the builds of its kits in the build namespace
+func BuildNamespaceRoleBindingOrCollect(ctx context.Context, c client.Client, namespace string, buildNamespace string, collection *kubernetes.Collection) error {
+	rb := rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "RoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: buildNamespace,
+			Name:      "camel-k-operator-" + namespace,
+			Labels: map[string]string{
+				"app": "camel-k",
+			},
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Namespace: namespace,
+				Name:      "camel-k-operator",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     "camel-k-operator",
+		},
+	}
+
+	return RuntimeObjectOrCollect(ctx, c, buildNamespace, collection, &rb)
+}
+
+// AdmissionClusterRoleBindingOrCollect binds the admission cluster role to the operator of the given namespace,
+// so that it can install the configuration of its admission webhooks
+func AdmissionClusterRoleBindingOrCollect(ctx context.Context, c client.Client, namespace string, collection *kubernetes.Collection) error {
+	crb := rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterRoleBinding",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "camel-k-operator-admission-" + namespace,
+			Labels: map[string]string{
+				"app": "camel-k",
+			},
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Namespace: namespace,
+				Name:      "camel-k-operator",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "ClusterRole",
+			Name:     "camel-k:admission",
+		},
+	}
+
+	return RuntimeObjectOrCollect(ctx, c, "", collection, &crb)
+}
+
 // Platform installs the platform custom resource
 func Platform(ctx context.Context, c client.Client, namespace string, registry v1alpha1.IntegrationPlatformRegistrySpec) (*v1alpha1.IntegrationPlatform, error) {
 	return PlatformOrCollect(ctx, c, namespace, registry, nil)
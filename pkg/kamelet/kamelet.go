@@ -0,0 +1,153 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kamelet validates the kamelets and loads the ones that integrations reference with kamelet: URIs
+package kamelet
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// placeholder matches the property placeholders of a template, e.g. {{period}} or {{period:1s}}
+var placeholder = regexp.MustCompile(`\{\{([^{}]+)\}\}`)
+
+// Validate checks that the kamelet has a template written in a supported language, and that its properties are
+// declared with a supported type, their default values matching the type, and are the only ones the template uses
+func Validate(kamelet *v1alpha1.Kamelet) error {
+	template := kamelet.Spec.Template
+	if strings.TrimSpace(template.Content) == "" {
+		return errors.New("the template is required")
+	}
+	if language := template.InferLanguage(); !isSupported(language) {
+		return errors.Errorf("unsupported template language %q, the language or a name with a known extension is required", language)
+	}
+
+	definition := kamelet.Spec.Definition
+	for _, name := range sortedProperties(definition) {
+		property := definition.Properties[name]
+		if err := validateProperty(property); err != nil {
+			return errors.Wrapf(err, "invalid property %s", name)
+		}
+	}
+	for _, name := range definition.Required {
+		if _, ok := definition.Properties[name]; !ok {
+			return errors.Errorf("required property %s is not declared", name)
+		}
+	}
+
+	undeclared := make([]string, 0)
+	for _, match := range placeholder.FindAllStringSubmatch(template.Content, -1) {
+		key := match[1]
+		if strings.HasPrefix(key, "env:") || strings.HasPrefix(key, "sys:") {
+			continue
+		}
+		if i := strings.Index(key, ":"); i >= 0 {
+			key = key[:i]
+		}
+		if _, ok := definition.Properties[key]; !ok {
+			util.StringSliceUniqueAdd(&undeclared, key)
+		}
+	}
+	if len(undeclared) > 0 {
+		return errors.Errorf("the template uses undeclared properties: %s", strings.Join(undeclared, ", "))
+	}
+
+	return nil
+}
+
+// Load returns the kamelets with the given names from the namespace, failing when one of them does not exist or has
+// not been validated
+func Load(ctx context.Context, c k8sclient.Reader, namespace string, names []string) (map[string]v1alpha1.Kamelet, error) {
+	kamelets := make(map[string]v1alpha1.Kamelet, len(names))
+	for _, name := range names {
+		kamelet := v1alpha1.NewKamelet(namespace, name)
+		key := k8sclient.ObjectKey{
+			Namespace: namespace,
+			Name:      name,
+		}
+		if err := c.Get(ctx, key, &kamelet); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return nil, errors.Errorf("kamelet %s not found", name)
+			}
+			return nil, errors.Wrapf(err, "unable to load kamelet %s", name)
+		}
+		if !kamelet.IsReady() {
+			if kamelet.Status.Phase == v1alpha1.KameletPhaseError {
+				return nil, errors.Errorf("kamelet %s is invalid: %s", name, kamelet.Status.Error)
+			}
+			return nil, errors.Errorf("kamelet %s is not ready", name)
+		}
+		kamelets[name] = kamelet
+	}
+
+	return kamelets, nil
+}
+
+func validateProperty(property v1alpha1.KameletProperty) error {
+	var err error
+	switch property.PropertyType() {
+	case v1alpha1.KameletPropertyTypeString:
+	case v1alpha1.KameletPropertyTypeInteger:
+		if property.Default != "" {
+			_, err = strconv.ParseInt(property.Default, 10, 64)
+		}
+	case v1alpha1.KameletPropertyTypeNumber:
+		if property.Default != "" {
+			_, err = strconv.ParseFloat(property.Default, 64)
+		}
+	case v1alpha1.KameletPropertyTypeBoolean:
+		if property.Default != "" {
+			_, err = strconv.ParseBool(property.Default)
+		}
+	default:
+		return fmt.Errorf("unsupported type %s", property.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("default value %s is not a valid %s", property.Default, property.PropertyType())
+	}
+	return nil
+}
+
+func isSupported(language v1alpha1.Language) bool {
+	for _, l := range v1alpha1.Languages {
+		if l == language && l != v1alpha1.LanguageJavaClass {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedProperties(definition v1alpha1.KameletDefinition) []string {
+	names := make([]string, 0, len(definition.Properties))
+	for name := range definition.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
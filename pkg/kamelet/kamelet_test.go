@@ -0,0 +1,131 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kamelet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestKamelet(name string) v1alpha1.Kamelet {
+	kamelet := v1alpha1.NewKamelet("ns", name)
+	kamelet.Spec.Definition = v1alpha1.KameletDefinition{
+		Title:    "Timer",
+		Required: []string{"message"},
+		Properties: map[string]v1alpha1.KameletProperty{
+			"message": {Title: "Message"},
+			"period":  {Type: v1alpha1.KameletPropertyTypeInteger, Default: "1000"},
+		},
+	}
+	kamelet.Spec.Template = v1alpha1.NewSourceSpec("timer.groovy",
+		`from('timer:tick?period={{period}}').setBody().constant('{{message}}').to('kamelet:sink')`, "")
+	return kamelet
+}
+
+func TestValidate(t *testing.T) {
+	kamelet := newTestKamelet("timer")
+	assert.Nil(t, Validate(&kamelet))
+
+	kamelet.Spec.Template.Content = `from('timer:tick?period={{period:500}}').setBody().simple('{{env:MESSAGE}}').to('kamelet:sink')`
+	assert.Nil(t, Validate(&kamelet))
+
+	testCases := []struct {
+		name   string
+		update func(k *v1alpha1.Kamelet)
+		err    string
+	}{
+		{
+			name:   "missing template",
+			update: func(k *v1alpha1.Kamelet) { k.Spec.Template.Content = " " },
+			err:    "the template is required",
+		},
+		{
+			name:   "unknown language",
+			update: func(k *v1alpha1.Kamelet) { k.Spec.Template.Name = "timer.txt" },
+			err:    `unsupported template language "", the language or a name with a known extension is required`,
+		},
+		{
+			name: "unsupported type",
+			update: func(k *v1alpha1.Kamelet) {
+				k.Spec.Definition.Properties["message"] = v1alpha1.KameletProperty{Type: "object"}
+			},
+			err: "invalid property message: unsupported type object",
+		},
+		{
+			name: "invalid default",
+			update: func(k *v1alpha1.Kamelet) {
+				k.Spec.Definition.Properties["period"] = v1alpha1.KameletProperty{Type: v1alpha1.KameletPropertyTypeInteger, Default: "1s"}
+			},
+			err: "invalid property period: default value 1s is not a valid integer",
+		},
+		{
+			name:   "undeclared required property",
+			update: func(k *v1alpha1.Kamelet) { k.Spec.Definition.Required = []string{"message", "count"} },
+			err:    "required property count is not declared",
+		},
+		{
+			name:   "undeclared placeholders",
+			update: func(k *v1alpha1.Kamelet) { k.Spec.Template.Content += `.to('log:{{logger}}?level={{level:INFO}}')` },
+			err:    "the template uses undeclared properties: logger, level",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			kamelet := newTestKamelet("timer")
+			tc.update(&kamelet)
+
+			err := Validate(&kamelet)
+			assert.NotNil(t, err)
+			assert.Equal(t, tc.err, err.Error())
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	ready := newTestKamelet("ready")
+	ready.Status.Phase = v1alpha1.KameletPhaseReady
+	invalid := newTestKamelet("invalid")
+	invalid.Status.Phase = v1alpha1.KameletPhaseError
+	invalid.Status.Error = "the template is required"
+	pending := newTestKamelet("pending")
+
+	c, err := test.NewFakeClient(&ready, &invalid, &pending)
+	assert.Nil(t, err)
+
+	kamelets, err := Load(context.TODO(), c, "ns", []string{"ready"})
+	assert.Nil(t, err)
+	assert.Len(t, kamelets, 1)
+	assert.Equal(t, "timer.groovy", kamelets["ready"].Spec.Template.Name)
+
+	kamelets, err = Load(context.TODO(), c, "ns", nil)
+	assert.Nil(t, err)
+	assert.Empty(t, kamelets)
+
+	_, err = Load(context.TODO(), c, "ns", []string{"ready", "invalid"})
+	assert.EqualError(t, err, "kamelet invalid is invalid: the template is required")
+	_, err = Load(context.TODO(), c, "ns", []string{"pending"})
+	assert.EqualError(t, err, "kamelet pending is not ready")
+	_, err = Load(context.TODO(), c, "ns", []string{"missing"})
+	assert.EqualError(t, err, "kamelet missing not found")
+}
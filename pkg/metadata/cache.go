@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/digest"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	src "github.com/apache/camel-k/pkg/util/source"
+)
+
+// maxCachedExtractions bounds the number of sources whose metadata are cached
+const maxCachedExtractions = 1024
+
+var (
+	cacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "camel_k_metadata_cache_hits_total",
+		Help: "Number of source metadata extractions served from the cache",
+	})
+	cacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "camel_k_metadata_cache_misses_total",
+		Help: "Number of source metadata extractions that required parsing the source",
+	})
+
+	// extractions caches the metadata extracted from the sources, as they are extracted again on
+	// every reconciliation of the integrations while their sources rarely change
+	extractions = newExtractionCache(maxCachedExtractions)
+)
+
+func init() {
+	metrics.Registry.MustRegister(cacheHits, cacheMisses)
+}
+
+// extractionCache holds the metadata of the sources keyed by the digest of the source and the version of the
+// catalog they have been extracted with. The metadata are copied in and out, so that callers can modify them
+type extractionCache struct {
+	lock    sync.Mutex
+	size    int
+	entries map[string]IntegrationMetadata
+}
+
+func newExtractionCache(size int) *extractionCache {
+	return &extractionCache{
+		size:    size,
+		entries: make(map[string]IntegrationMetadata),
+	}
+}
+
+// key returns the cache key of the source, or an empty string if the source cannot be cached
+func (c *extractionCache) key(catalog *camel.RuntimeCatalog, source v1alpha1.SourceSpec) string {
+	if catalog == nil {
+		return ""
+	}
+	d, err := digest.ComputeForSource(source)
+	if err != nil {
+		return ""
+	}
+	return catalog.Version + "/" + d
+}
+
+func (c *extractionCache) get(key string) (IntegrationMetadata, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	m, ok := c.entries[key]
+	if !ok {
+		cacheMisses.Inc()
+		return IntegrationMetadata{}, false
+	}
+
+	cacheHits.Inc()
+	return copyMetadata(m), true
+}
+
+func (c *extractionCache) put(key string, m IntegrationMetadata) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		// an arbitrary entry is evicted, the sources of the running integrations being cached again
+		// on their next reconciliation
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+
+	c.entries[key] = copyMetadata(m)
+}
+
+// copyMetadata returns a deep copy of the metadata, retaining the nil and empty slices as they are
+func copyMetadata(m IntegrationMetadata) IntegrationMetadata {
+	c := m
+	c.FromURIs = copyStrings(m.FromURIs)
+	c.ToURIs = copyStrings(m.ToURIs)
+	c.Dependencies = copyStrings(m.Dependencies)
+	c.HTTPURIs = copyStrings(m.HTTPURIs)
+	c.RestPaths = copyStrings(m.RestPaths)
+	if m.Routes != nil {
+		c.Routes = make([]src.Route, len(m.Routes))
+		for i, r := range m.Routes {
+			r.To = copyStrings(r.To)
+			c.Routes[i] = r
+		}
+	}
+	return c
+}
+
+func copyStrings(s []string) []string {
+	if s == nil {
+		return nil
+	}
+	return append(make([]string, 0, len(s)), s...)
+}
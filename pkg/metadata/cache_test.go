@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestExtractionCache(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	source := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name:    "cached.groovy",
+			Content: "from('timer:cached').routeId('cached').to('log:info')",
+		},
+		Language: v1alpha1.LanguageGroovy,
+	}
+
+	hits := testutil.ToFloat64(cacheHits)
+	misses := testutil.ToFloat64(cacheMisses)
+
+	first := Extract(catalog, source)
+	assert.Equal(t, misses+1, testutil.ToFloat64(cacheMisses))
+	assert.Equal(t, hits, testutil.ToFloat64(cacheHits))
+
+	// the metadata returned by the cache can be modified by the callers
+	first.FromURIs[0] = "timer:modified"
+	first.Routes[0].To[0] = "log:modified"
+
+	second := Extract(catalog, source)
+	assert.Equal(t, hits+1, testutil.ToFloat64(cacheHits))
+	assert.Equal(t, []string{"timer:cached"}, second.FromURIs)
+	assert.Equal(t, []string{"log:info"}, second.Routes[0].To)
+	assert.Equal(t, []string{"camel:core"}, second.Dependencies)
+
+	source.Content = "from('timer:changed').to('log:info')"
+	third := Extract(catalog, source)
+	assert.Equal(t, misses+2, testutil.ToFloat64(cacheMisses))
+	assert.Equal(t, []string{"timer:changed"}, third.FromURIs)
+}
+
+func TestExtractionCacheEviction(t *testing.T) {
+	cache := newExtractionCache(2)
+	cache.put("a", IntegrationMetadata{RestPort: 1})
+	cache.put("b", IntegrationMetadata{RestPort: 2})
+	cache.put("b", IntegrationMetadata{RestPort: 3})
+	assert.Len(t, cache.entries, 2)
+
+	cache.put("c", IntegrationMetadata{RestPort: 4})
+	assert.Len(t, cache.entries, 2)
+
+	m, ok := cache.get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 4, m.RestPort)
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
+)
+
+// DeprecationWarnings returns a warning for each deprecated component the given endpoint URIs belong to
+func DeprecationWarnings(catalog *camel.RuntimeCatalog, uris []string) []string {
+	schemes := make(map[string][]string)
+	for _, uri := range uris {
+		scheme := uriutil.Scheme(uri)
+		if artifact := catalog.GetArtifactByScheme(scheme); artifact != nil && artifact.Deprecated {
+			s := schemes[artifact.ArtifactID]
+			util.StringSliceUniqueAdd(&s, scheme)
+			schemes[artifact.ArtifactID] = s
+		}
+	}
+
+	warnings := make([]string, 0, len(schemes))
+	for id, s := range schemes {
+		sort.Strings(s)
+
+		warning := fmt.Sprintf("component %s (%s) is deprecated", id, strings.Join(s, ", "))
+		if replacement := catalog.Artifacts[id].ReplacedBy; replacement != "" {
+			warning += fmt.Sprintf(", use %s instead", replacement)
+		}
+		warnings = append(warnings, warning)
+	}
+
+	sort.Strings(warnings)
+
+	return warnings
+}
@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecationWarnings(t *testing.T) {
+	source := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name: "routes.groovy",
+			Content: `
+				from('quartz:tick?cron=0+0/5+*+*+*+?')
+					.to('http://localhost:8080/a')
+					.to('https://localhost:8443/b')
+					.to('log:info')
+			`,
+		},
+		Language: v1alpha1.LanguageGroovy,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := Extract(catalog, source)
+	warnings := DeprecationWarnings(catalog, append(meta.FromURIs, meta.ToURIs...))
+
+	assert.Equal(t, []string{
+		"component camel-http (http, https) is deprecated, use camel-http4 instead",
+		"component camel-quartz (quartz) is deprecated, use camel-quartz2 instead",
+	}, warnings)
+
+	assert.Empty(t, DeprecationWarnings(catalog, []string{"timer:tick", "http4://localhost"}))
+}
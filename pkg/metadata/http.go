@@ -19,26 +19,27 @@ package metadata
 
 import (
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
+	"github.com/apache/camel-k/pkg/util"
+
 	"github.com/apache/camel-k/pkg/util/camel"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	src "github.com/apache/camel-k/pkg/util/source"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
 )
 
 var restIndicator = regexp.MustCompile(`.*rest\s*\([^)]*\).*`)
-var xmlRestIndicator = regexp.MustCompile(`.*<\s*rest\s+[^>]*>.*`)
-
-// requiresHTTPService returns true if the integration needs to expose itself through HTTP
-func requiresHTTPService(catalog *camel.RuntimeCatalog, source v1alpha1.SourceSpec, fromURIs []string) bool {
-	if hasRestIndicator(source) {
-		return true
-	}
-	return containsHTTPURIs(catalog, fromURIs)
-}
+var yamlRestIndicator = regexp.MustCompile(`(?m)^\s*(?:-\s*)?rest\s*:`)
+var restPath = regexp.MustCompile(`\brest\s*\(\s*["']([^"']+)["']`)
+var restConfigurationPort = regexp.MustCompile(`(?s)\brestConfiguration\s*\(\s*\).*?\.port\s*\(\s*["']?([0-9]+)`)
+var restConfigurationContextPath = regexp.MustCompile(`(?s)\brestConfiguration\s*\(\s*\).*?\.contextPath\s*\(\s*["']([^"']*)["']`)
 
 // hasOnlyPassiveEndpoints returns true if the integration has no endpoint that needs to remain always active
-func hasOnlyPassiveEndpoints(catalog *camel.RuntimeCatalog, _ v1alpha1.SourceSpec, fromURIs []string) bool {
+func hasOnlyPassiveEndpoints(catalog *camel.RuntimeCatalog, fromURIs []string) bool {
 	passivePlusHTTP := make(map[string]bool)
 	catalog.VisitSchemes(func(id string, scheme v1alpha1.CamelScheme) bool {
 		if scheme.HTTP || scheme.Passive {
@@ -51,27 +52,20 @@ func hasOnlyPassiveEndpoints(catalog *camel.RuntimeCatalog, _ v1alpha1.SourceSpe
 	return containsOnlyURIsIn(fromURIs, passivePlusHTTP)
 }
 
-func containsHTTPURIs(catalog *camel.RuntimeCatalog, fromURI []string) bool {
-	for _, uri := range fromURI {
-		prefix := getURIPrefix(uri)
-		scheme, ok := catalog.GetScheme(prefix)
-
-		if !ok {
-			// scheme dees not exists
-			continue
-		}
-
-		if scheme.HTTP {
-			return true
+// httpURIs returns the given consumer URIs whose scheme exposes HTTP according to the catalog
+func httpURIs(catalog *camel.RuntimeCatalog, fromURIs []string) []string {
+	uris := make([]string, 0)
+	for _, uri := range fromURIs {
+		if scheme, ok := catalog.GetScheme(uriutil.Scheme(uri)); ok && scheme.HTTP {
+			util.StringSliceUniqueAdd(&uris, uri)
 		}
 	}
-
-	return false
+	return uris
 }
 
 func containsOnlyURIsIn(fromURI []string, allowed map[string]bool) bool {
 	for _, uri := range fromURI {
-		prefix := getURIPrefix(uri)
+		prefix := uriutil.Scheme(uri)
 		if enabled, ok := allowed[prefix]; !ok || !enabled {
 			return false
 		}
@@ -79,24 +73,72 @@ func containsOnlyURIsIn(fromURI []string, allowed map[string]bool) bool {
 	return true
 }
 
-func getURIPrefix(uri string) string {
-	parts := strings.SplitN(uri, ":", 2)
-	if len(parts) > 0 {
-		return parts[0]
+// hasRestIndicator returns true if the source exposes REST DSL definitions, the rest calls of the source code being
+// looked for out of comments and string literals
+func hasRestIndicator(source v1alpha1.SourceSpec) bool {
+	switch source.InferLanguage() {
+	case v1alpha1.LanguageJavaSource, v1alpha1.LanguageGroovy, v1alpha1.LanguageKotlin, v1alpha1.LanguageJavaScript:
+		return src.ContainsCall(source.Content, "rest")
+	case v1alpha1.LanguageXML:
+		return src.ParseXMLRoutes(source.Content).Rest
+	case v1alpha1.LanguageYamlFlow:
+		return yamlRestIndicator.MatchString(source.Content)
+	default:
+		return restIndicator.MatchString(source.Content)
 	}
-	return ""
 }
 
-func hasRestIndicator(source v1alpha1.SourceSpec) bool {
-	pat := getRestIndicatorRegexpsForLanguage(source.InferLanguage())
-	return pat.MatchString(source.Content)
+// restPaths returns the base paths exposed by the REST DSL definitions of the source, without any
+// path parameter (e.g. "/api/{id}" is exposed as "/api")
+func restPaths(source v1alpha1.SourceSpec) []string {
+	var candidates []string
+	if source.InferLanguage() == v1alpha1.LanguageXML {
+		candidates = src.ParseXMLRoutes(source.Content).RestPaths
+	} else {
+		candidates = util.FindAllDistinctStringSubmatch(source.Content, restPath)
+	}
+
+	paths := make([]string, 0)
+	for _, p := range candidates {
+		if i := strings.Index(p, "{"); i >= 0 {
+			p = p[:i]
+		}
+		p = "/" + strings.Trim(p, "/ ")
+		util.StringSliceUniqueAdd(&paths, p)
+	}
+	sort.Strings(paths)
+
+	return paths
 }
 
-func getRestIndicatorRegexpsForLanguage(language v1alpha1.Language) *regexp.Regexp {
-	switch language {
-	case v1alpha1.LanguageXML:
-		return xmlRestIndicator
-	default:
-		return restIndicator
+// restPort returns the port the REST DSL is configured to listen to, or 0 if not configured
+func restPort(source v1alpha1.SourceSpec) int {
+	if source.InferLanguage() == v1alpha1.LanguageXML {
+		return src.ParseXMLRoutes(source.Content).RestPort
+	}
+
+	match := restConfigurationPort.FindStringSubmatch(source.Content)
+	if len(match) < 2 {
+		return 0
+	}
+	port, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0
 	}
+
+	return port
+}
+
+// restContextPath returns the context path the REST DSL is configured with, or an empty string if not configured
+func restContextPath(source v1alpha1.SourceSpec) string {
+	if source.InferLanguage() == v1alpha1.LanguageXML {
+		return src.ParseXMLRoutes(source.Content).RestContextPath
+	}
+
+	match := restConfigurationContextPath.FindStringSubmatch(source.Content)
+	if len(match) < 2 {
+		return ""
+	}
+
+	return match[1]
 }
@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
+)
+
+// Kamelets returns the sorted names of the kamelets the endpoint URIs reference, e.g. telegram for
+// kamelet:telegram/route-1?chatId=123
+func Kamelets(meta IntegrationMetadata) []string {
+	names := make([]string, 0)
+	for _, uri := range util.StringSliceJoin(meta.FromURIs, meta.ToURIs) {
+		if name := kameletName(uri); name != "" {
+			util.StringSliceUniqueAdd(&names, name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ResolveKamelets adds the dependencies of the kamelets the endpoint URIs reference, i.e. those of the components
+// their templates use and those they declare, so that the kamelets can be used like any other component. The
+// kamelets that are not given are ignored
+func ResolveKamelets(catalog *camel.RuntimeCatalog, meta IntegrationMetadata, kamelets map[string]v1alpha1.Kamelet) IntegrationMetadata {
+	resolved := meta
+	resolved.Dependencies = append([]string{}, meta.Dependencies...)
+	for _, name := range Kamelets(meta) {
+		kamelet, ok := kamelets[name]
+		if !ok {
+			continue
+		}
+		for _, d := range Extract(catalog, kamelet.Spec.Template).Dependencies {
+			util.StringSliceUniqueAdd(&resolved.Dependencies, d)
+		}
+		for _, d := range kamelet.Spec.Dependencies {
+			util.StringSliceUniqueAdd(&resolved.Dependencies, d)
+		}
+	}
+	sort.Strings(resolved.Dependencies)
+
+	return resolved
+}
+
+// kameletName returns the name of the kamelet the given URI references, or an empty string if it's not a kamelet
+// URI or if the name is a property placeholder
+func kameletName(uri string) string {
+	u, err := uriutil.Parse(uri)
+	if err != nil || u.Scheme != v1alpha1.KameletScheme || strings.Contains(u.Path, "{{") {
+		return ""
+	}
+	if i := strings.Index(u.Path, "/"); i >= 0 {
+		return u.Path[:i]
+	}
+
+	return u.Path
+}
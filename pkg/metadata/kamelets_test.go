@@ -0,0 +1,53 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKamelets(t *testing.T) {
+	meta := IntegrationMetadata{}
+	meta.FromURIs = []string{"kamelet:telegram/source?chatId=123", "timer:tick"}
+	meta.ToURIs = []string{"kamelet://slack", "kamelet:telegram", "kamelet:{{sink}}", "log:info"}
+
+	assert.Equal(t, []string{"slack", "telegram"}, Kamelets(meta))
+}
+
+func TestResolveKamelets(t *testing.T) {
+	source := v1alpha1.NewSourceSpec("routes.groovy", `from('kamelet:telegram').to('kamelet:slack')`, v1alpha1.LanguageGroovy)
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := Extract(catalog, source)
+	assert.Empty(t, meta.Dependencies)
+
+	telegram := v1alpha1.NewKamelet("ns", "telegram")
+	telegram.Spec.Template = v1alpha1.NewSourceSpec("telegram.groovy", `from('telegram:bots').to('kamelet:sink')`, "")
+	telegram.Spec.Dependencies = []string{"mvn:org.acme:telegram-converters:1.0"}
+
+	resolved := ResolveKamelets(catalog, meta, map[string]v1alpha1.Kamelet{"telegram": telegram})
+	assert.Equal(t, []string{"camel:telegram", "mvn:org.acme:telegram-converters:1.0"}, resolved.Dependencies)
+	assert.Empty(t, meta.Dependencies)
+}
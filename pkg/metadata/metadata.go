@@ -18,7 +18,12 @@ limitations under the License.
 package metadata
 
 import (
+	"fmt"
+	"runtime"
 	"sort"
+	"sync"
+
+	"github.com/scylladb/go-set/strset"
 
 	"github.com/apache/camel-k/pkg/util/camel"
 
@@ -28,22 +33,80 @@ import (
 	src "github.com/apache/camel-k/pkg/util/source"
 )
 
-// ExtractAll returns metadata information from all listed source codes
-func ExtractAll(catalog *camel.RuntimeCatalog, sources []v1alpha1.SourceSpec) IntegrationMetadata {
-	// neutral metadata
-	meta := IntegrationMetadata{
-		Metadata: src.Metadata{
-			FromURIs:     []string{},
-			ToURIs:       []string{},
-			Dependencies: []string{},
+// maxParallelExtractions bounds the number of sources whose metadata are extracted concurrently
+var maxParallelExtractions = runtime.NumCPU()
+
+// SourceError reports a source whose metadata could not be fully extracted
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+func (e SourceError) Error() string {
+	return fmt.Sprintf("unable to extract metadata from source %s: %v", e.Source, e.Err)
+}
+
+// BatchMetadata contains the metadata extracted from many sources, combined and per source
+type BatchMetadata struct {
+	IntegrationMetadata
+	// Sources contains the metadata of each source, in the order of the sources
+	Sources []IntegrationMetadata
+	// Errors reports the sources whose metadata could not be fully extracted
+	Errors []SourceError
+}
+
+// ExtractBatch returns metadata information from all listed source codes, that are inspected in parallel
+func ExtractBatch(catalog *camel.RuntimeCatalog, sources []v1alpha1.SourceSpec) BatchMetadata {
+	batch := BatchMetadata{
+		// neutral metadata
+		IntegrationMetadata: IntegrationMetadata{
+			Metadata: src.Metadata{
+				FromURIs:     []string{},
+				ToURIs:       []string{},
+				Dependencies: []string{},
+				Routes:       []src.Route{},
+			},
+			PassiveEndpoints:    true,
+			RequiresHTTPService: false,
+			HTTPURIs:            []string{},
+			RestPaths:           []string{},
 		},
-		PassiveEndpoints:    true,
-		RequiresHTTPService: false,
+		Sources: make([]IntegrationMetadata, len(sources)),
+	}
+
+	errs := make([]error, len(sources))
+	slots := make(chan struct{}, maxParallelExtractions)
+	wg := sync.WaitGroup{}
+	for i := range sources {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			batch.Sources[i], errs[i] = extract(catalog, sources[i])
+		}(i)
 	}
-	for _, source := range sources {
-		meta = merge(meta, Extract(catalog, source))
+	wg.Wait()
+
+	// merged in the order of the sources so that the result does not depend on the scheduling
+	for i, meta := range batch.Sources {
+		batch.IntegrationMetadata = merge(batch.IntegrationMetadata, meta)
+		if errs[i] != nil {
+			batch.Errors = append(batch.Errors, SourceError{Source: sources[i].Name, Err: errs[i]})
+		}
+	}
+
+	return batch
+}
+
+// ExtractAll returns metadata information from all listed source codes
+func ExtractAll(catalog *camel.RuntimeCatalog, sources []v1alpha1.SourceSpec) IntegrationMetadata {
+	batch := ExtractBatch(catalog, sources)
+	for _, err := range batch.Errors {
+		log.Error(err.Err, "unable to extract metadata", "source", err.Source)
 	}
-	return meta
+	return batch.IntegrationMetadata
 }
 
 func merge(m1 IntegrationMetadata, m2 IntegrationMetadata) IntegrationMetadata {
@@ -59,43 +122,95 @@ func merge(m1 IntegrationMetadata, m2 IntegrationMetadata) IntegrationMetadata {
 		allDependencies = append(allDependencies, k)
 	}
 	sort.Strings(allDependencies)
+	restPaths := strset.Union(strset.New(m1.RestPaths...), strset.New(m2.RestPaths...)).List()
+	sort.Strings(restPaths)
+	restPort := m1.RestPort
+	if restPort == 0 {
+		restPort = m2.RestPort
+	}
+	restContextPath := m1.RestContextPath
+	if restContextPath == "" {
+		restContextPath = m2.RestContextPath
+	}
 	return IntegrationMetadata{
 		Metadata: src.Metadata{
 			FromURIs:     append(m1.FromURIs, m2.FromURIs...),
 			ToURIs:       append(m1.ToURIs, m2.ToURIs...),
 			Dependencies: allDependencies,
+			Routes:       append(m1.Routes, m2.Routes...),
 		},
 		RequiresHTTPService: m1.RequiresHTTPService || m2.RequiresHTTPService,
+		RestDSL:             m1.RestDSL || m2.RestDSL,
+		HTTPURIs:            append(m1.HTTPURIs, m2.HTTPURIs...),
 		PassiveEndpoints:    m1.PassiveEndpoints && m2.PassiveEndpoints,
+		RestPaths:           restPaths,
+		RestPort:            restPort,
+		RestContextPath:     restContextPath,
 	}
 }
 
 // Extract returns metadata information from the source code
 func Extract(catalog *camel.RuntimeCatalog, source v1alpha1.SourceSpec) IntegrationMetadata {
-	var err error
-	source, err = uncompress(source)
+	m, err := extract(catalog, source)
 	if err != nil {
-		log.Errorf(err, "unable to uncompress source %s: %v", source.Name, err)
+		log.Error(err, "unable to extract metadata", "source", source.Name)
+	}
+	return m
+}
+
+// extract returns metadata information from the source code, along with the first error met, the metadata
+// being extracted on a best effort basis. The metadata of the sources that have already been inspected are
+// taken from the cache, the sources that cannot be fully inspected being inspected again
+func extract(catalog *camel.RuntimeCatalog, source v1alpha1.SourceSpec) (IntegrationMetadata, error) {
+	key := extractions.key(catalog, source)
+	if key != "" {
+		if m, ok := extractions.get(key); ok {
+			return m, nil
+		}
+	}
+
+	m, err := inspect(catalog, source)
+	if err == nil && key != "" {
+		extractions.put(key, m)
+	}
+
+	return m, err
+}
+
+// inspect returns metadata information from the source code, along with the first error met
+func inspect(catalog *camel.RuntimeCatalog, source v1alpha1.SourceSpec) (IntegrationMetadata, error) {
+	source, err := uncompress(source)
+	if err != nil {
+		err = fmt.Errorf("unable to uncompress source: %v", err)
 	}
 
 	language := source.InferLanguage()
 
 	m := IntegrationMetadata{}
 
-	// TODO: handle error
-	_ = src.InspectorForLanguage(catalog, language).Extract(source, &m.Metadata)
+	if ierr := src.InspectorForLanguage(catalog, language).Extract(source, &m.Metadata); ierr != nil && err == nil {
+		err = ierr
+	}
 
-	m.RequiresHTTPService = requiresHTTPService(catalog, source, m.FromURIs)
-	m.PassiveEndpoints = hasOnlyPassiveEndpoints(catalog, source, m.FromURIs)
+	m.RestDSL = hasRestIndicator(source)
+	m.HTTPURIs = httpURIs(catalog, m.FromURIs)
+	m.RequiresHTTPService = m.RestDSL || len(m.HTTPURIs) > 0
+	m.PassiveEndpoints = hasOnlyPassiveEndpoints(catalog, m.FromURIs)
+	m.RestPaths = restPaths(source)
+	m.RestPort = restPort(source)
+	m.RestContextPath = restContextPath(source)
 
-	return m
+	return m, err
 }
 
 // Each --
 func Each(catalog *camel.RuntimeCatalog, sources []v1alpha1.SourceSpec, consumer func(int, IntegrationMetadata) bool) {
-	for i, s := range sources {
-		meta := Extract(catalog, s)
+	batch := ExtractBatch(catalog, sources)
+	for _, err := range batch.Errors {
+		log.Error(err.Err, "unable to extract metadata", "source", err.Source)
+	}
 
+	for i, meta := range batch.Sources {
 		if !consumer(i, meta) {
 			break
 		}
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractBatch(t *testing.T) {
+	sources := make([]v1alpha1.SourceSpec, 0)
+	for i := 0; i < 20; i++ {
+		sources = append(sources, v1alpha1.SourceSpec{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    fmt.Sprintf("route%d.groovy", i),
+				Content: fmt.Sprintf(`from("telegram:bots/b%d").to("amqp:queue")`, i),
+			},
+		})
+	}
+	sources = append(sources, v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name:        "broken.groovy",
+			Content:     "not base64 gzipped content",
+			Compression: true,
+		},
+	})
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	batch := ExtractBatch(catalog, sources)
+
+	assert.Len(t, batch.Sources, 21)
+	assert.Len(t, batch.FromURIs, 20)
+	for i := 0; i < 20; i++ {
+		uri := fmt.Sprintf("telegram:bots/b%d", i)
+		assert.Equal(t, []string{uri}, batch.Sources[i].FromURIs)
+		assert.Equal(t, uri, batch.FromURIs[i])
+	}
+	assert.Equal(t, []string{"camel:amqp", "camel:telegram"}, batch.Dependencies)
+	assert.Equal(t, ExtractAll(catalog, sources), batch.IntegrationMetadata)
+
+	assert.Len(t, batch.Errors, 1)
+	assert.Equal(t, "broken.groovy", batch.Errors[0].Source)
+	assert.Contains(t, batch.Errors[0].Error(), "unable to extract metadata from source broken.groovy")
+}
@@ -49,6 +49,88 @@ func TestDependenciesJavaSource(t *testing.T) {
 	assert.Equal(t, []string{"camel:amqp", "camel:core", "camel:telegram", "camel:twitter"}, meta.Dependencies)
 }
 
+func TestDependenciesEIPs(t *testing.T) {
+	code := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name: "Request.java",
+			Content: `
+			    from("timer:tick")
+			        .wireTap("jms:queue:audit")
+			        .enrich("http4:inventory/api", new Strategy())
+			        .pollEnrich("ftp:host/in", 1000)
+			        .to("log:info");
+			`,
+		},
+		Language: v1alpha1.LanguageJavaSource,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := Extract(catalog, code)
+	assert.Equal(t, []string{"timer:tick", "ftp:host/in"}, meta.FromURIs)
+	assert.Equal(t, []string{"jms:queue:audit", "http4:inventory/api", "log:info"}, meta.ToURIs)
+	assert.Equal(t, []string{"camel:core", "camel:ftp", "camel:http4", "camel:jms"}, meta.Dependencies)
+}
+
+func TestXMLDependenciesEIPs(t *testing.T) {
+	code := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name: "routes.xml",
+			Content: `
+			<routes>
+			    <route>
+			        <from uri="timer:tick"/>
+			        <wireTap uri="jms:queue:audit"/>
+			        <enrich strategyRef="strategy">
+			            <constant>http4:inventory/api</constant>
+			        </enrich>
+			        <pollEnrich uri="ftp:host/in"/>
+			        <setBody>
+			            <constant>Hello</constant>
+			        </setBody>
+			    </route>
+			</routes>
+			`,
+		},
+		Language: v1alpha1.LanguageXML,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := Extract(catalog, code)
+	assert.Equal(t, []string{"timer:tick", "ftp:host/in"}, meta.FromURIs)
+	assert.Equal(t, []string{"jms:queue:audit", "http4:inventory/api"}, meta.ToURIs)
+	assert.Equal(t, []string{"camel:core", "camel:ftp", "camel:http4", "camel:jms"}, meta.Dependencies)
+}
+
+func TestDependenciesImports(t *testing.T) {
+	code := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name: "Request.java",
+			Content: `
+				import com.google.gson.Gson;
+				import org.apache.camel.builder.RouteBuilder;
+				import org.apache.camel.dataformat.bindy.annotation.*;
+
+				public class Request extends RouteBuilder {
+					public void configure() {
+						from("timer:tick").to("log:info");
+					}
+				}
+			`,
+		},
+		Language: v1alpha1.LanguageJavaSource,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := Extract(catalog, code)
+	assert.Equal(t, []string{"camel:bindy", "camel:core", "camel:gson"}, meta.Dependencies)
+}
+
 func TestDependenciesJavaScript(t *testing.T) {
 	code := v1alpha1.SourceSpec{
 		DataSpec: v1alpha1.DataSpec{
@@ -189,3 +271,34 @@ func TestXMLHystrixDependency(t *testing.T) {
 	// assert all dependencies are found and sorted (removing duplicates)
 	assert.Equal(t, []string{"camel:core", "camel:hystrix", "camel:kafka"}, meta.Dependencies)
 }
+
+func TestXMLSpringDependencies(t *testing.T) {
+	code := v1alpha1.SourceSpec{
+
+		DataSpec: v1alpha1.DataSpec{
+			Name: "camel-context.xml",
+			Content: `
+			<beans xmlns="http://www.springframework.org/schema/beans">
+				<camelContext xmlns="http://camel.apache.org/schema/spring">
+					<restConfiguration component="undertow" />
+					<route>
+						<from uri="direct:ciao" />
+						<hystrix id="breaker">
+							<to uri="log:info" />
+						</hystrix>
+					</route>
+				</camelContext>
+			</beans>
+		`,
+		},
+		Language: v1alpha1.LanguageXML,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := Extract(catalog, code)
+
+	// assert all dependencies are found and sorted (removing duplicates)
+	assert.Equal(t, []string{"camel:core", "camel:hystrix", "camel:undertow"}, meta.Dependencies)
+}
@@ -271,3 +271,177 @@ func TestMultilangHTTPSource(t *testing.T) {
 	assert.True(t, meta.RequiresHTTPService)
 	assert.False(t, meta.PassiveEndpoints)
 }
+
+func TestHttpEndpoints(t *testing.T) {
+	testCases := []struct {
+		name                string
+		source              v1alpha1.SourceSpec
+		restDSL             bool
+		httpURIs            []string
+		requiresHTTPService bool
+		passiveEndpoints    bool
+	}{
+		{
+			name: "rest dsl",
+			source: v1alpha1.SourceSpec{
+				DataSpec: v1alpha1.DataSpec{Name: "Rest.java", Content: `rest().get("/hello").to("direct:hello");`},
+			},
+			restDSL:             true,
+			httpURIs:            []string{},
+			requiresHTTPService: true,
+			passiveEndpoints:    true,
+		},
+		{
+			name: "commented rest dsl",
+			source: v1alpha1.SourceSpec{
+				DataSpec: v1alpha1.DataSpec{Name: "routes.groovy", Content: `
+					// rest('/hello').get().to('direct:hello')
+					from('timer:tick').setBody().constant('rest(')
+						.process { interest() }
+						.to('log:info')`},
+			},
+			httpURIs: []string{},
+		},
+		{
+			name: "http consumers",
+			source: v1alpha1.SourceSpec{
+				DataSpec: v1alpha1.DataSpec{Name: "routes.js", Content: `
+					from('servlet:/hello').to('log:info');
+					from('undertow:http://0.0.0.0:8080/hello').to('knative:endpoint/out');
+					from('knative:endpoint/in').to('log:info');`},
+			},
+			httpURIs:            []string{"servlet:/hello", "undertow:http://0.0.0.0:8080/hello", "knative:endpoint/in"},
+			requiresHTTPService: true,
+			passiveEndpoints:    true,
+		},
+		{
+			name: "http and active consumers",
+			source: v1alpha1.SourceSpec{
+				DataSpec: v1alpha1.DataSpec{Name: "Routes.kts", Content: `
+					from("netty4-http:http://0.0.0.0:8080/hello").to("log:info")
+					from("timer:tick").to("http4://localhost:8080/hello")`},
+			},
+			httpURIs:            []string{"netty4-http:http://0.0.0.0:8080/hello"},
+			requiresHTTPService: true,
+		},
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			meta := Extract(catalog, tc.source)
+
+			assert.Equal(t, tc.restDSL, meta.RestDSL)
+			assert.Equal(t, tc.httpURIs, meta.HTTPURIs)
+			assert.Equal(t, tc.requiresHTTPService, meta.RequiresHTTPService)
+			assert.Equal(t, tc.passiveEndpoints, meta.PassiveEndpoints)
+		})
+	}
+}
+
+func TestMultilangHTTPEndpoints(t *testing.T) {
+	codes := []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "routes.xml",
+				Content: `<rest path="/"></rest>`,
+			},
+			Language: v1alpha1.LanguageXML,
+		},
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "routes.groovy",
+				Content: `from('undertow:http://0.0.0.0:8080/hello').to('log:info')`,
+			},
+			Language: v1alpha1.LanguageGroovy,
+		},
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := ExtractAll(catalog, codes)
+
+	assert.True(t, meta.RestDSL)
+	assert.Equal(t, []string{"undertow:http://0.0.0.0:8080/hello"}, meta.HTTPURIs)
+	assert.True(t, meta.RequiresHTTPService)
+	assert.True(t, meta.PassiveEndpoints)
+}
+
+func TestRestPaths(t *testing.T) {
+	codes := []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name: "Rest.java",
+				Content: `
+				restConfiguration()
+					.component("netty4-http")
+					.port(8090)
+					.contextPath("/services");
+				rest("/api/orders/").get("/{id}").to("direct:order");
+				rest("/api/items/{id}").get().to("direct:item");
+			`,
+			},
+			Language: v1alpha1.LanguageJavaSource,
+		},
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name: "routes.xml",
+				Content: `
+				<restConfiguration component="netty4-http" port="8091"/>
+				<rest path="/health">
+				</rest>
+			`,
+			},
+			Language: v1alpha1.LanguageXML,
+		},
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := Extract(catalog, codes[1])
+	assert.Equal(t, []string{"/health"}, meta.RestPaths)
+	assert.Equal(t, 8091, meta.RestPort)
+	assert.Empty(t, meta.RestContextPath)
+
+	meta = ExtractAll(catalog, codes)
+	assert.Equal(t, []string{"/api/items", "/api/orders", "/health"}, meta.RestPaths)
+	assert.Equal(t, 8090, meta.RestPort)
+	assert.Equal(t, "/services", meta.RestContextPath)
+}
+
+func TestHttpNamespacedXMLSource(t *testing.T) {
+	code := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name: "routes.xml",
+			Content: `
+			<blueprint xmlns="http://www.osgi.org/xmlns/blueprint/v1.0.0"
+				xmlns:camel="http://camel.apache.org/schema/blueprint">
+				<camel:camelContext>
+					<camel:restConfiguration port="8092" contextPath="/services" />
+					<camel:rest path="/api/{id}">
+						<camel:get>
+							<camel:to uri="direct:get" />
+						</camel:get>
+					</camel:rest>
+				</camel:camelContext>
+			</blueprint>
+		`,
+		},
+		Language: v1alpha1.LanguageXML,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := Extract(catalog, code)
+
+	assert.True(t, meta.RestDSL)
+	assert.True(t, meta.RequiresHTTPService)
+	assert.Equal(t, []string{"/api"}, meta.RestPaths)
+	assert.Equal(t, 8092, meta.RestPort)
+	assert.Equal(t, "/services", meta.RestContextPath)
+}
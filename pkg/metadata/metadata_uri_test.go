@@ -23,6 +23,7 @@ import (
 	"github.com/apache/camel-k/pkg/util/test"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	src "github.com/apache/camel-k/pkg/util/source"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -92,10 +93,71 @@ func TestJava2(t *testing.T) {
 	assert.Len(t, metadata.FromURIs, 1)
 	assert.Contains(t, metadata.ToURIs, "log:info?skipBodyLineSeparator=false")
 	assert.Contains(t, metadata.ToURIs, "uri:2")
-	assert.Contains(t, metadata.ToURIs, "uri:%s") // resolution not supported yet
+	assert.Contains(t, metadata.ToURIs, "uri:3")
 	assert.Len(t, metadata.ToURIs, 3)
 }
 
+func TestJava3(t *testing.T) {
+	source := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name: "test",
+			Content: `
+			import org.apache.camel.builder.RouteBuilder;
+
+			public class Sample extends RouteBuilder {
+				private static final String TIMER = "timer:tick";
+				private static final String LOG = "log:info";
+
+  				@Override
+  				public void configure() throws Exception {
+					// from("timer:commented").to("log:commented");
+		  			from(TIMER + "?period=" + 1000)
+		    			.setBody(constant("Hello from(\"timer:body\")"))
+							.to(Sample.LOG)
+							.to("uri:" + 2);
+  				}
+			}
+		`,
+		},
+		Language: v1alpha1.LanguageJavaSource,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	metadata := Extract(catalog, source)
+
+	assert.Contains(t, metadata.FromURIs, "timer:tick?period=1000")
+	assert.Len(t, metadata.FromURIs, 1)
+	assert.Contains(t, metadata.ToURIs, "log:info")
+	assert.Contains(t, metadata.ToURIs, "uri:2")
+	assert.Len(t, metadata.ToURIs, 2)
+}
+
+func TestJavaIncomplete(t *testing.T) {
+	source := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name: "test",
+			Content: `
+			from("timer:tick")
+				.to("log:info")
+				.to(
+		`,
+		},
+		Language: v1alpha1.LanguageJavaSource,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	metadata := Extract(catalog, source)
+
+	assert.Contains(t, metadata.FromURIs, "timer:tick")
+	assert.Len(t, metadata.FromURIs, 1)
+	assert.Contains(t, metadata.ToURIs, "log:info")
+	assert.Len(t, metadata.ToURIs, 1)
+}
+
 func TestGroovy1(t *testing.T) {
 	source := v1alpha1.SourceSpec{
 		DataSpec: v1alpha1.DataSpec{
@@ -155,7 +217,7 @@ func TestGroovy2(t *testing.T) {
 	assert.Contains(t, metadata.ToURIs, "http://url")
 	assert.Contains(t, metadata.ToURIs, "dyn:1")
 	assert.Contains(t, metadata.ToURIs, "dyn:2")
-	assert.Contains(t, metadata.ToURIs, "f:%s") // resolution not supported yet
+	assert.Contains(t, metadata.ToURIs, "f:2")
 	assert.Len(t, metadata.ToURIs, 5)
 }
 
@@ -226,7 +288,7 @@ func TestKotlin1(t *testing.T) {
 	assert.Contains(t, metadata.ToURIs, "http://url")
 	assert.Contains(t, metadata.ToURIs, "uri:3")
 	assert.Contains(t, metadata.ToURIs, "uri:4")
-	assert.Contains(t, metadata.ToURIs, "uri:%s") // resolution not supported yet
+	assert.Contains(t, metadata.ToURIs, "uri:5")
 	assert.Len(t, metadata.ToURIs, 5)
 }
 
@@ -254,7 +316,7 @@ func TestJavascript1(t *testing.T) {
 	assert.Contains(t, metadata.ToURIs, "log:info?skipBodyLineSeparator=false")
 	assert.Contains(t, metadata.ToURIs, "http://url")
 	assert.Contains(t, metadata.ToURIs, "uri:2")
-	assert.Contains(t, metadata.ToURIs, "uri:%s") // resolution not supported yet
+	assert.Contains(t, metadata.ToURIs, "uri:3")
 	assert.Len(t, metadata.ToURIs, 4)
 }
 
@@ -288,3 +350,117 @@ func TestJYamlFlow(t *testing.T) {
 	assert.Contains(t, metadata.ToURIs, "log:info")
 	assert.Len(t, metadata.ToURIs, 1)
 }
+
+const yamlRoutes = `
+- route:
+    id: "orders"
+    from:
+      uri: "timer:tick"
+      parameters:
+        period: 5000
+      steps:
+        - setBody:
+            constant: "Hello"
+        - choice:
+            when:
+              - simple: "${body} == 'Hello'"
+                steps:
+                  - to: "jms:queue:hello"
+            otherwise:
+              steps:
+                - toD: "http4:${header.host}/api"
+        - wireTap: "seda:audit"
+        - to:
+            uri: "log:info"
+            parameters:
+              showAll: true
+- from:
+    uri: "ftp:host/orders"
+    steps:
+      - pollEnrich: "file:/tmp/inbox"
+      - to: "amqp:queue:orders"
+- rest:
+    path: "/api"
+    get:
+      - uri: "/hello"
+        to: "direct:hello"
+`
+
+func TestYamlRoutes(t *testing.T) {
+	source := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name:    "routes.flow",
+			Content: yamlRoutes,
+		},
+		Language: v1alpha1.LanguageYamlFlow,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	metadata := Extract(catalog, source)
+
+	assert.Equal(t, []string{"timer:tick?period=5000", "ftp:host/orders", "file:/tmp/inbox"}, metadata.FromURIs)
+	assert.Equal(t, []string{
+		"http4:${header.host}/api",
+		"jms:queue:hello",
+		"seda:audit",
+		"log:info?showAll=true",
+		"amqp:queue:orders",
+		"direct:hello",
+	}, metadata.ToURIs)
+	assert.Equal(t, []string{"camel:amqp", "camel:core", "camel:ftp", "camel:http4", "camel:jms"}, metadata.Dependencies)
+	assert.True(t, metadata.RestDSL)
+	assert.Equal(t, []src.Route{
+		{
+			ID:   "orders",
+			From: "timer:tick?period=5000",
+			To:   []string{"http4:${header.host}/api", "jms:queue:hello", "seda:audit", "log:info?showAll=true"},
+		},
+		{From: "ftp:host/orders", To: []string{"amqp:queue:orders"}},
+		{To: []string{"direct:hello"}, RestPath: "/api"},
+	}, metadata.Routes)
+}
+
+const xmlRoutes = `
+<routes xmlns="http://camel.apache.org/schema/spring">
+    <route id="orders">
+        <from uri="timer:tick"/>
+        <wireTap uri="seda:audit"/>
+        <enrich>
+            <constant>http4:orders/api</constant>
+        </enrich>
+        <to uri="log:info"/>
+    </route>
+    <rest path="/api">
+        <get uri="/hello">
+            <to uri="direct:hello"/>
+        </get>
+    </rest>
+    <route>
+        <from uri="direct:hello"/>
+        <pollEnrich uri="file:/tmp/inbox"/>
+    </route>
+</routes>
+`
+
+func TestXMLRoutes(t *testing.T) {
+	source := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name:    "routes.xml",
+			Content: xmlRoutes,
+		},
+		Language: v1alpha1.LanguageXML,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	metadata := Extract(catalog, source)
+
+	assert.Equal(t, []src.Route{
+		{ID: "orders", From: "timer:tick", To: []string{"seda:audit", "http4:orders/api", "log:info"}},
+		{To: []string{"direct:hello"}, RestPath: "/api"},
+		{From: "direct:hello", To: []string{}},
+	}, metadata.Routes)
+}
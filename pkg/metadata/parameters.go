@@ -0,0 +1,155 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
+)
+
+// maxSuggestionDistance is the maximum number of edits between an unknown parameter and a suggested one
+const maxSuggestionDistance = 2
+
+// ParameterWarnings returns a warning for each query parameter of the given endpoint URIs that is unknown to the
+// catalog, or whose value is not one of the values the catalog allows. Only the URIs of the schemes with parameters
+// metadata are validated, the property placeholders and the expressions resolved at runtime being skipped
+func ParameterWarnings(catalog *camel.RuntimeCatalog, uris []string) []string {
+	warnings := make([]string, 0)
+	for _, uri := range uris {
+		scheme, ok := catalog.GetScheme(uriutil.Scheme(uri))
+		if !ok || len(scheme.Parameters) == 0 {
+			continue
+		}
+
+		query := strings.Index(uri, "?")
+		if query < 0 {
+			continue
+		}
+		endpoint := uri[:query]
+
+		for _, pair := range strings.Split(uri[query+1:], "&") {
+			kv := strings.SplitN(pair, "=", 2)
+			name := kv[0]
+			if name == "" || isResolvedAtRuntime(name) {
+				continue
+			}
+
+			parameter := findParameter(scheme, name)
+			if parameter == nil {
+				warning := fmt.Sprintf("unknown parameter %s in endpoint %s", name, endpoint)
+				if suggestion := suggestParameter(scheme, name); suggestion != "" {
+					warning += fmt.Sprintf(", did you mean %s?", suggestion)
+				}
+				util.StringSliceUniqueAdd(&warnings, warning)
+				continue
+			}
+
+			if len(kv) < 2 || len(parameter.Enum) == 0 || isResolvedAtRuntime(kv[1]) {
+				continue
+			}
+			value, err := url.QueryUnescape(kv[1])
+			if err != nil {
+				value = kv[1]
+			}
+			if !containsFold(parameter.Enum, value) {
+				util.StringSliceUniqueAdd(&warnings, fmt.Sprintf("invalid value %s for parameter %s in endpoint %s, allowed values are %s",
+					value, name, endpoint, strings.Join(parameter.Enum, ", ")))
+			}
+		}
+	}
+
+	sort.Strings(warnings)
+
+	return warnings
+}
+
+// isResolvedAtRuntime returns true for the property placeholders, the simple expressions of dynamic endpoints,
+// the format specifiers of the toF endpoints and the references to beans, that cannot be validated
+func isResolvedAtRuntime(value string) bool {
+	return strings.Contains(value, "{{") || strings.Contains(value, "${") || strings.Contains(value, "%") ||
+		strings.HasPrefix(value, "#") || strings.HasPrefix(value, "RAW(")
+}
+
+// findParameter returns the parameter with the given name, like Camel the name is matched ignoring case
+func findParameter(scheme v1alpha1.CamelScheme, name string) *v1alpha1.CamelSchemeParameter {
+	for i, parameter := range scheme.Parameters {
+		if parameter.Prefix != "" && len(name) > len(parameter.Prefix) && strings.EqualFold(name[:len(parameter.Prefix)], parameter.Prefix) {
+			return &scheme.Parameters[i]
+		}
+		if strings.EqualFold(name, parameter.Name) {
+			return &scheme.Parameters[i]
+		}
+	}
+	return nil
+}
+
+// suggestParameter returns the known parameter closest to the given unknown one, if close enough to be a typo
+func suggestParameter(scheme v1alpha1.CamelScheme, name string) string {
+	suggestion := ""
+	best := maxSuggestionDistance + 1
+	for _, parameter := range scheme.Parameters {
+		if d := editDistance(strings.ToLower(name), strings.ToLower(parameter.Name)); d < best {
+			suggestion = parameter.Name
+			best = d
+		}
+	}
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between the two given strings
+func editDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = previous[j-1] + cost
+			if previous[j]+1 < current[j] {
+				current[j] = previous[j] + 1
+			}
+			if current[j-1]+1 < current[j] {
+				current[j] = current[j-1] + 1
+			}
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParameterWarnings(t *testing.T) {
+	catalog := camel.NewRuntimeCatalog(v1alpha1.CamelCatalogSpec{
+		Version: "2.24.0",
+		Artifacts: map[string]v1alpha1.CamelArtifact{
+			"camel-core": {
+				Schemes: []v1alpha1.CamelScheme{
+					{
+						ID: "timer",
+						Parameters: []v1alpha1.CamelSchemeParameter{
+							{Name: "period"},
+							{Name: "delay"},
+							{Name: "repeatCount"},
+						},
+					},
+					{
+						ID: "log",
+						Parameters: []v1alpha1.CamelSchemeParameter{
+							{Name: "level", Enum: []string{"ERROR", "WARN", "INFO", "DEBUG", "TRACE", "OFF"}},
+							{Name: "showAll"},
+						},
+					},
+					{
+						ID: "file",
+						Parameters: []v1alpha1.CamelSchemeParameter{
+							{Name: "scheduler"},
+							{Name: "schedulerProperties", Prefix: "scheduler."},
+						},
+					},
+					{
+						ID: "direct",
+					},
+				},
+			},
+		},
+	})
+
+	testCases := []struct {
+		name     string
+		uris     []string
+		warnings []string
+	}{
+		{
+			name: "valid",
+			uris: []string{"timer:tick?period=3s&repeatCount=1", "log:info?level=warn&showAll=true", "timer:tick", "log:info?"},
+		},
+		{
+			name:     "unknown parameter",
+			uris:     []string{"timer:tick?perios=3s"},
+			warnings: []string{"unknown parameter perios in endpoint timer:tick, did you mean period?"},
+		},
+		{
+			name:     "unknown parameter without suggestion",
+			uris:     []string{"timer:tick?fixedRate=true"},
+			warnings: []string{"unknown parameter fixedRate in endpoint timer:tick"},
+		},
+		{
+			name:     "invalid value",
+			uris:     []string{"log:info?level=VERBOSE"},
+			warnings: []string{"invalid value VERBOSE for parameter level in endpoint log:info, allowed values are ERROR, WARN, INFO, DEBUG, TRACE, OFF"},
+		},
+		{
+			name: "case insensitive name",
+			uris: []string{"timer:tick?Period=3s&REPEATCOUNT=1"},
+		},
+		{
+			name: "prefixed parameter",
+			uris: []string{"file:data?scheduler=quartz2&scheduler.cron=0+0/5+*+*+*+?"},
+		},
+		{
+			name: "resolved at runtime",
+			uris: []string{"timer:tick?{{timer.options}}", "log:info?level={{log.level}}", "log:${header.name}?level=${header.level}", "log:%s?level=%s", "log:info?level=#level"},
+		},
+		{
+			name: "scheme without parameters metadata",
+			uris: []string{"direct:start?whatever=1", "unknown:start?whatever=1"},
+		},
+		{
+			name: "many warnings",
+			uris: []string{"timer:tick?perios=3s&dealy=1s", "timer:other?perios=3s", "log:info?level=VERBOSE"},
+			warnings: []string{
+				"invalid value VERBOSE for parameter level in endpoint log:info, allowed values are ERROR, WARN, INFO, DEBUG, TRACE, OFF",
+				"unknown parameter dealy in endpoint timer:tick, did you mean delay?",
+				"unknown parameter perios in endpoint timer:other, did you mean period?",
+				"unknown parameter perios in endpoint timer:tick, did you mean period?",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings := ParameterWarnings(catalog, tc.uris)
+			if tc.warnings == nil {
+				assert.Empty(t, warnings)
+			} else {
+				assert.Equal(t, tc.warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("period", "period"))
+	assert.Equal(t, 1, editDistance("perios", "period"))
+	assert.Equal(t, 2, editDistance("dealy", "delay"))
+	assert.Equal(t, 6, editDistance("", "period"))
+	assert.Equal(t, 3, editDistance("kitten", "sitting"))
+}
@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+	src "github.com/apache/camel-k/pkg/util/source"
+)
+
+// maxPlaceholderDepth bounds the nesting of placeholders in property values, so that cycles are not followed forever
+const maxPlaceholderDepth = 10
+
+// ResolvePlaceholders expands the property placeholders of the endpoint URIs with the given properties, so that the
+// components of the resolved URIs are added to the dependencies. The placeholders that cannot be resolved, e.g.
+// because their value is only known at runtime, are kept as is and their keys are returned
+func ResolvePlaceholders(catalog *camel.RuntimeCatalog, meta IntegrationMetadata, properties map[string]string) (IntegrationMetadata, []string) {
+	unresolved := make([]string, 0)
+	resolve := func(uris []string) []string {
+		resolved := make([]string, 0, len(uris))
+		for _, uri := range uris {
+			value, keys := resolvePlaceholders(uri, properties, 0)
+			for _, key := range keys {
+				util.StringSliceUniqueAdd(&unresolved, key)
+			}
+			util.StringSliceUniqueAdd(&resolved, value)
+		}
+		return resolved
+	}
+
+	resolved := meta
+	resolved.FromURIs = resolve(meta.FromURIs)
+	resolved.ToURIs = resolve(meta.ToURIs)
+	resolved.HTTPURIs = httpURIs(catalog, resolved.FromURIs)
+	resolved.RequiresHTTPService = resolved.RestDSL || len(resolved.HTTPURIs) > 0
+	resolved.PassiveEndpoints = hasOnlyPassiveEndpoints(catalog, resolved.FromURIs)
+
+	resolved.Dependencies = append([]string{}, meta.Dependencies...)
+	for _, uri := range util.StringSliceJoin(resolved.FromURIs, resolved.ToURIs) {
+		if dependency := src.ComponentDependency(catalog, uri); dependency != "" {
+			util.StringSliceUniqueAdd(&resolved.Dependencies, dependency)
+		}
+	}
+	sort.Strings(resolved.Dependencies)
+	sort.Strings(unresolved)
+
+	return resolved, unresolved
+}
+
+// resolvePlaceholders expands the {{key}} and {{key:default}} placeholders of the given value, returning the keys
+// of the placeholders that cannot be resolved. The env: and sys: functions are never resolved, as they are evaluated
+// by the runtime
+func resolvePlaceholders(value string, properties map[string]string, depth int) (string, []string) {
+	unresolved := make([]string, 0)
+
+	var sb strings.Builder
+	for {
+		start := strings.Index(value, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(value[start:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start
+
+		sb.WriteString(value[:start])
+		placeholder := value[start+2 : end]
+		value = value[end+2:]
+
+		key := placeholder
+		defaultValue, hasDefault := "", false
+		if !strings.HasPrefix(placeholder, "env:") && !strings.HasPrefix(placeholder, "sys:") {
+			if i := strings.Index(placeholder, ":"); i >= 0 {
+				key, defaultValue, hasDefault = placeholder[:i], placeholder[i+1:], true
+			}
+		}
+
+		if v, ok := properties[key]; ok && depth < maxPlaceholderDepth {
+			v, keys := resolvePlaceholders(v, properties, depth+1)
+			sb.WriteString(v)
+			unresolved = append(unresolved, keys...)
+		} else if hasDefault {
+			sb.WriteString(defaultValue)
+		} else {
+			sb.WriteString("{{" + placeholder + "}}")
+			unresolved = append(unresolved, key)
+		}
+	}
+	sb.WriteString(value)
+
+	return sb.String(), unresolved
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvePlaceholders(t *testing.T) {
+	source := v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name: "routes.groovy",
+			Content: `
+				from('{{input.endpoint}}')
+					.to('{{output.scheme}}:{{output.name}}?level={{log.level:INFO}}')
+					.to('log:{{env:LOG_NAME}}')
+					.to('{{unknown.endpoint}}')
+			`,
+		},
+		Language: v1alpha1.LanguageGroovy,
+	}
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	meta := Extract(catalog, source)
+	assert.Equal(t, []string{"{{input.endpoint}}"}, meta.FromURIs)
+	assert.NotContains(t, meta.Dependencies, "camel:undertow")
+
+	meta, unresolved := ResolvePlaceholders(catalog, meta, map[string]string{
+		"input.endpoint": "undertow:http://0.0.0.0:8080/{{input.path}}",
+		"input.path":     "hello",
+		"output.scheme":  "telegram",
+		"output.name":    "bots",
+	})
+
+	assert.Equal(t, []string{"undertow:http://0.0.0.0:8080/hello"}, meta.FromURIs)
+	assert.Equal(t, []string{
+		"telegram:bots?level=INFO",
+		"log:{{env:LOG_NAME}}",
+		"{{unknown.endpoint}}",
+	}, meta.ToURIs)
+	assert.Contains(t, meta.Dependencies, "camel:undertow")
+	assert.Contains(t, meta.Dependencies, "camel:telegram")
+	assert.True(t, meta.RequiresHTTPService)
+	assert.Equal(t, []string{"env:LOG_NAME", "unknown.endpoint"}, unresolved)
+}
+
+func TestResolvePlaceholdersValues(t *testing.T) {
+	properties := map[string]string{
+		"a":     "{{b}}",
+		"b":     "value",
+		"cycle": "x{{cycle}}",
+		"empty": "",
+	}
+
+	testCases := []struct {
+		value      string
+		resolved   string
+		unresolved []string
+	}{
+		{value: "timer:tick", resolved: "timer:tick", unresolved: []string{}},
+		{value: "{{a}}", resolved: "value", unresolved: []string{}},
+		{value: "{{b}}-{{b}}", resolved: "value-value", unresolved: []string{}},
+		{value: "{{missing:default}}", resolved: "default", unresolved: []string{}},
+		{value: "{{missing:log:info}}", resolved: "log:info", unresolved: []string{}},
+		{value: "{{b:default}}", resolved: "value", unresolved: []string{}},
+		{value: "{{empty}}", resolved: "", unresolved: []string{}},
+		{value: "{{missing}}", resolved: "{{missing}}", unresolved: []string{"missing"}},
+		{value: "{{sys:user.home}}", resolved: "{{sys:user.home}}", unresolved: []string{"sys:user.home"}},
+		{value: "{{not closed", resolved: "{{not closed", unresolved: []string{}},
+		{value: "{{cycle}}", resolved: "xxxxxxxxxx{{cycle}}", unresolved: []string{"cycle"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			resolved, unresolved := resolvePlaceholders(tc.value, properties, 0)
+			assert.Equal(t, tc.resolved, resolved)
+			assert.Equal(t, tc.unresolved, unresolved)
+		})
+	}
+}
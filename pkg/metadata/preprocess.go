@@ -0,0 +1,140 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/gzip"
+	"github.com/pkg/errors"
+)
+
+// Preprocessor transforms the content of a source before it's handed over to the runtime
+type Preprocessor func(source v1alpha1.SourceSpec, content string) (string, error)
+
+// Preprocess applies the preprocessors, in order, to the content of the given sources and returns the
+// transformed copies. Sources referencing their content from a ConfigMap are left untouched.
+func Preprocess(sources []v1alpha1.SourceSpec, preprocessors ...Preprocessor) ([]v1alpha1.SourceSpec, error) {
+	result := make([]v1alpha1.SourceSpec, 0, len(sources))
+
+	for _, source := range sources {
+		if source.ContentRef != "" || len(preprocessors) == 0 {
+			result = append(result, source)
+			continue
+		}
+
+		content := source.Content
+		if source.Compression {
+			data, err := gzip.UncompressBase64([]byte(content))
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot uncompress source %s", source.Name)
+			}
+			content = string(data)
+		}
+
+		for _, preprocessor := range preprocessors {
+			processed, err := preprocessor(source, content)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot preprocess source %s", source.Name)
+			}
+			content = processed
+		}
+
+		if source.Compression {
+			data, err := gzip.CompressBase64([]byte(content))
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot compress source %s", source.Name)
+			}
+			content = string(data)
+		}
+
+		source.Content = content
+		result = append(result, source)
+	}
+
+	return result, nil
+}
+
+// TemplatePreprocessor renders the sources as Go templates with the given values, e.g. [[ .env ]].
+// The [[ ]] delimiters are used so that Camel property placeholders like {{key}} are preserved.
+func TemplatePreprocessor(values map[string]string) Preprocessor {
+	return func(source v1alpha1.SourceSpec, content string) (string, error) {
+		tmpl, err := template.New(source.Name).Delims("[[", "]]").Option("missingkey=error").Parse(content)
+		if err != nil {
+			return "", err
+		}
+
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, values); err != nil {
+			return "", err
+		}
+
+		return out.String(), nil
+	}
+}
+
+// StripPreprocessor removes the blocks of lines delimited by lines containing the camel-k:begin:<tag>
+// and camel-k:end:<tag> markers for any of the given tags, e.g. to strip test-only routes.
+// The markers are usually written as comments so that they fit any language.
+func StripPreprocessor(tags ...string) Preprocessor {
+	begin := make(map[string]*regexp.Regexp, len(tags))
+	end := make(map[string]*regexp.Regexp, len(tags))
+	for _, tag := range tags {
+		begin[tag] = regexp.MustCompile(`camel-k:begin:` + regexp.QuoteMeta(tag) + `(?:$|[^\w-])`)
+		end[tag] = regexp.MustCompile(`camel-k:end:` + regexp.QuoteMeta(tag) + `(?:$|[^\w-])`)
+	}
+
+	return func(source v1alpha1.SourceSpec, content string) (string, error) {
+		var out strings.Builder
+
+		stripping := ""
+		scanner := bufio.NewScanner(strings.NewReader(content))
+		scanner.Buffer(make([]byte, 0, 64*1024), len(content)+1)
+		for scanner.Scan() {
+			line := scanner.Text()
+
+			if stripping == "" {
+				for _, tag := range tags {
+					if begin[tag].MatchString(line) {
+						stripping = tag
+						break
+					}
+				}
+				if stripping == "" {
+					out.WriteString(line)
+					out.WriteString("\n")
+				}
+			} else if end[stripping].MatchString(line) {
+				stripping = ""
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		if stripping != "" {
+			return "", errors.Errorf("missing camel-k:end:%s marker", stripping)
+		}
+
+		return out.String(), nil
+	}
+}
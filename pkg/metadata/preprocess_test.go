@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/gzip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreprocessTemplate(t *testing.T) {
+	sources := []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "routes.groovy",
+				Content: `from('timer:tick').to('kafka:orders-[[ .env ]]?brokers={{brokers}}')`,
+			},
+		},
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:       "external.groovy",
+				ContentRef: "my-configmap",
+			},
+		},
+	}
+
+	processed, err := Preprocess(sources, TemplatePreprocessor(map[string]string{"env": "prod"}))
+	assert.Nil(t, err)
+	assert.Len(t, processed, 2)
+	assert.Equal(t, `from('timer:tick').to('kafka:orders-prod?brokers={{brokers}}')`, processed[0].Content)
+	assert.Equal(t, "my-configmap", processed[1].ContentRef)
+	// the given sources are not modified
+	assert.Contains(t, sources[0].Content, "[[ .env ]]")
+
+	_, err = Preprocess(sources, TemplatePreprocessor(map[string]string{}))
+	assert.NotNil(t, err)
+}
+
+func TestPreprocessStrip(t *testing.T) {
+	content := `from('timer:tick').to('log:info')
+// camel-k:begin:test-only
+from('direct:test').to('mock:result')
+// camel-k:end:test-only
+// camel-k:begin:test
+from('direct:other').to('log:other')
+// camel-k:end:test
+`
+
+	compressed, err := gzip.CompressBase64([]byte(content))
+	assert.Nil(t, err)
+
+	sources := []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:        "routes.groovy",
+				Content:     string(compressed),
+				Compression: true,
+			},
+		},
+	}
+
+	processed, err := Preprocess(sources, StripPreprocessor("test-only"))
+	assert.Nil(t, err)
+
+	data, err := gzip.UncompressBase64([]byte(processed[0].Content))
+	assert.Nil(t, err)
+	assert.Equal(t, `from('timer:tick').to('log:info')
+// camel-k:begin:test
+from('direct:other').to('log:other')
+// camel-k:end:test
+`, string(data))
+
+	sources[0].Content = "// camel-k:begin:test-only\nfrom('direct:test').to('mock:result')\n"
+	sources[0].Compression = false
+	_, err = Preprocess(sources, StripPreprocessor("test-only"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/camel"
+	src "github.com/apache/camel-k/pkg/util/source"
+)
+
+// componentPropertyPrefix prefixes the properties configuring Camel components, i.e. camel.component.<name>.<option>
+const componentPropertyPrefix = "camel.component."
+
+// PropertyDependencies returns the dependencies on the components configured by the given properties, as
+// camel.component.kafka.brokers implies camel:kafka even if no kafka endpoint is found in the sources
+func PropertyDependencies(catalog *camel.RuntimeCatalog, properties map[string]string) []string {
+	dependencies := make([]string, 0)
+	for key := range properties {
+		if !strings.HasPrefix(key, componentPropertyPrefix) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(key, componentPropertyPrefix), ".", 2)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			continue
+		}
+		if dependency := src.ComponentDependency(catalog, parts[0]+":"); dependency != "" {
+			util.StringSliceUniqueAdd(&dependencies, dependency)
+		}
+	}
+	sort.Strings(dependencies)
+
+	return dependencies
+}
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropertyDependencies(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	dependencies := PropertyDependencies(catalog, map[string]string{
+		"camel.component.kafka.brokers":       "my-cluster-kafka-bootstrap:9092",
+		"camel.component.kafka.clientId":      "my-client",
+		"camel.component.telegram.authToken":  "token",
+		"camel.component.unknown.option":      "value",
+		"camel.component.undertow":            "value",
+		"camel.dataformat.json-jackson.prety": "true",
+		"kafka.brokers":                       "localhost:9092",
+	})
+
+	assert.Equal(t, []string{"camel:kafka", "camel:telegram"}, dependencies)
+}
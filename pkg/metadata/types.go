@@ -22,9 +22,20 @@ import "github.com/apache/camel-k/pkg/util/source"
 // IntegrationMetadata contains aggregate metadata about all Camel routes in a integrations
 type IntegrationMetadata struct {
 	source.Metadata
-	// RequiresHTTPService indicates if the integration needs to be invoked through HTTP
+	// RequiresHTTPService indicates if the integration needs to be invoked through HTTP, i.e. it exposes
+	// REST DSL definitions or consumes from HTTP endpoints
 	RequiresHTTPService bool
+	// RestDSL indicates that the integration exposes REST DSL definitions
+	RestDSL bool
+	// HTTPURIs contains the URIs of the consumer endpoints exposing HTTP, e.g. the servlet, undertow or knative ones
+	HTTPURIs []string
 	// PassiveEndpoints indicates that the integration contains only passive endpoints that are activated from
 	// external calls, including HTTP (useful to determine if the integration can scale to 0)
 	PassiveEndpoints bool
+	// RestPaths contains the base paths exposed through the REST DSL
+	RestPaths []string
+	// RestPort is the port the REST DSL is configured to listen to (0 if not configured)
+	RestPort int
+	// RestContextPath is the context path the REST DSL is configured with (empty if not configured)
+	RestContextPath string
 }
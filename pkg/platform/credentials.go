@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// CredentialsReasonHardcoded --
+const CredentialsReasonHardcoded = "HardcodedCredentials"
+
+var (
+	// credentialParameter matches the endpoint URI parameters holding credentials, capturing the name and the value
+	credentialParameter = regexp.MustCompile(`(?i)[?&](password|passphrase|secretKey|accessKey|clientSecret|apiKey|` +
+		`authorizationToken|oauthToken|accessToken|token)=([^&\s"'` + "`" + `]+)`)
+	awsAccessKey = regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)
+	privateKey   = regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY-----`)
+)
+
+// HardcodedCredentials returns where the given sources likely contain hard-coded credentials, i.e. credential
+// parameters of endpoint URIs that are not property placeholders, AWS access keys and private keys. Only inline
+// sources are scanned, and the credential values are never reported
+func HardcodedCredentials(sources []v1alpha1.SourceSpec) []string {
+	findings := make([]string, 0)
+	for _, source := range sources {
+		for i, line := range strings.Split(source.Content, "\n") {
+			location := fmt.Sprintf("%s:%d", source.Name, i+1)
+
+			for _, match := range credentialParameter.FindAllStringSubmatch(line, -1) {
+				if !isPlaceholder(match[2]) {
+					util.StringSliceUniqueAdd(&findings, fmt.Sprintf("%s: value of the %s parameter", location, match[1]))
+				}
+			}
+			if awsAccessKey.MatchString(line) {
+				util.StringSliceUniqueAdd(&findings, fmt.Sprintf("%s: AWS access key", location))
+			}
+			if privateKey.MatchString(line) {
+				util.StringSliceUniqueAdd(&findings, fmt.Sprintf("%s: private key", location))
+			}
+		}
+	}
+
+	return findings
+}
+
+// CheckHardcodedCredentials returns the condition to be set on an integration whose sources likely contain
+// hard-coded credentials, or nil if none is found, and whether the platform policy rejects the integration
+func CheckHardcodedCredentials(p *v1alpha1.IntegrationPlatform, integration *v1alpha1.Integration) (*v1alpha1.Condition, bool) {
+	policy := p.Spec.Policy.HardcodedCredentials
+	if policy == v1alpha1.IntegrationPlatformCredentialsPolicyIgnore {
+		return nil, false
+	}
+
+	findings := HardcodedCredentials(integration.Spec.Sources)
+	if len(findings) == 0 {
+		return nil, false
+	}
+
+	return &v1alpha1.Condition{
+		Type:   v1alpha1.ConditionHardcodedCredentials,
+		Status: corev1.ConditionTrue,
+		Reason: CredentialsReasonHardcoded,
+		Message: fmt.Sprintf("likely hard-coded credentials found (%s), use property placeholders resolved from a secret instead, "+
+			"e.g. with kamel run --secret", strings.Join(findings, "; ")),
+	}, policy == v1alpha1.IntegrationPlatformCredentialsPolicyReject
+}
+
+// isPlaceholder returns true for the values resolved at runtime, e.g. {{aws.secret}} or RAW({{password}})
+func isPlaceholder(value string) bool {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "RAW("), "RAW{")
+	return strings.HasPrefix(value, "{{") || strings.HasPrefix(value, "${") || strings.HasPrefix(value, "#")
+}
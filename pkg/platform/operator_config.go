@@ -0,0 +1,216 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/camel-k/pkg/util/defaults"
+)
+
+// OperatorConfigMapName is the name of the ConfigMap, located in the operator namespace, holding the operator configuration
+const OperatorConfigMapName = "camel-k-operator-config"
+
+const (
+	operatorConfigRuntimeVersion   = "runtime-version"
+	operatorConfigMaxRunningBuilds = "max-running-builds"
+	operatorConfigMaxRunningTotal  = "max-running-builds-total"
+	operatorConfigLogLevel         = "log-level"
+	operatorConfigRequeueInterval  = "requeue-interval"
+	operatorConfigDriftEnforcement = "drift-enforcement"
+	operatorConfigTraitSnapshots   = "trait-snapshots"
+
+	operatorConfigIntegrationMonitorInterval = "integration-monitor-interval"
+	operatorConfigBuildPollInterval          = "build-poll-interval"
+	operatorConfigPlatformMonitorInterval    = "platform-monitor-interval"
+	operatorConfigKitGCInterval              = "kit-gc-interval"
+)
+
+const (
+	// DriftEnforcementNone only reports resources generated for integrations that are missing or modified
+	DriftEnforcementNone = "none"
+	// DriftEnforcementRecreate recreates the generated resources that have been deleted
+	DriftEnforcementRecreate = "recreate"
+	// DriftEnforcementRevert recreates the deleted generated resources and reverts the modified ones
+	DriftEnforcementRevert = "revert"
+)
+
+const (
+	// TraitSnapshotsNone does not record the trait environments
+	TraitSnapshotsNone = "none"
+	// TraitSnapshotsConfigMap records the trait environments that fail to be applied in a ConfigMap of their namespace
+	TraitSnapshotsConfigMap = "configmap"
+	// TraitSnapshotsFile records the trait environments that fail to be applied in a file of the operator temporary directory
+	TraitSnapshotsFile = "file"
+)
+
+// OperatorConfiguration holds the operator tunables that can be changed without restarting the operator
+type OperatorConfiguration struct {
+	// The runtime version used by default by new platforms
+	RuntimeVersion string
+	// The maximum number of builds running at the same time in a namespace
+	MaxRunningBuilds int
+	// The maximum number of builds running at the same time in all the namespaces watched by a global
+	// operator, the namespaces taking turns to run their builds (unlimited if 0)
+	MaxRunningBuildsTotal int
+	// The operator log level (debug, info, warn, error)
+	LogLevel string
+	// The interval used to re-check resources waiting for something to happen
+	RequeueInterval time.Duration
+	// The interval used to monitor running integrations, e.g. refreshing their route statistics
+	// (defaults to the requeue interval)
+	IntegrationMonitorInterval time.Duration
+	// The interval used to poll the status of builds (defaults to the requeue interval)
+	BuildPollInterval time.Duration
+	// The interval used to monitor ready platforms, e.g. collecting their unused kits
+	// (defaults to the requeue interval)
+	PlatformMonitorInterval time.Duration
+	// The minimum interval between two collections of the unused kits of a platform
+	KitGCInterval time.Duration
+	// How the operator reacts to out-of-band changes to the resources generated for integrations
+	// (none, recreate, revert)
+	DriftEnforcement string
+	// Where the trait environments that fail to be applied are recorded, to be replayed for troubleshooting
+	// (none, configmap, file)
+	TraitSnapshots string
+}
+
+var operatorConfiguration atomic.Value
+
+func init() {
+	operatorConfiguration.Store(DefaultOperatorConfiguration())
+}
+
+// DefaultOperatorConfiguration returns the configuration used when no ConfigMap is provided
+func DefaultOperatorConfiguration() OperatorConfiguration {
+	return OperatorConfiguration{
+		RuntimeVersion: defaults.RuntimeVersion,
+		// a single build at a time is needed for the incremental build to work as expected
+		MaxRunningBuilds: 1,
+		LogLevel:         "info",
+		RequeueInterval:  5 * time.Second,
+		DriftEnforcement: DriftEnforcementRecreate,
+		TraitSnapshots:   TraitSnapshotsNone,
+
+		IntegrationMonitorInterval: 5 * time.Second,
+		BuildPollInterval:          5 * time.Second,
+		PlatformMonitorInterval:    5 * time.Second,
+		KitGCInterval:              time.Minute,
+	}
+}
+
+// GetOperatorConfiguration returns the current operator configuration
+func GetOperatorConfiguration() OperatorConfiguration {
+	return operatorConfiguration.Load().(OperatorConfiguration)
+}
+
+// SetOperatorConfiguration replaces the current operator configuration
+func SetOperatorConfiguration(configuration OperatorConfiguration) {
+	operatorConfiguration.Store(configuration)
+}
+
+// ParseOperatorConfiguration computes the operator configuration from the data of the operator ConfigMap,
+// unset keys retain their default value, except the unset controller intervals that default to the requeue interval
+func ParseOperatorConfiguration(data map[string]string) (OperatorConfiguration, error) {
+	configuration := DefaultOperatorConfiguration()
+	configuration.IntegrationMonitorInterval = 0
+	configuration.BuildPollInterval = 0
+	configuration.PlatformMonitorInterval = 0
+
+	for k, v := range data {
+		switch k {
+		case operatorConfigRuntimeVersion:
+			configuration.RuntimeVersion = v
+		case operatorConfigMaxRunningBuilds:
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return configuration, fmt.Errorf("invalid %s: %s", k, v)
+			}
+			configuration.MaxRunningBuilds = n
+		case operatorConfigMaxRunningTotal:
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return configuration, fmt.Errorf("invalid %s: %s", k, v)
+			}
+			configuration.MaxRunningBuildsTotal = n
+		case operatorConfigLogLevel:
+			switch v {
+			case "debug", "info", "warn", "error":
+				configuration.LogLevel = v
+			default:
+				return configuration, fmt.Errorf("invalid %s: %s", k, v)
+			}
+		case operatorConfigRequeueInterval:
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 {
+				return configuration, fmt.Errorf("invalid %s: %s", k, v)
+			}
+			configuration.RequeueInterval = d
+		case operatorConfigIntegrationMonitorInterval, operatorConfigBuildPollInterval, operatorConfigPlatformMonitorInterval:
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 {
+				return configuration, fmt.Errorf("invalid %s: %s", k, v)
+			}
+			switch k {
+			case operatorConfigIntegrationMonitorInterval:
+				configuration.IntegrationMonitorInterval = d
+			case operatorConfigBuildPollInterval:
+				configuration.BuildPollInterval = d
+			case operatorConfigPlatformMonitorInterval:
+				configuration.PlatformMonitorInterval = d
+			}
+		case operatorConfigKitGCInterval:
+			d, err := time.ParseDuration(v)
+			if err != nil || d <= 0 {
+				return configuration, fmt.Errorf("invalid %s: %s", k, v)
+			}
+			configuration.KitGCInterval = d
+		case operatorConfigDriftEnforcement:
+			switch v {
+			case DriftEnforcementNone, DriftEnforcementRecreate, DriftEnforcementRevert:
+				configuration.DriftEnforcement = v
+			default:
+				return configuration, fmt.Errorf("invalid %s: %s", k, v)
+			}
+		case operatorConfigTraitSnapshots:
+			switch v {
+			case TraitSnapshotsNone, TraitSnapshotsConfigMap, TraitSnapshotsFile:
+				configuration.TraitSnapshots = v
+			default:
+				return configuration, fmt.Errorf("invalid %s: %s", k, v)
+			}
+		default:
+			return configuration, fmt.Errorf("unknown operator configuration key: %s", k)
+		}
+	}
+
+	for _, interval := range []*time.Duration{
+		&configuration.IntegrationMonitorInterval,
+		&configuration.BuildPollInterval,
+		&configuration.PlatformMonitorInterval,
+	} {
+		if *interval == 0 {
+			*interval = configuration.RequeueInterval
+		}
+	}
+
+	return configuration, nil
+}
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/deploy"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestParseOperatorConfiguration(t *testing.T) {
+	c, err := ParseOperatorConfiguration(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultOperatorConfiguration(), c)
+
+	c, err = ParseOperatorConfiguration(map[string]string{
+		"runtime-version":          "0.3.4",
+		"max-running-builds":       "3",
+		"max-running-builds-total": "10",
+		"log-level":                "debug",
+		"requeue-interval":         "30s",
+		"drift-enforcement":        "revert",
+		"trait-snapshots":          "configmap",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, OperatorConfiguration{
+		RuntimeVersion:        "0.3.4",
+		MaxRunningBuilds:      3,
+		MaxRunningBuildsTotal: 10,
+		LogLevel:              "debug",
+		RequeueInterval:       30 * time.Second,
+		DriftEnforcement:      DriftEnforcementRevert,
+		TraitSnapshots:        TraitSnapshotsConfigMap,
+
+		IntegrationMonitorInterval: 30 * time.Second,
+		BuildPollInterval:          30 * time.Second,
+		PlatformMonitorInterval:    30 * time.Second,
+		KitGCInterval:              time.Minute,
+	}, c)
+
+	c, err = ParseOperatorConfiguration(map[string]string{
+		"requeue-interval":             "30s",
+		"integration-monitor-interval": "1m",
+		"build-poll-interval":          "2s",
+		"kit-gc-interval":              "10m",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 30*time.Second, c.RequeueInterval)
+	assert.Equal(t, time.Minute, c.IntegrationMonitorInterval)
+	assert.Equal(t, 2*time.Second, c.BuildPollInterval)
+	assert.Equal(t, 30*time.Second, c.PlatformMonitorInterval)
+	assert.Equal(t, 10*time.Minute, c.KitGCInterval)
+
+	_, err = ParseOperatorConfiguration(map[string]string{"max-running-builds": "0"})
+	assert.NotNil(t, err)
+	_, err = ParseOperatorConfiguration(map[string]string{"max-running-builds-total": "-1"})
+	assert.NotNil(t, err)
+	_, err = ParseOperatorConfiguration(map[string]string{"requeue-interval": "often"})
+	assert.NotNil(t, err)
+	_, err = ParseOperatorConfiguration(map[string]string{"build-poll-interval": "-1s"})
+	assert.NotNil(t, err)
+	_, err = ParseOperatorConfiguration(map[string]string{"kit-gc-interval": "0s"})
+	assert.NotNil(t, err)
+	_, err = ParseOperatorConfiguration(map[string]string{"platform-monitor-interval": "often"})
+	assert.NotNil(t, err)
+	_, err = ParseOperatorConfiguration(map[string]string{"log-level": "verbose"})
+	assert.NotNil(t, err)
+	_, err = ParseOperatorConfiguration(map[string]string{"drift-enforcement": "always"})
+	assert.NotNil(t, err)
+	_, err = ParseOperatorConfiguration(map[string]string{"trait-snapshots": "true"})
+	assert.NotNil(t, err)
+	_, err = ParseOperatorConfiguration(map[string]string{"unknown": "value"})
+	assert.NotNil(t, err)
+}
+
+func TestShippedOperatorConfiguration(t *testing.T) {
+	obj, err := kubernetes.LoadResourceFromYaml(scheme.Scheme, deploy.Resources["operator-config.yaml"])
+	assert.Nil(t, err)
+
+	cm, ok := obj.(*corev1.ConfigMap)
+	assert.True(t, ok)
+	assert.Equal(t, OperatorConfigMapName, cm.Name)
+
+	// the shipped ConfigMap holds the default values
+	configuration, err := ParseOperatorConfiguration(cm.Data)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultOperatorConfiguration(), configuration)
+}
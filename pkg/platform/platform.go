@@ -25,8 +25,23 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/util/defaults"
+	"github.com/apache/camel-k/pkg/util/jvm"
 )
 
+// baseImages maps a java version to the base image used to build and run integrations
+var baseImages = map[string]string{
+	"8":  defaults.BaseImage,
+	"11": "fabric8/s2i-java:3.0-java11",
+}
+
+// fipsBaseImages maps a java version to the base image whose JDK uses the FIPS validated crypto modules of the
+// system when the nodes run in FIPS mode
+var fipsBaseImages = map[string]string{
+	"8":  "registry.access.redhat.com/ubi8/openjdk-8",
+	"11": "registry.access.redhat.com/ubi8/openjdk-11",
+}
+
 // GetCurrentPlatform returns the currently installed platform
 func GetCurrentPlatform(ctx context.Context, c client.Client, namespace string) (*v1alpha1.IntegrationPlatform, error) {
 	lst, err := ListPlatforms(ctx, c, namespace)
@@ -80,3 +95,32 @@ func SupportsS2iPublishStrategy(p *v1alpha1.IntegrationPlatform) bool {
 func SupportsKanikoPublishStrategy(p *v1alpha1.IntegrationPlatform) bool {
 	return p.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko && p.Spec.Build.Registry.Address != ""
 }
+
+// SupportsBuildahPublishStrategy --
+func SupportsBuildahPublishStrategy(p *v1alpha1.IntegrationPlatform) bool {
+	return p.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyBuildah && p.Spec.Build.Registry.Address != ""
+}
+
+// SupportsSpectrumPublishStrategy --
+func SupportsSpectrumPublishStrategy(p *v1alpha1.IntegrationPlatform) bool {
+	return p.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategySpectrum && p.Spec.Build.Registry.Address != ""
+}
+
+// SupportsTektonPublishStrategy --
+func SupportsTektonPublishStrategy(p *v1alpha1.IntegrationPlatform) bool {
+	return p.Spec.Build.PublishStrategy == v1alpha1.IntegrationPlatformBuildPublishStrategyTekton &&
+		p.Spec.Build.Tekton.Pipeline != "" &&
+		p.Spec.Build.Registry.Address != ""
+}
+
+// DefaultBaseImage returns the base image matching the given java version, falling back to the default one
+func DefaultBaseImage(javaVersion string, fips bool) string {
+	images := baseImages
+	if fips {
+		images = fipsBaseImages
+	}
+	if image, ok := images[jvm.NormalizeJavaVersion(javaVersion)]; ok {
+		return image
+	}
+	return images["8"]
+}
@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	"github.com/pkg/errors"
+)
+
+// PolicyReasonViolated --
+const PolicyReasonViolated = "PolicyViolated"
+
+// PolicyViolations returns the naming and labeling conventions of the given policy the integration with the
+// given name and labels does not follow, as messages telling the user how to fix them
+func PolicyViolations(policy v1alpha1.IntegrationPlatformPolicySpec, name string, labels map[string]string) ([]string, error) {
+	violations := make([]string, 0)
+
+	if policy.NamePattern != "" {
+		rex, err := regexp.Compile(policy.NamePattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid integration name pattern %s", policy.NamePattern)
+		}
+		if !rex.MatchString(name) {
+			violations = append(violations, fmt.Sprintf("name %q does not match the pattern %q, rename the integration", name, policy.NamePattern))
+		}
+	}
+
+	missing := make([]string, 0)
+	for _, label := range policy.RequiredLabels {
+		if labels[label] == "" {
+			missing = append(missing, label)
+		}
+	}
+	if len(missing) > 0 {
+		violations = append(violations, fmt.Sprintf("missing required labels %s, set them with e.g. kamel run --label %s=<value>",
+			strings.Join(missing, ", "), missing[0]))
+	}
+
+	return violations, nil
+}
+
+// CheckIntegrationPolicy returns the condition to be set on an integration that cannot be admitted because
+// it does not follow the naming and labeling policy of the platform, or nil if the integration follows it
+func CheckIntegrationPolicy(p *v1alpha1.IntegrationPlatform, integration *v1alpha1.Integration) (*v1alpha1.Condition, error) {
+	violations, err := PolicyViolations(p.Spec.Policy, integration.Name, integration.Labels)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) == 0 {
+		return nil, nil
+	}
+
+	return &v1alpha1.Condition{
+		Type:    v1alpha1.ConditionPolicyViolated,
+		Status:  corev1.ConditionTrue,
+		Reason:  PolicyReasonViolated,
+		Message: strings.Join(violations, "; "),
+	}, nil
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+func TestCheckIntegrationPolicy(t *testing.T) {
+	p := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	integration := newIntegration("MyRoute", v1alpha1.IntegrationPhaseInitial)
+
+	violation, err := CheckIntegrationPolicy(&p, &integration)
+	assert.Nil(t, err)
+	assert.Nil(t, violation)
+
+	p.Spec.Policy.NamePattern = "^[a-z]+-[a-z]+$"
+	p.Spec.Policy.RequiredLabels = []string{"team", "cost-center"}
+	integration.Labels = map[string]string{"cost-center": "42"}
+
+	violation, err = CheckIntegrationPolicy(&p, &integration)
+	assert.Nil(t, err)
+	assert.NotNil(t, violation)
+	assert.Equal(t, v1alpha1.ConditionPolicyViolated, violation.Type)
+	assert.Equal(t, PolicyReasonViolated, violation.Reason)
+	assert.Contains(t, violation.Message, `name "MyRoute" does not match the pattern "^[a-z]+-[a-z]+$"`)
+	assert.Contains(t, violation.Message, "missing required labels team, set them with e.g. kamel run --label team=<value>")
+
+	integration = newIntegration("my-route", v1alpha1.IntegrationPhaseInitial)
+	integration.Labels = map[string]string{"team": "integration", "cost-center": "42"}
+
+	violation, err = CheckIntegrationPolicy(&p, &integration)
+	assert.Nil(t, err)
+	assert.Nil(t, violation)
+
+	p.Spec.Policy.NamePattern = "[a-z"
+	_, err = CheckIntegrationPolicy(&p, &integration)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+)
+
+const (
+	// QuotaReasonMaxRunningBuilds --
+	QuotaReasonMaxRunningBuilds = "MaxRunningBuildsReached"
+	// QuotaReasonMaxKits --
+	QuotaReasonMaxKits = "MaxKitsReached"
+	// QuotaReasonMaxIntegrations --
+	QuotaReasonMaxIntegrations = "MaxIntegrationsReached"
+)
+
+// CheckBuildQuota returns the condition to be set on a build that cannot be scheduled
+// because the given number of builds is already running, or nil if the build is within the quota
+func CheckBuildQuota(spec v1alpha1.IntegrationPlatformSpec, runningBuilds int) *v1alpha1.Condition {
+	max := spec.Quota.MaxRunningBuilds
+	if max <= 0 || runningBuilds < max {
+		return nil
+	}
+
+	return quotaExceededCondition(QuotaReasonMaxRunningBuilds,
+		fmt.Sprintf("the namespace has reached its limit of %d concurrent builds", max))
+}
+
+// CheckIntegrationKitQuota returns the condition to be set on a kit that cannot be admitted
+// because the namespace has reached the maximum number of kits, or nil if the kit is within the quota
+func CheckIntegrationKitQuota(ctx context.Context, c client.Client, p *v1alpha1.IntegrationPlatform, kit *v1alpha1.IntegrationKit) (*v1alpha1.Condition, error) {
+	max := p.Spec.Quota.MaxKits
+	if max <= 0 {
+		return nil, nil
+	}
+
+	kits := v1alpha1.NewIntegrationKitList()
+	if err := c.List(ctx, &k8sclient.ListOptions{Namespace: kit.Namespace}, &kits); err != nil {
+		return nil, err
+	}
+
+	admitted := 0
+	for _, k := range kits.Items {
+		if k.Name != kit.Name && k.Status.Phase != "" {
+			admitted++
+		}
+	}
+
+	if admitted < max {
+		return nil, nil
+	}
+
+	return quotaExceededCondition(QuotaReasonMaxKits,
+		fmt.Sprintf("the namespace has reached its limit of %d integration kits", max)), nil
+}
+
+// CheckIntegrationQuota returns the condition to be set on an integration that cannot be admitted
+// because the namespace has reached the maximum number of integrations, or nil if the integration
+// is within the quota
+func CheckIntegrationQuota(ctx context.Context, c client.Client, p *v1alpha1.IntegrationPlatform, integration *v1alpha1.Integration) (*v1alpha1.Condition, error) {
+	max := p.Spec.Quota.MaxIntegrations
+	if max <= 0 {
+		return nil, nil
+	}
+
+	integrations := v1alpha1.NewIntegrationList()
+	if err := c.List(ctx, &k8sclient.ListOptions{Namespace: integration.Namespace}, &integrations); err != nil {
+		return nil, err
+	}
+
+	admitted := 0
+	for _, i := range integrations.Items {
+		if i.Name == integration.Name {
+			continue
+		}
+		if i.Status.Phase != v1alpha1.IntegrationPhaseInitial && i.Status.Phase != v1alpha1.IntegrationPhaseWaitingForPlatform {
+			admitted++
+		}
+	}
+
+	if admitted < max {
+		return nil, nil
+	}
+
+	return quotaExceededCondition(QuotaReasonMaxIntegrations,
+		fmt.Sprintf("the namespace has reached its limit of %d integrations", max)), nil
+}
+
+func quotaExceededCondition(reason string, message string) *v1alpha1.Condition {
+	return &v1alpha1.Condition{
+		Type:    v1alpha1.ConditionQuotaExceeded,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	}
+}
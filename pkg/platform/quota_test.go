@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestCheckBuildQuota(t *testing.T) {
+	spec := v1alpha1.IntegrationPlatformSpec{}
+	assert.Nil(t, CheckBuildQuota(spec, 10))
+
+	spec.Quota.MaxRunningBuilds = 2
+	assert.Nil(t, CheckBuildQuota(spec, 1))
+
+	quota := CheckBuildQuota(spec, 2)
+	assert.NotNil(t, quota)
+	assert.Equal(t, v1alpha1.ConditionQuotaExceeded, quota.Type)
+	assert.Equal(t, QuotaReasonMaxRunningBuilds, quota.Reason)
+}
+
+func TestCheckIntegrationQuota(t *testing.T) {
+	running := newIntegration("running", v1alpha1.IntegrationPhaseRunning)
+	waiting := newIntegration("waiting", v1alpha1.IntegrationPhaseInitial)
+	candidate := newIntegration("candidate", v1alpha1.IntegrationPhaseInitial)
+
+	c, err := test.NewFakeClient(&running, &waiting, &candidate)
+	assert.Nil(t, err)
+
+	p := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+
+	quota, err := CheckIntegrationQuota(context.TODO(), c, &p, &candidate)
+	assert.Nil(t, err)
+	assert.Nil(t, quota)
+
+	p.Spec.Quota.MaxIntegrations = 2
+	quota, err = CheckIntegrationQuota(context.TODO(), c, &p, &candidate)
+	assert.Nil(t, err)
+	assert.Nil(t, quota)
+
+	p.Spec.Quota.MaxIntegrations = 1
+	quota, err = CheckIntegrationQuota(context.TODO(), c, &p, &candidate)
+	assert.Nil(t, err)
+	assert.NotNil(t, quota)
+	assert.Equal(t, QuotaReasonMaxIntegrations, quota.Reason)
+
+	// an already admitted integration is never held back by itself
+	quota, err = CheckIntegrationQuota(context.TODO(), c, &p, &running)
+	assert.Nil(t, err)
+	assert.Nil(t, quota)
+}
+
+func TestCheckIntegrationKitQuota(t *testing.T) {
+	ready := newIntegrationKit("ready", v1alpha1.IntegrationKitPhaseReady)
+	candidate := newIntegrationKit("candidate", "")
+
+	c, err := test.NewFakeClient(&ready, &candidate)
+	assert.Nil(t, err)
+
+	p := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	p.Spec.Quota.MaxKits = 2
+
+	quota, err := CheckIntegrationKitQuota(context.TODO(), c, &p, &candidate)
+	assert.Nil(t, err)
+	assert.Nil(t, quota)
+
+	p.Spec.Quota.MaxKits = 1
+	quota, err = CheckIntegrationKitQuota(context.TODO(), c, &p, &candidate)
+	assert.Nil(t, err)
+	assert.NotNil(t, quota)
+	assert.Equal(t, QuotaReasonMaxKits, quota.Reason)
+}
+
+func newIntegration(name string, phase v1alpha1.IntegrationPhase) v1alpha1.Integration {
+	integration := v1alpha1.NewIntegration("ns", name)
+	integration.Status.Phase = phase
+	return integration
+}
+
+func newIntegrationKit(name string, phase v1alpha1.IntegrationKitPhase) v1alpha1.IntegrationKit {
+	kit := v1alpha1.NewIntegrationKit("ns", name)
+	kit.Status.Phase = phase
+	return kit
+}
@@ -0,0 +1,71 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/proxy"
+)
+
+// registryProbeTimeout is how long the registry has to answer a probe
+const registryProbeTimeout = 5 * time.Second
+
+// ProbeRegistry checks that the registry the platform publishes the kit images to answers the
+// Docker Registry API, returning nil when no registry is configured, e.g. with the S2I publish strategy
+func ProbeRegistry(ctx context.Context, p *v1alpha1.IntegrationPlatform) *v1alpha1.IntegrationPlatformRegistryStatus {
+	address := p.Spec.Build.Registry.Address
+	if address == "" {
+		return nil
+	}
+	if !strings.Contains(address, "://") {
+		if p.Spec.Build.Registry.Insecure {
+			address = "http://" + address
+		} else {
+			address = "https://" + address
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, registryProbeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(address, "/")+"/v2/", nil)
+	if err != nil {
+		return &v1alpha1.IntegrationPlatformRegistryStatus{Message: err.Error()}
+	}
+
+	resp, err := proxy.NewClient(p.Spec.Build.Proxy).Do(req.WithContext(ctx))
+	if err != nil {
+		return &v1alpha1.IntegrationPlatformRegistryStatus{Message: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	// the registry asking for credentials is up and running
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusUnauthorized {
+		return &v1alpha1.IntegrationPlatformRegistryStatus{
+			Message: fmt.Sprintf("unexpected status %s", resp.Status),
+		}
+	}
+
+	return &v1alpha1.IntegrationPlatformRegistryStatus{Healthy: true}
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProbeRegistry(t *testing.T) {
+	statuses := map[string]int{
+		"/ok":        http.StatusOK,
+		"/secured":   http.StatusUnauthorized,
+		"/not-found": http.StatusNotFound,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statuses[strings.TrimSuffix(r.URL.Path, "/v2/")])
+	}))
+	defer server.Close()
+
+	p := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	assert.Nil(t, ProbeRegistry(context.TODO(), &p))
+
+	p.Spec.Build.Registry.Insecure = true
+
+	p.Spec.Build.Registry.Address = strings.TrimPrefix(server.URL, "http://") + "/ok"
+	assert.Equal(t, &v1alpha1.IntegrationPlatformRegistryStatus{Healthy: true}, ProbeRegistry(context.TODO(), &p))
+
+	p.Spec.Build.Registry.Address = server.URL + "/secured"
+	assert.True(t, ProbeRegistry(context.TODO(), &p).Healthy)
+
+	p.Spec.Build.Registry.Address = server.URL + "/not-found"
+	status := ProbeRegistry(context.TODO(), &p)
+	assert.False(t, status.Healthy)
+	assert.Equal(t, "unexpected status 404 Not Found", status.Message)
+}
@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/scan"
+)
+
+// VulnerabilitiesReasonFound --
+const VulnerabilitiesReasonFound = "VulnerabilitiesFound"
+
+// KitVulnerabilities returns the condition to be set on a kit whose image contains vulnerabilities at or above
+// the severity of the given image scan configuration, any severity if not set, or nil if the image is clean
+func KitVulnerabilities(spec v1alpha1.IntegrationPlatformImageScanSpec, summary map[string]int) *v1alpha1.Condition {
+	threshold := spec.Severity
+	if threshold == "" {
+		threshold = scan.SeverityUnknown
+	}
+
+	count := scan.Summary(summary).Exceeds(threshold)
+	if count == 0 {
+		return nil
+	}
+
+	severities := make([]string, 0, len(summary))
+	for severity := range summary {
+		severities = append(severities, severity)
+	}
+	sort.Strings(severities)
+
+	found := make([]string, 0, len(severities))
+	for _, severity := range severities {
+		if summary[severity] > 0 {
+			found = append(found, fmt.Sprintf("%s=%d", severity, summary[severity]))
+		}
+	}
+
+	return &v1alpha1.Condition{
+		Type:    v1alpha1.ConditionVulnerabilities,
+		Status:  corev1.ConditionTrue,
+		Reason:  VulnerabilitiesReasonFound,
+		Message: fmt.Sprintf("image contains %d vulnerabilities at or above %s severity (%s)", count, threshold, strings.Join(found, ", ")),
+	}
+}
+
+// IntegrationVulnerabilities returns the condition to be set on an integration using the given kit,
+// or nil if the kit image is not known to contain vulnerabilities
+func IntegrationVulnerabilities(kit *v1alpha1.IntegrationKit) *v1alpha1.Condition {
+	c := v1alpha1.GetCondition(kit.Status.Conditions, v1alpha1.ConditionVulnerabilities)
+	if c == nil || c.Status != corev1.ConditionTrue {
+		return nil
+	}
+
+	return &v1alpha1.Condition{
+		Type:    v1alpha1.ConditionVulnerabilities,
+		Status:  corev1.ConditionTrue,
+		Reason:  c.Reason,
+		Message: fmt.Sprintf("kit %s %s", kit.Name, c.Message),
+	}
+}
+
+// BlocksDeployment returns true if the platform holds back the deployment of the integrations using the given kit,
+// because its image contains vulnerabilities
+func BlocksDeployment(p *v1alpha1.IntegrationPlatform, kit *v1alpha1.IntegrationKit) bool {
+	if p.Spec.Build.ImageScan == nil || !p.Spec.Build.ImageScan.BlockDeployments {
+		return false
+	}
+
+	return IntegrationVulnerabilities(kit) != nil
+}
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package platform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+func TestKitVulnerabilities(t *testing.T) {
+	spec := v1alpha1.IntegrationPlatformImageScanSpec{}
+	summary := map[string]int{"HIGH": 2, "LOW": 1, "CRITICAL": 0}
+
+	c := KitVulnerabilities(spec, summary)
+	assert.NotNil(t, c)
+	assert.Equal(t, v1alpha1.ConditionVulnerabilities, c.Type)
+	assert.Equal(t, "image contains 3 vulnerabilities at or above UNKNOWN severity (HIGH=2, LOW=1)", c.Message)
+
+	spec.Severity = "high"
+	c = KitVulnerabilities(spec, summary)
+	assert.NotNil(t, c)
+	assert.Equal(t, "image contains 2 vulnerabilities at or above high severity (HIGH=2, LOW=1)", c.Message)
+
+	spec.Severity = "CRITICAL"
+	assert.Nil(t, KitVulnerabilities(spec, summary))
+	assert.Nil(t, KitVulnerabilities(spec, nil))
+}
+
+func TestBlocksDeployment(t *testing.T) {
+	p := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	kit := v1alpha1.NewIntegrationKit("ns", "my-kit")
+
+	assert.False(t, BlocksDeployment(&p, &kit))
+	assert.Nil(t, IntegrationVulnerabilities(&kit))
+
+	kit.Status.Conditions = v1alpha1.SetCondition(kit.Status.Conditions,
+		*KitVulnerabilities(v1alpha1.IntegrationPlatformImageScanSpec{}, map[string]int{"HIGH": 1}))
+
+	c := IntegrationVulnerabilities(&kit)
+	assert.NotNil(t, c)
+	assert.Equal(t, "kit my-kit image contains 1 vulnerabilities at or above UNKNOWN severity (HIGH=1)", c.Message)
+
+	p.Spec.Build.ImageScan = &v1alpha1.IntegrationPlatformImageScanSpec{}
+	assert.False(t, BlocksDeployment(&p, &kit))
+
+	p.Spec.Build.ImageScan.BlockDeployments = true
+	assert.True(t, BlocksDeployment(&p, &kit))
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+// Authorizer returns true if the caller identified by the given bearer token can list the integrations of the
+// given namespace, or of all the namespaces when it's empty
+type Authorizer func(ctx context.Context, token string, namespace string) (bool, error)
+
+// NewAuthorizer creates an authorizer asking the API server whether the callers can list integrations, on their
+// behalf, so that the token is authenticated by the API server and the operator needs no extra permissions
+func NewAuthorizer(cfg *rest.Config) Authorizer {
+	return func(ctx context.Context, token string, namespace string) (bool, error) {
+		callerCfg := rest.AnonymousClientConfig(cfg)
+		callerCfg.BearerToken = token
+
+		c, err := kubernetes.NewForConfig(callerCfg)
+		if err != nil {
+			return false, err
+		}
+
+		return canListIntegrations(c, namespace)
+	}
+}
+
+// canListIntegrations checks if the client user can list the integrations of the given namespace
+func canListIntegrations(c kubernetes.Interface, namespace string) (bool, error) {
+	review, err := c.AuthorizationV1().SelfSubjectAccessReviews().Create(&authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "list",
+				Group:     v1alpha1.SchemeGroupVersion.Group,
+				Resource:  "integrations",
+			},
+		},
+	})
+	if err != nil {
+		if k8serrors.IsUnauthorized(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return review.Status.Allowed, nil
+}
+
+// bearerToken returns the token of the Authorization header of the request, if any
+func bearerToken(r *http.Request) string {
+	header := r.Header.Get("Authorization")
+	if !strings.HasPrefix(header, "Bearer ") {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(header, "Bearer "))
+}
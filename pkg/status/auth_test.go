@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestCanListIntegrations(t *testing.T) {
+	c := fake.NewSimpleClientset()
+	c.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		attributes := review.Spec.ResourceAttributes
+		switch attributes.Namespace {
+		case "anonymous":
+			return true, review, k8serrors.NewUnauthorized("invalid token")
+		case "broken":
+			return true, review, k8serrors.NewServiceUnavailable("unavailable")
+		}
+		review.Status.Allowed = attributes.Namespace == "ns" && attributes.Verb == "list" &&
+			attributes.Group == "camel.apache.org" && attributes.Resource == "integrations"
+		return true, review, nil
+	})
+
+	allowed, err := canListIntegrations(c, "ns")
+	assert.Nil(t, err)
+	assert.True(t, allowed)
+
+	allowed, err = canListIntegrations(c, "")
+	assert.Nil(t, err)
+	assert.False(t, allowed)
+
+	allowed, err = canListIntegrations(c, "anonymous")
+	assert.Nil(t, err)
+	assert.False(t, allowed)
+
+	_, err = canListIntegrations(c, "broken")
+	assert.NotNil(t, err)
+}
+
+func TestBearerToken(t *testing.T) {
+	r := httptest.NewRequest("GET", Path, nil)
+	assert.Equal(t, "", bearerToken(r))
+
+	r.Header.Set("Authorization", "Basic dXNlcg==")
+	assert.Equal(t, "", bearerToken(r))
+
+	r.Header.Set("Authorization", "Bearer abc.def")
+	assert.Equal(t, "abc.def", bearerToken(r))
+}
@@ -0,0 +1,133 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+// Path is the path the summaries are served from
+const Path = "/status"
+
+// Server exposes the summaries of the watched namespaces over HTTP, it implements
+// the manager.Runnable interface so that it can be started along with the controllers.
+// As the callers send their bearer tokens, the server should be configured with TLS
+// unless it is only reachable from trusted networks.
+type Server struct {
+	address    string
+	reader     k8sclient.Reader
+	authorizer Authorizer
+	certFile   string
+	keyFile    string
+}
+
+// NewServer creates a server listening on the given address, the callers being authorized by the given authorizer
+func NewServer(address string, reader k8sclient.Reader, authorizer Authorizer) *Server {
+	return &Server{
+		address:    address,
+		reader:     reader,
+		authorizer: authorizer,
+	}
+}
+
+// WithTLS makes the server serve HTTPS using the given certificate and key files
+func (s *Server) WithTLS(certFile string, keyFile string) *Server {
+	s.certFile = certFile
+	s.keyFile = keyFile
+	return s
+}
+
+// Handler returns the HTTP handler serving the summaries, the namespace query parameter
+// can be used to restrict the summary to a single namespace. The callers must present a bearer
+// token allowing them to list the integrations of the namespace, or of all the namespaces
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(Path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		token := bearerToken(r)
+		if token == "" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		namespace := r.URL.Query().Get("namespace")
+		allowed, err := s.authorizer(r.Context(), token, namespace)
+		if err != nil {
+			log.Error(err, "cannot authorize status summary request")
+			http.Error(w, "cannot authorize request", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		summaries, err := Compute(r.Context(), s.reader, namespace)
+		if err != nil {
+			log.Error(err, "cannot compute status summary")
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(summaries); err != nil {
+			log.Error(err, "cannot write status summary")
+		}
+	})
+
+	return mux
+}
+
+// Start serves the summaries until the stop channel is closed
+func (s *Server) Start(stop <-chan struct{}) error {
+	server := &http.Server{
+		Addr:    s.address,
+		Handler: s.Handler(),
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		if s.certFile != "" && s.keyFile != "" {
+			log.Infof("Serving status summary on https://%s%s", s.address, Path)
+			errs <- server.ListenAndServeTLS(s.certFile, s.keyFile)
+			return
+		}
+		log.Infof("Serving status summary on http://%s%s, the bearer tokens of the callers are not encrypted", s.address, Path)
+		errs <- server.ListenAndServe()
+	}()
+
+	select {
+	case <-stop:
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(ctx)
+	case err := <-errs:
+		return err
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"sort"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+// PhaseInitializing is used in place of the empty phase of resources that have not been processed yet
+const PhaseInitializing = "Initializing"
+
+// Summary aggregates the state of the resources of a namespace
+type Summary struct {
+	Namespace           string               `json:"namespace"`
+	Integrations        map[string]int       `json:"integrations"`
+	Kits                map[string]int       `json:"kits"`
+	Builds              map[string]int       `json:"builds"`
+	BuildsInProgress    int                  `json:"buildsInProgress"`
+	FailingIntegrations []FailingIntegration `json:"failingIntegrations,omitempty"`
+}
+
+// FailingIntegration --
+type FailingIntegration struct {
+	Name   string `json:"name"`
+	Kit    string `json:"kit,omitempty"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Compute returns the summaries of the given namespace, or of all the namespaces if empty, sorted by namespace
+func Compute(ctx context.Context, c k8sclient.Reader, namespace string) ([]Summary, error) {
+	options := &k8sclient.ListOptions{Namespace: namespace}
+
+	integrations := v1alpha1.NewIntegrationList()
+	if err := c.List(ctx, options, &integrations); err != nil {
+		return nil, err
+	}
+	kits := v1alpha1.NewIntegrationKitList()
+	if err := c.List(ctx, options, &kits); err != nil {
+		return nil, err
+	}
+	builds := v1alpha1.BuildList{}
+	if err := c.List(ctx, options, &builds); err != nil {
+		return nil, err
+	}
+
+	summaries := make(map[string]*Summary)
+	summaryFor := func(ns string) *Summary {
+		if s, ok := summaries[ns]; ok {
+			return s
+		}
+		s := &Summary{
+			Namespace:    ns,
+			Integrations: make(map[string]int),
+			Kits:         make(map[string]int),
+			Builds:       make(map[string]int),
+		}
+		summaries[ns] = s
+		return s
+	}
+
+	kitsByName := make(map[string]v1alpha1.IntegrationKit)
+	for _, kit := range kits.Items {
+		kitsByName[kit.Namespace+"/"+kit.Name] = kit
+		summaryFor(kit.Namespace).Kits[phase(string(kit.Status.Phase))]++
+	}
+
+	buildsByName := make(map[string]v1alpha1.Build)
+	for _, build := range builds.Items {
+		buildsByName[build.Namespace+"/"+build.Name] = build
+
+		s := summaryFor(build.Namespace)
+		s.Builds[phase(string(build.Status.Phase))]++
+		if build.Status.Phase == v1alpha1.BuildPhasePending || build.Status.Phase == v1alpha1.BuildPhaseRunning {
+			s.BuildsInProgress++
+		}
+	}
+
+	for _, integration := range integrations.Items {
+		s := summaryFor(integration.Namespace)
+		s.Integrations[phase(string(integration.Status.Phase))]++
+
+		if integration.Status.Phase != v1alpha1.IntegrationPhaseError {
+			continue
+		}
+
+		failing := FailingIntegration{
+			Name: integration.Name,
+			Kit:  integration.Status.Kit,
+		}
+
+		// Look for the most specific reason of the failure, an integration
+		// generally fails because its kit could not be built
+		key := integration.Namespace + "/" + integration.Status.Kit
+		if integration.Status.Failure != nil {
+			failing.Reason = integration.Status.Failure.Reason
+		} else if kit, ok := kitsByName[key]; ok && kit.Status.Failure != nil {
+			failing.Reason = kit.Status.Failure.Reason
+		} else if build, ok := buildsByName[key]; ok && build.Status.Error != "" {
+			failing.Reason = build.Status.Error
+		}
+
+		s.FailingIntegrations = append(s.FailingIntegrations, failing)
+	}
+
+	result := make([]Summary, 0, len(summaries))
+	for _, s := range summaries {
+		sort.Slice(s.FailingIntegrations, func(i, j int) bool {
+			return s.FailingIntegrations[i].Name < s.FailingIntegrations[j].Name
+		})
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace < result[j].Namespace
+	})
+
+	return result, nil
+}
+
+func phase(p string) string {
+	if p == "" {
+		return PhaseInitializing
+	}
+	return p
+}
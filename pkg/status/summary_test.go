@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+)
+
+func TestCompute(t *testing.T) {
+	running := v1alpha1.NewIntegration("ns1", "running")
+	running.Status.Phase = v1alpha1.IntegrationPhaseRunning
+
+	failing := v1alpha1.NewIntegration("ns1", "failing")
+	failing.Status.Phase = v1alpha1.IntegrationPhaseError
+	failing.Status.Kit = "kit-1"
+
+	initializing := v1alpha1.NewIntegration("ns2", "initializing")
+
+	kit := v1alpha1.NewIntegrationKit("ns1", "kit-1")
+	kit.Status.Phase = v1alpha1.IntegrationKitPhaseError
+	kit.Status.Failure = &v1alpha1.Failure{
+		Reason: "build failed",
+	}
+
+	build := v1alpha1.Build{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+			Kind:       v1alpha1.BuildKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns2",
+			Name:      "kit-2",
+		},
+		Status: v1alpha1.BuildStatus{
+			Phase: v1alpha1.BuildPhaseRunning,
+		},
+	}
+
+	c, err := test.NewFakeClient(&running, &failing, &initializing, &kit, &build)
+	assert.Nil(t, err)
+
+	summaries, err := Compute(context.TODO(), c, "")
+	assert.Nil(t, err)
+	assert.Len(t, summaries, 2)
+
+	assert.Equal(t, "ns1", summaries[0].Namespace)
+	assert.Equal(t, map[string]int{"Running": 1, "Error": 1}, summaries[0].Integrations)
+	assert.Equal(t, map[string]int{"Error": 1}, summaries[0].Kits)
+	assert.Equal(t, []FailingIntegration{{Name: "failing", Kit: "kit-1", Reason: "build failed"}}, summaries[0].FailingIntegrations)
+
+	assert.Equal(t, "ns2", summaries[1].Namespace)
+	assert.Equal(t, map[string]int{PhaseInitializing: 1}, summaries[1].Integrations)
+	assert.Equal(t, map[string]int{"Running": 1}, summaries[1].Builds)
+	assert.Equal(t, 1, summaries[1].BuildsInProgress)
+	assert.Empty(t, summaries[1].FailingIntegrations)
+
+	summaries, err = Compute(context.TODO(), c, "ns2")
+	assert.Nil(t, err)
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, "ns2", summaries[0].Namespace)
+}
+
+func TestHandler(t *testing.T) {
+	integration := v1alpha1.NewIntegration("ns", "running")
+	integration.Status.Phase = v1alpha1.IntegrationPhaseRunning
+
+	c, err := test.NewFakeClient(&integration)
+	assert.Nil(t, err)
+
+	authorizer := func(_ context.Context, token string, namespace string) (bool, error) {
+		return token == "valid" && namespace == "ns", nil
+	}
+	server := httptest.NewServer(NewServer(":0", c, authorizer).Handler())
+	defer server.Close()
+
+	get := func(query string, token string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, server.URL+Path+query, nil)
+		assert.Nil(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		assert.Nil(t, err)
+		return res
+	}
+
+	res := get("?namespace=ns", "")
+	res.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	res = get("?namespace=ns", "other")
+	res.Body.Close()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res = get("", "valid")
+	res.Body.Close()
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res = get("?namespace=ns", "valid")
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "application/json", res.Header.Get("Content-Type"))
+
+	var summaries []Summary
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&summaries))
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, 1, summaries[0].Integrations["Running"])
+
+	res, err = http.Post(server.URL+Path, "application/json", nil)
+	assert.Nil(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, res.StatusCode)
+}
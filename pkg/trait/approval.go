@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The approval trait holds back the rollout of rebuilt integration images until they are approved,
+// either manually through the approved-image annotation (e.g. with kamel approve) or after a delay.
+// It's meant to be enabled on the platform of protected namespaces.
+type approvalTrait struct {
+	BaseTrait        `property:",squash"`
+	AutoApproveAfter string `property:"auto-approve-after"`
+}
+
+func newApprovalTrait() *approvalTrait {
+	return &approvalTrait{
+		BaseTrait: newBaseTrait("approval"),
+	}
+}
+
+func (t *approvalTrait) Configure(e *Environment) (bool, error) {
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying) {
+		return false, nil
+	}
+
+	if t.Enabled == nil || !*t.Enabled {
+		// release the integrations held back while the trait was enabled
+		e.Integration.Status.Conditions = v1alpha1.RemoveCondition(e.Integration.Status.Conditions, v1alpha1.ConditionWaitingForApproval)
+		return false, nil
+	}
+
+	if t.AutoApproveAfter != "" {
+		if _, err := time.ParseDuration(t.AutoApproveAfter); err != nil {
+			return false, errors.Wrapf(err, "invalid auto-approve-after duration %s", t.AutoApproveAfter)
+		}
+	}
+
+	return true, nil
+}
+
+func (t *approvalTrait) Apply(e *Environment) error {
+	image := e.Integration.Status.Image
+
+	deployed, err := t.deployedImage(e)
+	if err != nil {
+		return err
+	}
+
+	current := v1alpha1.GetCondition(e.Integration.Status.Conditions, v1alpha1.ConditionWaitingForApproval)
+	if deployed == "" || deployed == image || t.approved(e.Integration, current) {
+		e.Integration.Status.Conditions = v1alpha1.RemoveCondition(e.Integration.Status.Conditions, v1alpha1.ConditionWaitingForApproval)
+		return nil
+	}
+
+	message := fmt.Sprintf("rollout of image %s is waiting for approval, set the %s annotation or run kamel approve %s",
+		image, v1alpha1.IntegrationApprovedImageAnnotation, e.Integration.Name)
+	if t.AutoApproveAfter != "" {
+		message += fmt.Sprintf(" (automatically approved after %s)", t.AutoApproveAfter)
+	}
+
+	condition := v1alpha1.Condition{
+		Type:    v1alpha1.ConditionWaitingForApproval,
+		Status:  corev1.ConditionTrue,
+		Reason:  "ApprovalRequired",
+		Message: message,
+	}
+	// restart the approval delay when a newer image is waiting
+	if current != nil && current.Message != message {
+		e.Integration.Status.Conditions = v1alpha1.RemoveCondition(e.Integration.Status.Conditions, v1alpha1.ConditionWaitingForApproval)
+	}
+	e.Integration.Status.Conditions = v1alpha1.SetCondition(e.Integration.Status.Conditions, condition)
+
+	return nil
+}
+
+func (t *approvalTrait) approved(integration *v1alpha1.Integration, waiting *v1alpha1.Condition) bool {
+	if integration.Annotations[v1alpha1.IntegrationApprovedImageAnnotation] == integration.Status.Image {
+		return true
+	}
+	if t.AutoApproveAfter == "" || waiting == nil {
+		return false
+	}
+
+	// the duration has been validated while configuring the trait
+	delay, _ := time.ParseDuration(t.AutoApproveAfter)
+	return waiting.LastTransitionTime.Add(delay).Before(time.Now())
+}
+
+// deployedImage returns the image currently run by the integration workload, if any
+func (t *approvalTrait) deployedImage(e *Environment) (string, error) {
+	strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+	if err != nil {
+		return "", err
+	}
+
+	var workload runtime.Object
+	if strategy == ControllerStrategyKnativeService {
+		workload = &serving.Service{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: serving.SchemeGroupVersion.String(),
+				Kind:       "Service",
+			},
+		}
+	} else {
+		workload = &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "Deployment",
+			},
+		}
+	}
+
+	key := k8sclient.ObjectKey{
+		Namespace: e.Integration.Namespace,
+		Name:      e.Integration.Name,
+	}
+	if err := t.client.Get(t.ctx, key, workload); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	switch w := workload.(type) {
+	case *appsv1.Deployment:
+		if len(w.Spec.Template.Spec.Containers) > 0 {
+			return w.Spec.Template.Spec.Containers[0].Image, nil
+		}
+	case *serving.Service:
+		if w.Spec.RunLatest != nil {
+			return w.Spec.RunLatest.Configuration.RevisionTemplate.Spec.Container.Image, nil
+		}
+	}
+
+	return "", nil
+}
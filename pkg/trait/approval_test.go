@@ -0,0 +1,126 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createApprovalTestEnv(t *testing.T, deployedImage string) (*approvalTrait, *Environment) {
+	deployment := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "my-integration",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "integration", Image: deployedImage},
+					},
+				},
+			},
+		},
+	}
+
+	c, err := test.NewFakeClient(&deployment)
+	assert.Nil(t, err)
+
+	trait := newApprovalTrait()
+	trait.Enabled = &[]bool{true}[0]
+	trait.InjectClient(c)
+	trait.InjectContext(context.TODO())
+
+	env := createIntegrationTestEnv(t, "my-integration", v1alpha1.IntegrationPhaseDeploying)
+	env.Integration.Status.Image = "registry/ns/kit:2"
+
+	return trait, env
+}
+
+func applyApprovalTrait(t *testing.T, trait *approvalTrait, env *Environment) *v1alpha1.Condition {
+	enabled, err := trait.Configure(env)
+	assert.Nil(t, err)
+	if enabled {
+		assert.Nil(t, trait.Apply(env))
+	}
+
+	return v1alpha1.GetCondition(env.Integration.Status.Conditions, v1alpha1.ConditionWaitingForApproval)
+}
+
+func TestApprovalHoldsRebuiltImage(t *testing.T) {
+	trait, env := createApprovalTestEnv(t, "registry/ns/kit:1")
+
+	condition := applyApprovalTrait(t, trait, env)
+	assert.NotNil(t, condition)
+	assert.Equal(t, "ApprovalRequired", condition.Reason)
+	assert.Contains(t, condition.Message, "registry/ns/kit:2")
+
+	env.Integration.Annotations = map[string]string{
+		v1alpha1.IntegrationApprovedImageAnnotation: "registry/ns/kit:2",
+	}
+	assert.Nil(t, applyApprovalTrait(t, trait, env))
+}
+
+func TestApprovalSameImage(t *testing.T) {
+	trait, env := createApprovalTestEnv(t, "registry/ns/kit:2")
+
+	assert.Nil(t, applyApprovalTrait(t, trait, env))
+}
+
+func TestApprovalAutoApproveAfter(t *testing.T) {
+	trait, env := createApprovalTestEnv(t, "registry/ns/kit:1")
+	trait.AutoApproveAfter = "1h"
+
+	condition := applyApprovalTrait(t, trait, env)
+	assert.NotNil(t, condition)
+	assert.Contains(t, condition.Message, "automatically approved after 1h")
+
+	// still waiting before the delay elapses
+	assert.NotNil(t, applyApprovalTrait(t, trait, env))
+
+	condition.LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	assert.Nil(t, applyApprovalTrait(t, trait, env))
+
+	trait.AutoApproveAfter = "soon"
+	_, err := trait.Configure(env)
+	assert.NotNil(t, err)
+}
+
+func TestApprovalDisabledReleasesIntegration(t *testing.T) {
+	trait, env := createApprovalTestEnv(t, "registry/ns/kit:1")
+
+	assert.NotNil(t, applyApprovalTrait(t, trait, env))
+
+	trait.Enabled = nil
+	assert.Nil(t, applyApprovalTrait(t, trait, env))
+}
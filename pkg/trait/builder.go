@@ -18,15 +18,26 @@ limitations under the License.
 package trait
 
 import (
+	"github.com/pkg/errors"
+
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
-	"github.com/apache/camel-k/pkg/builder/kaniko"
-	"github.com/apache/camel-k/pkg/builder/s2i"
-	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/builder"
+
+	// register the built-in publish strategies
+	_ "github.com/apache/camel-k/pkg/builder/buildah"
+	_ "github.com/apache/camel-k/pkg/builder/kaniko"
+	_ "github.com/apache/camel-k/pkg/builder/s2i"
+	_ "github.com/apache/camel-k/pkg/builder/spectrum"
+	_ "github.com/apache/camel-k/pkg/builder/tekton"
 )
 
 // TODO: we should add a way to label a trait as platform so it cannot be disabled/removed
 type builderTrait struct {
-	BaseTrait `property:",squash"`
+	BaseTrait          `property:",squash"`
+	DeduplicateJars    *bool `property:"deduplicate-jars"`
+	JLink              bool  `property:"jlink"`
+	AppCDS             bool  `property:"appcds"`
+	ClasspathConflicts bool  `property:"classpath-conflicts"`
 }
 
 func newBuilderTrait() *builderTrait {
@@ -44,15 +55,63 @@ func (t *builderTrait) Configure(e *Environment) (bool, error) {
 }
 
 func (t *builderTrait) Apply(e *Environment) error {
-	if platform.SupportsS2iPublishStrategy(e.Platform) {
-		e.Steps = s2i.DefaultSteps
-		if e.DetermineProfile() == v1alpha1.TraitProfileKnative {
-			e.Steps = append(e.Steps, s2i.Steps.ReplaceHost)
+	if strategy, ok := builder.PublishStrategyFor(e.Platform); ok {
+		e.Steps = strategy.Steps(e.DetermineProfile())
+		e.BuildDir = strategy.BuildDir()
+	}
+
+	if e.Platform.Spec.Build.BuildTool == v1alpha1.IntegrationPlatformBuildToolGradle {
+		e.Steps = builder.ReplaceStep(e.Steps, builder.Steps.ComputeDependencies, builder.Steps.ComputeGradleDependencies)
+	}
+
+	// the build environment is set on the builder containers, except when the build runs in the operator
+	build := e.Platform.Spec.Build
+	if build.BuildStrategy == v1alpha1.IntegrationPlatformBuildStrategyRoutine && (len(build.Env) > 0 || len(build.Secrets) > 0) {
+		e.Steps = append(e.Steps, builder.Steps.InjectBuildEnvironment)
+	}
+
+	if t.DeduplicateJars == nil || *t.DeduplicateJars {
+		e.Steps = append(e.Steps, builder.Steps.DeduplicateArtifacts)
+	}
+	// the detection opens all the jars, so it only runs when requested by the trait or by an explicit platform policy
+	if t.ClasspathConflicts || (build.ClasspathConflicts != "" && build.ClasspathConflicts != v1alpha1.IntegrationPlatformClasspathPolicyIgnore) {
+		e.Steps = append(e.Steps, builder.Steps.DetectClasspathConflicts)
+	}
+	if t.JLink {
+		e.Steps = append(e.Steps, builder.Steps.TrimJRE)
+	}
+	if t.AppCDS {
+		// the training launch runs the code of the dependencies, that must not run in the operator
+		if build.BuildStrategy == v1alpha1.IntegrationPlatformBuildStrategyRoutine {
+			return errors.New("the class data sharing archive can only be created by the pod build strategy")
 		}
-	} else if platform.SupportsKanikoPublishStrategy(e.Platform) {
-		e.Steps = kaniko.DefaultSteps
-		e.BuildDir = kaniko.BuildDir
+		e.Steps = append(e.Steps, builder.Steps.GenerateAppCDSArchive)
+	}
+	if len(build.Provided.Artifacts) > 0 {
+		e.Steps = append(e.Steps, builder.Steps.ExcludeProvidedArtifacts)
+	}
+
+	if e.Platform.Spec.Build.ImageScan != nil {
+		e.Steps = append(e.Steps, builder.Steps.ScanImage)
 	}
 
 	return nil
 }
+
+// hasTrimmedJRE returns true if the kit image contains a JRE trimmed by the builder
+func hasTrimmedJRE(kit *v1alpha1.IntegrationKit) bool {
+	if kit == nil {
+		return false
+	}
+	spec, ok := kit.Spec.Traits["builder"]
+	return ok && spec.Configuration["jlink"] == "true"
+}
+
+// hasAppCDSArchive returns true if the kit image has been built with a class data sharing archive
+func hasAppCDSArchive(kit *v1alpha1.IntegrationKit) bool {
+	if kit == nil {
+		return false
+	}
+	spec, ok := kit.Spec.Traits["builder"]
+	return ok && spec.Configuration["appcds"] == "true"
+}
@@ -26,8 +26,11 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/builder/buildah"
 	"github.com/apache/camel-k/pkg/builder/kaniko"
 	"github.com/apache/camel-k/pkg/builder/s2i"
+	"github.com/apache/camel-k/pkg/builder/spectrum"
+	"github.com/apache/camel-k/pkg/builder/tekton"
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/apache/camel-k/pkg/util/test"
@@ -87,7 +90,7 @@ func TestS2IBuilderTrait(t *testing.T) {
 	assert.NotEmpty(t, env.ExecutedTraits)
 	assert.NotNil(t, env.GetTrait(ID("builder")))
 	assert.NotEmpty(t, env.Steps)
-	assert.Len(t, env.Steps, 7)
+	assert.Len(t, env.Steps, 9)
 	assert.Condition(t, func() bool {
 		for _, s := range env.Steps {
 			if s == s2i.Steps.Publisher && s.Phase() == builder.ApplicationPublishPhase {
@@ -107,7 +110,7 @@ func TestKanikoBuilderTrait(t *testing.T) {
 	assert.NotEmpty(t, env.ExecutedTraits)
 	assert.NotNil(t, env.GetTrait(ID("builder")))
 	assert.NotEmpty(t, env.Steps)
-	assert.Len(t, env.Steps, 7)
+	assert.Len(t, env.Steps, 9)
 	assert.Condition(t, func() bool {
 		for _, s := range env.Steps {
 			if s == kaniko.Steps.Publisher && s.Phase() == builder.ApplicationPublishPhase {
@@ -119,6 +122,115 @@ func TestKanikoBuilderTrait(t *testing.T) {
 	})
 }
 
+func TestBuildahBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyBuildah)
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.GetTrait(ID("builder")))
+	assert.Contains(t, env.Steps, buildah.Steps.Publisher)
+	assert.Contains(t, env.Steps, builder.Steps.IncrementalPackager)
+	assert.Equal(t, buildah.BuildDir, env.BuildDir)
+
+	// no registry, no build
+	env = createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyBuildah)
+	env.Platform.Spec.Build.Registry.Address = ""
+	err = NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, env.Steps, buildah.Steps.Publisher)
+}
+
+func TestSpectrumBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategySpectrum)
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.GetTrait(ID("builder")))
+	assert.Contains(t, env.Steps, spectrum.Steps.Publisher)
+	assert.Contains(t, env.Steps, builder.Steps.IncrementalPackager)
+
+	// no registry, no build
+	env = createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategySpectrum)
+	env.Platform.Spec.Build.Registry.Address = ""
+	err = NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, env.Steps, spectrum.Steps.Publisher)
+}
+
+func TestTektonBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyTekton)
+	env.Platform.Spec.Build.Tekton.Pipeline = "build-image"
+
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.GetTrait(ID("builder")))
+	assert.Contains(t, env.Steps, tekton.Steps.Publisher)
+	assert.NotContains(t, env.Steps, builder.Steps.IncrementalPackager)
+
+	// no pipeline, no build
+	env = createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyTekton)
+	err = NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, env.Steps, tekton.Steps.Publisher)
+}
+
+func TestGradleBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Spec.Build.BuildTool = v1alpha1.IntegrationPlatformBuildToolGradle
+
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.GetTrait(ID("builder")))
+	assert.Len(t, env.Steps, 9)
+	assert.Contains(t, env.Steps, builder.Steps.ComputeGradleDependencies)
+	assert.NotContains(t, env.Steps, builder.Steps.ComputeDependencies)
+	assert.Contains(t, kaniko.DefaultSteps, builder.Steps.ComputeDependencies)
+}
+
+type testPublishStrategy struct {
+	publisher builder.Step
+}
+
+func (s testPublishStrategy) Supports(_ *v1alpha1.IntegrationPlatform) bool {
+	return true
+}
+
+func (s testPublishStrategy) Steps(_ v1alpha1.TraitProfile) []builder.Step {
+	return []builder.Step{builder.Steps.GenerateProject, s.publisher}
+}
+
+func (s testPublishStrategy) BuildDir() string {
+	return "/test"
+}
+
+func TestCustomPublishStrategyBuilderTrait(t *testing.T) {
+	publisher := builder.NewStep(builder.ApplicationPublishPhase, func(_ *builder.Context) error {
+		return nil
+	})
+	builder.RegisterPublishStrategy("Test", testPublishStrategy{publisher: publisher})
+
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, "Test")
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, env.GetTrait(ID("builder")))
+	assert.Contains(t, env.Steps, publisher)
+	assert.Equal(t, "/test", env.BuildDir)
+
+	// unknown strategies do not contribute any step
+	env = createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, "Unknown")
+	err = NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, env.Steps, publisher)
+	assert.Empty(t, env.BuildDir)
+}
+
 func createBuilderTestEnv(cluster v1alpha1.IntegrationPlatformCluster, strategy v1alpha1.IntegrationPlatformBuildPublishStrategy) *Environment {
 	c, err := test.DefaultCatalog()
 	if err != nil {
@@ -163,3 +275,127 @@ func createBuilderTestEnv(cluster v1alpha1.IntegrationPlatformCluster, strategy
 func NewBuilderTestCatalog() *Catalog {
 	return NewCatalog(context.TODO(), nil)
 }
+
+func TestImageScanBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Spec.Build.ImageScan = &v1alpha1.IntegrationPlatformImageScanSpec{
+		Severity: "HIGH",
+	}
+
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Len(t, env.Steps, 10)
+	assert.Contains(t, env.Steps, builder.Steps.ScanImage)
+	assert.NotContains(t, kaniko.DefaultSteps, builder.Steps.ScanImage)
+}
+
+func TestImageSizeBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"builder": {
+			Configuration: map[string]string{
+				"deduplicate-jars": "false",
+				"jlink":            "true",
+			},
+		},
+	}
+
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Contains(t, env.Steps, builder.Steps.TrimJRE)
+	assert.NotContains(t, env.Steps, builder.Steps.DeduplicateArtifacts)
+
+	kit := v1alpha1.NewIntegrationKit("ns", "kit")
+	assert.False(t, hasTrimmedJRE(&kit))
+	kit.Spec.Traits = env.Integration.Spec.Traits
+	assert.True(t, hasTrimmedJRE(&kit))
+}
+
+func TestAppCDSBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, env.Steps, builder.Steps.GenerateAppCDSArchive)
+
+	env = createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"builder": {
+			Configuration: map[string]string{
+				"appcds": "true",
+			},
+		},
+	}
+
+	err = NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Contains(t, env.Steps, builder.Steps.GenerateAppCDSArchive)
+
+	// the training launch must not run in the operator
+	env.Steps = nil
+	env.Platform.Spec.Build.BuildStrategy = v1alpha1.IntegrationPlatformBuildStrategyRoutine
+	env.Integration.Status.Phase = v1alpha1.IntegrationPhaseInitial
+	env.IntegrationKit.Status.Phase = v1alpha1.IntegrationKitPhaseBuildSubmitted
+
+	err = NewBuilderTestCatalog().apply(env)
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "pod build strategy")
+
+	kit := v1alpha1.NewIntegrationKit("ns", "kit")
+	assert.False(t, hasAppCDSArchive(&kit))
+	kit.Spec.Traits = env.Integration.Spec.Traits
+	assert.True(t, hasAppCDSArchive(&kit))
+}
+
+func TestProvidedDependenciesBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, env.Steps, builder.Steps.ExcludeProvidedArtifacts)
+
+	env = createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Spec.Build.Provided.Artifacts = []string{"org.apache.camel:*"}
+
+	err = NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Contains(t, env.Steps, builder.Steps.ExcludeProvidedArtifacts)
+}
+
+func TestClasspathConflictsBuilderTrait(t *testing.T) {
+	env := createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+
+	err := NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.NotContains(t, env.Steps, builder.Steps.DetectClasspathConflicts)
+
+	env = createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"builder": {
+			Configuration: map[string]string{
+				"classpath-conflicts": "true",
+			},
+		},
+	}
+
+	err = NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Contains(t, env.Steps, builder.Steps.DetectClasspathConflicts)
+
+	env = createBuilderTestEnv(v1alpha1.IntegrationPlatformClusterKubernetes, v1alpha1.IntegrationPlatformBuildPublishStrategyKaniko)
+	env.Platform.Spec.Build.ClasspathConflicts = v1alpha1.IntegrationPlatformClasspathPolicyFail
+
+	err = NewBuilderTestCatalog().apply(env)
+
+	assert.Nil(t, err)
+	assert.Contains(t, env.Steps, builder.Steps.DetectClasspathConflicts)
+}
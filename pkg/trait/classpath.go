@@ -19,11 +19,13 @@ package trait
 
 import (
 	"fmt"
+	"path"
 
 	"sort"
 	"strings"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
 	"github.com/apache/camel-k/pkg/util/envvar"
 
 	"github.com/scylladb/go-set/strset"
@@ -38,6 +40,8 @@ import (
 
 type classpathTrait struct {
 	BaseTrait `property:",squash"`
+
+	Order string `property:"order"`
 }
 
 func newClasspathTrait() *classpathTrait {
@@ -96,6 +100,11 @@ func (t *classpathTrait) Apply(e *Environment) error {
 		e.Classpath.Add("/deployments/dependencies/*")
 	}
 
+	// the dependencies shipped by the base image, that are not packaged in the kit
+	for _, entry := range e.Platform.Spec.Build.Provided.Classpath {
+		e.Classpath.Add(entry)
+	}
+
 	if e.Resources != nil {
 		//
 		// Add mounted volumes as resources
@@ -108,7 +117,9 @@ func (t *classpathTrait) Apply(e *Environment) error {
 					cp.Add(m.MountPath)
 				}
 
-				t.setJavaClasspath(cp, &deployment.Spec.Template.Spec.Containers[i].Env)
+				t.setJavaClasspath(kit, cp, &deployment.Spec.Template.Spec.Containers[i].Env)
+				t.setJavaHome(kit, &deployment.Spec.Template.Spec.Containers[i].Env)
+				t.setAppCDSOptions(kit, &deployment.Spec.Template.Spec.Containers[i].Env)
 			}
 		})
 		e.Resources.VisitKnativeService(func(service *serving.Service) {
@@ -116,18 +127,69 @@ func (t *classpathTrait) Apply(e *Environment) error {
 				e.Classpath.Add(m.MountPath)
 			}
 
-			t.setJavaClasspath(e.Classpath, &service.Spec.RunLatest.Configuration.RevisionTemplate.Spec.Container.Env)
+			t.setJavaClasspath(kit, e.Classpath, &service.Spec.RunLatest.Configuration.RevisionTemplate.Spec.Container.Env)
+			t.setJavaHome(kit, &service.Spec.RunLatest.Configuration.RevisionTemplate.Spec.Container.Env)
+			t.setAppCDSOptions(kit, &service.Spec.RunLatest.Configuration.RevisionTemplate.Spec.Container.Env)
 		})
 	}
 
 	return nil
 }
 
-func (t *classpathTrait) setJavaClasspath(cp *strset.Set, env *[]corev1.EnvVar) {
+func (t *classpathTrait) setJavaClasspath(kit *v1alpha1.IntegrationKit, cp *strset.Set, env *[]corev1.EnvVar) {
 	items := cp.List()
 
-	// keep classpath sorted
+	// keep classpath sorted, except the entries explicitly ordered that come first
 	sort.Strings(items)
+	if t.Order != "" {
+		patterns := strings.Split(t.Order, ",")
+		sort.SliceStable(items, func(i, j int) bool {
+			return classpathRank(patterns, items[i]) < classpathRank(patterns, items[j])
+		})
+	} else if hasAppCDSArchive(kit) {
+		// the class data sharing archive is mapped only if the classpath starts with the one it's been created for
+		shared := builder.AppCDSClasspath(kit.Status.Artifacts)
+		others := cp.Copy()
+		others.Remove(shared...)
+		items = others.List()
+		sort.Strings(items)
+		items = append(shared, items...)
+	}
 
 	envvar.SetVal(env, "JAVA_CLASSPATH", strings.Join(items, ":"))
 }
+
+// classpathRank returns the index of the first pattern matching the given classpath entry, or the
+// number of patterns if none matches
+func classpathRank(patterns []string, entry string) int {
+	for i, p := range patterns {
+		p = strings.TrimSpace(p)
+		if matched, err := path.Match(p, entry); p == entry || (err == nil && matched) {
+			return i
+		}
+	}
+
+	return len(patterns)
+}
+
+// setAppCDSOptions makes the JVM map the class data sharing archive created by the builder, if any, falling back
+// to the regular class loading if the archive doesn't match the JVM or the classpath
+func (t *classpathTrait) setAppCDSOptions(kit *v1alpha1.IntegrationKit, env *[]corev1.EnvVar) {
+	if !hasAppCDSArchive(kit) {
+		return
+	}
+
+	options := []string{"-Xshare:auto", "-XX:SharedArchiveFile=" + path.Join("/deployments", builder.AppCDSArchiveFile)}
+	if existing := envvar.Get(*env, envVarJavaOptions); existing != nil && existing.Value != "" {
+		options = append([]string{existing.Value}, options...)
+	}
+
+	envvar.SetVal(env, envVarJavaOptions, strings.Join(options, " "))
+}
+
+// setJavaHome makes the integration run with the JRE trimmed by the builder, if any
+func (t *classpathTrait) setJavaHome(kit *v1alpha1.IntegrationKit, env *[]corev1.EnvVar) {
+	if hasTrimmedJRE(kit) {
+		envvar.SetVal(env, "JAVA_HOME", path.Join("/deployments", builder.TrimmedJREDir))
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestClasspathProvidedAndOrder(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.IntegrationKit.Status.Artifacts = []v1alpha1.Artifact{
+		{ID: "org.apache.camel:camel-core:2.23.0", Target: "dependencies/org.apache.camel.camel-core-2.23.0.jar"},
+		{ID: "org.acme:lib:1.0", Target: "dependencies/org.acme.lib-1.0.jar"},
+	}
+	env.Platform.Spec.Build.Provided.Classpath = []string{"/opt/camel/lib/*"}
+	env.Resources.Add(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: TestDeployment,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: TestDeployment},
+					},
+				},
+			},
+		},
+	})
+
+	trait := newClasspathTrait()
+	trait.Order = "dependencies/org.acme.*,/opt/camel/lib/*"
+
+	enabled, err := trait.Configure(env)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	err = trait.Apply(env)
+	assert.Nil(t, err)
+
+	d := env.Resources.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+
+	cp := envvar.Get(d.Spec.Template.Spec.Containers[0].Env, "JAVA_CLASSPATH")
+	assert.NotNil(t, cp)
+	assert.Equal(t, []string{
+		"dependencies/org.acme.lib-1.0.jar",
+		"/opt/camel/lib/*",
+		"./resources",
+		"/etc/camel/resources",
+		"dependencies/org.apache.camel.camel-core-2.23.0.jar",
+	}, strings.Split(cp.Value, ":"))
+}
+
+func TestClasspathAppCDS(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.IntegrationKit.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"builder": {
+			Configuration: map[string]string{
+				"appcds": "true",
+			},
+		},
+	}
+	env.IntegrationKit.Status.Artifacts = []v1alpha1.Artifact{
+		{ID: "org.apache.camel:camel-core:2.23.0", Target: "dependencies/org.apache.camel.camel-core-2.23.0.jar"},
+		{ID: "org.acme:lib:1.0", Target: "dependencies/org.acme.lib-1.0.jar"},
+	}
+	env.Resources.Add(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: TestDeployment,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: TestDeployment,
+							Env: []corev1.EnvVar{
+								{Name: "JAVA_OPTIONS", Value: "-Xmx256m"},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	trait := newClasspathTrait()
+
+	enabled, err := trait.Configure(env)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	err = trait.Apply(env)
+	assert.Nil(t, err)
+
+	d := env.Resources.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+
+	cp := envvar.Get(d.Spec.Template.Spec.Containers[0].Env, "JAVA_CLASSPATH")
+	assert.NotNil(t, cp)
+	assert.Equal(t, []string{
+		"dependencies/org.acme.lib-1.0.jar",
+		"dependencies/org.apache.camel.camel-core-2.23.0.jar",
+		"./resources",
+		"/etc/camel/resources",
+	}, strings.Split(cp.Value, ":"))
+
+	options := envvar.Get(d.Spec.Template.Spec.Containers[0].Env, "JAVA_OPTIONS")
+	assert.NotNil(t, options)
+	assert.Equal(t, "-Xmx256m -Xshare:auto -XX:SharedArchiveFile=/deployments/appcds/app.jsa", options.Value)
+}
@@ -0,0 +1,293 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
+
+	"github.com/pkg/errors"
+
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	cloudCredentialsProviderAWS   = "aws"
+	cloudCredentialsProviderGCP   = "gcp"
+	cloudCredentialsProviderAzure = "azure"
+
+	cloudCredentialsVolume    = "i-cloud-credentials"
+	cloudCredentialsTokenFile = "token"
+	cloudCredentialsGCPKey    = "key.json"
+
+	cloudCredentialsAWSAudience   = "sts.amazonaws.com"
+	cloudCredentialsAzureAudience = "api://AzureADTokenExchange"
+)
+
+// The cloud-credentials trait configures the credentials of the AWS, GCP or Azure components from a secret, or from
+// the cloud identity of a role the pods assume through a projected service account token, setting the environment
+// variables read by the cloud SDKs and the properties of the Camel components used by the routes.
+type cloudCredentialsTrait struct {
+	BaseTrait       `property:",squash"`
+	Provider        string `property:"provider"`
+	Secret          string `property:"secret"`
+	Role            string `property:"role"`
+	Tenant          string `property:"tenant"`
+	Region          string `property:"region"`
+	Path            string `property:"path"`
+	TokenExpiration int64  `property:"token-expiration"`
+}
+
+func newCloudCredentialsTrait() *cloudCredentialsTrait {
+	return &cloudCredentialsTrait{
+		BaseTrait:       newBaseTrait("cloud-credentials"),
+		Path:            "/etc/camel/cloud-credentials",
+		TokenExpiration: 3600,
+	}
+}
+
+func (t *cloudCredentialsTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial, v1alpha1.IntegrationPhaseDeploying) {
+		return false, nil
+	}
+
+	switch t.Provider {
+	case cloudCredentialsProviderAWS, cloudCredentialsProviderGCP, cloudCredentialsProviderAzure:
+	case "":
+		return false, errors.New("the cloud provider of the credentials is required")
+	default:
+		return false, fmt.Errorf("unsupported cloud provider %s", t.Provider)
+	}
+
+	if (t.Secret == "") == (t.Role == "") {
+		return false, errors.New("either the secret holding the credentials or the role to assume is required")
+	}
+	if t.Role != "" {
+		switch t.Provider {
+		case cloudCredentialsProviderGCP:
+			return false, errors.New("GKE Workload Identity is configured with the service-account trait")
+		case cloudCredentialsProviderAzure:
+			if t.Tenant == "" {
+				return false, errors.New("the tenant of the Azure role is required")
+			}
+		}
+		if t.TokenExpiration < serviceAccountMinTokenExpiration {
+			return false, fmt.Errorf("the expiration of projected tokens must be at least %d seconds", serviceAccountMinTokenExpiration)
+		}
+	}
+
+	return true, nil
+}
+
+func (t *cloudCredentialsTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial) {
+		return t.configureComponents(e)
+	}
+
+	if t.Role != "" {
+		strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+		if err != nil {
+			return err
+		}
+		if strategy != ControllerStrategyDeployment {
+			return errors.New("cloud roles are only supported by deployments, use a secret instead")
+		}
+	}
+
+	t.configureEnvVars(e)
+
+	volume := t.newVolume()
+	if volume == nil {
+		return nil
+	}
+
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		mount := corev1.VolumeMount{
+			Name:      cloudCredentialsVolume,
+			MountPath: t.Path,
+			ReadOnly:  true,
+		}
+		environment.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+			spec := &deployment.Spec.Template.Spec
+			spec.Volumes = append(spec.Volumes, *volume)
+			for i := range spec.Containers {
+				if spec.Containers[i].Name == environment.Integration.Name {
+					spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, mount)
+				}
+			}
+		})
+		environment.Resources.VisitKnativeConfigurationSpec(func(cs *serving.ConfigurationSpec) {
+			spec := &cs.RevisionTemplate.Spec
+			spec.Volumes = append(spec.Volumes, *volume)
+			spec.Container.VolumeMounts = append(spec.Container.VolumeMounts, mount)
+		})
+		return nil
+	})
+
+	return nil
+}
+
+// configureComponents sets the credentials of the AWS components used by the routes, that don't fall back
+// to the default credentials of the SDK
+func (t *cloudCredentialsTrait) configureComponents(e *Environment) error {
+	if t.Provider != cloudCredentialsProviderAWS {
+		return nil
+	}
+
+	sources, err := kubernetes.ResolveIntegrationSources(t.ctx, t.client, e.Integration, e.Resources)
+	if err != nil {
+		return err
+	}
+
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+	components := make(map[string]bool)
+	for _, uri := range append(meta.FromURIs, meta.ToURIs...) {
+		scheme := uriutil.Scheme(uri)
+		if strings.HasPrefix(scheme, "aws-") {
+			components[scheme] = true
+		}
+	}
+
+	properties := make([]string, 0)
+	for component := range components {
+		prefix := "camel.component." + component + "."
+		if t.Secret != "" {
+			properties = append(properties,
+				prefix+"accessKey={{env:AWS_ACCESS_KEY_ID}}",
+				prefix+"secretKey={{env:AWS_SECRET_ACCESS_KEY}}",
+			)
+		}
+		if t.Region != "" {
+			properties = append(properties, prefix+"region="+t.Region)
+		}
+	}
+
+	// sort the properties to get always the same configuration if they don't change
+	sort.Strings(properties)
+
+	for _, p := range properties {
+		e.Integration.Status.Configuration = append(e.Integration.Status.Configuration,
+			v1alpha1.ConfigurationSpec{Type: "property", Value: p})
+	}
+
+	return nil
+}
+
+// configureEnvVars sets the environment variables read by the default credentials chain of the cloud SDKs
+func (t *cloudCredentialsTrait) configureEnvVars(e *Environment) {
+	token := path.Join(t.Path, cloudCredentialsTokenFile)
+
+	switch t.Provider {
+	case cloudCredentialsProviderAWS:
+		if t.Secret != "" {
+			t.setFromSecret(e, "AWS_ACCESS_KEY_ID", false)
+			t.setFromSecret(e, "AWS_SECRET_ACCESS_KEY", false)
+			t.setFromSecret(e, "AWS_SESSION_TOKEN", true)
+		} else {
+			envvar.SetVal(&e.EnvVars, "AWS_ROLE_ARN", t.Role)
+			envvar.SetVal(&e.EnvVars, "AWS_WEB_IDENTITY_TOKEN_FILE", token)
+		}
+		if t.Region != "" {
+			envvar.SetVal(&e.EnvVars, "AWS_REGION", t.Region)
+		}
+	case cloudCredentialsProviderGCP:
+		envvar.SetVal(&e.EnvVars, "GOOGLE_APPLICATION_CREDENTIALS", path.Join(t.Path, cloudCredentialsGCPKey))
+	case cloudCredentialsProviderAzure:
+		if t.Secret != "" {
+			for _, name := range []string{"AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_TENANT_ID", "AZURE_STORAGE_ACCOUNT", "AZURE_STORAGE_KEY"} {
+				t.setFromSecret(e, name, true)
+			}
+		} else {
+			envvar.SetVal(&e.EnvVars, "AZURE_CLIENT_ID", t.Role)
+			envvar.SetVal(&e.EnvVars, "AZURE_TENANT_ID", t.Tenant)
+			envvar.SetVal(&e.EnvVars, "AZURE_FEDERATED_TOKEN_FILE", token)
+			envvar.SetVal(&e.EnvVars, "AZURE_AUTHORITY_HOST", "https://login.microsoftonline.com/")
+		}
+	}
+}
+
+// setFromSecret sets the environment variable from the secret key of the same name
+func (t *cloudCredentialsTrait) setFromSecret(e *Environment, name string, optional bool) {
+	envvar.SetVar(&e.EnvVars, corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: t.Secret,
+				},
+				Key:      name,
+				Optional: &optional,
+			},
+		},
+	})
+}
+
+// newVolume returns the volume holding the GCP key or the projected token of the role, if any
+func (t *cloudCredentialsTrait) newVolume() *corev1.Volume {
+	if t.Provider == cloudCredentialsProviderGCP {
+		return &corev1.Volume{
+			Name: cloudCredentialsVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: t.Secret,
+				},
+			},
+		}
+	}
+
+	if t.Role == "" {
+		return nil
+	}
+
+	audience := cloudCredentialsAWSAudience
+	if t.Provider == cloudCredentialsProviderAzure {
+		audience = cloudCredentialsAzureAudience
+	}
+	expiration := t.TokenExpiration
+
+	return &corev1.Volume{
+		Name: cloudCredentialsVolume,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{
+						ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+							Audience:          audience,
+							ExpirationSeconds: &expiration,
+							Path:              cloudCredentialsTokenFile,
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCloudCredentialsAWSComponents(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes,
+		"from('aws-sqs://orders').to('aws-s3://archive').to('log:info')")
+	env.Integration.Status.Phase = v1alpha1.IntegrationPhaseInitial
+
+	trait := newCloudCredentialsTrait()
+	enabled := true
+	trait.Enabled = &enabled
+	trait.Provider = "aws"
+	trait.Secret = "aws-credentials"
+	trait.Region = "eu-west-1"
+
+	ok, err := trait.Configure(env)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, trait.Apply(env))
+
+	values := make([]string, 0)
+	for _, c := range env.Integration.Status.Configuration {
+		values = append(values, c.Value)
+	}
+	assert.Equal(t, []string{
+		"camel.component.aws-s3.accessKey={{env:AWS_ACCESS_KEY_ID}}",
+		"camel.component.aws-s3.region=eu-west-1",
+		"camel.component.aws-s3.secretKey={{env:AWS_SECRET_ACCESS_KEY}}",
+		"camel.component.aws-sqs.accessKey={{env:AWS_ACCESS_KEY_ID}}",
+		"camel.component.aws-sqs.region=eu-west-1",
+		"camel.component.aws-sqs.secretKey={{env:AWS_SECRET_ACCESS_KEY}}",
+	}, values)
+}
+
+func TestCloudCredentialsAWSSecret(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').to('aws-s3://archive')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"cloud-credentials": {
+			Configuration: map[string]string{
+				"enabled":  "true",
+				"provider": "aws",
+				"secret":   "aws-credentials",
+				"region":   "eu-west-1",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	d := res.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+
+	container := d.Spec.Template.Spec.Containers[0]
+	key := envvar.Get(container.Env, "AWS_ACCESS_KEY_ID")
+	assert.NotNil(t, key)
+	assert.Equal(t, "aws-credentials", key.ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "AWS_ACCESS_KEY_ID", key.ValueFrom.SecretKeyRef.Key)
+	assert.False(t, *key.ValueFrom.SecretKeyRef.Optional)
+	assert.True(t, *envvar.Get(container.Env, "AWS_SESSION_TOKEN").ValueFrom.SecretKeyRef.Optional)
+	assert.Equal(t, "eu-west-1", envvar.Get(container.Env, "AWS_REGION").Value)
+
+	for _, v := range d.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, cloudCredentialsVolume, v.Name)
+	}
+}
+
+func TestCloudCredentialsAWSRole(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').to('aws-s3://archive')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"cloud-credentials": {
+			Configuration: map[string]string{
+				"enabled":  "true",
+				"provider": "aws",
+				"role":     "arn:aws:iam::123456789012:role/camel",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	d := res.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+
+	container := d.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "arn:aws:iam::123456789012:role/camel", envvar.Get(container.Env, "AWS_ROLE_ARN").Value)
+	assert.Equal(t, "/etc/camel/cloud-credentials/token", envvar.Get(container.Env, "AWS_WEB_IDENTITY_TOKEN_FILE").Value)
+	assert.Nil(t, envvar.Get(container.Env, "AWS_ACCESS_KEY_ID"))
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{
+		Name:      cloudCredentialsVolume,
+		MountPath: "/etc/camel/cloud-credentials",
+		ReadOnly:  true,
+	})
+
+	var volume *corev1.Volume
+	for i := range d.Spec.Template.Spec.Volumes {
+		if d.Spec.Template.Spec.Volumes[i].Name == cloudCredentialsVolume {
+			volume = &d.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	assert.NotNil(t, volume)
+	assert.Equal(t, "sts.amazonaws.com", volume.Projected.Sources[0].ServiceAccountToken.Audience)
+	assert.Equal(t, "token", volume.Projected.Sources[0].ServiceAccountToken.Path)
+}
+
+func TestCloudCredentialsGCPSecret(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"cloud-credentials": {
+			Configuration: map[string]string{
+				"enabled":  "true",
+				"provider": "gcp",
+				"secret":   "gcp-key",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	d := res.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+
+	container := d.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "/etc/camel/cloud-credentials/key.json", envvar.Get(container.Env, "GOOGLE_APPLICATION_CREDENTIALS").Value)
+	assert.Contains(t, d.Spec.Template.Spec.Volumes, corev1.Volume{
+		Name: cloudCredentialsVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{SecretName: "gcp-key"},
+		},
+	})
+}
+
+func TestCloudCredentialsValidation(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	enabled := true
+
+	trait := newCloudCredentialsTrait()
+	trait.Enabled = &enabled
+	trait.Provider = "ibm"
+	trait.Secret = "credentials"
+	_, err := trait.Configure(env)
+	assert.NotNil(t, err)
+
+	trait = newCloudCredentialsTrait()
+	trait.Enabled = &enabled
+	trait.Provider = "aws"
+	_, err = trait.Configure(env)
+	assert.NotNil(t, err)
+
+	trait = newCloudCredentialsTrait()
+	trait.Enabled = &enabled
+	trait.Provider = "gcp"
+	trait.Role = "camel@project.iam.gserviceaccount.com"
+	_, err = trait.Configure(env)
+	assert.NotNil(t, err)
+
+	trait = newCloudCredentialsTrait()
+	trait.Enabled = &enabled
+	trait.Provider = "azure"
+	trait.Role = "00000000-0000-0000-0000-000000000000"
+	_, err = trait.Configure(env)
+	assert.NotNil(t, err)
+
+	trait.Tenant = "11111111-1111-1111-1111-111111111111"
+	ok, err := trait.Configure(env)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+}
@@ -19,14 +19,26 @@ package trait
 
 import (
 	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/kamelet"
 	"github.com/apache/camel-k/pkg/metadata"
 	"github.com/apache/camel-k/pkg/util"
 )
 
+// kameletSourcePrefix prefixes the names of the generated sources holding the templates of the kamelets
+const kameletSourcePrefix = "kamelet-"
+
 type dependenciesTrait struct {
 	BaseTrait `property:",squash"`
+	// ReportUnresolvedPlaceholders sets a condition on the integration when the property placeholders of its
+	// endpoint URIs cannot be resolved from the configured properties
+	ReportUnresolvedPlaceholders bool `property:"report-unresolved-placeholders"`
 }
 
 func newDependenciesTrait() *dependenciesTrait {
@@ -45,13 +57,38 @@ func (t *dependenciesTrait) Configure(e *Environment) (bool, error) {
 
 func (t *dependenciesTrait) Apply(e *Environment) error {
 	dependencies := make([]string, 0)
+	uris := make([]string, 0)
 	if e.Integration.Spec.Dependencies != nil {
 		for _, dep := range e.Integration.Spec.Dependencies {
 			util.StringSliceUniqueAdd(&dependencies, dep)
 		}
 	}
-	for _, s := range e.Integration.Spec.Sources {
-		meta := metadata.Extract(e.CamelCatalog, s)
+	for _, name := range e.Integration.Spec.Libraries {
+		library, err := t.getLibrary(e, name)
+		if err != nil {
+			return err
+		}
+		for _, dep := range library.Spec.Dependencies {
+			util.StringSliceUniqueAdd(&dependencies, dep)
+		}
+	}
+	batch := metadata.ExtractBatch(e.CamelCatalog, e.Integration.Spec.Sources)
+	for _, err := range batch.Errors {
+		t.L.ForIntegration(e.Integration).Error(err.Err, "unable to extract metadata", "source", err.Source)
+	}
+	// the placeholders are expanded so that the components of the configured endpoints are detected
+	properties := e.CollectConfigurationPairs("property")
+	unresolved := make([]string, 0)
+	kamelets, err := t.loadKamelets(e, batch.IntegrationMetadata)
+	if err != nil {
+		return err
+	}
+	for i, s := range e.Integration.Spec.Sources {
+		meta, keys := metadata.ResolvePlaceholders(e.CamelCatalog, batch.Sources[i], properties)
+		meta = metadata.ResolveKamelets(e.CamelCatalog, meta, kamelets)
+		for _, key := range keys {
+			util.StringSliceUniqueAdd(&unresolved, key)
+		}
 
 		switch s.InferLanguage() {
 		case v1alpha1.LanguageGroovy:
@@ -69,10 +106,101 @@ func (t *dependenciesTrait) Apply(e *Environment) error {
 		for _, d := range meta.Dependencies {
 			util.StringSliceUniqueAdd(&dependencies, d)
 		}
+
+		uris = append(uris, meta.FromURIs...)
+		uris = append(uris, meta.ToURIs...)
+	}
+
+	// the components configured through properties are required even if none of their endpoints is found in the sources
+	for _, d := range metadata.PropertyDependencies(e.CamelCatalog, properties) {
+		util.StringSliceUniqueAdd(&dependencies, d)
 	}
 
 	// sort the dependencies to get always the same list if they don't change
 	sort.Strings(dependencies)
 	e.Integration.Status.Dependencies = dependencies
+
+	// warn about the components deprecated by the catalog the integration is built with
+	if warnings := metadata.DeprecationWarnings(e.CamelCatalog, uris); len(warnings) > 0 {
+		e.Integration.Status.Conditions = v1alpha1.SetCondition(e.Integration.Status.Conditions, v1alpha1.Condition{
+			Type:    v1alpha1.ConditionDeprecatedComponents,
+			Status:  corev1.ConditionTrue,
+			Reason:  "DeprecatedComponentsUsed",
+			Message: strings.Join(warnings, "; "),
+		})
+	} else {
+		e.Integration.Status.Conditions = v1alpha1.RemoveCondition(e.Integration.Status.Conditions, v1alpha1.ConditionDeprecatedComponents)
+	}
+
+	// warn about the endpoint parameters the catalog does not know, typos being caught before the deployment
+	if warnings := metadata.ParameterWarnings(e.CamelCatalog, uris); len(warnings) > 0 {
+		e.Integration.Status.Conditions = v1alpha1.SetCondition(e.Integration.Status.Conditions, v1alpha1.Condition{
+			Type:    v1alpha1.ConditionInvalidEndpointParameters,
+			Status:  corev1.ConditionTrue,
+			Reason:  "InvalidEndpointParametersUsed",
+			Message: strings.Join(warnings, "; "),
+		})
+	} else {
+		e.Integration.Status.Conditions = v1alpha1.RemoveCondition(e.Integration.Status.Conditions, v1alpha1.ConditionInvalidEndpointParameters)
+	}
+
+	if t.ReportUnresolvedPlaceholders && len(unresolved) > 0 {
+		e.Integration.Status.Conditions = v1alpha1.SetCondition(e.Integration.Status.Conditions, v1alpha1.Condition{
+			Type:    v1alpha1.ConditionUnresolvedPlaceholders,
+			Status:  corev1.ConditionTrue,
+			Reason:  "UnresolvedPlaceholdersFound",
+			Message: "unresolved property placeholders in endpoint URIs: " + strings.Join(unresolved, ", "),
+		})
+	} else {
+		e.Integration.Status.Conditions = v1alpha1.RemoveCondition(e.Integration.Status.Conditions, v1alpha1.ConditionUnresolvedPlaceholders)
+	}
+
 	return nil
 }
+
+// loadKamelets retrieves the kamelets the integration references with kamelet: URIs, and adds their templates to the
+// generated sources so that they are loaded along with the integration sources
+func (t *dependenciesTrait) loadKamelets(e *Environment, meta metadata.IntegrationMetadata) (map[string]v1alpha1.Kamelet, error) {
+	kamelets, err := kamelet.Load(t.ctx, t.client, e.Integration.Namespace, metadata.Kamelets(meta))
+	if err != nil {
+		return nil, err
+	}
+
+	generatedSources := make([]v1alpha1.SourceSpec, 0, len(e.Integration.Status.GeneratedSources)+len(kamelets))
+	for _, s := range e.Integration.Status.GeneratedSources {
+		// filter out the templates of the previously referenced kamelets
+		if !strings.HasPrefix(s.Name, kameletSourcePrefix) {
+			generatedSources = append(generatedSources, s)
+		}
+	}
+	for _, name := range metadata.Kamelets(meta) {
+		template := kamelets[name].Spec.Template
+		language := template.InferLanguage()
+		template.Name = kameletSourcePrefix + name + "." + string(language)
+		template.Language = language
+		generatedSources = append(generatedSources, template)
+	}
+	if len(generatedSources) == 0 {
+		generatedSources = nil
+	}
+	e.Integration.Status.GeneratedSources = generatedSources
+
+	return kamelets, nil
+}
+
+// getLibrary retrieves the library kit with the given name from the integration namespace
+func (t *dependenciesTrait) getLibrary(e *Environment, name string) (*v1alpha1.IntegrationKit, error) {
+	library := v1alpha1.NewIntegrationKit(e.Integration.Namespace, name)
+	key := k8sclient.ObjectKey{
+		Namespace: e.Integration.Namespace,
+		Name:      name,
+	}
+	if err := t.client.Get(t.ctx, key, &library); err != nil {
+		return nil, errors.Wrapf(err, "unable to find library kit %s", name)
+	}
+	if library.Labels["camel.apache.org/kit.type"] != v1alpha1.IntegrationKitTypeLibrary {
+		return nil, errors.Errorf("integration kit %s is not a library kit", name)
+	}
+
+	return &library, nil
+}
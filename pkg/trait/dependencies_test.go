@@ -0,0 +1,315 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/camel"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestDependenciesDeprecatedComponents(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	e := &Environment{
+		CamelCatalog: catalog,
+		Integration: &v1alpha1.Integration{
+			Spec: v1alpha1.IntegrationSpec{
+				Sources: []v1alpha1.SourceSpec{
+					{
+						DataSpec: v1alpha1.DataSpec{
+							Name:    "routes.groovy",
+							Content: `from('netty:tcp://localhost:5150').to('log:info')`,
+						},
+						Language: v1alpha1.LanguageGroovy,
+					},
+				},
+			},
+		},
+	}
+
+	trait := newDependenciesTrait()
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:netty")
+
+	condition := v1alpha1.GetCondition(e.Integration.Status.Conditions, v1alpha1.ConditionDeprecatedComponents)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "component camel-netty (netty) is deprecated, use camel-netty4 instead", condition.Message)
+
+	// the condition is removed as soon as the deprecated components are no longer used
+	e.Integration.Spec.Sources[0].Content = `from('netty4:tcp://localhost:5150').to('log:info')`
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Nil(t, v1alpha1.GetCondition(e.Integration.Status.Conditions, v1alpha1.ConditionDeprecatedComponents))
+}
+
+func TestDependenciesInvalidEndpointParameters(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	// the catalog is given the parameters metadata of the timer component
+	spec := catalog.CamelCatalogSpec.DeepCopy()
+	core := spec.Artifacts["camel-core"]
+	for i := range core.Schemes {
+		if core.Schemes[i].ID == "timer" {
+			core.Schemes[i].Parameters = []v1alpha1.CamelSchemeParameter{{Name: "period"}, {Name: "repeatCount"}}
+		}
+	}
+	spec.Artifacts["camel-core"] = core
+
+	e := &Environment{
+		CamelCatalog: camel.NewRuntimeCatalog(*spec),
+		Integration: &v1alpha1.Integration{
+			Spec: v1alpha1.IntegrationSpec{
+				Sources: []v1alpha1.SourceSpec{
+					{
+						DataSpec: v1alpha1.DataSpec{
+							Name:    "routes.groovy",
+							Content: `from('timer:tick?perios=3s').to('log:info')`,
+						},
+						Language: v1alpha1.LanguageGroovy,
+					},
+				},
+			},
+		},
+	}
+
+	trait := newDependenciesTrait()
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+
+	condition := v1alpha1.GetCondition(e.Integration.Status.Conditions, v1alpha1.ConditionInvalidEndpointParameters)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "unknown parameter perios in endpoint timer:tick, did you mean period?", condition.Message)
+
+	// the condition is removed as soon as the parameters are fixed
+	e.Integration.Spec.Sources[0].Content = `from('timer:tick?period=3s').to('log:info')`
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Nil(t, v1alpha1.GetCondition(e.Integration.Status.Conditions, v1alpha1.ConditionInvalidEndpointParameters))
+}
+
+func TestDependenciesPropertyPlaceholders(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	e := &Environment{
+		CamelCatalog: catalog,
+		Integration: &v1alpha1.Integration{
+			Spec: v1alpha1.IntegrationSpec{
+				Sources: []v1alpha1.SourceSpec{
+					{
+						DataSpec: v1alpha1.DataSpec{
+							Name:    "routes.groovy",
+							Content: `from('{{input.endpoint}}').to('{{output.endpoint}}')`,
+						},
+						Language: v1alpha1.LanguageGroovy,
+					},
+				},
+				Configuration: []v1alpha1.ConfigurationSpec{
+					{Type: "property", Value: "input.endpoint=telegram:bots"},
+				},
+			},
+		},
+	}
+
+	trait := newDependenciesTrait()
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:telegram")
+	// unresolved placeholders are not reported by default
+	assert.Nil(t, v1alpha1.GetCondition(e.Integration.Status.Conditions, v1alpha1.ConditionUnresolvedPlaceholders))
+
+	trait.ReportUnresolvedPlaceholders = true
+	assert.Nil(t, trait.Apply(e))
+
+	condition := v1alpha1.GetCondition(e.Integration.Status.Conditions, v1alpha1.ConditionUnresolvedPlaceholders)
+	assert.NotNil(t, condition)
+	assert.Equal(t, corev1.ConditionTrue, condition.Status)
+	assert.Equal(t, "unresolved property placeholders in endpoint URIs: output.endpoint", condition.Message)
+
+	// the condition is removed as soon as the placeholders are configured
+	e.Integration.Spec.Configuration = append(e.Integration.Spec.Configuration, v1alpha1.ConfigurationSpec{
+		Type:  "property",
+		Value: "output.endpoint=amqp:queue:out",
+	})
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:amqp")
+	assert.Nil(t, v1alpha1.GetCondition(e.Integration.Status.Conditions, v1alpha1.ConditionUnresolvedPlaceholders))
+}
+
+func TestDependenciesComponentProperties(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	e := &Environment{
+		CamelCatalog: catalog,
+		Integration: &v1alpha1.Integration{
+			Spec: v1alpha1.IntegrationSpec{
+				Sources: []v1alpha1.SourceSpec{
+					{
+						DataSpec: v1alpha1.DataSpec{
+							Name:    "routes.groovy",
+							Content: `from('{{input.endpoint}}').to('log:info')`,
+						},
+						Language: v1alpha1.LanguageGroovy,
+					},
+				},
+				Configuration: []v1alpha1.ConfigurationSpec{
+					{Type: "property", Value: "camel.component.kafka.brokers=my-cluster-kafka-bootstrap:9092"},
+				},
+			},
+		},
+	}
+
+	trait := newDependenciesTrait()
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:kafka")
+}
+
+func TestDependenciesLibraries(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	library := v1alpha1.NewIntegrationKit("ns", "shared-routes")
+	library.Labels = map[string]string{
+		"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypeLibrary,
+	}
+	library.Spec.Dependencies = []string{"mvn:org.acme:shared-routes:1.0", "runtime:jvm"}
+
+	user := v1alpha1.NewIntegrationKit("ns", "user-kit")
+	user.Labels = map[string]string{
+		"camel.apache.org/kit.type": v1alpha1.IntegrationKitTypeUser,
+	}
+
+	c, err := test.NewFakeClient(&library, &user)
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	integration.Spec.Libraries = []string{"shared-routes"}
+	integration.Spec.Sources = []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "routes.java",
+				Content: `from("timer:tick").to("log:info")`,
+			},
+			Language: v1alpha1.LanguageJavaSource,
+		},
+	}
+
+	e := &Environment{
+		CamelCatalog: catalog,
+		Integration:  &integration,
+	}
+
+	trait := newDependenciesTrait()
+	trait.InjectClient(c)
+	trait.InjectContext(context.TODO())
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Contains(t, e.Integration.Status.Dependencies, "mvn:org.acme:shared-routes:1.0")
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:core")
+
+	integration.Spec.Libraries = []string{"user-kit"}
+	assert.NotNil(t, trait.Apply(e))
+
+	integration.Spec.Libraries = []string{"missing"}
+	assert.NotNil(t, trait.Apply(e))
+}
+
+func TestDependenciesKamelets(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	telegram := v1alpha1.NewKamelet("ns", "telegram")
+	telegram.Spec.Template = v1alpha1.NewSourceSpec("telegram.groovy", `from('telegram:bots?authorizationToken={{token}}').to('kamelet:sink')`, "")
+	telegram.Spec.Dependencies = []string{"mvn:org.acme:telegram-converters:1.0"}
+	telegram.Status.Phase = v1alpha1.KameletPhaseReady
+
+	pending := v1alpha1.NewKamelet("ns", "pending")
+	pending.Spec.Template = v1alpha1.NewSourceSpec("pending.groovy", `from('timer:tick').to('kamelet:sink')`, "")
+
+	c, err := test.NewFakeClient(&telegram, &pending)
+	assert.Nil(t, err)
+
+	integration := v1alpha1.NewIntegration("ns", "my-integration")
+	integration.Spec.Sources = []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "routes.java",
+				Content: `from("kamelet:telegram/source?token=abc").to("log:info")`,
+			},
+			Language: v1alpha1.LanguageJavaSource,
+		},
+	}
+
+	e := &Environment{
+		CamelCatalog: catalog,
+		Integration:  &integration,
+	}
+
+	trait := newDependenciesTrait()
+	trait.InjectClient(c)
+	trait.InjectContext(context.TODO())
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:telegram")
+	assert.Contains(t, e.Integration.Status.Dependencies, "mvn:org.acme:telegram-converters:1.0")
+	assert.Len(t, e.Integration.Status.GeneratedSources, 1)
+	assert.Equal(t, "kamelet-telegram.groovy", e.Integration.Status.GeneratedSources[0].Name)
+	assert.Equal(t, v1alpha1.LanguageGroovy, e.Integration.Status.GeneratedSources[0].Language)
+
+	// the template is not added twice when the dependencies are computed again
+	assert.Nil(t, trait.Apply(e))
+	assert.Len(t, e.Integration.Status.GeneratedSources, 1)
+
+	integration.Spec.Sources[0].Content = `from("kamelet:pending").to("log:info")`
+	assert.NotNil(t, trait.Apply(e))
+
+	integration.Spec.Sources[0].Content = `from("kamelet:missing").to("log:info")`
+	assert.NotNil(t, trait.Apply(e))
+
+	integration.Spec.Sources[0].Content = `from("timer:tick").to("log:info")`
+	assert.Nil(t, trait.Apply(e))
+	assert.Empty(t, e.Integration.Status.GeneratedSources)
+}
@@ -18,18 +18,51 @@ limitations under the License.
 package trait
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/argo"
 	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// OpenShiftImageTriggersAnnotation declares the image stream tags whose changes roll out a deployment
+const OpenShiftImageTriggersAnnotation = "image.openshift.io/triggers"
+
+const (
+	deploymentModeDeployment = "deployment"
+	deploymentModeRollout    = "rollout"
+
+	rolloutStrategyCanary    = "canary"
+	rolloutStrategyBlueGreen = "blue-green"
 )
 
 type deploymentTrait struct {
-	BaseTrait `property:",squash"`
-	deployer  deployerTrait
+	BaseTrait        `property:",squash"`
+	ImageTrigger     *bool  `property:"image-trigger"`
+	Mode             string `property:"mode"`
+	RolloutStrategy  string `property:"rollout-strategy"`
+	CanarySteps      string `property:"canary-steps"`
+	AnalysisTemplate string `property:"analysis-template"`
+	PreviewService   string `property:"preview-service"`
+	deployer         deployerTrait
+	rollout          bool
+	canarySteps      []interface{}
+}
+
+type imageTrigger struct {
+	From      corev1.ObjectReference `json:"from"`
+	FieldPath string                 `json:"fieldPath"`
 }
 
 func newDeploymentTrait() *deploymentTrait {
@@ -55,6 +88,12 @@ func (t *deploymentTrait) Configure(e *Environment) (bool, error) {
 		}
 
 		enabled = strategy == ControllerStrategyDeployment
+
+		if enabled {
+			if err := t.configureRollout(); err != nil {
+				return false, err
+			}
+		}
 	} else if e.IntegrationKitInPhase(v1alpha1.IntegrationKitPhaseReady) &&
 		e.IntegrationInPhase(v1alpha1.IntegrationPhaseBuildingKit, v1alpha1.IntegrationPhaseResolvingKit) {
 		enabled = true
@@ -84,13 +123,70 @@ func (t *deploymentTrait) Apply(e *Environment) error {
 	}
 
 	if e.InPhase(v1alpha1.IntegrationKitPhaseReady, v1alpha1.IntegrationPhaseDeploying) {
+		deployment := t.getDeploymentFor(e)
+
+		// the pods are labeled with the routes they run
+		labels, err := routeLabels(t.ctx, t.client, e, false)
+		if err != nil {
+			return err
+		}
+		for k, v := range labels {
+			deployment.Spec.Template.Labels[k] = v
+		}
+
+		if t.rollout {
+			// The deployment is customized by the other traits, including their post processors,
+			// then replaced by the rollout
+			e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+				environment.PostProcessors = append(environment.PostProcessors, t.replaceWithRollout)
+				return nil
+			})
+		} else if err := t.addImageTrigger(e, deployment); err != nil {
+			return err
+		}
+
 		e.Resources.AddAll(e.ComputeConfigMaps())
-		e.Resources.Add(t.getDeploymentFor(e))
+		e.Resources.Add(deployment)
 	}
 
 	return nil
 }
 
+// configureRollout validates the rollout settings and determines if an Argo Rollout has to be created
+// instead of the deployment, which requires Argo Rollouts to be installed on the cluster
+func (t *deploymentTrait) configureRollout() error {
+	switch t.Mode {
+	case "", deploymentModeDeployment:
+		return nil
+	case deploymentModeRollout:
+	default:
+		return fmt.Errorf("unsupported deployment mode %s, expected one of %s, %s", t.Mode, deploymentModeDeployment, deploymentModeRollout)
+	}
+
+	switch t.RolloutStrategy {
+	case "", rolloutStrategyCanary, rolloutStrategyBlueGreen:
+	default:
+		return fmt.Errorf("unsupported rollout strategy %s, expected one of %s, %s", t.RolloutStrategy, rolloutStrategyCanary, rolloutStrategyBlueGreen)
+	}
+
+	steps, err := parseCanarySteps(t.CanarySteps)
+	if err != nil {
+		return err
+	}
+	t.canarySteps = steps
+
+	installed, err := argo.IsRolloutInstalled(t.client)
+	if err != nil {
+		return errors.Wrap(err, "unable to determine if Argo Rollouts is installed")
+	}
+	if !installed {
+		t.L.Info("Argo Rollouts is not installed, falling back to a deployment")
+	}
+	t.rollout = installed
+
+	return nil
+}
+
 // **********************************
 //
 // Deployment
@@ -182,3 +278,203 @@ func (t *deploymentTrait) getDeploymentFor(e *Environment) *appsv1.Deployment {
 
 	return &deployment
 }
+
+// addImageTrigger makes OpenShift roll out the deployment when the image stream tag the kit image
+// has been pushed to is updated
+func (t *deploymentTrait) addImageTrigger(e *Environment, deployment *appsv1.Deployment) error {
+	if t.ImageTrigger != nil && !*t.ImageTrigger {
+		return nil
+	}
+	if e.IntegrationKit == nil || e.IntegrationKit.Status.ImageStreamTag == "" {
+		return nil
+	}
+
+	triggers, err := json.Marshal([]imageTrigger{
+		{
+			From: corev1.ObjectReference{
+				Kind:      "ImageStreamTag",
+				Name:      e.IntegrationKit.Status.ImageStreamTag,
+				Namespace: e.Platform.Spec.Build.BuildNamespace(e.IntegrationKit.Namespace),
+			},
+			FieldPath: fmt.Sprintf(`spec.template.spec.containers[?(@.name=="%s")].image`, deployment.Spec.Template.Spec.Containers[0].Name),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	// the annotations map is shared with the pod template
+	annotations := make(map[string]string, len(deployment.Annotations)+1)
+	for k, v := range deployment.Annotations {
+		annotations[k] = v
+	}
+	annotations[OpenShiftImageTriggersAnnotation] = string(triggers)
+	deployment.Annotations = annotations
+
+	return nil
+}
+
+// **********************************
+//
+// Rollout
+//
+// **********************************
+
+// replaceWithRollout substitutes the integration deployment with an Argo Rollout sharing the same pod template
+func (t *deploymentTrait) replaceWithRollout(e *Environment) error {
+	deployment := e.Resources.RemoveDeployment(func(d *appsv1.Deployment) bool {
+		return d.Name == e.Integration.Name
+	})
+	if deployment == nil {
+		return nil
+	}
+
+	rollout, err := t.getRolloutFor(e, deployment)
+	if err != nil {
+		return err
+	}
+
+	e.Resources.Add(rollout)
+
+	return nil
+}
+
+func (t *deploymentTrait) getRolloutFor(e *Environment, deployment *appsv1.Deployment) (*unstructured.Unstructured, error) {
+	selector, err := runtime.DefaultUnstructuredConverter.ToUnstructured(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	template, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&deployment.Spec.Template)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy, err := t.getRolloutStrategyFor(e, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := map[string]interface{}{
+		"selector": selector,
+		"template": template,
+		"strategy": strategy,
+	}
+	if deployment.Spec.Replicas != nil {
+		spec["replicas"] = int64(*deployment.Spec.Replicas)
+	}
+	if deployment.Spec.MinReadySeconds > 0 {
+		spec["minReadySeconds"] = int64(deployment.Spec.MinReadySeconds)
+	}
+	if deployment.Spec.RevisionHistoryLimit != nil {
+		spec["revisionHistoryLimit"] = int64(*deployment.Spec.RevisionHistoryLimit)
+	}
+
+	rollout := unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": spec,
+		},
+	}
+	rollout.SetAPIVersion(argo.RolloutAPIVersion)
+	rollout.SetKind(argo.RolloutKind)
+	rollout.SetNamespace(deployment.Namespace)
+	rollout.SetName(deployment.Name)
+	rollout.SetLabels(deployment.Labels)
+	rollout.SetAnnotations(deployment.Annotations)
+	rollout.SetOwnerReferences(deployment.OwnerReferences)
+
+	return &rollout, nil
+}
+
+func (t *deploymentTrait) getRolloutStrategyFor(e *Environment, deployment *appsv1.Deployment) (map[string]interface{}, error) {
+	if t.RolloutStrategy == rolloutStrategyBlueGreen {
+		service := e.Resources.GetService(func(s *corev1.Service) bool {
+			return s.Name == e.Integration.Name
+		})
+		if service == nil {
+			return nil, errors.New("blue-green rollouts require the integration to expose a service")
+		}
+
+		blueGreen := map[string]interface{}{
+			"activeService": service.Name,
+		}
+		if t.PreviewService != "" {
+			blueGreen["previewService"] = t.PreviewService
+		}
+
+		return map[string]interface{}{
+			"blueGreen": blueGreen,
+		}, nil
+	}
+
+	canary := make(map[string]interface{})
+	if len(t.canarySteps) > 0 {
+		canary["steps"] = t.canarySteps
+	}
+	if t.AnalysisTemplate != "" {
+		canary["analysis"] = map[string]interface{}{
+			"templates": []interface{}{
+				map[string]interface{}{
+					"templateName": t.AnalysisTemplate,
+				},
+			},
+		}
+	}
+	if update := deployment.Spec.Strategy.RollingUpdate; update != nil {
+		if update.MaxSurge != nil {
+			canary["maxSurge"] = intOrStringValue(*update.MaxSurge)
+		}
+		if update.MaxUnavailable != nil {
+			canary["maxUnavailable"] = intOrStringValue(*update.MaxUnavailable)
+		}
+	}
+
+	return map[string]interface{}{
+		"canary": canary,
+	}, nil
+}
+
+// parseCanarySteps parses a comma separated list of canary steps, that are either traffic weights
+// or pauses, e.g. 20,pause:1m,50,pause (pauses without duration wait for a manual promotion)
+func parseCanarySteps(value string) ([]interface{}, error) {
+	steps := make([]interface{}, 0)
+	if value == "" {
+		return steps, nil
+	}
+
+	for _, step := range strings.Split(value, ",") {
+		step = strings.TrimSpace(step)
+
+		if step == "pause" {
+			steps = append(steps, map[string]interface{}{
+				"pause": map[string]interface{}{},
+			})
+		} else if strings.HasPrefix(step, "pause:") {
+			d, err := time.ParseDuration(strings.TrimPrefix(step, "pause:"))
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid canary step %s", step)
+			}
+			steps = append(steps, map[string]interface{}{
+				"pause": map[string]interface{}{
+					"duration": int64(d.Seconds()),
+				},
+			})
+		} else {
+			weight, err := strconv.Atoi(step)
+			if err != nil || weight < 0 || weight > 100 {
+				return nil, fmt.Errorf("invalid canary step %s, expected a weight between 0 and 100 or a pause", step)
+			}
+			steps = append(steps, map[string]interface{}{
+				"setWeight": int64(weight),
+			})
+		}
+	}
+
+	return steps, nil
+}
+
+func intOrStringValue(value intstr.IntOrString) interface{} {
+	if value.Type == intstr.Int {
+		return int64(value.IntVal)
+	}
+	return value.StrVal
+}
@@ -0,0 +1,242 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/argo"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeploymentImageTrigger(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterOpenShift, "camel:core")
+	env.IntegrationKit.Namespace = "ns"
+	env.IntegrationKit.Status.ImageStreamTag = "camel-k-kit-1:latest"
+
+	res := processTestEnv(t, env)
+
+	deployment := res.GetDeployment(func(deployment *appsv1.Deployment) bool {
+		return deployment.Name == TestDeployment
+	})
+	assert.NotNil(t, deployment)
+	assert.JSONEq(t,
+		`[{"from":{"kind":"ImageStreamTag","name":"camel-k-kit-1:latest","namespace":"ns"},"fieldPath":"spec.template.spec.containers[?(@.name==\"test\")].image"}]`,
+		deployment.Annotations[OpenShiftImageTriggersAnnotation])
+	assert.NotContains(t, deployment.Spec.Template.Annotations, OpenShiftImageTriggersAnnotation)
+}
+
+func TestDeploymentImageTriggerDisabled(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterOpenShift, "camel:core")
+	env.IntegrationKit.Status.ImageStreamTag = "camel-k-kit-1:latest"
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"deployment": {
+			Configuration: map[string]string{
+				"image-trigger": "false",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	deployment := res.GetDeployment(func(deployment *appsv1.Deployment) bool {
+		return deployment.Name == TestDeployment
+	})
+	assert.NotNil(t, deployment)
+	assert.NotContains(t, deployment.Annotations, OpenShiftImageTriggersAnnotation)
+}
+
+func TestDeploymentRouteLabels(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, `
+from('timer:tick').routeId('ticks').to('log:info')
+from('undertow:http://0.0.0.0:8080/orders').routeId('orders').to('jms:queue:orders')
+rest('/api').get('/hello').to('direct:hello')
+from('direct:hello').routeId('Hello World').to('log:hello')`)
+
+	res := processTestEnv(t, env)
+
+	deployment := res.GetDeployment(func(deployment *appsv1.Deployment) bool {
+		return deployment.Name == TestDeployment
+	})
+	assert.NotNil(t, deployment)
+	assert.Equal(t, "true", deployment.Spec.Template.Labels["route.camel.apache.org/ticks"])
+	assert.Equal(t, "true", deployment.Spec.Template.Labels["route.camel.apache.org/orders"])
+	assert.Len(t, deployment.Spec.Template.Labels, 3)
+	assert.NotContains(t, deployment.Spec.Selector.MatchLabels, "route.camel.apache.org/ticks")
+
+	service := res.GetService(func(service *corev1.Service) bool {
+		return service.Name == TestDeployment
+	})
+	assert.NotNil(t, service)
+	assert.Equal(t, "true", service.Labels["route.camel.apache.org/orders"])
+	assert.NotContains(t, service.Labels, "route.camel.apache.org/ticks")
+}
+
+func TestDeploymentRolloutCanary(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	replicas := int32(3)
+	env.Integration.Spec.Replicas = &replicas
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"deployment": {
+			Configuration: map[string]string{
+				"mode":              "rollout",
+				"canary-steps":      "20,pause:1m,50,pause",
+				"analysis-template": "success-rate",
+			},
+		},
+	}
+
+	err := newRolloutTestCatalog(t, true).apply(env)
+	assert.Nil(t, err)
+
+	assert.Nil(t, env.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool {
+		return deployment.Name == TestDeployment
+	}))
+
+	rollout := findRollout(env)
+	assert.NotNil(t, rollout)
+	assert.Equal(t, "ns", rollout.GetNamespace())
+	assert.Equal(t, TestDeployment, rollout.GetLabels()["camel.apache.org/integration"])
+
+	r, _, _ := unstructured.NestedInt64(rollout.Object, "spec", "replicas")
+	assert.Equal(t, int64(3), r)
+	image, _, _ := unstructured.NestedSlice(rollout.Object, "spec", "template", "spec", "containers")
+	assert.Len(t, image, 1)
+
+	steps, _, _ := unstructured.NestedSlice(rollout.Object, "spec", "strategy", "canary", "steps")
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"setWeight": int64(20)},
+		map[string]interface{}{"pause": map[string]interface{}{"duration": int64(60)}},
+		map[string]interface{}{"setWeight": int64(50)},
+		map[string]interface{}{"pause": map[string]interface{}{}},
+	}, steps)
+	templates, _, _ := unstructured.NestedSlice(rollout.Object, "spec", "strategy", "canary", "analysis", "templates")
+	assert.Equal(t, []interface{}{map[string]interface{}{"templateName": "success-rate"}}, templates)
+}
+
+func TestDeploymentRolloutBlueGreen(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('undertow:http').to('log:info')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"deployment": {
+			Configuration: map[string]string{
+				"mode":             "rollout",
+				"rollout-strategy": "blue-green",
+				"preview-service":  "test-preview",
+			},
+		},
+	}
+
+	err := newRolloutTestCatalog(t, true).apply(env)
+	assert.Nil(t, err)
+
+	rollout := findRollout(env)
+	assert.NotNil(t, rollout)
+
+	active, _, _ := unstructured.NestedString(rollout.Object, "spec", "strategy", "blueGreen", "activeService")
+	assert.Equal(t, TestDeployment, active)
+	preview, _, _ := unstructured.NestedString(rollout.Object, "spec", "strategy", "blueGreen", "previewService")
+	assert.Equal(t, "test-preview", preview)
+}
+
+func TestDeploymentRolloutBlueGreenWithoutService(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"deployment": {
+			Configuration: map[string]string{
+				"mode":             "rollout",
+				"rollout-strategy": "blue-green",
+			},
+		},
+	}
+
+	err := newRolloutTestCatalog(t, true).apply(env)
+	assert.NotNil(t, err)
+}
+
+func TestDeploymentRolloutNotInstalled(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"deployment": {
+			Configuration: map[string]string{
+				"mode": "rollout",
+			},
+		},
+	}
+
+	err := newRolloutTestCatalog(t, false).apply(env)
+	assert.Nil(t, err)
+
+	assert.Nil(t, findRollout(env))
+	assert.NotNil(t, env.Resources.GetDeployment(func(deployment *appsv1.Deployment) bool {
+		return deployment.Name == TestDeployment
+	}))
+}
+
+func TestParseCanarySteps(t *testing.T) {
+	steps, err := parseCanarySteps("")
+	assert.Nil(t, err)
+	assert.Empty(t, steps)
+
+	_, err = parseCanarySteps("20,150")
+	assert.NotNil(t, err)
+	_, err = parseCanarySteps("20,pause:forever")
+	assert.NotNil(t, err)
+}
+
+func newRolloutTestCatalog(t *testing.T, installed bool) *Catalog {
+	c, err := test.NewFakeClient()
+	assert.Nil(t, err)
+
+	resources := []metav1.APIResource{
+		{Name: "workflows", Kind: "Workflow"},
+	}
+	if installed {
+		resources = append(resources, metav1.APIResource{Name: "rollouts", Kind: argo.RolloutKind})
+	}
+
+	clientset := fakeclientset.NewSimpleClientset()
+	clientset.Resources = []*metav1.APIResourceList{
+		{
+			GroupVersion: argo.RolloutAPIVersion,
+			APIResources: resources,
+		},
+	}
+
+	return NewCatalog(context.TODO(), &FakeClient{Client: c, Interface: clientset})
+}
+
+func findRollout(e *Environment) *unstructured.Unstructured {
+	var rollout *unstructured.Unstructured
+	e.Resources.Visit(func(o runtime.Object) {
+		if u, ok := o.(*unstructured.Unstructured); ok && u.GetKind() == argo.RolloutKind {
+			rollout = u
+		}
+	})
+	return rollout
+}
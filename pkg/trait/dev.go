@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
+)
+
+var devSchemeRegexp = regexp.MustCompile(`^[a-z][a-z0-9+.-]*$`)
+
+// The dev trait eases running integrations in development namespaces lacking the brokers or the systems the routes
+// connect to, by having the runtime rewrite the endpoints of the given schemes, e.g. kafka or jms, to stub endpoints,
+// or to mock endpoints that record the exchanges. The rewrite is done by interceptors of the runtime, configured through
+// the `camel.k.dev.*` properties.
+type devTrait struct {
+	BaseTrait   `property:",squash"`
+	StubSchemes string `property:"stub-schemes"`
+	Mock        bool   `property:"mock"`
+}
+
+func newDevTrait() *devTrait {
+	return &devTrait{
+		BaseTrait: newBaseTrait("dev"),
+	}
+}
+
+func (t *devTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial) {
+		return false, nil
+	}
+
+	schemes := t.schemes()
+	if len(schemes) == 0 {
+		return false, fmt.Errorf("no scheme to stub, set the stub-schemes property, e.g. kafka,jms")
+	}
+	for _, scheme := range schemes {
+		if !devSchemeRegexp.MatchString(scheme) {
+			return false, fmt.Errorf("invalid scheme %q", scheme)
+		}
+		if scheme == "stub" || scheme == "mock" {
+			return false, fmt.Errorf("%s endpoints cannot be stubbed", scheme)
+		}
+	}
+
+	return true, nil
+}
+
+func (t *devTrait) Apply(e *Environment) error {
+	schemes := t.schemes()
+
+	component := "stub"
+	if t.Mock {
+		component = "mock"
+	}
+
+	if err := t.reportStubbedEndpoints(e, schemes, component); err != nil {
+		return err
+	}
+
+	e.Integration.Status.Configuration = append(e.Integration.Status.Configuration,
+		v1alpha1.ConfigurationSpec{Type: "property", Value: "camel.k.dev.stub.schemes=" + strings.Join(schemes, ",")},
+		v1alpha1.ConfigurationSpec{Type: "property", Value: "camel.k.dev.stub.component=" + component},
+	)
+
+	return nil
+}
+
+// schemes returns the sorted list of the schemes to stub
+func (t *devTrait) schemes() []string {
+	schemes := make([]string, 0)
+	for _, scheme := range strings.Split(t.StubSchemes, ",") {
+		scheme = strings.TrimSuffix(strings.TrimSpace(scheme), ":")
+		if scheme != "" {
+			util.StringSliceUniqueAdd(&schemes, scheme)
+		}
+	}
+
+	// sort the schemes to get always the same configuration if they don't change
+	sort.Strings(schemes)
+
+	return schemes
+}
+
+// reportStubbedEndpoints logs the endpoints of the routes that are rewritten, so that it's clear to the developers
+// which systems the integration doesn't connect to
+func (t *devTrait) reportStubbedEndpoints(e *Environment, schemes []string, component string) error {
+	sources, err := kubernetes.ResolveIntegrationSources(t.ctx, t.client, e.Integration, e.Resources)
+	if err != nil {
+		return err
+	}
+
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+	for _, uri := range util.StringSliceJoin(meta.FromURIs, meta.ToURIs) {
+		if util.StringSliceExists(schemes, uriutil.Scheme(uri)) {
+			t.L.ForIntegration(e.Integration).Infof("Endpoint %s is replaced by a %s endpoint", uri, component)
+		}
+	}
+
+	return nil
+}
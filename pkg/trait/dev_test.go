@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDevStubSchemes(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('kafka:orders').to('jms:queue:billing')")
+	env.Integration.Status.Phase = v1alpha1.IntegrationPhaseInitial
+
+	trait := newDevTrait()
+	enabled := true
+	trait.Enabled = &enabled
+	trait.StubSchemes = "kafka, jms:,kafka"
+
+	ok, err := trait.Configure(env)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, trait.Apply(env))
+
+	assert.Equal(t, []v1alpha1.ConfigurationSpec{
+		{Type: "property", Value: "camel.k.dev.stub.schemes=jms,kafka"},
+		{Type: "property", Value: "camel.k.dev.stub.component=stub"},
+	}, env.Integration.Status.Configuration)
+}
+
+func TestDevMock(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('kafka:orders').log('order')")
+	env.Integration.Status.Phase = v1alpha1.IntegrationPhaseInitial
+
+	trait := newDevTrait()
+	enabled := true
+	trait.Enabled = &enabled
+	trait.StubSchemes = "kafka"
+	trait.Mock = true
+
+	assert.Nil(t, trait.Apply(env))
+	assert.Contains(t, env.Integration.Status.Configuration,
+		v1alpha1.ConfigurationSpec{Type: "property", Value: "camel.k.dev.stub.component=mock"})
+}
+
+func TestDevConfigure(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('kafka:orders').log('order')")
+	env.Integration.Status.Phase = v1alpha1.IntegrationPhaseInitial
+	enabled := true
+
+	trait := newDevTrait()
+	ok, err := trait.Configure(env)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	trait.Enabled = &enabled
+	_, err = trait.Configure(env)
+	assert.NotNil(t, err)
+
+	trait.StubSchemes = "kafka,Not A Scheme"
+	_, err = trait.Configure(env)
+	assert.NotNil(t, err)
+
+	trait.StubSchemes = "mock"
+	_, err = trait.Configure(env)
+	assert.NotNil(t, err)
+
+	// the endpoints are rewritten by the runtime, so the trait is only applied once
+	env.Integration.Status.Phase = v1alpha1.IntegrationPhaseDeploying
+	trait.StubSchemes = "kafka"
+	ok, err = trait.Configure(env)
+	assert.Nil(t, err)
+	assert.False(t, ok)
+}
@@ -34,6 +34,10 @@ const (
 	envVarCamelKVersion        = "CAMEL_K_VERSION"
 	envVarCamelKRuntimeVersion = "CAMEL_K_RUNTIME_VERSION"
 	envVarCamelVersion         = "CAMEL_VERSION"
+	envVarJavaOptions          = "JAVA_OPTIONS"
+
+	// fipsJavaOptions makes the JDK use the FIPS validated crypto modules of the system
+	fipsJavaOptions = "-Dcom.redhat.fips=true"
 )
 
 func newEnvironmentTrait() *environmentTrait {
@@ -61,5 +65,14 @@ func (t *environmentTrait) Apply(e *Environment) error {
 		envvar.SetValFrom(&e.EnvVars, envVarPodName, "metadata.name")
 	}
 
+	if e.Platform != nil && e.Platform.Spec.FIPS {
+		// the options set through the env configuration are retained
+		options := fipsJavaOptions
+		if existing, ok := e.CollectConfigurationPairs("env")[envVarJavaOptions]; ok && existing != "" {
+			options = existing + " " + options
+		}
+		envvar.SetVal(&e.EnvVars, envVarJavaOptions, options)
+	}
+
 	return nil
 }
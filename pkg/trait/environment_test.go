@@ -26,6 +26,7 @@ import (
 	"github.com/apache/camel-k/pkg/util/test"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/envvar"
 	"github.com/apache/camel-k/pkg/util/kubernetes"
 	"github.com/stretchr/testify/assert"
 
@@ -224,3 +225,16 @@ func TestDisabledContainerMetaDataEnvVars(t *testing.T) {
 func NewEnvironmentTestCatalog() *Catalog {
 	return NewCatalog(context.TODO(), nil)
 }
+
+func TestEnvironmentFIPS(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Platform.Spec.FIPS = true
+	env.Integration.Spec.Configuration = append(env.Integration.Spec.Configuration,
+		v1alpha1.ConfigurationSpec{Type: "env", Value: "JAVA_OPTIONS=-Xmx256m"})
+
+	res := processTestEnv(t, env)
+
+	d := res.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+	assert.Equal(t, "-Xmx256m -Dcom.redhat.fips=true", envvar.Get(d.Spec.Template.Spec.Containers[0].Env, envVarJavaOptions).Value)
+}
@@ -28,9 +28,11 @@ import (
 )
 
 type ingressTrait struct {
-	BaseTrait `property:",squash"`
-	Host      string `property:"host"`
-	Auto      *bool  `property:"auto"`
+	BaseTrait       `property:",squash"`
+	Host            string `property:"host"`
+	Auto            *bool  `property:"auto"`
+	Paths           string `property:"paths"`
+	PathAnnotations string `property:"path-annotations"`
 }
 
 func newIngressTrait() *ingressTrait {
@@ -75,7 +77,25 @@ func (t *ingressTrait) Apply(e *Environment) error {
 		return errors.New("cannot Apply ingress trait: no target service")
 	}
 
-	e.Resources.Add(t.getIngressFor(service))
+	paths, err := exposedPaths(t.ctx, t.client, e, t.Paths)
+	if err != nil {
+		return err
+	}
+	annotations, err := parsePathAnnotations(t.PathAnnotations)
+	if err != nil {
+		return err
+	}
+
+	if len(paths) == 0 {
+		e.Resources.Add(t.getIngressFor(service, annotations["/"]))
+		return nil
+	}
+
+	// one ingress per path, so that annotations can be set on each of them
+	for _, path := range paths {
+		e.Resources.Add(t.getPathIngressFor(service, path, annotations[path]))
+	}
+
 	return nil
 }
 
@@ -93,15 +113,16 @@ func (t *ingressTrait) getTargetService(e *Environment) (service *corev1.Service
 	return
 }
 
-func (t *ingressTrait) getIngressFor(service *corev1.Service) *v1beta1.Ingress {
+func (t *ingressTrait) getIngressFor(service *corev1.Service, annotations map[string]string) *v1beta1.Ingress {
 	ingress := v1beta1.Ingress{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Ingress",
 			APIVersion: v1beta1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      service.Name,
-			Namespace: service.Namespace,
+			Name:        service.Name,
+			Namespace:   service.Namespace,
+			Annotations: annotations,
 		},
 		Spec: v1beta1.IngressSpec{
 			Backend: &v1beta1.IngressBackend{
@@ -117,3 +138,38 @@ func (t *ingressTrait) getIngressFor(service *corev1.Service) *v1beta1.Ingress {
 	}
 	return &ingress
 }
+
+func (t *ingressTrait) getPathIngressFor(service *corev1.Service, path string, annotations map[string]string) *v1beta1.Ingress {
+	ingress := v1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pathResourceName(service.Name, path),
+			Namespace:   service.Namespace,
+			Annotations: annotations,
+		},
+		Spec: v1beta1.IngressSpec{
+			Rules: []v1beta1.IngressRule{
+				{
+					Host: t.Host,
+					IngressRuleValue: v1beta1.IngressRuleValue{
+						HTTP: &v1beta1.HTTPIngressRuleValue{
+							Paths: []v1beta1.HTTPIngressPath{
+								{
+									Path: path,
+									Backend: v1beta1.IngressBackend{
+										ServiceName: service.Name,
+										ServicePort: intstr.FromString("http"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return &ingress
+}
@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const initContainersDataVolume = "i-init-data"
+
+// The init-containers trait adds the init containers declared in the integration spec to the integration pods.
+//
+// Kubernetes runs them sequentially, in declaration order and after the init containers generated by the operator,
+// before the Camel runtime is started. An emptyDir volume mounted in all of them and in the integration container
+// lets them hand over data, e.g. a fetched dataset or a warmed cache.
+type initContainersTrait struct {
+	BaseTrait `property:",squash"`
+	DataPath  string `property:"data-path"`
+}
+
+func newInitContainersTrait() *initContainersTrait {
+	return &initContainersTrait{
+		BaseTrait: newBaseTrait("init-containers"),
+		DataPath:  "/var/camel/data",
+	}
+}
+
+func (t *initContainersTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled != nil && !*t.Enabled {
+		return false, nil
+	}
+	if len(e.Integration.Spec.InitContainers) == 0 {
+		return false, nil
+	}
+
+	return e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying), nil
+}
+
+func (t *initContainersTrait) Apply(e *Environment) error {
+	strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+	if err != nil {
+		return err
+	}
+	if strategy != ControllerStrategyDeployment {
+		return errors.New("init containers are only supported by deployments")
+	}
+
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		environment.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+			t.addInitContainers(environment.Integration, deployment)
+		})
+		return nil
+	})
+
+	return nil
+}
+
+func (t *initContainersTrait) addInitContainers(integration *v1alpha1.Integration, deployment *appsv1.Deployment) {
+	spec := &deployment.Spec.Template.Spec
+	mount := corev1.VolumeMount{
+		Name:      initContainersDataVolume,
+		MountPath: t.DataPath,
+	}
+
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: initContainersDataVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	for _, c := range integration.Spec.InitContainers {
+		container := *c.DeepCopy()
+		container.VolumeMounts = append(container.VolumeMounts, mount)
+		spec.InitContainers = append(spec.InitContainers, container)
+	}
+
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == integration.Name {
+			spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, mount)
+		}
+	}
+}
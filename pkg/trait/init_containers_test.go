@@ -0,0 +1,92 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestInitContainers(t *testing.T) {
+	deployment := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{Name: "preprocessor"},
+					},
+					Containers: []corev1.Container{
+						{Name: "my-integration"},
+					},
+				},
+			},
+		},
+	}
+
+	e := &Environment{
+		Integration: &v1alpha1.Integration{
+			Status: v1alpha1.IntegrationStatus{
+				Phase: v1alpha1.IntegrationPhaseDeploying,
+			},
+		},
+		Platform: &v1alpha1.IntegrationPlatform{
+			Spec: v1alpha1.IntegrationPlatformSpec{
+				Cluster: v1alpha1.IntegrationPlatformClusterKubernetes,
+			},
+		},
+		Resources: kubernetes.NewCollection(&deployment),
+	}
+	e.Integration.Name = "my-integration"
+
+	trait := newInitContainersTrait()
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	e.Integration.Spec.InitContainers = []corev1.Container{
+		{Name: "fetch-dataset", Image: "acme/fetch:1.0"},
+		{Name: "migrate-db", Image: "acme/migrate:1.0"},
+	}
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Len(t, e.PostProcessors, 1)
+	assert.Nil(t, e.PostProcessors[0](e))
+
+	mount := corev1.VolumeMount{Name: initContainersDataVolume, MountPath: "/var/camel/data"}
+	spec := deployment.Spec.Template.Spec
+	assert.Len(t, spec.InitContainers, 3)
+	assert.Equal(t, "preprocessor", spec.InitContainers[0].Name)
+	assert.Equal(t, "fetch-dataset", spec.InitContainers[1].Name)
+	assert.Equal(t, "migrate-db", spec.InitContainers[2].Name)
+	assert.Equal(t, []corev1.VolumeMount{mount}, spec.InitContainers[2].VolumeMounts)
+	assert.Equal(t, []corev1.VolumeMount{mount}, spec.Containers[0].VolumeMounts)
+	assert.Equal(t, initContainersDataVolume, spec.Volumes[0].Name)
+
+	// the integration spec is left untouched
+	assert.Empty(t, e.Integration.Spec.InitContainers[0].VolumeMounts)
+}
@@ -29,6 +29,8 @@ import (
 	"github.com/apache/camel-k/pkg/metadata"
 	"github.com/apache/camel-k/pkg/util/envvar"
 
+	corev1 "k8s.io/api/core/v1"
+
 	knativeapi "github.com/apache/camel-k/pkg/apis/camel/v1alpha1/knative"
 	knativeutil "github.com/apache/camel-k/pkg/util/knative"
 )
@@ -42,10 +44,16 @@ type knativeTrait struct {
 	EndpointSinks        string `property:"endpoint-sinks"`
 	FilterSourceChannels *bool  `property:"filter-source-channels"`
 	Auto                 *bool  `property:"auto"`
+	HTTPFallback         *bool  `property:"http-fallback"`
+
+	fallback bool
 }
 
 const (
 	knativeHistoryHeader = "ce-knativehistory"
+
+	knativeReasonNotInstalled = "KnativeNotInstalled"
+	knativeReasonHTTPFallback = "HTTPFallback"
 )
 
 func newKnativeTrait() *knativeTrait {
@@ -61,7 +69,7 @@ func (t *knativeTrait) Configure(e *Environment) (bool, error) {
 		return false, nil
 	}
 
-	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying) {
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial, v1alpha1.IntegrationPhaseDeploying) {
 		return false, nil
 	}
 
@@ -115,20 +123,97 @@ func (t *knativeTrait) Configure(e *Environment) (bool, error) {
 		}
 	}
 
+	if e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial) {
+		// validate the Knative availability on every profile
+		return t.usesKnative(), nil
+	}
+
+	if e.DetermineProfile() != v1alpha1.TraitProfileKnative {
+		// outside of the knative profile, the trait is only needed to bind
+		// endpoints over plain HTTP when Knative is not available
+		c := v1alpha1.GetCondition(e.Integration.Status.Conditions, v1alpha1.ConditionKnativeUnavailable)
+		if c == nil || c.Reason != knativeReasonHTTPFallback {
+			return false, nil
+		}
+
+		t.fallback = true
+	}
+
 	return true, nil
 }
 
 func (t *knativeTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial) {
+		installed, err := knativeutil.IsInstalled(t.ctx, t.client)
+		if err != nil {
+			return errors.Wrap(err, "unable to determine if Knative is installed")
+		}
+
+		t.checkAvailability(e, installed)
+
+		return nil
+	}
+
 	if err := t.createConfiguration(e); err != nil {
 		return err
 	}
-	if err := t.createSubscriptions(e); err != nil {
-		return err
+	if !t.fallback {
+		if err := t.createSubscriptions(e); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// checkAvailability fails the integration when it requires Knative but it is not installed,
+// unless its endpoints can be bound over plain HTTP
+func (t *knativeTrait) checkAvailability(e *Environment, installed bool) {
+	if installed {
+		e.Integration.Status.Conditions = v1alpha1.RemoveCondition(e.Integration.Status.Conditions, v1alpha1.ConditionKnativeUnavailable)
+		return
+	}
+
+	channels := append(t.extractNames(t.ChannelSources), t.extractNames(t.ChannelSinks)...)
+	endpoints := append(t.extractNames(t.EndpointSources), t.extractNames(t.EndpointSinks)...)
+
+	if t.HTTPFallback != nil && *t.HTTPFallback && len(channels) == 0 && e.DetermineProfile() != v1alpha1.TraitProfileKnative {
+		e.Integration.Status.Conditions = v1alpha1.SetCondition(e.Integration.Status.Conditions, v1alpha1.Condition{
+			Type:    v1alpha1.ConditionKnativeUnavailable,
+			Status:  corev1.ConditionTrue,
+			Reason:  knativeReasonHTTPFallback,
+			Message: fmt.Sprintf("Knative is not installed, endpoints %v are bound over plain HTTP", endpoints),
+		})
+		return
+	}
+
+	message := "Knative is not installed but the integration uses"
+	if len(channels) > 0 {
+		message += fmt.Sprintf(" channels %v", channels)
+	}
+	if len(endpoints) > 0 {
+		if len(channels) > 0 {
+			message += " and"
+		}
+		message += fmt.Sprintf(" endpoints %v", endpoints)
+	}
+	if len(channels) == 0 && e.DetermineProfile() != v1alpha1.TraitProfileKnative {
+		message += " (enable the knative.http-fallback trait option to bind them over plain HTTP)"
+	}
+
+	e.Integration.Status.Phase = v1alpha1.IntegrationPhaseError
+	e.Integration.Status.Conditions = v1alpha1.SetCondition(e.Integration.Status.Conditions, v1alpha1.Condition{
+		Type:    v1alpha1.ConditionKnativeUnavailable,
+		Status:  corev1.ConditionTrue,
+		Reason:  knativeReasonNotInstalled,
+		Message: message,
+	})
+}
+
+func (t *knativeTrait) usesKnative() bool {
+	return t.ChannelSources != "" || t.ChannelSinks != "" || t.EndpointSources != "" || t.EndpointSinks != ""
+}
+
 func (t *knativeTrait) createConfiguration(e *Environment) error {
 	env := knativeapi.NewCamelEnvironment()
 	if t.Configuration != "" {
@@ -266,17 +351,24 @@ func (t *knativeTrait) configureEndpoints(e *Environment, env *knativeapi.CamelE
 			continue
 		}
 
-		s, err := knativeutil.GetService(t.ctx, t.client, e.Integration.Namespace, endpoint)
-		if err != nil {
-			return err
-		}
-		if s == nil || s.Status.Address == nil || s.Status.Address.Hostname == "" {
-			return errors.New("cannot find address of endpoint " + endpoint)
+		var host string
+		if t.fallback {
+			// target the plain service exposing the integration
+			host = endpoint + "." + e.Integration.Namespace + ".svc.cluster.local"
+		} else {
+			s, err := knativeutil.GetService(t.ctx, t.client, e.Integration.Namespace, endpoint)
+			if err != nil {
+				return err
+			}
+			if s == nil || s.Status.Address == nil || s.Status.Address.Hostname == "" {
+				return errors.New("cannot find address of endpoint " + endpoint)
+			}
+			host = s.Status.Address.Hostname
 		}
 
 		svc := knativeapi.CamelServiceDefinition{
 			Name:        endpoint,
-			Host:        s.Status.Address.Hostname,
+			Host:        host,
 			Port:        80,
 			Protocol:    knativeapi.CamelProtocolHTTP,
 			ServiceType: knativeapi.CamelServiceTypeEndpoint,
@@ -217,3 +217,134 @@ func NewFakeClient(namespace string) (controller.Client, error) {
 		},
 	), nil
 }
+
+func TestKnativeAvailability(t *testing.T) {
+	environment := Environment{
+		Integration: &v1alpha1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Status: v1alpha1.IntegrationStatus{
+				Phase: v1alpha1.IntegrationPhaseInitial,
+			},
+			Spec: v1alpha1.IntegrationSpec{
+				Profile: v1alpha1.TraitProfileKubernetes,
+			},
+		},
+	}
+
+	tr := newKnativeTrait()
+	tr.EndpointSinks = "endpoint-sink-1"
+
+	tr.checkAvailability(&environment, false)
+	assert.Equal(t, v1alpha1.IntegrationPhaseError, environment.Integration.Status.Phase)
+	c := v1alpha1.GetCondition(environment.Integration.Status.Conditions, v1alpha1.ConditionKnativeUnavailable)
+	assert.NotNil(t, c)
+	assert.Equal(t, knativeReasonNotInstalled, c.Reason)
+	assert.Contains(t, c.Message, "endpoint-sink-1")
+
+	fallback := true
+	tr.HTTPFallback = &fallback
+	environment.Integration.Status.Phase = v1alpha1.IntegrationPhaseInitial
+
+	tr.checkAvailability(&environment, false)
+	assert.Equal(t, v1alpha1.IntegrationPhaseInitial, environment.Integration.Status.Phase)
+	c = v1alpha1.GetCondition(environment.Integration.Status.Conditions, v1alpha1.ConditionKnativeUnavailable)
+	assert.NotNil(t, c)
+	assert.Equal(t, knativeReasonHTTPFallback, c.Reason)
+
+	// channels cannot fall back to plain HTTP
+	tr.ChannelSinks = "channel-sink-1"
+
+	tr.checkAvailability(&environment, false)
+	assert.Equal(t, v1alpha1.IntegrationPhaseError, environment.Integration.Status.Phase)
+	c = v1alpha1.GetCondition(environment.Integration.Status.Conditions, v1alpha1.ConditionKnativeUnavailable)
+	assert.NotNil(t, c)
+	assert.Equal(t, knativeReasonNotInstalled, c.Reason)
+	assert.Contains(t, c.Message, "channel-sink-1")
+
+	tr.checkAvailability(&environment, true)
+	assert.Nil(t, v1alpha1.GetCondition(environment.Integration.Status.Conditions, v1alpha1.ConditionKnativeUnavailable))
+}
+
+func TestKnativeHTTPFallback(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	environment := Environment{
+		CamelCatalog: catalog,
+		Catalog:      NewCatalog(context.TODO(), nil),
+		Integration: &v1alpha1.Integration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test",
+				Namespace: "ns",
+			},
+			Status: v1alpha1.IntegrationStatus{
+				Phase: v1alpha1.IntegrationPhaseDeploying,
+				Conditions: []v1alpha1.Condition{
+					{
+						Type:   v1alpha1.ConditionKnativeUnavailable,
+						Status: corev1.ConditionTrue,
+						Reason: knativeReasonHTTPFallback,
+					},
+				},
+			},
+			Spec: v1alpha1.IntegrationSpec{
+				Profile: v1alpha1.TraitProfileKubernetes,
+				Sources: []v1alpha1.SourceSpec{
+					{
+						DataSpec: v1alpha1.DataSpec{
+							Name:    "route.java",
+							Content: `from("knative:endpoint/ep-source").to("knative:endpoint/ep-sink")`,
+						},
+						Language: v1alpha1.LanguageJavaSource,
+					},
+				},
+				Traits: map[string]v1alpha1.TraitSpec{
+					"knative": {
+						Configuration: map[string]string{
+							"http-fallback": "true",
+						},
+					},
+				},
+			},
+		},
+		EnvVars:        make([]corev1.EnvVar, 0),
+		ExecutedTraits: make([]Trait, 0),
+		Resources:      k8sutils.NewCollection(),
+		Classpath:      strset.New(),
+	}
+
+	tc := NewCatalog(context.TODO(), nil)
+
+	err = tc.configure(&environment)
+	assert.Nil(t, err)
+
+	tr := tc.GetTrait("knative").(*knativeTrait)
+
+	ok, err := tr.Configure(&environment)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	err = tr.Apply(&environment)
+	assert.Nil(t, err)
+
+	kc := envvar.Get(environment.EnvVars, "CAMEL_KNATIVE_CONFIGURATION")
+	assert.NotNil(t, kc)
+
+	ne := knativeapi.NewCamelEnvironment()
+	err = ne.Deserialize(kc.Value)
+	assert.Nil(t, err)
+
+	eSource := ne.FindService("ep-source", knativeapi.CamelServiceTypeEndpoint)
+	assert.NotNil(t, eSource)
+	assert.Equal(t, "0.0.0.0", eSource.Host)
+
+	eSink := ne.FindService("ep-sink", knativeapi.CamelServiceTypeEndpoint)
+	assert.NotNil(t, eSink)
+	assert.Equal(t, "ep-sink.ns.svc.cluster.local", eSink.Host)
+	assert.Equal(t, 80, eSink.Port)
+
+	assert.Equal(t, 0, environment.Resources.Size())
+}
@@ -0,0 +1,344 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/masking"
+
+	"github.com/pkg/errors"
+
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	logForwardingConfigVolume = "i-log-forwarding"
+	logForwardingLogVolume    = "i-log"
+	logForwardingConfigPath   = "/etc/camel/log-forwarding"
+	logForwardingLogPath      = "/var/log/camel"
+	logForwardingContainer    = "fluent-bit"
+
+	// LogForwardingParserAnnotation tells the fluent-bit kubernetes filter of an existing log pipeline
+	// how to parse the logs of the pod
+	LogForwardingParserAnnotation = "fluentbit.io/parser"
+
+	// the log4j 2 pattern producing a JSON record per line
+	logForwardingJSONPattern = `{"timestamp":"%d{yyyy-MM-dd'T'HH:mm:ss.SSSZ}","level":"%p","logger":"%c","thread":"%enc{%t}{JSON}",` +
+		`"message":"%enc{%m}{JSON}","exception":"%enc{%throwable}{JSON}"}%n`
+	logForwardingPlainPattern = `%d{yyyy-MM-dd HH:mm:ss.SSS} %-5p [%c] (%t) %m%n`
+)
+
+// The log-forwarding trait configures the runtime to log JSON records and ships the logs to an existing log
+// pipeline, either annotating the pods for the pipeline collecting the container logs of the nodes, or deploying
+// a fluent-bit sidecar forwarding them to a fluentd (or fluent-bit) aggregator. The masking rules of the platform
+// and of the integration are applied by log4j to the messages, so that sensitive data don't reach the pipeline.
+type logForwardingTrait struct {
+	BaseTrait    `property:",squash"`
+	JSON         *bool  `property:"json"`
+	Annotations  string `property:"annotations"`
+	Sidecar      bool   `property:"sidecar"`
+	SidecarImage string `property:"sidecar-image"`
+	Host         string `property:"host"`
+	Port         int    `property:"port"`
+}
+
+func newLogForwardingTrait() *logForwardingTrait {
+	return &logForwardingTrait{
+		BaseTrait:    newBaseTrait("log-forwarding"),
+		SidecarImage: "fluent/fluent-bit:1.2",
+		Port:         24224,
+	}
+}
+
+func (t *logForwardingTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying) {
+		return false, nil
+	}
+
+	if t.Sidecar && t.Host == "" {
+		return false, errors.New("the host logs are forwarded to by the fluent-bit sidecar is required")
+	}
+
+	if _, err := masking.NewMasker(e.DetermineMaskingRules()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (t *logForwardingTrait) Apply(e *Environment) error {
+	annotations, err := t.podAnnotations()
+	if err != nil {
+		return err
+	}
+
+	if t.Sidecar {
+		strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+		if err != nil {
+			return err
+		}
+		if strategy != ControllerStrategyDeployment {
+			return errors.New("the fluent-bit sidecar is only supported by deployments, use the pod annotations instead")
+		}
+	}
+
+	configured := t.isJSON() || t.Sidecar || e.DetermineMaskingRules() != nil
+	if configured {
+		e.Resources.Add(t.newConfigMap(e))
+		envvar.SetVal(&e.EnvVars, "LOG4J_CONFIGURATION_FILE", path.Join(logForwardingConfigPath, "log4j2.properties"))
+	}
+
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		environment.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+			spec := &deployment.Spec.Template.Spec
+			if configured {
+				t.mountConfig(environment.Integration, &spec.Volumes, spec.Containers)
+			}
+			if t.Sidecar {
+				t.addSidecar(environment.Integration, spec)
+			}
+			deployment.Spec.Template.Annotations = mergeAnnotations(deployment.Spec.Template.Annotations, annotations)
+		})
+		environment.Resources.VisitKnativeService(func(service *serving.Service) {
+			template := &service.Spec.RunLatest.Configuration.RevisionTemplate
+			if configured {
+				containers := []corev1.Container{template.Spec.Container}
+				t.mountConfig(environment.Integration, &template.Spec.Volumes, containers)
+				template.Spec.Container = containers[0]
+			}
+			template.Annotations = mergeAnnotations(template.Annotations, annotations)
+		})
+		return nil
+	})
+
+	return nil
+}
+
+func (t *logForwardingTrait) isJSON() bool {
+	return t.JSON == nil || *t.JSON
+}
+
+// podAnnotations returns the annotations telling the log pipeline how to handle the pod logs, the JSON parser
+// annotation being set by default when logging JSON records
+func (t *logForwardingTrait) podAnnotations() (map[string]string, error) {
+	annotations := make(map[string]string)
+	if t.isJSON() && !t.Sidecar {
+		annotations[LogForwardingParserAnnotation] = "json"
+	}
+
+	if t.Annotations == "" {
+		return annotations, nil
+	}
+	for _, item := range strings.Split(t.Annotations, ",") {
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, fmt.Errorf("invalid annotation %q (expected \"<key>=<value>\")", item)
+		}
+		annotations[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return annotations, nil
+}
+
+func (t *logForwardingTrait) newConfigMap(e *Environment) *corev1.ConfigMap {
+	pattern := logForwardingPlainPattern
+	if t.isJSON() {
+		pattern = logForwardingJSONPattern
+	}
+	if rules := e.DetermineMaskingRules(); rules != nil {
+		pattern = strings.NewReplacer(
+			"%m", maskLog4jConverter("%m", rules),
+			"%throwable", maskLog4jConverter("%throwable", rules),
+		).Replace(pattern)
+	}
+	// backslashes are escape characters in properties files
+	pattern = strings.Replace(pattern, `\`, `\\`, -1)
+
+	log4j := []string{
+		"status = warn",
+		"appender.console.type = Console",
+		"appender.console.name = console",
+		"appender.console.layout.type = PatternLayout",
+		"appender.console.layout.pattern = " + pattern,
+		"rootLogger.level = info",
+		"rootLogger.appenderRef.console.ref = console",
+	}
+
+	data := make(map[string]string)
+	if t.Sidecar {
+		file := path.Join(logForwardingLogPath, "integration.log")
+		log4j = append(log4j,
+			"appender.file.type = RollingFile",
+			"appender.file.name = file",
+			"appender.file.fileName = "+file,
+			"appender.file.filePattern = "+file+".%i",
+			"appender.file.layout.type = PatternLayout",
+			"appender.file.layout.pattern = "+pattern,
+			"appender.file.policies.type = Policies",
+			"appender.file.policies.size.type = SizeBasedTriggeringPolicy",
+			"appender.file.policies.size.size = 10MB",
+			"appender.file.strategy.type = DefaultRolloverStrategy",
+			"appender.file.strategy.max = 2",
+			"rootLogger.appenderRef.file.ref = file",
+		)
+		data["fluent-bit.conf"] = t.fluentBitConfig(e.Integration.Name, file)
+	}
+	data["log4j2.properties"] = strings.Join(log4j, "\n") + "\n"
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name + "-log-forwarding",
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				"camel.apache.org/integration": e.Integration.Name,
+			},
+		},
+		Data: data,
+	}
+}
+
+func (t *logForwardingTrait) fluentBitConfig(integration string, file string) string {
+	input := []string{
+		"[INPUT]",
+		"    Name tail",
+		"    Path " + file,
+		"    Tag " + integration,
+	}
+	if t.isJSON() {
+		input = append(input, "    Parser json")
+	}
+
+	config := []string{
+		"[SERVICE]",
+		"    Flush 1",
+		"    Parsers_File /fluent-bit/etc/parsers.conf",
+		"",
+	}
+	config = append(config, input...)
+	config = append(config,
+		"",
+		"[OUTPUT]",
+		"    Name forward",
+		"    Match *",
+		"    Host "+t.Host,
+		"    Port "+strconv.Itoa(t.Port),
+	)
+
+	return strings.Join(config, "\n") + "\n"
+}
+
+func (t *logForwardingTrait) mountConfig(integration *v1alpha1.Integration, volumes *[]corev1.Volume, containers []corev1.Container) {
+	*volumes = append(*volumes, corev1.Volume{
+		Name: logForwardingConfigVolume,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: integration.Name + "-log-forwarding",
+				},
+			},
+		},
+	})
+
+	for i := range containers {
+		if containers[i].Name == integration.Name {
+			containers[i].VolumeMounts = append(containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      logForwardingConfigVolume,
+				MountPath: logForwardingConfigPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+}
+
+// addSidecar shares the log directory of the integration container with a fluent-bit container tailing the logs
+func (t *logForwardingTrait) addSidecar(integration *v1alpha1.Integration, spec *corev1.PodSpec) {
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: logForwardingLogVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	logs := corev1.VolumeMount{
+		Name:      logForwardingLogVolume,
+		MountPath: logForwardingLogPath,
+	}
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == integration.Name {
+			spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, logs)
+		}
+	}
+
+	logs.ReadOnly = true
+	spec.Containers = append(spec.Containers, corev1.Container{
+		Name:    logForwardingContainer,
+		Image:   t.SidecarImage,
+		Command: []string{"/fluent-bit/bin/fluent-bit", "-c", path.Join(logForwardingConfigPath, "fluent-bit.conf")},
+		VolumeMounts: []corev1.VolumeMount{
+			logs,
+			{
+				Name:      logForwardingConfigVolume,
+				MountPath: logForwardingConfigPath,
+				ReadOnly:  true,
+			},
+		},
+	})
+}
+
+// maskLog4jConverter wraps the log4j pattern converter with replace converters masking the text matching the rules
+func maskLog4jConverter(converter string, rules *v1alpha1.MaskingSpec) string {
+	// escaped braces would be taken by log4j for the end of the converter options
+	escape := strings.NewReplacer(`\{`, `\x7B`, `\}`, `\x7D`)
+	for _, p := range rules.Patterns {
+		converter = "%replace{" + converter + "}{" + escape.Replace(p) + "}{" + masking.Mask + "}"
+	}
+	if regex := masking.HeadersRegex(rules.Headers); regex != "" {
+		converter = "%replace{" + converter + "}{" + regex + "}{$1" + masking.Mask + "}"
+	}
+	return converter
+}
+
+func mergeAnnotations(annotations map[string]string, extra map[string]string) map[string]string {
+	if len(extra) == 0 {
+		return annotations
+	}
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	for k, v := range extra {
+		annotations[k] = v
+	}
+	return annotations
+}
@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestLogForwardingAnnotations(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"log-forwarding": {
+			Configuration: map[string]string{
+				"enabled":     "true",
+				"annotations": "fluentbit.io/exclude=false",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	d := res.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+	assert.Equal(t, "json", d.Spec.Template.Annotations[LogForwardingParserAnnotation])
+	assert.Equal(t, "false", d.Spec.Template.Annotations["fluentbit.io/exclude"])
+	assert.Len(t, d.Spec.Template.Spec.Containers, 1)
+
+	container := d.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: logForwardingConfigVolume, MountPath: logForwardingConfigPath, ReadOnly: true})
+	assert.Equal(t, "/etc/camel/log-forwarding/log4j2.properties", envvar.Get(container.Env, "LOG4J_CONFIGURATION_FILE").Value)
+
+	cm := res.GetConfigMap(func(cm *corev1.ConfigMap) bool { return cm.Name == TestDeployment+"-log-forwarding" })
+	assert.NotNil(t, cm)
+	assert.Contains(t, cm.Data["log4j2.properties"], `"message":"%enc{%m}{JSON}"`)
+	assert.NotContains(t, cm.Data, "fluent-bit.conf")
+}
+
+func TestLogForwardingSidecar(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"log-forwarding": {
+			Configuration: map[string]string{
+				"enabled": "true",
+				"sidecar": "true",
+				"host":    "fluentd.logging",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	d := res.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+	assert.NotContains(t, d.Spec.Template.Annotations, LogForwardingParserAnnotation)
+	assert.Len(t, d.Spec.Template.Spec.Containers, 2)
+	assert.Equal(t, TestDeployment, d.Spec.Template.Spec.Containers[0].Name)
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{Name: logForwardingLogVolume, MountPath: logForwardingLogPath})
+
+	sidecar := d.Spec.Template.Spec.Containers[1]
+	assert.Equal(t, logForwardingContainer, sidecar.Name)
+	assert.Equal(t, "fluent/fluent-bit:1.2", sidecar.Image)
+	assert.Len(t, sidecar.VolumeMounts, 2)
+
+	cm := res.GetConfigMap(func(cm *corev1.ConfigMap) bool { return cm.Name == TestDeployment+"-log-forwarding" })
+	assert.NotNil(t, cm)
+	assert.Contains(t, cm.Data["log4j2.properties"], "appender.file.fileName = /var/log/camel/integration.log")
+	assert.Contains(t, cm.Data["fluent-bit.conf"], "Host fluentd.logging")
+	assert.Contains(t, cm.Data["fluent-bit.conf"], "Port 24224")
+	assert.Contains(t, cm.Data["fluent-bit.conf"], "Parser json")
+}
+
+func TestLogForwardingSidecarRequiresHost(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+
+	trait := newLogForwardingTrait()
+	enabled := true
+	trait.Enabled = &enabled
+	trait.Sidecar = true
+
+	_, err := trait.Configure(env)
+	assert.NotNil(t, err)
+}
+
+func TestLogForwardingMasking(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Platform.Spec.Masking = &v1alpha1.MaskingSpec{
+		Headers: []string{"Authorization"},
+	}
+	env.Integration.Spec.Masking = &v1alpha1.MaskingSpec{
+		Patterns: []string{`\d{16}`},
+	}
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"log-forwarding": {
+			Configuration: map[string]string{
+				"enabled": "true",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	cm := res.GetConfigMap(func(cm *corev1.ConfigMap) bool { return cm.Name == TestDeployment+"-log-forwarding" })
+	assert.NotNil(t, cm)
+	assert.Contains(t, cm.Data["log4j2.properties"],
+		`"message":"%enc{%replace{%replace{%m}{\\d{16}}{*****}}{(?i)(\\b(?:Authorization)=)[^,\\x7D]*}{$1*****}}{JSON}"`)
+	assert.Contains(t, cm.Data["log4j2.properties"],
+		`"exception":"%enc{%replace{%replace{%throwable}{\\d{16}}{*****}}{(?i)(\\b(?:Authorization)=)[^,\\x7D]*}{$1*****}}{JSON}"`)
+}
+
+func TestLogForwardingInvalidMaskingPattern(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Masking = &v1alpha1.MaskingSpec{
+		Patterns: []string{`(`},
+	}
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"log-forwarding": {
+			Configuration: map[string]string{
+				"enabled": "true",
+			},
+		},
+	}
+
+	assert.NotNil(t, NewTraitTestCatalog().apply(env))
+}
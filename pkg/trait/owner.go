@@ -61,9 +61,13 @@ func (t *ownerTrait) Apply(e *Environment) error {
 	controller := true
 	blockOwnerDeletion := true
 
+	// the labels required by the platform policy are always transferred, so that the generated
+	// resources can be tracked as well
+	labels := append(strings.Split(t.TargetLabels, ","), e.Platform.Spec.Policy.RequiredLabels...)
+
 	targetLabels := make(map[string]string)
 	if e.Integration.Labels != nil {
-		for _, k := range strings.Split(t.TargetLabels, ",") {
+		for _, k := range labels {
 			if v, ok := e.Integration.Labels[k]; ok {
 				targetLabels[k] = v
 			}
@@ -52,6 +52,19 @@ func TestOwnerWithoutFinalizer(t *testing.T) {
 	ValidateOwnerResources(t, env, true)
 }
 
+func TestOwnerWithPolicyRequiredLabels(t *testing.T) {
+	env := SetUpOwnerEnvironment(t)
+	env.Platform.Spec.Policy.RequiredLabels = []string{"com.mycompany/mylabel2"}
+
+	processTestEnv(t, env)
+
+	env.Resources.VisitMetaObject(func(res metav1.Object) {
+		assert.Equal(t, "myvalue1", res.GetLabels()["com.mycompany/mylabel1"])
+		assert.Equal(t, "myvalue2", res.GetLabels()["com.mycompany/mylabel2"])
+		assert.NotContains(t, res.GetLabels(), "org.apache.camel/l1")
+	})
+}
+
 func SetUpOwnerEnvironment(t *testing.T) *Environment {
 	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterOpenShift, "camel:core")
 	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
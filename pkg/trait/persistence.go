@@ -0,0 +1,258 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/gzip"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	persistenceRepositoryFile       = "file"
+	persistenceRepositoryJDBC       = "jdbc"
+	persistenceRepositoryInfinispan = "infinispan"
+
+	persistenceVolume = "i-persistence"
+)
+
+// persistenceSchemes are the schemes of the consumers keeping track of the processed
+// files, that need durable state not to reprocess them on restarts
+var persistenceSchemes = []string{"file", "ftp", "ftps", "sftp"}
+
+// The persistence trait gives durable state to the routes of the integration, e.g. idempotent repositories and file
+// consumers, so that restarted or scaled pods don't reprocess files or messages. The state is kept in a persistent
+// volume claim, or in a JDBC or Infinispan backed repository configured through the runtime properties.
+type persistenceTrait struct {
+	BaseTrait    `property:",squash"`
+	Repository   string `property:"repository"`
+	Path         string `property:"path"`
+	Size         string `property:"size"`
+	StorageClass string `property:"storage-class"`
+	AccessMode   string `property:"access-mode"`
+	DataSource   string `property:"datasource"`
+	Hosts        string `property:"hosts"`
+}
+
+func newPersistenceTrait() *persistenceTrait {
+	return &persistenceTrait{
+		BaseTrait:  newBaseTrait("persistence"),
+		Repository: persistenceRepositoryFile,
+		Path:       "/var/camel/state",
+		Size:       "1Gi",
+		AccessMode: string(corev1.ReadWriteOnce),
+	}
+}
+
+func (t *persistenceTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled != nil && !*t.Enabled {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial, v1alpha1.IntegrationPhaseDeploying) {
+		return false, nil
+	}
+
+	switch t.Repository {
+	case persistenceRepositoryFile, persistenceRepositoryJDBC, persistenceRepositoryInfinispan:
+	default:
+		return false, fmt.Errorf("unsupported persistence repository %s", t.Repository)
+	}
+
+	if t.Enabled == nil {
+		required, err := t.requiresPersistence(e)
+		if err != nil || !required {
+			return false, err
+		}
+		if t.Repository == persistenceRepositoryFile {
+			strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+			if err != nil {
+				return false, err
+			}
+			if strategy != ControllerStrategyDeployment {
+				t.L.Infof("Persistent volumes are only supported by deployments, integration %s state is not persisted", e.Integration.Name)
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (t *persistenceTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial) {
+		t.configureRepository(e)
+	}
+
+	if e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying) && t.Repository == persistenceRepositoryFile {
+		strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+		if err != nil {
+			return err
+		}
+		if strategy != ControllerStrategyDeployment {
+			return errors.New("persistent volumes are only supported by deployments, use a jdbc or infinispan repository")
+		}
+
+		claim, err := t.newPersistentVolumeClaim(e)
+		if err != nil {
+			return err
+		}
+		e.Resources.Add(claim)
+
+		e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+			environment.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+				t.mountVolume(environment.Integration, claim.Name, deployment)
+			})
+			return nil
+		})
+	}
+
+	return nil
+}
+
+// requiresPersistence tells whether the routes consume files or use an idempotent consumer
+func (t *persistenceTrait) requiresPersistence(e *Environment) (bool, error) {
+	sources, err := kubernetes.ResolveIntegrationSources(t.ctx, t.client, e.Integration, e.Resources)
+	if err != nil {
+		return false, err
+	}
+
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+	for _, uri := range meta.FromURIs {
+		if util.StringSliceExists(persistenceSchemes, uriutil.Scheme(uri)) {
+			return true, nil
+		}
+	}
+	for _, s := range sources {
+		content := s.Content
+		if s.Compression {
+			data, err := gzip.UncompressBase64([]byte(content))
+			if err != nil {
+				// already reported by the metadata extraction
+				continue
+			}
+			content = string(data)
+		}
+		if strings.Contains(content, "idempotentConsumer") || strings.Contains(content, "idempotent-consumer") {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// configureRepository sets the runtime properties and dependencies of the repository
+func (t *persistenceTrait) configureRepository(e *Environment) {
+	properties := []string{
+		"camel.k.persistence.repository=" + t.Repository,
+	}
+
+	switch t.Repository {
+	case persistenceRepositoryFile:
+		properties = append(properties, "camel.k.persistence.path="+t.Path)
+	case persistenceRepositoryJDBC:
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, "camel:sql")
+		if t.DataSource != "" {
+			properties = append(properties, "camel.k.persistence.datasource="+t.DataSource)
+		}
+	case persistenceRepositoryInfinispan:
+		util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, "camel:infinispan")
+		if t.Hosts != "" {
+			properties = append(properties, "camel.k.persistence.hosts="+t.Hosts)
+		}
+	}
+
+	// sort the dependencies to get always the same list if they don't change
+	sort.Strings(e.Integration.Status.Dependencies)
+
+	for _, p := range properties {
+		e.Integration.Status.Configuration = append(e.Integration.Status.Configuration,
+			v1alpha1.ConfigurationSpec{Type: "property", Value: p})
+	}
+}
+
+func (t *persistenceTrait) newPersistentVolumeClaim(e *Environment) (*corev1.PersistentVolumeClaim, error) {
+	size, err := resource.ParseQuantity(t.Size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid persistence size %s", t.Size)
+	}
+
+	claim := corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      e.Integration.Name + "-state",
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				"camel.apache.org/integration": e.Integration.Name,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.PersistentVolumeAccessMode(t.AccessMode),
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+	if t.StorageClass != "" {
+		claim.Spec.StorageClassName = &t.StorageClass
+	}
+
+	return &claim, nil
+}
+
+func (t *persistenceTrait) mountVolume(integration *v1alpha1.Integration, claim string, deployment *appsv1.Deployment) {
+	spec := &deployment.Spec.Template.Spec
+
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: persistenceVolume,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: claim,
+			},
+		},
+	})
+
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == integration.Name {
+			spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      persistenceVolume,
+				MountPath: t.Path,
+			})
+		}
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func createPersistenceTestEnv(t *testing.T, phase v1alpha1.IntegrationPhase, content string) *Environment {
+	return createIntegrationTestEnv(t, "my-integration", phase, createGroovyTestSource("routes.groovy", content))
+}
+
+func TestPersistenceDetection(t *testing.T) {
+	e := createPersistenceTestEnv(t, v1alpha1.IntegrationPhaseInitial, `from("timer:tick").to("log:info")`)
+	enabled, err := newPersistenceTrait().Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	e = createPersistenceTestEnv(t, v1alpha1.IntegrationPhaseInitial, `from("file:/data/inbox").to("log:info")`)
+	enabled, err = newPersistenceTrait().Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	e = createPersistenceTestEnv(t, v1alpha1.IntegrationPhaseInitial,
+		`from("timer:tick").idempotentConsumer(header("id")).to("log:info")`)
+	enabled, err = newPersistenceTrait().Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	trait := newPersistenceTrait()
+	trait.Enabled = &[]bool{false}[0]
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	trait = newPersistenceTrait()
+	trait.Repository = "redis"
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+}
+
+func TestPersistenceVolume(t *testing.T) {
+	e := createPersistenceTestEnv(t, v1alpha1.IntegrationPhaseInitial, `from("file:/data/inbox").to("log:info")`)
+
+	trait := newPersistenceTrait()
+	trait.StorageClass = "standard"
+	assert.Nil(t, trait.Apply(e))
+	assert.Contains(t, e.Integration.Status.Configuration, v1alpha1.ConfigurationSpec{
+		Type: "property", Value: "camel.k.persistence.path=/var/camel/state",
+	})
+
+	deployment := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "my-integration"},
+					},
+				},
+			},
+		},
+	}
+	e.Integration.Status.Phase = v1alpha1.IntegrationPhaseDeploying
+	e.Resources.Add(&deployment)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Len(t, e.PostProcessors, 1)
+	assert.Nil(t, e.PostProcessors[0](e))
+
+	var claim *corev1.PersistentVolumeClaim
+	e.Resources.Visit(func(o runtime.Object) {
+		if c, ok := o.(*corev1.PersistentVolumeClaim); ok {
+			claim = c
+		}
+	})
+	assert.NotNil(t, claim)
+	assert.Equal(t, "my-integration-state", claim.Name)
+	assert.Equal(t, "standard", *claim.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, claim.Spec.AccessModes)
+	assert.Equal(t, resource.MustParse("1Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	spec := deployment.Spec.Template.Spec
+	assert.Equal(t, "my-integration-state", spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: persistenceVolume, MountPath: "/var/camel/state"},
+	}, spec.Containers[0].VolumeMounts)
+}
+
+func TestPersistenceJDBCRepository(t *testing.T) {
+	e := createPersistenceTestEnv(t, v1alpha1.IntegrationPhaseInitial, `from("file:/data/inbox").to("log:info")`)
+
+	trait := newPersistenceTrait()
+	trait.Repository = persistenceRepositoryJDBC
+	trait.DataSource = "myDataSource"
+	assert.Nil(t, trait.Apply(e))
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:sql")
+	assert.Contains(t, e.Integration.Status.Configuration, v1alpha1.ConfigurationSpec{
+		Type: "property", Value: "camel.k.persistence.datasource=myDataSource",
+	})
+
+	// no volume is needed
+	e.Integration.Status.Phase = v1alpha1.IntegrationPhaseDeploying
+	assert.Nil(t, trait.Apply(e))
+	assert.Empty(t, e.PostProcessors)
+	assert.Equal(t, 0, e.Resources.Size())
+}
@@ -0,0 +1,160 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/client"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TraitsConfigMapName is the name of the ConfigMap external controllers, e.g. a company policy operator, can use
+// to configure the traits of the integrations of its namespace, using <trait>.<property> keys.
+//
+// The trait configurations are merged in this order, the latter taking precedence:
+//
+//   - the integration platform traits
+//   - the traits ConfigMap of the namespace, with the default precedence
+//   - the integration kit traits
+//   - the integration traits
+//   - the integration trait annotations
+//   - the traits ConfigMap of the namespace, with the override precedence
+const TraitsConfigMapName = "camel-k-traits"
+
+// TraitsPrecedenceAnnotation is the annotation of the traits ConfigMap setting its precedence
+const TraitsPrecedenceAnnotation = "camel.apache.org/traits.precedence"
+
+// TraitAnnotationPrefix is the prefix of the integration annotations configuring a trait property,
+// e.g. trait.camel.apache.org/prometheus.enabled
+const TraitAnnotationPrefix = "trait.camel.apache.org/"
+
+// TraitsPrecedence --
+type TraitsPrecedence string
+
+const (
+	// TraitsPrecedenceDefault makes the traits ConfigMap provide defaults the integrations can override
+	TraitsPrecedenceDefault TraitsPrecedence = "default"
+	// TraitsPrecedenceOverride makes the traits ConfigMap override the integrations configuration
+	TraitsPrecedenceOverride TraitsPrecedence = "override"
+)
+
+// NewTraitsConfigMap creates the traits ConfigMap of the given namespace
+func NewTraitsConfigMap(namespace string, precedence TraitsPrecedence, traits map[string]v1alpha1.TraitSpec) *corev1.ConfigMap {
+	data := make(map[string]string)
+	for id, spec := range traits {
+		for property, value := range spec.Configuration {
+			data[id+"."+property] = value
+		}
+	}
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      TraitsConfigMapName,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				TraitsPrecedenceAnnotation: string(precedence),
+			},
+		},
+		Data: data,
+	}
+}
+
+// LoadTraitsConfigMap returns the traits ConfigMap of the given namespace, or nil if it does not exist
+func LoadTraitsConfigMap(ctx context.Context, c client.Client, namespace string) (*corev1.ConfigMap, error) {
+	cm := corev1.ConfigMap{}
+	key := k8sclient.ObjectKey{
+		Namespace: namespace,
+		Name:      TraitsConfigMapName,
+	}
+
+	if err := c.Get(ctx, key, &cm); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "unable to load the traits configmap of namespace %s", namespace)
+	}
+
+	return &cm, nil
+}
+
+// TraitsFromConfigMap decodes the traits configured by the given ConfigMap and its precedence
+func TraitsFromConfigMap(cm *corev1.ConfigMap) (map[string]v1alpha1.TraitSpec, TraitsPrecedence, error) {
+	precedence := TraitsPrecedence(cm.Annotations[TraitsPrecedenceAnnotation])
+	switch precedence {
+	case "":
+		precedence = TraitsPrecedenceDefault
+	case TraitsPrecedenceDefault, TraitsPrecedenceOverride:
+	default:
+		return nil, "", fmt.Errorf("invalid precedence %q of configmap %s (expected %s or %s)", precedence, cm.Name, TraitsPrecedenceDefault, TraitsPrecedenceOverride)
+	}
+
+	traits, err := decodeTraitProperties(cm.Data)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "invalid configmap %s", cm.Name)
+	}
+
+	return traits, precedence, nil
+}
+
+// TraitsFromAnnotations decodes the traits configured by the given annotations, ignoring the ones
+// not having the trait prefix
+func TraitsFromAnnotations(annotations map[string]string) (map[string]v1alpha1.TraitSpec, error) {
+	properties := make(map[string]string)
+	for k, v := range annotations {
+		if strings.HasPrefix(k, TraitAnnotationPrefix) {
+			properties[strings.TrimPrefix(k, TraitAnnotationPrefix)] = v
+		}
+	}
+
+	return decodeTraitProperties(properties)
+}
+
+func decodeTraitProperties(properties map[string]string) (map[string]v1alpha1.TraitSpec, error) {
+	traits := make(map[string]v1alpha1.TraitSpec)
+	for k, v := range properties {
+		parts := strings.SplitN(k, ".", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("unrecognized trait property %q (expected \"<trait>.<property>\")", k)
+		}
+
+		spec, ok := traits[parts[0]]
+		if !ok {
+			spec = v1alpha1.TraitSpec{
+				Configuration: make(map[string]string),
+			}
+		}
+		spec.Configuration[parts[1]] = v
+		traits[parts[0]] = spec
+	}
+
+	return traits, nil
+}
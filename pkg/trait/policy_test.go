@@ -0,0 +1,112 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTraitsFromConfigMap(t *testing.T) {
+	cm := NewTraitsConfigMap("ns", TraitsPrecedenceOverride, map[string]v1alpha1.TraitSpec{
+		"owner": {
+			Configuration: map[string]string{
+				"target-labels": "team",
+			},
+		},
+	})
+
+	assert.Equal(t, TraitsConfigMapName, cm.Name)
+	assert.Equal(t, "team", cm.Data["owner.target-labels"])
+
+	traits, precedence, err := TraitsFromConfigMap(cm)
+	assert.Nil(t, err)
+	assert.Equal(t, TraitsPrecedenceOverride, precedence)
+	assert.Equal(t, "team", traits["owner"].Configuration["target-labels"])
+
+	cm.Annotations = nil
+	_, precedence, err = TraitsFromConfigMap(cm)
+	assert.Nil(t, err)
+	assert.Equal(t, TraitsPrecedenceDefault, precedence)
+
+	cm.Annotations = map[string]string{TraitsPrecedenceAnnotation: "always"}
+	_, _, err = TraitsFromConfigMap(cm)
+	assert.NotNil(t, err)
+
+	cm.Annotations = nil
+	cm.Data = map[string]string{"owner": "team"}
+	_, _, err = TraitsFromConfigMap(cm)
+	assert.NotNil(t, err)
+}
+
+func TestTraitsFromAnnotations(t *testing.T) {
+	traits, err := TraitsFromAnnotations(map[string]string{
+		TraitAnnotationPrefix + "service.port":    "8081",
+		TraitAnnotationPrefix + "service.enabled": "true",
+		"camel.apache.org/other":                  "value",
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, traits, 1)
+	assert.Equal(t, map[string]string{"port": "8081", "enabled": "true"}, traits["service"].Configuration)
+}
+
+func TestLoadTraitsConfigMap(t *testing.T) {
+	c, err := test.NewFakeClient(NewTraitsConfigMap("ns", TraitsPrecedenceDefault, nil))
+	assert.Nil(t, err)
+
+	cm, err := LoadTraitsConfigMap(context.TODO(), c, "ns")
+	assert.Nil(t, err)
+	assert.NotNil(t, cm)
+
+	cm, err = LoadTraitsConfigMap(context.TODO(), c, "other")
+	assert.Nil(t, err)
+	assert.Nil(t, cm)
+}
+
+func TestTraitsPrecedence(t *testing.T) {
+	targetLabels := func(precedence TraitsPrecedence, annotated bool) string {
+		env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+		env.Platform.Spec.Traits = map[string]v1alpha1.TraitSpec{
+			"owner": {Configuration: map[string]string{"target-labels": "platform"}},
+		}
+		env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+			"owner": {Configuration: map[string]string{"target-labels": "integration"}},
+		}
+		if annotated {
+			env.Integration.Annotations = map[string]string{TraitAnnotationPrefix + "owner.target-labels": "annotation"}
+		}
+		env.TraitsConfigMap = NewTraitsConfigMap("ns", precedence, map[string]v1alpha1.TraitSpec{
+			"owner": {Configuration: map[string]string{"target-labels": "configmap"}},
+		})
+
+		c := NewTraitTestCatalog()
+		assert.Nil(t, c.configure(env))
+
+		return c.GetTrait("owner").(*ownerTrait).TargetLabels
+	}
+
+	assert.Equal(t, "integration", targetLabels(TraitsPrecedenceDefault, false))
+	assert.Equal(t, "annotation", targetLabels(TraitsPrecedenceDefault, true))
+	assert.Equal(t, "configmap", targetLabels(TraitsPrecedenceOverride, true))
+}
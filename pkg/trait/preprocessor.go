@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	preprocessorSourcesVolume = "i-preprocessed-sources"
+	preprocessorInputPath     = "/etc/camel/sources"
+	preprocessorOutputPath    = "/etc/camel/preprocessed"
+)
+
+// The preprocessor trait transforms the integration sources before they are handed over to the runtime,
+// either with the built-in preprocessors or with a preprocessor image run as an init container
+type preprocessorTrait struct {
+	BaseTrait `property:",squash"`
+	Values    string `property:"values"`
+	StripTags string `property:"strip-tags"`
+	Image     string `property:"image"`
+}
+
+func newPreprocessorTrait() *preprocessorTrait {
+	return &preprocessorTrait{
+		BaseTrait: newBaseTrait("preprocessor"),
+	}
+}
+
+func (t *preprocessorTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	// the sources are transformed when initializing the integration as well, so that its dependencies
+	// and metadata are computed from the transformed sources
+	return e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial, v1alpha1.IntegrationPhaseDeploying), nil
+}
+
+func (t *preprocessorTrait) Apply(e *Environment) error {
+	preprocessors := make([]metadata.Preprocessor, 0)
+
+	if t.Values != "" {
+		values, err := parseCsvMap(&t.Values)
+		if err != nil {
+			return err
+		}
+		preprocessors = append(preprocessors, metadata.TemplatePreprocessor(values))
+	}
+	if t.StripTags != "" {
+		preprocessors = append(preprocessors, metadata.StripPreprocessor(strings.Split(t.StripTags, ",")...))
+	}
+
+	if len(preprocessors) > 0 {
+		sources, err := metadata.Preprocess(e.Integration.Spec.Sources, preprocessors...)
+		if err != nil {
+			return err
+		}
+		// the transformed sources are only used to generate the resources, the status being
+		// the only part of the integration that gets updated
+		e.Integration.Spec.Sources = sources
+	}
+
+	if t.Image != "" && e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying) {
+		strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+		if err != nil {
+			return err
+		}
+		if strategy != ControllerStrategyDeployment {
+			return errors.New("preprocessor images are only supported by deployments")
+		}
+
+		e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+			environment.Resources.VisitDeployment(t.addPreprocessorContainer)
+			return nil
+		})
+	}
+
+	return nil
+}
+
+// addPreprocessorContainer runs the preprocessor image as an init container reading the sources
+// and makes the integration container load the preprocessed ones from an emptyDir volume
+func (t *preprocessorTrait) addPreprocessorContainer(deployment *appsv1.Deployment) {
+	spec := &deployment.Spec.Template.Spec
+	if len(spec.Containers) == 0 {
+		return
+	}
+
+	container := &spec.Containers[0]
+	sourceMounts := make([]corev1.VolumeMount, 0)
+	mounts := make([]corev1.VolumeMount, 0, len(container.VolumeMounts))
+	for _, m := range container.VolumeMounts {
+		if strings.HasPrefix(m.MountPath, preprocessorInputPath+"/") {
+			sourceMounts = append(sourceMounts, m)
+		} else {
+			mounts = append(mounts, m)
+		}
+	}
+
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: preprocessorSourcesVolume,
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	})
+
+	spec.InitContainers = append(spec.InitContainers, corev1.Container{
+		Name:  "preprocessor",
+		Image: t.Image,
+		Env: []corev1.EnvVar{
+			{Name: "PREPROCESSOR_INPUT", Value: preprocessorInputPath},
+			{Name: "PREPROCESSOR_OUTPUT", Value: preprocessorOutputPath},
+		},
+		VolumeMounts: append(sourceMounts, corev1.VolumeMount{
+			Name:      preprocessorSourcesVolume,
+			MountPath: preprocessorOutputPath,
+		}),
+	})
+
+	container.VolumeMounts = append(mounts, corev1.VolumeMount{
+		Name:      preprocessorSourcesVolume,
+		MountPath: preprocessorInputPath,
+	})
+}
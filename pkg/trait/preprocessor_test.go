@@ -0,0 +1,149 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPreprocessorSources(t *testing.T) {
+	e := &Environment{
+		Integration: &v1alpha1.Integration{
+			Spec: v1alpha1.IntegrationSpec{
+				Sources: []v1alpha1.SourceSpec{
+					{
+						DataSpec: v1alpha1.DataSpec{
+							Name:    "routes.groovy",
+							Content: "from('timer:[[ .name ]]').to('log:info')\n// camel-k:begin:test\nfrom('direct:test').to('mock:out')\n// camel-k:end:test\n",
+						},
+					},
+				},
+			},
+			Status: v1alpha1.IntegrationStatus{
+				Phase: v1alpha1.IntegrationPhaseDeploying,
+			},
+		},
+	}
+
+	trait := newPreprocessorTrait()
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	trait.Enabled = &[]bool{true}[0]
+	trait.Values = "name=tick"
+	trait.StripTags = "test"
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Equal(t, "from('timer:tick').to('log:info')\n", e.Integration.Spec.Sources[0].Content)
+}
+
+func TestPreprocessorInitialPhase(t *testing.T) {
+	e := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes,
+		"from('timer:tick').to('kafka:out')\n// camel-k:begin:test\nfrom('direct:test').to('mock:out')\n// camel-k:end:test\n")
+	e.Integration.Status.Phase = v1alpha1.IntegrationPhaseInitial
+
+	trait := newPreprocessorTrait()
+	trait.Enabled = &[]bool{true}[0]
+	trait.StripTags = "test"
+	trait.Image = "preprocessor"
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	assert.Nil(t, trait.Apply(e))
+	assert.Empty(t, e.PostProcessors)
+
+	// the dependencies are computed from the transformed sources
+	dependencies := newDependenciesTrait()
+	enabled, err = dependencies.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	assert.Nil(t, dependencies.Apply(e))
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:kafka")
+	assert.NotContains(t, e.Integration.Status.Dependencies, "camel:mock")
+
+	ordered, err := orderTraits([]Trait{dependencies, trait})
+	assert.Nil(t, err)
+	assert.Equal(t, []Trait{trait, dependencies}, ordered)
+}
+
+func TestPreprocessorImage(t *testing.T) {
+	deployment := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "my-integration",
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "i-source-000", MountPath: "/etc/camel/sources/i-source-000"},
+								{Name: "integration-properties", MountPath: "/etc/camel/conf"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	e := &Environment{
+		Integration: &v1alpha1.Integration{
+			Status: v1alpha1.IntegrationStatus{
+				Phase: v1alpha1.IntegrationPhaseDeploying,
+			},
+		},
+		Platform: &v1alpha1.IntegrationPlatform{
+			Spec: v1alpha1.IntegrationPlatformSpec{
+				Cluster: v1alpha1.IntegrationPlatformClusterKubernetes,
+			},
+		},
+		Resources: kubernetes.NewCollection(&deployment),
+	}
+
+	trait := newPreprocessorTrait()
+	trait.Enabled = &[]bool{true}[0]
+	trait.Image = "acme/preprocessor:1.0"
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Len(t, e.PostProcessors, 1)
+	assert.Nil(t, e.PostProcessors[0](e))
+
+	spec := deployment.Spec.Template.Spec
+	assert.Len(t, spec.InitContainers, 1)
+	assert.Equal(t, "acme/preprocessor:1.0", spec.InitContainers[0].Image)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "i-source-000", MountPath: "/etc/camel/sources/i-source-000"},
+		{Name: preprocessorSourcesVolume, MountPath: "/etc/camel/preprocessed"},
+	}, spec.InitContainers[0].VolumeMounts)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "integration-properties", MountPath: "/etc/camel/conf"},
+		{Name: preprocessorSourcesVolume, MountPath: "/etc/camel/sources"},
+	}, spec.Containers[0].VolumeMounts)
+	assert.Equal(t, preprocessorSourcesVolume, spec.Volumes[0].Name)
+}
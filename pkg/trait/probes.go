@@ -45,6 +45,14 @@ type probesTrait struct {
 	ReadinessPeriod           int32  `property:"readiness-period"`
 	ReadinessSuccessThreshold int32  `property:"readiness-success-threshold"`
 	ReadinessFailureThreshold int32  `property:"readiness-failure-threshold"`
+	// The startup window covers the JVM warmup, which may take minutes for integrations having
+	// large classpaths, giving them failure-threshold * period seconds before the liveness probe kicks in
+	StartupPeriod           int32 `property:"startup-period"`
+	StartupFailureThreshold int32 `property:"startup-failure-threshold"`
+	// The number of dependencies above which the slow start failure threshold applies, unless
+	// the startup failure threshold is set explicitly
+	SlowStartDependencies     int   `property:"slow-start-dependencies"`
+	SlowStartFailureThreshold int32 `property:"slow-start-failure-threshold"`
 }
 
 func newProbesTrait() *probesTrait {
@@ -53,6 +61,10 @@ func newProbesTrait() *probesTrait {
 		BindHost:  "0.0.0.0",
 		BindPort:  8081,
 		Path:      "/health",
+
+		StartupPeriod:             10,
+		SlowStartDependencies:     50,
+		SlowStartFailureThreshold: 30,
 	}
 }
 
@@ -86,12 +98,21 @@ func (t *probesTrait) Apply(e *Environment) error {
 	}
 
 	if e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying) {
+		window := t.startupWindow(e.Integration)
+
 		e.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
 			if len(deployment.Spec.Template.Spec.Containers) != 1 {
 				return
 			}
 
-			deployment.Spec.Template.Spec.Containers[0].LivenessProbe = t.newLivenessProbe()
+			liveness := t.newLivenessProbe()
+			// The Kubernetes API in use predates the startupProbe container field, so the startup
+			// window is enforced by holding off the liveness probe until it has elapsed
+			if liveness.InitialDelaySeconds < window {
+				liveness.InitialDelaySeconds = window
+			}
+
+			deployment.Spec.Template.Spec.Containers[0].LivenessProbe = liveness
 			deployment.Spec.Template.Spec.Containers[0].ReadinessProbe = t.newReadinessProbe()
 		})
 	}
@@ -136,3 +157,18 @@ func (t *probesTrait) newReadinessProbe() *corev1.Probe {
 
 	return &p
 }
+
+// startupWindow returns the seconds the integration is given to start, i.e. the startup failure threshold
+// times the startup period, falling back to the slow start failure threshold for integrations having a
+// large number of dependencies. A zero window means no startup probing.
+func (t *probesTrait) startupWindow(integration *v1alpha1.Integration) int32 {
+	threshold := t.StartupFailureThreshold
+	if threshold <= 0 && t.SlowStartDependencies > 0 && len(integration.Status.Dependencies) >= t.SlowStartDependencies {
+		threshold = t.SlowStartFailureThreshold
+	}
+	if threshold <= 0 || t.StartupPeriod <= 0 {
+		return 0
+	}
+
+	return t.StartupPeriod * threshold
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package trait
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
@@ -98,6 +99,72 @@ func TestProbesOnDeployment(t *testing.T) {
 	assert.Equal(t, int32(1234), target.Spec.Template.Spec.Containers[0].LivenessProbe.TimeoutSeconds)
 }
 
+func TestProbesStartupWindow(t *testing.T) {
+	newEnvironment := func(target *appsv1.Deployment, dependencies int) *Environment {
+		integration := v1alpha1.Integration{
+			Status: v1alpha1.IntegrationStatus{
+				Phase: v1alpha1.IntegrationPhaseDeploying,
+			},
+		}
+		for i := 0; i < dependencies; i++ {
+			integration.Status.Dependencies = append(integration.Status.Dependencies, fmt.Sprintf("mvn:org.acme:lib-%d", i))
+		}
+		return &Environment{
+			Resources:   kubernetes.NewCollection(target),
+			Integration: &integration,
+		}
+	}
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	enabled := true
+
+	// explicit startup failure threshold
+	target := newDeployment()
+	tr := newProbesTrait()
+	tr.Enabled = &enabled
+	tr.LivenessInitialDelay = 5
+	tr.StartupFailureThreshold = 12
+	assert.Nil(t, tr.Apply(newEnvironment(target, 1)))
+	assert.Equal(t, int32(120), target.Spec.Template.Spec.Containers[0].LivenessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(0), target.Spec.Template.Spec.Containers[0].ReadinessProbe.InitialDelaySeconds)
+
+	// large classpath
+	target = newDeployment()
+	tr = newProbesTrait()
+	tr.Enabled = &enabled
+	assert.Nil(t, tr.Apply(newEnvironment(target, tr.SlowStartDependencies)))
+	assert.Equal(t, int32(300), target.Spec.Template.Spec.Containers[0].LivenessProbe.InitialDelaySeconds)
+
+	// small classpath
+	target = newDeployment()
+	tr = newProbesTrait()
+	tr.Enabled = &enabled
+	tr.LivenessInitialDelay = 5
+	assert.Nil(t, tr.Apply(newEnvironment(target, 3)))
+	assert.Equal(t, int32(5), target.Spec.Template.Spec.Containers[0].LivenessProbe.InitialDelaySeconds)
+
+	// a longer liveness initial delay is kept
+	target = newDeployment()
+	tr = newProbesTrait()
+	tr.Enabled = &enabled
+	tr.LivenessInitialDelay = 600
+	tr.StartupFailureThreshold = 12
+	assert.Nil(t, tr.Apply(newEnvironment(target, 1)))
+	assert.Equal(t, int32(600), target.Spec.Template.Spec.Containers[0].LivenessProbe.InitialDelaySeconds)
+}
+
 func TestProbesOnKnativeService(t *testing.T) {
 	target := serving.Service{
 		Spec: serving.ServiceSpec{
@@ -123,6 +123,15 @@ func (t *prometheusTrait) getServiceMonitorFor(e *Environment) (*monitoringv1.Se
 	}
 	labels["camel.apache.org/integration"] = e.Integration.Name
 
+	// the routes are labeled so that they can be selected by Prometheus
+	routes, err := routeLabels(t.ctx, t.client, e, false)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range routes {
+		labels[k] = v
+	}
+
 	smt := monitoringv1.ServiceMonitor{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "ServiceMonitor",
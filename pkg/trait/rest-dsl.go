@@ -37,6 +37,7 @@ import (
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/util/defaults"
 	"github.com/apache/camel-k/pkg/util/maven"
+	"github.com/apache/camel-k/pkg/util/proxy"
 )
 
 type restDslTrait struct {
@@ -123,6 +124,8 @@ func (t *restDslTrait) Apply(e *Environment) error {
 		}
 		if settings != "" {
 			mc.SettingsData = []byte(settings)
+		} else if mc.Settings, err = proxy.MavenSettings(e.Platform.Spec.Build.Proxy); err != nil {
+			return err
 		}
 
 		err = maven.Run(mc)
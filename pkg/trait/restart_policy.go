@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"strconv"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// The restart-policy trait stops the endless restarts of integrations failing at startup: once the integration
+// container has been restarted more than the given number of times while crash looping, the operator sets the
+// integration in the Error phase with the exception extracted from the container logs, and optionally scales it to zero.
+type restartPolicyTrait struct {
+	BaseTrait   `property:",squash"`
+	MaxRestarts int  `property:"max-restarts"`
+	ScaleToZero bool `property:"scale-to-zero"`
+}
+
+func newRestartPolicyTrait() *restartPolicyTrait {
+	return &restartPolicyTrait{
+		BaseTrait:   newBaseTrait("restart-policy"),
+		MaxRestarts: 5,
+	}
+}
+
+func (t *restartPolicyTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	return e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying), nil
+}
+
+func (t *restartPolicyTrait) Apply(e *Environment) error {
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		environment.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+			if deployment.Annotations == nil {
+				deployment.Annotations = make(map[string]string)
+			}
+			deployment.Annotations[v1alpha1.IntegrationMaxRestartsAnnotation] = strconv.Itoa(t.MaxRestarts)
+			deployment.Annotations[v1alpha1.IntegrationScaleToZeroAnnotation] = strconv.FormatBool(t.ScaleToZero)
+
+			// the kubelet reports the last lines of the logs of the failed container,
+			// the startup exception being extracted from them
+			for i := range deployment.Spec.Template.Spec.Containers {
+				container := &deployment.Spec.Template.Spec.Containers[i]
+				if container.Name == environment.Integration.Name {
+					container.TerminationMessagePolicy = corev1.TerminationMessageFallbackToLogsOnError
+				}
+			}
+		})
+		return nil
+	})
+
+	return nil
+}
@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRestartPolicy(t *testing.T) {
+	deployment := appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "my-integration"},
+					},
+				},
+			},
+		},
+	}
+
+	e := &Environment{
+		Integration: &v1alpha1.Integration{
+			Status: v1alpha1.IntegrationStatus{
+				Phase: v1alpha1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(&deployment),
+	}
+	e.Integration.Name = "my-integration"
+
+	trait := newRestartPolicyTrait()
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	trait.Enabled = &[]bool{true}[0]
+	trait.ScaleToZero = true
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Len(t, e.PostProcessors, 1)
+	assert.Nil(t, e.PostProcessors[0](e))
+
+	assert.Equal(t, "5", deployment.Annotations[v1alpha1.IntegrationMaxRestartsAnnotation])
+	assert.Equal(t, "true", deployment.Annotations[v1alpha1.IntegrationScaleToZeroAnnotation])
+	assert.Equal(t, corev1.TerminationMessageFallbackToLogsOnError, deployment.Spec.Template.Spec.Containers[0].TerminationMessagePolicy)
+}
@@ -39,6 +39,8 @@ type routeTrait struct {
 	TLSCACertificate                 string `property:"tls-ca-certificate"`
 	TLSDestinationCACertificate      string `property:"tls-destination-ca-certificate"`
 	TLSInsecureEdgeTerminationPolicy string `property:"tls-insecure-edge-termination-policy"`
+	Paths                            string `property:"paths"`
+	PathAnnotations                  string `property:"path-annotations"`
 	service                          *corev1.Service
 }
 
@@ -76,7 +78,27 @@ func (t *routeTrait) Apply(e *Environment) error {
 		return nil
 	}
 
-	e.Resources.Add(t.getRouteFor(t.service))
+	paths, err := exposedPaths(t.ctx, t.client, e, t.Paths)
+	if err != nil {
+		return err
+	}
+	annotations, err := parsePathAnnotations(t.PathAnnotations)
+	if err != nil {
+		return err
+	}
+
+	// without host, each route would get its own generated host, so a single route named
+	// after the service keeps exposing all the paths on the same host
+	if len(paths) == 0 || t.Host == "" {
+		e.Resources.Add(t.getRouteFor(t.service, "", annotations["/"]))
+		return nil
+	}
+
+	// a route can only match a single path
+	for _, path := range paths {
+		e.Resources.Add(t.getRouteFor(t.service, path, annotations[path]))
+	}
+
 	return nil
 }
 
@@ -94,15 +116,16 @@ func (t *routeTrait) getTargetService(e *Environment) (service *corev1.Service)
 	return
 }
 
-func (t *routeTrait) getRouteFor(service *corev1.Service) *routev1.Route {
+func (t *routeTrait) getRouteFor(service *corev1.Service, path string, annotations map[string]string) *routev1.Route {
 	route := routev1.Route{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Route",
 			APIVersion: routev1.SchemeGroupVersion.String(),
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      service.Name,
-			Namespace: service.Namespace,
+			Name:        pathResourceName(service.Name, path),
+			Namespace:   service.Namespace,
+			Annotations: annotations,
 		},
 		Spec: routev1.RouteSpec{
 			Port: &routev1.RoutePort{
@@ -113,6 +136,7 @@ func (t *routeTrait) getRouteFor(service *corev1.Service) *routev1.Route {
 				Name: service.Name,
 			},
 			Host: t.Host,
+			Path: path,
 			TLS:  t.getTLSConfig(),
 		},
 	}
@@ -77,7 +77,7 @@ func createTestRouteEnvironment(t *testing.T) *Environment {
 				Name:      "test-i",
 				Namespace: "test-ns",
 				Labels: map[string]string{
-					"camel.apache.org/integration": "test-i",
+					"camel.apache.org/integration":  "test-i",
 					"camel.apache.org/service.type": "user",
 				},
 			},
@@ -135,3 +135,84 @@ func TestRoute_TLS(t *testing.T) {
 	assert.NotNil(t, route.Spec.TLS)
 	assert.Equal(t, routev1.TLSTerminationEdge, route.Spec.TLS.Termination)
 }
+
+func TestRoute_RestPaths(t *testing.T) {
+	environment := createTestRouteEnvironment(t)
+	traitsCatalog := environment.Catalog
+
+	environment.Integration.Spec.Sources = []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "Rest.java",
+				Content: `rest("/api/orders").get("/{id}").to("direct:order"); rest("/api/items").get().to("direct:item");`,
+			},
+			Language: v1alpha1.LanguageJavaSource,
+		},
+	}
+
+	err := traitsCatalog.apply(environment)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, environment.GetTrait(ID("route")))
+
+	// without host, the paths are exposed by the route named after the service
+	routes := 0
+	environment.Resources.VisitRoute(func(r *routev1.Route) {
+		routes++
+	})
+	assert.Equal(t, 1, routes)
+
+	route := environment.Resources.GetRoute(func(r *routev1.Route) bool {
+		return r.ObjectMeta.Name == "test-i"
+	})
+	assert.NotNil(t, route)
+	assert.Empty(t, route.Spec.Path)
+}
+
+func TestRoute_RestPathsWithHost(t *testing.T) {
+	environment := createTestRouteEnvironment(t)
+	traitsCatalog := environment.Catalog
+
+	environment.Integration.Spec.Sources = []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name: "Rest.java",
+				Content: `restConfiguration().contextPath("/services");
+					rest("/api/orders").get("/{id}").to("direct:order"); rest("/api/items").get().to("direct:item");`,
+			},
+			Language: v1alpha1.LanguageJavaSource,
+		},
+	}
+	environment.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"route": {
+			Configuration: map[string]string{
+				"host":             "api.example.com",
+				"path-annotations": "/services/api/orders:haproxy.router.openshift.io/timeout=60s",
+			},
+		},
+	}
+
+	err := traitsCatalog.apply(environment)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, environment.GetTrait(ID("route")))
+
+	assert.Nil(t, environment.Resources.GetRoute(func(r *routev1.Route) bool {
+		return r.ObjectMeta.Name == "test-i"
+	}))
+
+	orders := environment.Resources.GetRoute(func(r *routev1.Route) bool {
+		return r.Spec.Path == "/services/api/orders"
+	})
+	assert.NotNil(t, orders)
+	assert.Equal(t, pathResourceName("test-i", "/services/api/orders"), orders.Name)
+	assert.Equal(t, "api.example.com", orders.Spec.Host)
+	assert.Equal(t, "60s", orders.Annotations["haproxy.router.openshift.io/timeout"])
+
+	items := environment.Resources.GetRoute(func(r *routev1.Route) bool {
+		return r.Spec.Path == "/services/api/items"
+	})
+	assert.NotNil(t, items)
+	assert.Equal(t, pathResourceName("test-i", "/services/api/items"), items.Name)
+	assert.Empty(t, items.Annotations)
+}
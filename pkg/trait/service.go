@@ -34,12 +34,15 @@ type serviceTrait struct {
 	Port int   `property:"port"`
 }
 
-const httpPortName = "http"
+const (
+	httpPortName       = "http"
+	defaultServicePort = 8080
+)
 
 func newServiceTrait() *serviceTrait {
 	return &serviceTrait{
 		BaseTrait: newBaseTrait("service"),
-		Port:      8080,
+		Port:      defaultServicePort,
 	}
 }
 
@@ -61,6 +64,10 @@ func (t *serviceTrait) Configure(e *Environment) (bool, error) {
 		if !meta.RequiresHTTPService {
 			return false, nil
 		}
+		// listen to the port the REST DSL is configured with, unless explicitly set
+		if meta.RestPort > 0 && t.Port == defaultServicePort {
+			t.Port = meta.RestPort
+		}
 	}
 
 	return true, nil
@@ -87,6 +94,15 @@ func (t *serviceTrait) Apply(e *Environment) (err error) {
 	// Mark the service as a user service
 	svc.Labels["camel.apache.org/service.type"] = "user"
 
+	// Label the service with the routes it exposes
+	labels, err := routeLabels(t.ctx, t.client, e, true)
+	if err != nil {
+		return err
+	}
+	for k, v := range labels {
+		svc.Labels[k] = v
+	}
+
 	// Register a post processor to add a container port to the integration deployment
 	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
 		var container *corev1.Container
@@ -0,0 +1,214 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	"github.com/pkg/errors"
+
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	serviceAccountTokenVolume = "i-service-account-token"
+
+	// ServiceAccountAWSRoleAnnotation is the annotation the EKS pod identity webhook uses to inject
+	// the credentials of the IAM role into the pods (IRSA)
+	ServiceAccountAWSRoleAnnotation = "eks.amazonaws.com/role-arn"
+	// ServiceAccountGCPAnnotation binds the service account to a GCP service account through GKE Workload Identity
+	ServiceAccountGCPAnnotation = "iam.gke.io/gcp-service-account"
+
+	// the minimum validity of projected tokens accepted by the API server
+	serviceAccountMinTokenExpiration = 600
+)
+
+var serviceAccountTokenFileRegexp = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// The service-account trait runs the integration with a dedicated service account, bound to cloud identities (EKS IAM
+// roles for service accounts, GKE Workload Identity), and optionally mounts projected service account tokens with custom
+// audiences, so that the routes can call cloud services without static credentials.
+type serviceAccountTrait struct {
+	BaseTrait         `property:",squash"`
+	Name              string `property:"name"`
+	AWSRoleARN        string `property:"aws-role-arn"`
+	GCPServiceAccount string `property:"gcp-service-account"`
+	TokenAudiences    string `property:"token-audiences"`
+	TokenExpiration   int64  `property:"token-expiration"`
+	TokenPath         string `property:"token-path"`
+}
+
+func newServiceAccountTrait() *serviceAccountTrait {
+	return &serviceAccountTrait{
+		BaseTrait:       newBaseTrait("service-account"),
+		TokenExpiration: 3600,
+		TokenPath:       "/var/run/secrets/tokens",
+	}
+}
+
+func (t *serviceAccountTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying) {
+		return false, nil
+	}
+
+	if t.TokenAudiences != "" && t.TokenExpiration < serviceAccountMinTokenExpiration {
+		return false, fmt.Errorf("the expiration of projected tokens must be at least %d seconds", serviceAccountMinTokenExpiration)
+	}
+
+	return true, nil
+}
+
+func (t *serviceAccountTrait) Apply(e *Environment) error {
+	audiences := t.audiences()
+	if len(audiences) > 0 {
+		strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+		if err != nil {
+			return err
+		}
+		if strategy != ControllerStrategyDeployment {
+			return errors.New("projected service account tokens are only supported by deployments")
+		}
+	}
+
+	sa := t.newServiceAccount(e)
+	e.Resources.Add(sa)
+
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		environment.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+			spec := &deployment.Spec.Template.Spec
+			spec.ServiceAccountName = sa.Name
+			if len(audiences) > 0 {
+				t.mountTokens(environment.Integration, audiences, spec)
+			}
+		})
+		environment.Resources.VisitKnativeConfigurationSpec(func(cs *serving.ConfigurationSpec) {
+			cs.RevisionTemplate.Spec.ServiceAccountName = sa.Name
+		})
+		return nil
+	})
+
+	return nil
+}
+
+func (t *serviceAccountTrait) serviceAccountName(e *Environment) string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return e.Integration.Name
+}
+
+func (t *serviceAccountTrait) audiences() []string {
+	audiences := make([]string, 0)
+	for _, audience := range strings.Split(t.TokenAudiences, ",") {
+		if audience = strings.TrimSpace(audience); audience != "" {
+			audiences = append(audiences, audience)
+		}
+	}
+	return audiences
+}
+
+func (t *serviceAccountTrait) newServiceAccount(e *Environment) *corev1.ServiceAccount {
+	annotations := make(map[string]string)
+	if t.AWSRoleARN != "" {
+		annotations[ServiceAccountAWSRoleAnnotation] = t.AWSRoleARN
+	}
+	if t.GCPServiceAccount != "" {
+		annotations[ServiceAccountGCPAnnotation] = t.GCPServiceAccount
+	}
+
+	sa := corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      t.serviceAccountName(e),
+			Namespace: e.Integration.Namespace,
+			Labels: map[string]string{
+				"camel.apache.org/integration": e.Integration.Name,
+			},
+			Annotations: annotations,
+		},
+	}
+
+	// The images of the kits shared by the platform are pulled with the propagated secret
+	if e.IntegrationKit != nil && e.IntegrationKit.Annotations[v1alpha1.IntegrationKitSharedFromAnnotation] != "" &&
+		e.Platform != nil && e.Platform.Spec.Build.SharedKits != nil && e.Platform.Spec.Build.SharedKits.PullSecret != "" {
+		sa.ImagePullSecrets = []corev1.LocalObjectReference{
+			{Name: e.Platform.Spec.Build.SharedKits.PullSecret},
+		}
+	}
+
+	return &sa
+}
+
+// mountTokens mounts a projected token per audience in the integration container, the token file being named
+// after the audience
+func (t *serviceAccountTrait) mountTokens(integration *v1alpha1.Integration, audiences []string, spec *corev1.PodSpec) {
+	sources := make([]corev1.VolumeProjection, 0, len(audiences))
+	for _, audience := range audiences {
+		expiration := t.TokenExpiration
+		sources = append(sources, corev1.VolumeProjection{
+			ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+				Audience:          audience,
+				ExpirationSeconds: &expiration,
+				Path:              serviceAccountTokenFile(audience),
+			},
+		})
+	}
+
+	spec.Volumes = append(spec.Volumes, corev1.Volume{
+		Name: serviceAccountTokenVolume,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: sources,
+			},
+		},
+	})
+
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == integration.Name {
+			spec.Containers[i].VolumeMounts = append(spec.Containers[i].VolumeMounts, corev1.VolumeMount{
+				Name:      serviceAccountTokenVolume,
+				MountPath: t.TokenPath,
+				ReadOnly:  true,
+			})
+		}
+	}
+}
+
+// serviceAccountTokenFile returns the name of the token file of the given audience,
+// e.g. sts.amazonaws.com or https-iam.googleapis.com
+func serviceAccountTokenFile(audience string) string {
+	file := strings.Trim(serviceAccountTokenFileRegexp.ReplaceAllString(audience, "-"), "-.")
+	if file == "" {
+		return "token"
+	}
+	return file
+}
@@ -0,0 +1,165 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestServiceAccountWorkloadIdentity(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"service-account": {
+			Configuration: map[string]string{
+				"enabled":             "true",
+				"aws-role-arn":        "arn:aws:iam::123456789012:role/camel",
+				"gcp-service-account": "camel@project.iam.gserviceaccount.com",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	sa := findServiceAccount(res)
+	assert.NotNil(t, sa)
+	assert.Equal(t, TestDeployment, sa.Name)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/camel", sa.Annotations[ServiceAccountAWSRoleAnnotation])
+	assert.Equal(t, "camel@project.iam.gserviceaccount.com", sa.Annotations[ServiceAccountGCPAnnotation])
+
+	d := res.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+	assert.Equal(t, TestDeployment, d.Spec.Template.Spec.ServiceAccountName)
+	for _, v := range d.Spec.Template.Spec.Volumes {
+		assert.NotEqual(t, serviceAccountTokenVolume, v.Name)
+	}
+}
+
+func TestServiceAccountSharedKitPullSecret(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"service-account": {
+			Configuration: map[string]string{
+				"enabled": "true",
+			},
+		},
+	}
+	env.Platform.Spec.Build.SharedKits = &v1alpha1.IntegrationPlatformSharedKitsSpec{
+		Namespace:  "kits",
+		PullSecret: "kits-pull-secret",
+	}
+
+	sa := findServiceAccount(processTestEnv(t, env))
+	assert.NotNil(t, sa)
+	assert.Empty(t, sa.ImagePullSecrets)
+
+	env = createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"service-account": {
+			Configuration: map[string]string{
+				"enabled": "true",
+			},
+		},
+	}
+	env.Platform.Spec.Build.SharedKits = &v1alpha1.IntegrationPlatformSharedKitsSpec{
+		Namespace:  "kits",
+		PullSecret: "kits-pull-secret",
+	}
+	env.IntegrationKit.Annotations = map[string]string{
+		v1alpha1.IntegrationKitSharedFromAnnotation: "kits/kit-0123456789abcdef0123",
+	}
+
+	sa = findServiceAccount(processTestEnv(t, env))
+	assert.NotNil(t, sa)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "kits-pull-secret"}}, sa.ImagePullSecrets)
+}
+
+func TestServiceAccountProjectedTokens(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"service-account": {
+			Configuration: map[string]string{
+				"enabled":         "true",
+				"name":            "camel-routes",
+				"token-audiences": "sts.amazonaws.com, https://iam.googleapis.com",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	sa := findServiceAccount(res)
+	assert.NotNil(t, sa)
+	assert.Equal(t, "camel-routes", sa.Name)
+	assert.Empty(t, sa.Annotations)
+
+	d := res.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+	assert.Equal(t, "camel-routes", d.Spec.Template.Spec.ServiceAccountName)
+
+	var volume *corev1.Volume
+	for i := range d.Spec.Template.Spec.Volumes {
+		if d.Spec.Template.Spec.Volumes[i].Name == serviceAccountTokenVolume {
+			volume = &d.Spec.Template.Spec.Volumes[i]
+		}
+	}
+	assert.NotNil(t, volume)
+	assert.Len(t, volume.Projected.Sources, 2)
+	assert.Equal(t, "sts.amazonaws.com", volume.Projected.Sources[0].ServiceAccountToken.Audience)
+	assert.Equal(t, "sts.amazonaws.com", volume.Projected.Sources[0].ServiceAccountToken.Path)
+	assert.Equal(t, int64(3600), *volume.Projected.Sources[0].ServiceAccountToken.ExpirationSeconds)
+	assert.Equal(t, "https://iam.googleapis.com", volume.Projected.Sources[1].ServiceAccountToken.Audience)
+	assert.Equal(t, "https-iam.googleapis.com", volume.Projected.Sources[1].ServiceAccountToken.Path)
+
+	assert.Contains(t, d.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+		Name:      serviceAccountTokenVolume,
+		MountPath: "/var/run/secrets/tokens",
+		ReadOnly:  true,
+	})
+}
+
+func TestServiceAccountTokenExpiration(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+
+	trait := newServiceAccountTrait()
+	enabled := true
+	trait.Enabled = &enabled
+	trait.TokenAudiences = "sts.amazonaws.com"
+	trait.TokenExpiration = 60
+
+	_, err := trait.Configure(env)
+	assert.NotNil(t, err)
+}
+
+func findServiceAccount(resources *kubernetes.Collection) *corev1.ServiceAccount {
+	var sa *corev1.ServiceAccount
+	resources.Visit(func(o runtime.Object) {
+		if s, ok := o.(*corev1.ServiceAccount); ok {
+			sa = s
+		}
+	})
+	return sa
+}
@@ -0,0 +1,337 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
+)
+
+const (
+	sharedVolumePrefix    = "i-shared-"
+	sharedVolumeBasePath  = "/var/camel/shared"
+	sharedVolumeLabel     = "camel.apache.org/shared-volume.group"
+	sharedVolumeReadOnly  = "ro"
+	sharedVolumeReadWrite = "rw"
+)
+
+// The shared-volume trait mounts persistent volume claims shared by a group of integrations, e.g. so that a producer
+// writes files that a consumer reads with file endpoints. The claims are created by the first integration of the group
+// that is deployed, and are not owned by any integration, so that they outlive the integrations of the group.
+type sharedVolumeTrait struct {
+	BaseTrait    `property:",squash"`
+	Claims       string `property:"claims"`
+	Group        string `property:"group"`
+	Size         string `property:"size"`
+	StorageClass string `property:"storage-class"`
+	AccessMode   string `property:"access-mode"`
+}
+
+// sharedClaim is a claim mounted in the integration container
+type sharedClaim struct {
+	name     string
+	path     string
+	readOnly bool
+}
+
+func newSharedVolumeTrait() *sharedVolumeTrait {
+	return &sharedVolumeTrait{
+		BaseTrait:  newBaseTrait("shared-volume"),
+		Size:       "1Gi",
+		AccessMode: string(corev1.ReadWriteMany),
+	}
+}
+
+func (t *sharedVolumeTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying) {
+		return false, nil
+	}
+
+	if strings.TrimSpace(t.Claims) == "" {
+		return false, errors.New("shared volume claims are required, e.g. shared-volume.claims={{group}}-inbox:/data/inbox")
+	}
+
+	return true, nil
+}
+
+func (t *sharedVolumeTrait) Apply(e *Environment) error {
+	strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+	if err != nil {
+		return err
+	}
+	if strategy != ControllerStrategyDeployment {
+		return errors.New("shared volumes are only supported by deployments")
+	}
+
+	claims, err := t.parseClaims(e.Integration)
+	if err != nil {
+		return err
+	}
+	if err := t.validateAccessMode(claims); err != nil {
+		return err
+	}
+	if err := t.validateEndpoints(e, claims); err != nil {
+		return err
+	}
+
+	for _, claim := range claims {
+		if err := t.ensureClaim(e, claim.name); err != nil {
+			return err
+		}
+	}
+
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		environment.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+			t.mountVolumes(environment.Integration, claims, deployment)
+		})
+		return nil
+	})
+
+	return nil
+}
+
+// parseClaims parses the comma separated claims, given as name[:path[:ro|rw]]. The names can contain the {{group}}
+// and {{integration}} placeholders, and the claims are mounted read-write under /var/camel/shared by default
+func (t *sharedVolumeTrait) parseClaims(integration *v1alpha1.Integration) ([]sharedClaim, error) {
+	claims := make([]sharedClaim, 0)
+	paths := make(map[string]string)
+	for _, item := range strings.Split(t.Claims, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.Split(item, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid shared volume claim %s, expected name[:path[:ro|rw]]", item)
+		}
+
+		name, err := t.claimName(parts[0], integration)
+		if err != nil {
+			return nil, err
+		}
+		claim := sharedClaim{
+			name: name,
+			path: path.Join(sharedVolumeBasePath, name),
+		}
+		if len(parts) > 1 && parts[1] != "" {
+			if !path.IsAbs(parts[1]) {
+				return nil, fmt.Errorf("the mount path %s of shared volume claim %s is not absolute", parts[1], name)
+			}
+			claim.path = path.Clean(parts[1])
+		}
+		if len(parts) > 2 {
+			switch parts[2] {
+			case sharedVolumeReadOnly:
+				claim.readOnly = true
+			case sharedVolumeReadWrite:
+			default:
+				return nil, fmt.Errorf("invalid mode %s of shared volume claim %s, expected ro or rw", parts[2], name)
+			}
+		}
+		if other, ok := paths[claim.path]; ok {
+			return nil, fmt.Errorf("shared volume claims %s and %s are mounted at the same path %s", other, name, claim.path)
+		}
+		paths[claim.path] = name
+
+		claims = append(claims, claim)
+	}
+
+	return claims, nil
+}
+
+// claimName renders the given claim name template with the group and the integration names
+func (t *sharedVolumeTrait) claimName(template string, integration *v1alpha1.Integration) (string, error) {
+	if strings.Contains(template, "{{group}}") && t.Group == "" {
+		return "", fmt.Errorf("shared volume claim %s requires the group to be set", template)
+	}
+
+	name := strings.NewReplacer("{{group}}", t.Group, "{{integration}}", integration.Name).Replace(template)
+	if strings.Contains(name, "{{") {
+		return "", fmt.Errorf("unknown placeholder in shared volume claim %s, only {{group}} and {{integration}} are supported", template)
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid shared volume claim name %s: %s", name, strings.Join(errs, ", "))
+	}
+
+	return name, nil
+}
+
+// validateAccessMode checks that the access mode allows the claims to be shared, and to be written when they are
+// mounted read-write
+func (t *sharedVolumeTrait) validateAccessMode(claims []sharedClaim) error {
+	switch corev1.PersistentVolumeAccessMode(t.AccessMode) {
+	case corev1.ReadWriteMany:
+	case corev1.ReadWriteOnce:
+		t.L.Infof("Shared volume claims with the %s access mode can only be shared by pods running on the same node", t.AccessMode)
+	case corev1.ReadOnlyMany:
+		for _, claim := range claims {
+			if !claim.readOnly {
+				return fmt.Errorf("shared volume claim %s is mounted read-write with the %s access mode", claim.name, t.AccessMode)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported shared volume access mode %s", t.AccessMode)
+	}
+
+	return nil
+}
+
+// validateEndpoints checks that the routes do not produce files to the claims mounted read-only
+func (t *sharedVolumeTrait) validateEndpoints(e *Environment, claims []sharedClaim) error {
+	sources, err := kubernetes.ResolveIntegrationSources(t.ctx, t.client, e.Integration, e.Resources)
+	if err != nil {
+		return err
+	}
+
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+	for _, uri := range meta.ToURIs {
+		u, err := uriutil.Parse(uri)
+		if err != nil || u.Scheme != "file" {
+			continue
+		}
+		for _, claim := range claims {
+			if claim.readOnly && (u.Path == claim.path || strings.HasPrefix(u.Path, claim.path+"/")) {
+				return fmt.Errorf("the routes write files to %s, but shared volume claim %s is mounted read-only", uri, claim.name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ensureClaim creates the claim when no integration of the group has created it yet, and checks that an existing
+// claim supports the access mode otherwise
+func (t *sharedVolumeTrait) ensureClaim(e *Environment, name string) error {
+	existing := corev1.PersistentVolumeClaim{}
+	key := k8sclient.ObjectKey{
+		Namespace: e.Integration.Namespace,
+		Name:      name,
+	}
+	err := t.client.Get(t.ctx, key, &existing)
+	if err == nil {
+		for _, mode := range existing.Spec.AccessModes {
+			if mode == corev1.PersistentVolumeAccessMode(t.AccessMode) {
+				return nil
+			}
+		}
+		return fmt.Errorf("shared volume claim %s does not support the %s access mode", name, t.AccessMode)
+	}
+	if !k8serrors.IsNotFound(err) {
+		return err
+	}
+
+	claim, err := t.newPersistentVolumeClaim(e, name)
+	if err != nil {
+		return err
+	}
+	if err := t.client.Create(t.ctx, claim); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return errors.Wrapf(err, "unable to create shared volume claim %s", name)
+	}
+
+	return nil
+}
+
+func (t *sharedVolumeTrait) newPersistentVolumeClaim(e *Environment, name string) (*corev1.PersistentVolumeClaim, error) {
+	size, err := resource.ParseQuantity(t.Size)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid shared volume size %s", t.Size)
+	}
+
+	group := t.Group
+	if group == "" {
+		group = e.Integration.Name
+	}
+
+	claim := corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: e.Integration.Namespace,
+			// not labelled with the integration, so that it's not garbage collected with it
+			Labels: map[string]string{
+				sharedVolumeLabel: group,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.PersistentVolumeAccessMode(t.AccessMode),
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: size,
+				},
+			},
+		},
+	}
+	if t.StorageClass != "" {
+		claim.Spec.StorageClassName = &t.StorageClass
+	}
+
+	return &claim, nil
+}
+
+func (t *sharedVolumeTrait) mountVolumes(integration *v1alpha1.Integration, claims []sharedClaim, deployment *appsv1.Deployment) {
+	spec := &deployment.Spec.Template.Spec
+
+	for i, claim := range claims {
+		volume := fmt.Sprintf("%s%d", sharedVolumePrefix, i)
+		spec.Volumes = append(spec.Volumes, corev1.Volume{
+			Name: volume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claim.name,
+					ReadOnly:  claim.readOnly,
+				},
+			},
+		})
+
+		for j := range spec.Containers {
+			if spec.Containers[j].Name == integration.Name {
+				spec.Containers[j].VolumeMounts = append(spec.Containers[j].VolumeMounts, corev1.VolumeMount{
+					Name:      volume,
+					MountPath: claim.path,
+					ReadOnly:  claim.readOnly,
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,189 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func createSharedVolumeTestEnv(t *testing.T, name string, content string, objects ...runtime.Object) (*sharedVolumeTrait, *Environment) {
+	c, err := test.NewFakeClient(objects...)
+	assert.Nil(t, err)
+
+	trait := newSharedVolumeTrait()
+	trait.Enabled = &[]bool{true}[0]
+	trait.Group = "pipeline"
+	trait.InjectClient(c)
+	trait.InjectContext(context.TODO())
+
+	env := createIntegrationTestEnv(t, name, v1alpha1.IntegrationPhaseDeploying, createGroovyTestSource("routes.groovy", content))
+	env.Resources.Add(&appsv1.Deployment{
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: name},
+					},
+				},
+			},
+		},
+	})
+
+	return trait, env
+}
+
+func TestSharedVolumeConfigure(t *testing.T) {
+	trait, e := createSharedVolumeTestEnv(t, "producer", `from("timer:tick").to("log:info")`)
+	trait.Enabled = nil
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	trait.Enabled = &[]bool{true}[0]
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+
+	trait.Claims = "{{group}}-data"
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	e.Integration.Status.Phase = v1alpha1.IntegrationPhaseInitial
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+}
+
+func TestSharedVolumeClaims(t *testing.T) {
+	trait, e := createSharedVolumeTestEnv(t, "producer", `from("timer:tick").to("log:info")`)
+
+	trait.Claims = "{{group}}-inbox:/data/inbox:ro, {{integration}}-out"
+	claims, err := trait.parseClaims(e.Integration)
+	assert.Nil(t, err)
+	assert.Equal(t, []sharedClaim{
+		{name: "pipeline-inbox", path: "/data/inbox", readOnly: true},
+		{name: "producer-out", path: "/var/camel/shared/producer-out"},
+	}, claims)
+
+	for _, claims := range []string{
+		"Invalid_Name",
+		"{{namespace}}-data",
+		"data:relative/path",
+		"data:/data:rx",
+		"a:/data,b:/data/",
+		"data:/data:ro:more",
+	} {
+		trait.Claims = claims
+		_, err := trait.parseClaims(e.Integration)
+		assert.NotNil(t, err, claims)
+	}
+
+	trait.Group = ""
+	trait.Claims = "{{group}}-data"
+	_, err = trait.parseClaims(e.Integration)
+	assert.NotNil(t, err)
+}
+
+func TestSharedVolumeCreatesAndMountsClaims(t *testing.T) {
+	trait, e := createSharedVolumeTestEnv(t, "producer", `from("timer:tick").to("file:/data/outbox")`)
+	trait.Claims = "{{group}}-outbox:/data/outbox,{{group}}-archive:/data/archive:ro"
+	trait.StorageClass = "nfs"
+
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	assert.Nil(t, trait.Apply(e))
+	assert.Len(t, e.PostProcessors, 1)
+	assert.Nil(t, e.PostProcessors[0](e))
+
+	claim := corev1.PersistentVolumeClaim{}
+	assert.Nil(t, trait.client.Get(context.TODO(), k8sclient.ObjectKey{Namespace: "ns", Name: "pipeline-outbox"}, &claim))
+	assert.Equal(t, "pipeline", claim.Labels[sharedVolumeLabel])
+	assert.Empty(t, claim.OwnerReferences)
+	assert.Equal(t, "nfs", *claim.Spec.StorageClassName)
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, claim.Spec.AccessModes)
+	assert.Equal(t, resource.MustParse("1Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	spec := e.Resources.GetDeployment(func(*appsv1.Deployment) bool { return true }).Spec.Template.Spec
+	assert.Len(t, spec.Volumes, 2)
+	assert.Equal(t, "pipeline-archive", spec.Volumes[1].PersistentVolumeClaim.ClaimName)
+	assert.True(t, spec.Volumes[1].PersistentVolumeClaim.ReadOnly)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "i-shared-0", MountPath: "/data/outbox"},
+		{Name: "i-shared-1", MountPath: "/data/archive", ReadOnly: true},
+	}, spec.Containers[0].VolumeMounts)
+}
+
+func TestSharedVolumeReusesExistingClaim(t *testing.T) {
+	existing := corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "pipeline-inbox",
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+		},
+	}
+
+	trait, e := createSharedVolumeTestEnv(t, "consumer", `from("file:/data/inbox").to("log:info")`, &existing)
+	trait.Claims = "{{group}}-inbox:/data/inbox"
+	assert.NotNil(t, trait.Apply(e))
+
+	trait.AccessMode = string(corev1.ReadWriteOnce)
+	assert.Nil(t, trait.Apply(e))
+}
+
+func TestSharedVolumeValidation(t *testing.T) {
+	trait, e := createSharedVolumeTestEnv(t, "producer", `from("timer:tick").to("file:/data/inbox/orders")`)
+	trait.Claims = "{{group}}-inbox:/data/inbox:ro"
+	assert.NotNil(t, trait.Apply(e))
+
+	trait, e = createSharedVolumeTestEnv(t, "consumer", `from("file:/data/inbox").to("log:info")`)
+	trait.Claims = "{{group}}-inbox:/data/inbox:ro"
+	trait.AccessMode = string(corev1.ReadOnlyMany)
+	assert.Nil(t, trait.Apply(e))
+
+	trait.Claims = "{{group}}-inbox:/data/inbox"
+	assert.NotNil(t, trait.Apply(e))
+
+	trait.AccessMode = "ReadWriteSometimes"
+	assert.NotNil(t, trait.Apply(e))
+
+	trait.AccessMode = string(corev1.ReadWriteMany)
+	e.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"deployer": {Configuration: map[string]string{"kind": "knative-service"}},
+	}
+	assert.NotNil(t, trait.Apply(e))
+}
@@ -0,0 +1,273 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	k8s "k8s.io/client-go/kubernetes"
+	clientscheme "k8s.io/client-go/kubernetes/scheme"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/apache/camel-k/deploy"
+	"github.com/apache/camel-k/pkg/apis"
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/builder"
+	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	"github.com/pkg/errors"
+
+	yaml2 "gopkg.in/yaml.v2"
+)
+
+// TraitSnapshotLabel marks the ConfigMaps holding the snapshots of the trait environments that failed to be applied
+const TraitSnapshotLabel = "camel.apache.org/trait-snapshot"
+
+// TraitSnapshotKey is the key of the snapshot in the ConfigMap holding it
+const TraitSnapshotKey = "snapshot.yaml"
+
+// Snapshot records a trait environment that failed to be applied, i.e. the resources the traits have been applied to,
+// as they were before the application, and the state the environment had reached when the error occurred
+type Snapshot struct {
+	Error           string                        `json:"error"`
+	Integration     *v1alpha1.Integration         `json:"integration,omitempty"`
+	IntegrationKit  *v1alpha1.IntegrationKit      `json:"integrationKit,omitempty"`
+	Platform        *v1alpha1.IntegrationPlatform `json:"platform,omitempty"`
+	TraitsConfigMap *corev1.ConfigMap             `json:"traitsConfigMap,omitempty"`
+	CamelCatalog    *v1alpha1.CamelCatalogSpec    `json:"camelCatalog,omitempty"`
+	Environment     SnapshotEnvironment           `json:"environment"`
+}
+
+// SnapshotEnvironment is the state the trait environment had reached when the error occurred
+type SnapshotEnvironment struct {
+	RuntimeVersion string                   `json:"runtimeVersion,omitempty"`
+	ExecutedTraits []string                 `json:"executedTraits,omitempty"`
+	Steps          []string                 `json:"steps,omitempty"`
+	BuildDir       string                   `json:"buildDir,omitempty"`
+	EnvVars        []corev1.EnvVar          `json:"envVars,omitempty"`
+	Classpath      []string                 `json:"classpath,omitempty"`
+	Resources      []map[string]interface{} `json:"resources,omitempty"`
+}
+
+// newSnapshot records the resources the traits of the given environment are about to be applied to
+func newSnapshot(e *Environment) *Snapshot {
+	s := Snapshot{}
+	if e.Integration != nil {
+		s.Integration = e.Integration.DeepCopy()
+	}
+	if e.IntegrationKit != nil {
+		s.IntegrationKit = e.IntegrationKit.DeepCopy()
+	}
+	if e.Platform != nil {
+		s.Platform = e.Platform.DeepCopy()
+	}
+	if e.TraitsConfigMap != nil {
+		s.TraitsConfigMap = e.TraitsConfigMap.DeepCopy()
+	}
+	return &s
+}
+
+// record completes the snapshot with the error and the state the environment has reached
+func (s *Snapshot) record(e *Environment, err error) error {
+	s.Error = err.Error()
+	if e.CamelCatalog != nil {
+		s.CamelCatalog = e.CamelCatalog.CamelCatalogSpec.DeepCopy()
+	}
+
+	s.Environment = SnapshotEnvironment{
+		RuntimeVersion: e.RuntimeVersion,
+		Steps:          builder.StepIDsFor(e.Steps...),
+		BuildDir:       e.BuildDir,
+		EnvVars:        e.EnvVars,
+	}
+	for _, t := range e.ExecutedTraits {
+		s.Environment.ExecutedTraits = append(s.Environment.ExecutedTraits, string(t.ID()))
+	}
+	if e.Classpath != nil {
+		s.Environment.Classpath = e.Classpath.List()
+	}
+	for _, r := range e.Resources.Items() {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		resource := make(map[string]interface{})
+		if err := json.Unmarshal(data, &resource); err != nil {
+			return err
+		}
+		s.Environment.Resources = append(s.Environment.Resources, resource)
+	}
+
+	return nil
+}
+
+// name returns the name of the integration, or of the kit, the traits have been applied to
+func (s *Snapshot) name() (string, string) {
+	if s.Integration != nil {
+		return s.Integration.Namespace, s.Integration.Name
+	}
+	if s.IntegrationKit != nil {
+		return s.IntegrationKit.Namespace, s.IntegrationKit.Name
+	}
+	return "", ""
+}
+
+// save stores the snapshot either in a ConfigMap of the namespace of the integration, or in a file of the temporary
+// directory, and returns where it has been stored
+func (s *Snapshot) save(ctx context.Context, c client.Client, mode string) (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	data, err = kubernetes.JSONToYAML(data)
+	if err != nil {
+		return "", err
+	}
+
+	namespace, name := s.name()
+
+	switch mode {
+	case platform.TraitSnapshotsConfigMap:
+		cm := corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-trait-snapshot",
+				Namespace: namespace,
+				Labels: map[string]string{
+					TraitSnapshotLabel: "true",
+				},
+			},
+			Data: map[string]string{
+				TraitSnapshotKey: string(data),
+			},
+		}
+		if s.Integration != nil {
+			cm.Labels["camel.apache.org/integration"] = name
+		}
+		if err := kubernetes.ReplaceResource(ctx, c, &cm); err != nil {
+			return "", err
+		}
+		return "configmap " + cm.Namespace + "/" + cm.Name, nil
+	case platform.TraitSnapshotsFile:
+		file := path.Join(os.TempDir(), "camel-k-trait-snapshot-"+namespace+"-"+name+".yaml")
+		if err := ioutil.WriteFile(file, data, 0644); err != nil {
+			return "", err
+		}
+		return file, nil
+	}
+
+	return "", errors.Errorf("unknown trait snapshot destination %s", mode)
+}
+
+// LoadSnapshot decodes a snapshot stored in YAML or JSON
+func LoadSnapshot(data []byte) (*Snapshot, error) {
+	s := Snapshot{}
+	if err := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096).Decode(&s); err != nil {
+		return nil, errors.Wrap(err, "invalid trait snapshot")
+	}
+	if s.Integration == nil && s.IntegrationKit == nil {
+		return nil, errors.New("invalid trait snapshot: neither integration nor kit are set")
+	}
+	if s.Platform == nil {
+		return nil, errors.New("invalid trait snapshot: no integration platform")
+	}
+	return &s, nil
+}
+
+// Replay applies the traits again to the recorded resources, using a client that only knows about them, so
+// that the trait application can be troubleshot locally
+func (s *Snapshot) Replay(ctx context.Context) (*Environment, error) {
+	objects := []runtime.Object{s.Platform.DeepCopy()}
+
+	var integration *v1alpha1.Integration
+	if s.Integration != nil {
+		integration = s.Integration.DeepCopy()
+		objects = append(objects, integration.DeepCopy())
+	}
+	var kit *v1alpha1.IntegrationKit
+	if s.IntegrationKit != nil {
+		kit = s.IntegrationKit.DeepCopy()
+		objects = append(objects, kit.DeepCopy())
+	}
+	if s.TraitsConfigMap != nil {
+		objects = append(objects, s.TraitsConfigMap.DeepCopy())
+	}
+	if s.CamelCatalog == nil {
+		// the failure occurred before the catalog has been loaded, the embedded ones are used
+		for name, content := range deploy.Resources {
+			if strings.HasPrefix(name, "camel-catalog-") {
+				catalog := v1alpha1.CamelCatalog{}
+				if err := yaml2.Unmarshal([]byte(content), &catalog); err != nil {
+					return nil, err
+				}
+				catalog.Namespace = s.Platform.Namespace
+				objects = append(objects, &catalog)
+			}
+		}
+	} else {
+		objects = append(objects, &v1alpha1.CamelCatalog{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: v1alpha1.SchemeGroupVersion.String(),
+				Kind:       v1alpha1.CamelCatalogKind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: s.Platform.Namespace,
+				Name:      "camel-catalog-" + strings.ToLower(s.CamelCatalog.Version),
+			},
+			Spec: *s.CamelCatalog.DeepCopy(),
+		})
+	}
+
+	scheme := clientscheme.Scheme
+	if err := apis.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	c := snapshotClient{
+		Client: fake.NewFakeClientWithScheme(scheme, objects...),
+	}
+
+	return Apply(ctx, &c, integration, kit)
+}
+
+// snapshotClient is the client used to replay a snapshot, only knowing about the recorded resources
+type snapshotClient struct {
+	k8sclient.Client
+	k8s.Interface
+}
+
+// GetScheme --
+func (c *snapshotClient) GetScheme() *runtime.Scheme {
+	return clientscheme.Scheme
+}
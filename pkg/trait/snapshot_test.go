@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/platform"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotOnApplyError(t *testing.T) {
+	defer platform.SetOperatorConfiguration(platform.GetOperatorConfiguration())
+	configuration := platform.DefaultOperatorConfiguration()
+	configuration.TraitSnapshots = platform.TraitSnapshotsConfigMap
+	platform.SetOperatorConfiguration(configuration)
+
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	p := v1alpha1.NewIntegrationPlatform("ns", "camel-k")
+	p.Status.Phase = v1alpha1.IntegrationPlatformPhaseReady
+	p.Spec.Cluster = v1alpha1.IntegrationPlatformClusterKubernetes
+	p.Spec.Profile = v1alpha1.TraitProfileKubernetes
+	p.Spec.Build.CamelVersion = catalog.Version
+
+	integration := v1alpha1.NewIntegration("ns", "my-route")
+	integration.Status.Phase = v1alpha1.IntegrationPhaseDeploying
+	integration.Spec.Sources = []v1alpha1.SourceSpec{
+		{
+			DataSpec: v1alpha1.DataSpec{
+				Name:    "routes.groovy",
+				Content: "from('timer:tick').to('log:info')",
+			},
+		},
+	}
+	integration.Status.Kit = "my-kit"
+
+	kit := v1alpha1.NewIntegrationKit("ns", "my-kit")
+	kit.Status.Phase = v1alpha1.IntegrationKitPhaseReady
+	kit.Status.Image = "registry/my-kit:1"
+	kit.Status.CamelVersion = catalog.Version
+
+	traits := NewTraitsConfigMap("ns", "always", nil)
+
+	c, err := test.NewFakeClient(&p, &integration, &kit, traits)
+	assert.Nil(t, err)
+
+	_, err = Apply(context.TODO(), c, &integration, &kit)
+	assert.NotNil(t, err)
+
+	cm, err := kubernetes.GetConfigMap(context.TODO(), c, "my-route-trait-snapshot", "ns")
+	assert.Nil(t, err)
+	assert.Equal(t, "true", cm.Labels[TraitSnapshotLabel])
+	assert.Equal(t, "my-route", cm.Labels["camel.apache.org/integration"])
+
+	snapshot, err := LoadSnapshot([]byte(cm.Data[TraitSnapshotKey]))
+	assert.Nil(t, err)
+	assert.Contains(t, snapshot.Error, `invalid precedence "always"`)
+	assert.Equal(t, "my-route", snapshot.Integration.Name)
+	assert.Equal(t, "my-kit", snapshot.IntegrationKit.Name)
+	assert.Equal(t, "camel-k", snapshot.Platform.Name)
+	assert.NotNil(t, snapshot.TraitsConfigMap)
+
+	// the replay reproduces the error
+	_, err = snapshot.Replay(context.TODO())
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), `invalid precedence "always"`)
+
+	// and succeeds once the traits configuration is fixed, using the embedded catalogs as the failure occurred
+	// before the catalog has been loaded
+	assert.Nil(t, snapshot.CamelCatalog)
+	snapshot.TraitsConfigMap = nil
+
+	env, err := snapshot.Replay(context.TODO())
+	assert.Nil(t, err)
+	if assert.NotNil(t, env) {
+		assert.Equal(t, catalog.Version, env.CamelCatalog.Version)
+		assert.NotNil(t, env.GetTrait(ID("deployment")))
+		assert.NotEmpty(t, env.Resources.Items())
+	}
+}
+
+func TestLoadInvalidSnapshot(t *testing.T) {
+	_, err := LoadSnapshot([]byte("error: failure"))
+	assert.NotNil(t, err)
+
+	_, err = LoadSnapshot([]byte("{"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,338 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/envvar"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
+
+	"github.com/pkg/errors"
+
+	yaml2 "gopkg.in/yaml.v2"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	splitRouteLabel    = "camel.apache.org/route"
+	splitSourcesVolume = "i-route"
+	splitSourcesPath   = "/etc/camel/sources/i-route"
+	splitSourceName    = "route.flow"
+	splitBridge        = "camel:netty4-http"
+)
+
+// The split trait runs each route of the integration in its own deployment, sharing the integration kit, so
+// that the stages of a pipeline can be scaled independently. The direct endpoints shared by routes are bridged
+// over HTTP, each route consuming a shared direct endpoint being exposed by a service. It applies to the
+// integrations defined by flow sources.
+type splitTrait struct {
+	BaseTrait `property:",squash"`
+	Port      int `property:"port"`
+}
+
+func newSplitTrait() *splitTrait {
+	return &splitTrait{
+		BaseTrait: newBaseTrait("split"),
+		Port:      8080,
+	}
+}
+
+func (t *splitTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	return e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial) ||
+		e.InPhase(v1alpha1.IntegrationKitPhaseReady, v1alpha1.IntegrationPhaseDeploying), nil
+}
+
+func (t *splitTrait) Apply(e *Environment) error {
+	routes, err := t.routes(e)
+	if err != nil {
+		return err
+	}
+
+	if e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial) {
+		// the component the shared direct endpoints are bridged with
+		if len(sharedDirectEndpoints(routes)) > 0 {
+			util.StringSliceUniqueAdd(&e.Integration.Status.Dependencies, splitBridge)
+			sort.Strings(e.Integration.Status.Dependencies)
+		}
+		return nil
+	}
+
+	// The deployment is customized by the other traits, including their post processors, then split
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		environment.PostProcessors = append(environment.PostProcessors, func(environment *Environment) error {
+			return t.split(environment, routes)
+		})
+		return nil
+	})
+
+	return nil
+}
+
+// routes returns the routes of the integration flow sources, in order
+func (t *splitTrait) routes(e *Environment) ([]v1alpha1.Flow, error) {
+	sources, err := kubernetes.ResolveIntegrationSources(t.ctx, t.client, e.Integration, e.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]v1alpha1.Flow, 0)
+	for _, s := range sources {
+		if s.InferLanguage() != v1alpha1.LanguageYamlFlow || s.Compression {
+			return nil, fmt.Errorf("the split trait supports uncompressed flow sources only, %s cannot be split", s.Name)
+		}
+
+		var flows v1alpha1.Flows
+		if err := yaml2.Unmarshal([]byte(s.Content), &flows); err != nil {
+			return nil, errors.Wrapf(err, "unable to parse flow source %s", s.Name)
+		}
+		for _, flow := range flows {
+			if len(flow.Steps) > 0 {
+				routes = append(routes, flow)
+			}
+		}
+	}
+
+	return routes, nil
+}
+
+// split replaces the integration deployment with one deployment per route
+func (t *splitTrait) split(e *Environment, routes []v1alpha1.Flow) error {
+	deployment := e.Resources.RemoveDeployment(func(d *appsv1.Deployment) bool {
+		return d.Name == e.Integration.Name
+	})
+	if deployment == nil {
+		return nil
+	}
+
+	shared := sharedDirectEndpoints(routes)
+
+	for i, route := range routes {
+		name := t.routeName(e.Integration, i)
+
+		content, err := yaml2.Marshal(v1alpha1.Flows{t.bridge(e.Integration, route, i, shared)})
+		if err != nil {
+			return err
+		}
+
+		e.Resources.Add(&corev1.ConfigMap{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ConfigMap",
+				APIVersion: "v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-source",
+				Namespace: e.Integration.Namespace,
+				Labels: map[string]string{
+					"camel.apache.org/integration": e.Integration.Name,
+					splitRouteLabel:                strconv.Itoa(i),
+				},
+			},
+			Data: map[string]string{
+				"content": string(content),
+			},
+		})
+
+		e.Resources.Add(t.routeDeployment(deployment, name, i))
+
+		if consumer, ok := directEndpoint(route.Steps[0].URI); ok && shared[consumer] == i {
+			e.Resources.Add(t.routeService(e.Integration, name, i))
+		}
+	}
+
+	return nil
+}
+
+// routeDeployment returns a copy of the integration deployment running the given route only
+func (t *splitTrait) routeDeployment(deployment *appsv1.Deployment, name string, index int) *appsv1.Deployment {
+	d := deployment.DeepCopy()
+	d.Name = name
+
+	d.Labels = copyLabels(d.Labels)
+	d.Labels[splitRouteLabel] = strconv.Itoa(index)
+	d.Spec.Selector.MatchLabels = copyLabels(d.Spec.Selector.MatchLabels)
+	d.Spec.Selector.MatchLabels[splitRouteLabel] = strconv.Itoa(index)
+	d.Spec.Template.Labels = copyLabels(d.Spec.Template.Labels)
+	d.Spec.Template.Labels[splitRouteLabel] = strconv.Itoa(index)
+
+	// mount the route in place of the integration sources
+	volumes := make([]corev1.Volume, 0, len(d.Spec.Template.Spec.Volumes))
+	for _, v := range d.Spec.Template.Spec.Volumes {
+		if !strings.HasPrefix(v.Name, "i-source-") {
+			volumes = append(volumes, v)
+		}
+	}
+	d.Spec.Template.Spec.Volumes = append(volumes, corev1.Volume{
+		Name: splitSourcesVolume,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: name + "-source",
+				},
+				Items: []corev1.KeyToPath{
+					{
+						Key:  "content",
+						Path: splitSourceName,
+					},
+				},
+			},
+		},
+	})
+
+	for i := range d.Spec.Template.Spec.Containers {
+		container := &d.Spec.Template.Spec.Containers[i]
+		if envvar.Get(container.Env, "CAMEL_K_ROUTES") == nil {
+			continue
+		}
+
+		mounts := make([]corev1.VolumeMount, 0, len(container.VolumeMounts))
+		for _, m := range container.VolumeMounts {
+			if !strings.HasPrefix(m.Name, "i-source-") {
+				mounts = append(mounts, m)
+			}
+		}
+		container.VolumeMounts = append(mounts, corev1.VolumeMount{
+			Name:      splitSourcesVolume,
+			MountPath: splitSourcesPath,
+		})
+
+		envvar.SetVal(&container.Env, "CAMEL_K_ROUTES",
+			"file:"+path.Join(splitSourcesPath, splitSourceName)+"?language="+string(v1alpha1.LanguageYamlFlow))
+	}
+
+	return d
+}
+
+// routeService returns the service exposing the direct endpoints consumed by the given route to the other routes
+func (t *splitTrait) routeService(integration *v1alpha1.Integration, name string, index int) *corev1.Service {
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: integration.Namespace,
+			Labels: map[string]string{
+				"camel.apache.org/integration": integration.Name,
+				splitRouteLabel:                strconv.Itoa(index),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "http",
+					Port:       80,
+					Protocol:   corev1.ProtocolTCP,
+					TargetPort: intstr.FromInt(t.Port),
+				},
+			},
+			Selector: map[string]string{
+				"camel.apache.org/integration": integration.Name,
+				splitRouteLabel:                strconv.Itoa(index),
+			},
+		},
+	}
+}
+
+// bridge returns a copy of the given route where the shared direct endpoints are substituted by HTTP ones
+func (t *splitTrait) bridge(integration *v1alpha1.Integration, route v1alpha1.Flow, index int, shared map[string]int) v1alpha1.Flow {
+	result := v1alpha1.Flow{
+		Steps: make([]v1alpha1.Step, len(route.Steps)),
+	}
+	copy(result.Steps, route.Steps)
+
+	for i, step := range result.Steps {
+		name, ok := directEndpoint(step.URI)
+		if !ok {
+			continue
+		}
+		consumer, ok := shared[name]
+		if !ok {
+			continue
+		}
+
+		if i == 0 && consumer == index {
+			result.Steps[i].URI = fmt.Sprintf("netty4-http:http://0.0.0.0:%d/%s", t.Port, name)
+		} else if i > 0 && consumer != index {
+			result.Steps[i].URI = fmt.Sprintf("netty4-http:http://%s/%s", t.routeName(integration, consumer), name)
+		}
+	}
+
+	return result
+}
+
+func (t *splitTrait) routeName(integration *v1alpha1.Integration, index int) string {
+	return fmt.Sprintf("%s-route-%d", integration.Name, index)
+}
+
+// sharedDirectEndpoints returns the names of the direct endpoints consumed by a route and produced by
+// another one, mapped to the index of the consuming route
+func sharedDirectEndpoints(routes []v1alpha1.Flow) map[string]int {
+	consumers := make(map[string]int)
+	for i, route := range routes {
+		if name, ok := directEndpoint(route.Steps[0].URI); ok {
+			consumers[name] = i
+		}
+	}
+
+	shared := make(map[string]int)
+	for i, route := range routes {
+		for _, step := range route.Steps[1:] {
+			if name, ok := directEndpoint(step.URI); ok {
+				if consumer, ok := consumers[name]; ok && consumer != i {
+					shared[name] = consumer
+				}
+			}
+		}
+	}
+
+	return shared
+}
+
+// directEndpoint returns the name of the direct endpoint the given URI refers to, if any
+func directEndpoint(uri string) (string, bool) {
+	u, err := uriutil.Parse(uri)
+	if err != nil || u.Scheme != "direct" {
+		return "", false
+	}
+	return u.Path, u.Path != ""
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	result := make(map[string]string, len(labels)+1)
+	for k, v := range labels {
+		result[k] = v
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const splitTestFlow = `
+- steps:
+  - kind: endpoint
+    uri: timer:tick
+  - kind: endpoint
+    uri: direct:process
+- steps:
+  - kind: endpoint
+    uri: direct:process
+  - kind: endpoint
+    uri: log:info
+  - kind: endpoint
+    uri: direct:store?timeout=1000
+- steps:
+  - kind: endpoint
+    uri: direct:store
+  - kind: endpoint
+    uri: log:stored
+`
+
+func createSplitTestEnv(t *testing.T, phase v1alpha1.IntegrationPhase) (*splitTrait, *Environment) {
+	trait := newSplitTrait()
+	trait.InjectContext(context.TODO())
+	trait.Enabled = &[]bool{true}[0]
+
+	env := createIntegrationTestEnv(t, "pipeline", phase, v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name:    "pipeline.flow",
+			Content: splitTestFlow,
+		},
+		Language: v1alpha1.LanguageYamlFlow,
+	})
+
+	return trait, env
+}
+
+func TestSplitDependencies(t *testing.T) {
+	trait, e := createSplitTestEnv(t, v1alpha1.IntegrationPhaseInitial)
+
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Contains(t, e.Integration.Status.Dependencies, "camel:netty4-http")
+}
+
+func TestSplitRoutes(t *testing.T) {
+	trait, e := createSplitTestEnv(t, v1alpha1.IntegrationPhaseDeploying)
+	e.Resources.Add(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "pipeline",
+			Labels: map[string]string{"camel.apache.org/integration": "pipeline"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"camel.apache.org/integration": "pipeline"},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"camel.apache.org/integration": "pipeline"},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name: "pipeline",
+							Env: []corev1.EnvVar{
+								{Name: "CAMEL_K_ROUTES", Value: "file:/etc/camel/sources/i-source-000/pipeline.flow?language=flow"},
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "i-source-000", MountPath: "/etc/camel/sources/i-source-000"},
+								{Name: "integration-properties", MountPath: "/etc/camel/conf"},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{Name: "i-source-000"},
+						{Name: "integration-properties"},
+					},
+				},
+			},
+		},
+	})
+
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	assert.Nil(t, trait.Apply(e))
+
+	for i := 0; i < len(e.PostProcessors); i++ {
+		assert.Nil(t, e.PostProcessors[i](e))
+	}
+
+	assert.Nil(t, e.Resources.GetDeployment(func(d *appsv1.Deployment) bool { return d.Name == "pipeline" }))
+
+	for i, name := range []string{"pipeline-route-0", "pipeline-route-1", "pipeline-route-2"} {
+		d := e.Resources.GetDeployment(func(d *appsv1.Deployment) bool { return d.Name == name })
+		assert.NotNil(t, d)
+		assert.Equal(t, strconv.Itoa(i), d.Spec.Selector.MatchLabels["camel.apache.org/route"])
+		assert.Equal(t, strconv.Itoa(i), d.Spec.Template.Labels["camel.apache.org/route"])
+		assert.Len(t, d.Spec.Template.Spec.Volumes, 2)
+		assert.Equal(t, "pipeline-route-"+strconv.Itoa(i)+"-source", d.Spec.Template.Spec.Volumes[1].ConfigMap.Name)
+		assert.Equal(t, "file:/etc/camel/sources/i-route/route.flow?language=flow",
+			envvar.Get(d.Spec.Template.Spec.Containers[0].Env, "CAMEL_K_ROUTES").Value)
+	}
+
+	// the routes consuming the shared direct endpoints are exposed
+	assert.Nil(t, e.Resources.GetService(func(s *corev1.Service) bool { return s.Name == "pipeline-route-0" }))
+	assert.NotNil(t, e.Resources.GetService(func(s *corev1.Service) bool { return s.Name == "pipeline-route-1" }))
+	assert.NotNil(t, e.Resources.GetService(func(s *corev1.Service) bool { return s.Name == "pipeline-route-2" }))
+
+	source := func(name string) string {
+		cm := e.Resources.GetConfigMap(func(cm *corev1.ConfigMap) bool { return cm.Name == name })
+		assert.NotNil(t, cm)
+		return cm.Data["content"]
+	}
+	assert.Contains(t, source("pipeline-route-0-source"), "uri: timer:tick")
+	assert.Contains(t, source("pipeline-route-0-source"), "uri: netty4-http:http://pipeline-route-1/process")
+	assert.Contains(t, source("pipeline-route-1-source"), "uri: netty4-http:http://0.0.0.0:8080/process")
+	assert.Contains(t, source("pipeline-route-1-source"), "uri: netty4-http:http://pipeline-route-2/store")
+	assert.Contains(t, source("pipeline-route-2-source"), "uri: netty4-http:http://0.0.0.0:8080/store")
+}
+
+func TestSplitRequiresFlowSources(t *testing.T) {
+	trait, e := createSplitTestEnv(t, v1alpha1.IntegrationPhaseInitial)
+	e.Integration.Spec.Sources[0].Language = v1alpha1.LanguageGroovy
+
+	assert.NotNil(t, trait.Apply(e))
+}
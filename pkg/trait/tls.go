@@ -0,0 +1,327 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	tlsFormatPKCS12 = "pkcs12"
+	tlsFormatJKS    = "jks"
+
+	tlsStoresVolume       = "i-tls-stores"
+	tlsKeystoreVolume     = "i-tls-keystore"
+	tlsTruststoreVolume   = "i-tls-truststore"
+	tlsStoresPath         = "/etc/camel/tls/stores"
+	tlsSecretsPath        = "/etc/camel/tls/secrets"
+	tlsContainer          = "tls"
+	tlsPasswordEnvVar     = "TLS_STORE_PASSWORD"
+	tlsDefaultPassword    = "changeit"
+	tlsPasswordSecretKey  = "password"
+	tlsKeystoreCertKey    = "tls.crt"
+	tlsKeystorePrivateKey = "tls.key"
+	tlsCacertsPassword    = "changeit"
+)
+
+// The tls trait assembles the keystore of the integration from a TLS secret, holding the `tls.crt` certificate chain
+// and the `tls.key` private key, and its truststore from the certificates of the given secrets. The PEM files are
+// converted to PKCS12 or JKS stores by an init container, running the integration image by default, that requires
+// `openssl` and `keytool`. The truststore also holds the certificates of the JDK `cacerts`, so that the public
+// certificate authorities remain trusted. The stores are shared with the integration container through an emptyDir
+// volume and set as the default ones of the JVM, their location, type and password being also available to the routes
+// through the `camel.k.tls.*` properties, e.g. `{{camel.k.tls.keystore.location}}`.
+type tlsTrait struct {
+	BaseTrait         `property:",squash"`
+	KeystoreSecret    string `property:"keystore-secret"`
+	TruststoreSecrets string `property:"truststore-secrets"`
+	PasswordSecret    string `property:"password-secret"`
+	Format            string `property:"format"`
+	Image             string `property:"image"`
+}
+
+func newTLSTrait() *tlsTrait {
+	return &tlsTrait{
+		BaseTrait: newBaseTrait("tls"),
+		Format:    tlsFormatPKCS12,
+	}
+}
+
+func (t *tlsTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial, v1alpha1.IntegrationPhaseDeploying) {
+		return false, nil
+	}
+
+	if t.KeystoreSecret == "" && len(t.truststoreSecrets()) == 0 {
+		return false, errors.New("either the keystore secret or the truststore secrets are required")
+	}
+	if t.Format != tlsFormatPKCS12 && t.Format != tlsFormatJKS {
+		return false, fmt.Errorf("unsupported store format %s, should be one of: pkcs12|jks", t.Format)
+	}
+	if t.Format == tlsFormatJKS && t.fips(e) {
+		return false, errors.New("JKS stores aren't FIPS compliant, use PKCS12 stores instead")
+	}
+
+	return true, nil
+}
+
+func (t *tlsTrait) Apply(e *Environment) error {
+	if e.IntegrationInPhase(v1alpha1.IntegrationPhaseInitial) {
+		e.Integration.Status.Configuration = append(e.Integration.Status.Configuration, t.properties()...)
+		return nil
+	}
+
+	strategy, err := e.DetermineControllerStrategy(t.ctx, t.client)
+	if err != nil {
+		return err
+	}
+	if strategy != ControllerStrategyDeployment {
+		return errors.New("the tls trait is only supported by deployments")
+	}
+
+	image := t.Image
+	if image == "" {
+		image = e.Integration.Status.Image
+	}
+	fips := t.fips(e)
+
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		found := false
+		environment.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+			spec := &deployment.Spec.Template.Spec
+			for i := range spec.Containers {
+				if spec.Containers[i].Name == environment.Integration.Name {
+					t.configureContainer(&spec.Containers[i])
+					found = true
+				}
+			}
+			spec.Volumes = append(spec.Volumes, t.volumes()...)
+			spec.InitContainers = append(spec.InitContainers, t.newInitContainer(image, fips))
+		})
+		if !found {
+			return errors.New("cannot configure the TLS stores: no integration container")
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// fips tells whether the platform runs in FIPS mode
+func (t *tlsTrait) fips(e *Environment) bool {
+	return e.Platform != nil && e.Platform.Spec.FIPS
+}
+
+// truststoreSecrets returns the secrets holding the trusted certificates
+func (t *tlsTrait) truststoreSecrets() []string {
+	secrets := make([]string, 0)
+	for _, s := range strings.Split(t.TruststoreSecrets, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			secrets = append(secrets, s)
+		}
+	}
+	return secrets
+}
+
+func (t *tlsTrait) storeType() string {
+	return strings.ToUpper(t.Format)
+}
+
+func (t *tlsTrait) storeFile(name string) string {
+	extension := "p12"
+	if t.Format == tlsFormatJKS {
+		extension = "jks"
+	}
+	return path.Join(tlsStoresPath, name+"."+extension)
+}
+
+// properties returns the runtime properties the routes configure the TLS of the components with
+func (t *tlsTrait) properties() []v1alpha1.ConfigurationSpec {
+	properties := make([]v1alpha1.ConfigurationSpec, 0)
+	for _, store := range t.stores() {
+		properties = append(properties,
+			v1alpha1.ConfigurationSpec{Type: "property", Value: "camel.k.tls." + store + ".location=" + t.storeFile(store)},
+			v1alpha1.ConfigurationSpec{Type: "property", Value: "camel.k.tls." + store + ".type=" + t.storeType()},
+			v1alpha1.ConfigurationSpec{Type: "property", Value: "camel.k.tls." + store + ".password={{env:" + tlsPasswordEnvVar + "}}"},
+		)
+	}
+	return properties
+}
+
+// stores returns the names of the assembled stores
+func (t *tlsTrait) stores() []string {
+	stores := make([]string, 0, 2)
+	if t.KeystoreSecret != "" {
+		stores = append(stores, "keystore")
+	}
+	if len(t.truststoreSecrets()) > 0 {
+		stores = append(stores, "truststore")
+	}
+	return stores
+}
+
+// passwordEnvVar returns the environment variable holding the password of the stores, set from the password
+// secret if any, or to the customary default password of the JDK stores
+func (t *tlsTrait) passwordEnvVar() corev1.EnvVar {
+	if t.PasswordSecret == "" {
+		return corev1.EnvVar{Name: tlsPasswordEnvVar, Value: tlsDefaultPassword}
+	}
+	return corev1.EnvVar{
+		Name: tlsPasswordEnvVar,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: t.PasswordSecret},
+				Key:                  tlsPasswordSecretKey,
+			},
+		},
+	}
+}
+
+// configureContainer mounts the stores and sets them as the default ones of the JVM
+func (t *tlsTrait) configureContainer(container *corev1.Container) {
+	container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+		Name:      tlsStoresVolume,
+		MountPath: tlsStoresPath,
+		ReadOnly:  true,
+	})
+
+	options := make([]string, 0)
+	if existing := envvar.Get(container.Env, envVarJavaOptions); existing != nil && existing.Value != "" {
+		options = append(options, existing.Value)
+	}
+	for _, store := range t.stores() {
+		property := "-Djavax.net.ssl.keyStore"
+		if store == "truststore" {
+			property = "-Djavax.net.ssl.trustStore"
+		}
+		options = append(options,
+			property+"="+t.storeFile(store),
+			property+"Type="+t.storeType(),
+			// the password is expanded by Kubernetes, the variable being defined before
+			property+"Password=$("+tlsPasswordEnvVar+")",
+		)
+	}
+
+	envvar.Remove(&container.Env, tlsPasswordEnvVar)
+	envvar.Remove(&container.Env, envVarJavaOptions)
+	container.Env = append([]corev1.EnvVar{t.passwordEnvVar()}, container.Env...)
+	container.Env = append(container.Env, corev1.EnvVar{Name: envVarJavaOptions, Value: strings.Join(options, " ")})
+}
+
+func (t *tlsTrait) volumes() []corev1.Volume {
+	volumes := []corev1.Volume{
+		{
+			Name: tlsStoresVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+	if t.KeystoreSecret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: tlsKeystoreVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: t.KeystoreSecret,
+				},
+			},
+		})
+	}
+	for i, secret := range t.truststoreSecrets() {
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf("%s-%d", tlsTruststoreVolume, i),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: secret,
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// newInitContainer returns the init container converting the PEM files of the secrets to stores
+func (t *tlsTrait) newInitContainer(image string, fips bool) corev1.Container {
+	mounts := []corev1.VolumeMount{
+		{
+			Name:      tlsStoresVolume,
+			MountPath: tlsStoresPath,
+		},
+	}
+
+	script := []string{"set -e"}
+	if t.KeystoreSecret != "" {
+		dir := path.Join(tlsSecretsPath, "keystore")
+		mounts = append(mounts, corev1.VolumeMount{Name: tlsKeystoreVolume, MountPath: dir, ReadOnly: true})
+
+		p12 := path.Join(tlsStoresPath, "keystore.p12")
+		export := fmt.Sprintf(`openssl pkcs12 -export -name integration -in %s -inkey %s -out %s -passout env:%s`,
+			path.Join(dir, tlsKeystoreCertKey), path.Join(dir, tlsKeystorePrivateKey), p12, tlsPasswordEnvVar)
+		if fips {
+			// the default encryption of the PKCS12 stores relies on algorithms that aren't FIPS approved
+			export += " -keypbe AES-256-CBC -certpbe AES-256-CBC -macalg sha256"
+		}
+		script = append(script, export)
+		if t.Format == tlsFormatJKS {
+			script = append(script, fmt.Sprintf(`keytool -importkeystore -noprompt -srckeystore %s -srcstoretype PKCS12 -srcstorepass "$%s" `+
+				`-destkeystore %s -deststoretype JKS -deststorepass "$%s"`, p12, tlsPasswordEnvVar, t.storeFile("keystore"), tlsPasswordEnvVar))
+			script = append(script, "rm "+p12)
+		}
+	}
+	if len(t.truststoreSecrets()) > 0 {
+		// the truststore replaces the JDK one, so it starts from the JDK trusted certificates
+		script = append(script,
+			`java_home="${JAVA_HOME:-$(dirname "$(dirname "$(readlink -f "$(command -v keytool)")")")}"`,
+			`cacerts=""`,
+			`for f in "$java_home/lib/security/cacerts" "$java_home/jre/lib/security/cacerts"; do if [ -z "$cacerts" ] && [ -f "$f" ]; then cacerts="$f"; fi; done`,
+			`if [ -z "$cacerts" ]; then echo "cannot find the JDK cacerts in $java_home" >&2; exit 1; fi`,
+			fmt.Sprintf(`keytool -importkeystore -noprompt -srckeystore "$cacerts" -srcstorepass %s `+
+				`-destkeystore %s -deststoretype %s -deststorepass "$%s"`, tlsCacertsPassword, t.storeFile("truststore"), t.storeType(), tlsPasswordEnvVar),
+		)
+	}
+	for i, secret := range t.truststoreSecrets() {
+		dir := path.Join(tlsSecretsPath, fmt.Sprintf("truststore-%d", i))
+		mounts = append(mounts, corev1.VolumeMount{Name: fmt.Sprintf("%s-%d", tlsTruststoreVolume, i), MountPath: dir, ReadOnly: true})
+
+		// each key of the secret holds a certificate, the keys are used as aliases
+		script = append(script, fmt.Sprintf(`for f in %s/*; do keytool -importcert -noprompt -alias "%s-$(basename "$f")" -file "$f" `+
+			`-keystore %s -storetype %s -storepass "$%s"; done`, dir, secret, t.storeFile("truststore"), t.storeType(), tlsPasswordEnvVar))
+	}
+
+	return corev1.Container{
+		Name:         tlsContainer,
+		Image:        image,
+		Command:      []string{"/bin/sh", "-c", strings.Join(script, "\n")},
+		Env:          []corev1.EnvVar{t.passwordEnvVar()},
+		VolumeMounts: mounts,
+	}
+}
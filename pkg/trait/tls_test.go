@@ -0,0 +1,144 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/envvar"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestTLSProperties(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Status.Phase = v1alpha1.IntegrationPhaseInitial
+
+	trait := newTLSTrait()
+	enabled := true
+	trait.Enabled = &enabled
+	trait.KeystoreSecret = "my-tls"
+
+	ok, err := trait.Configure(env)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Nil(t, trait.Apply(env))
+
+	values := make([]string, 0)
+	for _, c := range env.Integration.Status.Configuration {
+		values = append(values, c.Value)
+	}
+	assert.Equal(t, []string{
+		"camel.k.tls.keystore.location=/etc/camel/tls/stores/keystore.p12",
+		"camel.k.tls.keystore.type=PKCS12",
+		"camel.k.tls.keystore.password={{env:TLS_STORE_PASSWORD}}",
+	}, values)
+}
+
+func TestTLSStores(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Integration.Spec.Configuration = append(env.Integration.Spec.Configuration,
+		v1alpha1.ConfigurationSpec{Type: "env", Value: "JAVA_OPTIONS=-Xmx256m"})
+	env.Integration.Spec.Traits = map[string]v1alpha1.TraitSpec{
+		"tls": {
+			Configuration: map[string]string{
+				"enabled":            "true",
+				"keystore-secret":    "my-tls",
+				"truststore-secrets": "ca-1, ca-2",
+				"password-secret":    "my-password",
+				"format":             "jks",
+			},
+		},
+	}
+
+	res := processTestEnv(t, env)
+
+	d := res.GetDeployment(func(d *appsv1.Deployment) bool { return true })
+	assert.NotNil(t, d)
+
+	spec := d.Spec.Template.Spec
+	assert.Contains(t, spec.Volumes, corev1.Volume{
+		Name:         "i-tls-truststore-1",
+		VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "ca-2"}},
+	})
+
+	container := spec.Containers[0]
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: tlsStoresVolume, MountPath: tlsStoresPath, ReadOnly: true})
+	assert.Equal(t, tlsPasswordEnvVar, container.Env[0].Name)
+	assert.Equal(t, "my-password", container.Env[0].ValueFrom.SecretKeyRef.Name)
+	assert.Equal(t, "-Xmx256m "+
+		"-Djavax.net.ssl.keyStore=/etc/camel/tls/stores/keystore.jks -Djavax.net.ssl.keyStoreType=JKS "+
+		"-Djavax.net.ssl.keyStorePassword=$(TLS_STORE_PASSWORD) "+
+		"-Djavax.net.ssl.trustStore=/etc/camel/tls/stores/truststore.jks -Djavax.net.ssl.trustStoreType=JKS "+
+		"-Djavax.net.ssl.trustStorePassword=$(TLS_STORE_PASSWORD)",
+		envvar.Get(container.Env, "JAVA_OPTIONS").Value)
+
+	assert.Len(t, spec.InitContainers, 1)
+	init := spec.InitContainers[0]
+	assert.Equal(t, tlsContainer, init.Name)
+	assert.Equal(t, env.Integration.Status.Image, init.Image)
+	assert.Len(t, init.VolumeMounts, 4)
+	assert.Contains(t, init.Command[2], "openssl pkcs12 -export -name integration -in /etc/camel/tls/secrets/keystore/tls.crt")
+	assert.Contains(t, init.Command[2], "-destkeystore /etc/camel/tls/stores/keystore.jks -deststoretype JKS")
+	assert.Contains(t, init.Command[2], `-alias "ca-2-$(basename "$f")"`)
+	assert.Contains(t, init.Command[2], `-srckeystore "$cacerts" -srcstorepass changeit -destkeystore /etc/camel/tls/stores/truststore.jks -deststoretype JKS`)
+	assert.True(t, strings.Index(init.Command[2], `-srckeystore "$cacerts"`) < strings.Index(init.Command[2], "-importcert"))
+}
+
+func TestTLSRequiresSecrets(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+
+	trait := newTLSTrait()
+	enabled := true
+	trait.Enabled = &enabled
+
+	_, err := trait.Configure(env)
+	assert.NotNil(t, err)
+
+	trait.KeystoreSecret = "my-tls"
+	trait.Format = "pem"
+	_, err = trait.Configure(env)
+	assert.NotNil(t, err)
+}
+
+func TestTLSFIPS(t *testing.T) {
+	env := createTestEnv(t, v1alpha1.IntegrationPlatformClusterKubernetes, "from('timer:test').log('hello')")
+	env.Platform.Spec.FIPS = true
+
+	trait := newTLSTrait()
+	enabled := true
+	trait.Enabled = &enabled
+	trait.KeystoreSecret = "my-tls"
+	trait.Format = "jks"
+
+	_, err := trait.Configure(env)
+	assert.NotNil(t, err)
+
+	trait.Format = "pkcs12"
+	ok, err := trait.Configure(env)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+
+	init := trait.newInitContainer("my-image", true)
+	assert.Contains(t, init.Command[2], "-keypbe AES-256-CBC -certpbe AES-256-CBC -macalg sha256")
+}
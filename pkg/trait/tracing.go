@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/masking"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+// The tracing trait enables the backlog tracer of the Camel context, so that the messages flowing through the
+// selected routes are sampled. The operator periodically collects the traced messages through the Jolokia agent,
+// that must be enabled with the jolokia trait, and keeps the last ones in the `<integration>-traces` config map,
+// displayed by `kamel trace`. The masking rules of the platform and of the integration are applied by the operator
+// to the collected messages.
+type tracingTrait struct {
+	BaseTrait    `property:",squash"`
+	Routes       string `property:"routes"`
+	Filter       string `property:"filter"`
+	BacklogSize  int    `property:"backlog-size"`
+	BodyMaxChars int    `property:"body-max-chars"`
+}
+
+func newTracingTrait() *tracingTrait {
+	return &tracingTrait{
+		BaseTrait:    newBaseTrait("tracing"),
+		BacklogSize:  100,
+		BodyMaxChars: 1024,
+	}
+}
+
+func (t *tracingTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	if !e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying) {
+		return false, nil
+	}
+
+	// the jolokia trait is always executed, the agent being deactivated unless it's enabled
+	if jt, ok := e.GetTrait("jolokia").(*jolokiaTrait); !ok || jt.Enabled == nil || !*jt.Enabled {
+		return false, fmt.Errorf("the tracing trait requires the jolokia trait to be enabled")
+	}
+	if t.BacklogSize <= 0 {
+		return false, fmt.Errorf("invalid backlog size %d", t.BacklogSize)
+	}
+	if t.BodyMaxChars <= 0 {
+		return false, fmt.Errorf("invalid body max chars %d", t.BodyMaxChars)
+	}
+	if _, err := masking.NewMasker(e.DetermineMaskingRules()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (t *tracingTrait) Apply(e *Environment) error {
+	var rules []byte
+	if spec := e.DetermineMaskingRules(); spec != nil {
+		var err error
+		if rules, err = json.Marshal(spec); err != nil {
+			return err
+		}
+	}
+
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		environment.Resources.VisitDeployment(func(deployment *appsv1.Deployment) {
+			if deployment.Annotations == nil {
+				deployment.Annotations = make(map[string]string)
+			}
+			deployment.Annotations[v1alpha1.IntegrationTraceBacklogSizeAnnotation] = strconv.Itoa(t.BacklogSize)
+			deployment.Annotations[v1alpha1.IntegrationTraceBodyMaxCharsAnnotation] = strconv.Itoa(t.BodyMaxChars)
+			if routes := t.routes(); routes != "" {
+				deployment.Annotations[v1alpha1.IntegrationTraceRoutesAnnotation] = routes
+			}
+			if t.Filter != "" {
+				deployment.Annotations[v1alpha1.IntegrationTraceFilterAnnotation] = t.Filter
+			}
+			if rules != nil {
+				deployment.Annotations[v1alpha1.IntegrationTraceMaskingAnnotation] = string(rules)
+			}
+		})
+		return nil
+	})
+
+	return nil
+}
+
+// routes returns the normalized comma separated list of the traced routes
+func (t *tracingTrait) routes() string {
+	routes := make([]string, 0)
+	for _, route := range strings.Split(t.Routes, ",") {
+		if route = strings.TrimSpace(route); route != "" {
+			routes = append(routes, route)
+		}
+	}
+	return strings.Join(routes, ",")
+}
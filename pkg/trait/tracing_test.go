@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+func TestTracing(t *testing.T) {
+	deployment := appsv1.Deployment{}
+
+	e := &Environment{
+		Integration: &v1alpha1.Integration{
+			Status: v1alpha1.IntegrationStatus{
+				Phase: v1alpha1.IntegrationPhaseDeploying,
+			},
+		},
+		Resources: kubernetes.NewCollection(&deployment),
+	}
+	e.Integration.Spec.Masking = &v1alpha1.MaskingSpec{Headers: []string{"Authorization"}}
+
+	trait := newTracingTrait()
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+
+	trait.Enabled = &[]bool{true}[0]
+	trait.Routes = "orders, payments,"
+	_, err = trait.Configure(e)
+	assert.NotNil(t, err)
+
+	jolokia := newJolokiaTrait()
+	jolokia.Enabled = &[]bool{true}[0]
+	e.ExecutedTraits = append(e.ExecutedTraits, jolokia)
+	enabled, err = trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Len(t, e.PostProcessors, 1)
+	assert.Nil(t, e.PostProcessors[0](e))
+
+	assert.Equal(t, "100", deployment.Annotations[v1alpha1.IntegrationTraceBacklogSizeAnnotation])
+	assert.Equal(t, "1024", deployment.Annotations[v1alpha1.IntegrationTraceBodyMaxCharsAnnotation])
+	assert.Equal(t, "orders,payments", deployment.Annotations[v1alpha1.IntegrationTraceRoutesAnnotation])
+	assert.NotContains(t, deployment.Annotations, v1alpha1.IntegrationTraceFilterAnnotation)
+	assert.Equal(t, `{"headers":["Authorization"]}`, deployment.Annotations[v1alpha1.IntegrationTraceMaskingAnnotation])
+}
+
+func TestTracingWithDisabledJolokia(t *testing.T) {
+	e := &Environment{
+		Integration: &v1alpha1.Integration{
+			Status: v1alpha1.IntegrationStatus{
+				Phase: v1alpha1.IntegrationPhaseDeploying,
+			},
+		},
+		ExecutedTraits: []Trait{newJolokiaTrait()},
+	}
+
+	trait := newTracingTrait()
+	trait.Enabled = &[]bool{true}[0]
+	_, err := trait.Configure(e)
+	assert.NotNil(t, err)
+}
@@ -45,8 +45,18 @@ func Apply(ctx context.Context, c client.Client, integration *v1alpha1.Integrati
 	// set the catalog
 	environment.Catalog = catalog
 
+	// record the resources the traits are applied to, so that failures can be troubleshot
+	var snapshot *Snapshot
+	mode := platform.GetOperatorConfiguration().TraitSnapshots
+	if mode != platform.TraitSnapshotsNone {
+		snapshot = newSnapshot(environment)
+	}
+
 	// invoke the trait framework to determine the needed resources
 	if err := catalog.apply(environment); err != nil {
+		if snapshot != nil {
+			saveSnapshot(ctx, c, catalog, snapshot, environment, mode, err)
+		}
 		return nil, errors.Wrap(err, "error during trait customization before deployment")
 	}
 
@@ -78,16 +88,38 @@ func newEnvironment(ctx context.Context, c client.Client, integration *v1alpha1.
 		}
 	}
 
+	cm, err := LoadTraitsConfigMap(ctx, c, namespace)
+	if err != nil {
+		return nil, err
+	}
+
 	env := Environment{
-		C:              ctx,
-		Platform:       pl,
-		Client:         c,
-		IntegrationKit: kit,
-		Integration:    integration,
-		ExecutedTraits: make([]Trait, 0),
-		Resources:      kubernetes.NewCollection(),
-		EnvVars:        make([]corev1.EnvVar, 0),
+		C:               ctx,
+		Platform:        pl,
+		Client:          c,
+		IntegrationKit:  kit,
+		Integration:     integration,
+		TraitsConfigMap: cm,
+		ExecutedTraits:  make([]Trait, 0),
+		Resources:       kubernetes.NewCollection(),
+		EnvVars:         make([]corev1.EnvVar, 0),
 	}
 
 	return &env, nil
 }
+
+// saveSnapshot records the trait environment that failed to be applied, the failures to record it being logged only
+func saveSnapshot(ctx context.Context, c client.Client, catalog *Catalog, snapshot *Snapshot, environment *Environment, mode string, cause error) {
+	if err := snapshot.record(environment, cause); err != nil {
+		catalog.L.Error(err, "Cannot record the trait environment snapshot")
+		return
+	}
+
+	location, err := snapshot.save(ctx, c, mode)
+	if err != nil {
+		catalog.L.Error(err, "Cannot save the trait environment snapshot")
+		return
+	}
+
+	catalog.L.Infof("Trait environment snapshot saved to %s", location)
+}
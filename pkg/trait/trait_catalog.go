@@ -26,14 +26,18 @@ import (
 	"github.com/apache/camel-k/pkg/client"
 	"github.com/apache/camel-k/pkg/util/log"
 	"github.com/fatih/structs"
+
+	"github.com/pkg/errors"
 )
 
 // Catalog collects all information about traits in one place
 type Catalog struct {
 	L                 log.Logger
 	tAffinity         Trait
+	tApproval         Trait
 	tCamel            Trait
 	tDebug            Trait
+	tDev              Trait
 	tDependencies     Trait
 	tDeployer         Trait
 	tDeployment       Trait
@@ -44,6 +48,7 @@ type Catalog struct {
 	tRoute            Trait
 	tIngress          Trait
 	tJolokia          Trait
+	tTracing          Trait
 	tPrometheus       Trait
 	tOwner            Trait
 	tBuilder          Trait
@@ -53,6 +58,17 @@ type Catalog struct {
 	tRestDsl          Trait
 	tProbes           Trait
 	tContainer        Trait
+	tPreprocessor     Trait
+	tInitContainers   Trait
+	tPersistence      Trait
+	tSharedVolume     Trait
+	tRestartPolicy    Trait
+	tLogForwarding    Trait
+	tServiceAccount   Trait
+	tCloudCredentials Trait
+	tTLS              Trait
+	tSplit            Trait
+	tWarmPool         Trait
 }
 
 // NewCatalog creates a new trait Catalog
@@ -60,8 +76,10 @@ func NewCatalog(ctx context.Context, c client.Client) *Catalog {
 	catalog := Catalog{
 		L:                 log.Log.WithName("trait"),
 		tAffinity:         newAffinityTrait(),
+		tApproval:         newApprovalTrait(),
 		tCamel:            newCamelTrait(),
 		tDebug:            newDebugTrait(),
+		tDev:              newDevTrait(),
 		tRestDsl:          newRestDslTrait(),
 		tKnative:          newKnativeTrait(),
 		tDependencies:     newDependenciesTrait(),
@@ -73,6 +91,7 @@ func NewCatalog(ctx context.Context, c client.Client) *Catalog {
 		tRoute:            newRouteTrait(),
 		tIngress:          newIngressTrait(),
 		tJolokia:          newJolokiaTrait(),
+		tTracing:          newTracingTrait(),
 		tPrometheus:       newPrometheusTrait(),
 		tOwner:            newOwnerTrait(),
 		tBuilder:          newBuilderTrait(),
@@ -81,6 +100,17 @@ func NewCatalog(ctx context.Context, c client.Client) *Catalog {
 		tClasspath:        newClasspathTrait(),
 		tProbes:           newProbesTrait(),
 		tContainer:        newContainerTrait(),
+		tPreprocessor:     newPreprocessorTrait(),
+		tInitContainers:   newInitContainersTrait(),
+		tPersistence:      newPersistenceTrait(),
+		tSharedVolume:     newSharedVolumeTrait(),
+		tRestartPolicy:    newRestartPolicyTrait(),
+		tLogForwarding:    newLogForwardingTrait(),
+		tServiceAccount:   newServiceAccountTrait(),
+		tCloudCredentials: newCloudCredentialsTrait(),
+		tTLS:              newTLSTrait(),
+		tSplit:            newSplitTrait(),
+		tWarmPool:         newWarmPoolTrait(),
 	}
 
 	for _, t := range catalog.allTraits() {
@@ -97,8 +127,10 @@ func NewCatalog(ctx context.Context, c client.Client) *Catalog {
 func (c *Catalog) allTraits() []Trait {
 	return []Trait{
 		c.tAffinity,
+		c.tApproval,
 		c.tCamel,
 		c.tDebug,
+		c.tDev,
 		c.tRestDsl,
 		c.tKnative,
 		c.tDependencies,
@@ -110,6 +142,7 @@ func (c *Catalog) allTraits() []Trait {
 		c.tRoute,
 		c.tIngress,
 		c.tJolokia,
+		c.tTracing,
 		c.tPrometheus,
 		c.tOwner,
 		c.tBuilder,
@@ -118,11 +151,22 @@ func (c *Catalog) allTraits() []Trait {
 		c.tClasspath,
 		c.tProbes,
 		c.tContainer,
+		c.tPreprocessor,
+		c.tInitContainers,
+		c.tPersistence,
+		c.tSharedVolume,
+		c.tRestartPolicy,
+		c.tLogForwarding,
+		c.tServiceAccount,
+		c.tCloudCredentials,
+		c.tTLS,
+		c.tSplit,
+		c.tWarmPool,
 	}
 }
 
-// Traits may depend on the result of previously executed ones,
-// so care must be taken while changing the lists order.
+// Traits may depend on the result of previously executed ones, as declared in traitRequirements,
+// the lists being sorted accordingly when the traits are applied.
 func (c *Catalog) traitsFor(environment *Environment) []Trait {
 	switch environment.DetermineProfile() {
 	case v1alpha1.TraitProfileOpenShift:
@@ -130,14 +174,28 @@ func (c *Catalog) traitsFor(environment *Environment) []Trait {
 			c.tCamel,
 			c.tGarbageCollector,
 			c.tDebug,
+			c.tDev,
+			c.tPreprocessor,
 			c.tRestDsl,
+			c.tKnative,
 			c.tDependencies,
 			c.tBuilder,
 			c.tEnvironment,
+			c.tInitContainers,
+			c.tPersistence,
+			c.tSharedVolume,
+			c.tRestartPolicy,
+			c.tLogForwarding,
+			c.tServiceAccount,
+			c.tCloudCredentials,
+			c.tTLS,
 			c.tJolokia,
+			c.tTracing,
 			c.tPrometheus,
 			c.tDeployer,
 			c.tDeployment,
+			c.tSplit,
+			c.tWarmPool,
 			c.tAffinity,
 			c.tContainer,
 			c.tClasspath,
@@ -145,20 +203,35 @@ func (c *Catalog) traitsFor(environment *Environment) []Trait {
 			c.tService,
 			c.tRoute,
 			c.tOwner,
+			c.tApproval,
 		}
 	case v1alpha1.TraitProfileKubernetes:
 		return []Trait{
 			c.tCamel,
 			c.tGarbageCollector,
 			c.tDebug,
+			c.tDev,
+			c.tPreprocessor,
 			c.tRestDsl,
+			c.tKnative,
 			c.tDependencies,
 			c.tBuilder,
 			c.tEnvironment,
+			c.tInitContainers,
+			c.tPersistence,
+			c.tSharedVolume,
+			c.tRestartPolicy,
+			c.tLogForwarding,
+			c.tServiceAccount,
+			c.tCloudCredentials,
+			c.tTLS,
 			c.tJolokia,
+			c.tTracing,
 			c.tPrometheus,
 			c.tDeployer,
 			c.tDeployment,
+			c.tSplit,
+			c.tWarmPool,
 			c.tAffinity,
 			c.tContainer,
 			c.tClasspath,
@@ -166,19 +239,32 @@ func (c *Catalog) traitsFor(environment *Environment) []Trait {
 			c.tService,
 			c.tIngress,
 			c.tOwner,
+			c.tApproval,
 		}
 	case v1alpha1.TraitProfileKnative:
 		return []Trait{
 			c.tCamel,
 			c.tGarbageCollector,
 			c.tDebug,
+			c.tDev,
+			c.tPreprocessor,
 			c.tRestDsl,
 			c.tKnative,
 			c.tDependencies,
 			c.tBuilder,
 			c.tEnvironment,
+			c.tInitContainers,
+			c.tPersistence,
+			c.tSharedVolume,
+			c.tRestartPolicy,
+			c.tLogForwarding,
+			c.tServiceAccount,
+			c.tCloudCredentials,
+			c.tTLS,
 			c.tDeployer,
 			c.tDeployment,
+			c.tSplit,
+			c.tWarmPool,
 			c.tAffinity,
 			c.tKnativeService,
 			c.tContainer,
@@ -186,6 +272,7 @@ func (c *Catalog) traitsFor(environment *Environment) []Trait {
 			c.tProbes,
 			c.tIstio,
 			c.tOwner,
+			c.tApproval,
 		}
 	}
 
@@ -196,7 +283,10 @@ func (c *Catalog) apply(environment *Environment) error {
 	if err := c.configure(environment); err != nil {
 		return err
 	}
-	traits := c.traitsFor(environment)
+	traits, err := orderTraits(c.traitsFor(environment))
+	if err != nil {
+		return err
+	}
 
 	for _, trait := range traits {
 		enabled, err := trait.Configure(environment)
@@ -216,8 +306,9 @@ func (c *Catalog) apply(environment *Environment) error {
 		}
 	}
 
-	for _, processor := range environment.PostProcessors {
-		err := processor(environment)
+	// Post processors may register further ones, that are run after all the others
+	for i := 0; i < len(environment.PostProcessors); i++ {
+		err := environment.PostProcessors[i](environment)
 		if err != nil {
 			return err
 		}
@@ -237,11 +328,25 @@ func (c *Catalog) GetTrait(id string) Trait {
 }
 
 func (c *Catalog) configure(env *Environment) error {
+	var external map[string]v1alpha1.TraitSpec
+	precedence := TraitsPrecedenceDefault
+	if env.TraitsConfigMap != nil {
+		var err error
+		if external, precedence, err = TraitsFromConfigMap(env.TraitsConfigMap); err != nil {
+			return err
+		}
+	}
+
 	if env.Platform != nil && env.Platform.Spec.Traits != nil {
 		if err := c.configureTraits(env.Platform.Spec.Traits); err != nil {
 			return err
 		}
 	}
+	if precedence == TraitsPrecedenceDefault {
+		if err := c.configureTraits(external); err != nil {
+			return err
+		}
+	}
 	if env.IntegrationKit != nil && env.IntegrationKit.Spec.Traits != nil {
 		if err := c.configureTraits(env.IntegrationKit.Spec.Traits); err != nil {
 			return err
@@ -252,6 +357,20 @@ func (c *Catalog) configure(env *Environment) error {
 			return err
 		}
 	}
+	if env.Integration != nil {
+		annotated, err := TraitsFromAnnotations(env.Integration.Annotations)
+		if err != nil {
+			return errors.Wrapf(err, "invalid trait annotation of integration %s", env.Integration.Name)
+		}
+		if err := c.configureTraits(annotated); err != nil {
+			return err
+		}
+	}
+	if precedence == TraitsPrecedenceOverride {
+		if err := c.configureTraits(external); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,158 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// capability is what a trait provides to the traits executed after it, e.g. the integration service
+type capability string
+
+const (
+	// the Camel catalog and versions of the integration
+	capabilityCatalog capability = "catalog"
+	// the sources generated for the integration, e.g. from the REST DSL
+	capabilitySources capability = "sources"
+	// the dependencies of the integration
+	capabilityDependencies capability = "dependencies"
+	// the environment variables of the integration container
+	capabilityEnvVars capability = "env-vars"
+	// the configuration of the Jolokia agent
+	capabilityJolokia capability = "jolokia"
+	// the deployer, i.e. the kind of workload the integration is deployed as
+	capabilityDeployer capability = "deployer"
+	// the workload of the integration, i.e. the deployment or the Knative service
+	capabilityWorkload capability = "workload"
+	// the deployment of the integration, when it's not deployed as a Knative service
+	capabilityDeployment capability = "deployment"
+	// the Kubernetes service of the integration
+	capabilityService capability = "service"
+	// any resource generated for the integration
+	capabilityResources capability = "resources"
+)
+
+// traitRequirements declares what the traits require from the traits executed before them, and what they
+// provide to the traits executed after them. The requirements that no trait executed for the integration
+// provides are ignored, e.g. the service required by the route trait when the integration has no service
+var traitRequirements = map[ID]struct {
+	requires []capability
+	provides []capability
+}{
+	"camel":             {provides: []capability{capabilityCatalog}},
+	"rest-dsl":          {requires: []capability{capabilityCatalog}, provides: []capability{capabilitySources, capabilityResources}},
+	"knative":           {requires: []capability{capabilityCatalog}, provides: []capability{capabilityEnvVars, capabilityResources}},
+	"preprocessor":      {provides: []capability{capabilitySources}},
+	"dependencies":      {requires: []capability{capabilityCatalog, capabilitySources}, provides: []capability{capabilityDependencies}},
+	"builder":           {requires: []capability{capabilityDependencies}},
+	"debug":             {provides: []capability{capabilityEnvVars}},
+	"environment":       {provides: []capability{capabilityEnvVars}},
+	"persistence":       {requires: []capability{capabilityDependencies}, provides: []capability{capabilityResources}},
+	"shared-volume":     {requires: []capability{capabilityCatalog, capabilitySources}},
+	"log-forwarding":    {provides: []capability{capabilityEnvVars, capabilityResources}},
+	"service-account":   {provides: []capability{capabilityResources}},
+	"cloud-credentials": {provides: []capability{capabilityEnvVars}},
+	"jolokia":           {provides: []capability{capabilityEnvVars, capabilityJolokia}},
+	"tracing":           {requires: []capability{capabilityJolokia}},
+	"prometheus":        {provides: []capability{capabilityEnvVars, capabilityService, capabilityResources}},
+	"deployer":          {provides: []capability{capabilityDeployer}},
+	"deployment": {
+		requires: []capability{capabilityDeployer, capabilityEnvVars},
+		provides: []capability{capabilityWorkload, capabilityDeployment, capabilityResources},
+	},
+	"knative-service": {
+		requires: []capability{capabilityDeployer, capabilityEnvVars},
+		provides: []capability{capabilityWorkload, capabilityResources},
+	},
+	"split":     {requires: []capability{capabilityDependencies, capabilityDeployment}, provides: []capability{capabilityResources}},
+	"warm-pool": {requires: []capability{capabilityDeployment}, provides: []capability{capabilityResources}},
+	"affinity":  {requires: []capability{capabilityDeployment}},
+	"container": {requires: []capability{capabilityWorkload}},
+	"classpath": {requires: []capability{capabilityWorkload}},
+	"probes":    {requires: []capability{capabilityDependencies, capabilityWorkload}},
+	"istio":     {requires: []capability{capabilityWorkload}},
+	"service":   {provides: []capability{capabilityService, capabilityResources}},
+	"route":     {requires: []capability{capabilityService}, provides: []capability{capabilityResources}},
+	"ingress":   {requires: []capability{capabilityService}, provides: []capability{capabilityResources}},
+	"owner":     {requires: []capability{capabilityWorkload, capabilityResources}},
+}
+
+// orderTraits sorts the traits so that each one is executed after the traits providing what it requires. The
+// sort is deterministic and stable, i.e. the given order is retained unless a requirement demands otherwise,
+// and an error is returned if the requirements are cyclic
+func orderTraits(traits []Trait) ([]Trait, error) {
+	providers := make(map[capability][]int)
+	for i, t := range traits {
+		for _, c := range traitRequirements[t.ID()].provides {
+			providers[c] = append(providers[c], i)
+		}
+	}
+
+	// after[i] lists the traits to be executed after the trait i
+	after := make([][]int, len(traits))
+	pending := make([]int, len(traits))
+	for i, t := range traits {
+		for _, c := range traitRequirements[t.ID()].requires {
+			for _, p := range providers[c] {
+				if p != i {
+					after[p] = append(after[p], i)
+					pending[i]++
+				}
+			}
+		}
+	}
+
+	// the ready trait that comes first in the given order is executed next
+	ready := make([]int, 0)
+	for i := range traits {
+		if pending[i] == 0 {
+			ready = append(ready, i)
+		}
+	}
+
+	ordered := make([]Trait, 0, len(traits))
+	for len(ready) > 0 {
+		sort.Ints(ready)
+		next := ready[0]
+		ready = ready[1:]
+		ordered = append(ordered, traits[next])
+
+		for _, i := range after[next] {
+			pending[i]--
+			if pending[i] == 0 {
+				ready = append(ready, i)
+			}
+		}
+	}
+
+	if len(ordered) < len(traits) {
+		// the traits in a cycle, and those requiring them
+		cyclic := make([]string, 0)
+		for i, t := range traits {
+			if pending[i] > 0 {
+				cyclic = append(cyclic, string(t.ID()))
+			}
+		}
+		return nil, errors.Errorf("cannot order traits %s: cyclic requirements", strings.Join(cyclic, ", "))
+	}
+
+	return ordered, nil
+}
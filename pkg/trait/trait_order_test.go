@@ -0,0 +1,123 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+func TestTraitRequirementsOfProfiles(t *testing.T) {
+	catalog := NewCatalog(context.TODO(), nil)
+
+	for id := range traitRequirements {
+		assert.NotNil(t, catalog.GetTrait(string(id)), "unknown trait %s", id)
+	}
+
+	for _, profile := range []v1alpha1.TraitProfile{
+		v1alpha1.TraitProfileOpenShift,
+		v1alpha1.TraitProfileKubernetes,
+		v1alpha1.TraitProfileKnative,
+	} {
+		t.Run(string(profile), func(t *testing.T) {
+			env := Environment{
+				Integration: &v1alpha1.Integration{
+					Spec: v1alpha1.IntegrationSpec{
+						Profile: profile,
+					},
+				},
+			}
+			traits := catalog.traitsFor(&env)
+
+			ordered, err := orderTraits(traits)
+			assert.Nil(t, err)
+			// the lists are already sorted
+			assert.Equal(t, traitIDs(traits), traitIDs(ordered))
+
+			// each trait is executed after the traits providing what it requires
+			for i, trait := range ordered {
+				for _, c := range traitRequirements[trait.ID()].requires {
+					for j, provider := range ordered {
+						if j != i && provides(provider, c) {
+							assert.True(t, j < i, "%s requires %s from %s", trait.ID(), c, provider.ID())
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestOrderTraits(t *testing.T) {
+	catalog := NewCatalog(context.TODO(), nil)
+
+	traits := []Trait{
+		catalog.tRoute,
+		catalog.tService,
+		catalog.tOwner,
+		catalog.tDeployment,
+		catalog.tDeployer,
+		catalog.tGarbageCollector,
+	}
+
+	ordered, err := orderTraits(traits)
+	assert.Nil(t, err)
+	assert.Equal(t, []ID{"service", "route", "deployer", "deployment", "owner", "gc"}, traitIDs(ordered))
+
+	// the order does not depend on the order of the declarations but only on the given one
+	again, err := orderTraits(traits)
+	assert.Nil(t, err)
+	assert.Equal(t, traitIDs(ordered), traitIDs(again))
+}
+
+func TestOrderTraitsCycle(t *testing.T) {
+	catalog := NewCatalog(context.TODO(), nil)
+
+	requirements := traitRequirements["service"]
+	defer func() {
+		traitRequirements["service"] = requirements
+	}()
+	service := requirements
+	service.requires = []capability{capabilityResources}
+	traitRequirements["service"] = service
+
+	_, err := orderTraits([]Trait{catalog.tCamel, catalog.tService, catalog.tRoute, catalog.tOwner})
+	assert.NotNil(t, err)
+	assert.Equal(t, "cannot order traits service, route, owner: cyclic requirements", err.Error())
+}
+
+func traitIDs(traits []Trait) []ID {
+	ids := make([]ID, 0, len(traits))
+	for _, t := range traits {
+		ids = append(ids, t.ID())
+	}
+	return ids
+}
+
+func provides(trait Trait, c capability) bool {
+	for _, p := range traitRequirements[trait.ID()].provides {
+		if p == c {
+			return true
+		}
+	}
+	return false
+}
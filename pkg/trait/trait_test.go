@@ -391,6 +391,12 @@ func processTestEnv(t *testing.T, env *Environment) *kubernetes.Collection {
 }
 
 func createTestEnv(t *testing.T, cluster v1alpha1.IntegrationPlatformCluster, script string) *Environment {
+	env := createIntegrationTestEnv(t, TestDeployment, v1alpha1.IntegrationPhaseDeploying, createGroovyTestSource("file.groovy", script))
+	env.Platform.Spec.Cluster = cluster
+	return env
+}
+
+func createIntegrationTestEnv(t *testing.T, name string, phase v1alpha1.IntegrationPhase, sources ...v1alpha1.SourceSpec) *Environment {
 	catalog, err := test.DefaultCatalog()
 	assert.Nil(t, err)
 
@@ -399,22 +405,14 @@ func createTestEnv(t *testing.T, cluster v1alpha1.IntegrationPlatformCluster, sc
 		Catalog:      NewCatalog(context.TODO(), nil),
 		Integration: &v1alpha1.Integration{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      TestDeployment,
+				Name:      name,
 				Namespace: "ns",
 			},
 			Spec: v1alpha1.IntegrationSpec{
-				Sources: []v1alpha1.SourceSpec{
-					{
-						DataSpec: v1alpha1.DataSpec{
-							Name:    "file.groovy",
-							Content: script,
-						},
-						Language: v1alpha1.LanguageGroovy,
-					},
-				},
+				Sources: sources,
 			},
 			Status: v1alpha1.IntegrationStatus{
-				Phase: v1alpha1.IntegrationPhaseDeploying,
+				Phase: phase,
 			},
 		},
 		IntegrationKit: &v1alpha1.IntegrationKit{
@@ -424,7 +422,7 @@ func createTestEnv(t *testing.T, cluster v1alpha1.IntegrationPlatformCluster, sc
 		},
 		Platform: &v1alpha1.IntegrationPlatform{
 			Spec: v1alpha1.IntegrationPlatformSpec{
-				Cluster: cluster,
+				Cluster: v1alpha1.IntegrationPlatformClusterKubernetes,
 			},
 		},
 		EnvVars:        make([]corev1.EnvVar, 0),
@@ -434,6 +432,16 @@ func createTestEnv(t *testing.T, cluster v1alpha1.IntegrationPlatformCluster, sc
 	}
 }
 
+func createGroovyTestSource(name string, content string) v1alpha1.SourceSpec {
+	return v1alpha1.SourceSpec{
+		DataSpec: v1alpha1.DataSpec{
+			Name:    name,
+			Content: content,
+		},
+		Language: v1alpha1.LanguageGroovy,
+	}
+}
+
 func NewTraitTestCatalog() *Catalog {
 	return NewCatalog(context.TODO(), nil)
 }
@@ -100,22 +100,23 @@ func (trait *BaseTrait) InjectContext(ctx context.Context) {
 
 // A Environment provides the context where the trait is executed
 type Environment struct {
-	CamelCatalog   *camel.RuntimeCatalog
-	RuntimeVersion string
-	Catalog        *Catalog
-	C              context.Context
-	Client         client.Client
-	Platform       *v1alpha1.IntegrationPlatform
-	IntegrationKit *v1alpha1.IntegrationKit
-	Integration    *v1alpha1.Integration
-	Resources      *kubernetes.Collection
-	PostActions    []func(*Environment) error
-	PostProcessors []func(*Environment) error
-	Steps          []builder.Step
-	BuildDir       string
-	ExecutedTraits []Trait
-	EnvVars        []corev1.EnvVar
-	Classpath      *strset.Set
+	CamelCatalog    *camel.RuntimeCatalog
+	RuntimeVersion  string
+	Catalog         *Catalog
+	C               context.Context
+	Client          client.Client
+	Platform        *v1alpha1.IntegrationPlatform
+	IntegrationKit  *v1alpha1.IntegrationKit
+	Integration     *v1alpha1.Integration
+	TraitsConfigMap *corev1.ConfigMap
+	Resources       *kubernetes.Collection
+	PostActions     []func(*Environment) error
+	PostProcessors  []func(*Environment) error
+	Steps           []builder.Step
+	BuildDir        string
+	ExecutedTraits  []Trait
+	EnvVars         []corev1.EnvVar
+	Classpath       *strset.Set
 }
 
 // ControllerStrategy is used to determine the kind of controller that needs to be created for the integration
@@ -254,6 +255,20 @@ func (e *Environment) DetermineRuntimeVersion() string {
 	return version
 }
 
+// DetermineMaskingRules returns the rules masking the sensitive data out of the integration logs and traced
+// messages, that are the union of the rules of the platform and of the integration
+func (e *Environment) DetermineMaskingRules() *v1alpha1.MaskingSpec {
+	var platform, integration *v1alpha1.MaskingSpec
+	if e.Platform != nil {
+		platform = e.Platform.Spec.Masking
+	}
+	if e.Integration != nil {
+		integration = e.Integration.Spec.Masking
+	}
+
+	return v1alpha1.MergeMaskingSpecs(platform, integration)
+}
+
 // ComputeConfigMaps --
 func (e *Environment) ComputeConfigMaps() []runtime.Object {
 	sources := e.Integration.Sources()
@@ -19,7 +19,10 @@ package trait
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"path"
 	"reflect"
 	"regexp"
 	"strings"
@@ -29,10 +32,17 @@ import (
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/client"
+	"github.com/apache/camel-k/pkg/metadata"
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/kubernetes"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// routeLabelPrefix prefixes the labels identifying the routes of an integration, e.g. route.camel.apache.org/orders
+const routeLabelPrefix = "route.camel.apache.org/"
+
 // GetIntegrationKit retrieves the kit set on the integration
 func GetIntegrationKit(ctx context.Context, c client.Client, integration *v1alpha1.Integration) (*v1alpha1.IntegrationKit, error) {
 	if integration.Status.Kit == "" {
@@ -164,3 +174,126 @@ func decodeTraitSpec(in *v1alpha1.TraitSpec, target interface{}) error {
 
 	return decoder.Decode(in.Configuration)
 }
+
+// exposedPaths returns the HTTP paths the integration should be reachable on, either explicitly
+// configured as a comma-separated list or taken from the REST DSL definitions of its sources.
+// An empty result means that the integration must be exposed on all paths.
+func exposedPaths(ctx context.Context, c client.Client, e *Environment, paths string) ([]string, error) {
+	answer := make([]string, 0)
+
+	if paths != "" {
+		for _, p := range strings.Split(paths, ",") {
+			p = strings.TrimSpace(p)
+			if p == "/" {
+				return []string{}, nil
+			}
+			if p != "" {
+				util.StringSliceUniqueAdd(&answer, p)
+			}
+		}
+		return answer, nil
+	}
+
+	sources, err := kubernetes.ResolveIntegrationSources(ctx, c, e.Integration, e.Resources)
+	if err != nil {
+		return nil, err
+	}
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+
+	// HTTP consumers not declared through the REST DSL may listen on any path
+	if len(meta.HTTPURIs) > 0 {
+		return answer, nil
+	}
+	if util.StringSliceExists(meta.RestPaths, "/") {
+		return answer, nil
+	}
+
+	// the REST DSL paths are relative to the context path the REST component serves them under
+	contextPath := meta.RestContextPath
+	properties := e.CollectConfigurationPairs("property")
+	for _, key := range []string{"camel.rest.contextPath", "camel.rest.context-path"} {
+		if value, ok := properties[key]; ok {
+			contextPath = value
+		}
+	}
+	for _, p := range meta.RestPaths {
+		answer = append(answer, path.Join("/", contextPath, p))
+	}
+
+	return answer, nil
+}
+
+// routeLabels returns the labels identifying the routes of the integration that have an id, only the routes
+// exposed over HTTP, i.e. the REST DSL definitions and the routes consuming from HTTP endpoints, being kept when
+// exposed is true. The ids that cannot be part of a label name are skipped.
+func routeLabels(ctx context.Context, c client.Client, e *Environment, exposed bool) (map[string]string, error) {
+	sources, err := kubernetes.ResolveIntegrationSources(ctx, c, e.Integration, e.Resources)
+	if err != nil {
+		return nil, err
+	}
+	meta := metadata.ExtractAll(e.CamelCatalog, sources)
+
+	labels := make(map[string]string)
+	for _, route := range meta.Routes {
+		if route.ID == "" {
+			continue
+		}
+		if exposed && route.RestPath == "" && !util.StringSliceExists(meta.HTTPURIs, route.From) {
+			continue
+		}
+		if key := routeLabelPrefix + route.ID; len(validation.IsQualifiedName(key)) == 0 {
+			labels[key] = "true"
+		}
+	}
+
+	return labels, nil
+}
+
+// parsePathAnnotations parses a comma-separated list of <path>:<annotation>=<value> entries
+func parsePathAnnotations(pathAnnotations string) (map[string]map[string]string, error) {
+	answer := make(map[string]map[string]string)
+
+	for _, entry := range strings.Split(pathAnnotations, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pa := strings.SplitN(entry, ":", 2)
+		if len(pa) != 2 || !strings.HasPrefix(pa[0], "/") {
+			return nil, fmt.Errorf("cannot parse [%s] as <path>:<annotation>=<value>", entry)
+		}
+		kv := strings.SplitN(pa[1], "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("cannot parse [%s] as <path>:<annotation>=<value>", entry)
+		}
+
+		if _, ok := answer[pa[0]]; !ok {
+			answer[pa[0]] = make(map[string]string)
+		}
+		answer[pa[0]][kv[0]] = kv[1]
+	}
+
+	return answer, nil
+}
+
+var nonDNSCharsRegexp = regexp.MustCompile(`[^a-z0-9]+`)
+
+// pathResourceName returns the name of the resource exposing the given path of a service. The name ends with
+// a hash of the path, as paths like /a-b and /a/b, or sharing a long prefix, would otherwise get the same name
+func pathResourceName(service string, path string) string {
+	suffix := strings.Trim(nonDNSCharsRegexp.ReplaceAllString(strings.ToLower(path), "-"), "-")
+	if suffix == "" {
+		return service
+	}
+
+	hash := sha256.Sum256([]byte(path))
+	id := hex.EncodeToString(hash[:])[:8]
+
+	name := service + "-" + suffix
+	if len(name) > validation.DNS1123LabelMaxLength-len(id)-1 {
+		name = strings.TrimRight(name[:validation.DNS1123LabelMaxLength-len(id)-1], "-")
+	}
+
+	return name + "-" + id
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package trait
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -97,3 +98,34 @@ func TestCollectConfigurationPairs(t *testing.T) {
 	assert.Equal(t, "platform", pairs["p3"])
 	assert.Equal(t, "integration", pairs["p4"])
 }
+
+func TestParsePathAnnotations(t *testing.T) {
+	annotations, err := parsePathAnnotations("/api:nginx.ingress.kubernetes.io/rewrite-target=/, /api:a=b,/health:c=d")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]map[string]string{
+		"/api": {
+			"nginx.ingress.kubernetes.io/rewrite-target": "/",
+			"a": "b",
+		},
+		"/health": {
+			"c": "d",
+		},
+	}, annotations)
+
+	_, err = parsePathAnnotations("api:a=b")
+	assert.NotNil(t, err)
+	_, err = parsePathAnnotations("/api:a")
+	assert.NotNil(t, err)
+}
+
+func TestPathResourceName(t *testing.T) {
+	assert.Equal(t, "svc", pathResourceName("svc", ""))
+	assert.Equal(t, "svc", pathResourceName("svc", "/"))
+	assert.Regexp(t, "^svc-api-v1-orders-[0-9a-f]{8}$", pathResourceName("svc", "/api/v1/Orders/"))
+
+	// paths mapped to the same characters, or sharing the truncated prefix, get distinct names
+	assert.NotEqual(t, pathResourceName("svc", "/a-b"), pathResourceName("svc", "/a/b"))
+	long := "/" + strings.Repeat("a", 70)
+	assert.NotEqual(t, pathResourceName("svc", long+"/x"), pathResourceName("svc", long+"/y"))
+	assert.Len(t, pathResourceName("svc", long+"/x"), 63)
+}
@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// warmPoolLabel marks the warm pods of the integration it's set to
+const warmPoolLabel = "camel.apache.org/warm-pool"
+
+// warmPoolCommand keeps the warm pods idle until they are preempted or deleted
+var warmPoolCommand = []string{"/bin/sh", "-c", "trap 'exit 0' TERM INT; while true; do sleep 3600 & wait $!; done"}
+
+// The warm-pool trait keeps pre-started idle pods of the integration image on the cluster, so that scaling the
+// integration out, e.g. from zero replicas, does not wait for the image to be pulled nor for capacity to be made
+// available. The warm pods request the same resources and are scheduled with the same constraints as the integration
+// pods, and are expected to run with a priority class lower than the integration one, so that they are preempted
+// by the integration pods when the nodes are full. They don't run the integration routes and don't receive any
+// traffic: deploying routes into a running runtime requires an authenticated deployment contract from the runtime.
+// It applies to the integrations deployed as deployments.
+type warmPoolTrait struct {
+	BaseTrait     `property:",squash"`
+	Size          int32  `property:"size"`
+	PriorityClass string `property:"priority-class"`
+}
+
+func newWarmPoolTrait() *warmPoolTrait {
+	return &warmPoolTrait{
+		BaseTrait: newBaseTrait("warm-pool"),
+		Size:      1,
+	}
+}
+
+func (t *warmPoolTrait) Configure(e *Environment) (bool, error) {
+	if t.Enabled == nil || !*t.Enabled {
+		return false, nil
+	}
+
+	return e.IntegrationInPhase(v1alpha1.IntegrationPhaseDeploying), nil
+}
+
+func (t *warmPoolTrait) Apply(e *Environment) error {
+	// the warm pods are created from the integration deployment, once customized by the other traits
+	e.PostProcessors = append(e.PostProcessors, func(environment *Environment) error {
+		deployment := environment.Resources.GetDeployment(func(d *appsv1.Deployment) bool {
+			return d.Name == environment.Integration.Name
+		})
+		if deployment == nil {
+			return nil
+		}
+
+		environment.Resources.Add(t.warmDeployment(environment.Integration, deployment))
+		return nil
+	})
+
+	return nil
+}
+
+// warmDeployment returns the deployment of the warm pods of the given integration deployment. The pods don't get
+// the integration label, so that they are not selected by the integration services.
+func (t *warmPoolTrait) warmDeployment(integration *v1alpha1.Integration, deployment *appsv1.Deployment) *appsv1.Deployment {
+	labels := copyLabels(deployment.Labels)
+	labels[warmPoolLabel] = integration.Name
+	selector := map[string]string{
+		warmPoolLabel: integration.Name,
+	}
+
+	spec := deployment.Spec.Template.Spec
+	containers := make([]corev1.Container, 0, 1)
+	for _, c := range spec.Containers {
+		if c.Name != integration.Name {
+			continue
+		}
+		containers = append(containers, corev1.Container{
+			Name:            c.Name,
+			Image:           c.Image,
+			ImagePullPolicy: c.ImagePullPolicy,
+			Command:         warmPoolCommand,
+			Resources:       c.Resources,
+		})
+	}
+
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            integration.Name + "-warm-pool",
+			Namespace:       deployment.Namespace,
+			Labels:          labels,
+			OwnerReferences: deployment.OwnerReferences,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &t.Size,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: selector,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: selector,
+				},
+				Spec: corev1.PodSpec{
+					Containers:         containers,
+					ImagePullSecrets:   spec.ImagePullSecrets,
+					ServiceAccountName: spec.ServiceAccountName,
+					NodeSelector:       spec.NodeSelector,
+					Affinity:           spec.Affinity,
+					Tolerations:        spec.Tolerations,
+					PriorityClassName:  t.PriorityClass,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trait
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func createWarmPoolTestEnv(t *testing.T) (*warmPoolTrait, *Environment) {
+	trait := newWarmPoolTrait()
+	trait.InjectContext(context.TODO())
+	trait.Enabled = &[]bool{true}[0]
+
+	env := createIntegrationTestEnv(t, "bursty", v1alpha1.IntegrationPhaseDeploying)
+
+	return trait, env
+}
+
+func TestWarmPoolDisabledByDefault(t *testing.T) {
+	trait, e := createWarmPoolTestEnv(t)
+	trait.Enabled = nil
+
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.False(t, enabled)
+}
+
+func TestWarmPoolDeployment(t *testing.T) {
+	trait, e := createWarmPoolTestEnv(t)
+	trait.Size = 2
+	trait.PriorityClass = "overprovisioning"
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+	e.Resources.Add(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "bursty",
+			Namespace:   "ns",
+			Labels:      map[string]string{"camel.apache.org/integration": "bursty"},
+			Annotations: map[string]string{v1alpha1.IntegrationMaxRestartsAnnotation: "5"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"camel.apache.org/integration": "bursty"},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"camel.apache.org/integration": "bursty"},
+				},
+				Spec: corev1.PodSpec{
+					NodeSelector: map[string]string{"pool": "integrations"},
+					Containers: []corev1.Container{
+						{
+							Name:      "bursty",
+							Image:     "registry/kit:1",
+							Resources: resources,
+							Env:       []corev1.EnvVar{{Name: "CAMEL_K_ROUTES", Value: "file:/etc/camel/sources/routes.groovy"}},
+							Ports:     []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}},
+						},
+						{
+							Name:  "fluent-bit",
+							Image: "fluent/fluent-bit:1.2",
+						},
+					},
+				},
+			},
+		},
+	})
+
+	enabled, err := trait.Configure(e)
+	assert.Nil(t, err)
+	assert.True(t, enabled)
+	assert.Nil(t, trait.Apply(e))
+
+	assert.Len(t, e.PostProcessors, 1)
+	assert.Nil(t, e.PostProcessors[0](e))
+
+	warm := e.Resources.GetDeployment(func(d *appsv1.Deployment) bool {
+		return d.Name == "bursty-warm-pool"
+	})
+	assert.NotNil(t, warm)
+	assert.Equal(t, "bursty", warm.Labels["camel.apache.org/integration"])
+	assert.Empty(t, warm.Annotations)
+	assert.Equal(t, int32(2), *warm.Spec.Replicas)
+
+	// the warm pods are not selected by the integration services
+	assert.Equal(t, map[string]string{warmPoolLabel: "bursty"}, warm.Spec.Selector.MatchLabels)
+	assert.Equal(t, map[string]string{warmPoolLabel: "bursty"}, warm.Spec.Template.Labels)
+
+	spec := warm.Spec.Template.Spec
+	assert.Equal(t, "overprovisioning", spec.PriorityClassName)
+	assert.Equal(t, map[string]string{"pool": "integrations"}, spec.NodeSelector)
+	assert.Len(t, spec.Containers, 1)
+	assert.Equal(t, "registry/kit:1", spec.Containers[0].Image)
+	assert.Equal(t, warmPoolCommand, spec.Containers[0].Command)
+	assert.Equal(t, resources, spec.Containers[0].Resources)
+	assert.Empty(t, spec.Containers[0].Env)
+	assert.Empty(t, spec.Containers[0].Ports)
+}
+
+func TestWarmPoolWithoutDeployment(t *testing.T) {
+	trait, e := createWarmPoolTestEnv(t)
+
+	assert.Nil(t, trait.Apply(e))
+	assert.Nil(t, e.PostProcessors[0](e))
+	assert.Equal(t, 0, e.Resources.Size())
+}
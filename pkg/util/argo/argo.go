@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package argo
+
+import (
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// RolloutAPIVersion --
+	RolloutAPIVersion = "argoproj.io/v1alpha1"
+	// RolloutKind --
+	RolloutKind = "Rollout"
+)
+
+// IsRolloutInstalled returns true if we are connected to a cluster with the Argo Rollouts custom resource installed
+func IsRolloutInstalled(c kubernetes.Interface) (bool, error) {
+	resources, err := c.Discovery().ServerResourcesForGroupVersion(RolloutAPIVersion)
+	if err != nil && k8serrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	// the group is shared with other Argo projects, e.g. Argo Workflows
+	for _, r := range resources.APIResources {
+		if r.Kind == RolloutKind {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,145 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+const (
+	// AnnotationRequestedBy holds the user that requested the creation or the change of a resource
+	AnnotationRequestedBy = "camel.apache.org/audit.requested-by"
+	// AnnotationTrigger holds a description of the change that caused a resource to be created
+	AnnotationTrigger = "camel.apache.org/audit.trigger"
+	// AnnotationDigest holds the digest of the integration a resource has been created for
+	AnnotationDigest = "camel.apache.org/audit.digest"
+)
+
+// Log is the audit stream, it is kept apart from the operator logs so that audit
+// records can be filtered out and collected on their own
+var Log = log.Log.WithName("audit")
+
+// Trigger describes the change of a resource that caused a build or a deployment
+type Trigger struct {
+	Kind                string
+	Name                string
+	PreviousDigest      string
+	Digest              string
+	AddedDependencies   []string
+	RemovedDependencies []string
+}
+
+// NewTrigger computes the change between the previous and the current state of a resource
+func NewTrigger(kind string, name string, previousDigest string, digest string, previousDependencies []string, dependencies []string) Trigger {
+	return Trigger{
+		Kind:                kind,
+		Name:                name,
+		PreviousDigest:      previousDigest,
+		Digest:              digest,
+		AddedDependencies:   difference(dependencies, previousDependencies),
+		RemovedDependencies: difference(previousDependencies, dependencies),
+	}
+}
+
+func (t Trigger) String() string {
+	changes := make([]string, 0)
+
+	switch {
+	case t.PreviousDigest == "":
+		changes = append(changes, "created")
+	case t.PreviousDigest != t.Digest:
+		changes = append(changes, fmt.Sprintf("digest %s -> %s", t.PreviousDigest, t.Digest))
+	}
+	if len(t.AddedDependencies) > 0 {
+		changes = append(changes, "added dependencies "+strings.Join(t.AddedDependencies, ","))
+	}
+	if len(t.RemovedDependencies) > 0 {
+		changes = append(changes, "removed dependencies "+strings.Join(t.RemovedDependencies, ","))
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "unchanged")
+	}
+
+	return fmt.Sprintf("%s/%s: %s", t.Kind, t.Name, strings.Join(changes, "; "))
+}
+
+// Annotate records the requester and the trigger on the given resource
+func Annotate(meta *metav1.ObjectMeta, requestedBy string, trigger Trigger) {
+	if meta.Annotations == nil {
+		meta.Annotations = make(map[string]string)
+	}
+
+	if requestedBy != "" {
+		meta.Annotations[AnnotationRequestedBy] = requestedBy
+	}
+	if trigger.Digest != "" {
+		meta.Annotations[AnnotationDigest] = trigger.Digest
+	}
+
+	meta.Annotations[AnnotationTrigger] = trigger.String()
+}
+
+// CopyAnnotations copies the audit annotations of the source resource to the target one
+func CopyAnnotations(source metav1.ObjectMeta, target *metav1.ObjectMeta) {
+	for _, key := range []string{AnnotationRequestedBy, AnnotationTrigger, AnnotationDigest} {
+		if value, ok := source.Annotations[key]; ok {
+			if target.Annotations == nil {
+				target.Annotations = make(map[string]string)
+			}
+			target.Annotations[key] = value
+		}
+	}
+}
+
+// Record emits an audit record for the given resource to the audit stream
+func Record(event string, kind string, meta metav1.ObjectMeta, keysAndValues ...interface{}) {
+	kv := []interface{}{
+		"kind", kind,
+		"ns", meta.Namespace,
+		"name", meta.Name,
+		"requested-by", meta.Annotations[AnnotationRequestedBy],
+		"trigger", meta.Annotations[AnnotationTrigger],
+	}
+
+	Log.Info(event, append(kv, keysAndValues...)...)
+}
+
+// difference returns the sorted items of the first slice that are not present in the second one
+func difference(a []string, b []string) []string {
+	set := make(map[string]bool, len(b))
+	for _, item := range b {
+		set[item] = true
+	}
+
+	diff := make([]string, 0)
+	for _, item := range a {
+		if !set[item] {
+			diff = append(diff, item)
+		}
+	}
+
+	sort.Strings(diff)
+
+	return diff
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTrigger(t *testing.T) {
+	trigger := NewTrigger("Integration", "flow", "", "v1", nil, []string{"camel:log"})
+	assert.Equal(t, "Integration/flow: created; added dependencies camel:log", trigger.String())
+
+	trigger = NewTrigger("Integration", "flow", "v1", "v2",
+		[]string{"camel:log", "camel:http"},
+		[]string{"camel:log", "camel:timer", "camel:jms"})
+	assert.Equal(t, []string{"camel:jms", "camel:timer"}, trigger.AddedDependencies)
+	assert.Equal(t, []string{"camel:http"}, trigger.RemovedDependencies)
+	assert.Equal(t, "Integration/flow: digest v1 -> v2; added dependencies camel:jms,camel:timer; removed dependencies camel:http", trigger.String())
+
+	trigger = NewTrigger("Integration", "flow", "v2", "v2", []string{"camel:log"}, []string{"camel:log"})
+	assert.Equal(t, "Integration/flow: unchanged", trigger.String())
+}
+
+func TestAnnotations(t *testing.T) {
+	kit := metav1.ObjectMeta{}
+	Annotate(&kit, "developer", NewTrigger("Integration", "flow", "", "v1", nil, nil))
+
+	assert.Equal(t, "developer", kit.Annotations[AnnotationRequestedBy])
+	assert.Equal(t, "v1", kit.Annotations[AnnotationDigest])
+	assert.Equal(t, "Integration/flow: created", kit.Annotations[AnnotationTrigger])
+
+	build := metav1.ObjectMeta{}
+	CopyAnnotations(kit, &build)
+	assert.Equal(t, kit.Annotations, build.Annotations)
+
+	other := metav1.ObjectMeta{}
+	CopyAnnotations(metav1.ObjectMeta{}, &other)
+	assert.Nil(t, other.Annotations)
+}
@@ -18,10 +18,12 @@ limitations under the License.
 package camel
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/Masterminds/semver"
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/jvm"
 	"github.com/apache/camel-k/pkg/util/log"
 )
 
@@ -90,3 +92,38 @@ func FindBestSemVerMatch(constraint *semver.Constraints, catalogs []v1alpha1.Cam
 
 	return nil, nil
 }
+
+// minimumCamelVersionForJava maps a Java version to the minimum Camel version supporting it
+var minimumCamelVersionForJava = map[string]string{
+	"8":  "2.18.0",
+	"11": "2.23.0",
+}
+
+// ValidateJavaVersion checks if the given Java version can be used to build and run the given Camel version
+func ValidateJavaVersion(camelVersion string, javaVersion string) error {
+	if javaVersion == "" {
+		return nil
+	}
+
+	minimum, ok := minimumCamelVersionForJava[jvm.NormalizeJavaVersion(javaVersion)]
+	if !ok {
+		return fmt.Errorf("unsupported java version: %s", javaVersion)
+	}
+
+	cv, err := semver.NewVersion(camelVersion)
+	if err != nil {
+		log.Debugf("Invalid semver version %s, skip java version validation", camelVersion)
+		return nil
+	}
+
+	mv, err := semver.NewVersion(minimum)
+	if err != nil {
+		return err
+	}
+
+	if cv.LessThan(mv) {
+		return fmt.Errorf("java version %s requires camel version >= %s (found %s)", javaVersion, minimum, camelVersion)
+	}
+
+	return nil
+}
@@ -94,3 +94,13 @@ func TestFindRangeMatch(t *testing.T) {
 	assert.NotNil(t, c)
 	assert.Equal(t, "2.23.1", c.Version)
 }
+
+func TestValidateJavaVersion(t *testing.T) {
+	assert.Nil(t, ValidateJavaVersion("2.24.0", ""))
+	assert.Nil(t, ValidateJavaVersion("2.24.0", "8"))
+	assert.Nil(t, ValidateJavaVersion("2.24.0", "1.8"))
+	assert.Nil(t, ValidateJavaVersion("2.24.0", "11"))
+	assert.Nil(t, ValidateJavaVersion("3.0.0-M2", "11"))
+	assert.NotNil(t, ValidateJavaVersion("2.22.1", "11"))
+	assert.NotNil(t, ValidateJavaVersion("2.24.0", "7"))
+}
@@ -20,13 +20,21 @@ package digest
 import (
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"math/rand"
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/util/defaults"
 )
 
+// platformKitDigestLength is the length of the platform kit digests, that is enough to make collisions
+// unlikely while keeping the kit names short
+const platformKitDigestLength = 20
+
 // ComputeForIntegration a digest of the fields that are relevant for the deployment
 // Produces a digest that can be used as docker image tag
 func ComputeForIntegration(integration *v1alpha1.Integration) (string, error) {
@@ -63,6 +71,35 @@ func ComputeForIntegration(integration *v1alpha1.Integration) (string, error) {
 		}
 	}
 
+	// Integration libraries
+	for _, item := range integration.Spec.Libraries {
+		if _, err := hash.Write([]byte(item)); err != nil {
+			return "", err
+		}
+	}
+
+	// Integration init containers
+	if len(integration.Spec.InitContainers) > 0 {
+		containers, err := json.Marshal(integration.Spec.InitContainers)
+		if err != nil {
+			return "", err
+		}
+		if _, err := hash.Write(containers); err != nil {
+			return "", err
+		}
+	}
+
+	// Integration masking rules
+	if integration.Spec.Masking != nil {
+		rules, err := json.Marshal(integration.Spec.Masking)
+		if err != nil {
+			return "", err
+		}
+		if _, err := hash.Write(rules); err != nil {
+			return "", err
+		}
+	}
+
 	// Integration configuration
 	for _, item := range integration.Spec.Configuration {
 		if _, err := hash.Write([]byte(item.String())); err != nil {
@@ -100,6 +137,54 @@ func ComputeForIntegrationKit(kit *v1alpha1.IntegrationKit) (string, error) {
 	return digest, nil
 }
 
+// ComputeForPlatformKit a digest of the fields of the integration that characterize the platform kit
+// it requires. Produces a lowercase digest that can be used in resource names, so that the integrations
+// requiring the same kit agree on its name
+func ComputeForPlatformKit(integration *v1alpha1.Integration) (string, error) {
+	hash := sha256.New()
+	// Operator version is relevant
+	if _, err := hash.Write([]byte(defaults.Version)); err != nil {
+		return "", err
+	}
+	if _, err := hash.Write([]byte(integration.Status.CamelVersion + "/" + integration.Status.RuntimeVersion)); err != nil {
+		return "", err
+	}
+
+	// Kit dependencies and repositories, regardless of their order
+	for _, items := range [][]string{integration.Status.Dependencies, integration.Spec.Repositories} {
+		sorted := append([]string(nil), items...)
+		sort.Strings(sorted)
+		if _, err := hash.Write([]byte(strings.Join(sorted, ",") + "\n")); err != nil {
+			return "", err
+		}
+	}
+
+	// Kit traits, maps being marshalled with sorted keys
+	if len(integration.Spec.Traits) > 0 {
+		traits, err := json.Marshal(integration.Spec.Traits)
+		if err != nil {
+			return "", err
+		}
+		if _, err := hash.Write(traits); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:platformKitDigestLength], nil
+}
+
+// ComputeForSource returns a digest of the source, i.e. of its name, language and content, that changes whenever
+// the metadata extracted from the source may change
+func ComputeForSource(source v1alpha1.SourceSpec) (string, error) {
+	data, err := json.Marshal(source)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
 // Random --
 func Random() string {
 	return "v" + strconv.FormatInt(rand.Int63(), 10)
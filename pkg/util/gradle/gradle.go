@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gradle
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/util"
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+// Log --
+var Log = log.WithName("gradle")
+
+// GenerateProjectStructure --
+func GenerateProjectStructure(context Context) error {
+	if err := util.WriteFileWithBytesMarshallerContent(context.Path, "build.gradle", context.Project); err != nil {
+		return err
+	}
+
+	if len(context.Project.Properties) > 0 {
+		if err := util.WriteFileWithContent(context.Path, "gradle.properties", context.Project.PropertiesBytes()); err != nil {
+			return err
+		}
+	}
+
+	settings := fmt.Sprintf("rootProject.name = '%s'\n", context.Project.Name)
+	return util.WriteFileWithContent(context.Path, "settings.gradle", []byte(settings))
+}
+
+// Run --
+func Run(context Context) error {
+	if err := GenerateProjectStructure(context); err != nil {
+		return err
+	}
+
+	gradleCmd := "gradle"
+	if c, ok := os.LookupEnv("GRADLE_CMD"); ok {
+		gradleCmd = c
+	}
+
+	writeLocks := false
+	if context.LockDir != "" {
+		restored, err := copyLockFile(context.LockDir, context.Path)
+		if err != nil {
+			return err
+		}
+		writeLocks = !restored
+	}
+
+	args := append(context.AdditionalArguments, "--no-daemon", "--console=plain")
+	if writeLocks {
+		args = append(args, "--write-locks")
+	}
+
+	cmd := exec.Command(gradleCmd, args...)
+	cmd.Dir = context.Path
+	if len(context.Env) > 0 {
+		cmd.Env = append(os.Environ(), context.Env...)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	Log.Infof("execute: %s", strings.Join(cmd.Args, " "))
+
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	if writeLocks {
+		if _, err := copyLockFile(context.Path, context.LockDir); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExtraOptions returns the options making the mavenLocal() repository point to the given local repository
+func ExtraOptions(localRepo string) []string {
+	if _, err := os.Stat(localRepo); err == nil {
+		return []string{"-Dmaven.repo.local=" + localRepo}
+	}
+	return []string{}
+}
+
+// copyLockFile copies the lock file from the source to the destination directory, if any
+func copyLockFile(from string, to string) (bool, error) {
+	data, err := ioutil.ReadFile(path.Join(from, LockFile))
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, util.WriteFileWithContent(to, LockFile, data)
+}
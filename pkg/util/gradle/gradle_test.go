@@ -0,0 +1,210 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gradle
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/maven"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const expectedBuild = `plugins {
+    id 'java'
+}
+
+group = 'org.apache.camel.k.integration'
+version = '1.0.0'
+
+repositories {
+    mavenLocal()
+    mavenCentral()
+    maven { url 'https://repository.apache.org/content/repositories/snapshots' }
+}
+
+dependencyLocking {
+    lockAllConfigurations()
+}
+
+dependencies {
+    implementation platform('org.apache.camel:camel-bom:2.24.0')
+    implementation 'org.apache.camel.k:camel-k-runtime-jvm:0.3.3'
+    implementation('org.apache.camel:camel-core') {
+        exclude group: 'com.sun.xml.bind', module: 'jaxb-core'
+    }
+    runtimeOnly 'org.apache.logging.log4j:log4j-slf4j-impl:2.11.2'
+}
+`
+
+func TestBuildGeneration(t *testing.T) {
+	p := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0.0")
+	p.Repositories = []maven.Repository{
+		maven.NewRepository("https://repository.apache.org/content/repositories/snapshots@snapshots"),
+	}
+	p.DependencyManagement.Dependencies = []maven.Dependency{
+		{
+			GroupID:    "org.apache.camel",
+			ArtifactID: "camel-bom",
+			Version:    "2.24.0",
+			Type:       "pom",
+			Scope:      "import",
+		},
+	}
+	p.AddDependencyGAV("org.apache.camel.k", "camel-k-runtime-jvm", "0.3.3")
+	p.AddDependencyGAV("org.apache.camel", "camel-core", "")
+	p.AddDependencyExclusion(maven.Dependency{GroupID: "org.apache.camel", ArtifactID: "camel-core"}, maven.Exclusion{
+		GroupID:    "com.sun.xml.bind",
+		ArtifactID: "jaxb-core",
+	})
+	p.AddDependency(maven.Dependency{
+		GroupID:    "org.apache.logging.log4j",
+		ArtifactID: "log4j-slf4j-impl",
+		Version:    "2.11.2",
+		Scope:      "runtime",
+	})
+
+	project := NewProjectFromMaven(p)
+	assert.Equal(t, "camel-k-integration", project.Name)
+
+	data, err := project.MarshalBytes()
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), expectedBuild)
+	assert.Contains(t, string(data), "task "+DependencyListTask)
+}
+
+func TestApplySettings(t *testing.T) {
+	p := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0.0")
+	p.Repositories = []maven.Repository{
+		maven.NewRepository("https://repository.apache.org/content/repositories/snapshots@id=apache-snapshots"),
+	}
+	project := NewProjectFromMaven(p)
+
+	settings, err := maven.ParseSettings([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<settings xmlns="http://maven.apache.org/SETTINGS/1.0.0">
+  <servers>
+    <server>
+      <id>internal</id>
+      <username>user</username>
+      <password>pa'ss</password>
+    </server>
+  </servers>
+  <mirrors>
+    <mirror>
+      <id>internal</id>
+      <url>https://repo.example.com/maven</url>
+      <mirrorOf>*,!apache-snapshots</mirrorOf>
+    </mirror>
+  </mirrors>
+  <proxies>
+    <proxy>
+      <id>proxy</id>
+      <active>true</active>
+      <protocol>https</protocol>
+      <host>proxy.example.com</host>
+      <port>3128</port>
+      <username>proxy-user</username>
+      <password>proxy-password</password>
+      <nonProxyHosts>*.example.com|localhost</nonProxyHosts>
+    </proxy>
+  </proxies>
+  <profiles>
+    <profile>
+      <id>extra</id>
+      <activation>
+        <activeByDefault>true</activeByDefault>
+      </activation>
+      <repositories>
+        <repository>
+          <id>extra</id>
+          <url>https://extra.example.com/maven</url>
+        </repository>
+      </repositories>
+    </profile>
+  </profiles>
+</settings>
+`))
+	assert.Nil(t, err)
+
+	project.ApplySettings(settings)
+
+	data, err := project.MarshalBytes()
+	assert.Nil(t, err)
+	assert.NotContains(t, string(data), "mavenCentral()")
+	assert.NotContains(t, string(data), "https://extra.example.com/maven")
+	assert.Contains(t, string(data), `repositories {
+    mavenLocal()
+    maven { url 'https://repository.apache.org/content/repositories/snapshots' }
+    maven {
+        url 'https://repo.example.com/maven'
+        credentials {
+            username 'user'
+            password 'pa\'ss'
+        }
+    }
+}`)
+
+	assert.Equal(t, `systemProp.https.nonProxyHosts=*.example.com|localhost
+systemProp.https.proxyHost=proxy.example.com
+systemProp.https.proxyPassword=proxy-password
+systemProp.https.proxyPort=3128
+systemProp.https.proxyUser=proxy-user
+`, string(project.PropertiesBytes()))
+}
+
+func TestRunPersistsLocks(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "gradle-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	// the fake gradle records its arguments and writes the locks when requested
+	script := path.Join(tmpDir, "gradle")
+	assert.Nil(t, ioutil.WriteFile(script, []byte(`#!/bin/sh
+echo "$@" > args
+for a in "$@"; do
+  if [ "$a" = "--write-locks" ]; then
+    echo "org.apache.camel:camel-core:2.24.0=runtimeClasspath" > gradle.lockfile
+  fi
+done
+`), 0755))
+	assert.Nil(t, os.Setenv("GRADLE_CMD", script))
+	defer os.Unsetenv("GRADLE_CMD")
+
+	lockDir := path.Join(tmpDir, "locks")
+	p := maven.NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0.0")
+
+	for i, buildDir := range []string{"first", "second"} {
+		gc := NewContext(path.Join(tmpDir, buildDir), NewProjectFromMaven(p))
+		gc.LockDir = lockDir
+		gc.AddArgument(DependencyListTask)
+		assert.Nil(t, Run(gc))
+
+		args, err := ioutil.ReadFile(path.Join(gc.Path, "args"))
+		assert.Nil(t, err)
+		// the locks are only written by the first build, the following ones enforcing them
+		assert.Equal(t, i == 0, strings.Contains(string(args), "--write-locks"))
+
+		locks, err := ioutil.ReadFile(path.Join(gc.Path, LockFile))
+		assert.Nil(t, err)
+		assert.Equal(t, "org.apache.camel:camel-core:2.24.0=runtimeClasspath\n", string(locks))
+	}
+}
@@ -0,0 +1,369 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gradle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+// DependencyListTask is the name of the task that writes the resolved runtime classpath
+// to build/dependencies.yaml using the same layout as the camel-k maven plugin
+const DependencyListTask = "generateDependencyList"
+
+// LockFile is the name of the file holding the dependency locks of the project
+const LockFile = "gradle.lockfile"
+
+// NewContext --
+func NewContext(buildDir string, project Project) Context {
+	return Context{
+		Path:                buildDir,
+		Project:             project,
+		AdditionalArguments: make([]string, 0),
+	}
+}
+
+// Context --
+type Context struct {
+	Path                string
+	Project             Project
+	AdditionalArguments []string
+	// Env holds extra KEY=value environment variables of the Gradle process
+	Env []string
+	// LockDir is the directory the dependency locks are persisted to across builds, the locks being
+	// written by the first build of a project and enforced by the following ones
+	LockDir string
+}
+
+// AddArgument --
+func (c *Context) AddArgument(argument string) {
+	c.AdditionalArguments = append(c.AdditionalArguments, argument)
+}
+
+// AddArguments --
+func (c *Context) AddArguments(arguments ...string) {
+	c.AdditionalArguments = append(c.AdditionalArguments, arguments...)
+}
+
+// Project represent a gradle project
+type Project struct {
+	Name    string
+	Group   string
+	Version string
+	// Central tells if Maven Central is used, that is when it is not mirrored
+	Central      bool
+	Repositories []Repository
+	Platforms    []string
+	Dependencies []Dependency
+	// Properties holds the gradle.properties entries, i.e. the proxies system properties
+	Properties map[string]string
+}
+
+// Repository represent a gradle maven repository declaration
+type Repository struct {
+	ID       string
+	URL      string
+	Username string
+	Password string
+}
+
+// Dependency represent a gradle dependency declaration
+type Dependency struct {
+	Configuration string
+	Notation      string
+	Exclusions    []maven.Exclusion
+}
+
+// NewProjectFromMaven translates the given maven project to an equivalent gradle project
+func NewProjectFromMaven(p maven.Project) Project {
+	project := Project{
+		Name:         p.ArtifactID,
+		Group:        p.GroupID,
+		Version:      p.Version,
+		Central:      true,
+		Repositories: make([]Repository, 0, len(p.Repositories)),
+		Platforms:    make([]string, 0, len(p.DependencyManagement.Dependencies)),
+		Dependencies: make([]Dependency, 0, len(p.Dependencies)),
+		Properties:   make(map[string]string),
+	}
+
+	for _, r := range p.Repositories {
+		project.Repositories = append(project.Repositories, Repository{ID: r.ID, URL: r.URL})
+	}
+
+	for _, d := range p.DependencyManagement.Dependencies {
+		if d.Scope == "import" {
+			project.Platforms = append(project.Platforms, notation(d))
+		}
+	}
+
+	for _, d := range p.Dependencies {
+		dep := Dependency{
+			Configuration: "implementation",
+			Notation:      notation(d),
+		}
+
+		switch d.Scope {
+		case "runtime":
+			dep.Configuration = "runtimeOnly"
+		case "provided":
+			dep.Configuration = "compileOnly"
+		case "test":
+			dep.Configuration = "testImplementation"
+		}
+
+		if d.Exclusions != nil {
+			dep.Exclusions = *d.Exclusions
+		}
+
+		project.Dependencies = append(project.Dependencies, dep)
+	}
+
+	return project
+}
+
+// ApplySettings configures the project as Maven would be by the given settings: the repositories of the
+// profiles active by default are added, the mirrors replace the repositories they mirror, the servers provide
+// the repositories credentials and the active proxies are set as system properties
+func (p *Project) ApplySettings(settings maven.Settings) {
+	for _, profile := range settings.Profiles {
+		if !profile.Activation.ActiveByDefault {
+			continue
+		}
+		for _, r := range profile.Repositories {
+			p.Repositories = append(p.Repositories, Repository{ID: r.ID, URL: r.URL})
+		}
+	}
+
+	mirrors := make([]maven.Mirror, 0)
+	if settings.Mirrors != nil {
+		mirrors = *settings.Mirrors
+	}
+	for _, m := range mirrors {
+		repositories := make([]Repository, 0, len(p.Repositories)+1)
+		mirrored := false
+		for _, r := range p.Repositories {
+			if mirrorOf(m.MirrorOf, r.ID) {
+				mirrored = true
+				continue
+			}
+			repositories = append(repositories, r)
+		}
+		if p.Central && mirrorOf(m.MirrorOf, "central") {
+			p.Central = false
+			mirrored = true
+		}
+		if mirrored {
+			repositories = append(repositories, Repository{ID: m.ID, URL: m.URL})
+		}
+		p.Repositories = repositories
+	}
+
+	servers := make([]maven.Server, 0)
+	if settings.Servers != nil {
+		servers = *settings.Servers
+	}
+	for _, s := range servers {
+		for i := range p.Repositories {
+			if p.Repositories[i].ID == s.ID {
+				p.Repositories[i].Username = s.Username
+				p.Repositories[i].Password = s.Password
+			}
+		}
+	}
+
+	if p.Properties == nil {
+		p.Properties = make(map[string]string)
+	}
+	for _, proxy := range settings.Proxies {
+		if !proxy.Active || (proxy.Protocol != "http" && proxy.Protocol != "https") {
+			continue
+		}
+		prefix := "systemProp." + proxy.Protocol + "."
+		if _, ok := p.Properties[prefix+"proxyHost"]; ok {
+			// as Maven, the first active proxy of a protocol is used
+			continue
+		}
+		p.Properties[prefix+"proxyHost"] = proxy.Host
+		if proxy.Port != 0 {
+			p.Properties[prefix+"proxyPort"] = strconv.Itoa(proxy.Port)
+		}
+		if proxy.Username != "" {
+			p.Properties[prefix+"proxyUser"] = proxy.Username
+			p.Properties[prefix+"proxyPassword"] = proxy.Password
+		}
+		if proxy.NonProxyHosts != "" {
+			p.Properties[prefix+"nonProxyHosts"] = proxy.NonProxyHosts
+		}
+	}
+}
+
+// mirrorOf checks if the repository is matched by the Maven mirrorOf specification, that is a comma
+// separated list of repository ids, where * matches all the repositories and !id excludes one
+func mirrorOf(spec string, id string) bool {
+	matched := false
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		switch {
+		case s == "!"+id:
+			return false
+		case s == "*", s == "external:*", s == id:
+			matched = true
+		}
+	}
+	return matched
+}
+
+func notation(d maven.Dependency) string {
+	n := d.GroupID + ":" + d.ArtifactID
+	if d.Version != "" {
+		n += ":" + d.Version
+	}
+	if d.Classifier != "" {
+		n += ":" + d.Classifier
+	}
+
+	return n
+}
+
+// MarshalBytes --
+func (p Project) MarshalBytes() ([]byte, error) {
+	w := &bytes.Buffer{}
+	if err := buildTemplate.Execute(w, p); err != nil {
+		return []byte{}, err
+	}
+
+	return w.Bytes(), nil
+}
+
+// Digest returns a digest of the project dependencies, that identifies the project dependency locks
+func (p Project) Digest() (string, error) {
+	hash := sha256.New()
+	for _, platform := range p.Platforms {
+		if _, err := hash.Write([]byte("platform:" + platform + "\n")); err != nil {
+			return "", err
+		}
+	}
+	for _, d := range p.Dependencies {
+		if _, err := hash.Write([]byte(d.Configuration + ":" + d.Notation + "\n")); err != nil {
+			return "", err
+		}
+		for _, e := range d.Exclusions {
+			if _, err := hash.Write([]byte("exclude:" + e.GroupID + ":" + e.ArtifactID + "\n")); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// PropertiesBytes returns the content of the gradle.properties file of the project
+func (p Project) PropertiesBytes() []byte {
+	keys := make([]string, 0, len(p.Properties))
+	for k := range p.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	w := &bytes.Buffer{}
+	for _, k := range keys {
+		w.WriteString(k + "=" + propertiesEscaper.Replace(p.Properties[k]) + "\n")
+	}
+
+	return w.Bytes()
+}
+
+var propertiesEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, "\r", `\r`)
+
+// groovyEscaper escapes the values written in single quoted groovy strings
+var groovyEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+
+var buildTemplate = template.Must(template.New("build.gradle").Funcs(template.FuncMap{
+	"quote": groovyEscaper.Replace,
+}).Parse(fmt.Sprintf(`plugins {
+    id 'java'
+}
+
+group = '{{ .Group }}'
+version = '{{ .Version }}'
+
+repositories {
+    mavenLocal()
+{{- if .Central }}
+    mavenCentral()
+{{- end }}
+{{- range .Repositories }}
+{{- if .Username }}
+    maven {
+        url '{{ quote .URL }}'
+        credentials {
+            username '{{ quote .Username }}'
+            password '{{ quote .Password }}'
+        }
+    }
+{{- else }}
+    maven { url '{{ quote .URL }}' }
+{{- end }}
+{{- end }}
+}
+
+dependencyLocking {
+    lockAllConfigurations()
+}
+
+dependencies {
+{{- range .Platforms }}
+    implementation platform('{{ . }}')
+{{- end }}
+{{- range .Dependencies }}
+{{- if .Exclusions }}
+    {{ .Configuration }}('{{ .Notation }}') {
+{{- range .Exclusions }}
+        exclude group: '{{ .GroupID }}', module: '{{ .ArtifactID }}'
+{{- end }}
+    }
+{{- else }}
+    {{ .Configuration }} '{{ .Notation }}'
+{{- end }}
+{{- end }}
+}
+
+task %s {
+    doLast {
+        def out = file("$buildDir/dependencies.yaml")
+        out.parentFile.mkdirs()
+        out.withWriter { w ->
+            w.println 'dependencies:'
+            configurations.runtimeClasspath.resolvedConfiguration.resolvedArtifacts.each { a ->
+                def id = a.moduleVersion.id
+                w.println "- id: ${id.group}:${id.name}:${a.extension}:${id.version}"
+                w.println "  location: ${a.file.absolutePath}"
+            }
+        }
+    }
+}
+`, DependencyListTask)))
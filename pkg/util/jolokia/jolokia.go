@@ -0,0 +1,245 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jolokia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// contextMBean is the MBean of the Camel context of the integration runtime
+const contextMBean = `org.apache.camel:context=camel-k,type=context,name="camel-k"`
+
+// routeAttributes are the attributes of the Camel routes MBeans read to compute the route statistics
+var routeAttributes = []string{"RouteId", "State", "ExchangesCompleted", "ExchangesFailed", "MeanProcessingTime"}
+
+type routeAttributesValue struct {
+	RouteID            string `json:"RouteId"`
+	State              string `json:"State"`
+	ExchangesCompleted int64  `json:"ExchangesCompleted"`
+	ExchangesFailed    int64  `json:"ExchangesFailed"`
+	MeanProcessingTime int64  `json:"MeanProcessingTime"`
+}
+
+type execResponse struct {
+	Status int         `json:"status"`
+	Error  string      `json:"error"`
+	Value  interface{} `json:"value"`
+}
+
+type readResponse struct {
+	Status int                             `json:"status"`
+	Error  string                          `json:"error"`
+	Value  map[string]routeAttributesValue `json:"value"`
+}
+
+// ReadRoutes reads the statistics of the Camel routes from the Jolokia agent listening at the given
+// endpoint, e.g. http://10.0.0.1:8778/jolokia/
+func ReadRoutes(ctx context.Context, endpoint string) ([]v1alpha1.RouteStatus, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"type":      "read",
+		"mbean":     "org.apache.camel:type=routes,*",
+		"attribute": routeAttributes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jolokia agent returned status %s", res.Status)
+	}
+
+	var response readResponse
+	if err := json.NewDecoder(res.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	// Jolokia reports errors in the response payload
+	if response.Status != http.StatusOK {
+		return nil, fmt.Errorf("jolokia agent returned status %d: %s", response.Status, response.Error)
+	}
+
+	routes := make([]v1alpha1.RouteStatus, 0, len(response.Value))
+	for _, v := range response.Value {
+		routes = append(routes, v1alpha1.RouteStatus{
+			ID:                 v.RouteID,
+			State:              v.State,
+			ExchangesCompleted: v.ExchangesCompleted,
+			ExchangesFailed:    v.ExchangesFailed,
+			MeanProcessingTime: v.MeanProcessingTime,
+		})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].ID < routes[j].ID
+	})
+
+	return routes, nil
+}
+
+// MergeRoutes aggregates the statistics of the same routes run by different pods, the mean
+// processing time being weighted by the number of completed exchanges
+func MergeRoutes(snapshots ...[]v1alpha1.RouteStatus) []v1alpha1.RouteStatus {
+	merged := make(map[string]*v1alpha1.RouteStatus)
+	ids := make([]string, 0)
+
+	for _, snapshot := range snapshots {
+		for _, route := range snapshot {
+			m, ok := merged[route.ID]
+			if !ok {
+				r := route
+				merged[route.ID] = &r
+				ids = append(ids, route.ID)
+				continue
+			}
+
+			if total := m.ExchangesCompleted + route.ExchangesCompleted; total > 0 {
+				m.MeanProcessingTime = (m.MeanProcessingTime*m.ExchangesCompleted + route.MeanProcessingTime*route.ExchangesCompleted) / total
+			}
+			m.ExchangesCompleted += route.ExchangesCompleted
+			m.ExchangesFailed += route.ExchangesFailed
+			// report the routes that are not started on every pod
+			if route.State != "Started" {
+				m.State = route.State
+			}
+		}
+	}
+
+	sort.Strings(ids)
+
+	routes := make([]v1alpha1.RouteStatus, 0, len(ids))
+	for _, id := range ids {
+		routes = append(routes, *merged[id])
+	}
+
+	return routes
+}
+
+// Endpoint returns the URL of the Jolokia agent of the pod, provided it's running and the
+// agent is served over HTTP, as there are no credentials to authenticate against HTTPS agents
+func Endpoint(pod corev1.Pod) string {
+	if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" {
+		return ""
+	}
+
+	for _, c := range pod.Spec.Containers {
+		for _, e := range c.Env {
+			if e.Name == "AB_JOLOKIA_OPTS" && strings.Contains(e.Value, "protocol=https") {
+				return ""
+			}
+		}
+		for _, p := range c.Ports {
+			if p.Name == "jolokia" {
+				return fmt.Sprintf("http://%s:%d/jolokia/", pod.Status.PodIP, p.ContainerPort)
+			}
+		}
+	}
+
+	return ""
+}
+
+// NewSendBodyRequest returns the Jolokia request sending the body to the given endpoint of the Camel context,
+// waiting for the reply when requested
+func NewSendBodyRequest(uri string, body string, reply bool) ([]byte, error) {
+	operation := "sendBody(java.lang.String,java.lang.Object)"
+	if reply {
+		operation = "requestBody(java.lang.String,java.lang.Object)"
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"type":      "exec",
+		"mbean":     contextMBean,
+		"operation": operation,
+		"arguments": []string{uri, body},
+	})
+}
+
+// SendBody sends the body to the given endpoint of the Camel context through the Jolokia agent listening at
+// the given agent endpoint, without waiting for a reply
+func SendBody(ctx context.Context, agent string, uri string, body string) error {
+	request, err := NewSendBodyRequest(uri, body, false)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, agent, bytes.NewReader(request))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("jolokia agent returned status %s", res.Status)
+	}
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	_, err = ParseExecResponse(data)
+	return err
+}
+
+// ParseExecResponse returns the value of the Jolokia operation response, formatted as JSON unless it's a string
+func ParseExecResponse(data []byte) (string, error) {
+	var response execResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return "", fmt.Errorf("invalid jolokia response %q: %v", string(data), err)
+	}
+	if response.Status != http.StatusOK {
+		return "", fmt.Errorf("jolokia agent returned status %d: %s", response.Status, response.Error)
+	}
+
+	switch v := response.Value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		value, err := json.Marshal(v)
+		return string(value), err
+	}
+}
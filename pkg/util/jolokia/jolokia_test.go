@@ -0,0 +1,161 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jolokia
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestReadRoutes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := make(map[string]interface{})
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "org.apache.camel:type=routes,*", request["mbean"])
+
+		_, _ = w.Write([]byte(`{
+			"status": 200,
+			"value": {
+				"org.apache.camel:context=camel-k,type=routes,name=\"route2\"": {
+					"RouteId": "route2", "State": "Started", "ExchangesCompleted": 10, "ExchangesFailed": 2, "MeanProcessingTime": 5
+				},
+				"org.apache.camel:context=camel-k,type=routes,name=\"route1\"": {
+					"RouteId": "route1", "State": "Stopped", "ExchangesCompleted": 0, "ExchangesFailed": 0, "MeanProcessingTime": 0
+				}
+			}
+		}`))
+	}))
+	defer server.Close()
+
+	routes, err := ReadRoutes(context.TODO(), server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, []v1alpha1.RouteStatus{
+		{ID: "route1", State: "Stopped"},
+		{ID: "route2", State: "Started", ExchangesCompleted: 10, ExchangesFailed: 2, MeanProcessingTime: 5},
+	}, routes)
+}
+
+func TestReadRoutesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status": 404, "error": "javax.management.InstanceNotFoundException"}`))
+	}))
+	defer server.Close()
+
+	_, err := ReadRoutes(context.TODO(), server.URL)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "InstanceNotFoundException")
+}
+
+func TestMergeRoutes(t *testing.T) {
+	merged := MergeRoutes(
+		[]v1alpha1.RouteStatus{
+			{ID: "route1", State: "Started", ExchangesCompleted: 10, ExchangesFailed: 1, MeanProcessingTime: 10},
+			{ID: "route2", State: "Started", ExchangesCompleted: 1},
+		},
+		[]v1alpha1.RouteStatus{
+			{ID: "route1", State: "Suspended", ExchangesCompleted: 30, ExchangesFailed: 2, MeanProcessingTime: 2},
+		},
+	)
+
+	assert.Equal(t, []v1alpha1.RouteStatus{
+		{ID: "route1", State: "Suspended", ExchangesCompleted: 40, ExchangesFailed: 3, MeanProcessingTime: 4},
+		{ID: "route2", State: "Started", ExchangesCompleted: 1},
+	}, merged)
+}
+
+func TestEndpoint(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "my-integration",
+					Ports: []corev1.ContainerPort{
+						{Name: "jolokia", ContainerPort: 8778},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodRunning,
+			PodIP: "10.0.0.1",
+		},
+	}
+	assert.Equal(t, "http://10.0.0.1:8778/jolokia/", Endpoint(pod))
+
+	https := pod.DeepCopy()
+	https.Spec.Containers[0].Env = []corev1.EnvVar{
+		{Name: "AB_JOLOKIA_OPTS", Value: "port=8778,protocol=https"},
+	}
+	assert.Equal(t, "", Endpoint(*https))
+
+	pending := pod.DeepCopy()
+	pending.Status.Phase = corev1.PodPending
+	assert.Equal(t, "", Endpoint(*pending))
+}
+
+func TestSendBody(t *testing.T) {
+	data, err := NewSendBodyRequest("direct:start", "hello", true)
+	assert.Nil(t, err)
+
+	request := make(map[string]interface{})
+	assert.Nil(t, json.Unmarshal(data, &request))
+	assert.Equal(t, "exec", request["type"])
+	assert.Equal(t, `org.apache.camel:context=camel-k,type=context,name="camel-k"`, request["mbean"])
+	assert.Equal(t, "requestBody(java.lang.String,java.lang.Object)", request["operation"])
+	assert.Equal(t, []interface{}{"direct:start", "hello"}, request["arguments"])
+
+	value, err := ParseExecResponse([]byte(`{"status": 200, "value": "HELLO"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "HELLO", value)
+
+	value, err = ParseExecResponse([]byte(`{"status": 200, "value": {"id": 1}}`))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"id":1}`, value)
+
+	_, err = ParseExecResponse([]byte(`{"status": 500, "error": "No consumers available on endpoint"}`))
+	assert.NotNil(t, err)
+
+	_, err = ParseExecResponse([]byte(`curl: (7) Failed to connect`))
+	assert.NotNil(t, err)
+}
+
+func TestSendBodyToAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := make(map[string]interface{})
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "sendBody(java.lang.String,java.lang.Object)", request["operation"])
+
+		if request["arguments"].([]interface{})[1] == "fail" {
+			_, _ = w.Write([]byte(`{"status": 500, "error": "org.apache.camel.CamelExecutionException"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status": 200}`))
+	}))
+	defer server.Close()
+
+	assert.Nil(t, SendBody(context.TODO(), server.URL, "direct:start", "hello"))
+	assert.NotNil(t, SendBody(context.TODO(), server.URL, "direct:start", "fail"))
+}
@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jolokia
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// tracerMBean is the MBean of the backlog tracer of the Camel context of the integration runtime
+const tracerMBean = "org.apache.camel:context=camel-k,type=tracer,name=BacklogTracer"
+
+// TracerOptions configures the backlog tracer of the Camel context
+type TracerOptions struct {
+	// Pattern is the comma separated list of the route or node ids to trace, all by default
+	Pattern string
+	// Filter is the Simple language predicate the traced exchanges must match
+	Filter       string
+	BacklogSize  int
+	BodyMaxChars int
+}
+
+// TracedMessage is a message traced by the backlog tracer when flowing through a node of a route
+type TracedMessage struct {
+	UID        int64             `json:"uid"`
+	Timestamp  string            `json:"timestamp"`
+	Pod        string            `json:"pod,omitempty"`
+	RouteID    string            `json:"routeId"`
+	Node       string            `json:"node"`
+	ExchangeID string            `json:"exchangeId"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+}
+
+type tracedMessagesXML struct {
+	Messages []struct {
+		UID        int64  `xml:"uid"`
+		Timestamp  string `xml:"timestamp"`
+		RouteID    string `xml:"routeId"`
+		ToNode     string `xml:"toNode"`
+		ExchangeID string `xml:"exchangeId"`
+		Message    struct {
+			Headers []struct {
+				Key   string `xml:"key,attr"`
+				Value string `xml:",chardata"`
+			} `xml:"headers>header"`
+			Body string `xml:"body"`
+		} `xml:"message"`
+	} `xml:"backlogTracerEventMessage"`
+}
+
+// CollectTraces enables and configures the backlog tracer of the Camel context through the Jolokia agent listening
+// at the given endpoint, and returns the messages traced since the last collection, that are removed from the backlog
+func CollectTraces(ctx context.Context, endpoint string, options TracerOptions) ([]TracedMessage, error) {
+	requests := []map[string]interface{}{
+		newWriteRequest("Enabled", true),
+		newWriteRequest("TracePattern", nullIfEmpty(options.Pattern)),
+		newWriteRequest("TraceFilter", nullIfEmpty(options.Filter)),
+		newWriteRequest("RemoveOnDump", true),
+	}
+	if options.BacklogSize > 0 {
+		requests = append(requests, newWriteRequest("BacklogSize", options.BacklogSize))
+	}
+	if options.BodyMaxChars > 0 {
+		requests = append(requests, newWriteRequest("BodyMaxChars", options.BodyMaxChars))
+	}
+	requests = append(requests, map[string]interface{}{
+		"type":      "exec",
+		"mbean":     tracerMBean,
+		"operation": "dumpAllTracedMessagesAsXml()",
+	})
+
+	body, err := json.Marshal(requests)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jolokia agent returned status %s", res.Status)
+	}
+
+	var responses []execResponse
+	if err := json.NewDecoder(res.Body).Decode(&responses); err != nil {
+		return nil, err
+	}
+	if len(responses) != len(requests) {
+		return nil, fmt.Errorf("jolokia agent returned %d responses for %d requests", len(responses), len(requests))
+	}
+	// Jolokia reports errors in the response payload of each request
+	for _, response := range responses {
+		if response.Status != http.StatusOK {
+			return nil, fmt.Errorf("jolokia agent returned status %d: %s", response.Status, response.Error)
+		}
+	}
+
+	dump, ok := responses[len(responses)-1].Value.(string)
+	if !ok || strings.TrimSpace(dump) == "" {
+		return []TracedMessage{}, nil
+	}
+
+	return ParseTracedMessages(dump)
+}
+
+// ParseTracedMessages parses the XML dump of the backlog tracer
+func ParseTracedMessages(dump string) ([]TracedMessage, error) {
+	var parsed tracedMessagesXML
+	if err := xml.Unmarshal([]byte(dump), &parsed); err != nil {
+		return nil, err
+	}
+
+	messages := make([]TracedMessage, 0, len(parsed.Messages))
+	for _, m := range parsed.Messages {
+		message := TracedMessage{
+			UID:        m.UID,
+			Timestamp:  m.Timestamp,
+			RouteID:    m.RouteID,
+			Node:       m.ToNode,
+			ExchangeID: m.ExchangeID,
+			Body:       strings.TrimSpace(m.Message.Body),
+		}
+		if len(m.Message.Headers) > 0 {
+			message.Headers = make(map[string]string, len(m.Message.Headers))
+			for _, h := range m.Message.Headers {
+				message.Headers[h.Key] = h.Value
+			}
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+func newWriteRequest(attribute string, value interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"type":      "write",
+		"mbean":     tracerMBean,
+		"attribute": attribute,
+		"value":     value,
+	}
+}
+
+func nullIfEmpty(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jolokia
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTracerDump = `<?xml version="1.0" encoding="UTF-8"?>
+<backlogTracerEventMessages>
+<backlogTracerEventMessage>
+  <uid>7</uid>
+  <timestamp>2019-07-01T10:00:00.000+0000</timestamp>
+  <routeId>orders</routeId>
+  <toNode>log1</toNode>
+  <exchangeId>ID-orders-1</exchangeId>
+  <message exchangeId="ID-orders-1">
+    <headers>
+      <header key="CamelFileName" type="java.lang.String">order.json</header>
+    </headers>
+    <body type="java.lang.String">{"id": 1}</body>
+  </message>
+</backlogTracerEventMessage>
+</backlogTracerEventMessages>`
+
+func TestCollectTraces(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests := make([]map[string]interface{}, 0)
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&requests))
+		assert.Len(t, requests, 6)
+		assert.Equal(t, "Enabled", requests[0]["attribute"])
+		assert.Equal(t, "orders", requests[1]["value"])
+		assert.Nil(t, requests[2]["value"])
+		assert.Equal(t, "BacklogSize", requests[4]["attribute"])
+		assert.Equal(t, "dumpAllTracedMessagesAsXml()", requests[5]["operation"])
+
+		dump, err := json.Marshal(testTracerDump)
+		assert.Nil(t, err)
+		_, _ = w.Write([]byte(`[{"status": 200}, {"status": 200}, {"status": 200}, {"status": 200}, {"status": 200},
+			{"status": 200, "value": ` + string(dump) + `}]`))
+	}))
+	defer server.Close()
+
+	messages, err := CollectTraces(context.TODO(), server.URL, TracerOptions{Pattern: "orders", BacklogSize: 50})
+	assert.Nil(t, err)
+	assert.Equal(t, []TracedMessage{
+		{
+			UID:        7,
+			Timestamp:  "2019-07-01T10:00:00.000+0000",
+			RouteID:    "orders",
+			Node:       "log1",
+			ExchangeID: "ID-orders-1",
+			Headers:    map[string]string{"CamelFileName": "order.json"},
+			Body:       `{"id": 1}`,
+		},
+	}, messages)
+}
+
+func TestCollectTracesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"status": 404, "error": "javax.management.InstanceNotFoundException"}, {"status": 200},
+			{"status": 200}, {"status": 200}, {"status": 200}]`))
+	}))
+	defer server.Close()
+
+	_, err := CollectTraces(context.TODO(), server.URL, TracerOptions{})
+	assert.NotNil(t, err)
+}
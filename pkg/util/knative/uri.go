@@ -19,11 +19,13 @@ package knative
 
 import (
 	"regexp"
+	"strings"
 
 	knativev1 "github.com/apache/camel-k/pkg/apis/camel/v1alpha1/knative"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
 )
 
-var uriRegexp = regexp.MustCompile("^knative:[/]*(channel|endpoint)/([a-z0-9.-]+)(?:[/?].*|$)")
+var nameRegexp = regexp.MustCompile("^[a-z0-9.-]+$")
 
 // ExtractChannelNames extracts all Knative named channels from the given URIs
 func ExtractChannelNames(uris []string) []string {
@@ -61,9 +63,13 @@ func ExtractEndpointlName(uri string) string {
 
 // ExtractName returns a channel name from the Knative URI if present
 func ExtractName(kind knativev1.CamelServiceType, uri string) string {
-	match := uriRegexp.FindStringSubmatch(uri)
-	if len(match) == 3 && match[1] == string(kind) {
-		return match[2]
+	u, err := uriutil.Parse(uri)
+	if err != nil || u.Scheme != "knative" {
+		return ""
 	}
-	return ""
+	parts := strings.SplitN(strings.TrimLeft(u.Path, "/"), "/", 3)
+	if len(parts) < 2 || parts[0] != string(kind) || !nameRegexp.MatchString(parts[1]) {
+		return ""
+	}
+	return parts[1]
 }
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// JSONRecord is a log record of an integration logging JSON records, e.g. through the log-forwarding trait
+type JSONRecord struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Logger    string `json:"logger"`
+	Thread    string `json:"thread"`
+	Message   string `json:"message"`
+	Exception string `json:"exception,omitempty"`
+}
+
+var levels = []string{"TRACE", "DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
+
+// PrintJSON prints the JSON logs of the integration to the stdout in a human readable form, skipping the records
+// below the given level, if any
+func PrintJSON(ctx context.Context, client kubernetes.Interface, integration *v1alpha1.Integration, level string) error {
+	scraper := NewSelectorScraper(client, integration.Namespace, integration.Name, "camel.apache.org/integration="+integration.Name)
+	reader := scraper.Start(ctx)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err != io.EOF {
+				fmt.Println(err.Error())
+			}
+			return nil
+		}
+		if formatted, ok := FormatJSON(line, level); ok {
+			fmt.Print(formatted)
+		}
+	}
+}
+
+// FormatJSON formats the JSON record of the given log line, that may be prefixed by the scraper, the lines not
+// holding a JSON record are returned as is. The returned flag is false when the record is below the given level.
+func FormatJSON(line string, level string) (string, bool) {
+	start := strings.Index(line, "{")
+	if start < 0 {
+		return line, true
+	}
+
+	record := JSONRecord{}
+	if err := json.Unmarshal([]byte(line[start:]), &record); err != nil || record.Level == "" {
+		return line, true
+	}
+
+	if level != "" && levelIndex(record.Level) < levelIndex(level) {
+		return "", false
+	}
+
+	formatted := fmt.Sprintf("%s%s %-5s [%s] (%s) %s\n", line[:start], record.Timestamp, record.Level, record.Logger, record.Thread, record.Message)
+	if record.Exception != "" {
+		formatted += strings.TrimRight(record.Exception, "\n") + "\n"
+	}
+
+	return formatted, true
+}
+
+// ValidateLevel checks the given level is a known log level
+func ValidateLevel(level string) error {
+	if levelIndex(level) < 0 {
+		return fmt.Errorf("unknown log level %s (expected one of %s)", level, strings.Join(levels, "|"))
+	}
+	return nil
+}
+
+func levelIndex(level string) int {
+	for i, l := range levels {
+		if strings.EqualFold(l, level) {
+			return i
+		}
+	}
+	return -1
+}
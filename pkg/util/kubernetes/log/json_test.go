@@ -0,0 +1,50 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatJSON(t *testing.T) {
+	line := `[1] {"timestamp":"2019-07-01T10:00:00.000+0000","level":"WARN","logger":"route1","thread":"main","message":"hello","exception":""}` + "\n"
+
+	formatted, ok := FormatJSON(line, "")
+	assert.True(t, ok)
+	assert.Equal(t, "[1] 2019-07-01T10:00:00.000+0000 WARN  [route1] (main) hello\n", formatted)
+
+	_, ok = FormatJSON(line, "warn")
+	assert.True(t, ok)
+	_, ok = FormatJSON(line, "ERROR")
+	assert.False(t, ok)
+
+	formatted, ok = FormatJSON("[1] Monitoring pod my-integration\n", "ERROR")
+	assert.True(t, ok)
+	assert.Equal(t, "[1] Monitoring pod my-integration\n", formatted)
+
+	formatted, ok = FormatJSON("[1] {not json}\n", "ERROR")
+	assert.True(t, ok)
+	assert.Equal(t, "[1] {not json}\n", formatted)
+}
+
+func TestValidateLevel(t *testing.T) {
+	assert.Nil(t, ValidateLevel("debug"))
+	assert.NotNil(t, ValidateLevel("verbose"))
+}
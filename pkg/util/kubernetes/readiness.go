@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/apache/camel-k/pkg/client"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// readyConditionTypes lists the condition types commonly used by operators to signal
+// that a resource is ready to be used (e.g. Ready for Strimzi, Available for deployments)
+var readyConditionTypes = []string{"Ready", "Available"}
+
+// GetObjectReadiness retrieves the referenced object and tells whether it's ready, with
+// a message explaining why it's not. The object must belong to the given namespace, so that
+// the references cannot be used to read the objects of other namespaces.
+func GetObjectReadiness(ctx context.Context, c client.Client, namespace string, ref corev1.ObjectReference) (bool, string, error) {
+	if ref.Namespace != "" && ref.Namespace != namespace {
+		return false, "", fmt.Errorf("%s %s/%s does not belong to namespace %s", ref.Kind, ref.Namespace, ref.Name, namespace)
+	}
+
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion(ref.APIVersion)
+	obj.SetKind(ref.Kind)
+
+	key := k8sclient.ObjectKey{
+		Namespace: namespace,
+		Name:      ref.Name,
+	}
+
+	if err := c.Get(ctx, key, &obj); err != nil {
+		return false, "", err
+	}
+
+	ready, message := IsObjectReady(obj)
+	return ready, message, nil
+}
+
+// IsObjectReady tells whether the object is ready according to its status conditions.
+// Objects not reporting any condition are considered ready as soon as they exist.
+func IsObjectReady(obj unstructured.Unstructured) (bool, string) {
+	conditions, found, err := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	if err != nil || !found || len(conditions) == 0 {
+		return true, ""
+	}
+
+	message := fmt.Sprintf("no %v condition reported", readyConditionTypes)
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, t := range readyConditionTypes {
+			if condition["type"] != t {
+				continue
+			}
+			if condition["status"] == string(corev1.ConditionTrue) {
+				return true, ""
+			}
+
+			message = fmt.Sprintf("condition %s is %v", t, condition["status"])
+			if m, ok := condition["message"].(string); ok && m != "" {
+				message += ": " + m
+			}
+		}
+	}
+
+	return false, message
+}
@@ -60,10 +60,11 @@ func ReplaceResource(ctx context.Context, c client.Client, res runtime.Object) e
 		mapRequiredServiceData(existing, res)
 		mapRequiredRouteData(existing, res)
 		mapRequiredKnativeData(existing, res)
+		mapRequiredPersistentVolumeClaimData(existing, res)
 		err = c.Update(ctx, res)
 	}
 	if err != nil {
-		return errors.Wrap(err, "could not create or replace "+findResourceDetails(res))
+		return errors.Wrap(err, "could not create or replace "+FindResourceDetails(res))
 	}
 	return nil
 }
@@ -100,14 +101,29 @@ func mapRequiredKnativeData(from runtime.Object, to runtime.Object) {
 	}
 }
 
-func findResourceDetails(res runtime.Object) string {
+func mapRequiredPersistentVolumeClaimData(from runtime.Object, to runtime.Object) {
+	if fromC, ok := from.(*corev1.PersistentVolumeClaim); ok {
+		if toC, ok := to.(*corev1.PersistentVolumeClaim); ok {
+			toC.Spec.VolumeName = fromC.Spec.VolumeName
+			if toC.Spec.StorageClassName == nil {
+				toC.Spec.StorageClassName = fromC.Spec.StorageClassName
+			}
+			if toC.Spec.VolumeMode == nil {
+				toC.Spec.VolumeMode = fromC.Spec.VolumeMode
+			}
+		}
+	}
+}
+
+// FindResourceDetails returns a human readable description of the resource (kind and name)
+func FindResourceDetails(res runtime.Object) string {
 	if res == nil {
 		return "nil resource"
 	}
 	if meta, ok := res.(metav1.Object); ok {
 		name := meta.GetName()
-		if ty, ok := res.(metav1.Type); ok {
-			return ty.GetKind() + " " + name
+		if kind := res.GetObjectKind().GroupVersionKind().Kind; kind != "" {
+			return kind + " " + name
 		}
 		return "resource " + name
 	}
@@ -124,6 +124,36 @@ func (l Logger) ForIntegrationPlatform(target *v1alpha1.IntegrationPlatform) Log
 	)
 }
 
+// ForReplay --
+func (l Logger) ForReplay(target *v1alpha1.Replay) Logger {
+	return l.WithValues(
+		"api-version", target.APIVersion,
+		"kind", target.Kind,
+		"ns", target.Namespace,
+		"name", target.Name,
+	)
+}
+
+// ForKamelet --
+func (l Logger) ForKamelet(target *v1alpha1.Kamelet) Logger {
+	return l.WithValues(
+		"api-version", target.APIVersion,
+		"kind", target.Kind,
+		"ns", target.Namespace,
+		"name", target.Name,
+	)
+}
+
+// ForKameletBinding --
+func (l Logger) ForKameletBinding(target *v1alpha1.KameletBinding) Logger {
+	return l.WithValues(
+		"api-version", target.APIVersion,
+		"kind", target.Kind,
+		"ns", target.Namespace,
+		"name", target.Name,
+	)
+}
+
 // ***********************************
 //
 // Helpers
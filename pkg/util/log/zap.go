@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	logf "sigs.k8s.io/controller-runtime/pkg/runtime/log"
+)
+
+// level is the level of the operator logger, it can be changed at runtime
+var level = zap.NewAtomicLevelAt(zap.InfoLevel)
+
+// ZapLogger returns a production zap logger whose level can be changed using SetLevel
+func ZapLogger() logr.Logger {
+	sink := zapcore.AddSync(os.Stderr)
+	enc := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+
+	log := zap.New(zapcore.NewCore(&logf.KubeAwareEncoder{Encoder: enc}, sink, level))
+	log = log.WithOptions(
+		zap.AddStacktrace(zap.WarnLevel),
+		zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return zapcore.NewSampler(core, time.Second, 100, 100)
+		}),
+		zap.AddCallerSkip(1),
+		zap.ErrorOutput(sink),
+	)
+
+	return zapr.NewLogger(log)
+}
+
+// SetLevel changes the level of the loggers created by ZapLogger (debug, info, warn, error)
+func SetLevel(l string) error {
+	return level.UnmarshalText([]byte(l))
+}
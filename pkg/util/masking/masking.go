@@ -0,0 +1,98 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package masking
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+)
+
+// Mask replaces the sensitive data
+const Mask = "*****"
+
+// Masker applies masking rules to the text
+type Masker struct {
+	patterns []*regexp.Regexp
+	values   *regexp.Regexp
+	headers  map[string]bool
+}
+
+// NewMasker compiles the given masking rules, that may be nil
+func NewMasker(spec *v1alpha1.MaskingSpec) (*Masker, error) {
+	m := Masker{
+		headers: make(map[string]bool),
+	}
+	if spec == nil {
+		return &m, nil
+	}
+
+	for _, p := range spec.Patterns {
+		r, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid masking pattern %q: %s", p, err.Error())
+		}
+		m.patterns = append(m.patterns, r)
+	}
+	if regex := HeadersRegex(spec.Headers); regex != "" {
+		m.values = regexp.MustCompile(regex)
+	}
+	for _, h := range spec.Headers {
+		m.headers[strings.ToLower(h)] = true
+	}
+
+	return &m, nil
+}
+
+// Mask masks the text matching the patterns, and the values of the headers as they are formatted by Camel,
+// e.g. `Authorization=Bearer xyz`
+func (m *Masker) Mask(text string) string {
+	for _, p := range m.patterns {
+		text = p.ReplaceAllLiteralString(text, Mask)
+	}
+	if m.values != nil {
+		text = m.values.ReplaceAllString(text, "${1}"+Mask)
+	}
+	return text
+}
+
+// MaskHeader masks the value of the header if it's one of the sensitive ones, or the text matching the patterns
+func (m *Masker) MaskHeader(name string, value string) string {
+	if m.headers[strings.ToLower(name)] {
+		return Mask
+	}
+	return m.Mask(value)
+}
+
+// HeadersRegex returns the regular expression matching the values of the given headers as they are formatted by
+// Camel, the header name being captured by the first group. The expression is compatible with Java regular
+// expressions and has no brace, so that it can be embedded in log4j patterns
+func HeadersRegex(headers []string) string {
+	names := make([]string, 0, len(headers))
+	for _, h := range headers {
+		if h != "" {
+			names = append(names, regexp.QuoteMeta(h))
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return `(?i)(\b(?:` + strings.Join(names, "|") + `)=)[^,\x7D]*`
+}
@@ -0,0 +1,51 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package masking
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMasker(t *testing.T) {
+	m, err := NewMasker(&v1alpha1.MaskingSpec{
+		Patterns: []string{`\d{4}-\d{4}-\d{4}-\d{4}`},
+		Headers:  []string{"Authorization", "X-Api-Key"},
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "card *****, Headers: {authorization=*****, x-api-key=*****, id=1}",
+		m.Mask("card 1234-5678-9012-3456, Headers: {authorization=Bearer xyz, x-api-key=secret, id=1}"))
+	assert.Equal(t, Mask, m.MaskHeader("AUTHORIZATION", "Bearer xyz"))
+	assert.Equal(t, "paid with *****", m.MaskHeader("note", "paid with 1234-5678-9012-3456"))
+	assert.Equal(t, "1", m.MaskHeader("id", "1"))
+}
+
+func TestMaskerWithoutRules(t *testing.T) {
+	m, err := NewMasker(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "Authorization=Bearer xyz", m.Mask("Authorization=Bearer xyz"))
+}
+
+func TestInvalidPattern(t *testing.T) {
+	_, err := NewMasker(&v1alpha1.MaskingSpec{Patterns: []string{`(`}})
+	assert.NotNil(t, err)
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package maven
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -26,7 +27,10 @@ import (
 	"path"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/Masterminds/semver"
 	"github.com/pkg/errors"
 
 	"github.com/apache/camel-k/pkg/util"
@@ -36,6 +40,8 @@ import (
 // Log --
 var Log = log.WithName("maven")
 
+var versionRegexp = regexp.MustCompile(`Apache Maven ([0-9]+\.[0-9]+\.[0-9]+)`)
+
 // GenerateProjectStructure --
 func GenerateProjectStructure(context Context) error {
 	if err := util.WriteFileWithBytesMarshallerContent(context.Path, "pom.xml", context.Project); err != nil {
@@ -91,6 +97,12 @@ func Run(context Context) error {
 		mvnCmd = c
 	}
 
+	if context.Version != "" {
+		if err := checkVersion(mvnCmd, context.Version); err != nil {
+			return err
+		}
+	}
+
 	args := append(context.AdditionalArguments, "--batch-mode")
 
 	settingsPath := path.Join(context.Path, "settings.xml")
@@ -103,14 +115,196 @@ func Run(context Context) error {
 		args = append(args, "--settings", settingsPath)
 	}
 
+	collector := newErrorCollector(os.Stdout)
+
 	cmd := exec.Command(mvnCmd, args...)
 	cmd.Dir = context.Path
-	cmd.Stdout = os.Stdout
+	if len(context.Env) > 0 {
+		cmd.Env = append(os.Environ(), context.Env...)
+	}
+	cmd.Stdout = collector
 	cmd.Stderr = os.Stderr
 
 	Log.Infof("execute: %s", strings.Join(cmd.Args, " "))
 
-	return cmd.Run()
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- cmd.Wait()
+	}()
+
+	var timeout <-chan time.Time
+	if context.Timeout > 0 {
+		timer := time.NewTimer(context.Timeout)
+		defer timer.Stop()
+
+		timeout = timer.C
+	}
+
+	select {
+	case err := <-done:
+		return newError(err, collector.Messages())
+	case <-timeout:
+		if err := cmd.Process.Kill(); err != nil {
+			Log.Errorf(err, "cannot kill maven process")
+		}
+		<-done
+
+		return &Error{
+			Kind:     ErrorKindTimeout,
+			ExitCode: -1,
+			Messages: append(collector.Messages(), fmt.Sprintf("timeout of %s exceeded", context.Timeout)),
+		}
+	}
+}
+
+// newError translates the result of a maven invocation to a typed error
+func newError(err error, messages []string) error {
+	if err == nil {
+		return nil
+	}
+
+	exitErr, ok := err.(*exec.ExitError)
+	if !ok {
+		return err
+	}
+
+	return &Error{
+		Kind:     classify(messages),
+		ExitCode: exitErr.ExitCode(),
+		Messages: messages,
+	}
+}
+
+var resolutionErrors = []string{
+	"Could not resolve dependencies",
+	"Failed to collect dependencies",
+	"Could not find artifact",
+	"Could not transfer artifact",
+	"Non-resolvable",
+	"Failed to read artifact descriptor",
+	"Plugin not found",
+}
+
+var compilationErrors = []string{
+	"COMPILATION ERROR",
+	"Compilation failure",
+}
+
+// classify determines the kind of failure from the [ERROR] lines emitted by maven. Resolution errors are
+// looked for first, as the goals failing to resolve their dependencies, e.g. the compiler plugin ones, are
+// reported on the same lines
+func classify(messages []string) ErrorKind {
+	for _, m := range messages {
+		for _, e := range resolutionErrors {
+			if strings.Contains(m, e) {
+				return ErrorKindResolution
+			}
+		}
+	}
+	for _, m := range messages {
+		for _, e := range compilationErrors {
+			if strings.Contains(m, e) {
+				return ErrorKindCompilation
+			}
+		}
+	}
+
+	return ErrorKindUnknown
+}
+
+// errorCollector forwards the maven output to the given writer while collecting [ERROR] lines
+type errorCollector struct {
+	writer   io.Writer
+	line     bytes.Buffer
+	messages []string
+	lock     sync.Mutex
+}
+
+func newErrorCollector(writer io.Writer) *errorCollector {
+	return &errorCollector{
+		writer:   writer,
+		messages: make([]string, 0),
+	}
+}
+
+func (c *errorCollector) Write(p []byte) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, b := range p {
+		if b == '\n' {
+			c.collect(c.line.String())
+			c.line.Reset()
+		} else {
+			c.line.WriteByte(b)
+		}
+	}
+
+	return c.writer.Write(p)
+}
+
+func (c *errorCollector) collect(line string) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "[ERROR]") {
+		return
+	}
+
+	line = strings.TrimSpace(strings.TrimPrefix(line, "[ERROR]"))
+	if line == "" || strings.HasPrefix(line, "->") || strings.HasPrefix(line, "Re-run Maven") ||
+		strings.HasPrefix(line, "To see the full stack trace") || strings.HasPrefix(line, "For more information") {
+		return
+	}
+
+	c.messages = append(c.messages, line)
+}
+
+// Messages returns the collected [ERROR] lines
+func (c *errorCollector) Messages() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.line.Len() > 0 {
+		c.collect(c.line.String())
+		c.line.Reset()
+	}
+
+	messages := make([]string, len(c.messages))
+	copy(messages, c.messages)
+
+	return messages
+}
+
+// checkVersion verifies that the maven executable satisfies the given version constraint
+func checkVersion(mvnCmd string, version string) error {
+	constraint, err := semver.NewConstraint(version)
+	if err != nil {
+		return errors.Wrapf(err, "invalid maven version constraint: %s", version)
+	}
+
+	out, err := exec.Command(mvnCmd, "--version").Output()
+	if err != nil {
+		return errors.Wrap(err, "cannot determine maven version")
+	}
+
+	res := versionRegexp.FindStringSubmatch(string(out))
+	if res == nil {
+		return fmt.Errorf("cannot determine maven version from: %s", string(out))
+	}
+
+	v, err := semver.NewVersion(res[1])
+	if err != nil {
+		return err
+	}
+
+	if !constraint.Check(v) {
+		return fmt.Errorf("maven version %s does not satisfy the required version %s", v, version)
+	}
+
+	return nil
 }
 
 // ParseGAV decode a maven artifact id to a dependency definition.
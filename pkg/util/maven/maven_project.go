@@ -21,6 +21,8 @@ import (
 	"bytes"
 	"encoding/xml"
 	"strings"
+
+	"github.com/apache/camel-k/pkg/util/jvm"
 )
 
 // NewProject --
@@ -60,6 +62,28 @@ func (p Project) MarshalBytes() ([]byte, error) {
 	return w.Bytes(), nil
 }
 
+// SetJavaVersion configures the maven compiler plugin to target the given java version
+func (p *Project) SetJavaVersion(javaVersion string) {
+	if javaVersion == "" {
+		return
+	}
+
+	if p.Properties == nil {
+		p.Properties = make(Properties)
+	}
+
+	version := jvm.NormalizeJavaVersion(javaVersion)
+	if version == "8" || version == "7" || version == "6" {
+		// legacy versions are expressed using the 1.x notation
+		version = "1." + version
+	} else {
+		p.Properties["maven.compiler.release"] = version
+	}
+
+	p.Properties["maven.compiler.source"] = version
+	p.Properties["maven.compiler.target"] = version
+}
+
 // LookupDependency --
 func (p *Project) LookupDependency(dep Dependency) *Dependency {
 	for i := range p.Dependencies {
@@ -141,6 +165,106 @@ func (p *Project) AddDependencyExclusions(dep Dependency, exclusions ...Exclusio
 	}
 }
 
+// AddPlugin adds a build plugin, replacing any plugin with the same coordinates
+func (p *Project) AddPlugin(plugin Plugin) {
+	p.Build.Plugins = addOrReplacePlugin(p.Build.Plugins, plugin)
+}
+
+// AddManagedPlugin adds a plugin to the plugin management section, replacing any plugin with the same coordinates
+func (p *Project) AddManagedPlugin(plugin Plugin) {
+	if p.Build.PluginManagement == nil {
+		p.Build.PluginManagement = &PluginManagement{}
+	}
+
+	p.Build.PluginManagement.Plugins = addOrReplacePlugin(p.Build.PluginManagement.Plugins, plugin)
+}
+
+// AddExtension adds a build extension
+func (p *Project) AddExtension(extension Extension) {
+	if p.Build.Extensions == nil {
+		extensions := make([]Extension, 0)
+		p.Build.Extensions = &extensions
+	}
+
+	for _, e := range *p.Build.Extensions {
+		if e.GroupID == extension.GroupID && e.ArtifactID == extension.ArtifactID {
+			return
+		}
+	}
+
+	*p.Build.Extensions = append(*p.Build.Extensions, extension)
+}
+
+// AddProfile adds a profile, replacing any profile with the same id
+func (p *Project) AddProfile(profile Profile) {
+	if p.Profiles == nil {
+		profiles := make([]Profile, 0)
+		p.Profiles = &profiles
+	}
+
+	for i, pr := range *p.Profiles {
+		if pr.ID == profile.ID {
+			(*p.Profiles)[i] = profile
+			return
+		}
+	}
+
+	*p.Profiles = append(*p.Profiles, profile)
+}
+
+func addOrReplacePlugin(plugins []Plugin, plugin Plugin) []Plugin {
+	for i, pl := range plugins {
+		if pl.GroupID == plugin.GroupID && pl.ArtifactID == plugin.ArtifactID {
+			plugins[i] = plugin
+			return plugins
+		}
+	}
+
+	return append(plugins, plugin)
+}
+
+// NewConfigurationValue creates a plugin configuration element with the given name and value
+func NewConfigurationValue(name string, value string) ConfigurationValue {
+	return ConfigurationValue{
+		XMLName: xml.Name{Local: name},
+		Value:   value,
+	}
+}
+
+// NewConfigurationNode creates a plugin configuration element holding the given nested elements
+func NewConfigurationNode(name string, children ...ConfigurationValue) ConfigurationValue {
+	return ConfigurationValue{
+		XMLName:  xml.Name{Local: name},
+		Children: children,
+	}
+}
+
+// WithAttribute returns a copy of the configuration element having the given attribute set,
+// e.g. the implementation of a shade plugin transformer
+func (v ConfigurationValue) WithAttribute(name string, value string) ConfigurationValue {
+	attributes := make([]xml.Attr, 0, len(v.Attributes)+1)
+	for _, a := range v.Attributes {
+		if a.Name.Local != name {
+			attributes = append(attributes, a)
+		}
+	}
+	v.Attributes = append(attributes, xml.Attr{Name: xml.Name{Local: name}, Value: value})
+
+	return v
+}
+
+// AddRepository adds a repository to maven's repositories
+func (p *Project) AddRepository(repository Repository) {
+	for _, r := range p.Repositories {
+		// Check if the given repository is already included in the repository list
+		if r.URL == repository.URL || (r.ID != "" && r.ID == repository.ID) {
+			return
+		}
+	}
+
+	p.Repositories = append(p.Repositories, repository)
+}
+
 // NewDependency create an new dependency from the given gav info
 func NewDependency(groupID string, artifactID string, version string) Dependency {
 	return Dependency{
@@ -190,3 +314,9 @@ func NewRepository(repo string) Repository {
 
 	return r
 }
+
+// NewJitPackRepository creates the repository used to resolve artifacts built on demand
+// by JitPack from git repositories
+func NewJitPackRepository() Repository {
+	return NewRepository("https://jitpack.io@id=jitpack.io@snapshots")
+}
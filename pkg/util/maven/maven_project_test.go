@@ -18,6 +18,7 @@ limitations under the License.
 package maven
 
 import (
+	"encoding/xml"
 	"testing"
 
 	"github.com/apache/camel-k/pkg/util"
@@ -215,3 +216,188 @@ func TestNewRepositoryWithID(t *testing.T) {
 	assert.True(t, r.Releases.Enabled)
 	assert.False(t, r.Snapshots.Enabled)
 }
+
+func TestSetJavaVersion(t *testing.T) {
+	project := NewProject()
+	project.SetJavaVersion("1.8")
+
+	assert.Equal(t, "1.8", project.Properties["maven.compiler.source"])
+	assert.Equal(t, "1.8", project.Properties["maven.compiler.target"])
+	assert.NotContains(t, project.Properties, "maven.compiler.release")
+
+	project = NewProject()
+	project.SetJavaVersion("11")
+
+	assert.Equal(t, "11", project.Properties["maven.compiler.source"])
+	assert.Equal(t, "11", project.Properties["maven.compiler.target"])
+	assert.Equal(t, "11", project.Properties["maven.compiler.release"])
+}
+
+const expectedPomWithPlugins = `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" ` +
+	`xsi:schemaLocation="http://maven.apache.org/POM/4.0.0 http://maven.apache.org/xsd/maven-4.0.0.xsd">
+  <modelVersion>4.0.0</modelVersion>
+  <groupId>org.apache.camel.k.integration</groupId>
+  <artifactId>camel-k-integration</artifactId>
+  <version>1.0.0</version>
+  <dependencyManagement>
+    <dependencies></dependencies>
+  </dependencyManagement>
+  <dependencies></dependencies>
+  <repositories></repositories>
+  <pluginRepositories></pluginRepositories>
+  <build>
+    <extensions>
+      <extension>
+        <groupId>kr.motd.maven</groupId>
+        <artifactId>os-maven-plugin</artifactId>
+        <version>1.6.2</version>
+      </extension>
+    </extensions>
+    <pluginManagement>
+      <plugins>
+        <plugin>
+          <groupId>org.apache.maven.plugins</groupId>
+          <artifactId>maven-compiler-plugin</artifactId>
+          <version>3.8.1</version>
+          <executions></executions>
+          <dependencies></dependencies>
+        </plugin>
+      </plugins>
+    </pluginManagement>
+    <plugins>
+      <plugin>
+        <groupId>org.apache.maven.plugins</groupId>
+        <artifactId>maven-shade-plugin</artifactId>
+        <version>3.2.1</version>
+        <executions>
+          <execution>
+            <id>shade</id>
+            <phase>package</phase>
+            <goals>
+              <goal>shade</goal>
+            </goals>
+            <configuration>
+              <createDependencyReducedPom>false</createDependencyReducedPom>
+              <transformers>
+                <transformer implementation="org.apache.maven.plugins.shade.resource.ManifestResourceTransformer">
+                  <mainClass>org.apache.camel.k.jvm.Application</mainClass>
+                </transformer>
+              </transformers>
+            </configuration>
+          </execution>
+        </executions>
+        <dependencies></dependencies>
+      </plugin>
+    </plugins>
+  </build>
+  <profiles>
+    <profile>
+      <id>native</id>
+      <activation>
+        <activeByDefault>false</activeByDefault>
+        <property>
+          <name>native</name>
+          <value>true</value>
+        </property>
+      </activation>
+      <repositories></repositories>
+      <pluginRepositories></pluginRepositories>
+      <build>
+        <plugins>
+          <plugin>
+            <groupId>io.quarkus</groupId>
+            <artifactId>quarkus-maven-plugin</artifactId>
+            <version>0.15.0</version>
+            <executions></executions>
+            <dependencies></dependencies>
+            <configuration>
+              <enableHttpUrlHandler>true</enableHttpUrlHandler>
+            </configuration>
+          </plugin>
+        </plugins>
+      </build>
+    </profile>
+  </profiles>
+</project>`
+
+func TestPomGenerationWithPlugins(t *testing.T) {
+	project := NewProjectWithGAV("org.apache.camel.k.integration", "camel-k-integration", "1.0.0")
+	project.AddExtension(Extension{
+		GroupID:    "kr.motd.maven",
+		ArtifactID: "os-maven-plugin",
+		Version:    "1.6.2",
+	})
+	project.AddManagedPlugin(Plugin{
+		GroupID:    "org.apache.maven.plugins",
+		ArtifactID: "maven-compiler-plugin",
+		Version:    "3.8.1",
+	})
+	project.AddPlugin(Plugin{
+		GroupID:    "org.apache.maven.plugins",
+		ArtifactID: "maven-shade-plugin",
+		Version:    "3.2.1",
+		Executions: []Execution{
+			{
+				ID:    "shade",
+				Phase: "package",
+				Goals: []string{"shade"},
+				Configuration: &Configuration{
+					Values: []ConfigurationValue{
+						NewConfigurationValue("createDependencyReducedPom", "false"),
+						NewConfigurationNode("transformers",
+							NewConfigurationNode("transformer",
+								NewConfigurationValue("mainClass", "org.apache.camel.k.jvm.Application"),
+							).WithAttribute("implementation", "org.apache.maven.plugins.shade.resource.ManifestResourceTransformer"),
+						),
+					},
+				},
+			},
+		},
+	})
+	project.AddProfile(Profile{
+		ID: "native",
+		Activation: Activation{
+			Property: &PropertyActivation{
+				Name:  "native",
+				Value: "true",
+			},
+		},
+		Build: &Build{
+			Plugins: []Plugin{
+				{
+					GroupID:    "io.quarkus",
+					ArtifactID: "quarkus-maven-plugin",
+					Version:    "0.15.0",
+					Configuration: &Configuration{
+						Values: []ConfigurationValue{
+							NewConfigurationValue("enableHttpUrlHandler", "true"),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	pom, err := util.EncodeXML(project)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, pom)
+
+	assert.Equal(t, expectedPomWithPlugins, string(pom))
+
+	// the attributes are retained when the configuration is read back
+	parsed := Project{}
+	assert.Nil(t, xml.Unmarshal(pom, &parsed))
+	transformer := parsed.Build.Plugins[0].Executions[0].Configuration.Values[1].Children[0]
+	assert.Equal(t, "transformer", transformer.XMLName.Local)
+	assert.Len(t, transformer.Attributes, 1)
+	assert.Equal(t, "implementation", transformer.Attributes[0].Name.Local)
+	assert.Equal(t, "org.apache.maven.plugins.shade.resource.ManifestResourceTransformer", transformer.Attributes[0].Value)
+}
+
+func TestConfigurationValueWithAttribute(t *testing.T) {
+	value := NewConfigurationNode("transformer").WithAttribute("implementation", "a").WithAttribute("implementation", "b")
+
+	assert.Equal(t, []xml.Attr{{Name: xml.Name{Local: "implementation"}, Value: "b"}}, value.Attributes)
+}
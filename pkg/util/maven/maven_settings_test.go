@@ -28,6 +28,7 @@ const expectedSettings = `<?xml version="1.0" encoding="UTF-8"?>
 <settings xmlns="http://maven.apache.org/SETTINGS/1.0.0" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" ` +
   `xsi:schemaLocation="http://maven.apache.org/SETTINGS/1.0.0 https://maven.apache.org/xsd/settings-1.0.0.xsd">
   <localRepository>/tmp/artifacts/m2</localRepository>
+  <proxies></proxies>
   <profiles>
     <profile>
       <id>my-profile</id>
@@ -84,3 +85,19 @@ func TestSettingsGeneration(t *testing.T) {
 
   assert.Equal(t, expectedSettings, string(content))
 }
+
+func TestNewProxy(t *testing.T) {
+  proxy, err := NewProxy("my-proxy", "proxy.example.com:3128", ".example.com, localhost")
+  assert.Nil(t, err)
+  assert.Equal(t, Proxy{
+    ID:            "my-proxy",
+    Active:        true,
+    Protocol:      "http",
+    Host:          "proxy.example.com",
+    Port:          3128,
+    NonProxyHosts: "*.example.com|localhost",
+  }, proxy)
+
+  _, err = NewProxy("my-proxy", "http://proxy.example.com:port", "")
+  assert.NotNil(t, err)
+}
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maven
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCollector(t *testing.T) {
+	out := bytes.Buffer{}
+	collector := newErrorCollector(&out)
+
+	_, err := collector.Write([]byte("[INFO] BUILD FAILURE\n[ERROR] Failed to execute goal on project camel-k-integration: "))
+	assert.Nil(t, err)
+	_, err = collector.Write([]byte("Could not resolve dependencies for project\n[ERROR] \n[ERROR] -> [Help 1]\n"))
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"Failed to execute goal on project camel-k-integration: Could not resolve dependencies for project",
+	}, collector.Messages())
+	assert.Contains(t, out.String(), "BUILD FAILURE")
+}
+
+func TestClassifyErrors(t *testing.T) {
+	assert.Equal(t, ErrorKindResolution, classify([]string{
+		"Failed to execute goal on project camel-k-integration: Could not resolve dependencies for project",
+	}))
+	assert.Equal(t, ErrorKindResolution, classify([]string{
+		"Plugin org.apache.maven.plugins:maven-compiler-plugin:3.8.0 or one of its dependencies could not be resolved: " +
+			"Failed to read artifact descriptor for org.apache.maven.plugins:maven-compiler-plugin:jar:3.8.0",
+	}))
+	assert.Equal(t, ErrorKindUnknown, classify([]string{
+		"Failed to execute goal org.apache.maven.plugins:maven-compiler-plugin:3.8.0:compile (default-compile): Fatal error compiling",
+	}))
+	assert.Equal(t, ErrorKindCompilation, classify([]string{
+		"COMPILATION ERROR :",
+		"/tmp/Routes.java:[10,5] cannot find symbol",
+	}))
+	assert.Equal(t, ErrorKindUnknown, classify([]string{
+		"something went wrong",
+	}))
+}
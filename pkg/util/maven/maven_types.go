@@ -21,6 +21,8 @@ import (
 	"bytes"
 	"encoding/xml"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // Repository --
@@ -40,24 +42,55 @@ type RepositoryPolicy struct {
 
 // Build --
 type Build struct {
-	DefaultGoal string   `xml:"defaultGoal,omitempty"`
-	Plugins     []Plugin `xml:"plugins>plugin,omitempty"`
+	DefaultGoal      string            `xml:"defaultGoal,omitempty"`
+	FinalName        string            `xml:"finalName,omitempty"`
+	Extensions       *[]Extension      `xml:"extensions>extension,omitempty"`
+	PluginManagement *PluginManagement `xml:"pluginManagement,omitempty"`
+	Plugins          []Plugin          `xml:"plugins>plugin,omitempty"`
+}
+
+// PluginManagement represent maven's plugin management block
+type PluginManagement struct {
+	Plugins []Plugin `xml:"plugins>plugin,omitempty"`
+}
+
+// Extension represent a maven's build extension
+type Extension struct {
+	GroupID    string `xml:"groupId"`
+	ArtifactID string `xml:"artifactId"`
+	Version    string `xml:"version,omitempty"`
 }
 
 // Plugin --
 type Plugin struct {
-	GroupID      string       `xml:"groupId"`
-	ArtifactID   string       `xml:"artifactId"`
-	Version      string       `xml:"version,omitempty"`
-	Executions   []Execution  `xml:"executions>execution,omitempty"`
-	Dependencies []Dependency `xml:"dependencies>dependency,omitempty"`
+	GroupID       string         `xml:"groupId"`
+	ArtifactID    string         `xml:"artifactId"`
+	Version       string         `xml:"version,omitempty"`
+	Extensions    bool           `xml:"extensions,omitempty"`
+	Executions    []Execution    `xml:"executions>execution,omitempty"`
+	Dependencies  []Dependency   `xml:"dependencies>dependency,omitempty"`
+	Configuration *Configuration `xml:"configuration,omitempty"`
 }
 
 // Execution --
 type Execution struct {
-	ID    string   `xml:"id"`
-	Phase string   `xml:"phase"`
-	Goals []string `xml:"goals>goal,omitempty"`
+	ID            string         `xml:"id"`
+	Phase         string         `xml:"phase"`
+	Goals         []string       `xml:"goals>goal,omitempty"`
+	Configuration *Configuration `xml:"configuration,omitempty"`
+}
+
+// Configuration represent the free-form configuration of a plugin or of an execution
+type Configuration struct {
+	Values []ConfigurationValue `xml:",any"`
+}
+
+// ConfigurationValue is a configuration element which may hold attributes and a value or nested elements
+type ConfigurationValue struct {
+	XMLName    xml.Name
+	Attributes []xml.Attr           `xml:",any,attr"`
+	Value      string               `xml:",chardata"`
+	Children   []ConfigurationValue `xml:",any"`
 }
 
 // Properties --
@@ -102,10 +135,14 @@ func NewContext(buildDir string, project Project) Context {
 type Context struct {
 	Path                string
 	Project             Project
+	Version             string
+	Timeout             time.Duration
 	Settings            *Settings
 	SettingsData        []byte
 	AdditionalArguments []string
 	AdditionalEntries   map[string]interface{}
+	// Env holds extra KEY=value environment variables of the Maven process
+	Env []string
 }
 
 // AddEntry --
@@ -132,6 +169,37 @@ func (c *Context) AddArguments(arguments ...string) {
 	c.AdditionalArguments = append(c.AdditionalArguments, arguments...)
 }
 
+// ErrorKind classifies the cause of a failed maven invocation
+type ErrorKind string
+
+const (
+	// ErrorKindResolution is used when artifacts cannot be resolved
+	ErrorKindResolution ErrorKind = "DependencyResolution"
+	// ErrorKindCompilation is used when sources cannot be compiled
+	ErrorKindCompilation ErrorKind = "Compilation"
+	// ErrorKindTimeout is used when the invocation exceeds the configured timeout
+	ErrorKindTimeout ErrorKind = "Timeout"
+	// ErrorKindUnknown is used when the cause of the failure cannot be determined
+	ErrorKindUnknown ErrorKind = "Unknown"
+)
+
+// Error represent a failed maven invocation
+type Error struct {
+	Kind     ErrorKind
+	ExitCode int
+	Messages []string
+}
+
+// Error --
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("maven invocation failed (reason=%s, exit code=%d)", e.Kind, e.ExitCode)
+	if len(e.Messages) > 0 {
+		msg += ": " + strings.Join(e.Messages, "; ")
+	}
+
+	return msg
+}
+
 // Settings represent a maven settings
 type Settings struct {
 	XMLName           xml.Name
@@ -139,9 +207,39 @@ type Settings struct {
 	XMLNsXsi          string    `xml:"xmlns:xsi,attr"`
 	XsiSchemaLocation string    `xml:"xsi:schemaLocation,attr"`
 	LocalRepository   string    `xml:"localRepository"`
+	Servers           *[]Server `xml:"servers>server,omitempty"`
+	Mirrors           *[]Mirror `xml:"mirrors>mirror,omitempty"`
+	Proxies           []Proxy   `xml:"proxies>proxy,omitempty"`
 	Profiles          []Profile `xml:"profiles>profile,omitempty"`
 }
 
+// Server --
+type Server struct {
+	ID       string `xml:"id"`
+	Username string `xml:"username,omitempty"`
+	Password string `xml:"password,omitempty"`
+}
+
+// Mirror --
+type Mirror struct {
+	ID       string `xml:"id"`
+	Name     string `xml:"name,omitempty"`
+	URL      string `xml:"url"`
+	MirrorOf string `xml:"mirrorOf"`
+}
+
+// Proxy --
+type Proxy struct {
+	ID            string `xml:"id"`
+	Active        bool   `xml:"active"`
+	Protocol      string `xml:"protocol"`
+	Host          string `xml:"host"`
+	Port          int    `xml:"port,omitempty"`
+	Username      string `xml:"username,omitempty"`
+	Password      string `xml:"password,omitempty"`
+	NonProxyHosts string `xml:"nonProxyHosts,omitempty"`
+}
+
 // MarshalBytes --
 func (s Settings) MarshalBytes() ([]byte, error) {
 	w := &bytes.Buffer{}
@@ -174,6 +272,7 @@ type Project struct {
 	Repositories         []Repository         `xml:"repositories>repository,omitempty"`
 	PluginRepositories   []Repository         `xml:"pluginRepositories>pluginRepository,omitempty"`
 	Build                Build                `xml:"build,omitempty"`
+	Profiles             *[]Profile           `xml:"profiles>profile,omitempty"`
 }
 
 // Exclusion represent a maven's dependency exlucsion
@@ -200,11 +299,14 @@ type Dependency struct {
 
 // Profile --
 type Profile struct {
-	ID                 string       `xml:"id"`
-	Activation         Activation   `xml:"activation,omitempty"`
-	Properties         Properties   `xml:"properties,omitempty"`
-	Repositories       []Repository `xml:"repositories>repository,omitempty"`
-	PluginRepositories []Repository `xml:"pluginRepositories>pluginRepository,omitempty"`
+	ID                   string                `xml:"id"`
+	Activation           Activation            `xml:"activation,omitempty"`
+	Properties           Properties            `xml:"properties,omitempty"`
+	DependencyManagement *DependencyManagement `xml:"dependencyManagement,omitempty"`
+	Dependencies         *[]Dependency         `xml:"dependencies>dependency,omitempty"`
+	Repositories         []Repository          `xml:"repositories>repository,omitempty"`
+	PluginRepositories   []Repository          `xml:"pluginRepositories>pluginRepository,omitempty"`
+	Build                *Build                `xml:"build,omitempty"`
 }
 
 // Activation --
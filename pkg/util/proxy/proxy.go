@@ -0,0 +1,152 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util/maven"
+)
+
+// IsConfigured returns true if a proxy is configured at the platform level
+func IsConfigured(spec v1alpha1.IntegrationPlatformProxySpec) bool {
+	return spec.HTTPProxy != "" || spec.HTTPSProxy != ""
+}
+
+// EnvVars returns the standard proxy environment variables, honored by most tools run in the builder pods
+func EnvVars(spec v1alpha1.IntegrationPlatformProxySpec) []corev1.EnvVar {
+	envs := make([]corev1.EnvVar, 0, 3)
+	if spec.HTTPProxy != "" {
+		envs = append(envs, corev1.EnvVar{Name: "HTTP_PROXY", Value: spec.HTTPProxy})
+	}
+	if spec.HTTPSProxy != "" {
+		envs = append(envs, corev1.EnvVar{Name: "HTTPS_PROXY", Value: spec.HTTPSProxy})
+	}
+	if spec.NoProxy != "" {
+		envs = append(envs, corev1.EnvVar{Name: "NO_PROXY", Value: spec.NoProxy})
+	}
+	return envs
+}
+
+// KanikoArgs returns the Kaniko build arguments propagating the proxy settings to the image build
+func KanikoArgs(spec v1alpha1.IntegrationPlatformProxySpec) []string {
+	args := make([]string, 0, 3)
+	for _, env := range EnvVars(spec) {
+		args = append(args, "--build-arg="+env.Name+"="+env.Value)
+	}
+	return args
+}
+
+// MavenProxies returns the proxies to be declared in the Maven settings
+func MavenProxies(spec v1alpha1.IntegrationPlatformProxySpec) ([]maven.Proxy, error) {
+	proxies := make([]maven.Proxy, 0, 2)
+	if spec.HTTPProxy != "" {
+		p, err := maven.NewProxy("camel-k-http-proxy", spec.HTTPProxy, spec.NoProxy)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, p)
+	}
+	if spec.HTTPSProxy != "" {
+		p, err := maven.NewProxy("camel-k-https-proxy", spec.HTTPSProxy, spec.NoProxy)
+		if err != nil {
+			return nil, err
+		}
+		// Maven selects the proxy by the protocol of the repository, not the one of the proxy
+		p.Protocol = "https"
+		proxies = append(proxies, p)
+	}
+	return proxies, nil
+}
+
+// MavenSettings returns the Maven settings declaring the proxies, or nil if no proxy is configured
+func MavenSettings(spec v1alpha1.IntegrationPlatformProxySpec) (*maven.Settings, error) {
+	if !IsConfigured(spec) {
+		return nil, nil
+	}
+	proxies, err := MavenProxies(spec)
+	if err != nil {
+		return nil, err
+	}
+	settings := maven.NewSettings()
+	settings.Proxies = proxies
+	return &settings, nil
+}
+
+// NewClient returns an HTTP client using the proxy configured at the platform level, or the one
+// configured in the operator environment otherwise
+func NewClient(spec v1alpha1.IntegrationPlatformProxySpec) *http.Client {
+	if !IsConfigured(spec) {
+		return http.DefaultClient
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyURL(spec, req.URL)
+	}
+
+	return &http.Client{
+		Transport: transport,
+	}
+}
+
+// proxyURL returns the proxy to be used for the given URL, nil meaning no proxy
+func proxyURL(spec v1alpha1.IntegrationPlatformProxySpec, u *url.URL) (*url.URL, error) {
+	proxy := spec.HTTPProxy
+	if u.Scheme == "https" {
+		proxy = spec.HTTPSProxy
+	}
+	if proxy == "" || !useProxy(spec.NoProxy, strings.ToLower(u.Hostname())) {
+		return nil, nil
+	}
+	if !strings.Contains(proxy, "://") {
+		proxy = "http://" + proxy
+	}
+	return url.Parse(proxy)
+}
+
+// useProxy matches the host against the NO_PROXY entries, that are either hosts, domains or CIDRs
+func useProxy(noProxy string, host string) bool {
+	ip := net.ParseIP(host)
+	for _, entry := range strings.Split(noProxy, ",") {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		switch {
+		case entry == "":
+			continue
+		case entry == "*":
+			return false
+		case ip != nil && strings.Contains(entry, "/"):
+			if _, cidr, err := net.ParseCIDR(entry); err == nil && cidr.Contains(ip) {
+				return false
+			}
+		case strings.HasPrefix(entry, "."):
+			if strings.HasSuffix(host, entry) || host == entry[1:] {
+				return false
+			}
+		case host == entry || strings.HasSuffix(host, "."+entry):
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,176 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Credentials are the user name and password used to access a registry
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// dockerConfig is the content of the Docker config files, e.g. the .dockerconfigjson key of the
+// kubernetes.io/dockerconfigjson secrets
+type dockerConfig struct {
+	Auths map[string]struct {
+		Auth     string `json:"auth"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+	} `json:"auths"`
+}
+
+// ParseDockerConfig returns the credentials of the registries found in the given Docker config file
+func ParseDockerConfig(data []byte) (map[string]Credentials, error) {
+	config := dockerConfig{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "invalid docker config")
+	}
+
+	credentials := make(map[string]Credentials)
+	for registry, auth := range config.Auths {
+		c := Credentials{
+			Username: auth.Username,
+			Password: auth.Password,
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid docker config auth for registry %s", registry)
+			}
+			parts := strings.SplitN(string(decoded), ":", 2)
+			if len(parts) != 2 {
+				return nil, errors.Errorf("invalid docker config auth for registry %s", registry)
+			}
+			c.Username, c.Password = parts[0], parts[1]
+		}
+		credentials[normalizeRegistry(registry)] = c
+	}
+
+	return credentials, nil
+}
+
+// parseChallenge parses the WWW-Authenticate header returned by the registry, e.g.
+// Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/alpine:pull"
+func parseChallenge(header string) (string, map[string]string) {
+	params := make(map[string]string)
+	parts := strings.SplitN(strings.TrimSpace(header), " ", 2)
+	scheme := parts[0]
+	if len(parts) < 2 {
+		return scheme, params
+	}
+
+	rest := parts[1]
+	for rest != "" {
+		eq := strings.Index(rest, "=")
+		if eq < 0 {
+			break
+		}
+		key := strings.ToLower(strings.TrimSpace(rest[:eq]))
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, "\"") {
+			end := strings.Index(rest[1:], "\"")
+			if end < 0 {
+				value, rest = rest[1:], ""
+			} else {
+				value, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else if comma := strings.Index(rest, ","); comma >= 0 {
+			value, rest = rest[:comma], rest[comma:]
+		} else {
+			value, rest = rest, ""
+		}
+		params[key] = value
+
+		rest = strings.TrimLeft(rest, ", ")
+	}
+
+	return scheme, params
+}
+
+// authenticate answers the challenge of the registry, the resulting authorization being reused
+// by the following requests needing the same scope
+func (c *Client) authenticate(ctx context.Context, registry string, scope string, challenge string) error {
+	scheme, params := parseChallenge(challenge)
+	credentials, hasCredentials := c.credentials[registry]
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if !hasCredentials {
+			return errors.Errorf("no credentials to access registry %s", registry)
+		}
+		auth := base64.StdEncoding.EncodeToString([]byte(credentials.Username + ":" + credentials.Password))
+		c.authorizations[registry+" "+scope] = "Basic " + auth
+	case "bearer":
+		realm, err := url.Parse(params["realm"])
+		if err != nil || params["realm"] == "" {
+			return errors.Errorf("invalid token realm %q of registry %s", params["realm"], registry)
+		}
+		query := realm.Query()
+		if service, ok := params["service"]; ok {
+			query.Set("service", service)
+		}
+		for _, s := range strings.Fields(scope) {
+			query.Add("scope", s)
+		}
+		realm.RawQuery = query.Encode()
+
+		req, err := http.NewRequest(http.MethodGet, realm.String(), nil)
+		if err != nil {
+			return err
+		}
+		if hasCredentials {
+			req.SetBasicAuth(credentials.Username, credentials.Password)
+		}
+		resp, err := c.client.Do(req.WithContext(ctx))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return errors.Wrapf(responseError(resp), "cannot get token of registry %s", registry)
+		}
+
+		token := struct {
+			Token       string `json:"token"`
+			AccessToken string `json:"access_token"`
+		}{}
+		if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+			return errors.Wrapf(err, "invalid token of registry %s", registry)
+		}
+		if token.Token == "" {
+			token.Token = token.AccessToken
+		}
+		c.authorizations[registry+" "+scope] = "Bearer " + token.Token
+	default:
+		return errors.Errorf("unsupported authentication scheme %q of registry %s", scheme, registry)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DockerHub is the registry of the images whose reference does not include a registry
+const DockerHub = "docker.io"
+
+// Reference identifies an image in a registry, by tag or by digest
+type Reference struct {
+	Registry   string
+	Repository string
+	// Reference is either a tag or a digest
+	Reference string
+}
+
+// ParseReference parses an image reference, e.g. registry:5000/organization/image:tag, the images
+// with no registry being looked up on Docker Hub
+func ParseReference(image string) (Reference, error) {
+	ref := Reference{}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Reference = name[i+1:]
+		name = name[:i]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Reference = name[i+1:]
+		name = name[:i]
+	} else {
+		ref.Reference = "latest"
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry = normalizeRegistry(parts[0])
+		ref.Repository = parts[1]
+	} else {
+		ref.Registry = DockerHub
+		ref.Repository = name
+	}
+	if ref.Registry == DockerHub && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+
+	if ref.Repository == "" || ref.Reference == "" {
+		return Reference{}, errors.Errorf("invalid image reference %s", image)
+	}
+
+	return ref, nil
+}
+
+// String returns the image reference
+func (r Reference) String() string {
+	if strings.Contains(r.Reference, ":") {
+		return r.Registry + "/" + r.Repository + "@" + r.Reference
+	}
+	return r.Registry + "/" + r.Repository + ":" + r.Reference
+}
+
+// WithReference returns the reference to the image of the same repository with the given tag or digest
+func (r Reference) WithReference(reference string) Reference {
+	r.Reference = reference
+	return r
+}
+
+// normalizeRegistry returns the name of the registry, the Docker Hub aliases, e.g. the https://index.docker.io/v1/
+// key of the Docker config files, being translated to docker.io
+func normalizeRegistry(registry string) string {
+	registry = strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	if i := strings.Index(registry, "/"); i >= 0 {
+		registry = registry[:i]
+	}
+	if registry == "index.docker.io" || registry == "registry-1.docker.io" {
+		return DockerHub
+	}
+	return registry
+}
+
+// endpoint returns the host serving the Docker Registry API of the given registry
+func endpoint(registry string) string {
+	if registry == DockerHub {
+		return "registry-1.docker.io"
+	}
+	return registry
+}
@@ -0,0 +1,353 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// The media types of the manifests and blobs handled by the client, the Docker and OCI formats being equivalent
+const (
+	MediaTypeManifest     = "application/vnd.docker.distribution.manifest.v2+json"
+	MediaTypeManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	MediaTypeConfig       = "application/vnd.docker.container.image.v1+json"
+	MediaTypeLayer        = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+
+	MediaTypeOCIManifest = "application/vnd.oci.image.manifest.v1+json"
+	MediaTypeOCIIndex    = "application/vnd.oci.image.index.v1+json"
+	MediaTypeOCIConfig   = "application/vnd.oci.image.config.v1+json"
+	MediaTypeOCILayer    = "application/vnd.oci.image.layer.v1.tar+gzip"
+)
+
+// Descriptor references a blob, or a manifest of a manifest list
+type Descriptor struct {
+	MediaType string    `json:"mediaType"`
+	Size      int64     `json:"size"`
+	Digest    string    `json:"digest"`
+	URLs      []string  `json:"urls,omitempty"`
+	Platform  *Platform `json:"platform,omitempty"`
+}
+
+// Platform identifies the platform of the images of a manifest list
+type Platform struct {
+	Architecture string `json:"architecture"`
+	OS           string `json:"os"`
+}
+
+// DefaultPlatform is the platform of the images picked from the manifest lists
+var DefaultPlatform = Platform{
+	Architecture: "amd64",
+	OS:           "linux",
+}
+
+// Manifest describes the config and the layers of an image
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+}
+
+// IsOCI returns true if the manifest is in the OCI format, false if it's in the Docker one
+func (m *Manifest) IsOCI() bool {
+	return m.MediaType == MediaTypeOCIManifest
+}
+
+// Digest returns the digest of the given content, as found in the descriptors
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Client accesses the Docker Registry HTTP API V2, authenticating with the configured credentials, if any,
+// when challenged. It's not meant to be used concurrently
+type Client struct {
+	client         *http.Client
+	insecure       map[string]bool
+	credentials    map[string]Credentials
+	authorizations map[string]string
+}
+
+// NewClient creates a registry client sending requests with the given HTTP client
+func NewClient(client *http.Client) *Client {
+	return &Client{
+		client:         client,
+		insecure:       make(map[string]bool),
+		credentials:    make(map[string]Credentials),
+		authorizations: make(map[string]string),
+	}
+}
+
+// SetInsecure makes the client access the given registry over plain HTTP
+func (c *Client) SetInsecure(registry string) {
+	c.insecure[normalizeRegistry(registry)] = true
+}
+
+// SetCredentials sets the credentials used to access the given registry
+func (c *Client) SetCredentials(registry string, credentials Credentials) {
+	c.credentials[normalizeRegistry(registry)] = credentials
+}
+
+// GetManifest retrieves the manifest of the given image, the manifest of the image for the given platform
+// being picked from manifest lists
+func (c *Client) GetManifest(ctx context.Context, ref Reference, platform Platform) (*Manifest, error) {
+	resp, err := c.do(ctx, ref.Registry, pullScope(ref), func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodGet, c.url(ref, "/manifests/"+ref.Reference), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", strings.Join([]string{MediaTypeManifest, MediaTypeManifestList, MediaTypeOCIManifest, MediaTypeOCIIndex}, ", "))
+		return req, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Wrapf(responseError(resp), "cannot get manifest of image %s", ref)
+	}
+
+	content := struct {
+		Manifest
+		Manifests []Descriptor `json:"manifests"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&content); err != nil {
+		return nil, errors.Wrapf(err, "invalid manifest of image %s", ref)
+	}
+
+	if len(content.Manifests) > 0 {
+		for _, m := range content.Manifests {
+			if m.Platform != nil && m.Platform.OS == platform.OS && m.Platform.Architecture == platform.Architecture {
+				return c.GetManifest(ctx, ref.WithReference(m.Digest), platform)
+			}
+		}
+		return nil, errors.Errorf("no image for platform %s/%s in manifest list %s", platform.OS, platform.Architecture, ref)
+	}
+
+	manifest := content.Manifest
+	if manifest.MediaType == "" {
+		manifest.MediaType = strings.TrimSpace(strings.Split(resp.Header.Get("Content-Type"), ";")[0])
+	}
+	if manifest.MediaType != MediaTypeManifest && manifest.MediaType != MediaTypeOCIManifest {
+		return nil, errors.Errorf("unsupported manifest type %q of image %s", manifest.MediaType, ref)
+	}
+
+	return &manifest, nil
+}
+
+// PutManifest pushes the manifest of the given image, returning its digest
+func (c *Client) PutManifest(ctx context.Context, ref Reference, manifest *Manifest) (string, error) {
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(ctx, ref.Registry, pushScope(ref), func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPut, c.url(ref, "/manifests/"+ref.Reference), bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", manifest.MediaType)
+		return req, nil
+	})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", errors.Wrapf(responseError(resp), "cannot push manifest of image %s", ref)
+	}
+
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	return Digest(content), nil
+}
+
+// HasBlob returns true if the repository of the given image contains the blob with the given digest
+func (c *Client) HasBlob(ctx context.Context, ref Reference, digest string) (bool, error) {
+	resp, err := c.do(ctx, ref.Registry, pullScope(ref), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodHead, c.url(ref, "/blobs/"+digest), nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, errors.Wrapf(responseError(resp), "cannot check blob %s of image %s", digest, ref)
+	}
+}
+
+// GetBlob returns the content of the blob with the given digest, to be closed by the caller
+func (c *Client) GetBlob(ctx context.Context, ref Reference, digest string) (io.ReadCloser, error) {
+	resp, err := c.do(ctx, ref.Registry, pullScope(ref), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodGet, c.url(ref, "/blobs/"+digest), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, errors.Wrapf(responseError(resp), "cannot get blob %s of image %s", digest, ref)
+	}
+
+	return resp.Body, nil
+}
+
+// MountBlob mounts the blob with the given digest from another repository of the same registry, returning
+// false if the registry did not mount it, in which case it has to be uploaded
+func (c *Client) MountBlob(ctx context.Context, ref Reference, from string, digest string) (bool, error) {
+	scope := pushScope(ref) + " repository:" + from + ":pull"
+	query := url.Values{}
+	query.Set("mount", digest)
+	query.Set("from", from)
+
+	resp, err := c.do(ctx, ref.Registry, scope, func() (*http.Request, error) {
+		return http.NewRequest(http.MethodPost, c.url(ref, "/blobs/uploads/?"+query.Encode()), nil)
+	})
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusCreated:
+		return true, nil
+	case http.StatusAccepted:
+		return false, nil
+	default:
+		return false, errors.Wrapf(responseError(resp), "cannot mount blob %s into image %s", digest, ref)
+	}
+}
+
+// PutBlob uploads the blob with the given digest and size to the repository of the given image
+func (c *Client) PutBlob(ctx context.Context, ref Reference, digest string, size int64, content io.Reader) error {
+	// the upload session is started first, so that the client is authenticated before streaming the content
+	resp, err := c.do(ctx, ref.Registry, pushScope(ref), func() (*http.Request, error) {
+		return http.NewRequest(http.MethodPost, c.url(ref, "/blobs/uploads/"), nil)
+	})
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return errors.Wrapf(responseError(resp), "cannot start upload of blob %s to image %s", digest, ref)
+	}
+
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return errors.Wrapf(err, "invalid upload location of blob %s", digest)
+	}
+	query := location.Query()
+	query.Set("digest", digest)
+	location.RawQuery = query.Encode()
+
+	resp, err = c.do(ctx, ref.Registry, pushScope(ref), func() (*http.Request, error) {
+		req, err := http.NewRequest(http.MethodPut, location.String(), content)
+		if err != nil {
+			return nil, err
+		}
+		req.ContentLength = size
+		req.Header.Set("Content-Type", "application/octet-stream")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return errors.Wrapf(responseError(resp), "cannot upload blob %s to image %s", digest, ref)
+	}
+
+	return nil
+}
+
+// do sends the request created by the given function, answering the authentication challenge of the
+// registry if any, in which case the request is created again and retried once authenticated
+func (c *Client) do(ctx context.Context, registry string, scope string, newRequest func() (*http.Request, error)) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := newRequest()
+		if err != nil {
+			return nil, err
+		}
+		if auth, ok := c.authorizations[registry+" "+scope]; ok {
+			req.Header.Set("Authorization", auth)
+		}
+
+		resp, err := c.client.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return resp, nil
+		}
+
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := c.authenticate(ctx, registry, scope, challenge); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// url returns the URL of the given path of the repository of the image
+func (c *Client) url(ref Reference, path string) string {
+	scheme := "https"
+	if c.insecure[ref.Registry] {
+		scheme = "http"
+	}
+	return scheme + "://" + endpoint(ref.Registry) + "/v2/" + ref.Repository + path
+}
+
+// pullScope is the token scope needed to read the repository of the given image
+func pullScope(ref Reference) string {
+	return "repository:" + ref.Repository + ":pull"
+}
+
+// pushScope is the token scope needed to write to the repository of the given image
+func pushScope(ref Reference) string {
+	return "repository:" + ref.Repository + ":pull,push"
+}
+
+// responseError returns an error reporting the unexpected status of the response, along with the
+// errors returned by the registry
+func responseError(resp *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+	if message := strings.TrimSpace(string(body)); message != "" {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, message)
+	}
+	return fmt.Errorf("unexpected status %s", resp.Status)
+}
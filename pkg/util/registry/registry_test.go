@@ -0,0 +1,186 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference(t *testing.T) {
+	ref, err := ParseReference("registry:5000/ns/camel-k-kits:1234")
+	assert.Nil(t, err)
+	assert.Equal(t, Reference{Registry: "registry:5000", Repository: "ns/camel-k-kits", Reference: "1234"}, ref)
+	assert.Equal(t, "registry:5000/ns/camel-k-kits:1234", ref.String())
+
+	ref, err = ParseReference("adoptopenjdk/openjdk8:slim")
+	assert.Nil(t, err)
+	assert.Equal(t, Reference{Registry: DockerHub, Repository: "adoptopenjdk/openjdk8", Reference: "slim"}, ref)
+
+	ref, err = ParseReference("docker.io/alpine")
+	assert.Nil(t, err)
+	assert.Equal(t, Reference{Registry: DockerHub, Repository: "library/alpine", Reference: "latest"}, ref)
+
+	ref, err = ParseReference("quay.io/org/image@sha256:abcd")
+	assert.Nil(t, err)
+	assert.Equal(t, Reference{Registry: "quay.io", Repository: "org/image", Reference: "sha256:abcd"}, ref)
+	assert.Equal(t, "quay.io/org/image@sha256:abcd", ref.String())
+
+	_, err = ParseReference("registry:5000/")
+	assert.NotNil(t, err)
+}
+
+func TestParseDockerConfig(t *testing.T) {
+	auth := base64.StdEncoding.EncodeToString([]byte("user:secret:with:colons"))
+	credentials, err := ParseDockerConfig([]byte(`{"auths": {
+		"https://index.docker.io/v1/": {"auth": "` + auth + `"},
+		"registry:5000": {"username": "admin", "password": "admin"}
+	}}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]Credentials{
+		DockerHub:       {Username: "user", Password: "secret:with:colons"},
+		"registry:5000": {Username: "admin", Password: "admin"},
+	}, credentials)
+
+	_, err = ParseDockerConfig([]byte(`{"auths": {"registry": {"auth": "invalid"}}}`))
+	assert.NotNil(t, err)
+}
+
+func TestParseChallenge(t *testing.T) {
+	scheme, params := parseChallenge(`Bearer realm="https://auth.docker.io/token",service="registry.docker.io",scope="repository:library/alpine:pull,push"`)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, map[string]string{
+		"realm":   "https://auth.docker.io/token",
+		"service": "registry.docker.io",
+		"scope":   "repository:library/alpine:pull,push",
+	}, params)
+
+	scheme, params = parseChallenge(`Basic realm=registry`)
+	assert.Equal(t, "Basic", scheme)
+	assert.Equal(t, map[string]string{"realm": "registry"}, params)
+}
+
+func TestClient(t *testing.T) {
+	r := test.NewFakeRegistry()
+	defer r.Close()
+	r.Token = "token"
+
+	c := NewClient(http.DefaultClient)
+	c.SetInsecure(r.Address())
+	ref := Reference{Registry: r.Address(), Repository: "ns/image", Reference: "1.0"}
+
+	content := []byte("layer")
+	digest := Digest(content)
+
+	exists, err := c.HasBlob(context.TODO(), ref, digest)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	assert.Nil(t, c.PutBlob(context.TODO(), ref, digest, int64(len(content)), bytes.NewReader(content)))
+
+	exists, err = c.HasBlob(context.TODO(), ref, digest)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	blob, err := c.GetBlob(context.TODO(), ref, digest)
+	assert.Nil(t, err)
+	data, err := ioutil.ReadAll(blob)
+	assert.Nil(t, err)
+	assert.Nil(t, blob.Close())
+	assert.Equal(t, content, data)
+
+	// the blobs of another repository are mounted rather than uploaded again
+	other := Reference{Registry: r.Address(), Repository: "ns/other", Reference: "1.0"}
+	mounted, err := c.MountBlob(context.TODO(), other, ref.Repository, digest)
+	assert.Nil(t, err)
+	assert.True(t, mounted)
+	assert.Equal(t, []string{"ns/other@" + digest}, r.Mounted)
+
+	mounted, err = c.MountBlob(context.TODO(), other, ref.Repository, Digest([]byte("unknown")))
+	assert.Nil(t, err)
+	assert.False(t, mounted)
+
+	manifest := Manifest{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeManifest,
+		Config:        Descriptor{MediaType: MediaTypeConfig, Size: 2, Digest: Digest([]byte("{}"))},
+		Layers:        []Descriptor{{MediaType: MediaTypeLayer, Size: int64(len(content)), Digest: digest}},
+	}
+	manifestDigest, err := c.PutManifest(context.TODO(), ref, &manifest)
+	assert.Nil(t, err)
+
+	pushed, err := c.GetManifest(context.TODO(), ref, DefaultPlatform)
+	assert.Nil(t, err)
+	assert.Equal(t, &manifest, pushed)
+
+	pushed, err = c.GetManifest(context.TODO(), ref.WithReference(manifestDigest), DefaultPlatform)
+	assert.Nil(t, err)
+	assert.Equal(t, &manifest, pushed)
+}
+
+func TestClientManifestList(t *testing.T) {
+	r := test.NewFakeRegistry()
+	defer r.Close()
+
+	manifest := Manifest{
+		SchemaVersion: 2,
+		MediaType:     MediaTypeOCIManifest,
+		Config:        Descriptor{MediaType: MediaTypeOCIConfig, Size: 2, Digest: Digest([]byte("{}"))},
+		Layers:        []Descriptor{},
+	}
+	// OCI manifests may omit their media type, that is then given by the content type
+	content, err := json.Marshal(struct {
+		SchemaVersion int          `json:"schemaVersion"`
+		Config        Descriptor   `json:"config"`
+		Layers        []Descriptor `json:"layers"`
+	}{manifest.SchemaVersion, manifest.Config, manifest.Layers})
+	assert.Nil(t, err)
+	amd64 := r.AddManifest("library/alpine", "amd64", MediaTypeOCIManifest, content)
+
+	index, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"manifests": []Descriptor{
+			{MediaType: MediaTypeOCIManifest, Digest: Digest([]byte("arm64")), Platform: &Platform{Architecture: "arm64", OS: "linux"}},
+			{MediaType: MediaTypeOCIManifest, Digest: amd64, Platform: &Platform{Architecture: "amd64", OS: "linux"}},
+		},
+	})
+	assert.Nil(t, err)
+	r.AddManifest("library/alpine", "3.9", MediaTypeOCIIndex, index)
+
+	c := NewClient(http.DefaultClient)
+	c.SetInsecure(r.Address())
+	ref := Reference{Registry: r.Address(), Repository: "library/alpine", Reference: "3.9"}
+
+	resolved, err := c.GetManifest(context.TODO(), ref, DefaultPlatform)
+	assert.Nil(t, err)
+	assert.Equal(t, &manifest, resolved)
+	assert.True(t, resolved.IsOCI())
+
+	_, err = c.GetManifest(context.TODO(), ref, Platform{Architecture: "s390x", OS: "linux"})
+	assert.NotNil(t, err)
+}
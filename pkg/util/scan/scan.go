@@ -0,0 +1,258 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/util/log"
+)
+
+// Log --
+var Log = log.WithName("scan")
+
+// Severity levels, in increasing order of importance
+const (
+	SeverityUnknown  = "UNKNOWN"
+	SeverityLow      = "LOW"
+	SeverityMedium   = "MEDIUM"
+	SeverityHigh     = "HIGH"
+	SeverityCritical = "CRITICAL"
+)
+
+var severities = []string{
+	SeverityUnknown,
+	SeverityLow,
+	SeverityMedium,
+	SeverityHigh,
+	SeverityCritical,
+}
+
+// Summary holds the number of vulnerabilities found by severity
+type Summary map[string]int
+
+// Context --
+type Context struct {
+	Image string
+	// Path is a directory holding content not yet published, e.g. the layer built on top of the image,
+	// which is scanned as a filesystem
+	Path string
+	// Artifacts are the IDs of the maven artifacts of the content held by Path, that are sent to the
+	// scanning service as it cannot access the local filesystem
+	Artifacts []string
+	Endpoint  string
+	Insecure  bool
+	// Client is the HTTP client used to invoke the scanning service, the default one if not set
+	Client *http.Client
+}
+
+// Error is returned when the image contains vulnerabilities at or above the configured severity
+type Error struct {
+	Threshold string
+	Summary   Summary
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("image contains vulnerabilities at or above %s severity: %v", e.Threshold, map[string]int(e.Summary))
+}
+
+// ValidateSeverity checks that the given severity is a known one
+func ValidateSeverity(severity string) error {
+	if index(severity) == -1 {
+		return fmt.Errorf("unknown severity %s, supported values are %s", severity, strings.Join(severities, ", "))
+	}
+
+	return nil
+}
+
+// Exceeds returns the number of vulnerabilities with a severity equal to or above the given one
+func (s Summary) Exceeds(threshold string) int {
+	count := 0
+
+	min := index(threshold)
+	for severity, n := range s {
+		if min != -1 && index(severity) >= min {
+			count += n
+		}
+	}
+
+	return count
+}
+
+func index(severity string) int {
+	for i, s := range severities {
+		if strings.EqualFold(s, severity) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Run scans the image either by invoking the scanning service at the configured
+// endpoint or by running trivy locally
+func Run(ctx context.Context, scan Context) (Summary, error) {
+	if scan.Endpoint != "" {
+		return runService(ctx, scan)
+	}
+
+	return runTrivy(ctx, scan)
+}
+
+// runService posts the image to the scanning service which is expected to reply with
+// the number of vulnerabilities found by severity, i.e. {"CRITICAL": 1, "HIGH": 3}
+func runService(ctx context.Context, scan Context) (Summary, error) {
+	request := map[string]interface{}{
+		"image":    scan.Image,
+		"insecure": scan.Insecure,
+	}
+	if len(scan.Artifacts) > 0 {
+		request["artifacts"] = scan.Artifacts
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, scan.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	Log.Infof("scanning image %s using %s", scan.Image, scan.Endpoint)
+
+	client := scan.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scanning service returned status %s", res.Status)
+	}
+
+	raw := make(map[string]int)
+	if err := json.NewDecoder(res.Body).Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	summary := make(Summary)
+	for severity, n := range raw {
+		summary[strings.ToUpper(severity)] += n
+	}
+
+	return summary, nil
+}
+
+// runTrivy scans the image and the filesystem path, if any, summing up the vulnerabilities found in both
+func runTrivy(ctx context.Context, scan Context) (Summary, error) {
+	summary := make(Summary)
+
+	targets := make([][]string, 0, 2)
+	if scan.Image != "" {
+		targets = append(targets, []string{"image", scan.Image})
+	}
+	if scan.Path != "" {
+		targets = append(targets, []string{"fs", scan.Path})
+	}
+
+	for _, target := range targets {
+		s, err := runTrivyCommand(ctx, scan, target[0], target[1])
+		if err != nil {
+			return nil, err
+		}
+		for severity, n := range s {
+			summary[severity] += n
+		}
+	}
+
+	return summary, nil
+}
+
+func runTrivyCommand(ctx context.Context, scan Context, command string, target string) (Summary, error) {
+	trivyCmd := "trivy"
+	if c, ok := os.LookupEnv("TRIVY_CMD"); ok {
+		trivyCmd = c
+	}
+
+	cmd := exec.CommandContext(ctx, trivyCmd, command, "--quiet", "--no-progress", "--format", "json", target)
+	cmd.Stderr = os.Stderr
+	cmd.Env = os.Environ()
+	if scan.Insecure {
+		cmd.Env = append(cmd.Env, "TRIVY_INSECURE=true")
+	}
+
+	Log.Infof("execute: %s", strings.Join(cmd.Args, " "))
+
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, err
+	}
+
+	return parseTrivyReport(out)
+}
+
+type trivyResult struct {
+	Target          string `json:"Target"`
+	Vulnerabilities []struct {
+		VulnerabilityID string `json:"VulnerabilityID"`
+		Severity        string `json:"Severity"`
+	} `json:"Vulnerabilities"`
+}
+
+// trivyReport is the report of the trivy versions wrapping the results, i.e. {"Results": [...]}
+type trivyReport struct {
+	Results []trivyResult `json:"Results"`
+}
+
+// parseTrivyReport supports both the legacy format, an array of results, and the current one
+// where the results are wrapped into an object
+func parseTrivyReport(data []byte) (Summary, error) {
+	results := make([]trivyResult, 0)
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		report := trivyReport{}
+		if err := json.Unmarshal(trimmed, &report); err != nil {
+			return nil, err
+		}
+		results = report.Results
+	} else if err := json.Unmarshal(data, &results); err != nil {
+		return nil, err
+	}
+
+	summary := make(Summary)
+	for _, r := range results {
+		for _, v := range r.Vulnerabilities {
+			summary[strings.ToUpper(v.Severity)]++
+		}
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,141 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scan
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTrivyReport(t *testing.T) {
+	report := `[
+		{
+			"Target": "my-image (debian 9.9)",
+			"Vulnerabilities": [
+				{ "VulnerabilityID": "CVE-2019-0001", "Severity": "CRITICAL" },
+				{ "VulnerabilityID": "CVE-2019-0002", "Severity": "HIGH" },
+				{ "VulnerabilityID": "CVE-2019-0003", "Severity": "LOW" }
+			]
+		},
+		{
+			"Target": "deployments/dependencies/org.apache.camel.camel-core-2.23.1.jar",
+			"Vulnerabilities": [
+				{ "VulnerabilityID": "CVE-2019-0004", "Severity": "HIGH" }
+			]
+		}
+	]`
+
+	summary, err := parseTrivyReport([]byte(report))
+	assert.Nil(t, err)
+	assert.Equal(t, Summary{SeverityCritical: 1, SeverityHigh: 2, SeverityLow: 1}, summary)
+
+	assert.Equal(t, 1, summary.Exceeds(SeverityCritical))
+	assert.Equal(t, 3, summary.Exceeds(SeverityHigh))
+	assert.Equal(t, 3, summary.Exceeds(SeverityMedium))
+	assert.Equal(t, 4, summary.Exceeds("low"))
+	assert.Equal(t, 0, summary.Exceeds("unsupported"))
+}
+
+func TestParseTrivyResultsReport(t *testing.T) {
+	report := `{
+		"SchemaVersion": 2,
+		"ArtifactName": "my-image",
+		"Results": [
+			{
+				"Target": "my-image (debian 10.10)",
+				"Vulnerabilities": [
+					{ "VulnerabilityID": "CVE-2021-0001", "Severity": "CRITICAL" },
+					{ "VulnerabilityID": "CVE-2021-0002", "Severity": "MEDIUM" }
+				]
+			},
+			{
+				"Target": "Java"
+			}
+		]
+	}`
+
+	summary, err := parseTrivyReport([]byte(report))
+	assert.Nil(t, err)
+	assert.Equal(t, Summary{SeverityCritical: 1, SeverityMedium: 1}, summary)
+
+	summary, err = parseTrivyReport([]byte("null"))
+	assert.Nil(t, err)
+	assert.Empty(t, summary)
+}
+
+func TestRunTrivy(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "trivy-")
+	assert.Nil(t, err)
+	defer os.RemoveAll(tmpDir)
+
+	// the fake trivy reports a vulnerability in the image and another one in the filesystem
+	script := path.Join(tmpDir, "trivy")
+	assert.Nil(t, ioutil.WriteFile(script, []byte(`#!/bin/sh
+if [ "$1" = "image" ]; then
+  echo '[{"Target": "base", "Vulnerabilities": [{"VulnerabilityID": "CVE-1", "Severity": "HIGH"}]}]'
+else
+  echo '{"Results": [{"Target": "Java", "Vulnerabilities": [{"VulnerabilityID": "CVE-2", "Severity": "LOW"}]}]}'
+fi
+`), 0755))
+	assert.Nil(t, os.Setenv("TRIVY_CMD", script))
+	defer os.Unsetenv("TRIVY_CMD")
+
+	summary, err := Run(context.TODO(), Context{Image: "registry/base:1", Path: tmpDir})
+	assert.Nil(t, err)
+	assert.Equal(t, Summary{SeverityHigh: 1, SeverityLow: 1}, summary)
+
+	summary, err = Run(context.TODO(), Context{Image: "registry/base:1"})
+	assert.Nil(t, err)
+	assert.Equal(t, Summary{SeverityHigh: 1}, summary)
+}
+
+func TestRunService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := make(map[string]interface{})
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&request))
+		assert.Equal(t, "registry/my-image:1", request["image"])
+		assert.Equal(t, []interface{}{"org.apache.camel:camel-core:jar:2.23.1"}, request["artifacts"])
+
+		_, _ = w.Write([]byte(`{"critical": 0, "high": 2, "Medium": 5}`))
+	}))
+	defer server.Close()
+
+	summary, err := Run(context.TODO(), Context{
+		Image:     "registry/my-image:1",
+		Artifacts: []string{"org.apache.camel:camel-core:jar:2.23.1"},
+		Endpoint:  server.URL,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, Summary{SeverityCritical: 0, SeverityHigh: 2, SeverityMedium: 5}, summary)
+	assert.Equal(t, 0, summary.Exceeds(SeverityCritical))
+	assert.Equal(t, 2, summary.Exceeds(SeverityHigh))
+}
+
+func TestValidateSeverity(t *testing.T) {
+	assert.Nil(t, ValidateSeverity("HIGH"))
+	assert.Nil(t, ValidateSeverity("critical"))
+	assert.NotNil(t, ValidateSeverity("SEVERE"))
+}
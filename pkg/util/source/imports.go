@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"strings"
+
+	"github.com/apache/camel-k/pkg/util/camel"
+)
+
+// camelPackages are the packages of the Camel components, data formats and languages, the name of the artifact
+// shipping a package being derived from the sub packages, e.g. camel-aws for org.apache.camel.component.aws.s3
+var camelPackages = []string{
+	"org.apache.camel.component.",
+	"org.apache.camel.dataformat.",
+	"org.apache.camel.language.",
+}
+
+// libraryPackages maps the packages of the third party libraries that are used through a Camel data format or
+// language to the catalog artifact providing it
+var libraryPackages = map[string]string{
+	"com.fasterxml.jackson":                "camel-jackson",
+	"com.fasterxml.jackson.dataformat.xml": "camel-jacksonxml",
+	"com.google.gson":                      "camel-gson",
+	"com.google.protobuf":                  "camel-protobuf",
+	"com.jayway.jsonpath":                  "camel-jsonpath",
+	"com.thoughtworks.xstream":             "camel-xstream",
+	"org.apache.avro":                      "camel-avro",
+	"org.apache.commons.csv":               "camel-csv",
+	"org.yaml.snakeyaml":                   "camel-snakeyaml",
+}
+
+// findImports returns the names imported by the import statements of the given Java, Groovy or Kotlin source code,
+// without the trailing wildcard of on demand imports
+func findImports(content string) []string {
+	tokens := tokenize(content)
+	imports := make([]string, 0)
+
+	for i := 0; i < len(tokens); i++ {
+		if tokens[i].kind != tokenIdentifier || tokens[i].value != "import" {
+			continue
+		}
+		i++
+		if i < len(tokens) && tokens[i].kind == tokenIdentifier && tokens[i].value == "static" {
+			i++
+		}
+
+		segments := make([]string, 0)
+		for ; i < len(tokens) && tokens[i].kind == tokenIdentifier; i += 2 {
+			segments = append(segments, tokens[i].value)
+			if i+1 >= len(tokens) || !tokens[i+1].isSymbol(".") {
+				break
+			}
+		}
+		if len(segments) > 0 {
+			imports = append(imports, strings.Join(segments, "."))
+		}
+	}
+
+	return imports
+}
+
+// ImportDependency returns the dependency on the catalog artifact providing the given imported class or package,
+// or an empty string if the import does not require any artifact of the catalog
+func ImportDependency(catalog *camel.RuntimeCatalog, name string) string {
+	for _, prefix := range camelPackages {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		// the sub packages, up to the imported class
+		packages := make([]string, 0)
+		for _, segment := range strings.Split(name[len(prefix):], ".") {
+			if segment == "" || segment[0] < 'a' || segment[0] > 'z' {
+				break
+			}
+			packages = append(packages, segment)
+		}
+		// the most specific artifact wins, e.g. camel-aws-s3 would win over camel-aws
+		for n := len(packages); n > 0; n-- {
+			if artifact, ok := catalog.Artifacts["camel-"+strings.Join(packages[:n], "-")]; ok {
+				return artifactDependency(&artifact)
+			}
+		}
+		return ""
+	}
+
+	artifactID := ""
+	longest := 0
+	for pkg, id := range libraryPackages {
+		if (name == pkg || strings.HasPrefix(name, pkg+".")) && len(pkg) > longest {
+			artifactID = id
+			longest = len(pkg)
+		}
+	}
+	if artifact, ok := catalog.Artifacts[artifactID]; ok {
+		return artifactDependency(&artifact)
+	}
+	return ""
+}
@@ -0,0 +1,110 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/apache/camel-k/pkg/util/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindImports(t *testing.T) {
+	testCases := []struct {
+		name    string
+		source  string
+		imports []string
+	}{
+		{
+			name: "java",
+			source: `
+				package org.acme;
+
+				import java.util.List;
+				import static org.apache.camel.builder.Builder.constant;
+				import org.apache.camel.component.jackson.*;
+				// import org.apache.camel.component.kafka.KafkaConstants;
+				/* import com.google.gson.Gson; */
+
+				public class Routes {
+					String s = "import org.apache.avro.Schema;";
+				}`,
+			imports: []string{"java.util.List", "org.apache.camel.builder.Builder.constant", "org.apache.camel.component.jackson"},
+		},
+		{
+			name: "groovy",
+			source: `
+				import com.fasterxml.jackson.databind.ObjectMapper as Mapper
+				import org.apache.camel.dataformat.bindy.annotation.*
+				from('timer:tick').to('log:info')`,
+			imports: []string{"com.fasterxml.jackson.databind.ObjectMapper", "org.apache.camel.dataformat.bindy.annotation"},
+		},
+		{
+			name: "kotlin",
+			source: `
+				import org.apache.camel.component.aws.s3.S3Constants
+				import org.yaml.snakeyaml.Yaml as SnakeYaml
+
+				from("timer:tick").to("log:info")`,
+			imports: []string{"org.apache.camel.component.aws.s3.S3Constants", "org.yaml.snakeyaml.Yaml"},
+		},
+		{
+			name:    "no imports",
+			source:  `from("timer:tick").to("log:info")`,
+			imports: []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.imports, findImports(tc.source))
+		})
+	}
+}
+
+func TestImportDependency(t *testing.T) {
+	catalog, err := test.DefaultCatalog()
+	assert.Nil(t, err)
+
+	testCases := []struct {
+		name       string
+		dependency string
+	}{
+		{name: "org.apache.camel.component.jackson.JacksonDataFormat", dependency: "camel:jackson"},
+		{name: "org.apache.camel.component.jackson", dependency: "camel:jackson"},
+		{name: "org.apache.camel.component.aws.s3.S3Constants", dependency: "camel:aws"},
+		{name: "org.apache.camel.component.kafka.KafkaConstants", dependency: "camel:kafka"},
+		{name: "org.apache.camel.dataformat.bindy.annotation.CsvRecord", dependency: "camel:bindy"},
+		{name: "org.apache.camel.language.groovy.GroovyLanguage", dependency: "camel:groovy"},
+		{name: "com.fasterxml.jackson.databind.ObjectMapper", dependency: "camel:jackson"},
+		{name: "com.fasterxml.jackson.dataformat.xml.XmlMapper", dependency: "camel:jacksonxml"},
+		{name: "com.google.gson.Gson", dependency: "camel:gson"},
+		{name: "org.yaml.snakeyaml.Yaml", dependency: "camel:snakeyaml"},
+		{name: "org.apache.commons.csv", dependency: "camel:csv"},
+		{name: "org.apache.camel.component.timer.TimerComponent", dependency: ""},
+		{name: "org.apache.camel.builder.RouteBuilder", dependency: ""},
+		{name: "com.google.gsonx.Gson", dependency: ""},
+		{name: "java.util.List", dependency: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.dependency, ImportDependency(catalog, tc.name))
+		})
+	}
+}
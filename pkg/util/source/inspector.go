@@ -25,19 +25,11 @@ import (
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
 	"github.com/apache/camel-k/pkg/util"
 	"github.com/apache/camel-k/pkg/util/camel"
+	uriutil "github.com/apache/camel-k/pkg/util/uri"
 	"github.com/scylladb/go-set/strset"
 )
 
 var (
-	singleQuotedFrom = regexp.MustCompile(`from\s*\(\s*'([a-z0-9-]+:[^']+)'`)
-	doubleQuotedFrom = regexp.MustCompile(`from\s*\(\s*"([a-z0-9-]+:[^"]+)"`)
-	singleQuotedTo   = regexp.MustCompile(`\.to\s*\(\s*'([a-z0-9-]+:[^']+)'`)
-	singleQuotedToD  = regexp.MustCompile(`\.toD\s*\(\s*'([a-z0-9-]+:[^']+)'`)
-	singleQuotedToF  = regexp.MustCompile(`\.toF\s*\(\s*'([a-z0-9-]+:[^']+)'`)
-	doubleQuotedTo   = regexp.MustCompile(`\.to\s*\(\s*"([a-z0-9-]+:[^"]+)"`)
-	doubleQuotedToD  = regexp.MustCompile(`\.toD\s*\(\s*"([a-z0-9-]+:[^"]+)"`)
-	doubleQuotedToF  = regexp.MustCompile(`\.toF\s*\(\s*"([a-z0-9-]+:[^"]+)"`)
-
 	additionalDependencies = map[string]string{
 		".*JsonLibrary\\.Jackson.*": "camel:jackson",
 		".*\\.hystrix().*":          "camel:hystrix",
@@ -107,7 +99,7 @@ func (i *baseInspector) discoverDependencies(source v1alpha1.SourceSpec, meta *M
 	candidates := strset.New()
 
 	for _, uri := range uris {
-		candidateComp := i.decodeComponent(uri)
+		candidateComp := ComponentDependency(i.catalog, uri)
 		if candidateComp != "" {
 			candidates.Add(candidateComp)
 		}
@@ -120,6 +112,15 @@ func (i *baseInspector) discoverDependencies(source v1alpha1.SourceSpec, meta *M
 		}
 	}
 
+	switch source.InferLanguage() {
+	case v1alpha1.LanguageJavaSource, v1alpha1.LanguageGroovy, v1alpha1.LanguageKotlin:
+		for _, name := range findImports(source.Content) {
+			if dep := ImportDependency(i.catalog, name); dep != "" {
+				candidates.Add(dep)
+			}
+		}
+	}
+
 	components := candidates.List()
 
 	sort.Strings(components)
@@ -127,21 +128,27 @@ func (i *baseInspector) discoverDependencies(source v1alpha1.SourceSpec, meta *M
 	return components
 }
 
-func (i *baseInspector) decodeComponent(uri string) string {
-	uriSplit := strings.SplitN(uri, ":", 2)
-	if len(uriSplit) < 2 {
+// ComponentDependency returns the dependency on the component the given endpoint URI belongs to, or an empty string
+// if the scheme of the URI is unknown to the catalog
+func ComponentDependency(catalog *camel.RuntimeCatalog, uri string) string {
+	scheme := uriutil.Scheme(uri)
+	if scheme == "" {
 		return ""
 	}
-	uriStart := uriSplit[0]
-	if component := i.catalog.GetArtifactByScheme(uriStart); component != nil {
-		artifactID := component.ArtifactID
-		if component.GroupID == "org.apache.camel" && strings.HasPrefix(artifactID, "camel-") {
-			return "camel:" + artifactID[6:]
-		}
-		if component.GroupID == "org.apache.camel.k" && strings.HasPrefix(artifactID, "camel-") {
-			return "camel-k:" + artifactID[6:]
-		}
-		return "mvn:" + component.GroupID + ":" + artifactID + ":" + component.Version
+	if component := catalog.GetArtifactByScheme(scheme); component != nil {
+		return artifactDependency(component)
 	}
 	return ""
 }
+
+// artifactDependency returns the dependency on the given catalog artifact, using the camel: and camel-k: shortcuts
+func artifactDependency(artifact *v1alpha1.CamelArtifact) string {
+	artifactID := artifact.ArtifactID
+	if artifact.GroupID == "org.apache.camel" && strings.HasPrefix(artifactID, "camel-") {
+		return "camel:" + artifactID[6:]
+	}
+	if artifact.GroupID == "org.apache.camel.k" && strings.HasPrefix(artifactID, "camel-") {
+		return "camel-k:" + artifactID[6:]
+	}
+	return "mvn:" + artifact.GroupID + ":" + artifactID + ":" + artifact.Version
+}
@@ -19,7 +19,6 @@ package source
 
 import (
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
-	"github.com/apache/camel-k/pkg/util"
 )
 
 // GroovyInspector --
@@ -29,23 +28,11 @@ type GroovyInspector struct {
 
 // Extract --
 func (i GroovyInspector) Extract(source v1alpha1.SourceSpec, meta *Metadata) error {
-	from := util.FindAllDistinctStringSubmatch(
-		source.Content,
-		singleQuotedFrom,
-		doubleQuotedFrom,
-	)
-	to := util.FindAllDistinctStringSubmatch(
-		source.Content,
-		singleQuotedTo,
-		doubleQuotedTo,
-		singleQuotedToD,
-		doubleQuotedToD,
-		singleQuotedToF,
-		doubleQuotedToF,
-	)
+	from, to := findURIs(source.Content)
 
 	meta.FromURIs = append(meta.FromURIs, from...)
 	meta.ToURIs = append(meta.ToURIs, to...)
+	meta.Routes = append(meta.Routes, findRoutes(source.Content)...)
 	meta.Dependencies = i.discoverDependencies(source, meta)
 
 	return nil
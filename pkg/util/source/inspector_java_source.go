@@ -19,7 +19,6 @@ package source
 
 import (
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
-	"github.com/apache/camel-k/pkg/util"
 )
 
 // JavaSourceInspector --
@@ -29,19 +28,15 @@ type JavaSourceInspector struct {
 
 // Extract --
 func (i JavaSourceInspector) Extract(source v1alpha1.SourceSpec, meta *Metadata) error {
-	from := util.FindAllDistinctStringSubmatch(
-		source.Content,
-		doubleQuotedFrom,
-	)
-	to := util.FindAllDistinctStringSubmatch(
-		source.Content,
-		doubleQuotedTo,
-		doubleQuotedToD,
-		doubleQuotedToF,
-	)
+	from, to, err := parseJavaURIs(source.Content)
+	if err != nil {
+		// the source cannot be parsed, e.g. because it is incomplete, so URIs are looked for in the tokens
+		from, to = findURIs(source.Content)
+	}
 
 	meta.FromURIs = append(meta.FromURIs, from...)
 	meta.ToURIs = append(meta.ToURIs, to...)
+	meta.Routes = append(meta.Routes, findRoutes(source.Content)...)
 	meta.Dependencies = i.discoverDependencies(source, meta)
 
 	return nil
@@ -19,20 +19,59 @@ package source
 
 import (
 	"encoding/xml"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util"
 )
 
-// XMLInspector --
-type XMLInspector struct {
-	baseInspector
+// camelXMLNamespace prefixes the namespaces of the Camel XML DSL, i.e. http://camel.apache.org/schema/spring
+// and http://camel.apache.org/schema/blueprint
+const camelXMLNamespace = "http://camel.apache.org/schema/"
+
+// xmlElementDependencies maps the elements of the Camel XML DSL to the dependencies they require
+var xmlElementDependencies = map[string]string{
+	"hystrix":              "camel:hystrix",
+	"hystrixConfiguration": "camel:hystrix",
 }
 
-// Extract --
-func (i XMLInspector) Extract(source v1alpha1.SourceSpec, meta *Metadata) error {
-	content := strings.NewReader(source.Content)
-	decoder := xml.NewDecoder(content)
+// XMLRoutes holds what the Camel elements of an XML document define
+type XMLRoutes struct {
+	// All starting URIs of defined routes
+	FromURIs []string
+	// All end URIs of defined routes, including the ones of the route configurations
+	ToURIs []string
+	// Rest is true if the document contains REST DSL definitions
+	Rest bool
+	// The paths of the REST DSL definitions
+	RestPaths []string
+	// The component and port set by the REST DSL configuration
+	RestComponent string
+	RestPort      int
+	// The context path set by the REST DSL configuration
+	RestContextPath string
+	// The local names of all the Camel elements
+	Elements []string
+	// The routes and REST DSL definitions, in the order they are defined
+	Routes []Route
+}
+
+// ParseXMLRoutes extracts the Camel definitions of an XML document, either a bare <routes>, <rests> or
+// <routeConfiguration> document, or a Spring or Blueprint document embedding a <camelContext>. Elements
+// are matched whatever their namespace prefix, and those in a namespace other than the Camel ones, e.g. Spring
+// beans, are skipped. Comments are ignored and the parsing stops at the first syntax error
+func ParseXMLRoutes(content string) XMLRoutes {
+	routes := XMLRoutes{}
+	decoder := xml.NewDecoder(strings.NewReader(content))
+
+	// the URI of the endpoint of the enrich EIPs is either an attribute or a constant expression
+	var enrich *[]string
+	constant := false
+
+	// the route or rest definition being read, if any
+	var route *Route
 
 	for {
 		// Read tokens from the XML document in a stream.
@@ -41,25 +80,125 @@ func (i XMLInspector) Extract(source v1alpha1.SourceSpec, meta *Metadata) error
 			break
 		}
 
-		if se, ok := t.(xml.StartElement); ok {
-			switch se.Name.Local {
+		switch e := t.(type) {
+		case xml.StartElement:
+			if !isCamelXMLNamespace(e.Name.Space) {
+				continue
+			}
+
+			util.StringSliceUniqueAdd(&routes.Elements, e.Name.Local)
+
+			switch e.Name.Local {
+			case "route":
+				route = &Route{ID: xmlAttr(e, "id"), To: make([]string, 0)}
 			case "from", "fromF":
-				for _, a := range se.Attr {
-					if a.Name.Local == "uri" {
-						meta.FromURIs = append(meta.FromURIs, a.Value)
+				if uri := xmlAttr(e, "uri"); uri != "" {
+					routes.FromURIs = append(routes.FromURIs, uri)
+					if route != nil && route.From == "" {
+						route.From = uri
 					}
 				}
-			case "to", "toD", "toF":
-				for _, a := range se.Attr {
-					if a.Name.Local == "uri" {
-						meta.ToURIs = append(meta.ToURIs, a.Value)
+			case "to", "toD", "toF", "wireTap", "enrich":
+				if uri := xmlAttr(e, "uri"); uri != "" {
+					routes.ToURIs = append(routes.ToURIs, uri)
+					if route != nil {
+						route.To = append(route.To, uri)
 					}
 				}
+				if e.Name.Local == "enrich" {
+					enrich = &routes.ToURIs
+				}
+			case "pollEnrich":
+				// the endpoint polled by the EIP is consumed from
+				if uri := xmlAttr(e, "uri"); uri != "" {
+					routes.FromURIs = append(routes.FromURIs, uri)
+				}
+				enrich = &routes.FromURIs
+			case "constant":
+				constant = enrich != nil
+			case "rest":
+				routes.Rest = true
+				route = &Route{ID: xmlAttr(e, "id"), To: make([]string, 0), RestPath: xmlAttr(e, "path")}
+				if route.RestPath == "" {
+					route.RestPath = "/"
+				} else {
+					util.StringSliceUniqueAdd(&routes.RestPaths, route.RestPath)
+				}
+			case "restConfiguration":
+				routes.RestComponent = xmlAttr(e, "component")
+				if port, err := strconv.Atoi(xmlAttr(e, "port")); err == nil {
+					routes.RestPort = port
+				}
+				routes.RestContextPath = xmlAttr(e, "contextPath")
+			}
+		case xml.CharData:
+			if uri := strings.TrimSpace(string(e)); constant && uri != "" {
+				*enrich = append(*enrich, uri)
+				if route != nil && enrich == &routes.ToURIs {
+					route.To = append(route.To, uri)
+				}
+			}
+		case xml.EndElement:
+			if !isCamelXMLNamespace(e.Name.Space) {
+				continue
+			}
+
+			switch e.Name.Local {
+			case "constant":
+				constant = false
+			case "enrich", "pollEnrich":
+				enrich = nil
+			case "route", "rest":
+				if route != nil {
+					routes.Routes = append(routes.Routes, *route)
+					route = nil
+				}
 			}
 		}
 	}
 
+	return routes
+}
+
+// isCamelXMLNamespace returns true if the given namespace is empty, a Camel one, or an undeclared prefix
+func isCamelXMLNamespace(space string) bool {
+	return space == "" || strings.HasPrefix(space, camelXMLNamespace) || !strings.Contains(space, ":")
+}
+
+func xmlAttr(se xml.StartElement, name string) string {
+	for _, a := range se.Attr {
+		if a.Name.Local == name {
+			return strings.TrimSpace(a.Value)
+		}
+	}
+	return ""
+}
+
+// XMLInspector --
+type XMLInspector struct {
+	baseInspector
+}
+
+// Extract --
+func (i XMLInspector) Extract(source v1alpha1.SourceSpec, meta *Metadata) error {
+	routes := ParseXMLRoutes(source.Content)
+
+	meta.FromURIs = append(meta.FromURIs, routes.FromURIs...)
+	meta.ToURIs = append(meta.ToURIs, routes.ToURIs...)
+	meta.Routes = append(meta.Routes, routes.Routes...)
 	meta.Dependencies = i.discoverDependencies(source, meta)
 
+	if routes.RestComponent != "" {
+		if dep := ComponentDependency(i.catalog, routes.RestComponent+":"); dep != "" {
+			util.StringSliceUniqueAdd(&meta.Dependencies, dep)
+		}
+	}
+	for _, element := range routes.Elements {
+		if dep, ok := xmlElementDependencies[element]; ok {
+			util.StringSliceUniqueAdd(&meta.Dependencies, dep)
+		}
+	}
+	sort.Strings(meta.Dependencies)
+
 	return nil
 }
@@ -0,0 +1,104 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSpringXMLRoutes(t *testing.T) {
+	routes := ParseXMLRoutes(`
+		<beans xmlns="http://www.springframework.org/schema/beans"
+			xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">
+			<bean id="to" class="org.acme.Processor">
+				<property name="uri" value="jms:queue" />
+			</bean>
+			<camelContext xmlns="http://camel.apache.org/schema/spring">
+				<restConfiguration component="undertow" port="8090" contextPath="/services" />
+				<rest path="/api">
+					<get uri="/items">
+						<to uri="direct:items" />
+					</get>
+				</rest>
+				<!-- <from uri="timer:commented" /> -->
+				<route>
+					<from uri="timer:tick" />
+					<toD uri="log:${header.level}" />
+				</route>
+			</camelContext>
+		</beans>
+	`)
+
+	assert.Equal(t, []string{"timer:tick"}, routes.FromURIs)
+	assert.Equal(t, []string{"direct:items", "log:${header.level}"}, routes.ToURIs)
+	assert.True(t, routes.Rest)
+	assert.Equal(t, []string{"/api"}, routes.RestPaths)
+	assert.Equal(t, "undertow", routes.RestComponent)
+	assert.Equal(t, 8090, routes.RestPort)
+	assert.Equal(t, "/services", routes.RestContextPath)
+	assert.NotContains(t, routes.Elements, "bean")
+	assert.NotContains(t, routes.Elements, "property")
+}
+
+func TestParseNamespacedXMLRoutes(t *testing.T) {
+	routes := ParseXMLRoutes(`
+		<blueprint xmlns="http://www.osgi.org/xmlns/blueprint/v1.0.0"
+			xmlns:camel="http://camel.apache.org/schema/blueprint">
+			<camel:camelContext>
+				<camel:rest>
+					<camel:post uri="/orders">
+						<camel:to uri="direct:orders" />
+					</camel:post>
+				</camel:rest>
+				<camel:route>
+					<camel:from uri="direct:orders" />
+					<camel:to uri="kafka:orders" />
+				</camel:route>
+			</camel:camelContext>
+		</blueprint>
+	`)
+
+	assert.Equal(t, []string{"direct:orders"}, routes.FromURIs)
+	assert.Equal(t, []string{"direct:orders", "kafka:orders"}, routes.ToURIs)
+	assert.True(t, routes.Rest)
+	assert.Empty(t, routes.RestPaths)
+
+	// undeclared prefixes are tolerated
+	routes = ParseXMLRoutes(`<camel:routes><camel:route><camel:from uri="timer:tick" /></camel:route></camel:routes>`)
+	assert.Equal(t, []string{"timer:tick"}, routes.FromURIs)
+}
+
+func TestParseXMLRouteConfiguration(t *testing.T) {
+	routes := ParseXMLRoutes(`
+		<routeConfiguration xmlns="http://camel.apache.org/schema/spring">
+			<onException>
+				<exception>java.lang.Exception</exception>
+				<handled><constant>true</constant></handled>
+				<to uri="kafka:errors" />
+			</onException>
+		</routeConfiguration>
+	`)
+
+	assert.Empty(t, routes.FromURIs)
+	assert.Equal(t, []string{"kafka:errors"}, routes.ToURIs)
+	assert.False(t, routes.Rest)
+	assert.Contains(t, routes.Elements, "routeConfiguration")
+	assert.Contains(t, routes.Elements, "onException")
+}
@@ -18,32 +18,67 @@ limitations under the License.
 package source
 
 import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
 	"github.com/apache/camel-k/pkg/apis/camel/v1alpha1"
+	"github.com/apache/camel-k/pkg/util"
 	yaml2 "gopkg.in/yaml.v2"
 )
 
+// yamlToKeys are the keys of the YAML DSL steps producing to an endpoint
+var yamlToKeys = map[string]bool{
+	"to":      true,
+	"toD":     true,
+	"wireTap": true,
+	"enrich":  true,
+}
+
+// yamlFromKeys are the keys of the YAML DSL consuming from an endpoint
+var yamlFromKeys = map[string]bool{
+	"from":       true,
+	"pollEnrich": true,
+}
+
 // YAMLFlowInspector --
 type YAMLFlowInspector struct {
 	baseInspector
 }
 
-// Extract --
+// Extract supports both the flows made of endpoint steps, and the Camel YAML route DSL, i.e. route, from, the
+// steps nested in the EIPs, with to, toD, wireTap, enrich and pollEnrich endpoints, and the rest definitions
 func (i YAMLFlowInspector) Extract(source v1alpha1.SourceSpec, meta *Metadata) error {
-	var flows []v1alpha1.Flow
+	var definitions []interface{}
 
-	if err := yaml2.Unmarshal([]byte(source.Content), &flows); err != nil {
+	if err := yaml2.Unmarshal([]byte(source.Content), &definitions); err != nil {
 		return nil
 	}
 
-	for _, flow := range flows {
-		if flow.Steps[0].URI != "" {
-			meta.FromURIs = append(meta.FromURIs, flow.Steps[0].URI)
-		}
+	for _, definition := range definitions {
+		// the URIs of each definition are collected apart to be reported in its route
+		m := Metadata{}
 
-		for i := 1; i < len(flow.Steps); i++ {
-			if flow.Steps[i].URI != "" {
-				meta.ToURIs = append(meta.ToURIs, flow.Steps[i].URI)
+		if flow, ok := endpointFlow(definition); ok {
+			for i, step := range flow.Steps {
+				if step.URI == "" {
+					continue
+				}
+				if i == 0 {
+					m.FromURIs = append(m.FromURIs, step.URI)
+				} else {
+					m.ToURIs = append(m.ToURIs, step.URI)
+				}
 			}
+		} else {
+			inspectYAMLNode(definition, &m)
+		}
+
+		meta.FromURIs = append(meta.FromURIs, m.FromURIs...)
+		meta.ToURIs = append(meta.ToURIs, m.ToURIs...)
+		if route, ok := yamlRoute(definition, m); ok {
+			meta.Routes = append(meta.Routes, route)
 		}
 	}
 
@@ -51,3 +86,140 @@ func (i YAMLFlowInspector) Extract(source v1alpha1.SourceSpec, meta *Metadata) e
 
 	return nil
 }
+
+// endpointFlow returns the flow made of endpoint steps the definition is, if so
+func endpointFlow(definition interface{}) (v1alpha1.Flow, bool) {
+	node, ok := definition.(map[interface{}]interface{})
+	if !ok {
+		return v1alpha1.Flow{}, false
+	}
+	steps, ok := node["steps"].([]interface{})
+	if !ok || len(node) != 1 {
+		return v1alpha1.Flow{}, false
+	}
+
+	flow := v1alpha1.Flow{}
+	for _, s := range steps {
+		step, ok := s.(map[interface{}]interface{})
+		if !ok || step["kind"] == nil {
+			return v1alpha1.Flow{}, false
+		}
+		uri, _ := step["uri"].(string)
+		flow.Steps = append(flow.Steps, v1alpha1.Step{
+			Kind: fmt.Sprint(step["kind"]),
+			URI:  uri,
+		})
+	}
+
+	return flow, true
+}
+
+// yamlRoute returns the route of the given top-level definition, i.e. a flow, a route, a from or a rest
+// definition, whose URIs have been collected into the given metadata
+func yamlRoute(definition interface{}, m Metadata) (Route, bool) {
+	node, ok := definition.(map[interface{}]interface{})
+	if !ok {
+		return Route{}, false
+	}
+
+	route := Route{To: make([]string, 0)}
+	for _, uri := range m.ToURIs {
+		util.StringSliceUniqueAdd(&route.To, uri)
+	}
+
+	switch {
+	case node["steps"] != nil:
+		if len(m.FromURIs) > 0 {
+			route.From = m.FromURIs[0]
+		}
+	case node["route"] != nil:
+		r, _ := node["route"].(map[interface{}]interface{})
+		route.ID, _ = r["id"].(string)
+		route.From = yamlEndpointURI(r["from"])
+	case node["from"] != nil:
+		route.From = yamlEndpointURI(node["from"])
+		if from, ok := node["from"].(map[interface{}]interface{}); ok {
+			route.ID, _ = from["id"].(string)
+		}
+	case node["rest"] != nil:
+		r, _ := node["rest"].(map[interface{}]interface{})
+		route.ID, _ = r["id"].(string)
+		route.RestPath, _ = r["path"].(string)
+		if route.RestPath == "" {
+			route.RestPath = "/"
+		}
+	default:
+		return Route{}, false
+	}
+
+	return route, true
+}
+
+// inspectYAMLNode collects the endpoint URIs of the node of the YAML DSL and of its children, the keys of the
+// mappings being visited in lexical order as yaml.v2 does not retain their order
+func inspectYAMLNode(node interface{}, meta *Metadata) {
+	switch n := node.(type) {
+	case []interface{}:
+		for _, child := range n {
+			inspectYAMLNode(child, meta)
+		}
+	case map[interface{}]interface{}:
+		keys := make([]string, 0, len(n))
+		for k := range n {
+			keys = append(keys, fmt.Sprint(k))
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			value := n[k]
+			switch {
+			case yamlFromKeys[k]:
+				if uri := yamlEndpointURI(value); uri != "" {
+					meta.FromURIs = append(meta.FromURIs, uri)
+				}
+				// the steps of the route are nested in from
+				inspectYAMLNode(value, meta)
+			case yamlToKeys[k]:
+				if uri := yamlEndpointURI(value); uri != "" {
+					meta.ToURIs = append(meta.ToURIs, uri)
+				}
+			default:
+				inspectYAMLNode(value, meta)
+			}
+		}
+	}
+}
+
+// yamlEndpointURI returns the URI of an endpoint of the YAML DSL, either in its short form, e.g. to: "log:info",
+// or in its long form, with its parameters, e.g. to: { uri: "log:info", parameters: { showAll: true } }
+func yamlEndpointURI(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[interface{}]interface{}:
+		uri, ok := v["uri"].(string)
+		if !ok {
+			return ""
+		}
+
+		parameters, ok := v["parameters"].(map[interface{}]interface{})
+		if !ok || len(parameters) == 0 {
+			return uri
+		}
+
+		query := make([]string, 0, len(parameters))
+		for k, p := range parameters {
+			query = append(query, fmt.Sprintf("%v=%s", k, url.QueryEscape(fmt.Sprint(p))))
+		}
+		sort.Strings(query)
+
+		separator := "?"
+		if strings.Contains(uri, "?") {
+			separator = "&"
+		}
+
+		return uri + separator + strings.Join(query, "&")
+	default:
+		return ""
+	}
+}
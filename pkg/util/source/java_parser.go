@@ -0,0 +1,378 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// javaExpression is a node of the abstract syntax tree of a Java expression
+type javaExpression interface {
+	// constant returns the value of the expression when it can be computed at compile time
+	constant(scope *javaScope) (javaLiteral, bool)
+}
+
+// javaLiteral is a string, character, integer or boolean literal
+type javaLiteral struct {
+	value   string
+	numeric bool
+}
+
+func (e javaLiteral) constant(*javaScope) (javaLiteral, bool) {
+	return e, true
+}
+
+// javaName is a simple or qualified name, referencing a variable or a field
+type javaName struct {
+	name string
+}
+
+func (e javaName) constant(scope *javaScope) (javaLiteral, bool) {
+	return scope.resolve(e.name)
+}
+
+// javaAddition is a chain of additions, that concatenates strings
+type javaAddition struct {
+	operands []javaExpression
+}
+
+func (e javaAddition) constant(scope *javaScope) (javaLiteral, bool) {
+	result, ok := e.operands[0].constant(scope)
+	if !ok {
+		return result, false
+	}
+	// evaluated from left to right, like in Java, so that 1 + 2 + "a" is "3a"
+	for _, operand := range e.operands[1:] {
+		value, ok := operand.constant(scope)
+		if !ok {
+			return value, false
+		}
+		if result.numeric && value.numeric {
+			left, _ := strconv.ParseInt(result.value, 10, 64)
+			right, _ := strconv.ParseInt(value.value, 10, 64)
+			result = javaLiteral{value: strconv.FormatInt(left+right, 10), numeric: true}
+		} else {
+			result = javaLiteral{value: result.value + value.value}
+		}
+	}
+	return result, true
+}
+
+// constants returns the values of the given expressions when they all can be computed at compile time
+func constants(expressions []javaExpression, scope *javaScope) ([]javaLiteral, bool) {
+	values := make([]javaLiteral, 0, len(expressions))
+	for _, expression := range expressions {
+		value, ok := expression.constant(scope)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, value)
+	}
+	return values, true
+}
+
+// javaUnknown is any expression that is not supported, like method calls, that cannot be computed
+type javaUnknown struct {
+}
+
+func (e javaUnknown) constant(*javaScope) (javaLiteral, bool) {
+	return javaLiteral{}, false
+}
+
+// javaScope contains the string variables and fields declared in a source file
+type javaScope struct {
+	classes   map[string]bool
+	variables map[string]javaExpression
+	resolving map[string]bool
+}
+
+// resolve returns the value of the given variable or field, qualified by this or by the name of one of the classes
+// of the source file, when it is initialized with a constant and never reassigned
+func (s *javaScope) resolve(name string) (javaLiteral, bool) {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		qualifier := name[:i]
+		if qualifier != "this" && !s.classes[qualifier] {
+			return javaLiteral{}, false
+		}
+		name = name[i+1:]
+	}
+
+	expression, ok := s.variables[name]
+	if !ok || s.resolving[name] {
+		return javaLiteral{}, false
+	}
+
+	s.resolving[name] = true
+	defer delete(s.resolving, name)
+
+	return expression.constant(s)
+}
+
+// javaParser builds the abstract syntax tree of the expressions of a Java source file
+type javaParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *javaParser) peek(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return token{kind: tokenSymbol}
+	}
+	return p.tokens[p.pos+offset]
+}
+
+// parseExpression parses the expression at the current position, only additions being supported as operators
+func (p *javaParser) parseExpression() javaExpression {
+	operands := []javaExpression{p.parsePrimary()}
+	for p.peek(0).isSymbol("+") && !p.peek(1).isSymbol("+") && !p.peek(1).isSymbol("=") {
+		p.pos++
+		operands = append(operands, p.parsePrimary())
+	}
+	if len(operands) == 1 {
+		return operands[0]
+	}
+	return javaAddition{operands: operands}
+}
+
+func (p *javaParser) parsePrimary() javaExpression {
+	t := p.peek(0)
+	switch {
+	case t.kind == tokenString:
+		p.pos++
+		return javaLiteral{value: t.value}
+	case t.isSymbol("("):
+		p.pos++
+		expression := p.parseExpression()
+		if !p.peek(0).isSymbol(")") {
+			return javaUnknown{}
+		}
+		p.pos++
+		return expression
+	case t.kind == tokenIdentifier && t.value[0] >= '0' && t.value[0] <= '9':
+		p.pos++
+		value, err := strconv.ParseInt(strings.TrimRight(strings.Replace(t.value, "_", "", -1), "lL"), 0, 64)
+		if err != nil {
+			return javaUnknown{}
+		}
+		return javaLiteral{value: strconv.FormatInt(value, 10), numeric: true}
+	case t.kind == tokenIdentifier && (t.value == "true" || t.value == "false"):
+		p.pos++
+		return javaLiteral{value: t.value}
+	case t.kind == tokenIdentifier:
+		p.pos++
+		name := t.value
+		for p.peek(0).isSymbol(".") && p.peek(1).kind == tokenIdentifier {
+			name += "." + p.peek(1).value
+			p.pos += 2
+		}
+		if p.peek(0).isSymbol("(") {
+			// the result of a method call is never a constant
+			if _, err := p.parseArguments(); err != nil {
+				p.pos = len(p.tokens)
+			}
+			return javaUnknown{}
+		}
+		return javaName{name: name}
+	}
+	return javaUnknown{}
+}
+
+// parseArguments parses the arguments of the method call whose opening parenthesis is at the current position, the
+// arguments that are not supported expressions being skipped
+func (p *javaParser) parseArguments() ([]javaExpression, error) {
+	p.pos++
+	arguments := make([]javaExpression, 0)
+	if p.peek(0).isSymbol(")") {
+		p.pos++
+		return arguments, nil
+	}
+
+	for {
+		argument := p.parseExpression()
+		if !p.peek(0).isSymbol(",") && !p.peek(0).isSymbol(")") {
+			argument = javaUnknown{}
+			if err := p.skipArgument(); err != nil {
+				return nil, err
+			}
+		}
+		arguments = append(arguments, argument)
+
+		if p.peek(0).isSymbol(")") {
+			p.pos++
+			return arguments, nil
+		}
+		p.pos++
+	}
+}
+
+// skipArgument moves to the comma or the closing parenthesis that ends the current argument, skipping any nested
+// parentheses, brackets and braces, like those of lambda expressions and anonymous classes
+func (p *javaParser) skipArgument() error {
+	closing := make([]string, 0)
+	for ; p.pos < len(p.tokens); p.pos++ {
+		t := p.tokens[p.pos]
+		if t.kind != tokenSymbol {
+			continue
+		}
+		switch t.value {
+		case "(":
+			closing = append(closing, ")")
+		case "[":
+			closing = append(closing, "]")
+		case "{":
+			closing = append(closing, "}")
+		case ")", "]", "}":
+			if len(closing) == 0 {
+				if t.value == ")" {
+					return nil
+				}
+				return errors.Errorf("unexpected %s", t.value)
+			}
+			if closing[len(closing)-1] != t.value {
+				return errors.Errorf("unexpected %s, expected %s", t.value, closing[len(closing)-1])
+			}
+			closing = closing[:len(closing)-1]
+		case ",":
+			if len(closing) == 0 {
+				return nil
+			}
+		}
+	}
+	return errors.New("unexpected end of source")
+}
+
+// parseScope collects the classes and the string variables and fields that are declared in the source file. The
+// variables that are declared many times, e.g. in different methods, or that are reassigned, are not resolved
+func (p *javaParser) parseScope() *javaScope {
+	scope := javaScope{
+		classes:   make(map[string]bool),
+		variables: make(map[string]javaExpression),
+		resolving: make(map[string]bool),
+	}
+	declarations := make(map[int]bool)
+
+	for i := 0; i+2 < len(p.tokens); i++ {
+		t := p.tokens[i]
+		if t.kind != tokenIdentifier || p.tokens[i+1].kind != tokenIdentifier {
+			continue
+		}
+		switch t.value {
+		case "class", "interface", "enum":
+			scope.classes[p.tokens[i+1].value] = true
+		case "String", "var":
+			for p.pos = i + 1; p.peek(0).kind == tokenIdentifier && p.peek(1).isSymbol("=") && !p.peek(2).isSymbol("="); {
+				name := p.peek(0).value
+				declarations[p.pos] = true
+				p.pos += 2
+
+				expression := p.parseExpression()
+				if _, ok := scope.variables[name]; ok {
+					expression = javaUnknown{}
+				}
+				scope.variables[name] = expression
+
+				if !p.peek(0).isSymbol(",") {
+					break
+				}
+				p.pos++
+			}
+		}
+	}
+
+	for i := 0; i+1 < len(p.tokens); i++ {
+		t := p.tokens[i]
+		if _, ok := scope.variables[t.value]; !ok || t.kind != tokenIdentifier || declarations[i] {
+			continue
+		}
+		if p.tokens[i+1].isSymbol("=") && (i+2 >= len(p.tokens) || !p.tokens[i+2].isSymbol("=")) ||
+			p.tokens[i+1].isSymbol("+") && i+2 < len(p.tokens) && p.tokens[i+2].isSymbol("=") {
+			scope.variables[t.value] = javaUnknown{}
+		}
+	}
+
+	return &scope
+}
+
+// parseJavaURIs returns the distinct URIs the routes of the given Java source code consume from and produce to.
+// They are computed from the abstract syntax tree of the arguments of the from, to, toD and toF methods, as well as
+// of the wireTap, enrich and pollEnrich EIPs, so that string concatenations and constants are resolved, as well as
+// the formats passed to toF when all their arguments are constants
+func parseJavaURIs(content string) (from []string, to []string, err error) {
+	p := javaParser{tokens: tokenize(content)}
+	scope := p.parseScope()
+	from = make([]string, 0)
+	to = make([]string, 0)
+
+	for i, t := range p.tokens {
+		if t.kind != tokenIdentifier || i+1 >= len(p.tokens) || !p.tokens[i+1].isSymbol("(") {
+			continue
+		}
+
+		var uris *[]string
+		// from and to accept many URIs while the other arguments of toD, toF and of the EIPs are not URIs
+		variadic := false
+		switch t.value {
+		case "from":
+			uris = &from
+			variadic = true
+		case "to", "toD", "toF", "wireTap", "enrich":
+			if i > 0 && p.tokens[i-1].isSymbol(".") {
+				uris = &to
+				variadic = t.value == "to"
+			}
+		case "pollEnrich":
+			// the endpoint polled by the EIP is consumed from
+			if i > 0 && p.tokens[i-1].isSymbol(".") {
+				uris = &from
+			}
+		}
+		if uris == nil {
+			continue
+		}
+
+		p.pos = i + 1
+		arguments, err := p.parseArguments()
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "unable to parse the arguments of %s", t.value)
+		}
+		if t.value == "toF" && len(arguments) > 1 {
+			if values, ok := constants(arguments, scope); ok {
+				if uri, ok := formatURI(values[0].value, values[1:]); ok {
+					arguments = []javaExpression{javaLiteral{value: uri}}
+				}
+			}
+		}
+		if !variadic && len(arguments) > 1 {
+			arguments = arguments[:1]
+		}
+
+		for _, argument := range arguments {
+			value, ok := argument.constant(scope)
+			if ok && uriPattern.MatchString(value.value) && !util.StringSliceExists(*uris, value.value) {
+				*uris = append(*uris, value.value)
+			}
+		}
+	}
+
+	return from, to, nil
+}
@@ -0,0 +1,236 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJavaURIs(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+		from   []string
+		to     []string
+	}{
+		{
+			name:   "literals",
+			source: `from("timer:tick").to("log:info").toD("log:${header.x}").toF("seda:%s", "queue");`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info", "log:${header.x}", "seda:queue"},
+		},
+		{
+			name:   "eips",
+			source: `from("timer:tick").wireTap("jms:queue:" + QUEUE).enrich("http4:host", new Strategy()).pollEnrich("ftp:host", 1000);`,
+			from:   []string{"timer:tick", "ftp:host"},
+			to:     []string{"http4:host"},
+		},
+		{
+			name:   "commented routes",
+			source: "// from(\"timer:a\").to(\"log:a\");\n/* from(\"timer:b\")\n.to(\"log:b\"); */\nfrom(\"timer:tick\");",
+			from:   []string{"timer:tick"},
+			to:     []string{},
+		},
+		{
+			name:   "concatenation",
+			source: `from("timer:" + "tick" + "?period=" + 1000L).to(("log:" + "info"));`,
+			from:   []string{"timer:tick?period=1000"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "numeric addition",
+			source: `from(1 + 2 + "-timer:tick").to("log:" + 1 + 2);`,
+			from:   []string{"3-timer:tick"},
+			to:     []string{"log:12"},
+		},
+		{
+			name:   "number literals",
+			source: `from("timer:tick?period=" + 1_000 + "&delay=" + 0x10 + "&repeat=" + true);`,
+			from:   []string{"timer:tick?period=1000&delay=16&repeat=true"},
+			to:     []string{},
+		},
+		{
+			name: "constants",
+			source: `
+				public class Routes extends RouteBuilder {
+					private static final String SCHEME = "timer";
+					private static final String URI = SCHEME + ":tick";
+					public static final String QUEUE = "seda:queue", LOG = "log:info";
+
+					public void configure() {
+						from(URI).to(Routes.QUEUE).to(this.LOG);
+					}
+				}`,
+			from: []string{"timer:tick"},
+			to:   []string{"seda:queue", "log:info"},
+		},
+		{
+			name: "local variables",
+			source: `
+				public void configure() {
+					final String period = "1000";
+					var log = "log:info";
+					from("timer:tick?period=" + period).to(log);
+				}`,
+			from: []string{"timer:tick?period=1000"},
+			to:   []string{"log:info"},
+		},
+		{
+			name: "reassigned variables",
+			source: `
+				public void configure() {
+					String uri = "timer:a";
+					String log = "log:a";
+					uri = "timer:b";
+					log += "?level=WARN";
+					from(uri).to(log).to("log:b");
+				}`,
+			from: []string{},
+			to:   []string{"log:b"},
+		},
+		{
+			name: "variables declared many times",
+			source: `
+				public void a() { String uri = "timer:a"; from(uri); }
+				public void b() { String uri = "timer:b"; from(uri); }`,
+			from: []string{},
+			to:   []string{},
+		},
+		{
+			name: "recursive constants",
+			source: `
+				static final String A = B + "a";
+				static final String B = A + "b";
+				void configure() { from(A).to("log:" + B); }`,
+			from: []string{},
+			to:   []string{},
+		},
+		{
+			name: "foreign constants",
+			source: `
+				import static org.acme.Constants.URI;
+				void configure() { from(URI).to(Other.LOG).to(Constants.QUEUE); }`,
+			from: []string{},
+			to:   []string{},
+		},
+		{
+			name:   "method calls",
+			source: `from(uri()).to(String.format("log:%s", "info")).to("log:info".trim()).to(uris.get(0) + "x");`,
+			from:   []string{},
+			to:     []string{},
+		},
+		{
+			name:   "variadic",
+			source: `from("timer:a", "timer:b").multicast().to("log:a", "log:b").toD("log:c", 10).toF("log:%s", "d");`,
+			from:   []string{"timer:a", "timer:b"},
+			to:     []string{"log:a", "log:b", "log:c", "log:d"},
+		},
+		{
+			name:   "formats",
+			source: `String QUEUE = "queue"; from("timer:tick").toF("seda:%s-%d", QUEUE, 1 + 1).toF("log:%s", level()).toF("log:%d", "a");`,
+			from:   []string{"timer:tick"},
+			to:     []string{"seda:queue-2", "log:%s", "log:%d"},
+		},
+		{
+			name:   "cast",
+			source: `from((String) "timer:tick").to((String) uri);`,
+			from:   []string{},
+			to:     []string{},
+		},
+		{
+			name: "lambdas and anonymous classes",
+			source: `
+				from("timer:tick")
+					.process(e -> { e.getIn().setBody(List.of(1, 2)[0]); })
+					.process(new Processor() {
+						public void process(Exchange e) { e.getIn().setBody("from"); }
+					})
+					.to("log:info");`,
+			from: []string{"timer:tick"},
+			to:   []string{"log:info"},
+		},
+		{
+			name: "nested routes",
+			source: `
+				context.addRoutes(new RouteBuilder() {
+					public void configure() {
+						from("direct:nested").to("log:nested");
+					}
+				});
+				from("timer:tick").choice().when(header("x").isEqualTo("y")).to("direct:nested").end();`,
+			from: []string{"direct:nested", "timer:tick"},
+			to:   []string{"log:nested", "direct:nested"},
+		},
+		{
+			name:   "not an uri",
+			source: `from("Timer tick").to(1000);`,
+			from:   []string{},
+			to:     []string{},
+		},
+		{
+			name:   "other identifiers",
+			source: `myfrom("timer:a").tony("log:a"); to("log:b"); String from = "timer:c";`,
+			from:   []string{},
+			to:     []string{},
+		},
+		{
+			name:   "distinct",
+			source: `String uri = "timer:tick"; from(uri).to("log:info"); from("timer:tick").to("log:" + "info");`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to, err := parseJavaURIs(tc.source)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.from, from)
+			assert.Equal(t, tc.to, to)
+		})
+	}
+}
+
+func TestParseJavaURIsError(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+	}{
+		{
+			name:   "unterminated call",
+			source: `from("timer:tick").to("log:info"`,
+		},
+		{
+			name:   "unbalanced brackets",
+			source: `from("timer:tick").to(uris.get(key]).to("log:info");`,
+		},
+		{
+			name:   "unexpected closing brace",
+			source: `from("timer:tick").to(a } b);`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := parseJavaURIs(tc.source)
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -0,0 +1,327 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/apache/camel-k/pkg/util"
+)
+
+// uriPattern matches the string literals that look like endpoint URIs, i.e. starting with a scheme or with a property
+// placeholder, that is resolved when the configuration of the integration is known
+var uriPattern = regexp.MustCompile(`^([a-z0-9-]+:|\{\{)`)
+
+type tokenKind int
+
+const (
+	tokenIdentifier tokenKind = iota
+	tokenString
+	tokenSymbol
+)
+
+// token is a lexical element of the source code of a JVM language, the value of string literals being unquoted
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func (t token) isSymbol(symbol string) bool {
+	return t.kind == tokenSymbol && t.value == symbol
+}
+
+// tokenize splits source code written in Java, Groovy, Kotlin or JavaScript into identifiers, string literals and
+// symbols. Comments are skipped, as well as white spaces. Single, double and triple quoted strings are supported,
+// as well as back quoted template literals, whose interpolated expressions are kept verbatim
+func tokenize(content string) []token {
+	tokens := make([]token, 0)
+
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(content[i:], "//"):
+			if end := strings.IndexByte(content[i:], '\n'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(content)
+			}
+		case strings.HasPrefix(content[i:], "/*"):
+			if end := strings.Index(content[i+2:], "*/"); end >= 0 {
+				i += end + 4
+			} else {
+				i = len(content)
+			}
+		case strings.HasPrefix(content[i:], `"""`) || strings.HasPrefix(content[i:], `'''`):
+			delimiter := content[i : i+3]
+			if end := strings.Index(content[i+3:], delimiter); end >= 0 {
+				tokens = append(tokens, token{kind: tokenString, value: content[i+3 : i+3+end]})
+				i += end + 6
+			} else {
+				tokens = append(tokens, token{kind: tokenString, value: content[i+3:]})
+				i = len(content)
+			}
+		case c == '"' || c == '\'' || c == '`':
+			var value string
+			value, i = readString(content, i+1, c)
+			tokens = append(tokens, token{kind: tokenString, value: value})
+		case isIdentifierPart(c):
+			start := i
+			for i < len(content) && isIdentifierPart(content[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdentifier, value: content[start:i]})
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, value: string(c)})
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// readString reads the string literal starting at the given position, up to the closing quote, returning
+// the unescaped literal and the position following it. Unterminated literals end with the line, except
+// for back quoted template literals that may span many lines
+func readString(content string, start int, quote byte) (string, int) {
+	var sb strings.Builder
+	for i := start; i < len(content); i++ {
+		c := content[i]
+		switch {
+		case c == '\\' && i+1 < len(content):
+			i++
+			sb.WriteByte(unescape(content[i]))
+		case c == quote:
+			return sb.String(), i + 1
+		case c == '\n' && quote != '`':
+			return sb.String(), i + 1
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String(), len(content)
+}
+
+func unescape(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	default:
+		return c
+	}
+}
+
+// isIdentifierPart returns true for the bytes that can be part of an identifier or a number literal, any
+// non ASCII byte being considered as part of a unicode letter
+func isIdentifierPart(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '$' || c >= 0x80
+}
+
+// findURIs returns the distinct URIs the routes of the given source code consume from and produce to, that is
+// the string literals, or constant concatenations of string literals, passed to the from function or method, to
+// the to, toD and toF methods and to the wireTap, enrich and pollEnrich EIPs. The format passed to toF is resolved
+// when all its arguments are literals
+func findURIs(content string) (from []string, to []string) {
+	tokens := tokenize(content)
+	from = make([]string, 0)
+	to = make([]string, 0)
+
+	for i, t := range tokens {
+		if t.kind != tokenIdentifier || i+2 >= len(tokens) || !tokens[i+1].isSymbol("(") {
+			continue
+		}
+
+		var uris *[]string
+		switch t.value {
+		case "from":
+			uris = &from
+		case "to", "toD", "toF", "wireTap", "enrich":
+			if i > 0 && tokens[i-1].isSymbol(".") {
+				uris = &to
+			}
+		case "pollEnrich":
+			// the endpoint polled by the EIP is consumed from
+			if i > 0 && tokens[i-1].isSymbol(".") {
+				uris = &from
+			}
+		}
+		if uris == nil {
+			continue
+		}
+
+		if uri := endpointURI(t.value, tokens[i+2:]); uriPattern.MatchString(uri) && !util.StringSliceExists(*uris, uri) {
+			*uris = append(*uris, uri)
+		}
+	}
+
+	return from, to
+}
+
+// findRoutes returns the routes of the given source code, i.e. the from and rest definitions along with the URIs
+// they produce to, and their ids when set with routeId
+func findRoutes(content string) []Route {
+	tokens := tokenize(content)
+	routes := make([]Route, 0)
+
+	for i, t := range tokens {
+		if t.kind != tokenIdentifier || i+1 >= len(tokens) || !tokens[i+1].isSymbol("(") {
+			continue
+		}
+
+		chained := i > 0 && tokens[i-1].isSymbol(".")
+		value := stringExpression(tokens[i+2:])
+
+		switch {
+		case t.value == "from" && !chained:
+			route := Route{To: make([]string, 0)}
+			if uriPattern.MatchString(value) {
+				route.From = value
+			}
+			routes = append(routes, route)
+		case t.value == "rest" && !chained:
+			route := Route{To: make([]string, 0), RestPath: value}
+			if route.RestPath == "" {
+				route.RestPath = "/"
+			}
+			routes = append(routes, route)
+		case len(routes) == 0 || !chained:
+			continue
+		case t.value == "routeId":
+			if routes[len(routes)-1].ID == "" {
+				routes[len(routes)-1].ID = value
+			}
+		case t.value == "to" || t.value == "toD" || t.value == "toF" || t.value == "wireTap" || t.value == "enrich":
+			if value = endpointURI(t.value, tokens[i+2:]); uriPattern.MatchString(value) && !util.StringSliceExists(routes[len(routes)-1].To, value) {
+				routes[len(routes)-1].To = append(routes[len(routes)-1].To, value)
+			}
+		}
+	}
+
+	return routes
+}
+
+// stringExpression returns the value of the concatenation of string literals the given tokens start with, the
+// concatenation stopping at the first operand that is not a string literal
+func stringExpression(tokens []token) string {
+	var sb strings.Builder
+	for i := 0; i < len(tokens) && tokens[i].kind == tokenString; i += 2 {
+		sb.WriteString(tokens[i].value)
+		if i+1 >= len(tokens) || !tokens[i+1].isSymbol("+") {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// endpointURI returns the URI passed to the given method, whose arguments start with the given tokens. The format
+// passed to toF is resolved when all its arguments are literals, and returned as is otherwise
+func endpointURI(method string, tokens []token) string {
+	if method == "toF" {
+		if arguments, ok := literalArguments(tokens); ok && len(arguments) > 1 {
+			if uri, ok := formatURI(arguments[0].value, arguments[1:]); ok {
+				return uri
+			}
+		}
+	}
+	return stringExpression(tokens)
+}
+
+// literalArguments returns the values of the arguments of a call, whose arguments start with the given tokens, when
+// they all are string literals, concatenations of string literals or integer literals
+func literalArguments(tokens []token) ([]javaLiteral, bool) {
+	arguments := make([]javaLiteral, 0)
+	for i := 0; i < len(tokens); i++ {
+		switch t := tokens[i]; {
+		case t.kind == tokenString:
+			arguments = append(arguments, javaLiteral{value: stringExpression(tokens[i:])})
+			for i+2 < len(tokens) && tokens[i+1].isSymbol("+") && tokens[i+2].kind == tokenString {
+				i += 2
+			}
+		case t.kind == tokenIdentifier && isInteger(t.value):
+			arguments = append(arguments, javaLiteral{value: t.value, numeric: true})
+		default:
+			return nil, false
+		}
+
+		i++
+		if i < len(tokens) && tokens[i].isSymbol(")") {
+			return arguments, true
+		}
+		if i >= len(tokens) || !tokens[i].isSymbol(",") {
+			return nil, false
+		}
+	}
+	return nil, false
+}
+
+// formatURI applies the given arguments to a format, like String.format does. Only the %s, %d and %% conversions
+// are supported, and false is returned for the other ones, or when arguments are missing
+func formatURI(format string, arguments []javaLiteral) (string, bool) {
+	var sb strings.Builder
+	next := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			sb.WriteByte(format[i])
+			continue
+		}
+		if i+1 >= len(format) {
+			return "", false
+		}
+		i++
+		switch format[i] {
+		case '%':
+			sb.WriteByte('%')
+		case 's', 'd':
+			if next >= len(arguments) || format[i] == 'd' && !arguments[next].numeric {
+				return "", false
+			}
+			sb.WriteString(arguments[next].value)
+			next++
+		default:
+			return "", false
+		}
+	}
+	return sb.String(), true
+}
+
+func isInteger(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// ContainsCall returns true if the given source code calls the function or method with the given name, the calls
+// in comments and string literals being ignored
+func ContainsCall(content string, name string) bool {
+	tokens := tokenize(content)
+	for i := 0; i+1 < len(tokens); i++ {
+		if tokens[i].kind == tokenIdentifier && tokens[i].value == name && tokens[i+1].isSymbol("(") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,417 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindURIs(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+		from   []string
+		to     []string
+	}{
+		{
+			name:   "double quoted",
+			source: `from("timer:tick").to("log:info");`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "single quoted",
+			source: `from('timer:tick').to('log:info')`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "back quoted",
+			source: "from(`timer:tick`).to(`log:info`)",
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "triple double quoted",
+			source: `from("""timer:tick""").to("""log:info""")`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "triple single quoted",
+			source: `from('''timer:tick''').to('''log:info''')`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "triple quoted with quotes",
+			source: `from("""timer:tick?name="x"&a=1""").to("""log:'info'""")`,
+			from:   []string{`timer:tick?name="x"&a=1`},
+			to:     []string{"log:'info'"},
+		},
+		{
+			name:   "white spaces",
+			source: "from (\n\t\"timer:tick\" )\n  .to  (  \"log:info\"\n)",
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "to variants",
+			source: `from("timer:tick").to("log:a").toD("log:b").toF("log:%s", "c")`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:a", "log:b", "log:c"},
+		},
+		{
+			name:   "formats",
+			source: `from("timer:tick").toF("seda:%s-%d", "q" + "ueue", 2).toF("log:%s", level).toF("log:%x", 1).toF("log:%s%%")`,
+			from:   []string{"timer:tick"},
+			to:     []string{"seda:queue-2", "log:%s", "log:%x", "log:%s%%"},
+		},
+		{
+			name:   "eips",
+			source: `from("timer:tick").wireTap("seda:tap").enrich("http4:host", strategy).pollEnrich("file:in", 1000)`,
+			from:   []string{"timer:tick", "file:in"},
+			to:     []string{"seda:tap", "http4:host"},
+		},
+		{
+			name:   "line comment",
+			source: "// from(\"timer:commented\").to(\"log:commented\")\nfrom(\"timer:tick\").to(\"log:info\")",
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "trailing line comment",
+			source: `from("timer:tick") // .to("log:commented")`,
+			from:   []string{"timer:tick"},
+			to:     []string{},
+		},
+		{
+			name:   "block comment",
+			source: "/*\n from(\"timer:commented\")\n  .to(\"log:commented\")\n*/\nfrom(\"timer:tick\")",
+			from:   []string{"timer:tick"},
+			to:     []string{},
+		},
+		{
+			name:   "inline block comment",
+			source: `from(/* "timer:commented" */ "timer:tick")./* skip */to("log:info")`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "javadoc comment",
+			source: "/**\n * Consumes from(\"jms:queue\") and sends .to(\"log:x\")\n */\nfrom(\"timer:tick\")",
+			from:   []string{"timer:tick"},
+			to:     []string{},
+		},
+		{
+			name:   "unterminated block comment",
+			source: "from(\"timer:tick\")\n/* .to(\"log:info\")",
+			from:   []string{"timer:tick"},
+			to:     []string{},
+		},
+		{
+			name:   "comment markers in strings",
+			source: `from("netty-http:http://0.0.0.0:8080/path").to("http://host/*").to("log:/*info*/")`,
+			from:   []string{"netty-http:http://0.0.0.0:8080/path"},
+			to:     []string{"http://host/*", "log:/*info*/"},
+		},
+		{
+			name:   "escaped double quote",
+			source: `from("timer:tick?message=\"hello\"").to("log:info")`,
+			from:   []string{`timer:tick?message="hello"`},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "escaped single quote",
+			source: `from('timer:tick?message=\'hello\'').to('log:info')`,
+			from:   []string{"timer:tick?message='hello'"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "escaped backslash",
+			source: `from("file:C:\\data").to("log:info")`,
+			from:   []string{`file:C:\data`},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "quotes in other quotes",
+			source: `from("timer:tick?message='hello'").to('log:info?marker="x"')`,
+			from:   []string{"timer:tick?message='hello'"},
+			to:     []string{`log:info?marker="x"`},
+		},
+		{
+			name:   "concatenation",
+			source: `from("twitter-search:{{twitterKeywords}}" + "?delay={{twitterDelayMs}}")`,
+			from:   []string{"twitter-search:{{twitterKeywords}}?delay={{twitterDelayMs}}"},
+			to:     []string{},
+		},
+		{
+			name:   "multi line concatenation",
+			source: "from(\"timer:tick\"\n    + \"?period=1000\"\n    + '&delay=10')\n  .to('log:' + \"info\")",
+			from:   []string{"timer:tick?period=1000&delay=10"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "concatenation with a variable",
+			source: `from("timer:tick?period=" + period + "&delay=10").to("log:info")`,
+			from:   []string{"timer:tick?period="},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "concatenation with a comment",
+			source: `from("timer:" /* name */ + "tick")`,
+			from:   []string{"timer:tick"},
+			to:     []string{},
+		},
+		{
+			name:   "property placeholders",
+			source: `from("{{input.endpoint}}").to("{{output.scheme}}:{{output.name}}").to("{{not closed")`,
+			from:   []string{"{{input.endpoint}}"},
+			to:     []string{"{{output.scheme}}:{{output.name}}", "{{not closed"},
+		},
+		{
+			name:   "variable",
+			source: `from(uri).to(target)`,
+			from:   []string{},
+			to:     []string{},
+		},
+		{
+			name:   "not an uri",
+			source: `from("Timer tick").to("some log")`,
+			from:   []string{},
+			to:     []string{},
+		},
+		{
+			name:   "other identifiers",
+			source: `myfrom("timer:a").tony("log:a").toDo("log:b"); fromTimer("timer:b")`,
+			from:   []string{},
+			to:     []string{},
+		},
+		{
+			name:   "to without dot",
+			source: `to("log:a"); from("timer:tick")`,
+			from:   []string{"timer:tick"},
+			to:     []string{},
+		},
+		{
+			name:   "method reference from",
+			source: `rest().get("/hello").route().from("direct:hello")`,
+			from:   []string{"direct:hello"},
+			to:     []string{},
+		},
+		{
+			name:   "distinct",
+			source: `from("timer:tick").to("log:info"); from("timer:tick").to("log:info")`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "order",
+			source: `from("timer:b").to("log:b"); from("timer:a").to("log:a")`,
+			from:   []string{"timer:b", "timer:a"},
+			to:     []string{"log:b", "log:a"},
+		},
+		{
+			name:   "nested expressions",
+			source: `from("timer:tick").choice().when(header("x").isEqualTo("y")).to("log:x").otherwise().to("log:y")`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:x", "log:y"},
+		},
+		{
+			name:   "kotlin interpolation",
+			source: `from("timer:tick").to("log:${name}")`,
+			from:   []string{"timer:tick"},
+			to:     []string{"log:${name}"},
+		},
+		{
+			name:   "unicode identifiers",
+			source: `val übung = from("timer:tick"); dëfrom("timer:other")`,
+			from:   []string{"timer:tick"},
+			to:     []string{},
+		},
+		{
+			name:   "unterminated string",
+			source: "from(\"timer:tick\n.to(\"log:info\")",
+			from:   []string{"timer:tick"},
+			to:     []string{"log:info"},
+		},
+		{
+			name:   "unterminated call",
+			source: `from(`,
+			from:   []string{},
+			to:     []string{},
+		},
+		{
+			name:   "empty",
+			source: ``,
+			from:   []string{},
+			to:     []string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to := findURIs(tc.source)
+			assert.Equal(t, tc.from, from)
+			assert.Equal(t, tc.to, to)
+		})
+	}
+}
+
+func TestFindURIsInRoutes(t *testing.T) {
+	testCases := []struct {
+		name   string
+		source string
+		from   []string
+		to     []string
+	}{
+		{
+			name: "java",
+			source: `
+				import org.apache.camel.builder.RouteBuilder;
+
+				/**
+				 * Sends the ticks to("log:javadoc")
+				 */
+				public class Sample extends RouteBuilder {
+					private static final String QUEUE = "jms:queue";
+
+					@Override
+					public void configure() throws Exception {
+						// from("timer:disabled").to("log:disabled");
+						from("timer:tick?period=" + 1000)
+							.setBody().constant("Hello \"World\" // not a comment")
+							.to("log:info")
+							.toD(QUEUE)
+							.toF("seda:%s", "queue");
+					}
+				}
+			`,
+			from: []string{"timer:tick?period="},
+			to:   []string{"log:info", "seda:queue"},
+		},
+		{
+			name: "groovy",
+			source: `
+				/*
+				from('timer:disabled')
+				    .to('log:disabled')
+				*/
+				from('timer:tick')
+				    .setBody().constant('''
+				        from("timer:body")
+				    ''')
+				    .to("""log:info""")
+				    .to('''seda:queue''')
+			`,
+			from: []string{"timer:tick"},
+			to:   []string{"log:info", "seda:queue"},
+		},
+		{
+			name: "kotlin",
+			source: `
+				val uri = "log:variable"
+
+				from("timer:tick")
+				    .process { e -> e.getIn().body = """ from("timer:body") """ }
+				    .to("log:info")  // .to("log:commented")
+				    .to(uri)
+			`,
+			from: []string{"timer:tick"},
+			to:   []string{"log:info"},
+		},
+		{
+			name: "javascript",
+			source: `
+				const body = 'from("timer:body")';
+
+				from('timer:tick')
+				    .setBody().constant(` + "`from('timer:template')`" + `)
+				    .to('log:info') /* .to('log:commented') */
+				    .to("seda:" + 'queue');
+			`,
+			from: []string{"timer:tick"},
+			to:   []string{"log:info", "seda:queue"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			from, to := findURIs(tc.source)
+			assert.Equal(t, tc.from, from)
+			assert.Equal(t, tc.to, to)
+		})
+	}
+}
+
+func TestFindRoutes(t *testing.T) {
+	source := `
+		public class Routes extends RouteBuilder {
+		    public void configure() {
+		        from("timer:tick").routeId("ticks")
+		            .to("log:info")
+		            .wireTap("seda:audit")
+		            .to("log:info");
+
+		        rest("/api")
+		            .get("/hello").to("direct:hello");
+
+		        rest()
+		            .post().to("direct:post");
+
+		        from("direct:hello")
+		            .routeId("hello").id("ignored")
+		            .setBody().constant("from('timer:body')")
+		            .toD("http4:{{host}}/hello");
+		    }
+		}
+	`
+
+	assert.Equal(t, []Route{
+		{ID: "ticks", From: "timer:tick", To: []string{"log:info", "seda:audit"}},
+		{To: []string{"direct:hello"}, RestPath: "/api"},
+		{To: []string{"direct:post"}, RestPath: "/"},
+		{ID: "hello", From: "direct:hello", To: []string{"http4:{{host}}/hello"}},
+	}, findRoutes(source))
+
+	assert.Empty(t, findRoutes(`to("log:info")`))
+}
+
+func TestContainsCall(t *testing.T) {
+	testCases := []struct {
+		source   string
+		expected bool
+	}{
+		{source: `rest("/api").get().to("direct:api")`, expected: true},
+		{source: `rest().get("/api").to("direct:api")`, expected: true},
+		{source: "from('timer:tick')\n  .rest ( '/api' )", expected: true},
+		{source: `// rest("/api")`, expected: false},
+		{source: `/* rest("/api") */`, expected: false},
+		{source: `from("timer:tick").setBody().constant("rest(")`, expected: false},
+		{source: `restConfiguration().port(8080); interest(); forest()`, expected: false},
+		{source: `def rest = 1`, expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.source, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ContainsCall(tc.source, "rest"))
+		})
+	}
+}
@@ -25,4 +25,19 @@ type Metadata struct {
 	ToURIs []string
 	// All inferred dependencies required to run the integration
 	Dependencies []string
+	// All routes, in the order they are defined
+	Routes []Route
+}
+
+// Route --
+type Route struct {
+	// The id of the route, set with routeId in the Java DSL or with the id of the XML and YAML route
+	// definitions, empty if not set
+	ID string
+	// The URI the route consumes from, empty for the REST DSL definitions
+	From string
+	// The URIs the route produces to
+	To []string
+	// The base path of the REST DSL definition, empty for the routes consuming from an endpoint
+	RestPath string
 }
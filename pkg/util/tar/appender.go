@@ -22,6 +22,7 @@ import (
 	"io"
 	"os"
 	"path"
+	"path/filepath"
 
 	"github.com/pkg/errors"
 )
@@ -128,6 +129,55 @@ func (t *Appender) AddFileWithName(fileName string, filePath string, tarDir stri
 	return fileName, nil
 }
 
+// AddDirectory adds the content of a directory to the tarDir, preserving the file modes and the symbolic links
+func (t *Appender) AddDirectory(dir string, tarDir string) error {
+	return filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			// directories are created when extracting the files
+			return nil
+		}
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			if link, err = os.Readlink(filePath); err != nil {
+				return err
+			}
+		}
+
+		header, err := atar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(tarDir, filepath.ToSlash(rel))
+
+		if err := t.writer.WriteHeader(header); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if _, err = io.Copy(t.writer, file); err != nil {
+			return errors.Wrap(err, "cannot add file to the tar archive")
+		}
+		return nil
+	})
+}
+
 // AddData appends the given content to a file inside the tar, creating it if it does not exist
 func (t *Appender) AddData(data []byte, tarPath string) error {
 	err := t.writer.WriteHeader(&atar.Header{
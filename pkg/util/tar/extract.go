@@ -47,6 +47,12 @@ func Extract(source string, destinationBase string) error {
 		if err := os.MkdirAll(targetDir, 0777); err != nil {
 			return err
 		}
+		if header.Typeflag == tarutils.TypeSymlink {
+			if err := os.Symlink(header.Linkname, targetName); err != nil {
+				return err
+			}
+			continue
+		}
 		buffer, err := ioutil.ReadAll(reader)
 		if err != nil {
 			return err
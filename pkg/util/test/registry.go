@@ -0,0 +1,223 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// FakeRegistry is an in-memory Docker registry serving the manifests and blobs pushed to it. It requires
+// a bearer token, obtained from its /token endpoint, when the token is set
+type FakeRegistry struct {
+	*httptest.Server
+	Token string
+
+	lock      sync.Mutex
+	manifests map[string]fakeManifest
+	blobs     map[string][]byte
+	uploads   int
+	// Uploaded holds the digests of the blobs uploaded to the registry, in the repo@digest format
+	Uploaded []string
+	// Mounted holds the digests of the blobs mounted from other repositories, in the repo@digest format
+	Mounted []string
+}
+
+type fakeManifest struct {
+	mediaType string
+	content   []byte
+}
+
+// NewFakeRegistry starts a fake registry, to be closed by the caller
+func NewFakeRegistry() *FakeRegistry {
+	r := &FakeRegistry{
+		manifests: make(map[string]fakeManifest),
+		blobs:     make(map[string][]byte),
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serve))
+	return r
+}
+
+// Address returns the host:port address of the registry
+func (r *FakeRegistry) Address() string {
+	return strings.TrimPrefix(r.Server.URL, "http://")
+}
+
+// AddBlob stores the given blob in the repository, returning its digest
+func (r *FakeRegistry) AddBlob(repository string, content []byte) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	digest := fakeDigest(content)
+	r.blobs[repository+"@"+digest] = content
+	return digest
+}
+
+// Blob returns the blob with the given digest stored in the repository, if any
+func (r *FakeRegistry) Blob(repository string, digest string) ([]byte, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	content, ok := r.blobs[repository+"@"+digest]
+	return content, ok
+}
+
+// AddManifest stores the given manifest in the repository under the given tag, returning its digest
+func (r *FakeRegistry) AddManifest(repository string, tag string, mediaType string, content []byte) string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	digest := fakeDigest(content)
+	r.manifests[repository+":"+tag] = fakeManifest{mediaType: mediaType, content: content}
+	r.manifests[repository+"@"+digest] = fakeManifest{mediaType: mediaType, content: content}
+	return digest
+}
+
+// Manifest returns the manifest stored in the repository under the given tag or digest, if any
+func (r *FakeRegistry) Manifest(repository string, reference string) ([]byte, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	m, ok := r.manifests[repository+r.separator(reference)+reference]
+	return m.content, ok
+}
+
+func (r *FakeRegistry) separator(reference string) string {
+	if strings.Contains(reference, ":") {
+		return "@"
+	}
+	return ":"
+}
+
+func (r *FakeRegistry) serve(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		fmt.Fprintf(w, `{"token": %q}`, r.Token)
+		return
+	}
+	if !strings.HasPrefix(req.URL.Path, "/v2/") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if r.Token != "" && req.Header.Get("Authorization") != "Bearer "+r.Token {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake"`, r.Server.URL))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.Contains(path, "/manifests/"):
+		parts := strings.SplitN(path, "/manifests/", 2)
+		r.serveManifest(w, req, parts[0], parts[1])
+	case strings.Contains(path, "/blobs/uploads/"):
+		parts := strings.SplitN(path, "/blobs/uploads/", 2)
+		r.serveUpload(w, req, parts[0], parts[1])
+	case strings.Contains(path, "/blobs/"):
+		parts := strings.SplitN(path, "/blobs/", 2)
+		r.serveBlob(w, req, parts[0], parts[1])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *FakeRegistry) serveManifest(w http.ResponseWriter, req *http.Request, repository string, reference string) {
+	key := repository + r.separator(reference) + reference
+	switch req.Method {
+	case http.MethodGet:
+		m, ok := r.manifests[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", m.mediaType)
+		_, _ = w.Write(m.content)
+	case http.MethodPut:
+		content, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		digest := fakeDigest(content)
+		m := fakeManifest{mediaType: req.Header.Get("Content-Type"), content: content}
+		r.manifests[key] = m
+		r.manifests[repository+"@"+digest] = m
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *FakeRegistry) serveBlob(w http.ResponseWriter, req *http.Request, repository string, digest string) {
+	content, ok := r.blobs[repository+"@"+digest]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	if req.Method == http.MethodGet {
+		_, _ = w.Write(content)
+	}
+}
+
+func (r *FakeRegistry) serveUpload(w http.ResponseWriter, req *http.Request, repository string, session string) {
+	switch req.Method {
+	case http.MethodPost:
+		if digest, from := req.URL.Query().Get("mount"), req.URL.Query().Get("from"); digest != "" {
+			if content, ok := r.blobs[from+"@"+digest]; ok {
+				r.blobs[repository+"@"+digest] = content
+				r.Mounted = append(r.Mounted, repository+"@"+digest)
+				w.WriteHeader(http.StatusCreated)
+				return
+			}
+		}
+		r.uploads++
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%d", repository, r.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		content, err := ioutil.ReadAll(req.Body)
+		if err != nil || session == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		digest := req.URL.Query().Get("digest")
+		if digest != fakeDigest(content) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[repository+"@"+digest] = content
+		r.Uploaded = append(r.Uploaded, repository+"@"+digest)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func fakeDigest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
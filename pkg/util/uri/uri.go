@@ -0,0 +1,137 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uri
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var schemeRegexp = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*$`)
+
+// URI is a Camel endpoint URI, e.g. timer:tick?period=1s, split into its scheme, path and parameters
+type URI struct {
+	Scheme     string
+	Path       string
+	Parameters map[string]string
+}
+
+// Parse parses the given endpoint URI. The "scheme://path" and "scheme:path" forms are equivalent, and the
+// RAW(...) parameter values are kept as they are, e.g. so that passwords containing & or % are not altered
+func Parse(uri string) (URI, error) {
+	scheme := Scheme(uri)
+	if scheme == "" {
+		return URI{}, fmt.Errorf("invalid endpoint uri %s: missing scheme", uri)
+	}
+
+	result := URI{
+		Scheme:     scheme,
+		Parameters: make(map[string]string),
+	}
+
+	remaining := strings.TrimPrefix(uri[len(scheme)+1:], "//")
+	query := ""
+	if i := strings.Index(remaining, "?"); i >= 0 {
+		remaining, query = remaining[:i], remaining[i+1:]
+	}
+	result.Path = remaining
+
+	for _, param := range splitQuery(query) {
+		kv := strings.SplitN(param, "=", 2)
+		key, err := url.QueryUnescape(kv[0])
+		if err != nil {
+			return URI{}, fmt.Errorf("invalid endpoint uri %s: %v", uri, err)
+		}
+
+		value := ""
+		if len(kv) == 2 {
+			value = kv[1]
+			if !isRaw(value) {
+				if value, err = url.QueryUnescape(value); err != nil {
+					return URI{}, fmt.Errorf("invalid endpoint uri %s: %v", uri, err)
+				}
+			}
+		}
+
+		result.Parameters[key] = value
+	}
+
+	return result, nil
+}
+
+// Scheme returns the scheme of the given endpoint URI, or an empty string if the URI has none
+func Scheme(uri string) string {
+	i := strings.Index(uri, ":")
+	if i < 0 || !schemeRegexp.MatchString(uri[:i]) {
+		return ""
+	}
+
+	return uri[:i]
+}
+
+// Parameter returns the value of the given parameter, RAW values being unwrapped
+func (u URI) Parameter(name string) (string, bool) {
+	value, ok := u.Parameters[name]
+	if ok && isRaw(value) {
+		value = value[4 : len(value)-1]
+	}
+
+	return value, ok
+}
+
+// splitQuery splits the query on &, except within RAW(...) and RAW{...} values
+func splitQuery(query string) []string {
+	params := make([]string, 0)
+	if query == "" {
+		return params
+	}
+
+	start := 0
+	closing := byte(0)
+	for i := 0; i < len(query); i++ {
+		switch {
+		case closing != 0:
+			if query[i] == closing {
+				closing = 0
+			}
+		case strings.HasPrefix(query[i:], "=RAW(") || strings.HasPrefix(query[i:], "=RAW{"):
+			closing = ')'
+			if query[i+4] == '{' {
+				closing = '}'
+			}
+			i += 4
+		case query[i] == '&':
+			if i > start {
+				params = append(params, query[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(query) {
+		params = append(params, query[start:])
+	}
+
+	return params
+}
+
+func isRaw(value string) bool {
+	return len(value) >= 5 && (strings.HasPrefix(value, "RAW(") && strings.HasSuffix(value, ")") ||
+		strings.HasPrefix(value, "RAW{") && strings.HasSuffix(value, "}"))
+}
@@ -0,0 +1,82 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package uri
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	u, err := Parse("timer:tick")
+	assert.Nil(t, err)
+	assert.Equal(t, "timer", u.Scheme)
+	assert.Equal(t, "tick", u.Path)
+	assert.Empty(t, u.Parameters)
+
+	u, err = Parse("knative://channel/messages?apiVersion=messaging.knative.dev%2Fv1alpha1&kind=InMemoryChannel")
+	assert.Nil(t, err)
+	assert.Equal(t, "knative", u.Scheme)
+	assert.Equal(t, "channel/messages", u.Path)
+	assert.Equal(t, map[string]string{
+		"apiVersion": "messaging.knative.dev/v1alpha1",
+		"kind":       "InMemoryChannel",
+	}, u.Parameters)
+
+	u, err = Parse("file:/tmp/data?noop=true&&delay")
+	assert.Nil(t, err)
+	assert.Equal(t, "/tmp/data", u.Path)
+	assert.Equal(t, map[string]string{"noop": "true", "delay": ""}, u.Parameters)
+
+	_, err = Parse("log?level=info")
+	assert.NotNil(t, err)
+	_, err = Parse("{{uri}}")
+	assert.NotNil(t, err)
+	_, err = Parse("http4:host?q=%zz")
+	assert.NotNil(t, err)
+}
+
+func TestParseRaw(t *testing.T) {
+	u, err := Parse("ftp:host?username=admin&password=RAW(se+c&r%t=)&passiveMode=true&secret=RAW{a)b&c}")
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"username":    "admin",
+		"password":    "RAW(se+c&r%t=)",
+		"passiveMode": "true",
+		"secret":      "RAW{a)b&c}",
+	}, u.Parameters)
+
+	password, ok := u.Parameter("password")
+	assert.True(t, ok)
+	assert.Equal(t, "se+c&r%t=", password)
+	secret, _ := u.Parameter("secret")
+	assert.Equal(t, "a)b&c", secret)
+	mode, _ := u.Parameter("passiveMode")
+	assert.Equal(t, "true", mode)
+	_, ok = u.Parameter("binary")
+	assert.False(t, ok)
+}
+
+func TestScheme(t *testing.T) {
+	assert.Equal(t, "aws-s3", Scheme("aws-s3://bucket?region=eu-west-1"))
+	assert.Equal(t, "timer", Scheme("timer:tick"))
+	assert.Equal(t, "", Scheme("tick"))
+	assert.Equal(t, "", Scheme("{{uri}}:tick"))
+	assert.Equal(t, "", Scheme(":tick"))
+}